@@ -2,28 +2,84 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/f00b455/blank-go/docs"
+	"github.com/f00b455/blank-go/internal/apikey"
+	"github.com/f00b455/blank-go/internal/auth"
 	"github.com/f00b455/blank-go/internal/config"
 	"github.com/f00b455/blank-go/internal/database"
+	"github.com/f00b455/blank-go/internal/execution"
 	"github.com/f00b455/blank-go/internal/handlers"
+	"github.com/f00b455/blank-go/internal/logger"
+	"github.com/f00b455/blank-go/internal/middleware"
+	"github.com/f00b455/blank-go/internal/migrations"
+	"github.com/f00b455/blank-go/internal/portfolio"
+	"github.com/f00b455/blank-go/internal/version"
+	"github.com/f00b455/blank-go/pkg/alerts"
 	"github.com/f00b455/blank-go/pkg/dax"
+	"github.com/f00b455/blank-go/pkg/finance"
+	"github.com/f00b455/blank-go/pkg/health"
+	"github.com/f00b455/blank-go/pkg/operations"
 	"github.com/f00b455/blank-go/pkg/stocks"
+	"github.com/f00b455/blank-go/pkg/stocks/cache"
+	"github.com/f00b455/blank-go/pkg/stocks/stream"
 	"github.com/f00b455/blank-go/pkg/task"
+	taskpostgres "github.com/f00b455/blank-go/pkg/task/postgres"
+	tasksqlite "github.com/f00b455/blank-go/pkg/task/sqlite"
 	"github.com/f00b455/blank-go/pkg/weather"
+	weatherpostgres "github.com/f00b455/blank-go/pkg/weather/postgres"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
+	swaggerfiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
+const (
+	// healthCheckTimeout bounds how long any single health.Checker gets to
+	// respond before /readyz and /health/detailed report it StatusDown.
+	healthCheckTimeout = 2 * time.Second
+	// healthCheckCacheTTL is how long a health.Checker's last Result is
+	// reused before it's re-run.
+	healthCheckCacheTTL = 5 * time.Second
+)
+
+// @title Blank Go API
+// @version 1.0
+// @description HTTP surface for the DAX financial-data and task-management services.
+// @BasePath /api/v1
+
+//go:generate go run github.com/swaggo/swag/cmd/swag init -g cmd/api/main.go -d ../.. -o ../../docs --parseDependency --parseInternal
 func main() {
+	showVersion := flag.Bool("version", false, "print version information and exit")
+	flag.Parse()
+
+	if *showVersion {
+		fmt.Println(version.Get().String())
+		return
+	}
+
 	startTime := time.Now()
 	cfg := config.Load()
 
+	if err := logger.Setup(&cfg.Log); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+
 	if cfg.Environment == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
@@ -31,28 +87,49 @@ func main() {
 	// Connect to PostgreSQL
 	db, err := database.Connect(&cfg.Database)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		logger.L().Sugar().Fatalf("Failed to connect to database: %v", err)
 	}
 
-	// Auto-migrate DAX schema
-	if err := dax.AutoMigrate(db); err != nil {
-		log.Fatalf("Failed to migrate database: %v", err)
+	// Bring the DAX schema up to date. Migrations.AutoApply opts into the
+	// versioned internal/migrations package; otherwise fall back to the
+	// legacy GORM AutoMigrate bring-up.
+	if cfg.Migrations.AutoApply {
+		if err := migrations.Up(context.Background(), cfg.Database.MigrateDSN()); err != nil {
+			logger.L().Sugar().Fatalf("Failed to migrate database: %v", err)
+		}
+	} else {
+		if err := dax.AutoMigrate(db); err != nil {
+			logger.L().Sugar().Fatalf("Failed to migrate database: %v", err)
+		}
+		if err := execution.AutoMigrate(db); err != nil {
+			logger.L().Sugar().Fatalf("Failed to migrate database: %v", err)
+		}
+		if cfg.Weather.CacheEnabled {
+			if err := weatherpostgres.AutoMigrate(db); err != nil {
+				logger.L().Sugar().Fatalf("Failed to migrate database: %v", err)
+			}
+		}
+		if cfg.Auth.APIKeysEnabled {
+			if err := apikey.AutoMigrate(db); err != nil {
+				logger.L().Sugar().Fatalf("Failed to migrate database: %v", err)
+			}
+		}
 	}
 
-	router := setupRouter(cfg, db, startTime)
+	router, importJobManager, stocksService, portfolioService, executionManager, taskExecutionManager, taskScheduler, weatherCacheManager := setupRouter(cfg, db, startTime)
 
 	srv := &http.Server{
-		Addr:         ":" + cfg.Port,
+		Addr:         ":" + cfg.Server.Port,
 		Handler:      router,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+		ReadTimeout:  time.Duration(cfg.Server.ReadTimeoutSeconds) * time.Second,
+		WriteTimeout: time.Duration(cfg.Server.WriteTimeoutSeconds) * time.Second,
+		IdleTimeout:  time.Duration(cfg.Server.IdleTimeoutSeconds) * time.Second,
 	}
 
 	go func() {
-		log.Printf("Starting API server on port %s", cfg.Port)
+		logger.L().Sugar().Infof("Starting API server on port %s", cfg.Server.Port)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Failed to start server: %v", err)
+			logger.L().Sugar().Fatalf("Failed to start server: %v", err)
 		}
 	}()
 
@@ -60,76 +137,629 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("Shutting down server...")
+	logger.L().Sugar().Info("Shutting down server...")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		logger.L().Sugar().Fatalf("Server forced to shutdown: %v", err)
+	}
+
+	if importJobManager != nil {
+		if err := importJobManager.Shutdown(ctx); err != nil {
+			logger.L().Sugar().Infof("Import job manager did not drain cleanly: %v", err)
+		}
+	}
+
+	if executionManager != nil {
+		if err := executionManager.Shutdown(ctx); err != nil {
+			logger.L().Sugar().Infof("Execution manager did not drain cleanly: %v", err)
+		}
+	}
+
+	taskScheduler.Stop()
+	if err := taskExecutionManager.Shutdown(ctx); err != nil {
+		logger.L().Sugar().Infof("Task execution manager did not drain cleanly: %v", err)
+	}
+
+	portfolioService.Stop()
+	stocksService.Stop()
+
+	if weatherCacheManager != nil {
+		weatherCacheManager.Stop()
 	}
 
-	log.Println("Server exited")
+	logger.L().Sugar().Info("Server exited")
 }
 
-func setupRouter(cfg *config.Config, db interface{}, startTime time.Time) *gin.Engine {
+func setupRouter(cfg *config.Config, db interface{}, startTime time.Time) (*gin.Engine, *dax.ImportJobManager, *stocks.Service, *portfolio.Service, *execution.Manager, *task.ExecutionManager, *task.Scheduler, *weatherpostgres.Manager) {
 	router := gin.New()
-	router.Use(gin.Logger())
+	router.Use(middleware.Logging())
 	router.Use(gin.Recovery())
+	router.Use(middleware.Metrics())
+	router.Use(middleware.ServerHeader())
 
 	router.GET("/health", handlers.HealthCheck)
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	// healthRegistry backs /readyz and /health/detailed. Each checker gets
+	// healthCheckTimeout to respond and its result is reused for
+	// healthCheckCacheTTL, so a burst of readiness polling doesn't hammer
+	// Postgres or the stocks upstream.
+	healthRegistry := health.NewRegistry(healthCheckTimeout, healthCheckCacheTTL)
+	healthRegistry.Register(health.NewGoroutineChecker(0))
+	healthRegistry.Register(health.NewDiskChecker("", 0))
+	router.GET("/healthz", handlers.LivenessCheck)
+	router.GET("/readyz", handlers.ReadinessCheck(healthRegistry))
 
 	// Initialize task service and handler
-	taskRepo := task.NewInMemoryRepository()
-	taskService := task.NewService(taskRepo)
-	taskHandler := handlers.NewTaskHandler(taskService)
+	taskRepo, err := buildTaskRepository(context.Background(), cfg)
+	if err != nil {
+		logger.L().Sugar().Fatalf("Failed to initialize task repository: %v", err)
+	}
+	taskService := task.NewServiceWithEventRingSize(taskRepo, cfg.Task.EventRingBufferSize)
+	taskHandler := handlers.NewTaskHandlerWithCursorKey(taskService, cfg.Pagination.CursorSigningKey)
+
+	taskWebhookRepo, err := buildWebhookRepository(context.Background(), cfg)
+	if err != nil {
+		logger.L().Sugar().Fatalf("Failed to initialize webhook repository: %v", err)
+	}
+	taskHandler.SetWebhookRepository(taskWebhookRepo)
+
+	taskWebhookDispatcher := task.NewWebhookDispatcher(taskWebhookRepo)
+	taskWebhookDispatcher.Start(taskService)
+	defer taskWebhookDispatcher.Stop()
+
+	// Task executions: a Scheduler keeps per-task cron entries in sync
+	// with each Task's Schedule field, enqueuing runs through the same
+	// ExecutionManager POST /tasks/:id/executions triggers manually.
+	taskExecRepo := task.NewInMemoryExecutionRepository()
+	taskExecutionManager := task.NewExecutionManagerWithRetry(
+		taskRepo,
+		taskExecRepo,
+		task.NoopExecutor{},
+		cfg.Task.ExecutionMaxAttempts,
+		time.Duration(cfg.Task.ExecutionRetryBaseDelaySeconds)*time.Second,
+	)
+	taskExecutionHandler := handlers.NewTaskExecutionHandler(taskExecutionManager)
+
+	taskScheduler, err := task.NewScheduler(taskRepo, taskExecutionManager, cfg.Task.ScheduleSyncSpec)
+	if err != nil {
+		logger.L().Sugar().Fatalf("Failed to initialize task scheduler: %v", err)
+	}
+	taskScheduler.Start()
+
+	var importJobManager *dax.ImportJobManager
+	var portfolioService *portfolio.Service
+	var stocksServiceRef *stocks.Service
+	var executionManager *execution.Manager
+	var weatherCacheManager *weatherpostgres.Manager
+	// daxRepoRef is set inside the `db != nil` block below, and read later
+	// by the alerts subsystem to resolve DAX-metric rules. It stays nil
+	// (and alerts.Evaluator simply errors on any rule that references a
+	// metric) when there's no database configured.
+	var daxRepoRef dax.Repository
 
 	api := router.Group("/api/v1")
 	{
 		api.GET("/ping", handlers.Ping)
-		api.GET("/health/detailed", handlers.DetailedHealthCheck(startTime))
+		api.GET("/version", handlers.GetVersion)
+
+		docs.SwaggerInfo.BasePath = "/api/v1"
+		api.GET("/docs/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 
 		// Task routes
-		api.POST("/tasks", taskHandler.CreateTask)
-		api.GET("/tasks", taskHandler.ListTasks)
-		api.GET("/tasks/:id", taskHandler.GetTask)
-		api.PUT("/tasks/:id", taskHandler.UpdateTask)
-		api.DELETE("/tasks/:id", taskHandler.DeleteTask)
+		taskTimeout := time.Duration(cfg.Task.RequestTimeoutSeconds) * time.Second
+		taskGroup := api.Group("/tasks", middleware.RequestTimeout(taskTimeout))
+
+		// authProviders is empty (and authMiddleware a no-op pass-through)
+		// unless cfg.Auth.Providers is configured, so the mutating task
+		// routes stay open by default the way they were before auth
+		// existed.
+		authProviders, err := buildAuthProviders(context.Background(), cfg)
+		if err != nil {
+			logger.L().Sugar().Fatalf("Failed to initialize auth providers: %v", err)
+		}
+		authMiddleware := func(c *gin.Context) { c.Next() }
+		if len(authProviders) > 0 {
+			authMiddleware = auth.Middleware(authProviders...)
+		}
+
+		{
+			taskGroup.POST("", authMiddleware, taskHandler.CreateTask)
+			taskGroup.POST("/bulk", taskHandler.BulkTasks)
+			taskGroup.GET("", taskHandler.ListTasks)
+			taskGroup.GET("/facets", taskHandler.GetTaskFacets)
+			taskGroup.GET("/:id", taskHandler.GetTask)
+			taskGroup.PUT("/:id", authMiddleware, taskHandler.UpdateTask)
+			taskGroup.DELETE("/:id", authMiddleware, taskHandler.DeleteTask)
+			taskGroup.PATCH("/:id/status", authMiddleware, taskHandler.UpdateTaskStatus)
+
+			taskGroup.POST("/:id/executions", taskExecutionHandler.TriggerExecution)
+			taskGroup.GET("/:id/executions", taskExecutionHandler.ListExecutions)
+
+			// Nested under /tasks/executions rather than the top-level
+			// /executions used by the generic execution.Manager below, so
+			// the two :eid/:id wildcards registered on the same router
+			// don't collide.
+			taskGroup.GET("/executions/:eid", taskExecutionHandler.GetExecution)
+			taskGroup.POST("/executions/:eid/stop", taskExecutionHandler.StopExecution)
+		}
+
+		// /tasks/events is registered outside taskGroup so it isn't cut off
+		// by middleware.RequestTimeout: it's a long-lived SSE stream, not a
+		// single bounded request.
+		api.GET("/tasks/events", taskHandler.Events)
+		api.POST("/webhooks", taskHandler.RegisterWebhook)
 
 		// DAX routes (only if database is available)
 		if db != nil {
-			daxRepo := dax.NewPostgresRepository(db.(*gorm.DB))
-			daxService := dax.NewService(daxRepo)
-			daxHandler := handlers.NewDAXHandler(daxService)
+			healthRegistry.RegisterCritical(health.NewDBChecker(db.(*gorm.DB)))
+
+			rawDAXRepo, err := dax.NewPostgresRepository(db.(*gorm.DB))
+			if err != nil {
+				logger.L().Sugar().Fatalf("Failed to initialize DAX repository: %v", err)
+			}
+			daxRepo := instrumentedDAXRepository(cfg, rawDAXRepo)
+			daxRepoRef = daxRepo
+			aliasRepo := dax.NewPostgresAliasRepository(db.(*gorm.DB))
+
+			var aliasResolver dax.AliasResolver = dax.NoopAliasResolver{}
+			if cfg.Dax.AliasResolutionEnabled {
+				aliasResolver = dax.NewRepositoryAliasResolver(aliasRepo)
+			}
+
+			daxService := dax.NewServiceWithAliases(daxRepo, aliasResolver)
+			operationsRegistry := operations.NewRegistry()
+			daxHandler := handlers.NewDAXHandlerWithOperations(daxService, cfg.Pagination.CursorSigningKey, operationsRegistry)
+			operationsHandler := handlers.NewOperationsHandler(operationsRegistry)
+			aliasHandler := handlers.NewAliasHandler(aliasRepo)
+
+			jobStore := dax.NewInMemoryJobStore()
+			idempotencyTTL := time.Duration(cfg.Dax.ImportIdempotencyTTLSeconds) * time.Second
+			importJobManager = dax.NewImportJobManager(daxService, jobStore, idempotencyTTL)
+			importJobHandler := handlers.NewImportJobHandler(importJobManager)
 
 			daxGroup := api.Group("/dax")
 			{
-				daxGroup.POST("/import", daxHandler.ImportCSV)
+				daxGroup.POST("/import", daxHandler.Import)
 				daxGroup.GET("", daxHandler.GetByFilters)
+				daxGroup.GET("/export", daxHandler.Export)
 				daxGroup.GET("/metrics", daxHandler.GetMetrics)
+				daxGroup.GET("/stats", daxHandler.GetStats)
+				daxGroup.GET("/series", daxHandler.GetSeries)
+				daxGroup.GET("/correlate", daxHandler.GetCorrelation)
+				daxGroup.GET("/analytics", daxHandler.GetAnalytics)
+				daxGroup.POST("/aliases", aliasHandler.CreateAlias)
+				daxGroup.GET("/aliases", aliasHandler.ListAliases)
+				daxGroup.DELETE("/aliases/:id", aliasHandler.DeleteAlias)
+				daxGroup.POST("/imports", importJobHandler.Submit)
+				daxGroup.GET("/imports", importJobHandler.List)
+				daxGroup.GET("/imports/:id", importJobHandler.Get)
+				daxGroup.POST("/imports/:id/stop", importJobHandler.Stop)
+			}
+
+			api.GET("/operations/:id", operationsHandler.Get)
+			api.DELETE("/operations/:id", operationsHandler.Cancel)
+
+			executionStore, err := execution.NewGormStore(db.(*gorm.DB))
+			if err != nil {
+				logger.L().Sugar().Fatalf("Failed to initialize execution store: %v", err)
+			}
+			executionManager = execution.NewManagerWithQueueCap(executionStore, cfg.Executions.WorkerPoolSize, cfg.Executions.QueueSize)
+			executionManager.Register(execution.KindDAXBulkUpsert, execution.NewBulkUpsertWorker(daxRepo))
+			executionHandler := handlers.NewExecutionHandler(executionManager)
+
+			executionGroup := api.Group("/executions")
+			{
+				executionGroup.POST("", executionHandler.Submit)
+				executionGroup.GET("", executionHandler.List)
+				executionGroup.GET("/:id", executionHandler.Get)
+				executionGroup.POST("/:id/stop", executionHandler.Stop)
 			}
 		}
 
 		// Weather routes
-		weatherClient := weather.NewClient()
-		weatherService := weather.NewService(weatherClient)
-		weatherHandler := handlers.NewWeatherHandler(weatherService)
+		weatherProviders := buildWeatherProviders(cfg)
+		weatherPolicy := weather.FailoverPolicy{
+			Strategy: weather.ProviderStrategy(cfg.Weather.Strategy),
+			Vote:     cfg.Weather.VoteOnDisagreement,
+		}
+		weatherService := weather.NewService(weatherProviders, weatherPolicy, weather.UnitSystem(cfg.Weather.DefaultUnits))
+
+		if cfg.Weather.CacheEnabled {
+			if gormDB, ok := db.(*gorm.DB); ok {
+				store, err := weatherpostgres.NewStore(gormDB)
+				if err != nil {
+					logger.L().Sugar().Infof("failed to start weather cache, continuing without it: %v", err)
+				} else {
+					ttls := weather.DefaultCacheTTLs()
+					if cfg.Weather.CacheCurrentTTLSeconds > 0 {
+						ttls.Current = time.Duration(cfg.Weather.CacheCurrentTTLSeconds) * time.Second
+					}
+					if cfg.Weather.CacheForecastTTLSeconds > 0 {
+						ttls.Forecast = time.Duration(cfg.Weather.CacheForecastTTLSeconds) * time.Second
+					}
+					if cfg.Weather.CacheGeocodeTTLSeconds > 0 {
+						ttls.Geocode = time.Duration(cfg.Weather.CacheGeocodeTTLSeconds) * time.Second
+					}
+					weatherService.SetCache(store, ttls)
+
+					weatherCacheManager = weatherpostgres.NewManager(gormDB, time.Duration(cfg.Weather.CacheSweepIntervalSeconds)*time.Second)
+					weatherCacheManager.Start()
+				}
+			}
+		}
+
+		var weatherPrefetcher *weather.Prefetcher
+		if cfg.Weather.PrefetchEnabled {
+			var err error
+			weatherPrefetcher, err = weather.NewPrefetcher(weatherService, cfg.Weather.PrefetchTopN, cfg.Weather.PrefetchSchedule)
+			if err != nil {
+				logger.L().Sugar().Infof("failed to start weather prefetcher, continuing without it: %v", err)
+				weatherPrefetcher = nil
+			} else {
+				weatherPrefetcher.Start()
+				defer weatherPrefetcher.Stop()
+			}
+		}
+
+		var weatherHandler *handlers.WeatherHandler
+		if weatherPrefetcher != nil {
+			weatherHandler = handlers.NewWeatherHandler(weatherService, weatherPrefetcher)
+		} else {
+			weatherHandler = handlers.NewWeatherHandler(weatherService, nil)
+		}
+
+		// apiKeyService/authHandler are nil, and weatherGroup carries no
+		// auth or rate-limit middleware, unless cfg.Auth.APIKeysEnabled -
+		// the routes stay open the way they were before API keys existed.
+		var apiKeyService *apikey.Store
+		var authHandler *handlers.AuthHandler
+		weatherGroup := api.Group("/weather")
+		if cfg.Auth.APIKeysEnabled {
+			if gormDB, ok := db.(*gorm.DB); ok {
+				var err error
+				apiKeyService, err = apikey.NewStore(gormDB, []byte(cfg.Auth.TokenSigningKey), time.Duration(cfg.Auth.TokenTTLSeconds)*time.Second)
+				if err != nil {
+					logger.L().Sugar().Fatalf("Failed to initialize API key store: %v", err)
+				}
+				authHandler = handlers.NewAuthHandler(apiKeyService)
+
+				var limiter middleware.Limiter
+				if cfg.Redis.Enabled {
+					limiter = apikey.NewRedisLimiter(redis.NewClient(&redis.Options{
+						Addr:     cfg.Redis.Addr,
+						Password: cfg.Redis.Password,
+						DB:       cfg.Redis.DB,
+					}))
+				} else {
+					limiter = apikey.NewMemoryLimiter()
+				}
+
+				weatherGroup.Use(middleware.APIKeyAuth(apiKeyService), middleware.RateLimit(limiter))
+				api.POST("/auth/token", authHandler.IssueToken)
+			} else {
+				logger.L().Sugar().Infof("auth.api_keys_enabled requires a postgres database, continuing without it")
+			}
+		}
+
+		weatherGroup.GET("", weatherHandler.GetCurrentWeather)
+		weatherGroup.GET("/forecast", weatherHandler.GetForecast)
+		weatherGroup.GET("/cities/:city", weatherHandler.GetWeatherByCity)
+		weatherGroup.GET("/batch", weatherHandler.GetBatchWeather)
+		weatherGroup.GET("/batch/coords", weatherHandler.GetBatchWeatherByCoords)
+		weatherGroup.GET("/_admin/hot", weatherHandler.GetHotQueries)
 
-		api.GET("/weather", weatherHandler.GetCurrentWeather)
-		api.GET("/weather/forecast", weatherHandler.GetForecast)
-		api.GET("/weather/cities/:city", weatherHandler.GetWeatherByCity)
+		stationHandler := handlers.NewStationHandler(weather.NewClient())
+		weatherGroup.GET("/stations/nearest", stationHandler.GetNearestStation)
+		weatherGroup.GET("/stations/:id/observation", stationHandler.GetObservation)
+		weatherGroup.GET("/stations/:id/history", stationHandler.GetHistoricalObservations)
 
 		// Stocks routes
-		stocksClient := stocks.NewClient()
-		stocksService := stocks.NewService(stocksClient)
-		stocksHandler := handlers.NewStocksHandler(stocksService)
+		stocksProviders := make([]stocks.ProviderName, 0, len(cfg.Stocks.Providers))
+		for _, name := range cfg.Stocks.Providers {
+			stocksProviders = append(stocksProviders, stocks.ProviderName(name))
+		}
+		stocksUpstream, err := stocks.NewClientFromConfig(stocks.Config{
+			Providers:       stocksProviders,
+			UpstreamURL:     cfg.Stocks.UpstreamURL,
+			Timeout:         time.Duration(cfg.Stocks.TimeoutSeconds) * time.Second,
+			AlpacaKeyID:     cfg.Stocks.AlpacaKeyID,
+			AlpacaSecretKey: cfg.Stocks.AlpacaSecretKey,
+			FinnhubAPIKey:   cfg.Stocks.FinnhubAPIKey,
+		})
+		if err != nil {
+			log.Fatalf("Failed to configure stocks providers: %v", err)
+		}
+		stocksClient := stocks.NewResilientClient(
+			stocksUpstream,
+			stocks.ResilientClientConfig{
+				RPS:              cfg.Stocks.RateLimitRPS,
+				Burst:            cfg.Stocks.RateLimitBurst,
+				FailureThreshold: cfg.Stocks.FailureThreshold,
+				RollingWindow:    time.Duration(cfg.Stocks.RollingWindowSeconds) * time.Second,
+				Cooldown:         time.Duration(cfg.Stocks.CooldownSeconds) * time.Second,
+			},
+		)
+		healthRegistry.RegisterCritical(health.NewStocksChecker(stocksClient, ""))
+		var stocksService *stocks.Service
+		if cfg.Redis.Enabled {
+			stocksService = stocks.NewServiceWithCache(stocksClient, cache.NewRedisCache(cache.RedisConfig{
+				Addr:     cfg.Redis.Addr,
+				Password: cfg.Redis.Password,
+				DB:       cfg.Redis.DB,
+			}))
+		} else {
+			stocksService = stocks.NewService(stocksClient)
+		}
+		stocksService.Start(context.Background())
+		stocksServiceRef = stocksService
+
+		if executionManager != nil {
+			executionManager.Register(execution.KindStocksBatchSummary, execution.NewBatchSummaryWorker(stocksService))
+		}
+		stocksHandler := handlers.NewStocksHandlerWithExecutions(stocksService, executionManager)
+
+		api.GET("/health/detailed", handlers.DetailedHealthCheck(startTime, stocksService, healthRegistry))
 
 		stocksGroup := api.Group("/stocks")
 		{
 			stocksGroup.GET("/:ticker/summary", stocksHandler.GetStockSummary)
+			stocksGroup.GET("/:ticker/bars", stocksHandler.GetBars)
+			stocksGroup.GET("/bars", stocksHandler.GetMultiBars)
 			stocksGroup.GET("/summary", stocksHandler.GetBatchSummary)
+
+			streamHub := stream.NewHub(stream.NewYahooUpstream())
+			streamHandler := handlers.NewStocksStreamHandler(streamHub)
+			stocksGroup.GET("/stream", streamHandler.HandleStream)
+			stocksService.SetStreamHub(streamHub)
+
+			watchHandler := handlers.NewStocksWatchHandler(stocksService)
+			stocksGroup.GET("/watch", watchHandler.HandleWatch)
+		}
+
+		// Admin routes
+		adminHandler := handlers.NewAdminHandler(stocksService)
+		adminGroup := api.Group("/admin")
+		{
+			adminGroup.GET("/stocks/cache", adminHandler.DumpStocksCache)
+			adminGroup.DELETE("/stocks/cache", adminHandler.InvalidateAllStocksCache)
+			adminGroup.DELETE("/stocks/cache/:ticker", adminHandler.InvalidateStocksCache)
+
+			if authHandler != nil {
+				adminGroup.POST("/auth/keys", authHandler.CreateAPIKey)
+			}
+		}
+
+		// Portfolio routes
+		var alertSink portfolio.AlertSink = portfolio.NoopSink{}
+		if cfg.Portfolio.AlertWebhookURL != "" {
+			alertSink = portfolio.NewWebhookSink(cfg.Portfolio.AlertWebhookURL)
+		}
+		portfolioService = portfolio.NewService(portfolio.NewInMemoryStore(), stocksService, alertSink)
+		portfolioService.Start(context.Background())
+		portfolioHandler := handlers.NewPortfolioHandler(portfolioService)
+
+		portfolioGroup := api.Group("/portfolio")
+		{
+			portfolioGroup.POST("", portfolioHandler.CreatePortfolio)
+			portfolioGroup.GET("", portfolioHandler.ListPortfolios)
+			portfolioGroup.GET("/alerts", portfolioHandler.ListAlerts)
+			portfolioGroup.GET("/:id", portfolioHandler.GetPortfolio)
+			portfolioGroup.DELETE("/:id", portfolioHandler.DeletePortfolio)
+		}
+
+		// Alerts routes
+		//
+		// FCMNotifier needs an oauth2.TokenSource, which in a real deployment
+		// comes from a service-account credentials file loaded via
+		// golang.org/x/oauth2/google - not wired up here, so a configured
+		// FCMProjectID only selects the notifier; actual push delivery is
+		// left to the caller supplying a TokenSource once that wiring exists.
+		// Until then, firings are evaluated and logged but not delivered.
+		var alertsNotifier alerts.Notifier = alerts.NoopNotifier{}
+		alertsRepo := alerts.NewInMemoryRepository()
+		alertsEvaluator := alerts.NewEvaluator(alertsRepo, stocksService, daxRepoRef, alertsNotifier)
+		alertsScheduler := alerts.NewScheduler(alertsEvaluator, time.Duration(cfg.Alerts.EvaluationIntervalSeconds)*time.Second)
+		go alertsScheduler.Run(context.Background())
+		alertsHandler := handlers.NewAlertsHandler(alertsRepo)
+
+		alertsGroup := api.Group("/alerts")
+		{
+			alertsGroup.POST("", alertsHandler.CreateRule)
+			alertsGroup.GET("", alertsHandler.ListRules)
+			alertsGroup.DELETE("/:id", alertsHandler.DeleteRule)
+		}
+
+		// Finance routes
+		//
+		// financeAggregator shares daxRepoRef with the alerts subsystem
+		// above; like that subsystem, a Query against it is only safe once
+		// a database is configured (daxRepoRef is non-nil).
+		financeAggregator := finance.NewAggregator(daxRepoRef, stocksService)
+		financeHandler := handlers.NewFinanceHandler(financeAggregator)
+
+		financeGroup := api.Group("/finance")
+		{
+			financeGroup.GET("/companies", financeHandler.GetCompanies)
 		}
 	}
 
-	return router
+	return router, importJobManager, stocksServiceRef, portfolioService, executionManager, taskExecutionManager, taskScheduler, weatherCacheManager
+}
+
+// weatherProviderRegistry builds a weather.ProviderRegistry with every
+// backend this binary knows how to construct registered, regardless of
+// which ones end up selected, so operators can change WEATHER_PROVIDER or
+// WEATHER_FALLBACK_PROVIDERS with just an env var change.
+func weatherProviderRegistry(cfg *config.Config) *weather.ProviderRegistry {
+	registry := weather.NewProviderRegistry()
+	registry.Register(weather.ProviderOpenMeteo, weather.NewClient())
+	registry.Register(weather.ProviderNWS, weather.NewNWSProvider(cfg.Weather.NWSUserAgent))
+	if cfg.Weather.OpenWeatherMapAPIKey != "" {
+		registry.Register(weather.ProviderOpenWeatherMap, weather.NewOpenWeatherMapProvider(cfg.Weather.OpenWeatherMapAPIKey))
+	}
+	if cfg.Weather.WorldWeatherOnlineAPIKey != "" {
+		registry.Register(weather.ProviderWorldWeatherOnline, weather.NewWorldWeatherOnlineProvider(cfg.Weather.WorldWeatherOnlineAPIKey))
+	}
+	if cfg.Weather.MeteologixAPIKey != "" {
+		registry.Register(weather.ProviderMeteologix, weather.NewMeteologixProvider(cfg.Weather.MeteologixAPIKey))
+	}
+	return registry
+}
+
+// buildWeatherProviders resolves cfg.Weather.Provider plus its comma-separated
+// cfg.Weather.FallbackProviders into a priority-ordered provider list for
+// weather.NewService. An unrecognized or unregistered name is logged and
+// skipped; if the primary provider itself can't be resolved, it falls back
+// to Open-Meteo, which needs no credentials.
+func buildWeatherProviders(cfg *config.Config) []weather.Provider {
+	registry := weatherProviderRegistry(cfg)
+
+	names := []string{cfg.Weather.Provider}
+	for _, name := range strings.Split(cfg.Weather.FallbackProviders, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	var providers []weather.Provider
+	for _, name := range names {
+		provider, err := registry.Get(weather.ProviderName(name))
+		if err != nil {
+			logger.L().Sugar().Infof("unresolved weather provider %q, skipping: %v", name, err)
+			continue
+		}
+		providers = append(providers, provider)
+	}
+
+	if len(providers) == 0 {
+		logger.L().Sugar().Infof("no weather providers resolved from %v, falling back to open-meteo", names)
+		provider, _ := registry.Get(weather.ProviderOpenMeteo)
+		providers = append(providers, provider)
+	}
+	return providers
+}
+
+// buildAuthProviders resolves cfg.Auth.Providers into auth.Provider
+// instances for auth.Middleware, in the configured order. OIDC discovery
+// runs against cfg.Auth.OIDCIssuerURL at startup (bounded by ctx), so a
+// misconfigured issuer fails fast here instead of on the first protected
+// request.
+func buildAuthProviders(ctx context.Context, cfg *config.Config) ([]auth.Provider, error) {
+	var providers []auth.Provider
+	for _, name := range cfg.Auth.Providers {
+		switch name {
+		case "basic":
+			providers = append(providers, auth.NewBasicProvider(cfg.Auth.BasicUsername, cfg.Auth.BasicPasswordHash))
+		case "oidc":
+			provider, err := auth.NewOIDCProvider(ctx, cfg.Auth.OIDCIssuerURL, cfg.Auth.OIDCAudience)
+			if err != nil {
+				return nil, err
+			}
+			providers = append(providers, provider)
+		}
+	}
+	return providers, nil
+}
+
+// buildTaskRepository constructs the task.Repository cfg.Task.Driver
+// selects: "memory" (the default) keeps tasks in process, "sqlite" opens
+// and migrates a local database file, and "postgres" connects to and
+// migrates a PostgreSQL database. cfg.Task.Validate has already rejected
+// any other driver name by the time this runs.
+func buildTaskRepository(ctx context.Context, cfg *config.Config) (task.Repository, error) {
+	switch cfg.Task.Driver {
+	case "", "memory":
+		return task.NewInMemoryRepository(), nil
+	case "sqlite":
+		db, err := tasksqlite.Open(cfg.Task.SQLitePath)
+		if err != nil {
+			return nil, err
+		}
+		if err := tasksqlite.AutoMigrate(ctx, db); err != nil {
+			return nil, err
+		}
+		return tasksqlite.NewRepository(db), nil
+	case "postgres":
+		pool, err := pgxpool.New(ctx, cfg.Task.PostgresURL)
+		if err != nil {
+			return nil, err
+		}
+		if err := taskpostgres.AutoMigrate(ctx, pool); err != nil {
+			return nil, err
+		}
+		return taskpostgres.NewRepository(pool), nil
+	default:
+		return nil, fmt.Errorf("unknown task.driver %q", cfg.Task.Driver)
+	}
+}
+
+// buildWebhookRepository constructs the task.WebhookRepository matching
+// cfg.Task.Driver: "sqlite" persists registrations in the same database
+// file as the task repository, so they survive a restart, and every
+// other driver (including "postgres", not yet wired here) falls back to
+// an in-memory registry.
+func buildWebhookRepository(ctx context.Context, cfg *config.Config) (task.WebhookRepository, error) {
+	if cfg.Task.Driver != "sqlite" {
+		return task.NewInMemoryWebhookRepository(), nil
+	}
+
+	db, err := tasksqlite.Open(cfg.Task.SQLitePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := tasksqlite.AutoMigrate(ctx, db); err != nil {
+		return nil, err
+	}
+	return tasksqlite.NewWebhookRepository(db), nil
+}
+
+// instrumentedDAXRepository wraps repo in the Repository middleware
+// decorators cfg.Observability enables, applying them logging -> metrics ->
+// tracing (ChainMiddleware's doc comment walks through the same ordering).
+func instrumentedDAXRepository(cfg *config.Config, repo dax.Repository) dax.Repository {
+	var mws []func(dax.Repository) dax.Repository
+
+	// Applied first (innermost), so a timed-out call still gets logged,
+	// measured, and traced by the decorators layered on top of it below.
+	if cfg.Database.QueryTimeout > 0 {
+		mws = append(mws, func(r dax.Repository) dax.Repository {
+			return dax.TimeoutRepository(r, cfg.Database.QueryTimeout)
+		})
+	}
+
+	if cfg.Observability.DAXRepositoryLoggingEnabled {
+		daxLogger, err := newDAXRepositoryLogger(cfg.Environment)
+		if err != nil {
+			logger.L().Sugar().Infof("failed to initialize DAX repository logger, logging disabled: %v", err)
+		} else {
+			mws = append(mws, func(r dax.Repository) dax.Repository { return dax.LoggingRepository(r, daxLogger) })
+		}
+	}
+
+	if cfg.Observability.DAXRepositoryMetricsEnabled {
+		mws = append(mws, func(r dax.Repository) dax.Repository {
+			return dax.MetricsRepository(r, prometheus.DefaultRegisterer)
+		})
+	}
+
+	if cfg.Observability.DAXRepositoryTracingEnabled {
+		tracer := otel.Tracer("github.com/f00b455/blank-go/pkg/dax")
+		mws = append(mws, func(r dax.Repository) dax.Repository { return dax.TracingRepository(r, tracer) })
+	}
+
+	return dax.ChainMiddleware(repo, mws...)
+}
+
+// newDAXRepositoryLogger builds the *zap.Logger instrumentedDAXRepository
+// passes to dax.LoggingRepository, matching gin.SetMode's own
+// production-vs-development split at the top of main.
+func newDAXRepositoryLogger(environment string) (*zap.Logger, error) {
+	if environment == "production" {
+		return zap.NewProduction()
+	}
+	return zap.NewDevelopment()
 }
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/f00b455/blank-go/internal/config"
+	"github.com/f00b455/blank-go/internal/migrations"
+	"github.com/spf13/cobra"
+)
+
+var migrateDownSteps int
+var migrateForceVersion int
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage the DAX Postgres schema",
+	Long:  `Applies, rolls back, and inspects the internal/migrations schema against DB_* configured in the environment.`,
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply every pending migration",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.Load()
+		if err := migrations.Up(context.Background(), cfg.Database.MigrateDSN()); err != nil {
+			return fmt.Errorf("migrate up: %w", err)
+		}
+		cmd.Println("migrations applied")
+		return nil
+	},
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Roll back applied migrations",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.Load()
+		if err := migrations.Down(context.Background(), cfg.Database.MigrateDSN(), migrateDownSteps); err != nil {
+			return fmt.Errorf("migrate down: %w", err)
+		}
+		cmd.Println("migrations rolled back")
+		return nil
+	},
+}
+
+var migrateVersionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the current schema migration version",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.Load()
+		v, ok, err := migrations.Version(context.Background(), cfg.Database.MigrateDSN())
+		if err != nil {
+			return fmt.Errorf("migrate version: %w", err)
+		}
+		if !ok {
+			cmd.Println("no migrations applied")
+			return nil
+		}
+		cmd.Println(v)
+		return nil
+	},
+}
+
+var migrateForceCmd = &cobra.Command{
+	Use:   "force",
+	Short: "Force the recorded schema version without running a migration",
+	Long:  `Repairs a dirty version left by a migration that failed partway through, once the schema has been checked/fixed by hand.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.Load()
+		if err := migrations.Force(context.Background(), cfg.Database.MigrateDSN(), migrateForceVersion); err != nil {
+			return fmt.Errorf("migrate force: %w", err)
+		}
+		cmd.Println("migration version forced")
+		return nil
+	},
+}
+
+func init() {
+	migrateDownCmd.Flags().IntVar(&migrateDownSteps, "steps", 0, "number of migrations to roll back (0 rolls back all)")
+	migrateForceCmd.Flags().IntVar(&migrateForceVersion, "version", 0, "migration version to force")
+
+	migrateCmd.AddCommand(migrateUpCmd, migrateDownCmd, migrateVersionCmd, migrateForceCmd)
+	rootCmd.AddCommand(migrateCmd)
+}
@@ -0,0 +1,171 @@
+// MCP Server for Task Management
+// Exposes the task API's list and create operations via Model Context
+// Protocol, talking to a running instance of cmd/api over HTTP the same
+// way cmd/mcp's weather tool talks to Open-Meteo.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/f00b455/blank-go/internal/version"
+	"github.com/f00b455/blank-go/pkg/mcp"
+	"github.com/f00b455/blank-go/pkg/task"
+)
+
+// taskAPIBaseURL is the task API's base URL, overridable so this server
+// can point at a non-default deployment.
+func taskAPIBaseURL() string {
+	if v := os.Getenv("TASK_API_BASE_URL"); v != "" {
+		return v
+	}
+	return "http://localhost:8080/api/v1"
+}
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+func listTasks(status, priority string) (string, error) {
+	url := taskAPIBaseURL() + "/tasks"
+	if status != "" || priority != "" {
+		q := make([]string, 0, 2)
+		if status != "" {
+			q = append(q, "status="+status)
+		}
+		if priority != "" {
+			q = append(q, "priority="+priority)
+		}
+		url += "?"
+		for i, p := range q {
+			if i > 0 {
+				url += "&"
+			}
+			url += p
+		}
+	}
+
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("list tasks request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read list tasks response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("list tasks failed: %s: %s", resp.Status, body)
+	}
+
+	var tasks []task.Task
+	if err := json.Unmarshal(body, &tasks); err != nil {
+		return "", fmt.Errorf("failed to parse list tasks response: %w", err)
+	}
+
+	if len(tasks) == 0 {
+		return "No tasks found.", nil
+	}
+
+	result := fmt.Sprintf("%d task(s):\n", len(tasks))
+	for _, t := range tasks {
+		result += fmt.Sprintf("- [%s] %s (status: %s, priority: %s)\n", t.ID, t.Title, t.Status, t.Priority)
+	}
+	return result, nil
+}
+
+func createTask(title, description, priority string) (string, error) {
+	req := task.CreateTaskRequest{Title: title, Description: description, Priority: priority}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode create task request: %w", err)
+	}
+
+	resp, err := httpClient.Post(taskAPIBaseURL()+"/tasks", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("create task request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read create task response: %w", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("create task failed: %s: %s", resp.Status, respBody)
+	}
+
+	var created task.Task
+	if err := json.Unmarshal(respBody, &created); err != nil {
+		return "", fmt.Errorf("failed to parse create task response: %w", err)
+	}
+
+	return fmt.Sprintf("Created task [%s] %s", created.ID, created.Title), nil
+}
+
+func handleListTasks(arguments map[string]any) (mcp.CallToolResult, error) {
+	status, _ := arguments["status"].(string)
+	priority, _ := arguments["priority"].(string)
+
+	result, err := listTasks(status, priority)
+	if err != nil {
+		return mcp.Errorf("Error: %v", err), nil
+	}
+	return mcp.Text(result), nil
+}
+
+func handleCreateTask(arguments map[string]any) (mcp.CallToolResult, error) {
+	title, ok := arguments["title"].(string)
+	if !ok || title == "" {
+		return mcp.Errorf("Error: title parameter is required"), nil
+	}
+	description, _ := arguments["description"].(string)
+	priority, _ := arguments["priority"].(string)
+
+	result, err := createTask(title, description, priority)
+	if err != nil {
+		return mcp.Errorf("Error: %v", err), nil
+	}
+	return mcp.Text(result), nil
+}
+
+func main() {
+	fmt.Fprintf(os.Stderr, "Task MCP Server started at %s\n", time.Now().Format(time.RFC3339))
+
+	server := mcp.NewServer("task-mcp", version.Version)
+
+	server.AddTool(mcp.Tool{
+		Name:        "list_tasks",
+		Description: "List tasks, optionally filtered by status and/or priority.",
+		InputSchema: mcp.InputSchema{
+			Type: "object",
+			Properties: map[string]mcp.Property{
+				"status":   {Type: "string", Description: "Filter by status: pending, in_progress, or completed"},
+				"priority": {Type: "string", Description: "Filter by priority: low, medium, or high"},
+			},
+		},
+	}, handleListTasks)
+
+	server.AddTool(mcp.Tool{
+		Name:        "create_task",
+		Description: "Create a new task.",
+		InputSchema: mcp.InputSchema{
+			Type: "object",
+			Properties: map[string]mcp.Property{
+				"title":       {Type: "string", Description: "Task title"},
+				"description": {Type: "string", Description: "Task description"},
+				"priority":    {Type: "string", Description: "Priority: low, medium, or high"},
+			},
+			Required: []string{"title"},
+		},
+	}, handleCreateTask)
+
+	if err := server.Run(os.Stdin, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "Server error: %v\n", err)
+		os.Exit(1)
+	}
+}
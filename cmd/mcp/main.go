@@ -3,85 +3,18 @@
 package main
 
 import (
-	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
 	"time"
 
 	"github.com/f00b455/blank-go/internal/version"
+	"github.com/f00b455/blank-go/pkg/mcp"
+	"github.com/f00b455/blank-go/pkg/weather"
 )
 
-// JSON-RPC structures
-type JSONRPCRequest struct {
-	JSONRPC string          `json:"jsonrpc"`
-	ID      any             `json:"id"`
-	Method  string          `json:"method"`
-	Params  json.RawMessage `json:"params,omitempty"`
-}
-
-type JSONRPCResponse struct {
-	JSONRPC string      `json:"jsonrpc"`
-	ID      any         `json:"id"`
-	Result  interface{} `json:"result,omitempty"`
-	Error   *RPCError   `json:"error,omitempty"`
-}
-
-type RPCError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-}
-
-// MCP Protocol structures
-type ServerInfo struct {
-	Name    string `json:"name"`
-	Version string `json:"version"`
-}
-
-type InitializeResult struct {
-	ProtocolVersion string            `json:"protocolVersion"`
-	ServerInfo      ServerInfo        `json:"serverInfo"`
-	Capabilities    map[string]any    `json:"capabilities"`
-}
-
-type Tool struct {
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	InputSchema InputSchema `json:"inputSchema"`
-}
-
-type InputSchema struct {
-	Type       string              `json:"type"`
-	Properties map[string]Property `json:"properties,omitempty"`
-	Required   []string            `json:"required,omitempty"`
-}
-
-type Property struct {
-	Type        string `json:"type"`
-	Description string `json:"description"`
-}
-
-type ToolsListResult struct {
-	Tools []Tool `json:"tools"`
-}
-
-type CallToolParams struct {
-	Name      string         `json:"name"`
-	Arguments map[string]any `json:"arguments,omitempty"`
-}
-
-type TextContent struct {
-	Type string `json:"type"`
-	Text string `json:"text"`
-}
-
-type CallToolResult struct {
-	Content []TextContent `json:"content"`
-	IsError bool          `json:"isError,omitempty"`
-}
-
 // Weather data structures
 type OpenMeteoResponse struct {
 	Latitude  float64 `json:"latitude"`
@@ -135,7 +68,22 @@ func getWeatherDescription(code int) string {
 	return "Unknown"
 }
 
-func fetchWeather(city string) (string, error) {
+// temperatureAndWindUnits maps a weather.UnitSystem string to the
+// temperature_unit/wind_speed_unit query params Open-Meteo's forecast API
+// accepts, so fetchWeather can ask it to do the conversion rather than
+// pulling in pkg/weather's own UnitConverter for this hand-rolled client.
+func temperatureAndWindUnits(units string) (temperatureUnit, windSpeedUnit string) {
+	switch weather.UnitSystem(units) {
+	case weather.UnitsImperial:
+		return "fahrenheit", "mph"
+	case weather.UnitsStandard:
+		return "celsius", "ms"
+	default:
+		return "celsius", "kmh"
+	}
+}
+
+func fetchWeather(city, units string) (string, error) {
 	// First, geocode the city
 	geoURL := fmt.Sprintf("https://geocoding-api.open-meteo.com/v1/search?name=%s&count=1", city)
 
@@ -158,10 +106,12 @@ func fetchWeather(city string) (string, error) {
 
 	location := geoData.Results[0]
 
+	temperatureUnit, windSpeedUnit := temperatureAndWindUnits(units)
+
 	// Fetch weather data
 	weatherURL := fmt.Sprintf(
-		"https://api.open-meteo.com/v1/forecast?latitude=%.4f&longitude=%.4f&current=temperature_2m,relative_humidity_2m,weather_code,wind_speed_10m&timezone=auto",
-		location.Latitude, location.Longitude,
+		"https://api.open-meteo.com/v1/forecast?latitude=%.4f&longitude=%.4f&current=temperature_2m,relative_humidity_2m,weather_code,wind_speed_10m&timezone=auto&temperature_unit=%s&wind_speed_unit=%s",
+		location.Latitude, location.Longitude, temperatureUnit, windSpeedUnit,
 	)
 
 	weatherResp, err := http.Get(weatherURL)
@@ -177,21 +127,34 @@ func fetchWeather(city string) (string, error) {
 		return "", fmt.Errorf("failed to parse weather response: %w", err)
 	}
 
+	temperature := weatherData.Current.Temperature2m
+	temperatureLabel := "°C"
+	windSpeedLabel := "km/h"
+	switch weather.UnitSystem(units) {
+	case weather.UnitsImperial:
+		temperatureLabel, windSpeedLabel = "°F", "mph"
+	case weather.UnitsStandard:
+		// Open-Meteo has no Kelvin temperature_unit, so celsius is
+		// requested above and converted here.
+		temperature += 273.15
+		temperatureLabel, windSpeedLabel = "K", "m/s"
+	}
+
 	// Format the result
 	result := fmt.Sprintf(`Weather for %s, %s
 ━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━
-🌡️  Temperature: %.1f°C
+🌡️  Temperature: %.1f%s
 💧 Humidity: %d%%
-💨 Wind Speed: %.1f km/h
+💨 Wind Speed: %.1f %s
 🌤️  Conditions: %s
 ⏰ Updated: %s
 ━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━
 📍 Coordinates: %.4f, %.4f
 🌐 Timezone: %s`,
 		location.Name, location.Country,
-		weatherData.Current.Temperature2m,
+		temperature, temperatureLabel,
 		weatherData.Current.RelativeHumidity,
-		weatherData.Current.WindSpeed10m,
+		weatherData.Current.WindSpeed10m, windSpeedLabel,
 		getWeatherDescription(weatherData.Current.WeatherCode),
 		weatherData.Current.Time,
 		location.Latitude, location.Longitude,
@@ -201,145 +164,113 @@ func fetchWeather(city string) (string, error) {
 	return result, nil
 }
 
-func handleRequest(req JSONRPCRequest) JSONRPCResponse {
-	switch req.Method {
-	case "initialize":
-		return JSONRPCResponse{
-			JSONRPC: "2.0",
-			ID:      req.ID,
-			Result: InitializeResult{
-				ProtocolVersion: "2024-11-05",
-				ServerInfo: ServerInfo{
-					Name:    "weather-mcp",
-					Version: version.Version,
-				},
-				Capabilities: map[string]any{
-					"tools": map[string]any{},
-				},
-			},
-		}
-
-	case "notifications/initialized":
-		// No response needed for notifications
-		return JSONRPCResponse{}
-
-	case "tools/list":
-		return JSONRPCResponse{
-			JSONRPC: "2.0",
-			ID:      req.ID,
-			Result: ToolsListResult{
-				Tools: []Tool{
-					{
-						Name:        "get_weather",
-						Description: "Get current weather for a city. Returns temperature, humidity, wind speed, and conditions.",
-						InputSchema: InputSchema{
-							Type: "object",
-							Properties: map[string]Property{
-								"city": {
-									Type:        "string",
-									Description: "City name (e.g., 'Berlin', 'Munich', 'Hamburg')",
-								},
-							},
-							Required: []string{"city"},
-						},
-					},
-				},
-			},
-		}
-
-	case "tools/call":
-		var params CallToolParams
-		if err := json.Unmarshal(req.Params, &params); err != nil {
-			return JSONRPCResponse{
-				JSONRPC: "2.0",
-				ID:      req.ID,
-				Error:   &RPCError{Code: -32602, Message: "Invalid params"},
-			}
-		}
-
-		if params.Name == "get_weather" {
-			city, ok := params.Arguments["city"].(string)
-			if !ok || city == "" {
-				return JSONRPCResponse{
-					JSONRPC: "2.0",
-					ID:      req.ID,
-					Result: CallToolResult{
-						Content: []TextContent{{Type: "text", Text: "Error: city parameter is required"}},
-						IsError: true,
-					},
-				}
-			}
-
-			weather, err := fetchWeather(city)
-			if err != nil {
-				return JSONRPCResponse{
-					JSONRPC: "2.0",
-					ID:      req.ID,
-					Result: CallToolResult{
-						Content: []TextContent{{Type: "text", Text: fmt.Sprintf("Error: %v", err)}},
-						IsError: true,
-					},
-				}
-			}
-
-			return JSONRPCResponse{
-				JSONRPC: "2.0",
-				ID:      req.ID,
-				Result: CallToolResult{
-					Content: []TextContent{{Type: "text", Text: weather}},
-				},
-			}
-		}
+// weatherClient serves the station/observation tools, which build on
+// pkg/weather.Client's Open-Meteo archive integration rather than the
+// hand-rolled HTTP calls fetchWeather makes above.
+var weatherClient = weather.NewClient()
 
-		return JSONRPCResponse{
-			JSONRPC: "2.0",
-			ID:      req.ID,
-			Error:   &RPCError{Code: -32601, Message: fmt.Sprintf("Unknown tool: %s", params.Name)},
-		}
+func handleFindNearestStation(arguments map[string]any) (mcp.CallToolResult, error) {
+	lat, ok := arguments["lat"].(float64)
+	if !ok {
+		return mcp.Errorf("Error: lat parameter is required"), nil
+	}
+	lon, ok := arguments["lon"].(float64)
+	if !ok {
+		return mcp.Errorf("Error: lon parameter is required"), nil
+	}
 
-	default:
-		return JSONRPCResponse{
-			JSONRPC: "2.0",
-			ID:      req.ID,
-			Error:   &RPCError{Code: -32601, Message: fmt.Sprintf("Method not found: %s", req.Method)},
-		}
+	station, err := weatherClient.GetNearestStation(lat, lon)
+	if err != nil {
+		return mcp.Errorf("Error: %v", err), nil
 	}
+
+	return mcp.Text(fmt.Sprintf("Nearest station: %s (id: %s, %.4f, %.4f)",
+		station.Name, station.ID, station.Latitude, station.Longitude)), nil
+}
+
+func handleGetStationObservation(arguments map[string]any) (mcp.CallToolResult, error) {
+	stationID, ok := arguments["station_id"].(string)
+	if !ok || stationID == "" {
+		return mcp.Errorf("Error: station_id parameter is required"), nil
+	}
+
+	observation, err := weatherClient.GetObservationByStationID(context.Background(), stationID)
+	if err != nil {
+		return mcp.Errorf("Error: %v", err), nil
+	}
+
+	return mcp.Text(fmt.Sprintf(
+		"Observation at %s: %.1f°C, humidity %d%%, pressure %.1f hPa, wind %.1f km/h",
+		observation.Time.Format(time.RFC3339),
+		observation.Temperature, observation.Humidity, observation.PressureMsl, observation.WindSpeed,
+	)), nil
+}
+
+func handleGetWeather(arguments map[string]any) (mcp.CallToolResult, error) {
+	city, ok := arguments["city"].(string)
+	if !ok || city == "" {
+		return mcp.Errorf("Error: city parameter is required"), nil
+	}
+	units, _ := arguments["units"].(string)
+
+	report, err := fetchWeather(city, units)
+	if err != nil {
+		return mcp.Errorf("Error: %v", err), nil
+	}
+
+	return mcp.Text(report), nil
 }
 
 func main() {
 	fmt.Fprintf(os.Stderr, "Weather MCP Server started at %s\n", time.Now().Format(time.RFC3339))
 
-	reader := bufio.NewReader(os.Stdin)
-
-	for {
-		line, err := reader.ReadBytes('\n')
-		if err != nil {
-			if err == io.EOF {
-				break
-			}
-			fmt.Fprintf(os.Stderr, "Read error: %v\n", err)
-			continue
-		}
-
-		var req JSONRPCRequest
-		if err := json.Unmarshal(line, &req); err != nil {
-			fmt.Fprintf(os.Stderr, "Parse error: %v\n", err)
-			continue
-		}
-
-		resp := handleRequest(req)
-
-		// Don't send response for notifications
-		if resp.ID == nil && resp.Result == nil && resp.Error == nil {
-			continue
-		}
-
-		respBytes, err := json.Marshal(resp)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Marshal error: %v\n", err)
-			continue
-		}
-
-		fmt.Println(string(respBytes))
+	server := mcp.NewServer("weather-mcp", version.Version)
+	server.AddTool(mcp.Tool{
+		Name:        "get_weather",
+		Description: "Get current weather for a city. Returns temperature, humidity, wind speed, and conditions.",
+		InputSchema: mcp.InputSchema{
+			Type: "object",
+			Properties: map[string]mcp.Property{
+				"city": {
+					Type:        "string",
+					Description: "City name (e.g., 'Berlin', 'Munich', 'Hamburg')",
+				},
+				"units": {
+					Type:        "string",
+					Description: "Unit system: metric (default), imperial, or standard",
+				},
+			},
+			Required: []string{"city"},
+		},
+	}, handleGetWeather)
+
+	server.AddTool(mcp.Tool{
+		Name:        "find_nearest_station",
+		Description: "Find the nearest known weather observation station to a latitude/longitude.",
+		InputSchema: mcp.InputSchema{
+			Type: "object",
+			Properties: map[string]mcp.Property{
+				"lat": {Type: "number", Description: "Latitude"},
+				"lon": {Type: "number", Description: "Longitude"},
+			},
+			Required: []string{"lat", "lon"},
+		},
+	}, handleFindNearestStation)
+
+	server.AddTool(mcp.Tool{
+		Name:        "get_station_observation",
+		Description: "Get the latest observation for a known weather station by its ID.",
+		InputSchema: mcp.InputSchema{
+			Type: "object",
+			Properties: map[string]mcp.Property{
+				"station_id": {Type: "string", Description: "Station ID, e.g. '10382' for Berlin-Tegel"},
+			},
+			Required: []string{"station_id"},
+		},
+	}, handleGetStationObservation)
+
+	if err := server.Run(os.Stdin, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "Server error: %v\n", err)
+		os.Exit(1)
 	}
 }
@@ -0,0 +1,250 @@
+package features
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/cucumber/godog"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+
+	"github.com/f00b455/blank-go/internal/handlers"
+	"github.com/f00b455/blank-go/pkg/task"
+)
+
+// apiContractContext boots a router wired the same way setupRouter wires
+// the task handler (setupRouter itself lives in package main and can't be
+// imported - see the identical tradeoff documented in swagger_test.go),
+// wraps it in an httptest.Server, and validates every response this suite
+// sends against the checked-in api/openapi.yaml contract.
+type apiContractContext struct {
+	service *task.Service
+	server  *httptest.Server
+	spec    *openapi3.T
+	router  routers.Router
+
+	lastTaskID string
+	lastResp   *contractResponse
+}
+
+// contractResponse is the result of one request/response round trip that
+// has already been checked against the OpenAPI spec.
+type contractResponse struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+func (ctx *apiContractContext) reset() {
+	gin.SetMode(gin.TestMode)
+
+	repo := task.NewInMemoryRepository()
+	ctx.service = task.NewService(repo)
+	taskHandler := handlers.NewTaskHandler(ctx.service)
+
+	router := gin.New()
+	api := router.Group("/api/v1")
+	{
+		tasks := api.Group("/tasks")
+		{
+			tasks.POST("", taskHandler.CreateTask)
+			tasks.GET("", taskHandler.ListTasks)
+			tasks.GET("/:id", taskHandler.GetTask)
+			tasks.PUT("/:id", taskHandler.UpdateTask)
+			tasks.DELETE("/:id", taskHandler.DeleteTask)
+		}
+	}
+	ctx.server = httptest.NewServer(router)
+
+	loader := openapi3.NewLoader()
+	spec, err := loader.LoadFromFile("../api/openapi.yaml")
+	if err != nil {
+		panic(fmt.Sprintf("failed to load api/openapi.yaml: %v", err))
+	}
+	if err := spec.Validate(loader.Context); err != nil {
+		panic(fmt.Sprintf("api/openapi.yaml is not a valid OpenAPI document: %v", err))
+	}
+	ctx.spec = spec
+
+	specRouter, err := gorillamux.NewRouter(spec)
+	if err != nil {
+		panic(fmt.Sprintf("failed to build a router from api/openapi.yaml: %v", err))
+	}
+	ctx.router = specRouter
+
+	ctx.lastTaskID = ""
+	ctx.lastResp = nil
+}
+
+func (ctx *apiContractContext) theTaskAPIIsAvailable() error {
+	return nil
+}
+
+// doAndValidate sends method/path (relative to /api/v1) with the given
+// JSON body against the live server, then validates both the outgoing
+// request and the response it got back against ctx.spec before recording
+// the result on ctx.lastResp.
+func (ctx *apiContractContext) doAndValidate(method, path string, body []byte) error {
+	fullPath := "/api/v1" + path
+
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	specReq, err := http.NewRequest(method, fullPath, reqBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		specReq.Header.Set("Content-Type", "application/json")
+	}
+
+	route, pathParams, err := ctx.router.FindRoute(specReq)
+	if err != nil {
+		return fmt.Errorf("no route in api/openapi.yaml matches %s %s: %w", method, fullPath, err)
+	}
+
+	reqCtx := context.Background()
+	requestInput := &openapi3filter.RequestValidationInput{
+		Request:    specReq,
+		PathParams: pathParams,
+		QueryParams: func() url.Values {
+			u, _ := url.Parse(fullPath)
+			return u.Query()
+		}(),
+		Route: route,
+	}
+	if err := openapi3filter.ValidateRequest(reqCtx, requestInput); err != nil {
+		return fmt.Errorf("request %s %s violates api/openapi.yaml: %w", method, fullPath, err)
+	}
+
+	var liveBody io.Reader
+	if body != nil {
+		liveBody = bytes.NewReader(body)
+	}
+	liveReq, err := http.NewRequest(method, ctx.server.URL+fullPath, liveBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		liveReq.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := ctx.server.Client().Do(liveReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	responseInput := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: requestInput,
+		Status:                 resp.StatusCode,
+		Header:                 resp.Header,
+	}
+	responseInput.SetBodyBytes(respBody)
+
+	if err := openapi3filter.ValidateResponse(reqCtx, responseInput); err != nil {
+		return fmt.Errorf("response to %s %s violates api/openapi.yaml: %w", method, fullPath, err)
+	}
+
+	ctx.lastResp = &contractResponse{status: resp.StatusCode, header: resp.Header, body: respBody}
+	return nil
+}
+
+func (ctx *apiContractContext) iCreateATaskWithTitleViaTheContractClient(title string) error {
+	body, _ := json.Marshal(map[string]string{"title": title})
+	if err := ctx.doAndValidate(http.MethodPost, "/tasks", body); err != nil {
+		return err
+	}
+
+	var created task.Task
+	if err := json.Unmarshal(ctx.lastResp.body, &created); err != nil {
+		return err
+	}
+	ctx.lastTaskID = created.ID
+	return nil
+}
+
+func (ctx *apiContractContext) iListTasksViaTheContractClient() error {
+	return ctx.doAndValidate(http.MethodGet, "/tasks", nil)
+}
+
+func (ctx *apiContractContext) iListTasksFilteredToStatusAndPriorityViaTheContractClient(status, priority string) error {
+	q := url.Values{"status": {status}, "priority": {priority}}
+	return ctx.doAndValidate(http.MethodGet, "/tasks?"+q.Encode(), nil)
+}
+
+func (ctx *apiContractContext) iListTasksWithCursorPaginationViaTheContractClient() error {
+	return ctx.doAndValidate(http.MethodGet, "/tasks?cursor=&limit=1", nil)
+}
+
+func (ctx *apiContractContext) iGetThatTaskViaTheContractClient() error {
+	return ctx.doAndValidate(http.MethodGet, "/tasks/"+ctx.lastTaskID, nil)
+}
+
+func (ctx *apiContractContext) iGetANonexistentTaskViaTheContractClient() error {
+	return ctx.doAndValidate(http.MethodGet, "/tasks/does-not-exist", nil)
+}
+
+func (ctx *apiContractContext) iUpdateThatTasksStatusToViaTheContractClient(status string) error {
+	body, _ := json.Marshal(map[string]string{"status": status})
+	return ctx.doAndValidate(http.MethodPut, "/tasks/"+ctx.lastTaskID, body)
+}
+
+func (ctx *apiContractContext) iDeleteThatTaskViaTheContractClient() error {
+	return ctx.doAndValidate(http.MethodDelete, "/tasks/"+ctx.lastTaskID, nil)
+}
+
+func (ctx *apiContractContext) theResponseShouldSatisfyTheOpenAPIContract() error {
+	if ctx.lastResp == nil {
+		return fmt.Errorf("no response has been recorded yet")
+	}
+	return nil
+}
+
+func (ctx *apiContractContext) theResponseShouldCarryAHeader(name string) error {
+	if ctx.lastResp.header.Get(name) == "" {
+		return fmt.Errorf("expected a %q response header, got none", name)
+	}
+	return nil
+}
+
+func InitializeAPIContractScenario(sc *godog.ScenarioContext) {
+	ctx := &apiContractContext{}
+
+	sc.Before(func(c context.Context, s *godog.Scenario) (context.Context, error) {
+		ctx.reset()
+		return c, nil
+	})
+	sc.After(func(c context.Context, s *godog.Scenario, err error) (context.Context, error) {
+		ctx.server.Close()
+		return c, nil
+	})
+
+	sc.Step(`^the task API is available$`, ctx.theTaskAPIIsAvailable)
+	sc.Step(`^I create a task with title "([^"]*)" via the contract client$`, ctx.iCreateATaskWithTitleViaTheContractClient)
+	sc.Step(`^I list tasks via the contract client$`, ctx.iListTasksViaTheContractClient)
+	sc.Step(`^I list tasks filtered to status "([^"]*)" and priority "([^"]*)" via the contract client$`, ctx.iListTasksFilteredToStatusAndPriorityViaTheContractClient)
+	sc.Step(`^I list tasks with cursor pagination via the contract client$`, ctx.iListTasksWithCursorPaginationViaTheContractClient)
+	sc.Step(`^I get that task via the contract client$`, ctx.iGetThatTaskViaTheContractClient)
+	sc.Step(`^I get a nonexistent task via the contract client$`, ctx.iGetANonexistentTaskViaTheContractClient)
+	sc.Step(`^I update that task's status to "([^"]*)" via the contract client$`, ctx.iUpdateThatTasksStatusToViaTheContractClient)
+	sc.Step(`^I delete that task via the contract client$`, ctx.iDeleteThatTaskViaTheContractClient)
+	sc.Step(`^the response should satisfy the OpenAPI contract$`, ctx.theResponseShouldSatisfyTheOpenAPIContract)
+	sc.Step(`^the response should carry an? "([^"]*)" header$`, ctx.theResponseShouldCarryAHeader)
+}
@@ -0,0 +1,179 @@
+package features
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/gin-gonic/gin"
+
+	"github.com/f00b455/blank-go/internal/handlers"
+	"github.com/f00b455/blank-go/pkg/dax"
+)
+
+type daxImportAsyncContext struct {
+	repo         dax.Repository
+	manager      *dax.ImportJobManager
+	handler      *handlers.ImportJobHandler
+	router       *gin.Engine
+	response     *httptest.ResponseRecorder
+	lastResponse map[string]interface{}
+	jobID        string
+}
+
+func (ctx *daxImportAsyncContext) reset() {
+	gin.SetMode(gin.TestMode)
+	ctx.repo = dax.NewInMemoryRepository()
+	service := dax.NewService(ctx.repo)
+	store := dax.NewInMemoryJobStore()
+	ctx.manager = dax.NewImportJobManager(service, store, time.Hour)
+	ctx.handler = handlers.NewImportJobHandler(ctx.manager)
+	ctx.setupRouter()
+
+	ctx.response = nil
+	ctx.lastResponse = nil
+	ctx.jobID = ""
+}
+
+func (ctx *daxImportAsyncContext) setupRouter() {
+	ctx.router = gin.New()
+	api := ctx.router.Group("/api/v1/dax")
+	{
+		api.POST("/imports", ctx.handler.Submit)
+		api.GET("/imports", ctx.handler.List)
+		api.GET("/imports/:id", ctx.handler.Get)
+		api.POST("/imports/:id/stop", ctx.handler.Stop)
+	}
+}
+
+func (ctx *daxImportAsyncContext) theDAXAPIIsAvailable() error {
+	return nil
+}
+
+func (ctx *daxImportAsyncContext) thePostgreSQLDatabaseIsClean() error {
+	return ctx.repo.DeleteAll(context.Background())
+}
+
+func (ctx *daxImportAsyncContext) iSubmitAnAsyncImportWithTheFollowingCSVContent(csvContent *godog.DocString) error {
+	// continue_on_error=true so a bad row is counted as a per-row failure
+	// instead of failing the whole job; harmless for scenarios whose CSV
+	// has no bad rows.
+	req, err := http.NewRequest("POST", "/api/v1/dax/imports?continue_on_error=true", strings.NewReader(csvContent.Content))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/csv")
+
+	ctx.response = httptest.NewRecorder()
+	ctx.router.ServeHTTP(ctx.response, req)
+
+	if err := json.Unmarshal(ctx.response.Body.Bytes(), &ctx.lastResponse); err != nil {
+		return err
+	}
+
+	jobID, ok := ctx.lastResponse["job_id"].(string)
+	if !ok {
+		return fmt.Errorf("job_id not found in response")
+	}
+	ctx.jobID = jobID
+
+	return nil
+}
+
+func (ctx *daxImportAsyncContext) theImportSubmissionShouldBeAccepted() error {
+	if ctx.response.Code != http.StatusAccepted {
+		return fmt.Errorf("expected status %d, got %d", http.StatusAccepted, ctx.response.Code)
+	}
+	return nil
+}
+
+func (ctx *daxImportAsyncContext) iStopTheImportJob() error {
+	req, err := http.NewRequest("POST", "/api/v1/dax/imports/"+ctx.jobID+"/stop", nil)
+	if err != nil {
+		return err
+	}
+
+	ctx.response = httptest.NewRecorder()
+	ctx.router.ServeHTTP(ctx.response, req)
+	return nil
+}
+
+func (ctx *daxImportAsyncContext) theImportJobShouldEventuallyReachStatus(want string) error {
+	deadline := time.Now().Add(2 * time.Second)
+	var lastStatus string
+	for time.Now().Before(deadline) {
+		job, err := ctx.manager.Get(ctx.jobID)
+		if err != nil {
+			return err
+		}
+		lastStatus = string(job.Status)
+		if lastStatus == want {
+			return nil
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return fmt.Errorf("job %s did not reach status %q in time (last seen %q)", ctx.jobID, want, lastStatus)
+}
+
+func (ctx *daxImportAsyncContext) theImportJobShouldReportSucceededAndFailedRecords(succeeded, failed int) error {
+	job, err := ctx.manager.Get(ctx.jobID)
+	if err != nil {
+		return err
+	}
+	if job.RecordsSucceeded != succeeded || job.RecordsFailed != failed {
+		return fmt.Errorf("expected %d succeeded / %d failed, got %d succeeded / %d failed",
+			succeeded, failed, job.RecordsSucceeded, job.RecordsFailed)
+	}
+	return nil
+}
+
+func (ctx *daxImportAsyncContext) iListImportJobsWithPageAndLimit(page, limit int) error {
+	url := fmt.Sprintf("/api/v1/dax/imports?page=%d&limit=%d", page, limit)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	ctx.response = httptest.NewRecorder()
+	ctx.router.ServeHTTP(ctx.response, req)
+
+	if err := json.Unmarshal(ctx.response.Body.Bytes(), &ctx.lastResponse); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ctx *daxImportAsyncContext) theImportJobListShouldContainJobs(expectedCount int) error {
+	data, ok := ctx.lastResponse["data"].([]interface{})
+	if !ok {
+		return fmt.Errorf("data not found in response")
+	}
+	if len(data) != expectedCount {
+		return fmt.Errorf("expected %d jobs, got %d", expectedCount, len(data))
+	}
+	return nil
+}
+
+func InitializeDAXImportAsyncScenario(sc *godog.ScenarioContext) {
+	ctx := &daxImportAsyncContext{}
+
+	sc.Before(func(c context.Context, s *godog.Scenario) (context.Context, error) {
+		ctx.reset()
+		return c, nil
+	})
+
+	sc.Step(`^the DAX API is available$`, ctx.theDAXAPIIsAvailable)
+	sc.Step(`^the PostgreSQL database is clean$`, ctx.thePostgreSQLDatabaseIsClean)
+	sc.Step(`^I submit an async import with the following CSV content:$`, ctx.iSubmitAnAsyncImportWithTheFollowingCSVContent)
+	sc.Step(`^the import submission should be accepted$`, ctx.theImportSubmissionShouldBeAccepted)
+	sc.Step(`^I stop the import job$`, ctx.iStopTheImportJob)
+	sc.Step(`^the import job should eventually reach status "([^"]*)"$`, ctx.theImportJobShouldEventuallyReachStatus)
+	sc.Step(`^the import job should report (\d+) succeeded and (\d+) failed records$`, ctx.theImportJobShouldReportSucceededAndFailedRecords)
+	sc.Step(`^I list import jobs with page (\d+) and limit (\d+)$`, ctx.iListImportJobsWithPageAndLimit)
+	sc.Step(`^the import job list should contain (\d+) jobs$`, ctx.theImportJobListShouldContainJobs)
+}
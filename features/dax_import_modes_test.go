@@ -0,0 +1,140 @@
+package features
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cucumber/godog"
+	"github.com/gin-gonic/gin"
+
+	"github.com/f00b455/blank-go/internal/handlers"
+	"github.com/f00b455/blank-go/pkg/dax"
+)
+
+type daxImportModesContext struct {
+	repo        dax.Repository
+	service     *dax.Service
+	handler     *handlers.DAXHandler
+	router      *gin.Engine
+	response    *httptest.ResponseRecorder
+	csvContent  string
+	recordCount int
+}
+
+func (ctx *daxImportModesContext) reset() {
+	gin.SetMode(gin.TestMode)
+	ctx.repo = dax.NewInMemoryRepository()
+	ctx.service = dax.NewService(ctx.repo)
+	ctx.handler = handlers.NewDAXHandler(ctx.service)
+	ctx.setupRouter()
+
+	ctx.response = nil
+	ctx.csvContent = ""
+	ctx.recordCount = 0
+}
+
+func (ctx *daxImportModesContext) setupRouter() {
+	ctx.router = gin.New()
+	api := ctx.router.Group("/api/v1/dax")
+	{
+		api.POST("/import", ctx.handler.ImportCSV)
+	}
+}
+
+func (ctx *daxImportModesContext) theDAXAPIIsAvailable() error {
+	return nil
+}
+
+func (ctx *daxImportModesContext) thePostgreSQLDatabaseIsClean() error {
+	return ctx.repo.DeleteAll(context.Background())
+}
+
+func (ctx *daxImportModesContext) uploadCSV(query string) error {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("file", "test.csv")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(part, ctx.csvContent); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", "/api/v1/dax/import?"+query, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	ctx.response = httptest.NewRecorder()
+	ctx.router.ServeHTTP(ctx.response, req)
+	return nil
+}
+
+func (ctx *daxImportModesContext) iUploadACSVFileWithRecordsInMode(recordCount int, mode string) error {
+	ctx.recordCount = recordCount
+	ctx.csvContent = "company,ticker,report_type,metric,year,value,currency\n"
+	for i := 0; i < recordCount; i++ {
+		ctx.csvContent += fmt.Sprintf("Company%d,TICK%d,income,EBITDA,2025,%d.0,EUR\n", i, i, i*1000000)
+	}
+
+	return ctx.uploadCSV("mode=" + mode)
+}
+
+func (ctx *daxImportModesContext) iUploadTheSameCSVFileAgainInMode(mode string) error {
+	return ctx.uploadCSV("mode=" + mode)
+}
+
+func (ctx *daxImportModesContext) iUploadACSVFileWithRecordsWithDryRunEnabled(recordCount int) error {
+	ctx.recordCount = recordCount
+	ctx.csvContent = "company,ticker,report_type,metric,year,value,currency\n"
+	for i := 0; i < recordCount; i++ {
+		ctx.csvContent += fmt.Sprintf("Company%d,TICK%d,income,EBITDA,2025,%d.0,EUR\n", i, i, i*1000000)
+	}
+
+	return ctx.uploadCSV("dry_run=true")
+}
+
+func (ctx *daxImportModesContext) theResponseStatusShouldBe(expected int) error {
+	if ctx.response.Code != expected {
+		return fmt.Errorf("expected status %d, got %d: %s", expected, ctx.response.Code, ctx.response.Body.String())
+	}
+	return nil
+}
+
+func (ctx *daxImportModesContext) theDAXDatabaseShouldContainRecords(expected int) error {
+	_, total, err := ctx.repo.FindAll(context.Background(), 1, 1)
+	if err != nil {
+		return err
+	}
+	if total != expected {
+		return fmt.Errorf("expected %d records in the database, got %d", expected, total)
+	}
+	return nil
+}
+
+func InitializeDAXImportModesScenario(sc *godog.ScenarioContext) {
+	ctx := &daxImportModesContext{}
+
+	sc.Before(func(c context.Context, s *godog.Scenario) (context.Context, error) {
+		ctx.reset()
+		return c, nil
+	})
+
+	sc.Step(`^the DAX API is available$`, ctx.theDAXAPIIsAvailable)
+	sc.Step(`^the PostgreSQL database is clean$`, ctx.thePostgreSQLDatabaseIsClean)
+	sc.Step(`^I upload a CSV file with (\d+) records in "([^"]*)" mode$`, ctx.iUploadACSVFileWithRecordsInMode)
+	sc.Step(`^I upload the same CSV file again in "([^"]*)" mode$`, ctx.iUploadTheSameCSVFileAgainInMode)
+	sc.Step(`^I upload a CSV file with (\d+) records with dry_run enabled$`, ctx.iUploadACSVFileWithRecordsWithDryRunEnabled)
+	sc.Step(`^the response status should be (\d+)$`, ctx.theResponseStatusShouldBe)
+	sc.Step(`^the DAX database should contain (\d+) records$`, ctx.theDAXDatabaseShouldContainRecords)
+}
@@ -0,0 +1,129 @@
+package features
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/cucumber/godog"
+	"github.com/gin-gonic/gin"
+
+	"github.com/f00b455/blank-go/internal/handlers"
+	"github.com/f00b455/blank-go/pkg/dax"
+)
+
+type daxImportStreamContext struct {
+	repo     dax.Repository
+	service  *dax.Service
+	handler  *handlers.DAXHandler
+	router   *gin.Engine
+	response *httptest.ResponseRecorder
+}
+
+func (ctx *daxImportStreamContext) reset() {
+	gin.SetMode(gin.TestMode)
+	ctx.repo = dax.NewInMemoryRepository()
+	ctx.service = dax.NewService(ctx.repo)
+	ctx.handler = handlers.NewDAXHandler(ctx.service)
+	ctx.setupRouter()
+
+	ctx.response = nil
+}
+
+func (ctx *daxImportStreamContext) setupRouter() {
+	ctx.router = gin.New()
+	api := ctx.router.Group("/api/v1/dax")
+	{
+		api.POST("/import", ctx.handler.ImportCSV)
+	}
+}
+
+func (ctx *daxImportStreamContext) theDAXAPIIsAvailable() error {
+	return nil
+}
+
+func (ctx *daxImportStreamContext) thePostgreSQLDatabaseIsClean() error {
+	return ctx.repo.DeleteAll(context.Background())
+}
+
+func (ctx *daxImportStreamContext) iStreamUploadACSVFileWithRecords(recordCount int) error {
+	csvContent := "company,ticker,report_type,metric,year,value,currency\n"
+	for i := 0; i < recordCount; i++ {
+		csvContent += fmt.Sprintf("Company%d,TICK%d,income,EBITDA,2025,%d.0,EUR\n", i, i%10, i*1000000)
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("file", "test.csv")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(part, csvContent); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", "/api/v1/dax/import", body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Accept", "text/event-stream")
+
+	ctx.response = httptest.NewRecorder()
+	ctx.router.ServeHTTP(ctx.response, req)
+	return nil
+}
+
+func (ctx *daxImportStreamContext) theResponseStatusShouldBe(expected int) error {
+	if ctx.response.Code != expected {
+		return fmt.Errorf("expected status %d, got %d", expected, ctx.response.Code)
+	}
+	return nil
+}
+
+func (ctx *daxImportStreamContext) iShouldReceiveAtLeastProgressEventsBeforeCompletion(minEvents int) error {
+	got := strings.Count(ctx.response.Body.String(), "event: progress\n")
+	if got < minEvents {
+		return fmt.Errorf("expected at least %d progress events, got %d", minEvents, got)
+	}
+	return nil
+}
+
+func (ctx *daxImportStreamContext) theStreamShouldEndWithADoneEventReportingRecordsImported(recordsImported int) error {
+	body := ctx.response.Body.String()
+	idx := strings.LastIndex(body, "event: done\n")
+	if idx == -1 {
+		return fmt.Errorf("no done event found in stream")
+	}
+
+	want := fmt.Sprintf(`"records_imported":%d`, recordsImported)
+	if !strings.Contains(body[idx:], want) {
+		return fmt.Errorf("expected done event to report %s, got %q", want, body[idx:])
+	}
+	return nil
+}
+
+func InitializeDAXImportStreamScenario(sc *godog.ScenarioContext) {
+	ctx := &daxImportStreamContext{}
+
+	sc.Before(func(c context.Context, s *godog.Scenario) (context.Context, error) {
+		ctx.reset()
+		return c, nil
+	})
+
+	sc.Step(`^the DAX API is available$`, ctx.theDAXAPIIsAvailable)
+	sc.Step(`^the PostgreSQL database is clean$`, ctx.thePostgreSQLDatabaseIsClean)
+	sc.Step(`^I stream-upload a CSV file with (\d+) records$`, ctx.iStreamUploadACSVFileWithRecords)
+	sc.Step(`^the response status should be (\d+)$`, ctx.theResponseStatusShouldBe)
+	sc.Step(`^I should receive at least (\d+) progress events before completion$`, ctx.iShouldReceiveAtLeastProgressEventsBeforeCompletion)
+	sc.Step(`^the stream should end with a done event reporting (\d+) records imported$`, ctx.theStreamShouldEndWithADoneEventReportingRecordsImported)
+}
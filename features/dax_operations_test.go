@@ -0,0 +1,172 @@
+package features
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/gin-gonic/gin"
+
+	"github.com/f00b455/blank-go/internal/handlers"
+	"github.com/f00b455/blank-go/pkg/dax"
+	"github.com/f00b455/blank-go/pkg/operations"
+)
+
+type daxOperationsContext struct {
+	repo         dax.Repository
+	registry     *operations.Registry
+	daxHandler   *handlers.DAXHandler
+	opsHandler   *handlers.OperationsHandler
+	router       *gin.Engine
+	response     *httptest.ResponseRecorder
+	lastResponse map[string]interface{}
+	operationID  string
+}
+
+func (ctx *daxOperationsContext) reset() {
+	gin.SetMode(gin.TestMode)
+	ctx.repo = dax.NewInMemoryRepository()
+	service := dax.NewService(ctx.repo)
+	ctx.registry = operations.NewRegistry()
+	ctx.daxHandler = handlers.NewDAXHandlerWithOperations(service, "test-cursor-signing-key", ctx.registry)
+	ctx.opsHandler = handlers.NewOperationsHandler(ctx.registry)
+	ctx.setupRouter()
+
+	ctx.response = nil
+	ctx.lastResponse = nil
+	ctx.operationID = ""
+}
+
+func (ctx *daxOperationsContext) setupRouter() {
+	ctx.router = gin.New()
+	api := ctx.router.Group("/api/v1")
+	{
+		api.POST("/dax/import", ctx.daxHandler.ImportCSV)
+		api.GET("/operations/:id", ctx.opsHandler.Get)
+		api.DELETE("/operations/:id", ctx.opsHandler.Cancel)
+	}
+}
+
+func (ctx *daxOperationsContext) theDAXAPIIsAvailable() error {
+	return nil
+}
+
+func (ctx *daxOperationsContext) thePostgreSQLDatabaseIsClean() error {
+	return ctx.repo.DeleteAll(context.Background())
+}
+
+func (ctx *daxOperationsContext) iAsyncUploadACSVFileWithRecords(recordCount int) error {
+	csvContent := "company,ticker,report_type,metric,year,value,currency\n"
+	for i := 0; i < recordCount; i++ {
+		csvContent += fmt.Sprintf("Company%d,TICK%d,income,EBITDA,2025,%d.0,EUR\n", i, i%10, i*1000000)
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("file", "test.csv")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(part, csvContent); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", "/api/v1/dax/import?async=true", body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	ctx.response = httptest.NewRecorder()
+	ctx.router.ServeHTTP(ctx.response, req)
+
+	if err := json.Unmarshal(ctx.response.Body.Bytes(), &ctx.lastResponse); err != nil {
+		return err
+	}
+
+	operationID, ok := ctx.lastResponse["operation_id"].(string)
+	if !ok {
+		return fmt.Errorf("operation_id not found in response")
+	}
+	ctx.operationID = operationID
+
+	return nil
+}
+
+func (ctx *daxOperationsContext) theImportSubmissionShouldBeAccepted() error {
+	if ctx.response.Code != http.StatusAccepted {
+		return fmt.Errorf("expected status %d, got %d", http.StatusAccepted, ctx.response.Code)
+	}
+	return nil
+}
+
+func (ctx *daxOperationsContext) iPollTheOperationUntilStatusIs(want string) error {
+	deadline := time.Now().Add(2 * time.Second)
+	var lastStatus string
+	for time.Now().Before(deadline) {
+		op, err := ctx.registry.Get(ctx.operationID)
+		if err != nil {
+			return err
+		}
+		lastStatus = string(op.Status)
+		if lastStatus == want {
+			return nil
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return fmt.Errorf("operation %s did not reach status %q in time (last seen %q)", ctx.operationID, want, lastStatus)
+}
+
+func (ctx *daxOperationsContext) theOperationProgressShouldReach(want int) error {
+	op, err := ctx.registry.Get(ctx.operationID)
+	if err != nil {
+		return err
+	}
+	if op.Progress != want {
+		return fmt.Errorf("expected progress %d, got %d", want, op.Progress)
+	}
+	return nil
+}
+
+func (ctx *daxOperationsContext) iCancelTheOperation() error {
+	req, err := http.NewRequest("DELETE", "/api/v1/operations/"+ctx.operationID, nil)
+	if err != nil {
+		return err
+	}
+	ctx.response = httptest.NewRecorder()
+	ctx.router.ServeHTTP(ctx.response, req)
+	return nil
+}
+
+func (ctx *daxOperationsContext) theOperationShouldEventuallyReachStatus(want string) error {
+	return ctx.iPollTheOperationUntilStatusIs(want)
+}
+
+func InitializeDAXOperationsScenario(sc *godog.ScenarioContext) {
+	ctx := &daxOperationsContext{}
+
+	sc.Before(func(c context.Context, s *godog.Scenario) (context.Context, error) {
+		ctx.reset()
+		return c, nil
+	})
+
+	sc.Step(`^the DAX API is available$`, ctx.theDAXAPIIsAvailable)
+	sc.Step(`^the PostgreSQL database is clean$`, ctx.thePostgreSQLDatabaseIsClean)
+	sc.Step(`^I async-upload a CSV file with (\d+) records$`, ctx.iAsyncUploadACSVFileWithRecords)
+	sc.Step(`^the import submission should be accepted$`, ctx.theImportSubmissionShouldBeAccepted)
+	sc.Step(`^I poll the operation until status is "([^"]*)"$`, ctx.iPollTheOperationUntilStatusIs)
+	sc.Step(`^the operation progress should reach (\d+)$`, ctx.theOperationProgressShouldReach)
+	sc.Step(`^I cancel the operation$`, ctx.iCancelTheOperation)
+	sc.Step(`^the operation should eventually reach status "([^"]*)"$`, ctx.theOperationShouldEventuallyReachStatus)
+}
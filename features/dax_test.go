@@ -51,7 +51,7 @@ func (ctx *daxContext) setupRouter() {
 }
 
 func (ctx *daxContext) cleanDatabase() {
-	ctx.repo.DeleteAll()
+	ctx.repo.DeleteAll(context.Background())
 }
 
 func (ctx *daxContext) theDAXAPIIsAvailable() error {
@@ -120,7 +120,7 @@ func (ctx *daxContext) theResponseShouldIndicateRecordsImported(expectedCount in
 }
 
 func (ctx *daxContext) theDatabaseShouldContainDAXRecords(expectedCount int) error {
-	count, err := ctx.repo.Count()
+	count, err := ctx.repo.Count(context.Background())
 	if err != nil {
 		return err
 	}
@@ -164,11 +164,12 @@ func (ctx *daxContext) theFollowingDAXRecordExists(table *godog.Table) error {
 		}
 	}
 
-	return ctx.repo.Create(&record)
+	_, err := ctx.repo.Upsert(context.Background(), &record)
+	return err
 }
 
 func (ctx *daxContext) theEBITDAValueForSIEShouldBe(year int, expectedValue float64) error {
-	records, _, err := ctx.repo.FindByFilters("SIE", &year, 1, 100)
+	records, _, err := ctx.repo.FindByFilters(context.Background(), &dax.Filters{Tickers: []string{"SIE"}, YearFrom: &year, YearTo: &year}, 1, 100)
 	if err != nil {
 		return err
 	}
@@ -270,7 +271,7 @@ func (ctx *daxContext) theFollowingDAXRecordsExist(table *godog.Table) error {
 			}
 		}
 
-		if err := ctx.repo.Create(&record); err != nil {
+		if _, err := ctx.repo.Upsert(context.Background(), &record); err != nil {
 			return err
 		}
 	}
@@ -1,6 +1,7 @@
 package features
 
 import (
+	"os"
 	"testing"
 
 	"github.com/cucumber/godog"
@@ -21,6 +22,21 @@ func TestTaskFeatures(t *testing.T) {
 	}
 }
 
+func TestTaskEventsFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		ScenarioInitializer: InitializeTaskEventsScenario,
+		Options: &godog.Options{
+			Format:   "pretty",
+			Paths:    []string{"task-events.feature"},
+			TestingT: t,
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("non-zero status returned, failed to run task events feature tests")
+	}
+}
+
 func TestDAXFeatures(t *testing.T) {
 	suite := godog.TestSuite{
 		ScenarioInitializer: InitializeDAXScenario,
@@ -36,6 +52,110 @@ func TestDAXFeatures(t *testing.T) {
 	}
 }
 
+func TestDAXImportAsyncFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		ScenarioInitializer: InitializeDAXImportAsyncScenario,
+		Options: &godog.Options{
+			Format:   "pretty",
+			Paths:    []string{"dax-import-async.feature"},
+			TestingT: t,
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("non-zero status returned, failed to run async DAX import feature tests")
+	}
+}
+
+func TestDAXImportStreamFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		ScenarioInitializer: InitializeDAXImportStreamScenario,
+		Options: &godog.Options{
+			Format:   "pretty",
+			Paths:    []string{"dax-import-stream.feature"},
+			TestingT: t,
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("non-zero status returned, failed to run DAX import stream feature tests")
+	}
+}
+
+func TestDAXImportModesFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		ScenarioInitializer: InitializeDAXImportModesScenario,
+		Options: &godog.Options{
+			Format:   "pretty",
+			Paths:    []string{"dax-import-modes.feature"},
+			TestingT: t,
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("non-zero status returned, failed to run DAX import modes feature tests")
+	}
+}
+
+func TestDAXOperationsFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		ScenarioInitializer: InitializeDAXOperationsScenario,
+		Options: &godog.Options{
+			Format:   "pretty",
+			Paths:    []string{"dax-operations.feature"},
+			TestingT: t,
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("non-zero status returned, failed to run DAX operations feature tests")
+	}
+}
+
+func TestVersionFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		ScenarioInitializer: InitializeVersionScenario,
+		Options: &godog.Options{
+			Format:   "pretty",
+			Paths:    []string{"version.feature"},
+			TestingT: t,
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("non-zero status returned, failed to run version feature tests")
+	}
+}
+
+// apiContractJUnitReportPath is where TestAPIContractFeatures writes its
+// machine-readable report. godog's junit formatter plain os.Create()s this
+// path, so the directory has to exist first.
+const apiContractJUnitReportPath = "reports/api-contract-junit.xml"
+
+// TestAPIContractFeatures replays the task API's CRUD scenarios against a
+// live router and validates every response against api/openapi.yaml,
+// making that spec the executable source of truth for the API contract.
+// It also emits a JUnit report so CI can surface contract drift the same
+// way it surfaces test failures.
+func TestAPIContractFeatures(t *testing.T) {
+	if err := os.MkdirAll("reports", 0o755); err != nil {
+		t.Fatalf("failed to create report directory: %v", err)
+	}
+
+	suite := godog.TestSuite{
+		ScenarioInitializer: InitializeAPIContractScenario,
+		Options: &godog.Options{
+			Format:   "pretty,junit:" + apiContractJUnitReportPath,
+			Paths:    []string{"api-contract.feature"},
+			TestingT: t,
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("non-zero status returned, failed to run API contract feature tests")
+	}
+}
+
 func TestWeatherFeatures(t *testing.T) {
 	suite := godog.TestSuite{
 		ScenarioInitializer: InitializeWeatherScenario,
@@ -14,6 +14,16 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/f00b455/blank-go/internal/handlers"
+	"github.com/f00b455/blank-go/pkg/health"
+	"github.com/f00b455/blank-go/pkg/stocks"
+)
+
+// healthCheckTimeout and healthCheckCacheTTL mirror the values
+// cmd/api/main.go wires up for the real health.Registry; the exact
+// durations don't matter here since no Checker is registered.
+const (
+	healthCheckTimeout  = 2 * time.Second
+	healthCheckCacheTTL = 5 * time.Second
 )
 
 type healthFeatureContext struct {
@@ -35,7 +45,8 @@ func (ctx *healthFeatureContext) setupRouter() {
 	ctx.router = gin.New()
 	api := ctx.router.Group("/api/v1")
 	{
-		api.GET("/health/detailed", handlers.DetailedHealthCheck(ctx.startTime))
+		registry := health.NewRegistry(healthCheckTimeout, healthCheckCacheTTL)
+		api.GET("/health/detailed", handlers.DetailedHealthCheck(ctx.startTime, stocks.NewService(nil), registry))
 	}
 }
 
@@ -118,6 +129,31 @@ func (ctx *healthFeatureContext) theResponseShouldContainSystemMetricsWithFields
 	return nil
 }
 
+func (ctx *healthFeatureContext) theResponseShouldContainCacheMetricsWithFields(table *godog.Table) error {
+	if ctx.lastResponse == nil {
+		return fmt.Errorf("no response data available")
+	}
+
+	cacheData, ok := ctx.lastResponse["cache"]
+	if !ok {
+		return fmt.Errorf("cache field not found in response")
+	}
+
+	cacheMetrics, ok := cacheData.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("cache field is not a map")
+	}
+
+	for i := 1; i < len(table.Rows); i++ {
+		field := table.Rows[i].Cells[0].Value
+		if _, ok := cacheMetrics[field]; !ok {
+			return fmt.Errorf("cache metric %q not found", field)
+		}
+	}
+
+	return nil
+}
+
 func (ctx *healthFeatureContext) theResponseShouldContainChecksWithFieldWithValue(field, value string) error {
 	if ctx.lastResponse == nil {
 		return fmt.Errorf("no response data available")
@@ -309,6 +345,7 @@ func InitializeHealthScenario(ctx *godog.ScenarioContext) {
 	ctx.Step(`^the response should contain field "([^"]*)" with value "([^"]*)"$`, feature.theResponseShouldContainFieldWithValue)
 	ctx.Step(`^the response should contain field "([^"]*)"$`, feature.theResponseShouldContainField)
 	ctx.Step(`^the response should contain system metrics with fields:$`, feature.theResponseShouldContainSystemMetricsWithFields)
+	ctx.Step(`^the response should contain cache metrics with fields:$`, feature.theResponseShouldContainCacheMetricsWithFields)
 	ctx.Step(`^the response should contain checks with field "([^"]*)" with value "([^"]*)"$`, feature.theResponseShouldContainChecksWithFieldWithValue)
 	ctx.Step(`^the system metric "([^"]*)" should be greater than (\d+)$`, feature.theSystemMetricShouldBeGreaterThan)
 	ctx.Step(`^the system metric "([^"]*)" should be greater than or equal to (\d+)$`, feature.theSystemMetricShouldBeGreaterThanOrEqualTo)
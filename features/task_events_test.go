@@ -0,0 +1,266 @@
+package features
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/f00b455/blank-go/internal/handlers"
+	"github.com/f00b455/blank-go/pkg/task"
+	"github.com/gin-gonic/gin"
+)
+
+// sseEvent is a parsed `id:`/`data:` frame from the /tasks/events stream.
+type sseEvent struct {
+	ID   string
+	Type string
+	Task map[string]interface{}
+}
+
+type taskEventsContext struct {
+	service  *task.Service
+	handler  *handlers.TaskHandler
+	server   *httptest.Server
+	received chan sseEvent
+	closeSub func()
+
+	lastTaskID  string
+	lastEventID string
+}
+
+func (ctx *taskEventsContext) reset() {
+	gin.SetMode(gin.TestMode)
+	repo := task.NewInMemoryRepository()
+	ctx.service = task.NewService(repo)
+	ctx.handler = handlers.NewTaskHandler(ctx.service)
+
+	router := gin.New()
+	tasks := router.Group("/api/v1/tasks")
+	{
+		tasks.POST("", ctx.handler.CreateTask)
+		tasks.PUT("/:id", ctx.handler.UpdateTask)
+		tasks.DELETE("/:id", ctx.handler.DeleteTask)
+		tasks.GET("/events", ctx.handler.Events)
+	}
+	ctx.server = httptest.NewServer(router)
+
+	ctx.received = make(chan sseEvent, 16)
+	ctx.closeSub = func() {}
+	ctx.lastTaskID = ""
+	ctx.lastEventID = ""
+}
+
+func (ctx *taskEventsContext) theTaskAPIIsAvailable() error {
+	return nil
+}
+
+// subscribe opens a GET /api/v1/tasks/events connection with the given
+// query string and Last-Event-ID header, and streams parsed frames into
+// ctx.received until the response body is closed.
+func (ctx *taskEventsContext) subscribe(query, lastEventID string) error {
+	reqCtx, cancel := context.WithCancel(context.Background())
+
+	url := ctx.server.URL + "/api/v1/tasks/events"
+	if query != "" {
+		url += "?" + query
+	}
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, url, nil)
+	if err != nil {
+		cancel()
+		return err
+	}
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := ctx.server.Client().Do(req)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	ctx.closeSub = func() {
+		cancel()
+		_ = resp.Body.Close()
+	}
+
+	go ctx.readFrames(resp.Body)
+
+	return nil
+}
+
+// readFrames parses SSE `id:`/`data:` frames from body until it closes,
+// pushing each onto ctx.received. Bare comment lines (the periodic
+// ":keepalive" ping) are ignored.
+func (ctx *taskEventsContext) readFrames(body io.ReadCloser) {
+	scanner := bufio.NewScanner(body)
+	var id string
+	var data bytes.Buffer
+
+	flush := func() {
+		if data.Len() == 0 {
+			return
+		}
+		var payload struct {
+			Type string                 `json:"type"`
+			Task map[string]interface{} `json:"task"`
+		}
+		if err := json.Unmarshal(data.Bytes(), &payload); err == nil {
+			ctx.received <- sseEvent{ID: id, Type: payload.Type, Task: payload.Task}
+		}
+		id = ""
+		data.Reset()
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "data: "):
+			data.WriteString(strings.TrimPrefix(line, "data: "))
+		case line == "":
+			flush()
+		}
+	}
+}
+
+func (ctx *taskEventsContext) aClientIsSubscribedToTheTaskEventsStream() error {
+	return ctx.subscribe("", "")
+}
+
+func (ctx *taskEventsContext) aClientIsSubscribedToTheTaskEventsStreamFilteredToStatus(status string) error {
+	return ctx.subscribe("status="+status, "")
+}
+
+func (ctx *taskEventsContext) iCreateATaskWithTitle(title string) error {
+	body, _ := json.Marshal(map[string]string{"title": title})
+	resp, err := http.Post(ctx.server.URL+"/api/v1/tasks", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var created map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return err
+	}
+	id, ok := created["id"].(string)
+	if !ok {
+		return fmt.Errorf("created task has no id: %v", created)
+	}
+	ctx.lastTaskID = id
+	return nil
+}
+
+func (ctx *taskEventsContext) iUpdateThatTasksStatusTo(status string) error {
+	body, _ := json.Marshal(map[string]string{"status": status})
+	req, err := http.NewRequest(http.MethodPut, ctx.server.URL+"/api/v1/tasks/"+ctx.lastTaskID, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := ctx.server.Client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return nil
+}
+
+func (ctx *taskEventsContext) iDeleteThatTask() error {
+	req, err := http.NewRequest(http.MethodDelete, ctx.server.URL+"/api/v1/tasks/"+ctx.lastTaskID, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := ctx.server.Client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return nil
+}
+
+func (ctx *taskEventsContext) theSubscriberShouldReceiveAEventFor(eventType, title string) error {
+	select {
+	case evt := <-ctx.received:
+		if evt.Type != eventType {
+			return fmt.Errorf("expected a %q event, got %q", eventType, evt.Type)
+		}
+		if got, _ := evt.Task["title"].(string); got != title {
+			return fmt.Errorf("expected event for task %q, got %q", title, got)
+		}
+		ctx.lastEventID = evt.ID
+		return nil
+	case <-time.After(2 * time.Second):
+		return fmt.Errorf("timed out waiting for a %q event", eventType)
+	}
+}
+
+func (ctx *taskEventsContext) theSubscriberShouldReceiveADeletedEvent() error {
+	select {
+	case evt := <-ctx.received:
+		if evt.Type != "deleted" {
+			return fmt.Errorf(`expected a "deleted" event, got %q`, evt.Type)
+		}
+		ctx.lastEventID = evt.ID
+		return nil
+	case <-time.After(2 * time.Second):
+		return fmt.Errorf("timed out waiting for a deleted event")
+	}
+}
+
+func (ctx *taskEventsContext) theSubscriberShouldNotReceiveAnyEventWithinMs(ms int) error {
+	select {
+	case evt := <-ctx.received:
+		return fmt.Errorf("expected no event, got %+v", evt)
+	case <-time.After(time.Duration(ms) * time.Millisecond):
+		return nil
+	}
+}
+
+func (ctx *taskEventsContext) theSubscriberDisconnectsAfterItsLastEvent() error {
+	ctx.closeSub()
+	return nil
+}
+
+func (ctx *taskEventsContext) aClientReconnectsToTheTaskEventsStreamFromItsLastEvent() error {
+	return ctx.subscribe("", ctx.lastEventID)
+}
+
+func InitializeTaskEventsScenario(sc *godog.ScenarioContext) {
+	ctx := &taskEventsContext{}
+
+	sc.Before(func(c context.Context, s *godog.Scenario) (context.Context, error) {
+		ctx.reset()
+		return c, nil
+	})
+	sc.After(func(c context.Context, s *godog.Scenario, err error) (context.Context, error) {
+		ctx.closeSub()
+		ctx.server.Close()
+		return c, nil
+	})
+
+	sc.Step(`^the task API is available$`, ctx.theTaskAPIIsAvailable)
+	sc.Step(`^a client is subscribed to the task events stream$`, ctx.aClientIsSubscribedToTheTaskEventsStream)
+	sc.Step(`^a client is subscribed to the task events stream filtered to status "([^"]*)"$`, ctx.aClientIsSubscribedToTheTaskEventsStreamFilteredToStatus)
+	sc.Step(`^I create a task with title "([^"]*)"$`, ctx.iCreateATaskWithTitle)
+	sc.Step(`^I update that task's status to "([^"]*)"$`, ctx.iUpdateThatTasksStatusTo)
+	sc.Step(`^I delete that task$`, ctx.iDeleteThatTask)
+	sc.Step(`^the subscriber should receive an? "([^"]*)" event for "([^"]*)"$`, ctx.theSubscriberShouldReceiveAEventFor)
+	sc.Step(`^the subscriber should receive a "deleted" event$`, ctx.theSubscriberShouldReceiveADeletedEvent)
+	sc.Step(`^the subscriber should not receive any event within (\d+)ms$`, ctx.theSubscriberShouldNotReceiveAnyEventWithinMs)
+	sc.Step(`^the subscriber disconnects after its last event$`, ctx.theSubscriberDisconnectsAfterItsLastEvent)
+	sc.Step(`^a client reconnects to the task events stream from its last event$`, ctx.aClientReconnectsToTheTaskEventsStreamFromItsLastEvent)
+}
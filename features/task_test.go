@@ -12,18 +12,20 @@ import (
 
 	"github.com/cucumber/godog"
 	"github.com/f00b455/blank-go/internal/handlers"
+	"github.com/f00b455/blank-go/internal/middleware"
 	"github.com/f00b455/blank-go/pkg/task"
 	"github.com/gin-gonic/gin"
 )
 
 type taskFeatureContext struct {
-	router       *gin.Engine
-	service      *task.Service
-	handler      *handlers.TaskHandler
-	response     *httptest.ResponseRecorder
-	lastTaskID   string
-	lastResponse map[string]interface{}
-	taskData     map[string]string
+	router         *gin.Engine
+	service        *task.Service
+	handler        *handlers.TaskHandler
+	response       *httptest.ResponseRecorder
+	lastTaskID     string
+	lastResponse   map[string]interface{}
+	lastBulkResult []map[string]interface{}
+	taskData       map[string]string
 }
 
 func (ctx *taskFeatureContext) reset() {
@@ -37,17 +39,19 @@ func (ctx *taskFeatureContext) reset() {
 	ctx.lastTaskID = ""
 	ctx.lastResponse = nil
 	ctx.taskData = make(map[string]string)
+	ctx.lastBulkResult = nil
 }
 
 func (ctx *taskFeatureContext) setupRouter() {
 	ctx.router = gin.New()
 	api := ctx.router.Group("/api/v1")
 	{
-		api.POST("/tasks", ctx.handler.CreateTask)
-		api.GET("/tasks", ctx.handler.ListTasks)
-		api.GET("/tasks/:id", ctx.handler.GetTask)
-		api.PUT("/tasks/:id", ctx.handler.UpdateTask)
-		api.DELETE("/tasks/:id", ctx.handler.DeleteTask)
+		tasks := api.Group("/tasks", middleware.RequestTimeout(30*time.Second))
+		tasks.POST("", ctx.handler.CreateTask)
+		tasks.GET("", ctx.handler.ListTasks)
+		tasks.GET("/:id", ctx.handler.GetTask)
+		tasks.PUT("/:id", ctx.handler.UpdateTask)
+		tasks.DELETE("/:id", ctx.handler.DeleteTask)
 	}
 }
 
@@ -212,14 +216,14 @@ func (ctx *taskFeatureContext) theErrorResponseShouldContain(message string) err
 }
 
 func (ctx *taskFeatureContext) aTaskExistsWithTitle(title string) error {
-	_, err := ctx.service.Create(task.CreateTaskRequest{Title: title})
+	_, err := ctx.service.Create(context.Background(), task.CreateTaskRequest{Title: title})
 	if err != nil {
 		return err
 	}
 
 	// Get the created task to store its ID
 	filter := task.FilterOptions{}
-	tasks, err := ctx.service.GetAll(filter)
+	tasks, err := ctx.service.GetAll(context.Background(), filter)
 	if err != nil || len(tasks) == 0 {
 		return fmt.Errorf("failed to retrieve created task")
 	}
@@ -286,7 +290,7 @@ func (ctx *taskFeatureContext) updateTask(id string) error {
 }
 
 func (ctx *taskFeatureContext) aTaskExistsWithTitleAndPriority(title, priority string) error {
-	_, err := ctx.service.Create(task.CreateTaskRequest{
+	_, err := ctx.service.Create(context.Background(), task.CreateTaskRequest{
 		Title:    title,
 		Priority: priority,
 	})
@@ -295,7 +299,7 @@ func (ctx *taskFeatureContext) aTaskExistsWithTitleAndPriority(title, priority s
 	}
 
 	filter := task.FilterOptions{}
-	tasks, err := ctx.service.GetAll(filter)
+	tasks, err := ctx.service.GetAll(context.Background(), filter)
 	if err != nil || len(tasks) == 0 {
 		return fmt.Errorf("failed to retrieve created task")
 	}
@@ -317,7 +321,7 @@ func (ctx *taskFeatureContext) iDeleteTheTaskByItsID() error {
 }
 
 func (ctx *taskFeatureContext) theTaskShouldNoLongerExist() error {
-	_, err := ctx.service.GetByID(ctx.lastTaskID)
+	_, err := ctx.service.GetByID(context.Background(), ctx.lastTaskID)
 	if err == nil {
 		return fmt.Errorf("task still exists")
 	}
@@ -340,7 +344,7 @@ func (ctx *taskFeatureContext) theFollowingTasksExist(table *godog.Table) error
 			Priority: row.Cells[2].Value,
 			Tags:     strings.Split(row.Cells[3].Value, ","),
 		}
-		_, err := ctx.service.Create(reqData)
+		_, err := ctx.service.Create(context.Background(), reqData)
 		if err != nil {
 			return err
 		}
@@ -355,6 +359,22 @@ func (ctx *taskFeatureContext) iRequestAllTasks() error {
 	return nil
 }
 
+// iRequestAllTasksWithADeadline exercises the 504 path by attaching a
+// context that has already passed its deadline to the request, the same way
+// an expired context.WithTimeout deadline arrives at a handler in
+// production once RequestTimeout's wrapped context elapses mid-request.
+func (ctx *taskFeatureContext) iRequestAllTasksWithADeadline(deadlineMs int) error {
+	deadline, cancel := context.WithTimeout(context.Background(), time.Duration(deadlineMs)*time.Millisecond)
+	defer cancel()
+	time.Sleep(time.Duration(deadlineMs)*time.Millisecond + 10*time.Millisecond)
+
+	req, _ := http.NewRequest("GET", "/api/v1/tasks", nil)
+	req = req.WithContext(deadline)
+	ctx.response = httptest.NewRecorder()
+	ctx.router.ServeHTTP(ctx.response, req)
+	return nil
+}
+
 func (ctx *taskFeatureContext) theResponseShouldContainTasks(count int) error {
 	var tasks []interface{}
 	if err := json.Unmarshal(ctx.response.Body.Bytes(), &tasks); err != nil {
@@ -514,6 +534,80 @@ func (ctx *taskFeatureContext) theFirstTaskShouldHavePriority(priority string) e
 	return nil
 }
 
+// bulkOpRow is one row of a "bulk task operations" table: op is
+// create/update/delete, id is required for update/delete, and data (when
+// present) is parsed as the raw JSON body for create/update.
+type bulkOpRow struct {
+	Op   string          `json:"op"`
+	ID   string          `json:"id,omitempty"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+func (ctx *taskFeatureContext) iSubmitTheFollowingBulkTaskOperations(table *godog.Table) error {
+	return ctx.submitBulkOperations(table, false)
+}
+
+func (ctx *taskFeatureContext) iSubmitTheFollowingBulkTaskOperationsAtomically(table *godog.Table) error {
+	return ctx.submitBulkOperations(table, true)
+}
+
+func (ctx *taskFeatureContext) submitBulkOperations(table *godog.Table, atomic bool) error {
+	header := table.Rows[0]
+	ops := make([]bulkOpRow, 0, len(table.Rows)-1)
+	for i := 1; i < len(table.Rows); i++ {
+		row := table.Rows[i]
+		var entry bulkOpRow
+		for j, cell := range row.Cells {
+			switch header.Cells[j].Value {
+			case "op":
+				entry.Op = cell.Value
+			case "id":
+				entry.ID = cell.Value
+			case "data":
+				if cell.Value != "" {
+					entry.Data = json.RawMessage(cell.Value)
+				}
+			}
+		}
+		ops = append(ops, entry)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"operations": ops})
+	if err != nil {
+		return err
+	}
+
+	url := "/api/v1/tasks/bulk"
+	if atomic {
+		url += "?atomic=true"
+	}
+	req, _ := http.NewRequest("POST", url, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	ctx.response = httptest.NewRecorder()
+	ctx.router.ServeHTTP(ctx.response, req)
+
+	return json.Unmarshal(ctx.response.Body.Bytes(), &ctx.lastBulkResult)
+}
+
+func (ctx *taskFeatureContext) theBulkResponseShouldContainResults(count int) error {
+	if len(ctx.lastBulkResult) != count {
+		return fmt.Errorf("expected %d bulk results, got %d", count, len(ctx.lastBulkResult))
+	}
+	return nil
+}
+
+func (ctx *taskFeatureContext) bulkResultShouldHaveStatus(index, status int) error {
+	if index < 0 || index >= len(ctx.lastBulkResult) {
+		return fmt.Errorf("no bulk result at index %d", index)
+	}
+	actual, _ := ctx.lastBulkResult[index]["status"].(float64)
+	if int(actual) != status {
+		return fmt.Errorf("expected bulk result %d to have status %d, got %d", index, status, int(actual))
+	}
+	return nil
+}
+
 func tableToMap(table *godog.Table) map[string]string {
 	result := make(map[string]string)
 	for i := 1; i < len(table.Rows); i++ {
@@ -568,6 +662,13 @@ func InitializeScenario(ctx *godog.ScenarioContext) {
 	// List and filter steps
 	ctx.Step(`^the following tasks exist:$`, feature.theFollowingTasksExist)
 	ctx.Step(`^I request all tasks$`, feature.iRequestAllTasks)
+	ctx.Step(`^I request all tasks with a (\d+)ms deadline$`, feature.iRequestAllTasksWithADeadline)
+
+	// Bulk operation steps
+	ctx.Step(`^I submit the following bulk task operations:$`, feature.iSubmitTheFollowingBulkTaskOperations)
+	ctx.Step(`^I submit the following bulk task operations atomically:$`, feature.iSubmitTheFollowingBulkTaskOperationsAtomically)
+	ctx.Step(`^the bulk response should contain (\d+) results$`, feature.theBulkResponseShouldContainResults)
+	ctx.Step(`^bulk result (\d+) should have status (\d+)$`, feature.bulkResultShouldHaveStatus)
 	ctx.Step(`^the response should contain (\d+) tasks$`, feature.theResponseShouldContainTasks)
 	ctx.Step(`^the response should contain (\d+) task$`, feature.theResponseShouldContainTasks)
 	ctx.Step(`^I request tasks with limit (\d+) and offset (\d+)$`, feature.iRequestTasksWithLimitAndOffset)
@@ -0,0 +1,88 @@
+package features
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cucumber/godog"
+	"github.com/gin-gonic/gin"
+
+	"github.com/f00b455/blank-go/internal/handlers"
+)
+
+type versionFeatureContext struct {
+	router       *gin.Engine
+	response     *httptest.ResponseRecorder
+	lastResponse map[string]interface{}
+}
+
+func (ctx *versionFeatureContext) reset() {
+	gin.SetMode(gin.TestMode)
+	ctx.router = gin.New()
+	ctx.router.GET("/api/v1/version", handlers.GetVersion)
+	ctx.response = nil
+	ctx.lastResponse = nil
+}
+
+func (ctx *versionFeatureContext) theAPIServerIsRunning() error {
+	return nil
+}
+
+func (ctx *versionFeatureContext) iRequestTheVersionEndpoint() error {
+	req, _ := http.NewRequest("GET", "/api/v1/version", nil)
+	ctx.response = httptest.NewRecorder()
+	ctx.router.ServeHTTP(ctx.response, req)
+
+	if ctx.response.Code == http.StatusOK {
+		if err := json.Unmarshal(ctx.response.Body.Bytes(), &ctx.lastResponse); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ctx *versionFeatureContext) theResponseStatusShouldBe(expected int) error {
+	if ctx.response.Code != expected {
+		return fmt.Errorf("expected status %d, got %d", expected, ctx.response.Code)
+	}
+	return nil
+}
+
+func (ctx *versionFeatureContext) theVersionResponseShouldContainNonEmptyFields(table *godog.Table) error {
+	if ctx.lastResponse == nil {
+		return fmt.Errorf("no response data available")
+	}
+
+	for i := 1; i < len(table.Rows); i++ {
+		field := table.Rows[i].Cells[0].Value
+		value, ok := ctx.lastResponse[field]
+		if !ok {
+			return fmt.Errorf("field %q not found in response", field)
+		}
+
+		str, ok := value.(string)
+		if !ok || str == "" {
+			return fmt.Errorf("field %q is empty or not a string", field)
+		}
+	}
+
+	return nil
+}
+
+func InitializeVersionScenario(sc *godog.ScenarioContext) {
+	ctx := &versionFeatureContext{}
+
+	sc.Before(func(c context.Context, s *godog.Scenario) (context.Context, error) {
+		ctx.reset()
+		return c, nil
+	})
+
+	sc.Step(`^the API server is running$`, ctx.theAPIServerIsRunning)
+	sc.Step(`^I request the version endpoint$`, ctx.iRequestTheVersionEndpoint)
+	sc.Step(`^the response status should be (\d+)$`, ctx.theResponseStatusShouldBe)
+	sc.Step(`^the version response should contain non-empty fields:$`, ctx.theVersionResponseShouldContainNonEmptyFields)
+}
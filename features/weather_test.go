@@ -31,10 +31,13 @@ type MockWeatherClient struct {
 	geocodeFunc        func(cityName string) (*weather.GeocodingResult, error)
 }
 
-func (m *MockWeatherClient) GetCurrentWeather(lat, lon float64) (*weather.WeatherResponse, error) {
+func (m *MockWeatherClient) GetCurrentWeather(ctx context.Context, lat, lon float64) (*weather.WeatherResponse, error) {
 	if m.currentWeatherFunc != nil {
 		return m.currentWeatherFunc(lat, lon)
 	}
+	dewpoint, pressureMsl, precip1h, precip24h := 9.8, 1013.2, 0.1, 2.4
+	humidityRelative, cloudCover, visibility := 65, 40, 10000.0
+	isDay := true
 	return &weather.WeatherResponse{
 		Location: weather.Location{
 			Latitude:  lat,
@@ -47,6 +50,14 @@ func (m *MockWeatherClient) GetCurrentWeather(lat, lon float64) (*weather.Weathe
 			WindSpeed:          12.5,
 			WeatherCode:        2,
 			WeatherDescription: "Partly cloudy",
+			Dewpoint:           &dewpoint,
+			PressureMsl:        &pressureMsl,
+			Precipitation1h:    &precip1h,
+			Precipitation24h:   &precip24h,
+			IsDay:              &isDay,
+			HumidityRelative:   &humidityRelative,
+			CloudCover:         &cloudCover,
+			Visibility:         &visibility,
 		},
 		Units: weather.Units{
 			Temperature: "Â°C",
@@ -56,13 +67,14 @@ func (m *MockWeatherClient) GetCurrentWeather(lat, lon float64) (*weather.Weathe
 	}, nil
 }
 
-func (m *MockWeatherClient) GetForecast(lat, lon float64, days int) (*weather.ForecastResponse, error) {
+func (m *MockWeatherClient) GetForecast(ctx context.Context, lat, lon float64, days int) (*weather.ForecastResponse, error) {
 	if m.forecastFunc != nil {
 		return m.forecastFunc(lat, lon, days)
 	}
 
 	forecast := make([]weather.ForecastDay, days)
 	for i := 0; i < days; i++ {
+		dewpointMax, dewpointMin, uvIndex := 11.4, 5.6, 3.2
 		forecast[i] = weather.ForecastDay{
 			Date:                     fmt.Sprintf("2025-01-%02d", 15+i),
 			TemperatureMax:           18.5,
@@ -70,6 +82,11 @@ func (m *MockWeatherClient) GetForecast(lat, lon float64, days int) (*weather.Fo
 			PrecipitationProbability: 20,
 			WeatherCode:              2,
 			WeatherDescription:       "Partly cloudy",
+			DewpointMax:              &dewpointMax,
+			DewpointMin:              &dewpointMin,
+			Sunrise:                  fmt.Sprintf("2025-01-%02dT08:00:00", 15+i),
+			Sunset:                   fmt.Sprintf("2025-01-%02dT16:30:00", 15+i),
+			UVIndex:                  &uvIndex,
 		}
 	}
 
@@ -83,7 +100,7 @@ func (m *MockWeatherClient) GetForecast(lat, lon float64, days int) (*weather.Fo
 	}, nil
 }
 
-func (m *MockWeatherClient) GeocodeCity(cityName string) (*weather.GeocodingResult, error) {
+func (m *MockWeatherClient) GeocodeCity(ctx context.Context, cityName string) (*weather.GeocodingResult, error) {
 	if m.geocodeFunc != nil {
 		return m.geocodeFunc(cityName)
 	}
@@ -100,11 +117,22 @@ func (m *MockWeatherClient) GeocodeCity(cityName string) (*weather.GeocodingResu
 	}, nil
 }
 
+func (m *MockWeatherClient) GetHourlyForecast(ctx context.Context, lat, lon float64, hours int) (*weather.HourlyForecastResponse, error) {
+	return &weather.HourlyForecastResponse{
+		Location: weather.Location{Latitude: lat, Longitude: lon},
+		Hourly:   []weather.HourlyForecastEntry{},
+	}, nil
+}
+
+func (m *MockWeatherClient) GetAlerts(ctx context.Context, lat, lon float64) ([]weather.Alert, error) {
+	return nil, nil
+}
+
 func (ctx *weatherFeatureContext) reset() {
 	gin.SetMode(gin.TestMode)
 	ctx.mockClient = &MockWeatherClient{}
-	ctx.service = weather.NewService(ctx.mockClient)
-	ctx.handler = handlers.NewWeatherHandler(ctx.service)
+	ctx.service = weather.NewServiceWithProvider(ctx.mockClient)
+	ctx.handler = handlers.NewWeatherHandler(ctx.service, nil)
 	ctx.setupRouter()
 
 	ctx.response = nil
@@ -221,6 +249,71 @@ func (ctx *weatherFeatureContext) theCurrentWeatherShouldIncludeWeatherDescripti
 	return nil
 }
 
+func (ctx *weatherFeatureContext) theCurrentWeatherShouldIncludeDewpoint() error {
+	current := ctx.lastResponse["current"].(map[string]interface{})
+	if _, ok := current["dewpoint"]; !ok {
+		return fmt.Errorf("current weather missing dewpoint")
+	}
+	return nil
+}
+
+func (ctx *weatherFeatureContext) theCurrentWeatherShouldIncludePressureMsl() error {
+	current := ctx.lastResponse["current"].(map[string]interface{})
+	if _, ok := current["pressure_msl"]; !ok {
+		return fmt.Errorf("current weather missing pressure_msl")
+	}
+	return nil
+}
+
+func (ctx *weatherFeatureContext) theCurrentWeatherShouldIncludeIsDay() error {
+	current := ctx.lastResponse["current"].(map[string]interface{})
+	if _, ok := current["is_day"]; !ok {
+		return fmt.Errorf("current weather missing is_day")
+	}
+	return nil
+}
+
+func (ctx *weatherFeatureContext) theCurrentWeatherShouldIncludeCloudCover() error {
+	current := ctx.lastResponse["current"].(map[string]interface{})
+	if _, ok := current["cloud_cover"]; !ok {
+		return fmt.Errorf("current weather missing cloud_cover")
+	}
+	return nil
+}
+
+func (ctx *weatherFeatureContext) theCurrentWeatherShouldIncludeVisibility() error {
+	current := ctx.lastResponse["current"].(map[string]interface{})
+	if _, ok := current["visibility"]; !ok {
+		return fmt.Errorf("current weather missing visibility")
+	}
+	return nil
+}
+
+func (ctx *weatherFeatureContext) eachForecastDayShouldIncludeUVIndex() error {
+	forecast := ctx.lastResponse["forecast"].([]interface{})
+	for i, day := range forecast {
+		dayMap := day.(map[string]interface{})
+		if _, ok := dayMap["uv_index"]; !ok {
+			return fmt.Errorf("forecast day %d missing uv_index", i)
+		}
+	}
+	return nil
+}
+
+func (ctx *weatherFeatureContext) eachForecastDayShouldIncludeSunriseAndSunset() error {
+	forecast := ctx.lastResponse["forecast"].([]interface{})
+	for i, day := range forecast {
+		dayMap := day.(map[string]interface{})
+		if _, ok := dayMap["sunrise"]; !ok {
+			return fmt.Errorf("forecast day %d missing sunrise", i)
+		}
+		if _, ok := dayMap["sunset"]; !ok {
+			return fmt.Errorf("forecast day %d missing sunset", i)
+		}
+	}
+	return nil
+}
+
 func (ctx *weatherFeatureContext) theUnitsShouldSpecifyForTemperature(unit string) error {
 	units := ctx.lastResponse["units"].(map[string]interface{})
 	if units["temperature"] != unit {
@@ -392,6 +485,11 @@ func InitializeWeatherScenario(sc *godog.ScenarioContext) {
 	sc.Step(`^the current weather should include humidity$`, ctx.theCurrentWeatherShouldIncludeHumidity)
 	sc.Step(`^the current weather should include wind speed$`, ctx.theCurrentWeatherShouldIncludeWindSpeed)
 	sc.Step(`^the current weather should include weather description$`, ctx.theCurrentWeatherShouldIncludeWeatherDescription)
+	sc.Step(`^the current weather should include dewpoint$`, ctx.theCurrentWeatherShouldIncludeDewpoint)
+	sc.Step(`^the current weather should include pressure msl$`, ctx.theCurrentWeatherShouldIncludePressureMsl)
+	sc.Step(`^the current weather should include is day$`, ctx.theCurrentWeatherShouldIncludeIsDay)
+	sc.Step(`^the current weather should include cloud cover$`, ctx.theCurrentWeatherShouldIncludeCloudCover)
+	sc.Step(`^the current weather should include visibility$`, ctx.theCurrentWeatherShouldIncludeVisibility)
 	sc.Step(`^the units should specify "([^"]*)" for temperature$`, ctx.theUnitsShouldSpecifyForTemperature)
 	sc.Step(`^the units should specify "([^"]*)" for wind speed$`, ctx.theUnitsShouldSpecifyForWindSpeed)
 	sc.Step(`^the units should specify "([^"]*)" for humidity$`, ctx.theUnitsShouldSpecifyForHumidity)
@@ -404,6 +502,8 @@ func InitializeWeatherScenario(sc *godog.ScenarioContext) {
 	sc.Step(`^each forecast day should include min temperature$`, ctx.eachForecastDayShouldIncludeMinTemperature)
 	sc.Step(`^each forecast day should include precipitation probability$`, ctx.eachForecastDayShouldIncludePrecipitationProbability)
 	sc.Step(`^each forecast day should include weather description$`, ctx.eachForecastDayShouldIncludeWeatherDescription)
+	sc.Step(`^each forecast day should include UV index$`, ctx.eachForecastDayShouldIncludeUVIndex)
+	sc.Step(`^each forecast day should include sunrise and sunset$`, ctx.eachForecastDayShouldIncludeSunriseAndSunset)
 
 	// City weather validation steps
 	sc.Step(`^the location should include city name "([^"]*)"$`, ctx.theLocationShouldIncludeCityName)
@@ -0,0 +1,86 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	argon2Time    = 1
+	argon2Memory  = 64 * 1024
+	argon2Threads = 4
+	argon2KeyLen  = 32
+	saltLen       = 16
+	plaintextLen  = 32
+)
+
+// generatePlaintextKey returns a new, randomly generated API key value,
+// base64url-encoded so it's safe to put directly in the X-API-Key header.
+func generatePlaintextKey() (string, error) {
+	buf := make([]byte, plaintextLen)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("apikey: generating key: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// lookupHash returns the fast, deterministic digest CreateKey indexes
+// api_keys.lookup_hash by and AuthenticateAPIKey looks candidate rows up
+// with. It is not itself a credential check - hashArgon2id/verifyArgon2id
+// does that - just a way to find the row without a full-table scan.
+func lookupHash(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+// hashArgon2id derives an argon2id digest of plaintext with a fresh
+// random salt, encoded as "<params>$<salt>$<hash>" (base64url, no
+// padding) so verifyArgon2id can recover the exact parameters and salt
+// used.
+func hashArgon2id(plaintext string) (string, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("apikey: generating salt: %w", err)
+	}
+	sum := argon2.IDKey([]byte(plaintext), salt, argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+
+	return fmt.Sprintf("%d,%d,%d$%s$%s",
+		argon2Time, argon2Memory, argon2Threads,
+		base64.RawURLEncoding.EncodeToString(salt),
+		base64.RawURLEncoding.EncodeToString(sum),
+	), nil
+}
+
+// verifyArgon2id reports whether plaintext matches encoded, a digest
+// previously produced by hashArgon2id. Comparison is constant-time over
+// the derived key to avoid leaking timing information about a near miss.
+func verifyArgon2id(encoded, plaintext string) bool {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 3 {
+		return false
+	}
+
+	var time, memory uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[0], "%d,%d,%d", &time, &memory, &threads); err != nil {
+		return false
+	}
+	salt, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return false
+	}
+	want, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+
+	got := argon2.IDKey([]byte(plaintext), salt, time, memory, threads, uint32(len(want)))
+	return subtle.ConstantTimeCompare(got, want) == 1
+}
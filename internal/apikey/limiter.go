@@ -0,0 +1,114 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenBucket is a simple token-bucket rate limiter: tokens refill
+// continuously at rate per second up to burst, and each request consumes
+// one. Mirrors pkg/weather's tokenBucket (itself mirroring
+// pkg/stocks/resilience.go's).
+type tokenBucket struct {
+	mu         sync.Mutex
+	rate       float64
+	burst      float64
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newTokenBucket(ratePerMinute int) *tokenBucket {
+	burst := float64(ratePerMinute)
+	if burst <= 0 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:       burst / 60,
+		burst:      burst,
+		tokens:     burst,
+		lastRefill: time.Now(),
+	}
+}
+
+func (b *tokenBucket) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	b.lastRefill = now
+	b.tokens = math.Min(b.burst, b.tokens+elapsed*b.rate)
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// MemoryLimiter implements middleware.Limiter with one tokenBucket per
+// key ID, suitable for a single API replica. Multi-replica deployments
+// should use RedisLimiter instead, so every replica meters the same
+// caller against a shared counter.
+type MemoryLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+// NewMemoryLimiter creates an empty MemoryLimiter.
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{buckets: make(map[string]*tokenBucket)}
+}
+
+// Allow implements middleware.Limiter.
+func (l *MemoryLimiter) Allow(ctx context.Context, keyID string, rateLimitPerMin int) (bool, error) {
+	l.mu.Lock()
+	bucket, ok := l.buckets[keyID]
+	if !ok {
+		bucket = newTokenBucket(rateLimitPerMin)
+		l.buckets[keyID] = bucket
+	}
+	l.mu.Unlock()
+
+	return bucket.allow(), nil
+}
+
+// RedisLimiter implements middleware.Limiter as a fixed-window counter
+// in Redis, shared across every API replica: each call does an atomic
+// INCR against a key scoped to the caller and the current minute,
+// setting its expiry on first use so the window resets itself.
+type RedisLimiter struct {
+	client *redis.Client
+}
+
+// NewRedisLimiter creates a RedisLimiter against client.
+func NewRedisLimiter(client *redis.Client) *RedisLimiter {
+	return &RedisLimiter{client: client}
+}
+
+// Allow implements middleware.Limiter.
+func (l *RedisLimiter) Allow(ctx context.Context, keyID string, rateLimitPerMin int) (bool, error) {
+	if rateLimitPerMin <= 0 {
+		return true, nil
+	}
+
+	window := time.Now().UTC().Truncate(time.Minute).Unix()
+	redisKey := fmt.Sprintf("apikey:ratelimit:%s:%d", keyID, window)
+
+	count, err := l.client.Incr(ctx, redisKey).Result()
+	if err != nil {
+		return false, fmt.Errorf("apikey: redis rate limit check failed: %w", err)
+	}
+	if count == 1 {
+		if err := l.client.Expire(ctx, redisKey, time.Minute).Err(); err != nil {
+			return false, fmt.Errorf("apikey: redis rate limit expiry failed: %w", err)
+		}
+	}
+
+	return count <= int64(rateLimitPerMin), nil
+}
@@ -0,0 +1,44 @@
+package apikey
+
+import (
+	"strings"
+	"time"
+)
+
+// APIKey is the persisted record backing an issued API key. The
+// plaintext key itself is never stored: LookupHash is a fast, indexed
+// SHA-256 digest used to find the candidate row, and Hash is an argon2id
+// digest verified against it afterwards, so a stolen database dump alone
+// can't be replayed as a credential.
+type APIKey struct {
+	ID              string `gorm:"type:varchar(36);primary_key"`
+	Name            string `gorm:"type:varchar(255);not null"`
+	LookupHash      string `gorm:"column:lookup_hash;type:varchar(64);uniqueIndex;not null"`
+	Hash            string `gorm:"column:hash;type:varchar(255);not null"`
+	ScopesCSV       string `gorm:"column:scopes;type:varchar(255);not null"`
+	RateLimitPerMin int    `gorm:"column:rate_limit_per_min;not null;default:60"`
+	// RevokedAt marks a key unusable (by either credential form) without
+	// deleting its row, preserving it for audit trails.
+	RevokedAt *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName implements gorm's Tabler.
+func (APIKey) TableName() string { return "api_keys" }
+
+// Scopes splits ScopesCSV back into the list CreateKey was given.
+func (k *APIKey) Scopes() []string {
+	return splitScopes(k.ScopesCSV)
+}
+
+func joinScopes(scopes []string) string {
+	return strings.Join(scopes, ",")
+}
+
+func splitScopes(csv string) []string {
+	if csv == "" {
+		return nil
+	}
+	return strings.Split(csv, ",")
+}
@@ -0,0 +1,119 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/f00b455/blank-go/internal/middleware"
+)
+
+// ErrMigrationsPending mirrors weather/postgres.ErrMigrationsPending and
+// execution.Store's equivalent: NewStore fast-fails instead of returning
+// a Store that would 500 on first use.
+var ErrMigrationsPending = errors.New("apikey: api_keys table not found: run AutoMigrate before starting the server")
+
+// ErrInvalidCredentials is returned by AuthenticateAPIKey and
+// AuthenticateToken when the presented credential doesn't resolve to an
+// active key, mirroring internal/auth.ErrInvalidCredentials.
+var ErrInvalidCredentials = errors.New("apikey: invalid or revoked credential")
+
+// Store persists API keys and implements middleware.KeyStore against
+// them, plus issues the short-lived JWTs POST /api/v1/auth/token
+// exchanges a key for.
+type Store struct {
+	db         *gorm.DB
+	signingKey []byte
+	tokenTTL   time.Duration
+}
+
+// NewStore creates a Store backed by db, signing tokens with signingKey
+// and issuing them valid for tokenTTL. It fails fast if the api_keys
+// table hasn't been migrated yet, or if signingKey is empty.
+func NewStore(db *gorm.DB, signingKey []byte, tokenTTL time.Duration) (*Store, error) {
+	if !db.Migrator().HasTable(&APIKey{}) {
+		return nil, ErrMigrationsPending
+	}
+	if len(signingKey) == 0 {
+		return nil, errors.New("apikey: a non-empty token signing key is required")
+	}
+	return &Store{db: db, signingKey: signingKey, tokenTTL: tokenTTL}, nil
+}
+
+// AutoMigrate creates or updates the api_keys table for db.
+func AutoMigrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(&APIKey{}); err != nil {
+		return fmt.Errorf("failed to migrate api_keys schema: %w", err)
+	}
+	return nil
+}
+
+// CreateKey registers a new API key named name, scoped to scopes and
+// capped at rateLimitPerMin requests per minute, returning the one-time
+// plaintext value the caller must save - Store never stores or returns
+// it again, only its hashes.
+func (s *Store) CreateKey(ctx context.Context, name string, scopes []string, rateLimitPerMin int) (plaintext string, record *APIKey, err error) {
+	plaintext, err = generatePlaintextKey()
+	if err != nil {
+		return "", nil, err
+	}
+	hash, err := hashArgon2id(plaintext)
+	if err != nil {
+		return "", nil, err
+	}
+
+	record = &APIKey{
+		ID:              uuid.New().String(),
+		Name:            name,
+		LookupHash:      lookupHash(plaintext),
+		Hash:            hash,
+		ScopesCSV:       joinScopes(scopes),
+		RateLimitPerMin: rateLimitPerMin,
+	}
+	if err := s.db.WithContext(ctx).Create(record).Error; err != nil {
+		return "", nil, fmt.Errorf("apikey: creating key: %w", err)
+	}
+	return plaintext, record, nil
+}
+
+// AuthenticateAPIKey implements middleware.KeyStore for a raw X-API-Key
+// header value.
+func (s *Store) AuthenticateAPIKey(ctx context.Context, plaintext string) (*middleware.AuthenticatedKey, error) {
+	var row APIKey
+	err := s.db.WithContext(ctx).
+		Where("lookup_hash = ? AND revoked_at IS NULL", lookupHash(plaintext)).
+		First(&row).Error
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	if !verifyArgon2id(row.Hash, plaintext) {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &middleware.AuthenticatedKey{
+		ID:              row.ID,
+		Scopes:          row.Scopes(),
+		RateLimitPerMin: row.RateLimitPerMin,
+	}, nil
+}
+
+// IssueToken exchanges plaintext for a short-lived JWT encoding the key's
+// identity, scopes, and rate limit, for POST /api/v1/auth/token.
+func (s *Store) IssueToken(ctx context.Context, plaintext string) (string, error) {
+	var row APIKey
+	err := s.db.WithContext(ctx).
+		Where("lookup_hash = ? AND revoked_at IS NULL", lookupHash(plaintext)).
+		First(&row).Error
+	if err != nil {
+		return "", ErrInvalidCredentials
+	}
+	if !verifyArgon2id(row.Hash, plaintext) {
+		return "", ErrInvalidCredentials
+	}
+
+	return s.signToken(&row)
+}
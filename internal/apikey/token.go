@@ -0,0 +1,67 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v4"
+	josejwt "github.com/go-jose/go-jose/v4/jwt"
+
+	"github.com/f00b455/blank-go/internal/middleware"
+)
+
+// tokenClaims is the JWT payload signToken mints and AuthenticateToken
+// verifies: the standard registered claims plus the key identity
+// RateLimit and scope checks need, so verifying a token never requires a
+// database round-trip.
+type tokenClaims struct {
+	josejwt.Claims
+	Scopes          []string `json:"scopes"`
+	RateLimitPerMin int      `json:"rate_limit_per_min"`
+}
+
+func (s *Store) signToken(key *APIKey) (string, error) {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: s.signingKey}, nil)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := tokenClaims{
+		Claims: josejwt.Claims{
+			Subject:  key.ID,
+			IssuedAt: josejwt.NewNumericDate(now),
+			Expiry:   josejwt.NewNumericDate(now.Add(s.tokenTTL)),
+		},
+		Scopes:          key.Scopes(),
+		RateLimitPerMin: key.RateLimitPerMin,
+	}
+	return josejwt.Signed(signer).Claims(claims).Serialize()
+}
+
+// AuthenticateToken implements middleware.KeyStore for a bearer JWT
+// minted by signToken. It verifies the signature against s.signingKey
+// and the expiry claim, without a database lookup - a revoked key's
+// already-issued tokens remain valid until they expire, the same
+// trade-off short-lived-token systems like crowdsec's apiclient accept
+// in exchange for not hitting the store on every request.
+func (s *Store) AuthenticateToken(ctx context.Context, rawToken string) (*middleware.AuthenticatedKey, error) {
+	tok, err := josejwt.ParseSigned(rawToken, []jose.SignatureAlgorithm{jose.HS256})
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	var claims tokenClaims
+	if err := tok.Claims(s.signingKey, &claims); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	if err := claims.Claims.Validate(josejwt.Expected{Time: time.Now()}); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &middleware.AuthenticatedKey{
+		ID:              claims.Subject,
+		Scopes:          claims.Scopes,
+		RateLimitPerMin: claims.RateLimitPerMin,
+	}, nil
+}
@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeProvider lets tests control Authenticate's return value without
+// depending on BasicProvider or OIDCProvider's actual verification logic.
+type fakeProvider struct {
+	principal *Principal
+	err       error
+}
+
+func (f *fakeProvider) Authenticate(c *gin.Context) (*Principal, error) {
+	return f.principal, f.err
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("first provider accepts", func(t *testing.T) {
+		router := gin.New()
+		router.Use(Middleware(&fakeProvider{principal: &Principal{Subject: "alice", Provider: "fake"}}))
+		router.GET("/test", func(c *gin.Context) {
+			principal, ok := FromContext(c)
+			assert.True(t, ok)
+			assert.Equal(t, "alice", principal.Subject)
+			c.Status(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("falls through to the next provider", func(t *testing.T) {
+		router := gin.New()
+		router.Use(Middleware(
+			&fakeProvider{principal: nil, err: nil},
+			&fakeProvider{principal: &Principal{Subject: "bob", Provider: "fake"}},
+		))
+		router.GET("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("invalid credentials reject immediately", func(t *testing.T) {
+		router := gin.New()
+		router.Use(Middleware(
+			&fakeProvider{err: ErrInvalidCredentials},
+			&fakeProvider{principal: &Principal{Subject: "bob", Provider: "fake"}},
+		))
+		router.GET("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("no provider recognizes the request", func(t *testing.T) {
+		router := gin.New()
+		router.Use(Middleware(&fakeProvider{}))
+		router.GET("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
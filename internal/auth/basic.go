@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BasicProvider authenticates requests against a single static username
+// and bcrypt-hashed password, the htpasswd-style credential pair
+// config.AuthConfig.BasicUsername/BasicPasswordHash hold. It's meant for
+// service-to-service or operator access, not end users - there's no
+// per-user store, just the one configured pair.
+type BasicProvider struct {
+	username     string
+	passwordHash []byte
+}
+
+// NewBasicProvider creates a BasicProvider that accepts username paired
+// with the bcrypt hash passwordHash (as produced by
+// golang.org/x/crypto/bcrypt.GenerateFromPassword, the same format
+// htpasswd's bcrypt mode writes).
+func NewBasicProvider(username, passwordHash string) *BasicProvider {
+	return &BasicProvider{username: username, passwordHash: []byte(passwordHash)}
+}
+
+// Authenticate implements Provider. It returns (nil, nil) when the request
+// has no Basic auth header at all, so Middleware falls through to the next
+// configured provider instead of rejecting tokens meant for OIDCProvider.
+func (p *BasicProvider) Authenticate(c *gin.Context) (*Principal, error) {
+	username, password, ok := c.Request.BasicAuth()
+	if !ok {
+		return nil, nil
+	}
+
+	if username != p.username {
+		return nil, ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword(p.passwordHash, []byte(password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &Principal{Subject: username, Provider: "basic"}, nil
+}
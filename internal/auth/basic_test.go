@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newTestGinContext(req *http.Request) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = req
+	return c
+}
+
+func TestBasicProvider_Authenticate(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.MinCost)
+	require.NoError(t, err)
+	provider := NewBasicProvider("admin", string(hash))
+
+	t.Run("no authorization header", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/tasks", nil)
+		principal, err := provider.Authenticate(newTestGinContext(req))
+		require.NoError(t, err)
+		assert.Nil(t, principal)
+	})
+
+	t.Run("correct credentials", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/tasks", nil)
+		req.SetBasicAuth("admin", "s3cret")
+		principal, err := provider.Authenticate(newTestGinContext(req))
+		require.NoError(t, err)
+		require.NotNil(t, principal)
+		assert.Equal(t, "admin", principal.Subject)
+		assert.Equal(t, "basic", principal.Provider)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/tasks", nil)
+		req.SetBasicAuth("admin", "wrong")
+		_, err := provider.Authenticate(newTestGinContext(req))
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("wrong username", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/tasks", nil)
+		req.SetBasicAuth("someone-else", "s3cret")
+		_, err := provider.Authenticate(newTestGinContext(req))
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+}
@@ -1,14 +1,82 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"log"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
+// Config aggregates every subsystem's settings, loaded in increasing
+// precedence by LoadFrom: compiled-in defaults, a base config.yaml, an
+// optional per-environment overlay, then environment variable overrides.
 type Config struct {
-	Port        string
-	Environment string
-	Database    DatabaseConfig
+	Server        ServerConfig        `yaml:"server"`
+	Environment   string              `yaml:"environment"`
+	Database      DatabaseConfig      `yaml:"database"`
+	Redis         RedisConfig         `yaml:"redis"`
+	Dax           DaxConfig           `yaml:"dax"`
+	Migrations    MigrationsConfig    `yaml:"migrations"`
+	Portfolio     PortfolioConfig     `yaml:"portfolio"`
+	Alerts        AlertsConfig        `yaml:"alerts"`
+	Task          TaskConfig          `yaml:"task"`
+	Auth          AuthConfig          `yaml:"auth"`
+	Pagination    PaginationConfig    `yaml:"pagination"`
+	Observability ObservabilityConfig `yaml:"observability"`
+	Weather       WeatherConfig       `yaml:"weather"`
+	Stocks        StocksConfig        `yaml:"stocks"`
+	Executions    ExecutionsConfig    `yaml:"executions"`
+	Log           LogConfig           `yaml:"log"`
+}
+
+// Validate runs every subsystem's Validate, returning the first error
+// encountered (in the field order above).
+func (c *Config) Validate() error {
+	for _, err := range []error{
+		c.Server.Validate(),
+		c.Database.Validate(),
+		c.Stocks.Validate(),
+		c.Executions.Validate(),
+		c.Migrations.Validate(),
+		c.Auth.Validate(),
+		c.Task.Validate(),
+		c.Log.Validate(),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ServerConfig configures the HTTP server cmd/api/main.go listens with.
+type ServerConfig struct {
+	// Port is the TCP port the API listens on.
+	Port string `yaml:"port"`
+	// ReadTimeoutSeconds bounds how long reading a request (including its
+	// body) may take before the server aborts it.
+	ReadTimeoutSeconds int `yaml:"read_timeout_seconds"`
+	// WriteTimeoutSeconds bounds how long writing a response may take.
+	WriteTimeoutSeconds int `yaml:"write_timeout_seconds"`
+	// IdleTimeoutSeconds bounds how long a keep-alive connection may sit
+	// idle between requests.
+	IdleTimeoutSeconds int `yaml:"idle_timeout_seconds"`
+}
+
+// Validate fails fast if Port is unset, since http.Server can't listen
+// without one.
+func (s *ServerConfig) Validate() error {
+	if s.Port == "" {
+		return errors.New("config: server.port is required")
+	}
+	return nil
 }
 
 type DatabaseConfig struct {
@@ -18,31 +86,739 @@ type DatabaseConfig struct {
 	Password string
 	Name     string
 	SSLMode  string
+	// QueryTimeout bounds how long dax.TimeoutRepository lets a single
+	// repository call run before its context is canceled. Zero disables
+	// the timeout decorator entirely (see instrumentedDAXRepository).
+	QueryTimeout time.Duration
 }
 
-func Load() *Config {
+// rawDatabaseConfig mirrors DatabaseConfig for YAML decoding, with
+// QueryTimeout written out as a whole number of seconds (query_timeout_seconds)
+// rather than a Go time.Duration, matching the _SECONDS convention this
+// package already uses for its environment variables.
+type rawDatabaseConfig struct {
+	Host                string `yaml:"host"`
+	Port                string `yaml:"port"`
+	User                string `yaml:"user"`
+	Password            string `yaml:"password"`
+	Name                string `yaml:"name"`
+	SSLMode             string `yaml:"sslmode"`
+	QueryTimeoutSeconds int    `yaml:"query_timeout_seconds"`
+}
+
+// UnmarshalYAML decodes only the fields value sets, leaving the rest of d
+// (its pre-merge value) untouched - the same partial-overlay behavior
+// yaml.Unmarshal gives every other Config field for free.
+func (d *DatabaseConfig) UnmarshalYAML(value *yaml.Node) error {
+	raw := rawDatabaseConfig{
+		Host:                d.Host,
+		Port:                d.Port,
+		User:                d.User,
+		Password:            d.Password,
+		Name:                d.Name,
+		SSLMode:             d.SSLMode,
+		QueryTimeoutSeconds: int(d.QueryTimeout.Seconds()),
+	}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	d.Host = raw.Host
+	d.Port = raw.Port
+	d.User = raw.User
+	d.Password = raw.Password
+	d.Name = raw.Name
+	d.SSLMode = raw.SSLMode
+	d.QueryTimeout = time.Duration(raw.QueryTimeoutSeconds) * time.Second
+	return nil
+}
+
+// Validate fails fast on the fields DSN/MigrateDSN need to build a usable
+// connection string.
+func (d *DatabaseConfig) Validate() error {
+	if d.Host == "" {
+		return errors.New("config: database.host is required")
+	}
+	if d.Port == "" {
+		return errors.New("config: database.port is required")
+	}
+	if d.Name == "" {
+		return errors.New("config: database.name is required")
+	}
+	return nil
+}
+
+// RedisConfig configures the optional Redis-backed stocks cache. When
+// Enabled is false, callers should fall back to the in-memory cache.
+type RedisConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	Addr     string `yaml:"addr"`
+	Password string `yaml:"password"`
+	DB       int    `yaml:"db"`
+}
+
+// DaxConfig configures DAX-specific behavior.
+type DaxConfig struct {
+	// AliasResolutionEnabled controls whether ticker aliases (e.g. "SIEGY"
+	// resolving to "SIE") are applied during import and queries. Callers
+	// that want raw, unresolved ticker values can disable it.
+	AliasResolutionEnabled bool `yaml:"alias_resolution_enabled"`
+	// ImportIdempotencyTTLSeconds is how long an Idempotency-Key submitted
+	// to POST /dax/imports dedupes repeated submissions before a new job
+	// is started for the same key.
+	ImportIdempotencyTTLSeconds int `yaml:"import_idempotency_ttl_seconds"`
+}
+
+// MigrationsConfig controls how the DAX Postgres schema is brought up to
+// date at startup.
+type MigrationsConfig struct {
+	// AutoApply runs internal/migrations.Up against
+	// DatabaseConfig.MigrateDSN before the server starts accepting
+	// requests, instead of the legacy dax.AutoMigrate GORM bring-up.
+	AutoApply bool `yaml:"auto_apply"`
+}
+
+// Validate always succeeds: MigrationsConfig has no required fields.
+func (MigrationsConfig) Validate() error { return nil }
+
+// PortfolioConfig configures the portfolio alerting subsystem.
+type PortfolioConfig struct {
+	// AlertWebhookURL receives a JSON POST for every fired alert. If empty,
+	// alerts are still recorded but not delivered anywhere.
+	AlertWebhookURL string `yaml:"alert_webhook_url"`
+}
+
+// AlertsConfig configures pkg/alerts, the cross-source (stocks + DAX)
+// threshold-rule subsystem.
+type AlertsConfig struct {
+	// FCMProjectID is the Firebase project rule firings are pushed to via
+	// alerts.FCMNotifier. If empty, firings are still evaluated and logged
+	// but not delivered to any device.
+	FCMProjectID string `yaml:"fcm_project_id"`
+	// EvaluationIntervalSeconds is how often alerts.Scheduler re-checks
+	// every registered rule.
+	EvaluationIntervalSeconds int `yaml:"evaluation_interval_seconds"`
+}
+
+// TaskConfig configures the task API.
+type TaskConfig struct {
+	// RequestTimeoutSeconds bounds how long a single task request may run
+	// before middleware.RequestTimeout cancels its context and the handler
+	// responds 504 Gateway Timeout.
+	RequestTimeoutSeconds int `yaml:"request_timeout_seconds"`
+	// EventRingBufferSize bounds how many recent task mutation events
+	// task.Service retains for a reconnecting /tasks/events SSE client to
+	// replay via Last-Event-ID.
+	EventRingBufferSize int `yaml:"event_ring_buffer_size"`
+	// ExecutionMaxAttempts bounds how many times task.ExecutionManager
+	// runs a Task's Executor (the initial attempt plus retries) before
+	// marking the Execution failed.
+	ExecutionMaxAttempts int `yaml:"execution_max_attempts"`
+	// ExecutionRetryBaseDelaySeconds is the backoff task.ExecutionManager
+	// waits before the first retry, doubled on each subsequent one.
+	ExecutionRetryBaseDelaySeconds int `yaml:"execution_retry_base_delay_seconds"`
+	// ScheduleSyncSpec is the robfig/cron spec on which task.Scheduler
+	// re-reads every Task's Schedule field and syncs its cron entries.
+	ScheduleSyncSpec string `yaml:"schedule_sync_spec"`
+	// Driver selects which task.Repository implementation cmd/api/main.go
+	// constructs: "memory" (the default), "sqlite", or "postgres".
+	Driver string `yaml:"driver"`
+	// SQLitePath is the database file pkg/task/sqlite.Open opens when
+	// Driver is "sqlite". The special value ":memory:" opens a private,
+	// in-process database instead of a file.
+	SQLitePath string `yaml:"sqlite_path"`
+	// PostgresURL is the connection string pkg/task/postgres connects
+	// with when Driver is "postgres".
+	PostgresURL string `yaml:"postgres_url"`
+}
+
+// Validate checks that Driver names a known task.Repository implementation
+// and that the fields it needs are set.
+func (t *TaskConfig) Validate() error {
+	switch t.Driver {
+	case "", "memory":
+		return nil
+	case "sqlite":
+		if t.SQLitePath == "" {
+			return errors.New("config: task.sqlite_path is required when task.driver is \"sqlite\"")
+		}
+	case "postgres":
+		if t.PostgresURL == "" {
+			return errors.New("config: task.postgres_url is required when task.driver is \"postgres\"")
+		}
+	default:
+		return fmt.Errorf("config: task.driver: unknown driver %q", t.Driver)
+	}
+	return nil
+}
+
+// AuthConfig configures the pluggable authentication chain
+// internal/auth.Middleware installs in front of the task API's mutating
+// endpoints.
+type AuthConfig struct {
+	// Providers lists which internal/auth.Provider implementations the
+	// middleware chains, in order; the first to recognize the request's
+	// credentials wins. Valid values are "basic" and "oidc". Empty leaves
+	// the mutating task routes unauthenticated, the way they were before
+	// this config block existed.
+	Providers []string `yaml:"providers"`
+	// BasicUsername and BasicPasswordHash are the single static
+	// credential pair the "basic" provider accepts. PasswordHash is a
+	// bcrypt hash (as produced by golang.org/x/crypto/bcrypt), never a
+	// plaintext secret.
+	BasicUsername     string `yaml:"basic_username"`
+	BasicPasswordHash string `yaml:"basic_password_hash"`
+	// OIDCIssuerURL is the OpenID Connect issuer the "oidc" provider
+	// discovers its JWKS from.
+	OIDCIssuerURL string `yaml:"oidc_issuer_url"`
+	// OIDCAudience is the "aud" claim every bearer token the "oidc"
+	// provider accepts must contain.
+	OIDCAudience string `yaml:"oidc_audience"`
+	// APIKeysEnabled gates the machine-authentication middleware
+	// (internal/middleware.APIKeyAuth/RateLimit) in front of
+	// /api/v1/weather/*. Disabled by default so those routes stay open
+	// the way they were before API keys existed.
+	APIKeysEnabled bool `yaml:"api_keys_enabled"`
+	// TokenSigningKey is the HMAC secret internal/apikey.Store signs and
+	// verifies POST /api/v1/auth/token's JWTs with. Required when
+	// APIKeysEnabled is true.
+	TokenSigningKey string `yaml:"token_signing_key"`
+	// TokenTTLSeconds bounds how long a token issued by
+	// POST /api/v1/auth/token remains valid.
+	TokenTTLSeconds int `yaml:"token_ttl_seconds"`
+}
+
+// Validate fails fast if Providers names "basic" or "oidc" without the
+// settings that provider needs to start, since internal/auth would
+// otherwise fail at first request (or, for "oidc", at the discovery call
+// NewOIDCProvider makes during startup) instead of at config load time.
+func (a *AuthConfig) Validate() error {
+	for _, p := range a.Providers {
+		switch p {
+		case "basic":
+			if a.BasicUsername == "" || a.BasicPasswordHash == "" {
+				return errors.New("config: auth.basic_username and auth.basic_password_hash are required when auth.providers includes \"basic\"")
+			}
+		case "oidc":
+			if a.OIDCIssuerURL == "" || a.OIDCAudience == "" {
+				return errors.New("config: auth.oidc_issuer_url and auth.oidc_audience are required when auth.providers includes \"oidc\"")
+			}
+		default:
+			return fmt.Errorf("config: auth.providers: unknown provider %q", p)
+		}
+	}
+	if a.APIKeysEnabled && a.TokenSigningKey == "" {
+		return errors.New("config: auth.token_signing_key is required when auth.api_keys_enabled is true")
+	}
+	return nil
+}
+
+// PaginationConfig configures cursor-based pagination shared by the task
+// and DAX list endpoints.
+type PaginationConfig struct {
+	// CursorSigningKey signs opaque pagination cursors so a tampered or
+	// forged cursor value is rejected instead of silently resuming from
+	// the wrong position. Set a long random value in production; the
+	// default is fine for local development only.
+	CursorSigningKey string `yaml:"cursor_signing_key"`
+}
+
+// ObservabilityConfig controls which Repository middleware decorators
+// (see pkg/dax's LoggingRepository/MetricsRepository/TracingRepository)
+// the DI bootstrap wraps the DAX repository in.
+type ObservabilityConfig struct {
+	// DAXRepositoryLoggingEnabled wraps the DAX repository in
+	// dax.LoggingRepository, logging every call's duration and outcome.
+	DAXRepositoryLoggingEnabled bool `yaml:"dax_repository_logging_enabled"`
+	// DAXRepositoryMetricsEnabled wraps the DAX repository in
+	// dax.MetricsRepository, publishing per-call Prometheus metrics
+	// alongside the ones middleware.Metrics already records for HTTP
+	// requests.
+	DAXRepositoryMetricsEnabled bool `yaml:"dax_repository_metrics_enabled"`
+	// DAXRepositoryTracingEnabled wraps the DAX repository in
+	// dax.TracingRepository, emitting an OpenTelemetry span per call.
+	// Disabled by default since this tree has no span exporter configured,
+	// so the spans it would emit go nowhere.
+	DAXRepositoryTracingEnabled bool `yaml:"dax_repository_tracing_enabled"`
+}
+
+// WeatherConfig selects and configures the weather.Provider backend.
+type WeatherConfig struct {
+	// Provider selects the primary backend by name: "open-meteo" (default,
+	// no credentials required), "nws" (US-only, requires NWSUserAgent),
+	// "openweathermap" (requires OpenWeatherMapAPIKey),
+	// "worldweatheronline" (requires WorldWeatherOnlineAPIKey), or
+	// "meteologix" (requires MeteologixAPIKey). An unrecognized value falls
+	// back to "open-meteo".
+	Provider string `yaml:"provider"`
+	// NWSUserAgent identifies this application to api.weather.gov, which
+	// rejects requests without a descriptive User-Agent.
+	NWSUserAgent string `yaml:"nws_user_agent"`
+	// OpenWeatherMapAPIKey authenticates OpenWeatherMap API requests.
+	OpenWeatherMapAPIKey string `yaml:"open_weather_map_api_key"`
+	// WorldWeatherOnlineAPIKey authenticates WorldWeatherOnline API requests.
+	WorldWeatherOnlineAPIKey string `yaml:"world_weather_online_api_key"`
+	// MeteologixAPIKey authenticates Meteologix API requests.
+	MeteologixAPIKey string `yaml:"meteologix_api_key"`
+	// FallbackProviders lists additional provider names (comma-separated,
+	// in priority order) that weather.Service fails over to when Provider's
+	// backend returns a transient error. Empty disables failover.
+	FallbackProviders string `yaml:"fallback_providers"`
+	// VoteOnDisagreement enables MultiProvider's fan-out-and-majority-vote
+	// behavior for current-conditions requests across Provider plus
+	// FallbackProviders, instead of simple sequential failover.
+	VoteOnDisagreement bool `yaml:"vote_on_disagreement"`
+	// Strategy selects weather.ProviderStrategy: "primary-with-failover"
+	// (default), "round-robin", or "first-successful". Ignored for
+	// current-conditions requests when VoteOnDisagreement is set, since
+	// Vote takes precedence there.
+	Strategy string `yaml:"strategy"`
+	// DefaultUnits is the unit system ("metric", "imperial", or "standard")
+	// Service converts responses into when a request's own `units` query
+	// parameter is absent.
+	DefaultUnits string `yaml:"default_units"`
+	// PrefetchEnabled starts a weather.Prefetcher that keeps the topN
+	// hottest queries' cache entries warm on PrefetchSchedule.
+	PrefetchEnabled bool `yaml:"prefetch_enabled"`
+	// PrefetchTopN bounds how many of the hottest queries are re-fetched
+	// on each tick.
+	PrefetchTopN int `yaml:"prefetch_top_n"`
+	// PrefetchSchedule is a robfig/cron schedule expression (e.g.
+	// "*/30 * * * *" for wttr.in's :00/:30 cadence) controlling how often
+	// the Prefetcher ticks.
+	PrefetchSchedule string `yaml:"prefetch_schedule"`
+	// CacheEnabled wires a postgres.Store-backed weather.Cache into Service,
+	// persisting geocoded locations and current/forecast responses to
+	// PostgreSQL and serving them read-through on a cache hit.
+	CacheEnabled bool `yaml:"cache_enabled"`
+	// CacheCurrentTTLSeconds, CacheForecastTTLSeconds and
+	// CacheGeocodeTTLSeconds configure weather.CacheTTLs; 0 falls back to
+	// weather.DefaultCacheTTLs' value for that field.
+	CacheCurrentTTLSeconds  int `yaml:"cache_current_ttl_seconds"`
+	CacheForecastTTLSeconds int `yaml:"cache_forecast_ttl_seconds"`
+	CacheGeocodeTTLSeconds  int `yaml:"cache_geocode_ttl_seconds"`
+	// CacheSweepIntervalSeconds controls how often postgres.Manager's
+	// background sweeper evicts expired cache rows; 0 falls back to
+	// postgres.defaultSweepInterval.
+	CacheSweepIntervalSeconds int `yaml:"cache_sweep_interval_seconds"`
+}
+
+// StocksConfig configures the upstream stocks client and the rate
+// limiter/circuit breaker stocks.ResilientClient wraps it with.
+type StocksConfig struct {
+	// UpstreamURL is the stocks.Client quote endpoint to call.
+	UpstreamURL string `yaml:"upstream_url"`
+	// TimeoutSeconds bounds a single upstream HTTP call.
+	TimeoutSeconds int `yaml:"timeout_seconds"`
+	// RateLimitRPS and RateLimitBurst configure ResilientClient's
+	// token-bucket rate limiter.
+	RateLimitRPS   float64 `yaml:"rate_limit_rps"`
+	RateLimitBurst int     `yaml:"rate_limit_burst"`
+	// FailureThreshold is how many failures within RollingWindowSeconds
+	// trip ResilientClient's circuit breaker from closed to open.
+	FailureThreshold     int `yaml:"failure_threshold"`
+	RollingWindowSeconds int `yaml:"rolling_window_seconds"`
+	// CooldownSeconds is how long the breaker stays open before allowing a
+	// single half-open probe request through.
+	CooldownSeconds int `yaml:"cooldown_seconds"`
+	// Providers lists the stocks.ProviderName values to wire, in failover
+	// order, for stocks.NewClientFromConfig. Defaults to just "yahoo".
+	Providers []string `yaml:"providers"`
+	// AlpacaKeyID and AlpacaSecretKey authenticate the alpaca provider,
+	// required only if "alpaca" appears in Providers.
+	AlpacaKeyID     string `yaml:"alpaca_key_id"`
+	AlpacaSecretKey string `yaml:"alpaca_secret_key"`
+	// FinnhubAPIKey authenticates the finnhub provider, required only if
+	// "finnhub" appears in Providers.
+	FinnhubAPIKey string `yaml:"finnhub_api_key"`
+}
+
+// Validate fails fast on the fields stocks.NewResilientClient needs to be
+// usable.
+func (s *StocksConfig) Validate() error {
+	if s.UpstreamURL == "" {
+		return errors.New("config: stocks.upstream_url is required")
+	}
+	if s.TimeoutSeconds <= 0 {
+		return errors.New("config: stocks.timeout_seconds must be positive")
+	}
+	return nil
+}
+
+// ExecutionsConfig configures the internal/execution background job
+// subsystem.
+type ExecutionsConfig struct {
+	// WorkerPoolSize bounds how many executions internal/execution.Manager
+	// runs concurrently; an execution submitted past this limit stays
+	// StatusPending until a running one finishes.
+	WorkerPoolSize int `yaml:"worker_pool_size"`
+	// QueueSize bounds how many executions may be pending or running at
+	// once; a Submit past this limit is rejected with
+	// execution.ErrQueueFull instead of accepted into an unbounded queue.
+	// Zero leaves it unbounded.
+	QueueSize int `yaml:"queue_size"`
+}
+
+// Validate fails fast if WorkerPoolSize isn't positive, since
+// execution.NewManagerWithQueueCap would otherwise silently fall back to
+// its own default and mask a config mistake.
+func (e *ExecutionsConfig) Validate() error {
+	if e.WorkerPoolSize <= 0 {
+		return errors.New("config: executions.worker_pool_size must be positive")
+	}
+	return nil
+}
+
+// LogConfig configures the process-wide structured logger internal/logger
+// builds at startup.
+type LogConfig struct {
+	// Level is a zap level name: "debug", "info", "warn", "error", "dpanic",
+	// "panic", or "fatal".
+	Level string `yaml:"level"`
+	// Format selects the zap encoder: "json" for machine-parseable
+	// production logs, or "console" for human-readable local development
+	// output.
+	Format string `yaml:"format"`
+	// SamplingInitial and SamplingThereafter configure zap's log sampling:
+	// the first SamplingInitial identical messages logged per second pass
+	// through unconditionally, then every SamplingThereafter-th one after
+	// that. Leaving both at 0 disables sampling.
+	SamplingInitial    int `yaml:"sampling_initial"`
+	SamplingThereafter int `yaml:"sampling_thereafter"`
+}
+
+// validLogLevels are the level names internal/logger.Setup accepts.
+var validLogLevels = map[string]bool{
+	"debug": true, "info": true, "warn": true, "error": true,
+	"dpanic": true, "panic": true, "fatal": true,
+}
+
+// Validate fails fast on a Format or Level internal/logger.Setup wouldn't
+// know how to build a logger from.
+func (l *LogConfig) Validate() error {
+	if l.Format != "json" && l.Format != "console" {
+		return fmt.Errorf("config: log.format must be \"json\" or \"console\", got %q", l.Format)
+	}
+	if !validLogLevels[strings.ToLower(l.Level)] {
+		return fmt.Errorf("config: log.level %q is not a recognized level", l.Level)
+	}
+	return nil
+}
+
+// defaultConfig returns Config's compiled-in defaults, the starting point
+// LoadFrom layers a config file and then environment variables on top of.
+func defaultConfig() *Config {
 	return &Config{
-		Port:        getEnv("PORT", "3002"),
-		Environment: getEnv("ENVIRONMENT", "development"),
+		Server: ServerConfig{
+			Port:                "3002",
+			ReadTimeoutSeconds:  15,
+			WriteTimeoutSeconds: 15,
+			IdleTimeoutSeconds:  60,
+		},
+		Environment: "development",
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "5432"),
-			User:     getEnv("DB_USER", "dax_user"),
-			Password: getEnv("DB_PASSWORD", "dax_password"),
-			Name:     getEnv("DB_NAME", "dax_db"),
-			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+			Host:         "localhost",
+			Port:         "5432",
+			User:         "dax_user",
+			Password:     "dax_password",
+			Name:         "dax_db",
+			SSLMode:      "disable",
+			QueryTimeout: 30 * time.Second,
+		},
+		Redis: RedisConfig{
+			Enabled: false,
+			Addr:    "localhost:6379",
+		},
+		Dax: DaxConfig{
+			AliasResolutionEnabled:      true,
+			ImportIdempotencyTTLSeconds: 86400,
+		},
+		Migrations: MigrationsConfig{
+			AutoApply: false,
+		},
+		Alerts: AlertsConfig{
+			EvaluationIntervalSeconds: 60,
+		},
+		Task: TaskConfig{
+			RequestTimeoutSeconds:          10,
+			EventRingBufferSize:            1024,
+			ExecutionMaxAttempts:           3,
+			ExecutionRetryBaseDelaySeconds: 1,
+			ScheduleSyncSpec:               "* * * * *",
+			Driver:                         "memory",
+		},
+		Auth: AuthConfig{
+			TokenTTLSeconds: 900,
+		},
+		Pagination: PaginationConfig{
+			CursorSigningKey: "dev-cursor-signing-key",
+		},
+		Observability: ObservabilityConfig{
+			DAXRepositoryLoggingEnabled: true,
+			DAXRepositoryMetricsEnabled: true,
+			DAXRepositoryTracingEnabled: false,
+		},
+		Weather: WeatherConfig{
+			Provider:         "open-meteo",
+			DefaultUnits:     "metric",
+			PrefetchTopN:     20,
+			PrefetchSchedule: "*/30 * * * *",
+		},
+		Stocks: StocksConfig{
+			UpstreamURL:          "https://query1.finance.yahoo.com/v7/finance/quote",
+			TimeoutSeconds:       10,
+			RateLimitRPS:         5,
+			RateLimitBurst:       10,
+			FailureThreshold:     5,
+			RollingWindowSeconds: 30,
+			CooldownSeconds:      30,
+			Providers:            []string{"yahoo"},
+		},
+		Executions: ExecutionsConfig{
+			WorkerPoolSize: 4,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "console",
 		},
 	}
 }
 
+// Load builds a Config the same way LoadFrom("") does, exiting the process
+// if the result is invalid (a malformed config file, or one that fails
+// Validate) - the startup-time fatal-on-error behavior main.go has always
+// relied on.
+func Load() *Config {
+	cfg, err := LoadFrom("")
+	if err != nil {
+		log.Fatalf("config: %v", err)
+	}
+	return cfg
+}
+
+// LoadFrom builds a Config by layering, in increasing precedence:
+//  1. defaultConfig's compiled-in defaults
+//  2. basePath's YAML (CONFIG_FILE, default "./config/config.yaml", if
+//     basePath is ""), skipped entirely if the file doesn't exist
+//  3. an environment-specific overlay alongside it, named
+//     "<base>.<environment>.yaml", skipped the same way
+//  4. environment variable overrides
+//
+// A missing config file at either layer is not an error; a malformed one
+// is. The result is validated before it's returned.
+func LoadFrom(basePath string) (*Config, error) {
+	cfg := defaultConfig()
+
+	if basePath == "" {
+		basePath = getEnv("CONFIG_FILE", "./config/config.yaml")
+	}
+
+	if err := mergeFile(cfg, basePath); err != nil {
+		return nil, err
+	}
+
+	environment := getEnv("ENVIRONMENT", cfg.Environment)
+	if err := mergeFile(cfg, overlayPath(basePath, environment)); err != nil {
+		return nil, err
+	}
+
+	applyEnvOverrides(cfg)
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// mergeFile reads path as YAML and unmarshals it into cfg, leaving fields
+// the document doesn't mention at whatever value cfg already held. A
+// missing file is not an error; a file that exists but fails to parse is.
+func mergeFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("config: reading %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return fmt.Errorf("config: parsing %s: %w", path, err)
+	}
+	return nil
+}
+
+// overlayPath derives "<base>.<environment>.yaml" from basePath, e.g.
+// "./config/config.yaml" + "production" -> "./config/config.production.yaml".
+func overlayPath(basePath, environment string) string {
+	dir := filepath.Dir(basePath)
+	ext := filepath.Ext(basePath)
+	name := strings.TrimSuffix(filepath.Base(basePath), ext)
+	return filepath.Join(dir, name+"."+environment+ext)
+}
+
+// applyEnvOverrides overrides every field of cfg with its environment
+// variable if set, leaving cfg's current (default- or file-derived) value
+// otherwise. Variable names match this package's pre-file-loading history
+// so existing deployments that only set environment variables keep working
+// unchanged.
+func applyEnvOverrides(cfg *Config) {
+	cfg.Server.Port = getEnv("PORT", cfg.Server.Port)
+	cfg.Server.ReadTimeoutSeconds = getEnvInt("SERVER_READ_TIMEOUT_SECONDS", cfg.Server.ReadTimeoutSeconds)
+	cfg.Server.WriteTimeoutSeconds = getEnvInt("SERVER_WRITE_TIMEOUT_SECONDS", cfg.Server.WriteTimeoutSeconds)
+	cfg.Server.IdleTimeoutSeconds = getEnvInt("SERVER_IDLE_TIMEOUT_SECONDS", cfg.Server.IdleTimeoutSeconds)
+
+	cfg.Environment = getEnv("ENVIRONMENT", cfg.Environment)
+
+	cfg.Database.Host = getEnv("DB_HOST", cfg.Database.Host)
+	cfg.Database.Port = getEnv("DB_PORT", cfg.Database.Port)
+	cfg.Database.User = getEnv("DB_USER", cfg.Database.User)
+	cfg.Database.Password = getEnv("DB_PASSWORD", cfg.Database.Password)
+	cfg.Database.Name = getEnv("DB_NAME", cfg.Database.Name)
+	cfg.Database.SSLMode = getEnv("DB_SSLMODE", cfg.Database.SSLMode)
+	cfg.Database.QueryTimeout = time.Duration(getEnvInt("DB_QUERY_TIMEOUT_SECONDS", int(cfg.Database.QueryTimeout.Seconds()))) * time.Second
+
+	cfg.Redis.Enabled = getEnvBool("REDIS_ENABLED", cfg.Redis.Enabled)
+	cfg.Redis.Addr = getEnv("REDIS_ADDR", cfg.Redis.Addr)
+	cfg.Redis.Password = getEnv("REDIS_PASSWORD", cfg.Redis.Password)
+	cfg.Redis.DB = getEnvInt("REDIS_DB", cfg.Redis.DB)
+
+	cfg.Dax.AliasResolutionEnabled = getEnvBool("DAX_ALIAS_RESOLUTION_ENABLED", cfg.Dax.AliasResolutionEnabled)
+	cfg.Dax.ImportIdempotencyTTLSeconds = getEnvInt("DAX_IMPORT_IDEMPOTENCY_TTL_SECONDS", cfg.Dax.ImportIdempotencyTTLSeconds)
+
+	cfg.Migrations.AutoApply = getEnvBool("MIGRATE_ON_STARTUP", cfg.Migrations.AutoApply)
+
+	cfg.Portfolio.AlertWebhookURL = getEnv("PORTFOLIO_ALERT_WEBHOOK_URL", cfg.Portfolio.AlertWebhookURL)
+
+	cfg.Alerts.FCMProjectID = getEnv("ALERTS_FCM_PROJECT_ID", cfg.Alerts.FCMProjectID)
+	cfg.Alerts.EvaluationIntervalSeconds = getEnvInt("ALERTS_EVALUATION_INTERVAL_SECONDS", cfg.Alerts.EvaluationIntervalSeconds)
+
+	cfg.Task.RequestTimeoutSeconds = getEnvInt("TASK_REQUEST_TIMEOUT_SECONDS", cfg.Task.RequestTimeoutSeconds)
+	cfg.Task.EventRingBufferSize = getEnvInt("TASK_EVENT_RING_BUFFER_SIZE", cfg.Task.EventRingBufferSize)
+	cfg.Task.ExecutionMaxAttempts = getEnvInt("TASK_EXECUTION_MAX_ATTEMPTS", cfg.Task.ExecutionMaxAttempts)
+	cfg.Task.ExecutionRetryBaseDelaySeconds = getEnvInt("TASK_EXECUTION_RETRY_BASE_DELAY_SECONDS", cfg.Task.ExecutionRetryBaseDelaySeconds)
+	cfg.Task.ScheduleSyncSpec = getEnv("TASK_SCHEDULE_SYNC_SPEC", cfg.Task.ScheduleSyncSpec)
+	cfg.Task.Driver = getEnv("TASK_DRIVER", cfg.Task.Driver)
+	cfg.Task.SQLitePath = getEnv("TASK_SQLITE_PATH", cfg.Task.SQLitePath)
+	cfg.Task.PostgresURL = getEnv("TASK_POSTGRES_URL", cfg.Task.PostgresURL)
+
+	if v := getEnv("AUTH_PROVIDERS", ""); v != "" {
+		cfg.Auth.Providers = strings.Split(v, ",")
+	}
+	cfg.Auth.BasicUsername = getEnv("AUTH_BASIC_USERNAME", cfg.Auth.BasicUsername)
+	cfg.Auth.BasicPasswordHash = getEnv("AUTH_BASIC_PASSWORD_HASH", cfg.Auth.BasicPasswordHash)
+	cfg.Auth.OIDCIssuerURL = getEnv("AUTH_OIDC_ISSUER_URL", cfg.Auth.OIDCIssuerURL)
+	cfg.Auth.OIDCAudience = getEnv("AUTH_OIDC_AUDIENCE", cfg.Auth.OIDCAudience)
+	cfg.Auth.APIKeysEnabled = getEnvBool("AUTH_API_KEYS_ENABLED", cfg.Auth.APIKeysEnabled)
+	cfg.Auth.TokenSigningKey = getEnv("AUTH_TOKEN_SIGNING_KEY", cfg.Auth.TokenSigningKey)
+	cfg.Auth.TokenTTLSeconds = getEnvInt("AUTH_TOKEN_TTL_SECONDS", cfg.Auth.TokenTTLSeconds)
+
+	cfg.Pagination.CursorSigningKey = getEnv("CURSOR_SIGNING_KEY", cfg.Pagination.CursorSigningKey)
+
+	cfg.Observability.DAXRepositoryLoggingEnabled = getEnvBool("DAX_REPOSITORY_LOGGING_ENABLED", cfg.Observability.DAXRepositoryLoggingEnabled)
+	cfg.Observability.DAXRepositoryMetricsEnabled = getEnvBool("DAX_REPOSITORY_METRICS_ENABLED", cfg.Observability.DAXRepositoryMetricsEnabled)
+	cfg.Observability.DAXRepositoryTracingEnabled = getEnvBool("DAX_REPOSITORY_TRACING_ENABLED", cfg.Observability.DAXRepositoryTracingEnabled)
+
+	cfg.Weather.Provider = getEnv("WEATHER_PROVIDER", cfg.Weather.Provider)
+	cfg.Weather.NWSUserAgent = getEnv("WEATHER_NWS_USER_AGENT", cfg.Weather.NWSUserAgent)
+	cfg.Weather.OpenWeatherMapAPIKey = getEnv("WEATHER_OPENWEATHERMAP_API_KEY", cfg.Weather.OpenWeatherMapAPIKey)
+	cfg.Weather.WorldWeatherOnlineAPIKey = getEnv("WEATHER_WORLDWEATHERONLINE_API_KEY", cfg.Weather.WorldWeatherOnlineAPIKey)
+	cfg.Weather.MeteologixAPIKey = getEnv("WEATHER_METEOLOGIX_API_KEY", cfg.Weather.MeteologixAPIKey)
+	cfg.Weather.FallbackProviders = getEnv("WEATHER_FALLBACK_PROVIDERS", cfg.Weather.FallbackProviders)
+	cfg.Weather.VoteOnDisagreement = getEnvBool("WEATHER_VOTE_ON_DISAGREEMENT", cfg.Weather.VoteOnDisagreement)
+	cfg.Weather.Strategy = getEnv("WEATHER_STRATEGY", cfg.Weather.Strategy)
+	cfg.Weather.DefaultUnits = getEnv("WEATHER_DEFAULT_UNITS", cfg.Weather.DefaultUnits)
+	cfg.Weather.PrefetchEnabled = getEnvBool("WEATHER_PREFETCH_ENABLED", cfg.Weather.PrefetchEnabled)
+	cfg.Weather.PrefetchTopN = getEnvInt("WEATHER_PREFETCH_TOP_N", cfg.Weather.PrefetchTopN)
+	cfg.Weather.PrefetchSchedule = getEnv("WEATHER_PREFETCH_SCHEDULE", cfg.Weather.PrefetchSchedule)
+	cfg.Weather.CacheEnabled = getEnvBool("WEATHER_CACHE_ENABLED", cfg.Weather.CacheEnabled)
+	cfg.Weather.CacheCurrentTTLSeconds = getEnvInt("WEATHER_CACHE_CURRENT_TTL_SECONDS", cfg.Weather.CacheCurrentTTLSeconds)
+	cfg.Weather.CacheForecastTTLSeconds = getEnvInt("WEATHER_CACHE_FORECAST_TTL_SECONDS", cfg.Weather.CacheForecastTTLSeconds)
+	cfg.Weather.CacheGeocodeTTLSeconds = getEnvInt("WEATHER_CACHE_GEOCODE_TTL_SECONDS", cfg.Weather.CacheGeocodeTTLSeconds)
+	cfg.Weather.CacheSweepIntervalSeconds = getEnvInt("WEATHER_CACHE_SWEEP_INTERVAL_SECONDS", cfg.Weather.CacheSweepIntervalSeconds)
+
+	cfg.Stocks.UpstreamURL = getEnv("STOCKS_UPSTREAM_URL", cfg.Stocks.UpstreamURL)
+	cfg.Stocks.TimeoutSeconds = getEnvInt("STOCKS_TIMEOUT_SECONDS", cfg.Stocks.TimeoutSeconds)
+	cfg.Stocks.RateLimitRPS = getEnvFloat("STOCKS_RATE_LIMIT_RPS", cfg.Stocks.RateLimitRPS)
+	cfg.Stocks.RateLimitBurst = getEnvInt("STOCKS_RATE_LIMIT_BURST", cfg.Stocks.RateLimitBurst)
+	cfg.Stocks.FailureThreshold = getEnvInt("STOCKS_FAILURE_THRESHOLD", cfg.Stocks.FailureThreshold)
+	cfg.Stocks.RollingWindowSeconds = getEnvInt("STOCKS_ROLLING_WINDOW_SECONDS", cfg.Stocks.RollingWindowSeconds)
+	cfg.Stocks.CooldownSeconds = getEnvInt("STOCKS_COOLDOWN_SECONDS", cfg.Stocks.CooldownSeconds)
+	if v := getEnv("STOCKS_PROVIDERS", ""); v != "" {
+		cfg.Stocks.Providers = strings.Split(v, ",")
+	}
+	cfg.Stocks.AlpacaKeyID = getEnv("ALPACA_KEY_ID", cfg.Stocks.AlpacaKeyID)
+	cfg.Stocks.AlpacaSecretKey = getEnv("ALPACA_SECRET_KEY", cfg.Stocks.AlpacaSecretKey)
+	cfg.Stocks.FinnhubAPIKey = getEnv("FINNHUB_API_KEY", cfg.Stocks.FinnhubAPIKey)
+
+	cfg.Executions.WorkerPoolSize = getEnvInt("EXECUTION_WORKER_POOL_SIZE", cfg.Executions.WorkerPoolSize)
+	cfg.Executions.QueueSize = getEnvInt("EXECUTION_QUEUE_SIZE", cfg.Executions.QueueSize)
+
+	cfg.Log.Level = getEnv("LOG_LEVEL", cfg.Log.Level)
+	cfg.Log.Format = getEnv("LOG_FORMAT", cfg.Log.Format)
+	cfg.Log.SamplingInitial = getEnvInt("LOG_SAMPLING_INITIAL", cfg.Log.SamplingInitial)
+	cfg.Log.SamplingThereafter = getEnvInt("LOG_SAMPLING_THEREAFTER", cfg.Log.SamplingThereafter)
+}
+
 func (d *DatabaseConfig) DSN() string {
 	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		d.Host, d.Port, d.User, d.Password, d.Name, d.SSLMode)
 }
 
+// MigrateDSN returns d as a postgres:// connection URL, the form
+// golang-migrate's postgres driver (and internal/migrations, which drives
+// it) expects, as opposed to DSN's libpq key=value form.
+func (d *DatabaseConfig) MigrateDSN() string {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(d.User, d.Password),
+		Host:     fmt.Sprintf("%s:%s", d.Host, d.Port),
+		Path:     "/" + d.Name,
+		RawQuery: "sslmode=" + d.SSLMode,
+	}
+	return u.String()
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
+
+func getEnvBool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
+}
@@ -2,9 +2,12 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLoad(t *testing.T) {
@@ -18,7 +21,7 @@ func TestLoad(t *testing.T) {
 			name:    "loads default configuration",
 			envVars: map[string]string{},
 			expected: &Config{
-				Port:        "3002",
+				Server:      ServerConfig{Port: "3002"},
 				Environment: "development",
 				Database: DatabaseConfig{
 					Host:     "localhost",
@@ -28,23 +31,33 @@ func TestLoad(t *testing.T) {
 					Name:     "dax_db",
 					SSLMode:  "disable",
 				},
+				Redis: RedisConfig{
+					Enabled:  false,
+					Addr:     "localhost:6379",
+					Password: "",
+					DB:       0,
+				},
 			},
 			description: "should load defaults when no env vars are set",
 		},
 		{
 			name: "loads custom configuration from environment",
 			envVars: map[string]string{
-				"PORT":        "8080",
-				"ENVIRONMENT": "production",
-				"DB_HOST":     "db.example.com",
-				"DB_PORT":     "5433",
-				"DB_USER":     "custom_user",
-				"DB_PASSWORD": "custom_pass",
-				"DB_NAME":     "custom_db",
-				"DB_SSLMODE":  "require",
+				"PORT":           "8080",
+				"ENVIRONMENT":    "production",
+				"DB_HOST":        "db.example.com",
+				"DB_PORT":        "5433",
+				"DB_USER":        "custom_user",
+				"DB_PASSWORD":    "custom_pass",
+				"DB_NAME":        "custom_db",
+				"DB_SSLMODE":     "require",
+				"REDIS_ENABLED":  "true",
+				"REDIS_ADDR":     "redis.example.com:6380",
+				"REDIS_PASSWORD": "redis_pass",
+				"REDIS_DB":       "2",
 			},
 			expected: &Config{
-				Port:        "8080",
+				Server:      ServerConfig{Port: "8080"},
 				Environment: "production",
 				Database: DatabaseConfig{
 					Host:     "db.example.com",
@@ -54,6 +67,12 @@ func TestLoad(t *testing.T) {
 					Name:     "custom_db",
 					SSLMode:  "require",
 				},
+				Redis: RedisConfig{
+					Enabled:  true,
+					Addr:     "redis.example.com:6380",
+					Password: "redis_pass",
+					DB:       2,
+				},
 			},
 			description: "should load custom values from environment variables",
 		},
@@ -64,7 +83,7 @@ func TestLoad(t *testing.T) {
 				"DB_HOST": "remote-db",
 			},
 			expected: &Config{
-				Port:        "9000",
+				Server:      ServerConfig{Port: "9000"},
 				Environment: "development",
 				Database: DatabaseConfig{
 					Host:     "remote-db",
@@ -74,6 +93,12 @@ func TestLoad(t *testing.T) {
 					Name:     "dax_db",
 					SSLMode:  "disable",
 				},
+				Redis: RedisConfig{
+					Enabled:  false,
+					Addr:     "localhost:6379",
+					Password: "",
+					DB:       0,
+				},
 			},
 			description: "should mix custom values with defaults",
 		},
@@ -81,8 +106,12 @@ func TestLoad(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Clear environment
+			// Clear environment, and point CONFIG_FILE somewhere that
+			// doesn't exist so these cases exercise defaults-plus-env-vars
+			// only, independent of whatever repo-relative config/ directory
+			// a later test in this file creates.
 			os.Clearenv()
+			_ = os.Setenv("CONFIG_FILE", filepath.Join(t.TempDir(), "missing.yaml"))
 
 			// Set test environment variables
 			for key, value := range tt.envVars {
@@ -93,7 +122,7 @@ func TestLoad(t *testing.T) {
 			cfg := Load()
 
 			// Assertions
-			assert.Equal(t, tt.expected.Port, cfg.Port)
+			assert.Equal(t, tt.expected.Server.Port, cfg.Server.Port)
 			assert.Equal(t, tt.expected.Environment, cfg.Environment)
 			assert.Equal(t, tt.expected.Database.Host, cfg.Database.Host)
 			assert.Equal(t, tt.expected.Database.Port, cfg.Database.Port)
@@ -101,10 +130,91 @@ func TestLoad(t *testing.T) {
 			assert.Equal(t, tt.expected.Database.Password, cfg.Database.Password)
 			assert.Equal(t, tt.expected.Database.Name, cfg.Database.Name)
 			assert.Equal(t, tt.expected.Database.SSLMode, cfg.Database.SSLMode)
+			assert.Equal(t, tt.expected.Redis.Enabled, cfg.Redis.Enabled)
+			assert.Equal(t, tt.expected.Redis.Addr, cfg.Redis.Addr)
+			assert.Equal(t, tt.expected.Redis.Password, cfg.Redis.Password)
+			assert.Equal(t, tt.expected.Redis.DB, cfg.Redis.DB)
 		})
 	}
 }
 
+func TestLoadFrom_MissingFileFallsBackToDefaults(t *testing.T) {
+	os.Clearenv()
+
+	cfg, err := LoadFrom(filepath.Join(t.TempDir(), "does-not-exist.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "3002", cfg.Server.Port)
+	assert.Equal(t, "localhost", cfg.Database.Host)
+}
+
+func TestLoadFrom_MalformedYAMLReturnsError(t *testing.T) {
+	os.Clearenv()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("server: [this is not valid yaml"), 0o644))
+
+	_, err := LoadFrom(path)
+	assert.Error(t, err)
+}
+
+func TestLoadFrom_MergesBaseFileThenEnvironmentOverlay(t *testing.T) {
+	os.Clearenv()
+
+	dir := t.TempDir()
+	base := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(base, []byte(`
+server:
+  port: "4000"
+database:
+  host: base-db
+  port: "5432"
+  name: dax_db
+`), 0o644))
+
+	overlay := filepath.Join(dir, "config.staging.yaml")
+	require.NoError(t, os.WriteFile(overlay, []byte(`
+database:
+  host: staging-db
+`), 0o644))
+
+	_ = os.Setenv("ENVIRONMENT", "staging")
+
+	cfg, err := LoadFrom(base)
+	require.NoError(t, err)
+	assert.Equal(t, "4000", cfg.Server.Port, "base file value should survive when the overlay doesn't mention it")
+	assert.Equal(t, "staging-db", cfg.Database.Host, "overlay should win over the base file")
+}
+
+func TestLoadFrom_EnvVarOverridesFileValue(t *testing.T) {
+	os.Clearenv()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+server:
+  port: "4000"
+`), 0o644))
+
+	_ = os.Setenv("PORT", "5000")
+
+	cfg, err := LoadFrom(path)
+	require.NoError(t, err)
+	assert.Equal(t, "5000", cfg.Server.Port, "an env var should win over the file it overlays")
+}
+
+func TestDatabaseConfig_UnmarshalYAML_DecodesQueryTimeoutSeconds(t *testing.T) {
+	os.Clearenv()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+database:
+  query_timeout_seconds: 45
+`), 0o644))
+
+	cfg, err := LoadFrom(path)
+	require.NoError(t, err)
+	assert.Equal(t, 45*time.Second, cfg.Database.QueryTimeout)
+}
+
 func TestDatabaseConfig_DSN(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -180,3 +290,89 @@ func TestGetEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestGetEnvBool(t *testing.T) {
+	tests := []struct {
+		name         string
+		key          string
+		defaultValue bool
+		envValue     string
+		expected     bool
+	}{
+		{
+			name:         "returns true when env set to true",
+			key:          "TEST_BOOL",
+			defaultValue: false,
+			envValue:     "true",
+			expected:     true,
+		},
+		{
+			name:         "returns default when env not set",
+			key:          "UNSET_BOOL",
+			defaultValue: true,
+			envValue:     "",
+			expected:     true,
+		},
+		{
+			name:         "returns default when env is not a valid bool",
+			key:          "TEST_BOOL",
+			defaultValue: false,
+			envValue:     "not-a-bool",
+			expected:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Clearenv()
+			if tt.envValue != "" {
+				_ = os.Setenv(tt.key, tt.envValue)
+			}
+			result := getEnvBool(tt.key, tt.defaultValue)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestGetEnvInt(t *testing.T) {
+	tests := []struct {
+		name         string
+		key          string
+		defaultValue int
+		envValue     string
+		expected     int
+	}{
+		{
+			name:         "returns parsed int when env set",
+			key:          "TEST_INT",
+			defaultValue: 0,
+			envValue:     "7",
+			expected:     7,
+		},
+		{
+			name:         "returns default when env not set",
+			key:          "UNSET_INT",
+			defaultValue: 3,
+			envValue:     "",
+			expected:     3,
+		},
+		{
+			name:         "returns default when env is not a valid int",
+			key:          "TEST_INT",
+			defaultValue: 1,
+			envValue:     "not-a-number",
+			expected:     1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Clearenv()
+			if tt.envValue != "" {
+				_ = os.Setenv(tt.key, tt.envValue)
+			}
+			result := getEnvInt(tt.key, tt.defaultValue)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
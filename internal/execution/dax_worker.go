@@ -0,0 +1,76 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/f00b455/blank-go/pkg/dax"
+)
+
+// KindDAXBulkUpsert is the Worker kind registered for BulkUpsertWorker.
+const KindDAXBulkUpsert = "dax.bulk_upsert"
+
+// defaultBulkUpsertBatchSize is used when BulkUpsertPayload.BatchSize is
+// <= 0, matching dax.ImportCSVOptions' own default.
+const defaultBulkUpsertBatchSize = 1000
+
+// BulkUpsertPayload is the JSON payload BulkUpsertWorker expects.
+type BulkUpsertPayload struct {
+	Records   []dax.DAXRecord `json:"records"`
+	BatchSize int             `json:"batch_size,omitempty"`
+}
+
+// BulkUpsertResult is BulkUpsertWorker's JSON result.
+type BulkUpsertResult struct {
+	RecordsUpserted int `json:"records_upserted"`
+}
+
+// BulkUpsertWorker streams a BulkUpsertPayload's records into
+// dax.Repository.BulkUpsertBatch in configurable batches, so a large
+// payload doesn't hold every record's worth of transaction state open at
+// once and reports progress between batches.
+type BulkUpsertWorker struct {
+	repo dax.Repository
+}
+
+// NewBulkUpsertWorker creates a BulkUpsertWorker that persists through
+// repo.
+func NewBulkUpsertWorker(repo dax.Repository) *BulkUpsertWorker {
+	return &BulkUpsertWorker{repo: repo}
+}
+
+// Run implements Worker.
+func (w *BulkUpsertWorker) Run(ctx context.Context, payload json.RawMessage, report func(progress int)) (json.RawMessage, error) {
+	var p BulkUpsertPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, fmt.Errorf("invalid %s payload: %w", KindDAXBulkUpsert, err)
+	}
+
+	batchSize := p.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBulkUpsertBatchSize
+	}
+
+	total := len(p.Records)
+	if total == 0 {
+		return json.Marshal(BulkUpsertResult{RecordsUpserted: 0})
+	}
+
+	for start := 0; start < total; start += batchSize {
+		end := start + batchSize
+		if end > total {
+			end = total
+		}
+
+		if err := w.repo.BulkUpsertBatch(ctx, p.Records[start:end]); err != nil {
+			return nil, err
+		}
+
+		if report != nil {
+			report(end * 100 / total)
+		}
+	}
+
+	return json.Marshal(BulkUpsertResult{RecordsUpserted: total})
+}
@@ -0,0 +1,87 @@
+// Package execution runs long-running work items in the background,
+// tracked as Executions that survive a process restart because they're
+// persisted via GORM rather than held only in memory (compare
+// pkg/dax.ImportJobManager and pkg/operations.Registry, which don't
+// survive one). A Manager dispatches submitted work to the Worker
+// registered for its kind, bounding how many run concurrently with a
+// fixed-size pool.
+package execution
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned when an execution is not found.
+var ErrNotFound = errors.New("execution not found")
+
+// ErrNotCancelable is returned by Manager.Stop when the execution has
+// already reached a terminal status (succeeded, failed, or stopped).
+var ErrNotCancelable = errors.New("execution is not running")
+
+// ErrUnknownKind is returned by Manager.Submit when no Worker has been
+// registered for the requested kind.
+var ErrUnknownKind = errors.New("no worker registered for this execution kind")
+
+// ErrQueueFull is returned by Manager.Submit when the number of pending
+// and running executions has reached the Manager's queue cap (see
+// NewManagerWithQueueCap).
+var ErrQueueFull = errors.New("execution queue is full")
+
+// Status represents the current state of an Execution.
+type Status string
+
+const (
+	// StatusPending is an execution that has been accepted but is still
+	// waiting for a free worker-pool slot.
+	StatusPending Status = "pending"
+	// StatusRunning is an execution currently being processed by its
+	// Worker.
+	StatusRunning Status = "running"
+	// StatusSucceeded is an execution that completed without error.
+	StatusSucceeded Status = "succeeded"
+	// StatusFailed is an execution whose Worker returned an error.
+	StatusFailed Status = "failed"
+	// StatusStopped is an execution that was canceled via Manager.Stop
+	// before it finished on its own.
+	StatusStopped Status = "stopped"
+)
+
+// Execution tracks the lifecycle of a single Worker invocation submitted
+// via Manager.Submit.
+type Execution struct {
+	ID           string          `json:"id" gorm:"type:uuid;primary_key"`
+	Kind         string          `json:"kind" gorm:"type:varchar(100);not null;index:idx_executions_kind_status"`
+	Status       Status          `json:"status" gorm:"type:varchar(20);not null;index:idx_executions_kind_status"`
+	Progress     int             `json:"progress"`
+	Payload      json.RawMessage `json:"payload,omitempty" gorm:"type:jsonb"`
+	Result       json.RawMessage `json:"result,omitempty" gorm:"type:jsonb"`
+	ErrorMessage string          `json:"error_message,omitempty" gorm:"type:text"`
+	CreatedAt    time.Time       `json:"created_at" gorm:"autoCreateTime"`
+	StartedAt    *time.Time      `json:"started_at,omitempty"`
+	EndedAt      *time.Time      `json:"ended_at,omitempty"`
+}
+
+// TableName sets the table name for GORM.
+func (Execution) TableName() string {
+	return "executions"
+}
+
+// ListFilter narrows Manager.List to executions matching Kind and/or
+// Status (either left zero-valued matches everything), paginated by Page
+// (1-indexed) and Limit.
+type ListFilter struct {
+	Kind   string
+	Status Status
+	Page   int
+	Limit  int
+}
+
+// PaginationMeta describes a List page alongside the full matching count.
+type PaginationMeta struct {
+	Page       int `json:"page"`
+	Limit      int `json:"limit"`
+	TotalCount int `json:"total_count"`
+	TotalPages int `json:"total_pages"`
+}
@@ -0,0 +1,253 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Worker executes a single Execution's work item. report, when non-nil,
+// publishes a 0-100 progress value as the work advances. The returned
+// bytes become Execution.Result.
+type Worker interface {
+	Run(ctx context.Context, payload json.RawMessage, report func(progress int)) (json.RawMessage, error)
+}
+
+// defaultPoolSize is used when Manager is constructed with a non-positive
+// poolSize.
+const defaultPoolSize = 4
+
+// Manager runs Workers in background goroutines, bounding how many run at
+// once to poolSize (typically config.ExecutionsConfig.WorkerPoolSize), and
+// tracks each run as an Execution in store that a caller can poll or stop
+// independently of the request that submitted it.
+type Manager struct {
+	store     Store
+	sem       chan struct{}
+	wg        sync.WaitGroup
+	maxQueued int
+
+	workersMu sync.RWMutex
+	workers   map[string]Worker
+
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+
+	queuedMu sync.Mutex
+	queued   int
+}
+
+// NewManager creates a Manager backed by store, running at most poolSize
+// Workers concurrently (defaultPoolSize if poolSize <= 0), with no cap on
+// how many executions may be pending or running at once.
+func NewManager(store Store, poolSize int) *Manager {
+	return NewManagerWithQueueCap(store, poolSize, 0)
+}
+
+// NewManagerWithQueueCap is NewManager with maxQueued bounding how many
+// executions may be pending or running at once (typically
+// config.ExecutionsConfig.QueueSize; 0 leaves it unbounded). Submit past
+// that cap returns ErrQueueFull instead of accepting an execution that
+// might then wait indefinitely for a worker-pool slot.
+func NewManagerWithQueueCap(store Store, poolSize, maxQueued int) *Manager {
+	if poolSize <= 0 {
+		poolSize = defaultPoolSize
+	}
+	return &Manager{
+		store:     store,
+		sem:       make(chan struct{}, poolSize),
+		workers:   make(map[string]Worker),
+		cancels:   make(map[string]context.CancelFunc),
+		maxQueued: maxQueued,
+	}
+}
+
+// Register associates kind with worker, so a later Submit(ctx, kind, ...)
+// dispatches to it. Registering the same kind twice replaces the prior
+// Worker.
+func (m *Manager) Register(kind string, worker Worker) {
+	m.workersMu.Lock()
+	defer m.workersMu.Unlock()
+	m.workers[kind] = worker
+}
+
+// Submit enqueues payload for asynchronous processing by the Worker
+// registered for kind, returning ErrUnknownKind if none is. The returned
+// Execution is always in StatusPending; the work itself runs in a
+// background goroutine once a worker-pool slot frees up, independent of
+// ctx's lifetime.
+func (m *Manager) Submit(ctx context.Context, kind string, payload json.RawMessage) (*Execution, error) {
+	m.workersMu.RLock()
+	worker, ok := m.workers[kind]
+	m.workersMu.RUnlock()
+	if !ok {
+		return nil, ErrUnknownKind
+	}
+
+	m.queuedMu.Lock()
+	if m.maxQueued > 0 && m.queued >= m.maxQueued {
+		m.queuedMu.Unlock()
+		return nil, ErrQueueFull
+	}
+	m.queued++
+	m.queuedMu.Unlock()
+
+	exec := &Execution{
+		ID:        uuid.New().String(),
+		Kind:      kind,
+		Status:    StatusPending,
+		Payload:   payload,
+		CreatedAt: time.Now(),
+	}
+	if err := m.store.Create(ctx, exec); err != nil {
+		return nil, err
+	}
+
+	m.wg.Add(1)
+	go m.run(exec.ID, worker, payload)
+
+	return exec, nil
+}
+
+// Get retrieves an execution by ID.
+func (m *Manager) Get(ctx context.Context, id string) (*Execution, error) {
+	return m.store.Get(ctx, id)
+}
+
+// List returns executions matching filter, newest-first, with pagination
+// metadata covering the full matching count.
+func (m *Manager) List(ctx context.Context, filter ListFilter) ([]*Execution, PaginationMeta, error) {
+	execs, total, err := m.store.List(ctx, filter)
+	if err != nil {
+		return nil, PaginationMeta{}, err
+	}
+
+	page, limit := filter.Page, filter.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	return execs, PaginationMeta{
+		Page:       page,
+		Limit:      limit,
+		TotalCount: total,
+		TotalPages: (total + limit - 1) / limit,
+	}, nil
+}
+
+// Stop cancels a pending or running execution, marking it StatusStopped
+// once its worker goroutine observes the cancellation (or immediately, if
+// it hasn't started yet). It returns ErrNotFound if id doesn't exist, or
+// ErrNotCancelable if the execution has already reached a terminal status.
+func (m *Manager) Stop(ctx context.Context, id string) error {
+	exec, err := m.store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if exec.Status != StatusPending && exec.Status != StatusRunning {
+		return ErrNotCancelable
+	}
+
+	m.mu.Lock()
+	cancel, ok := m.cancels[id]
+	m.mu.Unlock()
+	if ok {
+		cancel()
+	}
+
+	exec.Status = StatusStopped
+	now := time.Now()
+	exec.EndedAt = &now
+	return m.store.Update(ctx, exec)
+}
+
+// Shutdown waits for every running execution to drain, up to ctx's
+// deadline. It does not stop accepting Submits; callers that want that
+// should stop routing traffic to the handler first.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run is the worker-pool body for a single execution: it waits for a free
+// slot in m.sem, drives worker over a cancelable context (so Stop can
+// interrupt it), publishes progress via the report callback, and persists
+// the outcome.
+func (m *Manager) run(id string, worker Worker, payload json.RawMessage) {
+	defer m.wg.Done()
+	defer func() {
+		m.queuedMu.Lock()
+		m.queued--
+		m.queuedMu.Unlock()
+	}()
+
+	m.sem <- struct{}{}
+	defer func() { <-m.sem }()
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	m.mu.Lock()
+	m.cancels[id] = cancel
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		delete(m.cancels, id)
+		m.mu.Unlock()
+		cancel()
+	}()
+
+	exec, err := m.store.Get(context.Background(), id)
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	exec.Status = StatusRunning
+	exec.StartedAt = &now
+	_ = m.store.Update(context.Background(), exec)
+
+	report := func(progress int) {
+		exec.Progress = progress
+		_ = m.store.Update(context.Background(), exec)
+	}
+
+	result, runErr := worker.Run(runCtx, payload, report)
+
+	// Stop already marked the execution StatusStopped; don't clobber that
+	// with whatever error the canceled context produced.
+	current, getErr := m.store.Get(context.Background(), id)
+	if getErr == nil && current.Status == StatusStopped {
+		return
+	}
+
+	finished := time.Now()
+	exec.EndedAt = &finished
+
+	if runErr != nil {
+		exec.Status = StatusFailed
+		exec.ErrorMessage = runErr.Error()
+		_ = m.store.Update(context.Background(), exec)
+		return
+	}
+
+	exec.Status = StatusSucceeded
+	exec.Progress = 100
+	exec.Result = result
+	_ = m.store.Update(context.Background(), exec)
+}
@@ -0,0 +1,228 @@
+package execution_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/f00b455/blank-go/internal/execution"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// inMemoryStore is a minimal execution.Store for tests that don't want a
+// real Postgres instance.
+type inMemoryStore struct {
+	mu    sync.Mutex
+	execs map[string]*execution.Execution
+}
+
+func newInMemoryStore() *inMemoryStore {
+	return &inMemoryStore{execs: make(map[string]*execution.Execution)}
+}
+
+func (s *inMemoryStore) Create(_ context.Context, exec *execution.Execution) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.execs[exec.ID] = exec
+	return nil
+}
+
+func (s *inMemoryStore) Get(_ context.Context, id string) (*execution.Execution, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	exec, ok := s.execs[id]
+	if !ok {
+		return nil, execution.ErrNotFound
+	}
+	clone := *exec
+	return &clone, nil
+}
+
+func (s *inMemoryStore) Update(_ context.Context, exec *execution.Execution) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.execs[exec.ID]; !ok {
+		return execution.ErrNotFound
+	}
+	clone := *exec
+	s.execs[exec.ID] = &clone
+	return nil
+}
+
+func (s *inMemoryStore) List(_ context.Context, filter execution.ListFilter) ([]*execution.Execution, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matched []*execution.Execution
+	for _, exec := range s.execs {
+		if filter.Kind != "" && exec.Kind != filter.Kind {
+			continue
+		}
+		if filter.Status != "" && exec.Status != filter.Status {
+			continue
+		}
+		matched = append(matched, exec)
+	}
+	return matched, len(matched), nil
+}
+
+// blockingWorker runs until its context is canceled or started is closed
+// externally to unblock it, so tests can observe StatusRunning before
+// moving on.
+type blockingWorker struct {
+	started chan struct{}
+	unblock chan struct{}
+}
+
+func newBlockingWorker() *blockingWorker {
+	return &blockingWorker{started: make(chan struct{}), unblock: make(chan struct{})}
+}
+
+func (w *blockingWorker) Run(ctx context.Context, _ json.RawMessage, _ func(int)) (json.RawMessage, error) {
+	close(w.started)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-w.unblock:
+		return json.RawMessage(`{"done":true}`), nil
+	}
+}
+
+type echoWorker struct{}
+
+func (echoWorker) Run(_ context.Context, payload json.RawMessage, report func(int)) (json.RawMessage, error) {
+	if report != nil {
+		report(100)
+	}
+	return payload, nil
+}
+
+type failingWorker struct{ err error }
+
+func (w failingWorker) Run(context.Context, json.RawMessage, func(int)) (json.RawMessage, error) {
+	return nil, w.err
+}
+
+func waitForStatus(t *testing.T, manager *execution.Manager, id string, want execution.Status) *execution.Execution {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		exec, err := manager.Get(context.Background(), id)
+		require.NoError(t, err)
+		if exec.Status == want {
+			return exec
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("execution %s did not reach status %s in time", id, want)
+	return nil
+}
+
+func TestManager_Submit_RunsToCompletion(t *testing.T) {
+	manager := execution.NewManager(newInMemoryStore(), 2)
+	manager.Register("echo", echoWorker{})
+
+	exec, err := manager.Submit(context.Background(), "echo", json.RawMessage(`{"hello":"world"}`))
+	require.NoError(t, err)
+
+	done := waitForStatus(t, manager, exec.ID, execution.StatusSucceeded)
+	assert.JSONEq(t, `{"hello":"world"}`, string(done.Result))
+	assert.Equal(t, 100, done.Progress)
+	assert.NotNil(t, done.StartedAt)
+	assert.NotNil(t, done.EndedAt)
+}
+
+func TestManager_Submit_UnknownKind(t *testing.T) {
+	manager := execution.NewManager(newInMemoryStore(), 2)
+
+	_, err := manager.Submit(context.Background(), "nope", nil)
+	assert.True(t, errors.Is(err, execution.ErrUnknownKind))
+}
+
+func TestManager_Submit_WorkerErrorMarksFailed(t *testing.T) {
+	manager := execution.NewManager(newInMemoryStore(), 2)
+	boom := errors.New("boom")
+	manager.Register("broken", failingWorker{err: boom})
+
+	exec, err := manager.Submit(context.Background(), "broken", nil)
+	require.NoError(t, err)
+
+	done := waitForStatus(t, manager, exec.ID, execution.StatusFailed)
+	assert.Equal(t, boom.Error(), done.ErrorMessage)
+}
+
+func TestManager_Stop_CancelsRunningExecution(t *testing.T) {
+	manager := execution.NewManager(newInMemoryStore(), 2)
+	worker := newBlockingWorker()
+	manager.Register("blocking", worker)
+
+	exec, err := manager.Submit(context.Background(), "blocking", nil)
+	require.NoError(t, err)
+
+	<-worker.started
+	require.NoError(t, manager.Stop(context.Background(), exec.ID))
+
+	stopped, err := manager.Get(context.Background(), exec.ID)
+	require.NoError(t, err)
+	assert.Equal(t, execution.StatusStopped, stopped.Status)
+}
+
+func TestManager_Stop_NotCancelableOnceTerminal(t *testing.T) {
+	manager := execution.NewManager(newInMemoryStore(), 2)
+	manager.Register("echo", echoWorker{})
+
+	exec, err := manager.Submit(context.Background(), "echo", json.RawMessage(`{}`))
+	require.NoError(t, err)
+	waitForStatus(t, manager, exec.ID, execution.StatusSucceeded)
+
+	err = manager.Stop(context.Background(), exec.ID)
+	assert.True(t, errors.Is(err, execution.ErrNotCancelable))
+}
+
+func TestManager_Submit_ErrQueueFullOnceCapReached(t *testing.T) {
+	manager := execution.NewManagerWithQueueCap(newInMemoryStore(), 1, 1)
+	first := newBlockingWorker()
+	manager.Register("blocking", first)
+
+	firstExec, err := manager.Submit(context.Background(), "blocking", nil)
+	require.NoError(t, err)
+	<-first.started
+
+	second := newBlockingWorker()
+	manager.Register("blocking2", second)
+	_, err = manager.Submit(context.Background(), "blocking2", nil)
+	assert.True(t, errors.Is(err, execution.ErrQueueFull))
+
+	close(first.unblock)
+	waitForStatus(t, manager, firstExec.ID, execution.StatusSucceeded)
+
+	secondExec, err := manager.Submit(context.Background(), "blocking2", nil)
+	require.NoError(t, err, "queue slot should free up once the first execution finishes")
+	close(second.unblock)
+	waitForStatus(t, manager, secondExec.ID, execution.StatusSucceeded)
+}
+
+func TestManager_List_FiltersByKindAndStatus(t *testing.T) {
+	manager := execution.NewManager(newInMemoryStore(), 2)
+	manager.Register("echo", echoWorker{})
+
+	exec, err := manager.Submit(context.Background(), "echo", json.RawMessage(`{}`))
+	require.NoError(t, err)
+	waitForStatus(t, manager, exec.ID, execution.StatusSucceeded)
+
+	execs, meta, err := manager.List(context.Background(), execution.ListFilter{Kind: "echo", Status: execution.StatusSucceeded, Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, execs, 1)
+	assert.Equal(t, exec.ID, execs[0].ID)
+	assert.Equal(t, 1, meta.TotalCount)
+
+	execs, _, err = manager.List(context.Background(), execution.ListFilter{Kind: "unrelated-" + uuid.New().String()})
+	require.NoError(t, err)
+	assert.Empty(t, execs)
+}
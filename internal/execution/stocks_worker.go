@@ -0,0 +1,52 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/f00b455/blank-go/pkg/stocks"
+)
+
+// KindStocksBatchSummary is the Worker kind registered for
+// BatchSummaryWorker.
+const KindStocksBatchSummary = "stocks.batch_summary"
+
+// BatchSummaryPayload is the JSON payload BatchSummaryWorker expects.
+type BatchSummaryPayload struct {
+	// Tickers is a comma-separated ticker list, the same format
+	// stocks.Service.GetBatchSummary's tickersStr parameter accepts.
+	Tickers string `json:"tickers"`
+}
+
+// BatchSummaryWorker runs stocks.Service.GetBatchSummary, which already
+// fans out its per-ticker fetches with a bounded errgroup (see
+// pkg/stocks/resilience.go), as a background Execution instead of blocking
+// the HTTP request that submitted it.
+type BatchSummaryWorker struct {
+	service *stocks.Service
+}
+
+// NewBatchSummaryWorker creates a BatchSummaryWorker backed by service.
+func NewBatchSummaryWorker(service *stocks.Service) *BatchSummaryWorker {
+	return &BatchSummaryWorker{service: service}
+}
+
+// Run implements Worker.
+func (w *BatchSummaryWorker) Run(ctx context.Context, payload json.RawMessage, report func(progress int)) (json.RawMessage, error) {
+	var p BatchSummaryPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, fmt.Errorf("invalid %s payload: %w", KindStocksBatchSummary, err)
+	}
+
+	response, err := w.service.GetBatchSummary(ctx, p.Tickers)
+	if err != nil {
+		return nil, err
+	}
+
+	if report != nil {
+		report(100)
+	}
+
+	return json.Marshal(response)
+}
@@ -0,0 +1,104 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Store persists Executions. GormStore is the only production
+// implementation; InMemoryStore exists for tests that don't want a real
+// Postgres instance.
+type Store interface {
+	Create(ctx context.Context, exec *Execution) error
+	Get(ctx context.Context, id string) (*Execution, error)
+	Update(ctx context.Context, exec *Execution) error
+	List(ctx context.Context, filter ListFilter) ([]*Execution, int, error)
+}
+
+// ErrMigrationsPending is returned by NewGormStore when db has no
+// executions table, so a misconfigured deploy fails at startup instead of
+// surfacing as a "relation does not exist" error from the first Submit.
+var ErrMigrationsPending = errors.New("executions table not found: run internal/migrations (or AutoMigrate) before starting the server")
+
+// GormStore implements Store against PostgreSQL via GORM.
+type GormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore creates a GormStore, fast-failing with ErrMigrationsPending
+// if db's schema hasn't been brought up yet.
+func NewGormStore(db *gorm.DB) (*GormStore, error) {
+	if !db.Migrator().HasTable(&Execution{}) {
+		return nil, ErrMigrationsPending
+	}
+	return &GormStore{db: db}, nil
+}
+
+// AutoMigrate creates the executions table via GORM, for deployments that
+// haven't opted into internal/migrations' versioned schema (see
+// config.DaxConfig.MigrateOnStartup, which gates the same choice for the
+// dax table).
+func AutoMigrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(&Execution{}); err != nil {
+		return fmt.Errorf("failed to migrate executions schema: %w", err)
+	}
+	return nil
+}
+
+// Create implements Store.
+func (s *GormStore) Create(ctx context.Context, exec *Execution) error {
+	return s.db.WithContext(ctx).Create(exec).Error
+}
+
+// Get implements Store.
+func (s *GormStore) Get(ctx context.Context, id string) (*Execution, error) {
+	var exec Execution
+	err := s.db.WithContext(ctx).First(&exec, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &exec, nil
+}
+
+// Update implements Store.
+func (s *GormStore) Update(ctx context.Context, exec *Execution) error {
+	return s.db.WithContext(ctx).Save(exec).Error
+}
+
+// List implements Store, returning executions newest-first.
+func (s *GormStore) List(ctx context.Context, filter ListFilter) ([]*Execution, int, error) {
+	query := s.db.WithContext(ctx).Model(&Execution{})
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	page, limit := filter.Page, filter.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	var execs []*Execution
+	err := query.Order("created_at DESC").Offset((page - 1) * limit).Limit(limit).Find(&execs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return execs, int(total), nil
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/f00b455/blank-go/pkg/stocks"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminStocksService is the subset of stocks.Service the admin handler
+// needs: cache introspection and selective/full invalidation.
+type AdminStocksService interface {
+	Dump() []stocks.CacheEntry
+	Invalidate(ticker string)
+	InvalidateAll()
+}
+
+// AdminHandler handles operator-facing introspection and maintenance
+// endpoints, e.g. dumping the stocks cache to debug stale prices without
+// restarting the process.
+type AdminHandler struct {
+	stocksService AdminStocksService
+}
+
+// NewAdminHandler creates a new admin handler.
+func NewAdminHandler(stocksService AdminStocksService) *AdminHandler {
+	return &AdminHandler{stocksService: stocksService}
+}
+
+// DumpStocksCache handles GET /api/v1/admin/stocks/cache
+func (h *AdminHandler) DumpStocksCache(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"entries": h.stocksService.Dump(),
+	})
+}
+
+// InvalidateStocksCache handles DELETE /api/v1/admin/stocks/cache/:ticker,
+// purging every cached session for a single ticker.
+func (h *AdminHandler) InvalidateStocksCache(c *gin.Context) {
+	h.stocksService.Invalidate(c.Param("ticker"))
+	c.Status(http.StatusNoContent)
+}
+
+// InvalidateAllStocksCache handles DELETE /api/v1/admin/stocks/cache,
+// purging the entire stocks cache.
+func (h *AdminHandler) InvalidateAllStocksCache(c *gin.Context) {
+	h.stocksService.InvalidateAll()
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/f00b455/blank-go/pkg/stocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAdminStocksService is a hand-rolled AdminStocksService test double.
+type fakeAdminStocksService struct {
+	dump              []stocks.CacheEntry
+	invalidated       []string
+	invalidatedAllCnt int
+}
+
+func (f *fakeAdminStocksService) Dump() []stocks.CacheEntry { return f.dump }
+
+func (f *fakeAdminStocksService) Invalidate(ticker string) {
+	f.invalidated = append(f.invalidated, ticker)
+}
+
+func (f *fakeAdminStocksService) InvalidateAll() { f.invalidatedAllCnt++ }
+
+func TestAdminHandler_DumpStocksCache(t *testing.T) {
+	service := &fakeAdminStocksService{
+		dump: []stocks.CacheEntry{
+			{Ticker: "AAPL", Session: "regular", Source: "yahoo", Hits: 3, Misses: 1},
+		},
+	}
+	handler := NewAdminHandler(service)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/admin/stocks/cache", nil)
+
+	handler.DumpStocksCache(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "AAPL")
+	assert.Contains(t, w.Body.String(), "yahoo")
+}
+
+func TestAdminHandler_InvalidateStocksCache(t *testing.T) {
+	service := &fakeAdminStocksService{}
+	handler := NewAdminHandler(service)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodDelete, "/api/v1/admin/stocks/cache/AAPL", nil)
+	c.Params = gin.Params{{Key: "ticker", Value: "AAPL"}}
+
+	handler.InvalidateStocksCache(c)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, []string{"AAPL"}, service.invalidated)
+}
+
+func TestAdminHandler_InvalidateAllStocksCache(t *testing.T) {
+	service := &fakeAdminStocksService{}
+	handler := NewAdminHandler(service)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodDelete, "/api/v1/admin/stocks/cache", nil)
+
+	handler.InvalidateAllStocksCache(c)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, 1, service.invalidatedAllCnt)
+}
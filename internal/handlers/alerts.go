@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/alerts"
+	"github.com/gin-gonic/gin"
+)
+
+// AlertsHandler handles HTTP requests for pkg/alerts rule CRUD.
+type AlertsHandler struct {
+	repo alerts.RuleRepository
+}
+
+// NewAlertsHandler creates a new alerts handler backed by repo.
+func NewAlertsHandler(repo alerts.RuleRepository) *AlertsHandler {
+	return &AlertsHandler{repo: repo}
+}
+
+// createRuleRequest is the body of POST /api/v1/alerts.
+type createRuleRequest struct {
+	UserToken string    `json:"user_token" binding:"required"`
+	Ticker    string    `json:"ticker" binding:"required"`
+	Metric    string    `json:"metric"`
+	Op        alerts.Op `json:"op" binding:"required"`
+	Threshold float64   `json:"threshold"`
+}
+
+// CreateRule handles POST /api/v1/alerts.
+func (h *AlertsHandler) CreateRule(c *gin.Context) {
+	var req createRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	rule := &alerts.Rule{
+		ID:        alerts.NewRuleID(),
+		UserToken: req.UserToken,
+		Ticker:    req.Ticker,
+		Metric:    req.Metric,
+		Op:        req.Op,
+		Threshold: req.Threshold,
+		CreatedAt: time.Now(),
+	}
+
+	if err := rule.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.repo.Create(rule); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create rule"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// ListRules handles GET /api/v1/alerts.
+func (h *AlertsHandler) ListRules(c *gin.Context) {
+	rules, err := h.repo.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve rules"})
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+// DeleteRule handles DELETE /api/v1/alerts/:id.
+func (h *AlertsHandler) DeleteRule(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.repo.Delete(id); err != nil {
+		if errors.Is(err, alerts.ErrRuleNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete rule"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "alert rule deleted successfully"})
+}
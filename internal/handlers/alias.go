@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/f00b455/blank-go/pkg/dax"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AliasHandler handles admin endpoints for managing DAX ticker aliases
+type AliasHandler struct {
+	repo dax.AliasRepository
+}
+
+// NewAliasHandler creates a new alias handler
+func NewAliasHandler(repo dax.AliasRepository) *AliasHandler {
+	return &AliasHandler{repo: repo}
+}
+
+// CreateAlias handles creation of a new alias
+func (h *AliasHandler) CreateAlias(c *gin.Context) {
+	var req struct {
+		Kind  uint8  `json:"kind"`
+		Key   string `json:"key" binding:"required"`
+		Value string `json:"value" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	alias := &dax.Alias{Kind: req.Kind, Key: req.Key, Value: req.Value}
+	if err := h.repo.Create(alias); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, alias)
+}
+
+// ListAliases returns every persisted alias
+func (h *AliasHandler) ListAliases(c *gin.Context) {
+	aliases, err := h.repo.FindAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"aliases": aliases})
+}
+
+// DeleteAlias removes an alias by ID
+func (h *AliasHandler) DeleteAlias(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid alias id",
+		})
+		return
+	}
+
+	if err := h.repo.Delete(id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/f00b455/blank-go/pkg/dax"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAliasHandler() (*AliasHandler, dax.AliasRepository) {
+	repo := dax.NewInMemoryAliasRepository()
+	handler := NewAliasHandler(repo)
+	return handler, repo
+}
+
+func TestAliasHandler_CreateAlias_Success(t *testing.T) {
+	handler, _ := setupAliasHandler()
+
+	body := bytes.NewBufferString(`{"kind":0,"key":"SIEGY","value":"SIE"}`)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/api/v1/dax/aliases", body)
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.CreateAlias(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), "SIEGY")
+	assert.Contains(t, w.Body.String(), "SIE")
+}
+
+func TestAliasHandler_CreateAlias_MissingFields(t *testing.T) {
+	handler, _ := setupAliasHandler()
+
+	body := bytes.NewBufferString(`{"kind":0}`)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/api/v1/dax/aliases", body)
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.CreateAlias(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAliasHandler_ListAliases(t *testing.T) {
+	handler, repo := setupAliasHandler()
+	require.NoError(t, repo.Create(&dax.Alias{Kind: dax.AliasKindTicker, Key: "SIEGY", Value: "SIE"}))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/api/v1/dax/aliases", nil)
+
+	handler.ListAliases(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "SIEGY")
+}
+
+func TestAliasHandler_DeleteAlias_InvalidID(t *testing.T) {
+	handler, _ := setupAliasHandler()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("DELETE", "/api/v1/dax/aliases/not-a-uuid", nil)
+	c.Params = gin.Params{{Key: "id", Value: "not-a-uuid"}}
+
+	handler.DeleteAlias(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAliasHandler_DeleteAlias_Success(t *testing.T) {
+	handler, repo := setupAliasHandler()
+	alias := &dax.Alias{Kind: dax.AliasKindTicker, Key: "SIEGY", Value: "SIE"}
+	assert.NoError(t, repo.Create(alias))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("DELETE", "/api/v1/dax/aliases/"+alias.ID.String(), nil)
+	c.Params = gin.Params{{Key: "id", Value: alias.ID.String()}}
+
+	handler.DeleteAlias(c)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	aliases, err := repo.FindAll()
+	assert.NoError(t, err)
+	assert.Empty(t, aliases)
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/f00b455/blank-go/internal/apikey"
+	"github.com/f00b455/blank-go/internal/middleware"
+)
+
+// APIKeyService is the subset of apikey.Store AuthHandler needs:
+// registering new keys and exchanging one for a short-lived token.
+type APIKeyService interface {
+	CreateKey(ctx context.Context, name string, scopes []string, rateLimitPerMin int) (plaintext string, record *apikey.APIKey, err error)
+	IssueToken(ctx context.Context, plaintext string) (string, error)
+}
+
+// AuthHandler handles API key registration and token issuance for the
+// machine-authentication flow middleware.APIKeyAuth enforces on
+// /api/v1/weather/*.
+type AuthHandler struct {
+	service APIKeyService
+}
+
+// NewAuthHandler creates a new auth handler.
+func NewAuthHandler(service APIKeyService) *AuthHandler {
+	return &AuthHandler{service: service}
+}
+
+// CreateAPIKeyRequest is the POST /api/v1/admin/auth/keys request body.
+type CreateAPIKeyRequest struct {
+	Name            string   `json:"name" binding:"required"`
+	Scopes          []string `json:"scopes"`
+	RateLimitPerMin int      `json:"rate_limit_per_min"`
+}
+
+// CreateAPIKey handles POST /api/v1/admin/auth/keys, registering a new
+// API key and returning its one-time plaintext value - the only time
+// it's ever available, since only its hashes are persisted.
+func (h *AuthHandler) CreateAPIKey(c *gin.Context) {
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	plaintext, record, err := h.service.CreateKey(c.Request.Context(), req.Name, req.Scopes, req.RateLimitPerMin)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"id":                 record.ID,
+		"name":               record.Name,
+		"api_key":            plaintext,
+		"scopes":             record.Scopes(),
+		"rate_limit_per_min": record.RateLimitPerMin,
+	})
+}
+
+// IssueToken handles POST /api/v1/auth/token, exchanging the X-API-Key
+// header for a short-lived bearer token scoped and rate-limited the same
+// as the key it was minted from.
+func (h *AuthHandler) IssueToken(c *gin.Context) {
+	rawKey := c.GetHeader(middleware.APIKeyHeader)
+	if rawKey == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": middleware.APIKeyHeader + " header is required",
+		})
+		return
+	}
+
+	token, err := h.service.IssueToken(c.Request.Context(), rawKey)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid API key"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"access_token": token,
+		"token_type":   "Bearer",
+	})
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/f00b455/blank-go/internal/apikey"
+	"github.com/f00b455/blank-go/internal/middleware"
+)
+
+// fakeAPIKeyService is a hand-rolled APIKeyService test double.
+type fakeAPIKeyService struct {
+	createdRecord *apikey.APIKey
+	createErr     error
+	token         string
+	tokenErr      error
+}
+
+func (f *fakeAPIKeyService) CreateKey(ctx context.Context, name string, scopes []string, rateLimitPerMin int) (string, *apikey.APIKey, error) {
+	if f.createErr != nil {
+		return "", nil, f.createErr
+	}
+	return "plaintext-key", f.createdRecord, nil
+}
+
+func (f *fakeAPIKeyService) IssueToken(ctx context.Context, plaintext string) (string, error) {
+	return f.token, f.tokenErr
+}
+
+func TestAuthHandler_CreateAPIKey(t *testing.T) {
+	service := &fakeAPIKeyService{
+		createdRecord: &apikey.APIKey{ID: "key-1", Name: "ci", ScopesCSV: "weather:read", RateLimitPerMin: 60},
+	}
+	handler := NewAuthHandler(service)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/admin/auth/keys", bytes.NewBufferString(`{"name":"ci","scopes":["weather:read"],"rate_limit_per_min":60}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.CreateAPIKey(c)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), "plaintext-key")
+	assert.Contains(t, w.Body.String(), "weather:read")
+}
+
+func TestAuthHandler_IssueToken(t *testing.T) {
+	t.Run("missing header", func(t *testing.T) {
+		handler := NewAuthHandler(&fakeAPIKeyService{})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/auth/token", nil)
+
+		handler.IssueToken(c)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("valid key", func(t *testing.T) {
+		handler := NewAuthHandler(&fakeAPIKeyService{token: "signed.jwt.token"})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/auth/token", nil)
+		c.Request.Header.Set(middleware.APIKeyHeader, "some-key")
+
+		handler.IssueToken(c)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "signed.jwt.token")
+	})
+
+	t.Run("rejected key", func(t *testing.T) {
+		handler := NewAuthHandler(&fakeAPIKeyService{tokenErr: apikey.ErrInvalidCredentials})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/auth/token", nil)
+		c.Request.Header.Set(middleware.APIKeyHeader, "bad-key")
+
+		handler.IssueToken(c)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
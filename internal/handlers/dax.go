@@ -1,25 +1,56 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/f00b455/blank-go/pkg/dax"
+	"github.com/f00b455/blank-go/pkg/operations"
 	"github.com/gin-gonic/gin"
 )
 
 // DAXHandler handles DAX-related HTTP requests
 type DAXHandler struct {
-	service *dax.Service
+	service          *dax.Service
+	cursorSigningKey []byte
+	operations       *operations.Registry
 }
 
 // NewDAXHandler creates a new DAX handler
 func NewDAXHandler(service *dax.Service) *DAXHandler {
-	return &DAXHandler{service: service}
+	return NewDAXHandlerWithCursorKey(service, "dev-cursor-signing-key")
 }
 
-// ImportCSV handles CSV file upload and import
+// NewDAXHandlerWithCursorKey creates a DAX handler whose GetByFilters signs
+// cursor-pagination tokens with cursorSigningKey (typically
+// cfg.Pagination.CursorSigningKey), so a cursor forged or tampered with by
+// a client fails verification instead of resuming from the wrong position.
+func NewDAXHandlerWithCursorKey(service *dax.Service, cursorSigningKey string) *DAXHandler {
+	return NewDAXHandlerWithOperations(service, cursorSigningKey, operations.NewRegistry())
+}
+
+// NewDAXHandlerWithOperations creates a DAX handler whose ImportCSV runs
+// ?async=true uploads through registry, so the same registry can be
+// shared with an OperationsHandler for polling and cancellation.
+func NewDAXHandlerWithOperations(service *dax.Service, cursorSigningKey string, registry *operations.Registry) *DAXHandler {
+	return &DAXHandler{
+		service:          service,
+		cursorSigningKey: []byte(cursorSigningKey),
+		operations:       registry,
+	}
+}
+
+// ImportCSV handles CSV file upload and import. With ?async=true it
+// returns a pollable operations.Operation instead of blocking; this
+// variant backs the dax-operations BDD feature, while production traffic
+// is served by the content-type-dispatching Import below.
 func (h *DAXHandler) ImportCSV(c *gin.Context) {
 	file, err := c.FormFile("file")
 	if err != nil {
@@ -39,8 +70,209 @@ func (h *DAXHandler) ImportCSV(c *gin.Context) {
 	}
 	defer func() { _ = openedFile.Close() }()
 
+	mode, ok := parseImportMode(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid mode: must be one of insert, upsert, replace"})
+		return
+	}
+
+	opts := dax.ImportCSVOptions{
+		BatchSize:       parseIntQuery(c, "batch_size", 0),
+		Mode:            mode,
+		ContinueOnError: c.Query("continue_on_error") == "true",
+		DryRun:          c.Query("dry_run") == "true",
+	}
+
+	if c.Query("async") == "true" {
+		h.importCSVAsync(c, openedFile, opts)
+		return
+	}
+
+	if strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+		h.streamImportCSV(c, openedFile, opts)
+		return
+	}
+
 	// Import CSV
-	response, err := h.service.ImportCSV(openedFile)
+	response, err := h.service.ImportCSV(c.Request.Context(), openedFile, opts)
+	respondImportResult(c, response, err)
+}
+
+// progressEvent is the payload of a "progress" SSE frame sent by
+// streamImportCSV. Errors aren't included here - ContinueOnError only
+// surfaces which rows failed once parsing finishes - so the final "done"
+// frame is where a caller finds out what, if anything, went wrong.
+type progressEvent struct {
+	Parsed   int `json:"parsed,omitempty"`
+	Inserted int `json:"inserted,omitempty"`
+}
+
+// streamImportCSV handles an `Accept: text/event-stream` ImportCSV request
+// by running the import on a goroutine and relaying its OnParsed/OnProgress
+// callbacks to the client as they fire, one SSE "progress" frame per
+// callback. A final "done" frame carries the same JSON body the
+// synchronous path returns via respondImportResult, so a client that
+// doesn't care about interim progress can just read the last event.
+func (h *DAXHandler) streamImportCSV(c *gin.Context, file io.Reader, opts dax.ImportCSVOptions) {
+	flusher, ok := c.Writer.(http.Flusher)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "streaming unsupported"})
+		return
+	}
+
+	// The import runs on its own goroutine against a buffered copy of the
+	// upload so the request goroutine is free to relay progress events as
+	// they arrive instead of blocking until ImportCSV returns.
+	data, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read uploaded file"})
+		return
+	}
+
+	events := make(chan progressEvent, 1)
+	var response *dax.ImportResponse
+	var importErr error
+
+	go func() {
+		defer close(events)
+		opts.OnParsed = func(parsed int) {
+			events <- progressEvent{Parsed: parsed}
+		}
+		opts.OnProgress = func(recordsProcessed int) {
+			events <- progressEvent{Inserted: recordsProcessed}
+		}
+		response, importErr = h.service.ImportCSV(c.Request.Context(), bytes.NewReader(data), opts)
+	}()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Status(http.StatusOK)
+	flusher.Flush()
+
+	for evt := range events {
+		payload, err := json.Marshal(evt)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(c.Writer, "event: progress\ndata: %s\n\n", payload)
+		flusher.Flush()
+	}
+
+	var donePayload []byte
+	if importErr != nil {
+		donePayload, _ = json.Marshal(gin.H{"error": importErr.Error()})
+	} else {
+		donePayload, _ = json.Marshal(response)
+	}
+	fmt.Fprintf(c.Writer, "event: done\ndata: %s\n\n", donePayload)
+	flusher.Flush()
+}
+
+// importCSVAsync buffers file into memory and hands it off to
+// h.operations, returning 202 Accepted with an operation ID the caller
+// can poll via OperationsHandler.Get instead of blocking on the full
+// parse+insert cycle.
+func (h *DAXHandler) importCSVAsync(c *gin.Context, file io.Reader, opts dax.ImportCSVOptions) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to read uploaded file",
+		})
+		return
+	}
+
+	var total int
+	op := h.operations.Run(func(ctx context.Context, report func(int)) (interface{}, error) {
+		opts.OnParsed = func(parsed int) { total = parsed }
+		opts.OnProgress = func(recordsProcessed int) {
+			if total > 0 {
+				report(recordsProcessed * 100 / total)
+			}
+		}
+		return h.service.ImportCSV(ctx, bytes.NewReader(data), opts)
+	})
+
+	c.JSON(http.StatusAccepted, gin.H{"operation_id": op.ID})
+}
+
+// Import handles DAX data import in any format Service.Import supports
+// (CSV, XLSX, JSON, or JSON Lines). The importer is chosen from the
+// uploaded file's Content-Type for a traditional multipart/form-data
+// upload, or from the request's own Content-Type when the body is posted
+// directly (e.g. an ndjson payload sent with curl --data-binary).
+//
+// @Summary      Import DAX records
+// @Description  Imports DAX records from an uploaded file or request body in CSV, XLSX, JSON, or JSON Lines format
+// @Tags         dax
+// @Accept       multipart/form-data
+// @Accept       json
+// @Accept       text/csv
+// @Produce      json
+// @Param        file               formData  file    false  "File to import (multipart upload)"
+// @Param        batch_size         query     int     false  "Rows per insert batch"
+// @Param        mode               query     string  false  "Persistence mode: insert, upsert, or replace (default)"
+// @Param        continue_on_error  query     bool    false  "Continue past row-level errors"
+// @Param        dry_run            query     bool    false  "Validate without writing"
+// @Success      200  {object}  dax.ImportResponse
+// @Success      207  {object}  dax.ImportResponse
+// @Failure      400  {object}  map[string]string
+// @Router       /dax/import [post]
+func (h *DAXHandler) Import(c *gin.Context) {
+	var reader io.Reader
+	contentType := c.ContentType()
+
+	if file, ferr := c.FormFile("file"); ferr == nil {
+		openedFile, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "failed to open file",
+			})
+			return
+		}
+		defer func() { _ = openedFile.Close() }()
+
+		reader = openedFile
+		if fileContentType := file.Header.Get("Content-Type"); dax.ImporterForContentType(fileContentType) != nil {
+			contentType = fileContentType
+		} else {
+			// Browsers and multipart writers often send a generic
+			// application/octet-stream for the uploaded file, so fall
+			// back to the historical assumption that file uploads are CSV.
+			contentType = "text/csv"
+		}
+	} else {
+		reader = c.Request.Body
+	}
+
+	if dax.ImporterForContentType(contentType) == nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "unsupported content type: " + contentType,
+		})
+		return
+	}
+
+	mode, ok := parseImportMode(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid mode: must be one of insert, upsert, replace"})
+		return
+	}
+
+	opts := dax.ImportCSVOptions{
+		BatchSize:       parseIntQuery(c, "batch_size", 0),
+		Mode:            mode,
+		ContinueOnError: c.Query("continue_on_error") == "true",
+		DryRun:          c.Query("dry_run") == "true",
+	}
+
+	response, err := h.service.Import(c.Request.Context(), reader, contentType, opts)
+	respondImportResult(c, response, err)
+}
+
+// respondImportResult writes the outcome of an import to c: a 4xx for
+// validation errors, 5xx for anything else, 207 when some rows failed but
+// others imported, and 200 on a clean import.
+func respondImportResult(c *gin.Context, response *dax.ImportResponse, err error) {
 	if err != nil {
 		status := http.StatusInternalServerError
 		errMsg := err.Error()
@@ -56,15 +288,21 @@ func (h *DAXHandler) ImportCSV(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	status := http.StatusOK
+	if response.RecordsFailed > 0 {
+		status = http.StatusMultiStatus
+	}
+	c.JSON(status, response)
 }
 
-// GetAll retrieves all DAX records with pagination
+// GetAll retrieves all DAX records with pagination. It is unused by
+// setupRouter, which wires GET /dax to GetByFilters instead; kept for
+// callers that want unfiltered pagination directly against Service.GetAll.
 func (h *DAXHandler) GetAll(c *gin.Context) {
 	page := parseIntQuery(c, "page", 1)
 	limit := parseIntQuery(c, "limit", 10)
 
-	response, err := h.service.GetAll(page, limit)
+	response, err := h.service.GetAll(c.Request.Context(), page, limit)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
@@ -75,13 +313,39 @@ func (h *DAXHandler) GetAll(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-// GetByFilters retrieves DAX records filtered by ticker and/or year
+// GetByFilters retrieves DAX records matching query-parameter filters, e.g.
+// ?ticker=SIE,SAP&year_from=2020&year_to=2025&metric=EBITDA&sort=year:desc.
+// The legacy single-value ?ticker= and ?year= params are still accepted.
+//
+// @Summary      Query DAX records
+// @Description  Lists DAX records matching filters, offset- or cursor-paginated
+// @Tags         dax
+// @Produce      json
+// @Param        ticker       query  string  false  "Comma-separated tickers"
+// @Param        metric       query  string  false  "Comma-separated metrics"
+// @Param        report_type  query  string  false  "Comma-separated report types"
+// @Param        currency     query  string  false  "Comma-separated currencies"
+// @Param        year         query  int     false  "Exact year"
+// @Param        year_from    query  int     false  "Year range start"
+// @Param        year_to      query  int     false  "Year range end"
+// @Param        min_value    query  number  false  "Minimum value"
+// @Param        max_value    query  number  false  "Maximum value"
+// @Param        sort         query  string  false  "field:asc|desc"
+// @Param        cursor       query  string  false  "Opaque pagination cursor"
+// @Param        page         query  int     false  "Page number (offset pagination)"
+// @Param        limit        query  int     false  "Page size"
+// @Success      200  {array}   dax.DAXRecord
+// @Failure      400  {object}  map[string]string
+// @Router       /dax [get]
 func (h *DAXHandler) GetByFilters(c *gin.Context) {
-	ticker := c.Query("ticker")
-	yearStr := c.Query("year")
+	filters := &dax.Filters{
+		Tickers:     parseCSVQuery(c, "ticker"),
+		Metrics:     parseCSVQuery(c, "metric"),
+		ReportTypes: parseCSVQuery(c, "report_type"),
+		Currencies:  parseCSVQuery(c, "currency"),
+	}
 
-	var year *int
-	if yearStr != "" {
+	if yearStr := c.Query("year"); yearStr != "" {
 		y, err := strconv.Atoi(yearStr)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{
@@ -89,16 +353,68 @@ func (h *DAXHandler) GetByFilters(c *gin.Context) {
 			})
 			return
 		}
-		year = &y
+		filters.YearFrom = &y
+		filters.YearTo = &y
+	}
+
+	if yearFrom, ok, err := parseIntPtrQuery(c, "year_from"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid year_from parameter"})
+		return
+	} else if ok {
+		filters.YearFrom = yearFrom
+	}
+
+	if yearTo, ok, err := parseIntPtrQuery(c, "year_to"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid year_to parameter"})
+		return
+	} else if ok {
+		filters.YearTo = yearTo
+	}
+
+	if minValue, ok, err := parseFloatPtrQuery(c, "min_value"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid min_value parameter"})
+		return
+	} else if ok {
+		filters.MinValue = minValue
+	}
+
+	if maxValue, ok, err := parseFloatPtrQuery(c, "max_value"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid max_value parameter"})
+		return
+	} else if ok {
+		filters.MaxValue = maxValue
+	}
+
+	if sortStr := c.Query("sort"); sortStr != "" {
+		field, dir, _ := strings.Cut(sortStr, ":")
+		filters.SortBy = field
+		filters.SortDir = dir
+	}
+
+	if _, usesCursor := c.Request.URL.Query()["cursor"]; usesCursor {
+		limit := parseIntQuery(c, "limit", 0)
+		h.getByFiltersCursor(c, filters, c.Query("cursor"), limit)
+		return
+	}
+
+	if c.Query("page") != "" || c.Query("limit") != "" {
+		setLegacyOffsetDeprecationHeader(c)
 	}
 
 	page := parseIntQuery(c, "page", 1)
 	limit := parseIntQuery(c, "limit", 10)
 
-	response, err := h.service.GetByFilters(ticker, year, page, limit)
+	response, err := h.service.GetByFilters(c.Request.Context(), filters, page, limit)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
+		status := http.StatusInternalServerError
+		errMsg := err.Error()
+		if strings.Contains(errMsg, "year_from") ||
+			strings.Contains(errMsg, "min_value") ||
+			strings.Contains(errMsg, "invalid sort") {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"error": errMsg,
 		})
 		return
 	}
@@ -106,7 +422,173 @@ func (h *DAXHandler) GetByFilters(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// getByFiltersCursor serves GET /api/v1/dax's cursor-paginated path: records
+// matching filters, ordered by (ticker, year, id), with Link
+// (rel="next"/"prev"/"first") and X-Total-Count response headers.
+func (h *DAXHandler) getByFiltersCursor(c *gin.Context, filters *dax.Filters, cursor string, limit int) {
+	page, err := h.service.GetByFiltersCursor(c.Request.Context(), filters, h.cursorSigningKey, cursor, limit)
+	if err != nil {
+		if errors.Is(err, dax.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_cursor"})
+			return
+		}
+
+		status := http.StatusInternalServerError
+		errMsg := err.Error()
+		if strings.Contains(errMsg, "year_from") ||
+			strings.Contains(errMsg, "min_value") ||
+			strings.Contains(errMsg, "invalid sort") {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": errMsg})
+		return
+	}
+
+	rels := map[string]string{"first": ""}
+	order := []string{"next", "prev", "first"}
+	if page.NextCursor != "" {
+		rels["next"] = page.NextCursor
+	}
+	if page.PrevCursor != "" {
+		rels["prev"] = page.PrevCursor
+	}
+	setLinkHeader(c, c.Request.URL, rels, order)
+	setTotalCountHeader(c, page.TotalCount)
+
+	c.JSON(http.StatusOK, page.Data)
+}
+
+// Export streams DAX records matching query-parameter filters (the same
+// ones GetByFilters accepts) to the client in the format selected by the
+// Accept header: text/csv (default), application/x-ndjson, or
+// application/vnd.apache.parquet. Unlike GetByFilters it isn't paginated -
+// the whole filtered dataset is written as a single streamed response, one
+// Repository.Stream batch at a time, so memory stays bounded no matter how
+// large the result is.
+//
+// @Summary      Export DAX records
+// @Description  Streams DAX records matching filters as CSV, NDJSON, or Parquet
+// @Tags         dax
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Produce      application/vnd.apache.parquet
+// @Param        ticker       query  string  false  "Comma-separated tickers"
+// @Param        metric       query  string  false  "Comma-separated metrics"
+// @Param        report_type  query  string  false  "Comma-separated report types"
+// @Param        currency     query  string  false  "Comma-separated currencies"
+// @Param        year         query  int     false  "Exact year"
+// @Param        year_from    query  int     false  "Year range start"
+// @Param        year_to      query  int     false  "Year range end"
+// @Param        min_value    query  number  false  "Minimum value"
+// @Param        max_value    query  number  false  "Maximum value"
+// @Param        sort         query  string  false  "field:asc|desc"
+// @Param        batch_size   query  int     false  "Stream batch size"
+// @Success      200  {string}  string  "streamed export body"
+// @Failure      400  {object}  map[string]string
+// @Router       /dax/export [get]
+func (h *DAXHandler) Export(c *gin.Context) {
+	filters := &dax.Filters{
+		Tickers:     parseCSVQuery(c, "ticker"),
+		Metrics:     parseCSVQuery(c, "metric"),
+		ReportTypes: parseCSVQuery(c, "report_type"),
+		Currencies:  parseCSVQuery(c, "currency"),
+	}
+
+	if yearStr := c.Query("year"); yearStr != "" {
+		y, err := strconv.Atoi(yearStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid year parameter"})
+			return
+		}
+		filters.YearFrom = &y
+		filters.YearTo = &y
+	}
+
+	if yearFrom, ok, err := parseIntPtrQuery(c, "year_from"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid year_from parameter"})
+		return
+	} else if ok {
+		filters.YearFrom = yearFrom
+	}
+
+	if yearTo, ok, err := parseIntPtrQuery(c, "year_to"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid year_to parameter"})
+		return
+	} else if ok {
+		filters.YearTo = yearTo
+	}
+
+	if minValue, ok, err := parseFloatPtrQuery(c, "min_value"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid min_value parameter"})
+		return
+	} else if ok {
+		filters.MinValue = minValue
+	}
+
+	if maxValue, ok, err := parseFloatPtrQuery(c, "max_value"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid max_value parameter"})
+		return
+	} else if ok {
+		filters.MaxValue = maxValue
+	}
+
+	if sortStr := c.Query("sort"); sortStr != "" {
+		field, dir, _ := strings.Cut(sortStr, ":")
+		filters.SortBy = field
+		filters.SortDir = dir
+	}
+
+	if err := filters.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	flusher, ok := c.Writer.(http.Flusher)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "streaming unsupported"})
+		return
+	}
+
+	serialize, contentType := dax.ExportSerializerForAccept(c.GetHeader("Accept"))
+
+	c.Header("Content-Type", contentType)
+	c.Status(http.StatusOK)
+	flusher.Flush()
+
+	w := flushWriter{w: c.Writer, flusher: flusher}
+	batchSize := parseIntQuery(c, "batch_size", 0)
+	if err := h.service.Export(c.Request.Context(), filters, batchSize, serialize, w); err != nil {
+		// The 200 status and part of the body may already be on the wire by
+		// the time a Stream batch fails, so there's no way to report err to
+		// the client beyond truncating the response here.
+		return
+	}
+}
+
+// flushWriter wraps an http.ResponseWriter's Flusher so Export's
+// batch-at-a-time writes reach the client immediately instead of sitting
+// in a buffer until the response closes.
+type flushWriter struct {
+	w       io.Writer
+	flusher http.Flusher
+}
+
+func (fw flushWriter) Write(p []byte) (int, error) {
+	n, err := fw.w.Write(p)
+	fw.flusher.Flush()
+	return n, err
+}
+
 // GetMetrics retrieves available metrics for a ticker
+//
+// @Summary      List metrics for a ticker
+// @Description  Lists the distinct metric names reported for a ticker
+// @Tags         dax
+// @Produce      json
+// @Param        ticker  query  string  true  "Ticker symbol"
+// @Success      200  {object}  dax.MetricsResponse
+// @Failure      400  {object}  map[string]string
+// @Router       /dax/metrics [get]
 func (h *DAXHandler) GetMetrics(c *gin.Context) {
 	ticker := c.Query("ticker")
 	if ticker == "" {
@@ -116,7 +598,7 @@ func (h *DAXHandler) GetMetrics(c *gin.Context) {
 		return
 	}
 
-	response, err := h.service.GetMetrics(ticker)
+	response, err := h.service.GetMetrics(c.Request.Context(), ticker)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
@@ -127,6 +609,214 @@ func (h *DAXHandler) GetMetrics(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// GetStats handles GET /api/v1/dax/stats?ticker=...&from=YYYY&to=YYYY&metric=close&agg=mean|min|max|stddev|percentile:95,
+// computing agg over metric's values across the matching records in a
+// single streaming pass (see dax.ComputeStat).
+//
+// @Summary      DAX metric statistics
+// @Description  Computes a streaming aggregate (mean, min, max, stddev, or percentile:N) over a metric's values
+// @Tags         dax
+// @Produce      json
+// @Param        ticker   query  string  false  "Comma-separated tickers"
+// @Param        metric   query  string  true   "Metric name"
+// @Param        from     query  int     false  "Year range start"
+// @Param        to       query  int     false  "Year range end"
+// @Param        agg      query  string  true   "mean|min|max|stddev|percentile:N"
+// @Success      200  {object}  dax.StatResult
+// @Failure      400  {object}  map[string]string
+// @Router       /dax/stats [get]
+func (h *DAXHandler) GetStats(c *gin.Context) {
+	metric := c.Query("metric")
+	if metric == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "metric parameter is required"})
+		return
+	}
+
+	agg, percentile, err := dax.ParseStatAgg(c.Query("agg"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filters, err := parseStatsFilters(c, metric)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.Stats(c.Request.Context(), filters, agg, percentile)
+	if err != nil {
+		c.JSON(daxQueryStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetSeries handles GET /api/v1/dax/series?ticker=...&from=...&to=...&metric=...&resample=monthly|quarterly|yearly,
+// returning metric's values across the matching records as a time series
+// (see dax.ComputeSeries).
+//
+// @Summary      DAX metric time series
+// @Description  Streams a metric's values across matching records, resampled by period
+// @Tags         dax
+// @Produce      json
+// @Param        ticker    query  string  false  "Comma-separated tickers"
+// @Param        metric    query  string  true   "Metric name"
+// @Param        from      query  int     false  "Year range start"
+// @Param        to        query  int     false  "Year range end"
+// @Param        resample  query  string  false  "monthly|quarterly|yearly"
+// @Success      200  {object}  dax.SeriesResponse
+// @Failure      400  {object}  map[string]string
+// @Router       /dax/series [get]
+func (h *DAXHandler) GetSeries(c *gin.Context) {
+	metric := c.Query("metric")
+	if metric == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "metric parameter is required"})
+		return
+	}
+
+	resample, err := dax.ParseResamplePeriod(c.Query("resample"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filters, err := parseStatsFilters(c, metric)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.Series(c.Request.Context(), filters, metric, resample)
+	if err != nil {
+		c.JSON(daxQueryStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetAnalytics handles GET /api/v1/dax/analytics?ticker=...&from=YYYY&to=YYYY&metric=Revenue,
+// computing CAGR, year-over-year growth, volatility, Sharpe/Sortino
+// ratios, max drawdown, and a linear trend for metric across the matching
+// records (see dax.ComputeAnalyticsReport).
+//
+// @Summary      DAX metric analytics report
+// @Description  Computes CAGR, YoY growth, volatility, Sharpe/Sortino, max drawdown and trend for a metric
+// @Tags         dax
+// @Produce      json
+// @Param        ticker   query  string  false  "Comma-separated tickers"
+// @Param        metric   query  string  true   "Metric name"
+// @Param        from     query  int     false  "Year range start"
+// @Param        to       query  int     false  "Year range end"
+// @Success      200  {object}  dax.AnalyticsReport
+// @Failure      400  {object}  map[string]string
+// @Router       /dax/analytics [get]
+func (h *DAXHandler) GetAnalytics(c *gin.Context) {
+	metric := c.Query("metric")
+	if metric == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "metric parameter is required"})
+		return
+	}
+
+	filters, err := parseStatsFilters(c, metric)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.Analytics(c.Request.Context(), filters, metric)
+	if err != nil {
+		c.JSON(daxQueryStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetCorrelation handles GET /api/v1/dax/correlate?tickers=SAP.DE,DBK.DE&metric=close,
+// returning the Pearson correlation matrix for metric across tickers over
+// their overlapping years (see dax.CorrelationMatrix).
+//
+// @Summary      DAX cross-ticker correlation
+// @Description  Computes the Pearson correlation matrix for a metric across tickers' overlapping years
+// @Tags         dax
+// @Produce      json
+// @Param        tickers  query  string  true  "Comma-separated tickers, at least two"
+// @Param        metric   query  string  true  "Metric name"
+// @Success      200  {object}  dax.CorrelationMatrixResponse
+// @Failure      400  {object}  map[string]string
+// @Router       /dax/correlate [get]
+func (h *DAXHandler) GetCorrelation(c *gin.Context) {
+	metric := c.Query("metric")
+	tickers := parseCSVQuery(c, "tickers")
+	if metric == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "metric parameter is required"})
+		return
+	}
+	if len(tickers) < 2 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "tickers parameter must list at least two tickers"})
+		return
+	}
+
+	result, err := h.service.Correlate(c.Request.Context(), tickers, metric)
+	if err != nil {
+		c.JSON(daxQueryStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// parseStatsFilters builds the Filters GetStats/GetSeries query by, from
+// the ticker/from/to query parameters shared by both endpoints.
+func parseStatsFilters(c *gin.Context, metric string) (*dax.Filters, error) {
+	filters := &dax.Filters{
+		Tickers: parseCSVQuery(c, "ticker"),
+		Metrics: []string{metric},
+	}
+
+	if from, ok, err := parseIntPtrQuery(c, "from"); err != nil {
+		return nil, fmt.Errorf("invalid from parameter")
+	} else if ok {
+		filters.YearFrom = from
+	}
+
+	if to, ok, err := parseIntPtrQuery(c, "to"); err != nil {
+		return nil, fmt.Errorf("invalid to parameter")
+	} else if ok {
+		filters.YearTo = to
+	}
+
+	return filters, nil
+}
+
+// daxQueryStatusCode maps a stats/series/correlate error to an HTTP
+// status code: ErrNoData and ErrInsufficientOverlap mean the query was
+// well-formed but matched no (or too little) data, which is a 404 rather
+// than the 400 a malformed filter would be.
+func daxQueryStatusCode(err error) int {
+	if errors.Is(err, dax.ErrNoData) || errors.Is(err, dax.ErrInsufficientOverlap) {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
+
+// parseImportMode parses the ?mode= query param into a dax.ImportMode,
+// reporting ok=false for an unrecognized value so the caller can reject it
+// with a 400 instead of silently falling back to ImportModeReplace.
+func parseImportMode(c *gin.Context) (mode dax.ImportMode, ok bool) {
+	switch dax.ImportMode(c.Query("mode")) {
+	case "":
+		return dax.ImportModeReplace, true
+	case dax.ImportModeReplace, dax.ImportModeUpsert, dax.ImportModeInsert:
+		return dax.ImportMode(c.Query("mode")), true
+	default:
+		return "", false
+	}
+}
+
 // parseIntQuery parses an integer query parameter with a default value
 func parseIntQuery(c *gin.Context, key string, defaultValue int) int {
 	valueStr := c.Query(key)
@@ -141,3 +831,45 @@ func parseIntQuery(c *gin.Context, key string, defaultValue int) int {
 
 	return value
 }
+
+// parseCSVQuery splits a comma-separated query parameter into its values,
+// returning nil when the parameter is absent.
+func parseCSVQuery(c *gin.Context, key string) []string {
+	valueStr := c.Query(key)
+	if valueStr == "" {
+		return nil
+	}
+	return strings.Split(valueStr, ",")
+}
+
+// parseIntPtrQuery parses an optional integer query parameter. ok is false
+// when the parameter was absent.
+func parseIntPtrQuery(c *gin.Context, key string) (value *int, ok bool, err error) {
+	valueStr := c.Query(key)
+	if valueStr == "" {
+		return nil, false, nil
+	}
+
+	v, err := strconv.Atoi(valueStr)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &v, true, nil
+}
+
+// parseFloatPtrQuery parses an optional float query parameter. ok is false
+// when the parameter was absent.
+func parseFloatPtrQuery(c *gin.Context, key string) (value *float64, ok bool, err error) {
+	valueStr := c.Query(key)
+	if valueStr == "" {
+		return nil, false, nil
+	}
+
+	v, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &v, true, nil
+}
@@ -119,6 +119,71 @@ Siemens AG,SIE,income,EBITDA,invalid,15859000000.0,EUR`
 	assert.Contains(t, w.Body.String(), "invalid year")
 }
 
+func TestDAXHandler_Import_JSONBody(t *testing.T) {
+	handler, _ := setupDAXHandler()
+
+	body := `[{"company":"Siemens AG","ticker":"SIE","report_type":"income","metric":"EBITDA","year":2025,"value":15859000000.0,"currency":"EUR"}]`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/api/v1/dax/import", bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.Import(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "records_imported")
+}
+
+func TestDAXHandler_Import_JSONLinesBody(t *testing.T) {
+	handler, _ := setupDAXHandler()
+
+	body := `{"company":"Siemens AG","ticker":"SIE","report_type":"income","metric":"EBITDA","year":2025,"value":15859000000.0,"currency":"EUR"}
+{"company":"SAP SE","ticker":"SAP","report_type":"income","metric":"Net Income","year":2025,"value":8500000000.0,"currency":"EUR"}`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/api/v1/dax/import", bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/x-ndjson")
+
+	handler.Import(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "\"records_imported\":2")
+}
+
+func TestDAXHandler_Import_UnsupportedContentType(t *testing.T) {
+	handler, _ := setupDAXHandler()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/api/v1/dax/import", bytes.NewBufferString("<data/>"))
+	c.Request.Header.Set("Content-Type", "application/xml")
+
+	handler.Import(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "unsupported content type")
+}
+
+func TestDAXHandler_Import_MultipartCSVFile(t *testing.T) {
+	handler, _ := setupDAXHandler()
+
+	csvContent := `company,ticker,report_type,metric,year,value,currency
+Siemens AG,SIE,income,EBITDA,2025,15859000000.0,EUR`
+
+	req, _ := createMultipartRequest(t, csvContent)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.Import(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "records_imported")
+}
+
 func TestDAXHandler_GetAll_Success(t *testing.T) {
 	handler, repo := setupDAXHandler()
 
@@ -458,3 +523,56 @@ Siemens AG,SIE,income,EBITDA,2025,not-a-number,EUR`
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 	assert.Contains(t, w.Body.String(), "invalid data at row")
 }
+
+func TestDAXHandler_Export_CSVDefault(t *testing.T) {
+	handler, repo := setupDAXHandler()
+
+	records := []dax.DAXRecord{
+		{Company: "Siemens AG", Ticker: "SIE", ReportType: "income", Metric: "EBITDA", Year: 2025, Value: float64Ptr(1000.0), Currency: "EUR"},
+		{Company: "SAP SE", Ticker: "SAP", ReportType: "income", Metric: "Revenue", Year: 2025, Value: float64Ptr(2000.0), Currency: "EUR"},
+	}
+	require.NoError(t, repo.BulkUpsert(records))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/api/v1/dax/export?ticker=SIE", nil)
+
+	handler.Export(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "SIE")
+	assert.NotContains(t, w.Body.String(), "SAP")
+}
+
+func TestDAXHandler_Export_JSONLByAccept(t *testing.T) {
+	handler, repo := setupDAXHandler()
+
+	require.NoError(t, repo.BulkUpsert([]dax.DAXRecord{
+		{Company: "Siemens AG", Ticker: "SIE", ReportType: "income", Metric: "EBITDA", Year: 2025, Value: float64Ptr(1000.0), Currency: "EUR"},
+	}))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/api/v1/dax/export", nil)
+	c.Request.Header.Set("Accept", "application/x-ndjson")
+
+	handler.Export(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"ticker":"SIE"`)
+}
+
+func TestDAXHandler_Export_InvalidFilter(t *testing.T) {
+	handler, _ := setupDAXHandler()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/api/v1/dax/export?year_from=abc", nil)
+
+	handler.Export(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid year_from parameter")
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/f00b455/blank-go/internal/execution"
+	"github.com/gin-gonic/gin"
+)
+
+// ExecutionHandler exposes submission, polling, and cancellation for
+// background work started through an execution.Manager (for example, an
+// async stocks batch summary kicked off via StocksHandler.GetBatchSummary,
+// or a direct dax.bulk_upsert submission).
+type ExecutionHandler struct {
+	manager *execution.Manager
+}
+
+// NewExecutionHandler creates a new execution handler.
+func NewExecutionHandler(manager *execution.Manager) *ExecutionHandler {
+	return &ExecutionHandler{manager: manager}
+}
+
+// submitExecutionRequest is the POST /executions request body.
+type submitExecutionRequest struct {
+	Kind    string          `json:"kind" binding:"required"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// Submit handles POST /executions, enqueuing payload for the Worker
+// registered under kind and returning 202 Accepted with the execution's ID.
+//
+// @Summary      Submit an execution
+// @Description  Enqueues background work for the Worker registered under kind
+// @Tags         executions
+// @Accept       json
+// @Produce      json
+// @Param        request body submitExecutionRequest true "Execution request"
+// @Success      202  {object}  map[string]string
+// @Failure      400  {object}  map[string]string
+// @Router       /executions [post]
+func (h *ExecutionHandler) Submit(c *gin.Context) {
+	var req submitExecutionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	exec, err := h.manager.Submit(c.Request.Context(), req.Kind, req.Payload)
+	if err != nil {
+		if errors.Is(err, execution.ErrUnknownKind) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"id":         exec.ID,
+		"status_url": "/api/v1/executions/" + exec.ID,
+	})
+}
+
+// Get handles GET /executions/:id, returning the current state of an
+// execution.
+//
+// @Summary      Get an execution
+// @Description  Fetches the status, progress, and result of a background execution
+// @Tags         executions
+// @Produce      json
+// @Param        id   path      string  true  "Execution ID"
+// @Success      200  {object}  execution.Execution
+// @Failure      404  {object}  map[string]string
+// @Router       /executions/{id} [get]
+func (h *ExecutionHandler) Get(c *gin.Context) {
+	exec, err := h.manager.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, execution.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "execution not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, exec)
+}
+
+// List handles GET /executions?kind=&status=&page=&limit=, returning
+// executions newest-first with pagination metadata.
+//
+// @Summary      List executions
+// @Description  Lists background executions, optionally filtered by kind and status
+// @Tags         executions
+// @Produce      json
+// @Param        kind    query  string  false  "Filter by execution kind"
+// @Param        status  query  string  false  "Filter by execution status"
+// @Param        page    query  int     false  "Page number"
+// @Param        limit   query  int     false  "Page size"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /executions [get]
+func (h *ExecutionHandler) List(c *gin.Context) {
+	filter := execution.ListFilter{
+		Kind:   c.Query("kind"),
+		Status: execution.Status(c.Query("status")),
+		Page:   parseIntQuery(c, "page", 1),
+		Limit:  parseIntQuery(c, "limit", 10),
+	}
+
+	execs, pagination, err := h.manager.List(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       execs,
+		"pagination": pagination,
+	})
+}
+
+// Stop handles POST /executions/:id/stop, canceling a pending or running
+// execution.
+//
+// @Summary      Stop an execution
+// @Description  Cancels a pending or running background execution
+// @Tags         executions
+// @Produce      json
+// @Param        id   path      string  true  "Execution ID"
+// @Success      200  {object}  execution.Execution
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Router       /executions/{id}/stop [post]
+func (h *ExecutionHandler) Stop(c *gin.Context) {
+	err := h.manager.Stop(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, execution.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "execution not found"})
+		case errors.Is(err, execution.ErrNotCancelable):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	exec, err := h.manager.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, exec)
+}
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/f00b455/blank-go/internal/execution"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeExecutionStore is a minimal execution.Store for handler tests that
+// don't need a real Postgres instance.
+type fakeExecutionStore struct {
+	mu    sync.Mutex
+	execs map[string]*execution.Execution
+}
+
+func newFakeExecutionStore() *fakeExecutionStore {
+	return &fakeExecutionStore{execs: make(map[string]*execution.Execution)}
+}
+
+func (s *fakeExecutionStore) Create(_ context.Context, exec *execution.Execution) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.execs[exec.ID] = exec
+	return nil
+}
+
+func (s *fakeExecutionStore) Get(_ context.Context, id string) (*execution.Execution, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	exec, ok := s.execs[id]
+	if !ok {
+		return nil, execution.ErrNotFound
+	}
+	clone := *exec
+	return &clone, nil
+}
+
+func (s *fakeExecutionStore) Update(_ context.Context, exec *execution.Execution) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.execs[exec.ID]; !ok {
+		return execution.ErrNotFound
+	}
+	clone := *exec
+	s.execs[exec.ID] = &clone
+	return nil
+}
+
+func (s *fakeExecutionStore) List(_ context.Context, filter execution.ListFilter) ([]*execution.Execution, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var matched []*execution.Execution
+	for _, exec := range s.execs {
+		if filter.Kind != "" && exec.Kind != filter.Kind {
+			continue
+		}
+		matched = append(matched, exec)
+	}
+	return matched, len(matched), nil
+}
+
+type echoExecutionWorker struct{}
+
+func (echoExecutionWorker) Run(_ context.Context, payload json.RawMessage, report func(int)) (json.RawMessage, error) {
+	if report != nil {
+		report(100)
+	}
+	return payload, nil
+}
+
+type blockingExecutionWorker struct{ started chan struct{} }
+
+func (w blockingExecutionWorker) Run(ctx context.Context, _ json.RawMessage, _ func(int)) (json.RawMessage, error) {
+	close(w.started)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func waitForExecutionStatus(t *testing.T, handler *ExecutionHandler, id, want string) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: id}}
+		handler.Get(c)
+		if w.Code == http.StatusOK && strings.Contains(w.Body.String(), `"status":"`+want+`"`) {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("execution %s did not reach status %s in time", id, want)
+}
+
+func TestExecutionHandler_Submit_UnknownKind(t *testing.T) {
+	handler := NewExecutionHandler(execution.NewManager(newFakeExecutionStore(), 2))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	body := `{"kind":"nope","payload":{}}`
+	c.Request = httptest.NewRequest(http.MethodPost, "/executions", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.Submit(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestExecutionHandler_Submit_RunsToCompletion(t *testing.T) {
+	manager := execution.NewManager(newFakeExecutionStore(), 2)
+	manager.Register("echo", echoExecutionWorker{})
+	handler := NewExecutionHandler(manager)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	body := `{"kind":"echo","payload":{"hello":"world"}}`
+	c.Request = httptest.NewRequest(http.MethodPost, "/executions", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.Submit(c)
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	var resp struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	waitForExecutionStatus(t, handler, resp.ID, "succeeded")
+}
+
+func TestExecutionHandler_Get_NotFound(t *testing.T) {
+	handler := NewExecutionHandler(execution.NewManager(newFakeExecutionStore(), 2))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "missing"}}
+
+	handler.Get(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestExecutionHandler_Stop_StopsRunningExecution(t *testing.T) {
+	manager := execution.NewManager(newFakeExecutionStore(), 2)
+	worker := blockingExecutionWorker{started: make(chan struct{})}
+	manager.Register("blocking", worker)
+	handler := NewExecutionHandler(manager)
+
+	exec, err := manager.Submit(context.Background(), "blocking", nil)
+	require.NoError(t, err)
+	<-worker.started
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: exec.ID}}
+
+	handler.Stop(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"stopped"`)
+}
+
+func TestExecutionHandler_List_FiltersByKind(t *testing.T) {
+	manager := execution.NewManager(newFakeExecutionStore(), 2)
+	manager.Register("echo", echoExecutionWorker{})
+	handler := NewExecutionHandler(manager)
+
+	exec, err := manager.Submit(context.Background(), "echo", json.RawMessage(`{}`))
+	require.NoError(t, err)
+	waitForExecutionStatus(t, handler, exec.ID, "succeeded")
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/executions?kind=echo", nil)
+
+	handler.List(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), exec.ID)
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/f00b455/blank-go/pkg/finance"
+	"github.com/gin-gonic/gin"
+)
+
+// FinanceAggregator is the subset of finance.Aggregator FinanceHandler
+// depends on.
+type FinanceAggregator interface {
+	Query(ctx context.Context, q finance.Query) (*finance.Response, error)
+}
+
+// FinanceHandler handles HTTP requests that cut across pkg/dax fundamentals
+// and pkg/stocks live quotes.
+type FinanceHandler struct {
+	aggregator FinanceAggregator
+}
+
+// NewFinanceHandler creates a new finance handler backed by aggregator.
+func NewFinanceHandler(aggregator FinanceAggregator) *FinanceHandler {
+	return &FinanceHandler{aggregator: aggregator}
+}
+
+// GetCompanies handles
+// GET /api/v1/finance/companies?tickers=AAPL,GOOGL&fromYear=2018&toYear=2023&metrics=Revenue,EBITDA&live=true.
+func (h *FinanceHandler) GetCompanies(c *gin.Context) {
+	tickers := parseCSVQuery(c, "tickers")
+	if len(tickers) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "tickers parameter is required"})
+		return
+	}
+
+	q := finance.Query{
+		Tickers:     tickers,
+		Metrics:     parseCSVQuery(c, "metrics"),
+		IncludeLive: c.Query("live") == "true",
+	}
+
+	if fromYear, ok, err := parseIntPtrQuery(c, "fromYear"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid fromYear parameter"})
+		return
+	} else if ok {
+		q.FromYear = fromYear
+	}
+
+	if toYear, ok, err := parseIntPtrQuery(c, "toYear"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid toYear parameter"})
+		return
+	} else if ok {
+		q.ToYear = toYear
+	}
+
+	response, err := h.aggregator.Query(c.Request.Context(), q)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	statusCode := http.StatusOK
+	if len(response.Errors) > 0 && len(response.Companies) == len(response.Errors) {
+		statusCode = http.StatusNotFound
+	} else if len(response.Errors) > 0 {
+		statusCode = http.StatusMultiStatus
+	}
+
+	c.JSON(statusCode, response)
+}
@@ -6,9 +6,18 @@ import (
 	"time"
 
 	"github.com/f00b455/blank-go/internal/version"
+	"github.com/f00b455/blank-go/pkg/health"
+	"github.com/f00b455/blank-go/pkg/stocks"
 	"github.com/gin-gonic/gin"
 )
 
+// CacheMetricsProvider is implemented by stocks.Service, supplying the
+// aggregate stock cache counters surfaced in the detailed health check's
+// "cache" block.
+type CacheMetricsProvider interface {
+	CacheMetrics() stocks.CacheMetrics
+}
+
 const (
 	bytesToKB = 1024
 	bytesToMB = bytesToKB * 1024
@@ -20,23 +29,68 @@ func HealthCheck(c *gin.Context) {
 	})
 }
 
+// LivenessCheck handles GET /healthz. It reports only that this process is
+// up and never probes a dependency, so an outage in Postgres or the stocks
+// upstream doesn't get this pod restarted by a Kubernetes liveness probe -
+// that's what ReadinessCheck and /readyz are for.
+func LivenessCheck(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// ReadinessCheck handles GET /readyz, running registry and reporting 503
+// if any of its critical Checkers isn't health.StatusUp - signaling a load
+// balancer or Kubernetes readiness probe to stop routing traffic here until
+// the dependency recovers.
+func ReadinessCheck(registry *health.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		checks, ready := registry.Ready(c.Request.Context())
+
+		status := http.StatusOK
+		if !ready {
+			status = http.StatusServiceUnavailable
+		}
+
+		c.JSON(status, gin.H{
+			"ready":  ready,
+			"checks": checks,
+		})
+	}
+}
+
 func Ping(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"message": "pong",
 	})
 }
 
-func DetailedHealthCheck(startTime time.Time) gin.HandlerFunc {
+// GetVersion reports the running binary's version.BuildInfo.
+//
+// @Summary      Get build version
+// @Description  Returns the application's version, commit, build date, and Go runtime version
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  version.BuildInfo
+// @Router       /version [get]
+func GetVersion(c *gin.Context) {
+	c.JSON(http.StatusOK, version.Get())
+}
+
+// DetailedHealthCheck returns the GET /health/detailed handler: process
+// uptime, memory/goroutine stats, stocks cache metrics, and the result of
+// every Checker registered on registry.
+func DetailedHealthCheck(startTime time.Time, cacheProvider CacheMetricsProvider, registry *health.Registry) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var memStats runtime.MemStats
 		runtime.ReadMemStats(&memStats)
 
 		uptime := time.Since(startTime).Seconds()
+		cacheMetrics := cacheProvider.CacheMetrics()
+		checks := registry.Run(c.Request.Context())
 
 		c.JSON(http.StatusOK, gin.H{
 			"status":         "healthy",
 			"timestamp":      time.Now().UTC().Format(time.RFC3339),
-			"version":        version.Version,
+			"version":        version.Get().Version,
 			"uptime_seconds": uptime,
 			"system": gin.H{
 				"go_version":      runtime.Version(),
@@ -45,9 +99,13 @@ func DetailedHealthCheck(startTime time.Time) gin.HandlerFunc {
 				"memory_sys_mb":   float64(memStats.Sys) / bytesToMB,
 				"gc_runs":         memStats.NumGC,
 			},
-			"checks": gin.H{
-				"api": "ok",
+			"cache": gin.H{
+				"cache_size":      cacheMetrics.Size,
+				"cache_hits":      cacheMetrics.Hits,
+				"cache_misses":    cacheMetrics.Misses,
+				"cache_evictions": cacheMetrics.Evictions,
 			},
+			"checks": checks,
 		})
 	}
 }
@@ -1,15 +1,29 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/f00b455/blank-go/pkg/health"
+	"github.com/f00b455/blank-go/pkg/stocks"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 )
 
+// fakeHealthChecker is a health.Checker whose behavior is scripted by fn,
+// for tests that don't want a real database or upstream API.
+type fakeHealthChecker struct {
+	name string
+	fn   func(ctx context.Context) health.Result
+}
+
+func (c fakeHealthChecker) Name() string { return c.name }
+
+func (c fakeHealthChecker) Check(ctx context.Context) health.Result { return c.fn(ctx) }
+
 func TestHealthCheck(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -57,7 +71,7 @@ func TestDetailedHealthCheck(t *testing.T) {
 	startTime := time.Now().Add(-5 * time.Minute)
 
 	// Get handler function
-	handler := DetailedHealthCheck(startTime)
+	handler := DetailedHealthCheck(startTime, stocks.NewService(nil), health.NewRegistry(time.Second, 0))
 
 	// Create test request
 	req := httptest.NewRequest(http.MethodGet, "/health/detailed", nil)
@@ -82,8 +96,85 @@ func TestDetailedHealthCheck(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "memory_alloc_mb")
 	assert.Contains(t, w.Body.String(), "memory_sys_mb")
 	assert.Contains(t, w.Body.String(), "gc_runs")
+	assert.Contains(t, w.Body.String(), "cache")
+	assert.Contains(t, w.Body.String(), "cache_size")
+	assert.Contains(t, w.Body.String(), "cache_hits")
+	assert.Contains(t, w.Body.String(), "cache_misses")
+	assert.Contains(t, w.Body.String(), "cache_evictions")
 	assert.Contains(t, w.Body.String(), "checks")
-	assert.Contains(t, w.Body.String(), `"api":"ok"`)
+}
+
+func TestDetailedHealthCheck_IncludesCheckerResults(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	registry := health.NewRegistry(time.Second, 0)
+	registry.Register(fakeHealthChecker{name: "downstream", fn: func(context.Context) health.Result {
+		return health.Result{Status: health.StatusUp}
+	}})
+
+	handler := DetailedHealthCheck(time.Now(), stocks.NewService(nil), registry)
+
+	req := httptest.NewRequest(http.MethodGet, "/health/detailed", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"downstream":{"status":"up"`)
+}
+
+func TestLivenessCheck(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	LivenessCheck(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"ok"`)
+}
+
+func TestReadinessCheck_OKWhenCriticalCheckersAreUp(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	registry := health.NewRegistry(time.Second, 0)
+	registry.RegisterCritical(fakeHealthChecker{name: "db", fn: func(context.Context) health.Result {
+		return health.Result{Status: health.StatusUp}
+	}})
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	ReadinessCheck(registry)(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"ready":true`)
+}
+
+func TestReadinessCheck_ServiceUnavailableWhenACriticalCheckerFails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	registry := health.NewRegistry(time.Second, 0)
+	registry.RegisterCritical(fakeHealthChecker{name: "db", fn: func(context.Context) health.Result {
+		return health.Result{Status: health.StatusDown, Error: "connection refused"}
+	}})
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	ReadinessCheck(registry)(c)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), `"ready":false`)
 }
 
 func TestDetailedHealthCheck_UptimeCalculation(t *testing.T) {
@@ -93,7 +184,7 @@ func TestDetailedHealthCheck_UptimeCalculation(t *testing.T) {
 	startTime := time.Now().Add(-10 * time.Second)
 
 	// Get handler function
-	handler := DetailedHealthCheck(startTime)
+	handler := DetailedHealthCheck(startTime, stocks.NewService(nil), health.NewRegistry(time.Second, 0))
 
 	// Create test request
 	req := httptest.NewRequest(http.MethodGet, "/health/detailed", nil)
@@ -119,7 +210,7 @@ func TestDetailedHealthCheck_MemoryMetrics(t *testing.T) {
 	startTime := time.Now()
 
 	// Get handler function
-	handler := DetailedHealthCheck(startTime)
+	handler := DetailedHealthCheck(startTime, stocks.NewService(nil), health.NewRegistry(time.Second, 0))
 
 	// Create test request
 	req := httptest.NewRequest(http.MethodGet, "/health/detailed", nil)
@@ -151,7 +242,7 @@ func TestDetailedHealthCheck_SystemInfo(t *testing.T) {
 	startTime := time.Now()
 
 	// Get handler function
-	handler := DetailedHealthCheck(startTime)
+	handler := DetailedHealthCheck(startTime, stocks.NewService(nil), health.NewRegistry(time.Second, 0))
 
 	// Create test request
 	req := httptest.NewRequest(http.MethodGet, "/health/detailed", nil)
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/f00b455/blank-go/pkg/dax"
+	"github.com/gin-gonic/gin"
+)
+
+// ImportJobHandler handles asynchronous DAX import job requests.
+type ImportJobHandler struct {
+	manager *dax.ImportJobManager
+}
+
+// NewImportJobHandler creates a new import job handler.
+func NewImportJobHandler(manager *dax.ImportJobManager) *ImportJobHandler {
+	return &ImportJobHandler{manager: manager}
+}
+
+// Submit handles POST /dax/imports: it buffers the request body, enqueues
+// a background import, and returns 202 Accepted with the job's ID and
+// status URL. Repeated submissions sharing an Idempotency-Key header
+// within the configured TTL return the original job instead of starting a
+// new import.
+func (h *ImportJobHandler) Submit(c *gin.Context) {
+	var reader io.Reader
+	contentType := c.ContentType()
+
+	if file, ferr := c.FormFile("file"); ferr == nil {
+		openedFile, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "failed to open file",
+			})
+			return
+		}
+		defer func() { _ = openedFile.Close() }()
+
+		reader = openedFile
+		if fileContentType := file.Header.Get("Content-Type"); dax.ImporterForContentType(fileContentType) != nil {
+			contentType = fileContentType
+		} else {
+			contentType = "text/csv"
+		}
+	} else {
+		reader = c.Request.Body
+	}
+
+	if dax.ImporterForContentType(contentType) == nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "unsupported content type: " + contentType,
+		})
+		return
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to read request body",
+		})
+		return
+	}
+
+	opts := dax.ImportCSVOptions{
+		BatchSize:       parseIntQuery(c, "batch_size", 0),
+		ContinueOnError: c.Query("continue_on_error") == "true",
+		DryRun:          c.Query("dry_run") == "true",
+	}
+
+	job, err := h.manager.Submit(c.Request.Context(), data, contentType, opts, c.GetHeader("Idempotency-Key"), dax.TriggerAPI)
+	if err != nil {
+		if errors.Is(err, dax.ErrManagerShuttingDown) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"job_id":     job.ID,
+		"status_url": "/api/v1/dax/imports/" + job.ID,
+	})
+}
+
+// Get handles GET /dax/imports/:id, returning the current state of an
+// import job.
+func (h *ImportJobHandler) Get(c *gin.Context) {
+	job, err := h.manager.Get(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, dax.ErrJobNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, jobJSON(job))
+}
+
+// List handles GET /dax/imports, returning import jobs newest-first with
+// pagination metadata.
+func (h *ImportJobHandler) List(c *gin.Context) {
+	page := parseIntQuery(c, "page", 1)
+	limit := parseIntQuery(c, "limit", 10)
+
+	jobs, pagination, err := h.manager.List(page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	data := make([]gin.H, 0, len(jobs))
+	for _, job := range jobs {
+		data = append(data, jobJSON(job))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       data,
+		"pagination": pagination,
+	})
+}
+
+// Stop handles POST /dax/imports/:id/stop, canceling a pending or running
+// import job.
+func (h *ImportJobHandler) Stop(c *gin.Context) {
+	err := h.manager.Stop(c.Param("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, dax.ErrJobNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+		case errors.Is(err, dax.ErrJobNotCancelable):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	job, err := h.manager.Get(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, jobJSON(job))
+}
+
+// jobJSON renders job the same way across Get, List, and Stop.
+func jobJSON(job *dax.ImportJob) gin.H {
+	return gin.H{
+		"id":                  job.ID,
+		"status":              job.Status,
+		"trigger":             job.Trigger,
+		"records_processed":   job.RecordsProcessed,
+		"records_total":       job.RecordsTotal,
+		"records_succeeded":   job.RecordsSucceeded,
+		"records_failed":      job.RecordsFailed,
+		"records_in_progress": job.InProgress(),
+		"errors":              job.Errors,
+		"started_at":          job.StartedAt,
+		"finished_at":         job.FinishedAt,
+	}
+}
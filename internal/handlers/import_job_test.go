@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/dax"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupImportJobHandler() *ImportJobHandler {
+	repo := dax.NewInMemoryRepository()
+	service := dax.NewService(repo)
+	store := dax.NewInMemoryJobStore()
+	manager := dax.NewImportJobManager(service, store, time.Hour)
+	return NewImportJobHandler(manager)
+}
+
+func waitForJobStatus(t *testing.T, handler *ImportJobHandler, jobID, want string) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: jobID}}
+		handler.Get(c)
+		if strings.Contains(w.Body.String(), want) {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("job %s did not reach status %s in time", jobID, want)
+}
+
+func TestImportJobHandler_Submit_Accepted(t *testing.T) {
+	handler := setupImportJobHandler()
+
+	csvContent := `company,ticker,report_type,metric,year,value,currency
+Siemens AG,SIE,income,EBITDA,2025,15859000000.0,EUR`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/api/v1/dax/imports", strings.NewReader(csvContent))
+	c.Request.Header.Set("Content-Type", "text/csv")
+
+	handler.Submit(c)
+
+	require.Equal(t, http.StatusAccepted, w.Code)
+	assert.Contains(t, w.Body.String(), "job_id")
+	assert.Contains(t, w.Body.String(), "status_url")
+}
+
+func TestImportJobHandler_Submit_UnsupportedContentType(t *testing.T) {
+	handler := setupImportJobHandler()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/api/v1/dax/imports", strings.NewReader("<xml/>"))
+	c.Request.Header.Set("Content-Type", "application/xml")
+
+	handler.Submit(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "unsupported content type")
+}
+
+func TestImportJobHandler_Submit_DedupesIdempotencyKey(t *testing.T) {
+	handler := setupImportJobHandler()
+
+	csvContent := `company,ticker,report_type,metric,year,value,currency
+Siemens AG,SIE,income,EBITDA,2025,15859000000.0,EUR`
+
+	submit := func() string {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/api/v1/dax/imports", strings.NewReader(csvContent))
+		c.Request.Header.Set("Content-Type", "text/csv")
+		c.Request.Header.Set("Idempotency-Key", "dup-key")
+		handler.Submit(c)
+		require.Equal(t, http.StatusAccepted, w.Code)
+		return w.Body.String()
+	}
+
+	first := submit()
+	second := submit()
+
+	require.Equal(t, first, second)
+}
+
+func TestImportJobHandler_Get_NotFound(t *testing.T) {
+	handler := setupImportJobHandler()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "missing"}}
+
+	handler.Get(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestImportJobHandler_Get_ReflectsCompletedImport(t *testing.T) {
+	handler := setupImportJobHandler()
+
+	csvContent := `company,ticker,report_type,metric,year,value,currency
+Siemens AG,SIE,income,EBITDA,2025,15859000000.0,EUR`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/api/v1/dax/imports", strings.NewReader(csvContent))
+	c.Request.Header.Set("Content-Type", "text/csv")
+	handler.Submit(c)
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	var submitResponse struct {
+		JobID string `json:"job_id"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &submitResponse))
+	require.NotEmpty(t, submitResponse.JobID)
+
+	waitForJobStatus(t, handler, submitResponse.JobID, "succeeded")
+}
+
+func TestImportJobHandler_List_ReturnsJobsAndPagination(t *testing.T) {
+	handler := setupImportJobHandler()
+
+	csvContent := `company,ticker,report_type,metric,year,value,currency
+Siemens AG,SIE,income,EBITDA,2025,15859000000.0,EUR`
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/api/v1/dax/imports", strings.NewReader(csvContent))
+		c.Request.Header.Set("Content-Type", "text/csv")
+		handler.Submit(c)
+		require.Equal(t, http.StatusAccepted, w.Code)
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/api/v1/dax/imports", nil)
+
+	handler.List(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "pagination")
+
+	var body struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Len(t, body.Data, 2)
+}
+
+func TestImportJobHandler_Stop_NotFound(t *testing.T) {
+	handler := setupImportJobHandler()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "missing"}}
+
+	handler.Stop(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestImportJobHandler_Stop_AlreadyFinishedConflicts(t *testing.T) {
+	handler := setupImportJobHandler()
+
+	csvContent := `company,ticker,report_type,metric,year,value,currency
+Siemens AG,SIE,income,EBITDA,2025,15859000000.0,EUR`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/api/v1/dax/imports", strings.NewReader(csvContent))
+	c.Request.Header.Set("Content-Type", "text/csv")
+	handler.Submit(c)
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	var submitResponse struct {
+		JobID string `json:"job_id"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &submitResponse))
+	waitForJobStatus(t, handler, submitResponse.JobID, "succeeded")
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: submitResponse.JobID}}
+
+	handler.Stop(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
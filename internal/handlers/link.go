@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setLinkHeader builds an RFC 5988 Link header from rels (ordered
+// rel -> cursor, e.g. "next" -> the opaque next-page cursor) and sets it on
+// the response, so cursor-paginated endpoints expose their neighboring
+// pages without the client needing to construct cursor URLs itself.
+// baseURL is the request's own URL with any existing cursor/page/offset
+// query params already stripped.
+func setLinkHeader(c *gin.Context, baseURL *url.URL, rels map[string]string, order []string) {
+	var links []string
+	for _, rel := range order {
+		cursor, ok := rels[rel]
+		if !ok {
+			continue
+		}
+
+		pageURL := *baseURL
+		q := pageURL.Query()
+		if cursor == "" {
+			q.Del("cursor")
+		} else {
+			q.Set("cursor", cursor)
+		}
+		pageURL.RawQuery = q.Encode()
+
+		links = append(links, fmt.Sprintf(`<%s>; rel="%s"`, pageURL.String(), rel))
+	}
+
+	if len(links) > 0 {
+		c.Header("Link", strings.Join(links, ", "))
+	}
+}
+
+// setTotalCountHeader sets the X-Total-Count header cursor-paginated list
+// endpoints return alongside their Link header, since a keyset page can't
+// otherwise tell a client how many pages remain.
+func setTotalCountHeader(c *gin.Context, total int) {
+	c.Header("X-Total-Count", strconv.Itoa(total))
+}
+
+// setLegacyOffsetDeprecationHeader warns a caller still using the
+// limit/offset query parameters that they're deprecated in favor of
+// ?cursor=, without breaking their request.
+func setLegacyOffsetDeprecationHeader(c *gin.Context) {
+	c.Header("Warning", `299 - "limit/offset pagination is deprecated, use ?cursor= instead"`)
+}
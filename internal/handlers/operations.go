@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/f00b455/blank-go/pkg/operations"
+	"github.com/gin-gonic/gin"
+)
+
+// OperationsHandler exposes polling and cancellation for background work
+// started through an operations.Registry (for example, an async DAX
+// import kicked off via DAXHandler.ImportCSV).
+type OperationsHandler struct {
+	registry *operations.Registry
+}
+
+// NewOperationsHandler creates a new operations handler.
+func NewOperationsHandler(registry *operations.Registry) *OperationsHandler {
+	return &OperationsHandler{registry: registry}
+}
+
+// Get handles GET /operations/:id, returning the current state of a
+// background operation.
+//
+// @Summary      Get an operation
+// @Description  Fetches the status, progress, and result of a background operation
+// @Tags         operations
+// @Produce      json
+// @Param        id   path      string  true  "Operation ID"
+// @Success      200  {object}  operations.Operation
+// @Failure      404  {object}  map[string]string
+// @Router       /operations/{id} [get]
+func (h *OperationsHandler) Get(c *gin.Context) {
+	op, err := h.registry.Get(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, operations.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "operation not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, op)
+}
+
+// Cancel handles DELETE /operations/:id, canceling a pending or running
+// operation.
+//
+// @Summary      Cancel an operation
+// @Description  Cancels a pending or running background operation
+// @Tags         operations
+// @Produce      json
+// @Param        id   path      string  true  "Operation ID"
+// @Success      200  {object}  operations.Operation
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Router       /operations/{id} [delete]
+func (h *OperationsHandler) Cancel(c *gin.Context) {
+	err := h.registry.Cancel(c.Param("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, operations.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "operation not found"})
+		case errors.Is(err, operations.ErrNotCancelable):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	op, err := h.registry.Get(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, op)
+}
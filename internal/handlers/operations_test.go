@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/operations"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func waitForOperationStatus(t *testing.T, handler *OperationsHandler, id, want string) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: id}}
+		handler.Get(c)
+		if w.Code == http.StatusOK && strings.Contains(w.Body.String(), `"status":"`+want+`"`) {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("operation %s did not reach status %s in time", id, want)
+}
+
+func TestOperationsHandler_Get_NotFound(t *testing.T) {
+	handler := NewOperationsHandler(operations.NewRegistry())
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "missing"}}
+
+	handler.Get(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestOperationsHandler_Get_ReflectsCompletedOperation(t *testing.T) {
+	registry := operations.NewRegistry()
+	handler := NewOperationsHandler(registry)
+
+	op := registry.Run(func(ctx context.Context, report func(int)) (interface{}, error) {
+		report(50)
+		return "done", nil
+	})
+
+	waitForOperationStatus(t, handler, op.ID, "succeeded")
+}
+
+func TestOperationsHandler_Cancel_NotFound(t *testing.T) {
+	handler := NewOperationsHandler(operations.NewRegistry())
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "missing"}}
+
+	handler.Cancel(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestOperationsHandler_Cancel_AlreadyFinishedConflicts(t *testing.T) {
+	registry := operations.NewRegistry()
+	handler := NewOperationsHandler(registry)
+
+	op := registry.Run(func(ctx context.Context, report func(int)) (interface{}, error) {
+		return nil, nil
+	})
+	waitForOperationStatus(t, handler, op.ID, "succeeded")
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: op.ID}}
+
+	handler.Cancel(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestOperationsHandler_Cancel_StopsRunningOperation(t *testing.T) {
+	registry := operations.NewRegistry()
+	handler := NewOperationsHandler(registry)
+	started := make(chan struct{})
+
+	op := registry.Run(func(ctx context.Context, report func(int)) (interface{}, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	<-started
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: op.ID}}
+
+	handler.Cancel(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"canceled"`)
+}
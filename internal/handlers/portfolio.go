@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/f00b455/blank-go/internal/portfolio"
+	"github.com/gin-gonic/gin"
+)
+
+// PortfolioHandler handles HTTP requests for portfolio and alert operations.
+type PortfolioHandler struct {
+	service *portfolio.Service
+}
+
+// NewPortfolioHandler creates a new portfolio handler.
+func NewPortfolioHandler(service *portfolio.Service) *PortfolioHandler {
+	return &PortfolioHandler{
+		service: service,
+	}
+}
+
+// CreatePortfolio handles POST /api/v1/portfolio
+func (h *PortfolioHandler) CreatePortfolio(c *gin.Context) {
+	var req portfolio.CreatePortfolioRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	created, err := h.service.Create(req)
+	if err != nil {
+		handlePortfolioError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// ListPortfolios handles GET /api/v1/portfolio
+func (h *PortfolioHandler) ListPortfolios(c *gin.Context) {
+	portfolios, err := h.service.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve portfolios"})
+		return
+	}
+
+	c.JSON(http.StatusOK, portfolios)
+}
+
+// GetPortfolio handles GET /api/v1/portfolio/:id
+func (h *PortfolioHandler) GetPortfolio(c *gin.Context) {
+	id := c.Param("id")
+
+	found, err := h.service.GetByID(id)
+	if err != nil {
+		handlePortfolioError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, found)
+}
+
+// DeletePortfolio handles DELETE /api/v1/portfolio/:id
+func (h *PortfolioHandler) DeletePortfolio(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.service.Delete(id); err != nil {
+		handlePortfolioError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "portfolio deleted successfully"})
+}
+
+// ListAlerts handles GET /api/v1/portfolio/alerts?portfolio_id=...
+func (h *PortfolioHandler) ListAlerts(c *gin.Context) {
+	portfolioID := c.Query("portfolio_id")
+
+	alerts, err := h.service.GetAlerts(portfolioID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve alerts"})
+		return
+	}
+
+	c.JSON(http.StatusOK, alerts)
+}
+
+// handlePortfolioError converts portfolio service errors to HTTP responses.
+func handlePortfolioError(c *gin.Context, err error) {
+	if errors.Is(err, portfolio.ErrPortfolioNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	switch {
+	case errors.Is(err, portfolio.ErrInvalidName),
+		errors.Is(err, portfolio.ErrInvalidHoldings),
+		errors.Is(err, portfolio.ErrInvalidRuleType):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
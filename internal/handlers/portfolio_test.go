@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/f00b455/blank-go/internal/portfolio"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPortfolioHandler() (*PortfolioHandler, *portfolio.InMemoryStore) {
+	store := portfolio.NewInMemoryStore()
+	service := portfolio.NewService(store, nil, portfolio.NoopSink{})
+	return NewPortfolioHandler(service), store
+}
+
+func TestPortfolioHandler_CreatePortfolio(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, _ := newTestPortfolioHandler()
+
+	router := gin.New()
+	router.POST("/portfolio", handler.CreatePortfolio)
+
+	t.Run("valid portfolio", func(t *testing.T) {
+		body, _ := json.Marshal(portfolio.CreatePortfolioRequest{
+			Name:     "Retirement",
+			Holdings: []portfolio.Holding{{Ticker: "AAPL", Shares: 10, CostBasis: 100}},
+		})
+		req := httptest.NewRequest(http.MethodPost, "/portfolio", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("missing holdings", func(t *testing.T) {
+		body, _ := json.Marshal(portfolio.CreatePortfolioRequest{Name: "Empty"})
+		req := httptest.NewRequest(http.MethodPost, "/portfolio", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/portfolio", bytes.NewReader([]byte("not json")))
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestPortfolioHandler_GetPortfolio(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, store := newTestPortfolioHandler()
+	require.NoError(t, store.Create(&portfolio.Portfolio{ID: "p1", Name: "Retirement"}))
+
+	router := gin.New()
+	router.GET("/portfolio/:id", handler.GetPortfolio)
+
+	t.Run("found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/portfolio/p1", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/portfolio/missing", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestPortfolioHandler_ListPortfolios(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, store := newTestPortfolioHandler()
+	require.NoError(t, store.Create(&portfolio.Portfolio{ID: "p1", Name: "A"}))
+	require.NoError(t, store.Create(&portfolio.Portfolio{ID: "p2", Name: "B"}))
+
+	router := gin.New()
+	router.GET("/portfolio", handler.ListPortfolios)
+
+	req := httptest.NewRequest(http.MethodGet, "/portfolio", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var got []portfolio.Portfolio
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Len(t, got, 2)
+}
+
+func TestPortfolioHandler_DeletePortfolio(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, store := newTestPortfolioHandler()
+	require.NoError(t, store.Create(&portfolio.Portfolio{ID: "p1", Name: "A"}))
+
+	router := gin.New()
+	router.DELETE("/portfolio/:id", handler.DeletePortfolio)
+
+	req := httptest.NewRequest(http.MethodDelete, "/portfolio/p1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest(http.MethodDelete, "/portfolio/p1", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPortfolioHandler_ListAlerts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, store := newTestPortfolioHandler()
+	require.NoError(t, store.SaveAlert(&portfolio.Alert{ID: "a1", PortfolioID: "p1"}))
+	require.NoError(t, store.SaveAlert(&portfolio.Alert{ID: "a2", PortfolioID: "p2"}))
+
+	router := gin.New()
+	router.GET("/portfolio/alerts", handler.ListAlerts)
+
+	req := httptest.NewRequest(http.MethodGet, "/portfolio/alerts?portfolio_id=p1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var got []portfolio.Alert
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	require.Len(t, got, 1)
+	assert.Equal(t, "a1", got[0].ID)
+}
@@ -1,48 +1,89 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/f00b455/blank-go/internal/execution"
 	"github.com/f00b455/blank-go/pkg/stocks"
 	"github.com/gin-gonic/gin"
 )
 
+// retryAfterSeconds is the Retry-After value (in seconds) sent alongside a
+// 503 response while the stocks circuit breaker is open, matching
+// stocks.DefaultResilientClientConfig's cooldown.
+const retryAfterSeconds = 30
+
 // StocksService defines the interface for stocks business logic
 type StocksService interface {
-	GetSummary(ticker string) (*stocks.StockSummary, error)
-	GetBatchSummary(tickers string) (*stocks.BatchResponse, error)
+	GetSummary(ctx context.Context, ticker string) (*stocks.StockSummary, error)
+	GetSummaryForSession(ctx context.Context, ticker, session string) (*stocks.StockSummary, bool, error)
+	GetBatchSummary(ctx context.Context, tickers string) (*stocks.BatchResponse, error)
+	GetBars(ctx context.Context, ticker string, req stocks.BarsRequest) (*stocks.BarsResponse, error)
+	GetMultiBars(ctx context.Context, tickers string, req stocks.BarsRequest) (*stocks.MultiBarsResponse, error)
 }
 
 // StocksHandler handles stock market HTTP requests
 type StocksHandler struct {
-	service StocksService
+	service    StocksService
+	executions *execution.Manager
 }
 
-// NewStocksHandler creates a new stocks handler
+// NewStocksHandler creates a new stocks handler whose GetBatchSummary
+// doesn't support ?async=true (see NewStocksHandlerWithExecutions).
 func NewStocksHandler(service StocksService) *StocksHandler {
+	return NewStocksHandlerWithExecutions(service, nil)
+}
+
+// NewStocksHandlerWithExecutions creates a stocks handler whose
+// GetBatchSummary runs ?async=true requests through executions as a
+// execution.KindStocksBatchSummary execution instead of blocking. A nil
+// executions makes ?async=true respond 503, the same way a nil manager
+// would fail any other submission.
+func NewStocksHandlerWithExecutions(service StocksService, executions *execution.Manager) *StocksHandler {
 	return &StocksHandler{
-		service: service,
+		service:    service,
+		executions: executions,
 	}
 }
 
-// GetStockSummary handles GET /api/v1/stocks/:ticker/summary
+// GetStockSummary handles GET /api/v1/stocks/:ticker/summary?session=regular|pre|post|extended
 func (h *StocksHandler) GetStockSummary(c *gin.Context) {
 	ticker := c.Param("ticker")
+	session := c.DefaultQuery("session", stocks.SessionRegular)
 
-	summary, err := h.service.GetSummary(ticker)
+	summary, cacheHit, err := h.service.GetSummaryForSession(c.Request.Context(), ticker, session)
 	if err != nil {
 		handleStockError(c, err)
 		return
 	}
 
+	if cacheHit {
+		c.Header("X-Cache", "HIT")
+	} else {
+		c.Header("X-Cache", "MISS")
+	}
+
 	c.JSON(http.StatusOK, summary)
 }
 
-// GetBatchSummary handles GET /api/v1/stocks/summary?tickers=AAPL,GOOGL,MSFT
+// GetBatchSummary handles GET /api/v1/stocks/summary?tickers=AAPL,GOOGL,MSFT.
+// With ?async=true it submits the fetch as a execution.KindStocksBatchSummary
+// execution and returns 202 Accepted with the execution's ID instead of
+// blocking until every ticker resolves.
 func (h *StocksHandler) GetBatchSummary(c *gin.Context) {
 	tickers := c.Query("tickers")
 
-	response, err := h.service.GetBatchSummary(tickers)
+	if c.Query("async") == "true" {
+		h.getBatchSummaryAsync(c, tickers)
+		return
+	}
+
+	response, err := h.service.GetBatchSummary(c.Request.Context(), tickers)
 	if err != nil {
 		handleStockError(c, err)
 		return
@@ -61,8 +102,128 @@ func (h *StocksHandler) GetBatchSummary(c *gin.Context) {
 	c.JSON(statusCode, response)
 }
 
+// getBatchSummaryAsync submits tickers as a execution.KindStocksBatchSummary
+// execution.
+func (h *StocksHandler) getBatchSummaryAsync(c *gin.Context, tickers string) {
+	if h.executions == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "async batch summaries are not available"})
+		return
+	}
+
+	payload, err := json.Marshal(execution.BatchSummaryPayload{Tickers: tickers})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	exec, err := h.executions.Submit(c.Request.Context(), execution.KindStocksBatchSummary, payload)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"id":         exec.ID,
+		"status_url": "/api/v1/executions/" + exec.ID,
+	})
+}
+
+// GetBars handles GET /api/v1/stocks/:ticker/bars?start=...&end=...&timeframe=1Day&adjustment=raw&limit=100&page_token=...
+func (h *StocksHandler) GetBars(c *gin.Context) {
+	ticker := c.Param("ticker")
+
+	start, err := time.Parse(time.RFC3339, c.Query("start"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start"})
+		return
+	}
+
+	end, err := time.Parse(time.RFC3339, c.Query("end"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end"})
+		return
+	}
+
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+	}
+
+	req := stocks.BarsRequest{
+		Start:      start,
+		End:        end,
+		Timeframe:  c.Query("timeframe"),
+		Adjustment: c.DefaultQuery("adjustment", stocks.AdjustmentRaw),
+		Limit:      limit,
+		PageToken:  c.Query("page_token"),
+	}
+
+	response, err := h.service.GetBars(c.Request.Context(), ticker, req)
+	if err != nil {
+		handleStockError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetMultiBars handles GET /api/v1/stocks/bars?tickers=AAPL,GOOGL&start=...&end=...&timeframe=1Day&adjustment=raw,
+// fetching bars for several tickers in parallel the way GetBatchSummary does for quotes.
+func (h *StocksHandler) GetMultiBars(c *gin.Context) {
+	tickers := c.Query("tickers")
+
+	start, err := time.Parse(time.RFC3339, c.Query("start"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start"})
+		return
+	}
+
+	end, err := time.Parse(time.RFC3339, c.Query("end"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end"})
+		return
+	}
+
+	req := stocks.BarsRequest{
+		Start:      start,
+		End:        end,
+		Timeframe:  c.Query("timeframe"),
+		Adjustment: c.DefaultQuery("adjustment", stocks.AdjustmentRaw),
+	}
+
+	response, err := h.service.GetMultiBars(c.Request.Context(), tickers, req)
+	if err != nil {
+		handleStockError(c, err)
+		return
+	}
+
+	statusCode := http.StatusOK
+	if len(response.Errors) > 0 && len(response.Bars) > 0 {
+		statusCode = http.StatusMultiStatus
+	} else if len(response.Errors) > 0 && len(response.Bars) == 0 {
+		statusCode = http.StatusNotFound
+	}
+
+	c.JSON(statusCode, response)
+}
+
 // handleStockError maps service errors to HTTP responses
 func handleStockError(c *gin.Context, err error) {
+	if errors.Is(err, stocks.ErrUpstreamUnavailable) {
+		c.Header("Retry-After", strconv.Itoa(retryAfterSeconds))
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		c.JSON(http.StatusGatewayTimeout, gin.H{"error": err.Error()})
+		return
+	}
+
 	errMsg := err.Error()
 
 	switch errMsg {
@@ -74,6 +235,18 @@ func handleStockError(c *gin.Context, err error) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
 	case "at least one valid ticker is required":
 		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+	case "invalid session":
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+	case "invalid timeframe":
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+	case "invalid adjustment":
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+	case "start and end are required":
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+	case "start must be before end":
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+	case "invalid page_token":
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
 	default:
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 	}
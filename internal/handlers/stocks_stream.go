@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/f00b455/blank-go/pkg/stocks/stream"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// streamUpgrader upgrades HTTP connections to WebSocket for the stocks
+// stream endpoint. Origin checking is left to the CORS middleware in front
+// of this handler.
+var streamUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// StreamHub creates per-connection stream.StreamClient sessions.
+type StreamHub interface {
+	NewSession() stream.StreamClient
+}
+
+// StocksStreamHandler upgrades HTTP connections to WebSocket and relays live
+// quote updates from a StreamHub session.
+type StocksStreamHandler struct {
+	hub StreamHub
+}
+
+// NewStocksStreamHandler creates a new stocks stream handler.
+func NewStocksStreamHandler(hub StreamHub) *StocksStreamHandler {
+	return &StocksStreamHandler{hub: hub}
+}
+
+// HandleStream handles GET /api/v1/stocks/stream, upgrading the connection to
+// a WebSocket that accepts subscribe/unsubscribe control frames and pushes
+// quote/trade/bar/error data frames as they arrive.
+func (h *StocksStreamHandler) HandleStream(c *gin.Context) {
+	conn, err := streamUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Printf("stocks stream: upgrade failed: %v", err)
+		return
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	session := h.hub.NewSession()
+	defer func() {
+		_ = session.Close()
+	}()
+
+	quotes, err := session.Subscribe(nil)
+	if err != nil {
+		_ = conn.WriteJSON(stream.DataMessage{Type: stream.TypeError, Error: err.Error()})
+		return
+	}
+	trades, err := session.SubscribeTrades(nil)
+	if err != nil {
+		_ = conn.WriteJSON(stream.DataMessage{Type: stream.TypeError, Error: err.Error()})
+		return
+	}
+	bars, err := session.SubscribeBars(nil)
+	if err != nil {
+		_ = conn.WriteJSON(stream.DataMessage{Type: stream.TypeError, Error: err.Error()})
+		return
+	}
+
+	done := make(chan struct{})
+	go h.writeQuotes(conn, quotes, done)
+	go h.writeTrades(conn, trades, done)
+	go h.writeBars(conn, bars, done)
+	h.readControlFrames(conn, session)
+	close(done)
+}
+
+// writeQuotes relays quotes from the session to the client until done is
+// closed or the connection write fails.
+func (h *StocksStreamHandler) writeQuotes(conn *websocket.Conn, quotes <-chan stream.Quote, done <-chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		case quote, ok := <-quotes:
+			if !ok {
+				return
+			}
+			if err := conn.WriteJSON(stream.DataMessage{Type: stream.TypeQuote, Quote: &quote}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// writeTrades relays trades from the session to the client until done is
+// closed or the connection write fails.
+func (h *StocksStreamHandler) writeTrades(conn *websocket.Conn, trades <-chan stream.Trade, done <-chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		case trade, ok := <-trades:
+			if !ok {
+				return
+			}
+			if err := conn.WriteJSON(stream.DataMessage{Type: stream.TypeTrade, Trade: &trade}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// writeBars relays bars from the session to the client until done is closed
+// or the connection write fails.
+func (h *StocksStreamHandler) writeBars(conn *websocket.Conn, bars <-chan stream.Bar, done <-chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		case bar, ok := <-bars:
+			if !ok {
+				return
+			}
+			if err := conn.WriteJSON(stream.DataMessage{Type: stream.TypeBar, Bar: &bar}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readControlFrames reads subscribe/unsubscribe control frames from the
+// client until the connection closes or an unrecoverable read error occurs.
+// Tickers subscribes/unsubscribes the quote stream; Trades and Bars do the
+// same for their own streams.
+func (h *StocksStreamHandler) readControlFrames(conn *websocket.Conn, session stream.StreamClient) {
+	for {
+		var ctrl stream.ControlMessage
+		if err := conn.ReadJSON(&ctrl); err != nil {
+			return
+		}
+
+		for _, opErr := range h.applyControl(session, ctrl) {
+			if opErr != nil {
+				_ = conn.WriteJSON(stream.DataMessage{Type: stream.TypeError, Error: opErr.Error()})
+			}
+		}
+	}
+}
+
+// applyControl dispatches a single control frame's quote/trade/bar ticker
+// lists to session, returning the error (if any) from each operation.
+func (h *StocksStreamHandler) applyControl(session stream.StreamClient, ctrl stream.ControlMessage) []error {
+	var errs []error
+
+	switch ctrl.Action {
+	case stream.ActionSubscribe:
+		_, err := session.Subscribe(ctrl.Tickers)
+		errs = append(errs, err)
+		if len(ctrl.Trades) > 0 {
+			_, err := session.SubscribeTrades(ctrl.Trades)
+			errs = append(errs, err)
+		}
+		if len(ctrl.Bars) > 0 {
+			_, err := session.SubscribeBars(ctrl.Bars)
+			errs = append(errs, err)
+		}
+	case stream.ActionUnsubscribe:
+		errs = append(errs, session.Unsubscribe(ctrl.Tickers))
+		if len(ctrl.Trades) > 0 {
+			errs = append(errs, session.UnsubscribeTrades(ctrl.Trades))
+		}
+		if len(ctrl.Bars) > 0 {
+			errs = append(errs, session.UnsubscribeBars(ctrl.Bars))
+		}
+	}
+
+	return errs
+}
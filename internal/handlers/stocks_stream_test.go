@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/stocks/stream"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStocksStreamHandler_SubscribeAndReceiveQuote(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	upstream := newFakeStreamUpstream()
+	hub := stream.NewHub(upstream)
+	defer hub.Close()
+
+	router := gin.New()
+	router.GET("/stocks/stream", NewStocksStreamHandler(hub).HandleStream)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/stocks/stream"
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	require.NoError(t, conn.WriteJSON(stream.ControlMessage{Action: stream.ActionSubscribe, Tickers: []string{"AAPL"}}))
+
+	// Give the server a moment to process the subscribe frame before publishing.
+	require.Eventually(t, func() bool { return upstream.subscribedTo("AAPL") }, time.Second, 10*time.Millisecond)
+
+	upstream.push(stream.Quote{Ticker: "AAPL", Price: 185.5})
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var msg stream.DataMessage
+	require.NoError(t, conn.ReadJSON(&msg))
+
+	require.Equal(t, stream.TypeQuote, msg.Type)
+	require.NotNil(t, msg.Quote)
+	require.Equal(t, "AAPL", msg.Quote.Ticker)
+	require.Equal(t, 185.5, msg.Quote.Price)
+}
+
+// fakeStreamUpstream is a minimal stream.Upstream test double local to the
+// handlers package (the stream package's own fake isn't exported).
+type fakeStreamUpstream struct {
+	quoteCh    chan stream.Quote
+	tradeCh    chan stream.Trade
+	barCh      chan stream.Bar
+	subscribed chan string
+	seen       map[string]bool
+}
+
+func newFakeStreamUpstream() *fakeStreamUpstream {
+	return &fakeStreamUpstream{
+		quoteCh:    make(chan stream.Quote, 16),
+		tradeCh:    make(chan stream.Trade, 16),
+		barCh:      make(chan stream.Bar, 16),
+		subscribed: make(chan string, 16),
+		seen:       make(map[string]bool),
+	}
+}
+
+func (f *fakeStreamUpstream) Connect() error { return nil }
+
+func (f *fakeStreamUpstream) Subscribe(tickers []string) error {
+	for _, t := range tickers {
+		f.subscribed <- t
+	}
+	return nil
+}
+
+func (f *fakeStreamUpstream) Unsubscribe(tickers []string) error { return nil }
+
+func (f *fakeStreamUpstream) Quotes() <-chan stream.Quote { return f.quoteCh }
+
+func (f *fakeStreamUpstream) Trades() <-chan stream.Trade { return f.tradeCh }
+
+func (f *fakeStreamUpstream) Bars() <-chan stream.Bar { return f.barCh }
+
+func (f *fakeStreamUpstream) Close() error { return nil }
+
+func (f *fakeStreamUpstream) push(q stream.Quote) { f.quoteCh <- q }
+
+func (f *fakeStreamUpstream) subscribedTo(ticker string) bool {
+	for {
+		select {
+		case t := <-f.subscribed:
+			f.seen[t] = true
+		default:
+			return f.seen[ticker]
+		}
+	}
+}
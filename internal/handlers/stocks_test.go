@@ -12,6 +12,7 @@ import (
 	"github.com/f00b455/blank-go/pkg/stocks"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestStocksHandler_GetStockSummary(t *testing.T) {
@@ -22,7 +23,9 @@ func TestStocksHandler_GetStockSummary(t *testing.T) {
 		ticker         string
 		mockSummary    *stocks.StockSummary
 		mockError      error
+		cacheHit       bool
 		expectedStatus int
+		expectedCache  string
 		checkResponse  func(t *testing.T, body map[string]interface{})
 	}{
 		{
@@ -42,7 +45,9 @@ func TestStocksHandler_GetStockSummary(t *testing.T) {
 				Currency:      "USD",
 				UpdatedAt:     time.Now(),
 			},
+			cacheHit:       false,
 			expectedStatus: http.StatusOK,
+			expectedCache:  "MISS",
 			checkResponse: func(t *testing.T, body map[string]interface{}) {
 				assert.Equal(t, "AAPL", body["ticker"])
 				assert.Equal(t, "Apple Inc.", body["name"])
@@ -75,9 +80,9 @@ func TestStocksHandler_GetStockSummary(t *testing.T) {
 			handler := NewStocksHandler(mockService)
 
 			if tt.mockSummary != nil {
-				mockService.On("GetSummary", tt.ticker).Return(tt.mockSummary, tt.mockError)
+				mockService.On("GetSummaryForSession", mock.Anything, tt.ticker, "regular").Return(tt.mockSummary, tt.cacheHit, tt.mockError)
 			} else {
-				mockService.On("GetSummary", tt.ticker).Return(nil, tt.mockError)
+				mockService.On("GetSummaryForSession", mock.Anything, tt.ticker, "regular").Return(nil, false, tt.mockError)
 			}
 
 			router := gin.New()
@@ -89,6 +94,9 @@ func TestStocksHandler_GetStockSummary(t *testing.T) {
 			router.ServeHTTP(w, req)
 
 			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedCache != "" {
+				assert.Equal(t, tt.expectedCache, w.Header().Get("X-Cache"))
+			}
 
 			var response map[string]interface{}
 			err := json.Unmarshal(w.Body.Bytes(), &response)
@@ -103,6 +111,42 @@ func TestStocksHandler_GetStockSummary(t *testing.T) {
 	}
 }
 
+func TestStocksHandler_GetStockSummary_Session(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedSession string
+	}{
+		{name: "default session", query: "", expectedSession: "regular"},
+		{name: "pre session", query: "?session=pre", expectedSession: "pre"},
+		{name: "post session", query: "?session=post", expectedSession: "post"},
+		{name: "extended session", query: "?session=extended", expectedSession: "extended"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.MockStocksService)
+			handler := NewStocksHandler(mockService)
+
+			mockService.On("GetSummaryForSession", mock.Anything, "AAPL", tt.expectedSession).
+				Return(&stocks.StockSummary{Ticker: "AAPL"}, false, nil)
+
+			router := gin.New()
+			router.GET("/stocks/:ticker/summary", handler.GetStockSummary)
+
+			req := httptest.NewRequest(http.MethodGet, "/stocks/AAPL/summary"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 func TestStocksHandler_GetBatchSummary(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -190,9 +234,9 @@ func TestStocksHandler_GetBatchSummary(t *testing.T) {
 			handler := NewStocksHandler(mockService)
 
 			if tt.mockResponse != nil {
-				mockService.On("GetBatchSummary", tt.tickers).Return(tt.mockResponse, tt.mockError)
+				mockService.On("GetBatchSummary", mock.Anything, tt.tickers).Return(tt.mockResponse, tt.mockError)
 			} else {
-				mockService.On("GetBatchSummary", tt.tickers).Return(nil, tt.mockError)
+				mockService.On("GetBatchSummary", mock.Anything, tt.tickers).Return(nil, tt.mockError)
 			}
 
 			router := gin.New()
@@ -245,6 +289,12 @@ func TestHandleStockError(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedMsg:    "tickers parameter is required",
 		},
+		{
+			name:           "invalid session",
+			error:          errors.New("invalid session"),
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "invalid session",
+		},
 		{
 			name:           "internal server error",
 			error:          errors.New("unknown error"),
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/f00b455/blank-go/pkg/stocks"
+	"github.com/gin-gonic/gin"
+)
+
+// StocksWatchService is the subset of stocks.Service the watch endpoint
+// needs: a single multiplexed subscription per set of tickers.
+type StocksWatchService interface {
+	Watch(ctx context.Context, tickers []string) (<-chan stocks.StockEvent, error)
+}
+
+// StocksWatchHandler streams live stock updates over Server-Sent Events.
+type StocksWatchHandler struct {
+	service StocksWatchService
+}
+
+// NewStocksWatchHandler creates a new stocks watch handler.
+func NewStocksWatchHandler(service StocksWatchService) *StocksWatchHandler {
+	return &StocksWatchHandler{service: service}
+}
+
+// HandleWatch handles GET /api/v1/stocks/watch?tickers=AAPL,GOOGL, streaming
+// a StockEvent as a server-sent "data:" line each time one of the requested
+// tickers' price or volume changes. The stream ends when the client
+// disconnects.
+func (h *StocksWatchHandler) HandleWatch(c *gin.Context) {
+	tickers := strings.Split(c.Query("tickers"), ",")
+
+	events, err := h.service.Watch(c.Request.Context(), tickers)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	c.Stream(func(w io.Writer) bool {
+		event, ok := <-events
+		if !ok {
+			return false
+		}
+
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return true
+		}
+
+		_, _ = fmt.Fprintf(w, "data: %s\n\n", payload)
+		return true
+	})
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/stocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStocksWatchService is a hand-rolled StocksWatchService test double.
+type fakeStocksWatchService struct {
+	events chan stocks.StockEvent
+	err    error
+}
+
+func (f *fakeStocksWatchService) Watch(ctx context.Context, tickers []string) (<-chan stocks.StockEvent, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.events, nil
+}
+
+func TestStocksWatchHandler_HandleWatch_StreamsEvents(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	events := make(chan stocks.StockEvent, 1)
+	events <- stocks.StockEvent{Type: stocks.StockEventSummary, Ticker: "AAPL", Summary: &stocks.StockSummary{Ticker: "AAPL"}}
+
+	router := gin.New()
+	router.GET("/stocks/watch", NewStocksWatchHandler(&fakeStocksWatchService{events: events}).HandleWatch)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/stocks/watch?tickers=AAPL", nil)
+	require.NoError(t, err)
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	scanner := bufio.NewScanner(resp.Body)
+	var sawAAPL bool
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.Contains(line, "AAPL") {
+			sawAAPL = true
+			close(events)
+		}
+	}
+	require.True(t, sawAAPL, "expected the streamed body to contain the AAPL event")
+}
+
+func TestStocksWatchHandler_HandleWatch_ServiceError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/stocks/watch?tickers=", nil)
+
+	NewStocksWatchHandler(&fakeStocksWatchService{err: errInvalidTickers}).HandleWatch(c)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+var errInvalidTickers = stocksWatchTestError("at least one ticker is required")
+
+type stocksWatchTestError string
+
+func (e stocksWatchTestError) Error() string { return string(e) }
@@ -1,26 +1,54 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/f00b455/blank-go/internal/auth"
+	"github.com/f00b455/blank-go/internal/logger"
 	"github.com/f00b455/blank-go/pkg/task"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
 // TaskHandler handles HTTP requests for task operations
 type TaskHandler struct {
-	service *task.Service
+	service          *task.Service
+	cursorSigningKey []byte
+	webhooks         task.WebhookRepository
 }
 
 // NewTaskHandler creates a new task handler
 func NewTaskHandler(service *task.Service) *TaskHandler {
+	return NewTaskHandlerWithCursorKey(service, "dev-cursor-signing-key")
+}
+
+// NewTaskHandlerWithCursorKey creates a task handler whose ListTasks signs
+// cursor-pagination tokens with cursorSigningKey (typically
+// cfg.Pagination.CursorSigningKey), so a cursor forged or tampered with by
+// a client fails verification instead of resuming from the wrong position.
+// RegisterWebhook persists into an in-memory WebhookRepository by
+// default; call SetWebhookRepository to back it with a durable one
+// instead.
+func NewTaskHandlerWithCursorKey(service *task.Service, cursorSigningKey string) *TaskHandler {
 	return &TaskHandler{
-		service: service,
+		service:          service,
+		cursorSigningKey: []byte(cursorSigningKey),
+		webhooks:         task.NewInMemoryWebhookRepository(),
 	}
 }
 
+// SetWebhookRepository replaces the repository RegisterWebhook persists
+// into, so a caller can back it with a durable store (e.g. the sqlite or
+// postgres task.Repository's webhook table) instead of the in-memory
+// default.
+func (h *TaskHandler) SetWebhookRepository(repo task.WebhookRepository) {
+	h.webhooks = repo
+}
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error ErrorDetail `json:"error"`
@@ -34,9 +62,20 @@ type ErrorDetail struct {
 }
 
 // CreateTask handles POST /api/v1/tasks
+//
+// @Summary      Create a task
+// @Description  Creates a new task
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        task  body      task.CreateTaskRequest  true  "Task to create"
+// @Success      201   {object}  task.Task
+// @Failure      400   {object}  ErrorResponse
+// @Router       /tasks [post]
 func (h *TaskHandler) CreateTask(c *gin.Context) {
 	var req task.CreateTaskRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c).Warn("invalid create task request body", zap.Error(err))
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error: ErrorDetail{
 				Code:    "INVALID_REQUEST",
@@ -47,7 +86,11 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 		return
 	}
 
-	createdTask, err := h.service.Create(req)
+	if principal, ok := auth.FromContext(c); ok {
+		req.CreatedBy = principal.Subject
+	}
+
+	createdTask, err := h.service.Create(c.Request.Context(), req)
 	if err != nil {
 		handleServiceError(c, err)
 		return
@@ -57,10 +100,19 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 }
 
 // GetTask handles GET /api/v1/tasks/:id
+//
+// @Summary      Get a task
+// @Description  Fetches a single task by ID
+// @Tags         tasks
+// @Produce      json
+// @Param        id   path      string  true  "Task ID"
+// @Success      200  {object}  task.Task
+// @Failure      404  {object}  ErrorResponse
+// @Router       /tasks/{id} [get]
 func (h *TaskHandler) GetTask(c *gin.Context) {
 	id := c.Param("id")
 
-	foundTask, err := h.service.GetByID(id)
+	foundTask, err := h.service.GetByID(c.Request.Context(), id)
 	if err != nil {
 		handleServiceError(c, err)
 		return
@@ -69,30 +121,124 @@ func (h *TaskHandler) GetTask(c *gin.Context) {
 	c.JSON(http.StatusOK, foundTask)
 }
 
-// ListTasks handles GET /api/v1/tasks
+// ListTasks handles GET /api/v1/tasks. A ?cursor= query parameter (even an
+// empty one, for the first page) switches it to opaque, HMAC-signed cursor
+// pagination, setting Link (rel="next"/"prev"/"first") and X-Total-Count
+// response headers; without it, ?limit=&?offset= are still honored for
+// compatibility, marked deprecated via a Warning header in favor of
+// ?cursor=, and with neither it returns the full, unpaginated list as before.
+//
+// @Summary      List tasks
+// @Description  Lists tasks, optionally filtered and cursor- or offset-paginated
+// @Tags         tasks
+// @Produce      json
+// @Param        status    query     string  false  "Filter by status"
+// @Param        priority  query     string  false  "Filter by priority"
+// @Param        tag       query     string  false  "Filter by tag"
+// @Param        q         query     string  false  "Search title/description"
+// @Param        tags      query     string  false  "Comma-separated tags"
+// @Param        tags_mode query     string  false  "any (default) or all"
+// @Param        cursor    query     string  false  "Opaque pagination cursor"
+// @Param        limit     query     int     false  "Page size"
+// @Success      200  {array}   task.Task
+// @Router       /tasks [get]
 func (h *TaskHandler) ListTasks(c *gin.Context) {
 	filter := parseFilterOptions(c)
 
-	tasks, err := h.service.GetAll(filter)
+	if _, usesCursor := c.Request.URL.Query()["cursor"]; !usesCursor {
+		if c.Query("limit") != "" || c.Query("offset") != "" {
+			setLegacyOffsetDeprecationHeader(c)
+		}
+
+		tasks, err := h.service.GetAll(c.Request.Context(), filter)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, tasks)
+		return
+	}
+
+	page, err := h.service.GetAllCursor(c.Request.Context(), filter, h.cursorSigningKey, c.Query("cursor"), filter.Limit)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "INTERNAL_ERROR",
-				Message: "Failed to retrieve tasks",
-			},
-		})
+		if errors.Is(err, task.ErrInvalidCursor) {
+			logger.FromContext(c).Warn("invalid list tasks cursor", zap.Error(err))
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: ErrorDetail{
+					Code:    "INVALID_CURSOR",
+					Message: "invalid_cursor",
+				},
+			})
+			return
+		}
+		handleServiceError(c, err)
+		return
+	}
+
+	rels := map[string]string{"first": ""}
+	order := []string{"next", "prev", "first"}
+	if page.NextCursor != "" {
+		rels["next"] = page.NextCursor
+	}
+	if page.PrevCursor != "" {
+		rels["prev"] = page.PrevCursor
+	}
+	setLinkHeader(c, c.Request.URL, rels, order)
+	setTotalCountHeader(c, page.TotalCount)
+
+	c.JSON(http.StatusOK, page.Tasks)
+}
+
+// GetTaskFacets handles GET /api/v1/tasks/facets, returning status/priority/
+// tag counts across every task matching the same status/priority/tag/q/
+// tags query parameters ListTasks accepts (its cursor/limit/offset/sort_by
+// parameters are accepted but have no effect, since facets always describe
+// the whole matching set).
+//
+// @Summary      Get task facet counts
+// @Description  Returns status/priority/tag counts for tasks matching the given filter
+// @Tags         tasks
+// @Produce      json
+// @Param        status    query     string  false  "Filter by status"
+// @Param        priority  query     string  false  "Filter by priority"
+// @Param        tag       query     string  false  "Filter by tag"
+// @Param        q         query     string  false  "Search title/description"
+// @Param        tags      query     string  false  "Comma-separated tags"
+// @Param        tags_mode query     string  false  "any (default) or all"
+// @Success      200  {object}  task.Facets
+// @Router       /tasks/facets [get]
+func (h *TaskHandler) GetTaskFacets(c *gin.Context) {
+	filter := parseFilterOptions(c)
+
+	facets, err := h.service.Facets(c.Request.Context(), filter)
+	if err != nil {
+		handleServiceError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, tasks)
+	c.JSON(http.StatusOK, facets)
 }
 
 // UpdateTask handles PUT /api/v1/tasks/:id
+//
+// @Summary      Update a task
+// @Description  Updates an existing task's fields
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                  true  "Task ID"
+// @Param        task  body      task.UpdateTaskRequest  true  "Fields to update"
+// @Success      200   {object}  task.Task
+// @Failure      400   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Router       /tasks/{id} [put]
 func (h *TaskHandler) UpdateTask(c *gin.Context) {
 	id := c.Param("id")
 
 	var req task.UpdateTaskRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c).Warn("invalid update task request body", zap.Error(err))
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error: ErrorDetail{
 				Code:    "INVALID_REQUEST",
@@ -103,7 +249,75 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 		return
 	}
 
-	updatedTask, err := h.service.Update(id, req)
+	if principal, ok := auth.FromContext(c); ok {
+		req.UpdatedBy = principal.Subject
+	}
+
+	updatedTask, err := h.service.Update(c.Request.Context(), id, req)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedTask)
+}
+
+// UpdateTaskStatusRequest is the payload for PATCH /api/v1/tasks/:id/status.
+type UpdateTaskStatusRequest struct {
+	Status string `json:"status" binding:"required"`
+}
+
+// UpdateTaskStatus handles PATCH /api/v1/tasks/:id/status, a narrower
+// alternative to UpdateTask for callers (e.g. a Kanban board drag-drop)
+// that only ever change a task's status and shouldn't need to resend the
+// rest of it. It validates Status with task.IsValidStatus itself, ahead
+// of task.Service.Update, so an invalid value is reported the same way
+// ValidateCreateRequest reports one for CreateTask.
+//
+// @Summary      Update a task's status
+// @Description  Transitions a task to a new status
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                   true  "Task ID"
+// @Param        body  body      UpdateTaskStatusRequest  true  "New status"
+// @Success      200  {object}  task.Task
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /tasks/{id}/status [patch]
+func (h *TaskHandler) UpdateTaskStatus(c *gin.Context) {
+	id := c.Param("id")
+
+	var req UpdateTaskStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c).Warn("invalid update task status request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: ErrorDetail{
+				Code:    "INVALID_REQUEST",
+				Message: "Invalid request body",
+				Details: []string{err.Error()},
+			},
+		})
+		return
+	}
+
+	if !task.IsValidStatus(req.Status) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: ErrorDetail{
+				Code:    "INVALID_REQUEST",
+				Message: "Invalid status",
+				Details: []string{req.Status},
+			},
+		})
+		return
+	}
+
+	updateReq := task.UpdateTaskRequest{Status: &req.Status}
+	if principal, ok := auth.FromContext(c); ok {
+		updateReq.UpdatedBy = principal.Subject
+	}
+
+	updatedTask, err := h.service.Update(c.Request.Context(), id, updateReq)
 	if err != nil {
 		handleServiceError(c, err)
 		return
@@ -113,10 +327,19 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 }
 
 // DeleteTask handles DELETE /api/v1/tasks/:id
+//
+// @Summary      Delete a task
+// @Description  Deletes a task by ID
+// @Tags         tasks
+// @Produce      json
+// @Param        id   path      string  true  "Task ID"
+// @Success      200  {object}  map[string]string
+// @Failure      404  {object}  ErrorResponse
+// @Router       /tasks/{id} [delete]
 func (h *TaskHandler) DeleteTask(c *gin.Context) {
 	id := c.Param("id")
 
-	err := h.service.Delete(id)
+	err := h.service.Delete(c.Request.Context(), id)
 	if err != nil {
 		handleServiceError(c, err)
 		return
@@ -127,8 +350,85 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 	})
 }
 
-// handleServiceError converts service errors to HTTP responses
+// BulkOperationsRequest is the payload for POST /api/v1/tasks/bulk.
+type BulkOperationsRequest struct {
+	Operations []task.BulkOp `json:"operations"`
+}
+
+// BulkTasks handles POST /api/v1/tasks/bulk, applying a batch of
+// create/update/delete operations and reporting one result per input
+// index. With ?atomic=true the whole batch runs inside a single repository
+// transaction and any operation failing rolls back every other operation in
+// the batch; without it, each operation succeeds or fails independently.
+// The response status is 207 Multi-Status if any operation failed, 200
+// otherwise, mirroring respondImportResult's partial-failure convention.
+//
+// @Summary      Apply a batch of task operations
+// @Description  Runs a batch of create/update/delete operations, optionally atomically
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        atomic  query     bool                   false  "Roll back the whole batch if any operation fails"
+// @Param        body    body      BulkOperationsRequest  true   "Operations to apply"
+// @Success      200  {array}  task.BulkResult
+// @Failure      400  {object}  ErrorResponse
+// @Router       /tasks/bulk [post]
+func (h *TaskHandler) BulkTasks(c *gin.Context) {
+	var req BulkOperationsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c).Warn("invalid bulk tasks request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: ErrorDetail{
+				Code:    "INVALID_REQUEST",
+				Message: "Invalid request body",
+				Details: []string{err.Error()},
+			},
+		})
+		return
+	}
+
+	if len(req.Operations) == 0 {
+		logger.FromContext(c).Warn("bulk tasks request has no operations")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: ErrorDetail{
+				Code:    "INVALID_REQUEST",
+				Message: "operations must not be empty",
+			},
+		})
+		return
+	}
+
+	atomic := c.Query("atomic") == "true"
+
+	results, _ := h.service.BulkApply(c.Request.Context(), req.Operations, atomic)
+
+	status := http.StatusOK
+	for _, result := range results {
+		if result.Status >= http.StatusBadRequest {
+			status = http.StatusMultiStatus
+			break
+		}
+	}
+
+	c.JSON(status, results)
+}
+
+// handleServiceError converts service errors to HTTP responses, logging
+// every one as a warning so an operator can correlate a 4xx/5xx response
+// back to the request that caused it via its X-Request-ID.
 func handleServiceError(c *gin.Context, err error) {
+	logger.FromContext(c).Warn("task request failed", zap.Error(err))
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		c.JSON(http.StatusGatewayTimeout, ErrorResponse{
+			Error: ErrorDetail{
+				Code:    "TIMEOUT",
+				Message: "request exceeded its deadline",
+			},
+		})
+		return
+	}
+
 	if errors.Is(err, task.ErrTaskNotFound) {
 		c.JSON(http.StatusNotFound, ErrorResponse{
 			Error: ErrorDetail{
@@ -139,6 +439,26 @@ func handleServiceError(c *gin.Context, err error) {
 		return
 	}
 
+	if errors.Is(err, task.ErrExecutionNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: ErrorDetail{
+				Code:    "NOT_FOUND",
+				Message: "execution not found",
+			},
+		})
+		return
+	}
+
+	if errors.Is(err, task.ErrExecutionNotCancelable) {
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error: ErrorDetail{
+				Code:    "CONFLICT",
+				Message: "execution is not running",
+			},
+		})
+		return
+	}
+
 	if errors.Is(err, task.ErrInvalidTitle) {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error: ErrorDetail{
@@ -212,6 +532,13 @@ func parseFilterOptions(c *gin.Context) task.FilterOptions {
 		filter.Tag = &tag
 	}
 
+	filter.Query = c.Query("q")
+
+	if tags := c.Query("tags"); tags != "" {
+		filter.Tags = strings.Split(tags, ",")
+	}
+	filter.TagsMode = c.Query("tags_mode")
+
 	if sortBy := c.Query("sort_by"); sortBy != "" {
 		filter.SortBy = sortBy
 	}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/f00b455/blank-go/pkg/task"
+	"github.com/gin-gonic/gin"
+)
+
+// TaskExecutionHandler exposes triggering, listing, and canceling task
+// executions started through a task.ExecutionManager. It's kept separate
+// from TaskHandler, and its single-execution routes are mounted under
+// /tasks/executions/:eid rather than /executions/:eid, so they don't
+// collide with the generic execution.Manager's /executions/:id routes
+// registered alongside it in cmd/api/main.go.
+type TaskExecutionHandler struct {
+	manager *task.ExecutionManager
+}
+
+// NewTaskExecutionHandler creates a new task execution handler.
+func NewTaskExecutionHandler(manager *task.ExecutionManager) *TaskExecutionHandler {
+	return &TaskExecutionHandler{manager: manager}
+}
+
+// TriggerExecution handles POST /api/v1/tasks/:id/executions, starting a
+// manually-triggered run of task :id.
+//
+// @Summary      Trigger a task execution
+// @Description  Starts a new run of a task's Executor
+// @Tags         task-executions
+// @Produce      json
+// @Param        id   path      string  true  "Task ID"
+// @Success      202  {object}  task.Execution
+// @Failure      404  {object}  ErrorResponse
+// @Router       /tasks/{id}/executions [post]
+func (h *TaskExecutionHandler) TriggerExecution(c *gin.Context) {
+	exec, err := h.manager.Trigger(c.Request.Context(), c.Param("id"), task.TriggerManual)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, exec)
+}
+
+// ListExecutions handles GET /api/v1/tasks/:id/executions?status=&trigger=&page=&page_size=,
+// returning task :id's execution history newest-first.
+//
+// @Summary      List a task's executions
+// @Description  Lists execution history for a task, optionally filtered by status and trigger
+// @Tags         task-executions
+// @Produce      json
+// @Param        id         path      string  true   "Task ID"
+// @Param        status     query     string  false  "Filter by execution status"
+// @Param        trigger    query     string  false  "Filter by execution trigger"
+// @Param        page       query     int     false  "Page number"
+// @Param        page_size  query     int     false  "Page size"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /tasks/{id}/executions [get]
+func (h *TaskExecutionHandler) ListExecutions(c *gin.Context) {
+	filter := task.ExecutionFilter{
+		TaskID:   c.Param("id"),
+		Status:   task.ExecutionStatus(c.Query("status")),
+		Trigger:  task.ExecutionTrigger(c.Query("trigger")),
+		Page:     parseIntQuery(c, "page", 1),
+		PageSize: parseIntQuery(c, "page_size", 10),
+	}
+
+	execs, total, err := h.manager.List(c.Request.Context(), filter)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":      execs,
+		"total":     total,
+		"page":      filter.Page,
+		"page_size": filter.PageSize,
+	})
+}
+
+// GetExecution handles GET /api/v1/tasks/executions/:eid.
+//
+// @Summary      Get a task execution
+// @Description  Fetches the status, attempt count, and error of a task execution
+// @Tags         task-executions
+// @Produce      json
+// @Param        eid  path      string  true  "Execution ID"
+// @Success      200  {object}  task.Execution
+// @Failure      404  {object}  ErrorResponse
+// @Router       /tasks/executions/{eid} [get]
+func (h *TaskExecutionHandler) GetExecution(c *gin.Context) {
+	exec, err := h.manager.Get(c.Request.Context(), c.Param("eid"))
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, exec)
+}
+
+// StopExecution handles POST /api/v1/tasks/executions/:eid/stop, canceling
+// a queued or running execution.
+//
+// @Summary      Stop a task execution
+// @Description  Cancels a queued or running task execution
+// @Tags         task-executions
+// @Produce      json
+// @Param        eid  path      string  true  "Execution ID"
+// @Success      200  {object}  task.Execution
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /tasks/executions/{eid}/stop [post]
+func (h *TaskExecutionHandler) StopExecution(c *gin.Context) {
+	if err := h.manager.Stop(c.Request.Context(), c.Param("eid")); err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	exec, err := h.manager.Get(c.Request.Context(), c.Param("eid"))
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, exec)
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/task"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTaskExecutionHandler(t *testing.T, executor task.Executor) (*TaskExecutionHandler, *task.Task) {
+	t.Helper()
+
+	repo := task.NewInMemoryRepository()
+	tk := &task.Task{
+		ID:        "task-1",
+		Title:     "Test task",
+		Status:    task.StatusPending,
+		Priority:  task.PriorityMedium,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	require.NoError(t, repo.Create(context.Background(), tk))
+
+	execRepo := task.NewInMemoryExecutionRepository()
+	manager := task.NewExecutionManager(repo, execRepo, executor)
+
+	return NewTaskExecutionHandler(manager), tk
+}
+
+func waitForTaskExecutionStatus(t *testing.T, handler *TaskExecutionHandler, execID, want string) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/tasks/executions/"+execID, nil)
+		c.Params = gin.Params{{Key: "eid", Value: execID}}
+		handler.GetExecution(c)
+		if strings.Contains(w.Body.String(), want) {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("execution %s did not reach status %s in time", execID, want)
+}
+
+func TestTaskExecutionHandler_TriggerExecution(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("known task", func(t *testing.T) {
+		handler, tk := setupTaskExecutionHandler(t, task.NoopExecutor{})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/tasks/"+tk.ID+"/executions", nil)
+		c.Params = gin.Params{{Key: "id", Value: tk.ID}}
+		handler.TriggerExecution(c)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Contains(t, w.Body.String(), `"task_id":"task-1"`)
+	})
+
+	t.Run("unknown task", func(t *testing.T) {
+		handler, _ := setupTaskExecutionHandler(t, task.NoopExecutor{})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/tasks/missing/executions", nil)
+		c.Params = gin.Params{{Key: "id", Value: "missing"}}
+		handler.TriggerExecution(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestTaskExecutionHandler_GetAndListExecutions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, tk := setupTaskExecutionHandler(t, task.NoopExecutor{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/tasks/"+tk.ID+"/executions", nil)
+	c.Params = gin.Params{{Key: "id", Value: tk.ID}}
+	handler.TriggerExecution(c)
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	waitForTaskExecutionStatus(t, handler, execIDFromBody(t, w.Body.String()), string(task.ExecutionSucceeded))
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: tk.ID}}
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/tasks/"+tk.ID+"/executions", nil)
+	handler.ListExecutions(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total":1`)
+}
+
+func TestTaskExecutionHandler_StopExecution(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler, tk := setupTaskExecutionHandler(t, taskExecutorFunc(func(ctx context.Context, _ *task.Task) error {
+		close(started)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-release:
+			return nil
+		}
+	}))
+	defer close(release)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/tasks/"+tk.ID+"/executions", nil)
+	c.Params = gin.Params{{Key: "id", Value: tk.ID}}
+	handler.TriggerExecution(c)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	execID := execIDFromBody(t, w.Body.String())
+
+	<-started
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/tasks/executions/"+execID+"/stop", nil)
+	c.Params = gin.Params{{Key: "eid", Value: execID}}
+	handler.StopExecution(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), string(task.ExecutionStopped))
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/tasks/executions/"+execID+"/stop", nil)
+	c.Params = gin.Params{{Key: "eid", Value: execID}}
+	handler.StopExecution(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+type taskExecutorFunc func(ctx context.Context, t *task.Task) error
+
+func (f taskExecutorFunc) Execute(ctx context.Context, t *task.Task) error {
+	return f(ctx, t)
+}
+
+// execIDFromBody extracts the "id" field from a TriggerExecution response
+// body without pulling in a full JSON struct just for this test helper.
+func execIDFromBody(t *testing.T, body string) string {
+	t.Helper()
+	const marker = `"id":"`
+	start := strings.Index(body, marker)
+	require.NotEqual(t, -1, start, "response missing id field: %s", body)
+	start += len(marker)
+	end := strings.Index(body[start:], `"`)
+	require.NotEqual(t, -1, end, "response missing closing quote for id: %s", body)
+	return body[start : start+end]
+}
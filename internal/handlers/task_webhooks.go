@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/f00b455/blank-go/pkg/task"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterWebhookRequest is the payload for POST /api/v1/webhooks.
+type RegisterWebhookRequest struct {
+	URL string `json:"url"`
+	// Secret signs each delivery's body via an X-Task-Signature
+	// HMAC-SHA256 header, so the receiver can verify it came from this
+	// service.
+	Secret string `json:"secret"`
+	// EventTypes restricts delivery to these event types
+	// ("created"/"updated"/"deleted"); empty means every type.
+	EventTypes []string `json:"event_types,omitempty"`
+	Status     string   `json:"status,omitempty"`
+	Priority   string   `json:"priority,omitempty"`
+	Tag        string   `json:"tag,omitempty"`
+}
+
+// RegisterWebhook handles POST /api/v1/webhooks, registering an outbound
+// HTTPS webhook that receives every subsequent task Create/Update/Delete
+// event matching the request's event-type/status/priority/tag filter.
+//
+// @Summary      Register a task webhook
+// @Description  Registers an outbound webhook for task change events
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        webhook  body      RegisterWebhookRequest  true  "Webhook to register"
+// @Success      201      {object}  task.Webhook
+// @Failure      400      {object}  ErrorResponse
+// @Router       /webhooks [post]
+func (h *TaskHandler) RegisterWebhook(c *gin.Context) {
+	var req RegisterWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: ErrorDetail{Code: "INVALID_REQUEST", Message: err.Error()},
+		})
+		return
+	}
+
+	if req.URL == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: ErrorDetail{Code: "INVALID_REQUEST", Message: "url is required"},
+		})
+		return
+	}
+
+	webhook := &task.Webhook{
+		URL:    req.URL,
+		Secret: req.Secret,
+	}
+	for _, t := range req.EventTypes {
+		webhook.EventTypes = append(webhook.EventTypes, task.EventType(t))
+	}
+	if req.Status != "" {
+		if !task.IsValidStatus(req.Status) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: ErrorDetail{Code: "INVALID_REQUEST", Message: "invalid status"},
+			})
+			return
+		}
+		s := task.Status(req.Status)
+		webhook.Filter.Status = &s
+	}
+	if req.Priority != "" {
+		if !task.IsValidPriority(req.Priority) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: ErrorDetail{Code: "INVALID_REQUEST", Message: "invalid priority"},
+			})
+			return
+		}
+		p := task.Priority(req.Priority)
+		webhook.Filter.Priority = &p
+	}
+	if req.Tag != "" {
+		webhook.Filter.Tag = &req.Tag
+	}
+
+	if err := h.webhooks.CreateWebhook(c.Request.Context(), webhook); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: ErrorDetail{Code: "INTERNAL_ERROR", Message: err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, webhook)
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/f00b455/blank-go/pkg/task"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("registers a webhook and persists it", func(t *testing.T) {
+		service := task.NewService(task.NewInMemoryRepository())
+		handler := NewTaskHandler(service)
+
+		body, err := json.Marshal(RegisterWebhookRequest{
+			URL:        "https://example.com/hook",
+			Secret:     "shh",
+			EventTypes: []string{"created"},
+			Status:     "pending",
+		})
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", bytes.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.RegisterWebhook(c)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		var created task.Webhook
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		assert.NotEmpty(t, created.ID)
+		assert.Equal(t, "https://example.com/hook", created.URL)
+
+		webhooks, err := handler.webhooks.GetAllWebhooks(c.Request.Context())
+		require.NoError(t, err)
+		require.Len(t, webhooks, 1)
+		assert.Equal(t, "shh", webhooks[0].Secret)
+	})
+
+	t.Run("rejects a missing url", func(t *testing.T) {
+		service := task.NewService(task.NewInMemoryRepository())
+		handler := NewTaskHandler(service)
+
+		body, err := json.Marshal(RegisterWebhookRequest{})
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", bytes.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.RegisterWebhook(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("rejects an invalid status filter", func(t *testing.T) {
+		service := task.NewService(task.NewInMemoryRepository())
+		handler := NewTaskHandler(service)
+
+		body, err := json.Marshal(RegisterWebhookRequest{URL: "https://example.com/hook", Status: "bogus"})
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", bytes.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.RegisterWebhook(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
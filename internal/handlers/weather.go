@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -13,20 +16,35 @@ const defaultForecastDays = 7
 
 // WeatherService defines the interface for weather operations
 type WeatherService interface {
-	GetCurrentWeatherByCoords(lat, lon string) (*weather.WeatherResponse, error)
-	GetForecastByCoords(lat, lon string, days int) (*weather.ForecastResponse, error)
-	GetWeatherByCity(cityName string) (*weather.WeatherResponse, error)
+	GetCurrentWeatherByCoords(ctx context.Context, lat, lon, units, provider string) (*weather.WeatherResponse, error)
+	GetForecastByCoords(ctx context.Context, lat, lon string, days int, units, provider string) (*weather.ForecastResponse, error)
+	GetHourlyForecastByCoords(ctx context.Context, lat, lon string, hours int, units, provider string) (*weather.HourlyForecastResponse, error)
+	GetWeatherByCity(ctx context.Context, cityName, units, provider string) (*weather.WeatherResponse, error)
+	GetBatchWeatherByCities(ctx context.Context, citiesStr, units, provider string) (*weather.BatchWeatherResponse, error)
+	GetBatchWeatherByCoords(ctx context.Context, coordsStr, units, provider string) (*weather.BatchWeatherResponse, error)
+}
+
+// WeatherPrefetcher is the subset of a weather.Prefetcher the handler
+// needs: recording each served query so it can be considered for the hot
+// set, and reporting that hot set for the debug endpoint.
+type WeatherPrefetcher interface {
+	RecordQuery(cityName string, lat, lon float64, units string)
+	Hot() []weather.HotQuery
 }
 
 // WeatherHandler handles weather-related HTTP requests
 type WeatherHandler struct {
-	service WeatherService
+	service    WeatherService
+	prefetcher WeatherPrefetcher
 }
 
-// NewWeatherHandler creates a new weather handler
-func NewWeatherHandler(service WeatherService) *WeatherHandler {
+// NewWeatherHandler creates a new weather handler. prefetcher may be nil,
+// in which case GetHotQueries reports an empty hot set and no query is
+// ever recorded.
+func NewWeatherHandler(service WeatherService, prefetcher WeatherPrefetcher) *WeatherHandler {
 	return &WeatherHandler{
-		service: service,
+		service:    service,
+		prefetcher: prefetcher,
 	}
 }
 
@@ -42,7 +60,8 @@ func (h *WeatherHandler) GetCurrentWeather(c *gin.Context) {
 		return
 	}
 
-	result, err := h.service.GetCurrentWeatherByCoords(lat, lon)
+	units := queryUnits(c)
+	result, err := h.service.GetCurrentWeatherByCoords(c.Request.Context(), lat, lon, units, c.Query("provider"))
 	if err != nil {
 		statusCode := determineStatusCode(err)
 		c.JSON(statusCode, gin.H{
@@ -51,10 +70,17 @@ func (h *WeatherHandler) GetCurrentWeather(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	if h.prefetcher != nil {
+		h.prefetcher.RecordQuery("", result.Location.Latitude, result.Location.Longitude, units)
+	}
+
+	setCacheHeaders(c, result.CacheStatus, result.CacheAge)
+	respondWeather(c, result)
 }
 
-// GetForecast handles GET /api/v1/weather/forecast
+// GetForecast handles GET /api/v1/weather/forecast. By default it returns
+// one entry per day; passing granularity=hourly instead returns an
+// hour-by-hour breakdown (hours defaults to days*24).
 func (h *WeatherHandler) GetForecast(c *gin.Context) {
 	lat := c.Query("lat")
 	lon := c.Query("lon")
@@ -75,7 +101,39 @@ func (h *WeatherHandler) GetForecast(c *gin.Context) {
 		return
 	}
 
-	result, err := h.service.GetForecastByCoords(lat, lon, days)
+	if c.Query("granularity") == "hourly" {
+		h.getHourlyForecast(c, lat, lon, days)
+		return
+	}
+
+	result, err := h.service.GetForecastByCoords(c.Request.Context(), lat, lon, days, queryUnits(c), c.Query("provider"))
+	if err != nil {
+		statusCode := determineStatusCode(err)
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	setCacheHeaders(c, result.CacheStatus, result.CacheAge)
+	c.JSON(http.StatusOK, result)
+}
+
+// getHourlyForecast serves the granularity=hourly branch of GetForecast.
+// hours defaults to days*24 but can be overridden directly via the hours
+// query parameter.
+func (h *WeatherHandler) getHourlyForecast(c *gin.Context, lat, lon string, days int) {
+	hoursStr := c.DefaultQuery("hours", strconv.Itoa(days*24))
+
+	hours, err := strconv.Atoi(hoursStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid hours parameter",
+		})
+		return
+	}
+
+	result, err := h.service.GetHourlyForecastByCoords(c.Request.Context(), lat, lon, hours, queryUnits(c), c.Query("provider"))
 	if err != nil {
 		statusCode := determineStatusCode(err)
 		c.JSON(statusCode, gin.H{
@@ -98,7 +156,8 @@ func (h *WeatherHandler) GetWeatherByCity(c *gin.Context) {
 		return
 	}
 
-	result, err := h.service.GetWeatherByCity(city)
+	units := queryUnits(c)
+	result, err := h.service.GetWeatherByCity(c.Request.Context(), city, units, c.Query("provider"))
 	if err != nil {
 		statusCode := determineStatusCode(err)
 		c.JSON(statusCode, gin.H{
@@ -107,11 +166,120 @@ func (h *WeatherHandler) GetWeatherByCity(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	if h.prefetcher != nil {
+		h.prefetcher.RecordQuery(city, 0, 0, units)
+	}
+
+	setCacheHeaders(c, result.CacheStatus, result.CacheAge)
+	respondWeather(c, result)
+}
+
+// setCacheHeaders surfaces a weather.Service read-through cache lookup
+// (see weather.Service.SetCache) as X-Cache/Age response headers. A zero
+// status (no weather.Cache configured) sets neither header.
+func setCacheHeaders(c *gin.Context, status weather.CacheStatus, age time.Duration) {
+	if status == "" {
+		return
+	}
+	c.Header("X-Cache", string(status))
+	c.Header("Age", strconv.Itoa(int(age.Seconds())))
 }
 
-// determineStatusCode maps error messages to HTTP status codes
+// queryUnits resolves the requested UnitSystem from the `units` query
+// parameter, falling back to the `Accept-Units` header for callers (e.g.
+// the MCP tools) that prefer setting it there. An empty result defers to
+// Service's own default.
+func queryUnits(c *gin.Context) string {
+	if units := c.Query("units"); units != "" {
+		return units
+	}
+	return c.GetHeader("Accept-Units")
+}
+
+// respondWeather writes result as plain-text or ANSI, per the request's
+// Accept header, falling back to the handlers' usual JSON body when
+// neither format was asked for.
+func respondWeather(c *gin.Context, result *weather.WeatherResponse) {
+	render, contentType := weather.RendererForAccept(c.GetHeader("Accept"))
+	if render == nil {
+		c.JSON(http.StatusOK, result)
+		return
+	}
+	c.Data(http.StatusOK, contentType, []byte(render(result)))
+}
+
+// GetHotQueries handles GET /api/v1/weather/_admin/hot, listing the
+// current Prefetcher hot set for debugging which queries are being kept
+// warm.
+func (h *WeatherHandler) GetHotQueries(c *gin.Context) {
+	hot := []weather.HotQuery{}
+	if h.prefetcher != nil {
+		hot = h.prefetcher.Hot()
+	}
+	c.JSON(http.StatusOK, gin.H{"hot": hot})
+}
+
+// GetBatchWeather handles GET /api/v1/weather/batch?cities=Berlin,Paris,Tokyo
+func (h *WeatherHandler) GetBatchWeather(c *gin.Context) {
+	cities := c.Query("cities")
+	response, err := h.service.GetBatchWeatherByCities(c.Request.Context(), cities, queryUnits(c), c.Query("provider"))
+	if err != nil {
+		statusCode := determineStatusCode(err)
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(batchStatusCode(response), response)
+}
+
+// GetBatchWeatherByCoords handles
+// GET /api/v1/weather/batch/coords?coords=52.52,13.41;48.85,2.35
+func (h *WeatherHandler) GetBatchWeatherByCoords(c *gin.Context) {
+	coords := c.Query("coords")
+	response, err := h.service.GetBatchWeatherByCoords(c.Request.Context(), coords, queryUnits(c), c.Query("provider"))
+	if err != nil {
+		statusCode := determineStatusCode(err)
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(batchStatusCode(response), response)
+}
+
+// batchStatusCode reports 200 when every query in response succeeded, 207
+// Multi-Status when some failed, and 404 when all of them did, mirroring
+// StocksHandler.GetBatchSummary's partial-success status logic.
+func batchStatusCode(response *weather.BatchWeatherResponse) int {
+	if len(response.Errors) == 0 {
+		return http.StatusOK
+	}
+	if len(response.Summaries) == 0 {
+		return http.StatusNotFound
+	}
+	return http.StatusMultiStatus
+}
+
+// determineStatusCode maps errors to HTTP status codes, preferring
+// weather's typed sentinel errors where the service returns one and
+// falling back to message-matching for the validation errors this
+// handler raises itself.
 func determineStatusCode(err error) int {
+	switch {
+	case errors.Is(err, weather.ErrCityNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, weather.ErrRateLimited):
+		return http.StatusTooManyRequests
+	case errors.Is(err, weather.ErrUpstreamUnavailable), errors.Is(err, weather.ErrTimeout):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, weather.ErrGeocodingUnsupported), errors.Is(err, weather.ErrInvalidUnitSystem),
+		errors.Is(err, weather.ErrProviderNotFound):
+		return http.StatusBadRequest
+	}
+
 	errMsg := err.Error()
 
 	switch {
@@ -122,7 +290,11 @@ func determineStatusCode(err error) int {
 		contains(errMsg, "latitude out of range"),
 		contains(errMsg, "longitude out of range"),
 		contains(errMsg, "days must be"),
-		contains(errMsg, "city name is required"):
+		contains(errMsg, "hours must be"),
+		contains(errMsg, "city name is required"),
+		contains(errMsg, "cities parameter is required"),
+		contains(errMsg, "coords parameter is required"),
+		contains(errMsg, "invalid coordinates"):
 		return http.StatusBadRequest
 	default:
 		return http.StatusInternalServerError
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/f00b455/blank-go/pkg/weather"
+)
+
+// StationHandler handles weather-observation-station HTTP requests. It
+// depends on the concrete *weather.Client rather than WeatherService or
+// weather.Provider because station lookups are specific to Open-Meteo's
+// archive API, not something every weather backend offers.
+type StationHandler struct {
+	client *weather.Client
+}
+
+// NewStationHandler creates a new station handler backed by client.
+func NewStationHandler(client *weather.Client) *StationHandler {
+	return &StationHandler{client: client}
+}
+
+// GetNearestStation handles GET /api/v1/weather/stations/nearest?lat=&lon=
+func (h *StationHandler) GetNearestStation(c *gin.Context) {
+	lat, lon, ok := parseLatLon(c)
+	if !ok {
+		return
+	}
+
+	station, err := h.client.GetNearestStation(lat, lon)
+	if err != nil {
+		c.JSON(stationStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, station)
+}
+
+// GetObservation handles GET /api/v1/weather/stations/:id/observation
+func (h *StationHandler) GetObservation(c *gin.Context) {
+	observation, err := h.client.GetObservationByStationID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(stationStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, observation)
+}
+
+// GetHistoricalObservations handles
+// GET /api/v1/weather/stations/:id/history?from=2026-01-01&to=2026-01-02
+func (h *StationHandler) GetHistoricalObservations(c *gin.Context) {
+	fromStr := c.Query("from")
+	toStr := c.Query("to")
+	if fromStr == "" || toStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to are required"})
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", fromStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from date, expected YYYY-MM-DD"})
+		return
+	}
+	to, err := time.Parse("2006-01-02", toStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to date, expected YYYY-MM-DD"})
+		return
+	}
+
+	observations, err := h.client.GetHistoricalObservations(c.Request.Context(), c.Param("id"), from, to)
+	if err != nil {
+		c.JSON(stationStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, observations)
+}
+
+// parseLatLon reads and validates the lat/lon query parameters shared by
+// the station endpoints, writing a 400 response itself when they're
+// missing or malformed.
+func parseLatLon(c *gin.Context) (lat, lon float64, ok bool) {
+	latStr := c.Query("lat")
+	lonStr := c.Query("lon")
+	if latStr == "" || lonStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "latitude and longitude are required"})
+		return 0, 0, false
+	}
+
+	lat, err := strconv.ParseFloat(latStr, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid latitude"})
+		return 0, 0, false
+	}
+	lon, err = strconv.ParseFloat(lonStr, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid longitude"})
+		return 0, 0, false
+	}
+
+	return lat, lon, true
+}
+
+// stationStatusCode maps station/observation errors to HTTP status
+// codes.
+func stationStatusCode(err error) int {
+	if errors.Is(err, weather.ErrStationNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
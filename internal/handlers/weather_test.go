@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/f00b455/blank-go/internal/handlers/mocks"
 	"github.com/f00b455/blank-go/pkg/weather"
@@ -17,7 +18,7 @@ import (
 
 func TestNewWeatherHandler(t *testing.T) {
 	mockService := mocks.NewMockWeatherService(t)
-	handler := NewWeatherHandler(mockService)
+	handler := NewWeatherHandler(mockService, nil)
 
 	assert.NotNil(t, handler)
 	assert.Equal(t, mockService, handler.service)
@@ -106,11 +107,11 @@ func TestGetCurrentWeather(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := mocks.NewMockWeatherService(t)
-			handler := NewWeatherHandler(mockService)
+			handler := NewWeatherHandler(mockService, nil)
 
 			// Setup mock expectations only if we expect the service to be called
 			if tt.lat != "" && tt.lon != "" {
-				mockService.On("GetCurrentWeatherByCoords", tt.lat, tt.lon).
+				mockService.On("GetCurrentWeatherByCoords", tt.lat, tt.lon, "", "").
 					Return(tt.mockResponse, tt.mockError)
 			}
 
@@ -136,6 +137,117 @@ func TestGetCurrentWeather(t *testing.T) {
 	}
 }
 
+func TestGetCurrentWeather_AcceptNegotiation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockResponse := &weather.WeatherResponse{
+		Location: weather.Location{City: "Berlin", Latitude: 52.52, Longitude: 13.41},
+		Current:  weather.CurrentWeather{Temperature: 18.5, Humidity: 60, WindSpeed: 3.2, WeatherDescription: "Clear sky"},
+		Units:    weather.Units{Temperature: "°C", WindSpeed: "m/s"},
+	}
+
+	tests := []struct {
+		name                string
+		accept              string
+		expectedContentType string
+	}{
+		{name: "no Accept header falls back to JSON", accept: "", expectedContentType: "application/json; charset=utf-8"},
+		{name: "text/plain renders plain text", accept: "text/plain", expectedContentType: "text/plain; charset=utf-8"},
+		{name: "text/x-ansi renders ANSI", accept: "text/x-ansi", expectedContentType: "text/x-ansi; charset=utf-8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewMockWeatherService(t)
+			mockService.On("GetCurrentWeatherByCoords", "52.52", "13.41", "", "").Return(mockResponse, nil)
+			handler := NewWeatherHandler(mockService, nil)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			req, _ := http.NewRequest("GET", "/api/v1/weather?lat=52.52&lon=13.41", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			c.Request = req
+
+			handler.GetCurrentWeather(c)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Contains(t, w.Header().Get("Content-Type"), tt.expectedContentType)
+			if tt.accept == "text/plain" || tt.accept == "text/x-ansi" {
+				assert.Contains(t, w.Body.String(), "Berlin")
+			}
+		})
+	}
+}
+
+func TestGetCurrentWeather_ProviderOverride(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockResponse := &weather.WeatherResponse{
+		Location: weather.Location{Latitude: 52.52, Longitude: 13.41, City: "Berlin"},
+	}
+
+	mockService := mocks.NewMockWeatherService(t)
+	mockService.On("GetCurrentWeatherByCoords", "52.52", "13.41", "", "nws").Return(mockResponse, nil)
+	handler := NewWeatherHandler(mockService, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	req, _ := http.NewRequest("GET", "/api/v1/weather?lat=52.52&lon=13.41&provider=nws", nil)
+	c.Request = req
+
+	handler.GetCurrentWeather(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestGetCurrentWeather_SurfacesCacheHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockResponse := &weather.WeatherResponse{
+		Location:    weather.Location{Latitude: 52.52, Longitude: 13.41, City: "Berlin"},
+		CacheStatus: weather.CacheHit,
+		CacheAge:    90 * time.Second,
+	}
+
+	mockService := mocks.NewMockWeatherService(t)
+	mockService.On("GetCurrentWeatherByCoords", "52.52", "13.41", "", "").Return(mockResponse, nil)
+	handler := NewWeatherHandler(mockService, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	req, _ := http.NewRequest("GET", "/api/v1/weather?lat=52.52&lon=13.41", nil)
+	c.Request = req
+
+	handler.GetCurrentWeather(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "HIT", w.Header().Get("X-Cache"))
+	assert.Equal(t, "90", w.Header().Get("Age"))
+}
+
+func TestGetCurrentWeather_NoCacheHeadersWhenCacheDisabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockResponse := &weather.WeatherResponse{Location: weather.Location{Latitude: 52.52, Longitude: 13.41}}
+
+	mockService := mocks.NewMockWeatherService(t)
+	mockService.On("GetCurrentWeatherByCoords", "52.52", "13.41", "", "").Return(mockResponse, nil)
+	handler := NewWeatherHandler(mockService, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	req, _ := http.NewRequest("GET", "/api/v1/weather?lat=52.52&lon=13.41", nil)
+	c.Request = req
+
+	handler.GetCurrentWeather(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("X-Cache"))
+	assert.Empty(t, w.Header().Get("Age"))
+}
+
 func TestGetForecast(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -229,7 +341,7 @@ func TestGetForecast(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := mocks.NewMockWeatherService(t)
-			handler := NewWeatherHandler(mockService)
+			handler := NewWeatherHandler(mockService, nil)
 
 			// Setup mock expectations only if we expect the service to be called
 			if tt.lat != "" && tt.lon != "" && tt.days != "invalid" {
@@ -237,7 +349,7 @@ func TestGetForecast(t *testing.T) {
 				if tt.days != "" {
 					expectedDays, _ = strconv.Atoi(tt.days)
 				}
-				mockService.On("GetForecastByCoords", tt.lat, tt.lon, expectedDays).
+				mockService.On("GetForecastByCoords", tt.lat, tt.lon, expectedDays, "", "").
 					Return(tt.mockResponse, tt.mockError)
 			}
 
@@ -267,6 +379,64 @@ func TestGetForecast(t *testing.T) {
 	}
 }
 
+func TestGetForecast_HourlyGranularity(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedHours  int
+		mockResponse   *weather.HourlyForecastResponse
+		mockError      error
+		expectedStatus int
+	}{
+		{
+			name:          "hourly granularity defaults hours to days*24",
+			query:         "lat=52.52&lon=13.41&granularity=hourly",
+			expectedHours: 168,
+			mockResponse: &weather.HourlyForecastResponse{
+				Location: weather.Location{Latitude: 52.52, Longitude: 13.41},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:          "hourly granularity honors explicit hours",
+			query:         "lat=52.52&lon=13.41&granularity=hourly&days=3&hours=336",
+			expectedHours: 336,
+			mockResponse: &weather.HourlyForecastResponse{
+				Location: weather.Location{Latitude: 52.52, Longitude: 13.41},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "hourly granularity surfaces service error",
+			query:          "lat=52.52&lon=13.41&granularity=hourly",
+			expectedHours:  168,
+			mockError:      errors.New("service error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewMockWeatherService(t)
+			handler := NewWeatherHandler(mockService, nil)
+
+			mockService.On("GetHourlyForecastByCoords", "52.52", "13.41", tt.expectedHours, "", "").
+				Return(tt.mockResponse, tt.mockError)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			req, _ := http.NewRequest("GET", "/api/v1/weather/forecast?"+tt.query, nil)
+			c.Request = req
+
+			handler.GetForecast(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
 func TestGetWeatherByCity(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -313,10 +483,10 @@ func TestGetWeatherByCity(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := mocks.NewMockWeatherService(t)
-			handler := NewWeatherHandler(mockService)
+			handler := NewWeatherHandler(mockService, nil)
 
 			// Setup mock expectations
-			mockService.On("GetWeatherByCity", tt.city).
+			mockService.On("GetWeatherByCity", tt.city, "", "").
 				Return(tt.mockResponse, tt.mockError)
 
 			// Setup request
@@ -389,6 +559,11 @@ func TestDetermineStatusCode(t *testing.T) {
 			err:            errors.New("some other error"),
 			expectedStatus: http.StatusInternalServerError,
 		},
+		{
+			name:           "provider not found error",
+			err:            weather.ErrProviderNotFound,
+			expectedStatus: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,84 @@
+// Package logger provides the process-wide structured logger: a
+// package-level *zap.Logger built once from config.LogConfig by Setup, and
+// a context-scoped accessor middleware.Logging uses to attach a
+// request-correlated child logger to every inbound request.
+package logger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/f00b455/blank-go/internal/config"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// global is the logger Setup installs and L returns. It defaults to a
+// no-op logger so code that logs before Setup runs (e.g. in tests) doesn't
+// need a nil check.
+var global = zap.NewNop()
+
+// Setup builds the package-level logger from cfg's level, format, and
+// sampling settings, replacing whatever Setup installed before. Call it
+// once during startup, before the server starts accepting requests.
+func Setup(cfg *config.LogConfig) error {
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(strings.ToLower(cfg.Level))); err != nil {
+		return fmt.Errorf("logger: %w", err)
+	}
+
+	var zapCfg zap.Config
+	if cfg.Format == "console" {
+		zapCfg = zap.NewDevelopmentConfig()
+	} else {
+		zapCfg = zap.NewProductionConfig()
+	}
+	zapCfg.Level = zap.NewAtomicLevelAt(level)
+
+	if cfg.SamplingInitial > 0 || cfg.SamplingThereafter > 0 {
+		zapCfg.Sampling = &zap.SamplingConfig{
+			Initial:    cfg.SamplingInitial,
+			Thereafter: cfg.SamplingThereafter,
+		}
+	} else {
+		zapCfg.Sampling = nil
+	}
+
+	built, err := zapCfg.Build()
+	if err != nil {
+		return fmt.Errorf("logger: building zap logger: %w", err)
+	}
+
+	global = built
+	return nil
+}
+
+// L returns the logger most recently installed by Setup, or a no-op
+// logger if Setup hasn't run yet.
+func L() *zap.Logger {
+	return global
+}
+
+// contextKey is unexported so NewContext/FromContext's key can't collide
+// with one from another package, matching the convention of Go's own
+// context.WithValue guidance.
+type contextKey struct{ name string }
+
+var loggerKey = contextKey{"logger"}
+
+// NewContext returns a copy of ctx carrying l, retrievable via FromContext.
+func NewContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, l)
+}
+
+// FromContext returns the *zap.Logger middleware.Logging stored in ctx -
+// typically a *gin.Context, which satisfies context.Context - falling back
+// to L() if ctx doesn't carry one, e.g. in a background goroutine with no
+// associated request.
+func FromContext(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(loggerKey).(*zap.Logger); ok && l != nil {
+		return l
+	}
+	return L()
+}
@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader is the header APIKeyAuth reads a raw API key from.
+const APIKeyHeader = "X-API-Key"
+
+const apiKeyContextKey = "middleware.api_key"
+
+// AuthenticatedKey is the identity APIKeyAuth attaches to a request's
+// context on success, whether the caller presented a raw API key or a
+// bearer token minted for one - both resolve to the same shape so
+// RateLimit and handlers don't need to care which form was used.
+type AuthenticatedKey struct {
+	// ID identifies the underlying api_keys row, and is what RateLimit
+	// meters against.
+	ID string
+	// Scopes lists what the key is authorized for (e.g. "weather:read").
+	Scopes []string
+	// RateLimitPerMin is the key's configured per-minute ceiling. Zero or
+	// negative leaves the key unmetered.
+	RateLimitPerMin int
+}
+
+// HasScope reports whether k carries scope.
+func (k AuthenticatedKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// KeyStore authenticates the credential a request presents to APIKeyAuth:
+// either a raw API key from the X-API-Key header, or a bearer JWT minted
+// by exchanging one at POST /api/v1/auth/token.
+type KeyStore interface {
+	AuthenticateAPIKey(ctx context.Context, plaintext string) (*AuthenticatedKey, error)
+	AuthenticateToken(ctx context.Context, rawToken string) (*AuthenticatedKey, error)
+}
+
+// APIKeyAuth requires a request to present either a valid X-API-Key
+// header or an `Authorization: Bearer <jwt>` minted for one, resolving
+// whichever was presented against store and attaching the result to the
+// request context as an AuthenticatedKey, retrievable via
+// KeyFromContext. Neither credential present, or one store rejects,
+// fails the request with 401.
+func APIKeyAuth(store KeyStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		if raw := c.GetHeader(APIKeyHeader); raw != "" {
+			key, err := store.AuthenticateAPIKey(ctx, raw)
+			if err != nil {
+				unauthorized(c)
+				return
+			}
+			c.Set(apiKeyContextKey, key)
+			c.Next()
+			return
+		}
+
+		if header := c.GetHeader("Authorization"); strings.HasPrefix(header, "Bearer ") {
+			rawToken := strings.TrimPrefix(header, "Bearer ")
+			key, err := store.AuthenticateToken(ctx, rawToken)
+			if err != nil {
+				unauthorized(c)
+				return
+			}
+			c.Set(apiKeyContextKey, key)
+			c.Next()
+			return
+		}
+
+		unauthorized(c)
+	}
+}
+
+func unauthorized(c *gin.Context) {
+	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+		"error": gin.H{
+			"code":    "UNAUTHORIZED",
+			"message": "a valid X-API-Key header or bearer token is required",
+		},
+	})
+}
+
+// KeyFromContext returns the AuthenticatedKey APIKeyAuth attached to c, if
+// any.
+func KeyFromContext(c *gin.Context) (*AuthenticatedKey, bool) {
+	v, ok := c.Get(apiKeyContextKey)
+	if !ok {
+		return nil, false
+	}
+	key, ok := v.(*AuthenticatedKey)
+	return key, ok
+}
+
+// Limiter reports whether the caller identified by keyID may make one
+// more request right now, against a per-minute ceiling of
+// rateLimitPerMin. Implementations meter each keyID independently.
+type Limiter interface {
+	Allow(ctx context.Context, keyID string, rateLimitPerMin int) (bool, error)
+}
+
+// RateLimit enforces limiter against the AuthenticatedKey APIKeyAuth
+// attached to the request, so it must be chained after APIKeyAuth. A key
+// with RateLimitPerMin <= 0, or a request with no AuthenticatedKey at
+// all, is left unmetered rather than rejected - callers that need
+// authentication enforced get that from APIKeyAuth itself.
+func RateLimit(limiter Limiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key, ok := KeyFromContext(c)
+		if !ok || key.RateLimitPerMin <= 0 {
+			c.Next()
+			return
+		}
+
+		allowed, err := limiter.Allow(c.Request.Context(), key.ID, key.RateLimitPerMin)
+		if err != nil || !allowed {
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error": gin.H{
+					"code":    "RATE_LIMITED",
+					"message": "rate limit exceeded",
+				},
+			})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeKeyStore lets tests control AuthenticateAPIKey/AuthenticateToken's
+// return values without depending on apikey.Store's actual hashing or JWT
+// verification logic.
+type fakeKeyStore struct {
+	key *AuthenticatedKey
+	err error
+}
+
+func (f *fakeKeyStore) AuthenticateAPIKey(ctx context.Context, plaintext string) (*AuthenticatedKey, error) {
+	return f.key, f.err
+}
+
+func (f *fakeKeyStore) AuthenticateToken(ctx context.Context, rawToken string) (*AuthenticatedKey, error) {
+	return f.key, f.err
+}
+
+// fakeLimiter lets tests control Allow's return value without depending
+// on a real token-bucket or Redis backend.
+type fakeLimiter struct {
+	allowed bool
+	err     error
+}
+
+func (f *fakeLimiter) Allow(ctx context.Context, keyID string, rateLimitPerMin int) (bool, error) {
+	return f.allowed, f.err
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("valid X-API-Key header", func(t *testing.T) {
+		router := gin.New()
+		router.Use(APIKeyAuth(&fakeKeyStore{key: &AuthenticatedKey{ID: "key-1", Scopes: []string{"weather:read"}}}))
+		router.GET("/test", func(c *gin.Context) {
+			key, ok := KeyFromContext(c)
+			assert.True(t, ok)
+			assert.Equal(t, "key-1", key.ID)
+			c.Status(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set(APIKeyHeader, "some-plaintext-key")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("valid bearer token", func(t *testing.T) {
+		router := gin.New()
+		router.Use(APIKeyAuth(&fakeKeyStore{key: &AuthenticatedKey{ID: "key-1"}}))
+		router.GET("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("Authorization", "Bearer some.jwt.token")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("store rejects the credential", func(t *testing.T) {
+		router := gin.New()
+		router.Use(APIKeyAuth(&fakeKeyStore{err: assert.AnError}))
+		router.GET("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set(APIKeyHeader, "wrong-key")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("no credential presented", func(t *testing.T) {
+		router := gin.New()
+		router.Use(APIKeyAuth(&fakeKeyStore{}))
+		router.GET("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("allowed request passes through", func(t *testing.T) {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set(apiKeyContextKey, &AuthenticatedKey{ID: "key-1", RateLimitPerMin: 60})
+			c.Next()
+		})
+		router.Use(RateLimit(&fakeLimiter{allowed: true}))
+		router.GET("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("exceeded limit rejects with 429", func(t *testing.T) {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set(apiKeyContextKey, &AuthenticatedKey{ID: "key-1", RateLimitPerMin: 60})
+			c.Next()
+		})
+		router.Use(RateLimit(&fakeLimiter{allowed: false}))
+		router.GET("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	})
+
+	t.Run("unmetered key with no limit passes through", func(t *testing.T) {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set(apiKeyContextKey, &AuthenticatedKey{ID: "key-1"})
+			c.Next()
+		})
+		router.Use(RateLimit(&fakeLimiter{allowed: false}))
+		router.GET("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -1,16 +1,40 @@
 package middleware
 
 import (
+	"context"
+	"strconv"
 	"time"
 
+	"github.com/f00b455/blank-go/internal/logger"
+	"github.com/f00b455/blank-go/internal/version"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+// RequestIDHeader is the header Logging reads an inbound request ID from,
+// and sets on the response when the client didn't supply one.
+const RequestIDHeader = "X-Request-ID"
+
+var (
+	httpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total number of HTTP requests, labeled by method, route, and status.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDurationSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "http_request_duration_seconds",
+		Help: "HTTP request latency in seconds, labeled by method and route.",
+	}, []string{"method", "route"})
 )
 
 func CORS() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization, Upgrade, Connection, Sec-WebSocket-Key, Sec-WebSocket-Version, Sec-WebSocket-Extensions, Sec-WebSocket-Protocol")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -21,6 +45,22 @@ func CORS() gin.HandlerFunc {
 	}
 }
 
+// RequestTimeout wraps the incoming request's context in a context.WithTimeout
+// of d, so handlers and the services/repositories they call can bound any
+// blocking work to a single per-request deadline instead of running
+// unbounded. It does not itself inspect the handler's response: handlers
+// that observe context.DeadlineExceeded from a downstream call are expected
+// to translate it into a 504, as handleServiceError does for task handlers.
+func RequestTimeout(d time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
 func RequestTimer() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
@@ -28,3 +68,69 @@ func RequestTimer() gin.HandlerFunc {
 		c.Header("X-Response-Time", time.Since(start).String())
 	}
 }
+
+// ServerHeader stamps every response with a Server header identifying the
+// running build ("blank-go/<version> (<commit>)"), so an operator curling
+// the API during an incident can immediately see which deploy answered.
+func ServerHeader() gin.HandlerFunc {
+	server := version.Get().String()
+	return func(c *gin.Context) {
+		c.Header("Server", server)
+		c.Next()
+	}
+}
+
+// Logging replaces gin.Logger(): it propagates an inbound X-Request-ID
+// header (minting a new one if the client didn't send it), stamps it onto
+// the response, and stores a *zap.Logger tagged with it in the request's
+// context - retrievable downstream via logger.FromContext(c), including
+// from handleServiceError's 4xx/5xx branches - before logging one
+// structured line per request with its method, route, status, and latency.
+func Logging() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+		c.Header(RequestIDHeader, requestID)
+
+		reqLogger := logger.L().With(zap.String("request_id", requestID))
+		c.Request = c.Request.WithContext(logger.NewContext(c.Request.Context(), reqLogger))
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		reqLogger.Info("http_request",
+			zap.String("method", c.Request.Method),
+			zap.String("route", route),
+			zap.Int("status", c.Writer.Status()),
+			zap.Duration("latency", time.Since(start)),
+		)
+	}
+}
+
+// Metrics records per-route request counts and latency histograms for
+// Prometheus scraping via GET /metrics. The route label is the matched
+// route template (e.g. "/api/v1/stocks/:ticker/summary") rather than the
+// raw request path, so requests for distinct tickers don't each mint a new
+// label value and explode cardinality.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		httpRequestsTotal.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		httpRequestDurationSeconds.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+	}
+}
@@ -1,11 +1,14 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -63,7 +66,7 @@ func TestCORS(t *testing.T) {
 			if tt.checkHeaders {
 				assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
 				assert.Equal(t, "GET, POST, PUT, DELETE, OPTIONS", w.Header().Get("Access-Control-Allow-Methods"))
-				assert.Equal(t, "Origin, Content-Type, Authorization", w.Header().Get("Access-Control-Allow-Headers"))
+				assert.Equal(t, "Origin, Content-Type, Authorization, Upgrade, Connection, Sec-WebSocket-Key, Sec-WebSocket-Version, Sec-WebSocket-Extensions, Sec-WebSocket-Protocol", w.Header().Get("Access-Control-Allow-Headers"))
 			}
 		})
 	}
@@ -102,3 +105,79 @@ func TestRequestTimer(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("lets fast handlers complete normally", func(t *testing.T) {
+		router := gin.New()
+		router.Use(RequestTimeout(50 * time.Millisecond))
+		router.GET("/test", func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		req, _ := http.NewRequest("GET", "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("cancels the request context once the deadline elapses", func(t *testing.T) {
+		router := gin.New()
+		router.Use(RequestTimeout(10 * time.Millisecond))
+		router.GET("/test", func(c *gin.Context) {
+			<-c.Request.Context().Done()
+			assert.ErrorIs(t, c.Request.Context().Err(), context.DeadlineExceeded)
+			c.Status(http.StatusGatewayTimeout)
+		})
+
+		req, _ := http.NewRequest("GET", "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	})
+}
+
+func TestServerHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(ServerHeader())
+	router.GET("/test", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Regexp(t, `^blank-go/\S+ \(\S+\)$`, w.Header().Get("Server"))
+}
+
+func TestMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(Metrics())
+	router.GET("/test/:id", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	req, _ := http.NewRequest("GET", "/test/123", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	metricsReq, _ := http.NewRequest("GET", "/metrics", nil)
+	metricsW := httptest.NewRecorder()
+	router.ServeHTTP(metricsW, metricsReq)
+
+	body := metricsW.Body.String()
+	assert.Contains(t, body, "http_requests_total")
+	assert.Contains(t, body, `route="/test/:id"`)
+	assert.Contains(t, body, "http_request_duration_seconds")
+}
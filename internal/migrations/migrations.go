@@ -0,0 +1,143 @@
+// Package migrations drives the DAX Postgres schema with
+// github.com/golang-migrate/migrate/v4, reading versioned .up.sql/.down.sql
+// files embedded into the binary so a deploy never depends on a migrations
+// directory being present on disk next to it.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+//go:embed sql/*.sql
+var sqlFS embed.FS
+
+// newMigrate opens a *migrate.Migrate backed by the embedded sql/
+// directory and dsn, a postgres:// connection URL (not the libpq
+// key=value form config.DatabaseConfig.DSN returns - see
+// config.DatabaseConfig.MigrateDSN).
+func newMigrate(dsn string) (*migrate.Migrate, error) {
+	source, err := iofs.New(sqlFS, "sql")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embedded migrations: %w", err)
+	}
+
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	driver, err := postgres.WithInstance(db, &postgres.Config{})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to init postgres migration driver: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, "postgres", driver)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to init migrate instance: %w", err)
+	}
+
+	return m, nil
+}
+
+// Up runs every pending migration against dsn, returning nil (rather than
+// migrate's own ErrNoChange) when the schema is already current.
+func Up(ctx context.Context, dsn string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m, err := newMigrate(dsn)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+	return nil
+}
+
+// Down rolls back steps applied migrations against dsn (all of them if
+// steps <= 0), returning nil when there was nothing to roll back.
+func Down(ctx context.Context, dsn string, steps int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m, err := newMigrate(dsn)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if steps <= 0 {
+		if err := m.Down(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			return fmt.Errorf("failed to roll back migrations: %w", err)
+		}
+		return nil
+	}
+
+	if err := m.Steps(-steps); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to roll back %d migration(s): %w", steps, err)
+	}
+	return nil
+}
+
+// Version reports dsn's current migration version and whether it was left
+// dirty by a previously failed migration. ok is false when no migration
+// has ever been applied.
+func Version(ctx context.Context, dsn string) (v uint, ok bool, err error) {
+	if err := ctx.Err(); err != nil {
+		return 0, false, err
+	}
+
+	m, err := newMigrate(dsn)
+	if err != nil {
+		return 0, false, err
+	}
+	defer m.Close()
+
+	version, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read migration version: %w", err)
+	}
+	if dirty {
+		return version, true, fmt.Errorf("migration version %d is dirty: a prior migration failed partway through and needs Force before Up/Down can run again", version)
+	}
+	return version, true, nil
+}
+
+// Force sets dsn's recorded migration version to v without running any
+// migration, for repairing a dirty version left by a failed migration once
+// the schema has been checked/fixed by hand.
+func Force(ctx context.Context, dsn string, v int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m, err := newMigrate(dsn)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if err := m.Force(v); err != nil {
+		return fmt.Errorf("failed to force migration version %d: %w", v, err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package portfolio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// AlertSink delivers a fired Alert to wherever alerts are consumed.
+type AlertSink interface {
+	Send(ctx context.Context, alert Alert) error
+}
+
+// NoopSink discards alerts. Useful when no webhook is configured but
+// alerts should still be recorded by the Evaluator via Repository.SaveAlert.
+type NoopSink struct{}
+
+// Send does nothing and always succeeds.
+func (NoopSink) Send(ctx context.Context, alert Alert) error {
+	return nil
+}
+
+// WebhookSink delivers alerts as a JSON POST to a configured URL.
+type WebhookSink struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewWebhookSink creates a WebhookSink that posts alerts to url.
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		url:        url,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Send posts alert to the configured webhook URL as JSON.
+func (w *WebhookSink) Send(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package portfolio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSink_Send(t *testing.T) {
+	var received Alert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL)
+	alert := Alert{ID: "a1", Ticker: "AAPL", Message: "moved"}
+
+	err := sink.Send(context.Background(), alert)
+	require.NoError(t, err)
+	assert.Equal(t, alert.ID, received.ID)
+	assert.Equal(t, alert.Ticker, received.Ticker)
+}
+
+func TestWebhookSink_Send_NonSuccessStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL)
+	err := sink.Send(context.Background(), Alert{ID: "a1"})
+	assert.Error(t, err)
+}
+
+func TestNoopSink_Send(t *testing.T) {
+	var sink NoopSink
+	assert.NoError(t, sink.Send(context.Background(), Alert{}))
+}
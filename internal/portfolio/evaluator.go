@@ -0,0 +1,117 @@
+package portfolio
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/stocks"
+	"github.com/google/uuid"
+)
+
+// StocksService is the subset of stocks.Service the Evaluator depends on.
+// GetBatchSummary already reuses stocks.Service's cache, so evaluating many
+// portfolios that share tickers never triggers more than one upstream fetch
+// per distinct ticker on a given tick.
+type StocksService interface {
+	GetBatchSummary(ctx context.Context, tickers string) (*stocks.BatchResponse, error)
+}
+
+// Evaluator checks every portfolio's holdings against live quotes and sends
+// an Alert through sink whenever a rule fires.
+type Evaluator struct {
+	repo   Repository
+	quotes StocksService
+	sink   AlertSink
+}
+
+// NewEvaluator creates an Evaluator backed by repo, quotes and sink.
+func NewEvaluator(repo Repository, quotes StocksService, sink AlertSink) *Evaluator {
+	return &Evaluator{repo: repo, quotes: quotes, sink: sink}
+}
+
+// Evaluate fetches a single batch of quotes covering every distinct ticker
+// across all portfolios, then checks each portfolio's rules against it,
+// persisting and sending any alerts that fire.
+func (e *Evaluator) Evaluate(ctx context.Context) error {
+	portfolios, err := e.repo.GetAll()
+	if err != nil {
+		return err
+	}
+	if len(portfolios) == 0 {
+		return nil
+	}
+
+	tickers := distinctTickers(portfolios)
+	if len(tickers) == 0 {
+		return nil
+	}
+
+	batch, err := e.quotes.GetBatchSummary(ctx, strings.Join(tickers, ","))
+	if err != nil {
+		return err
+	}
+
+	summaries := make(map[string]*stocks.StockSummary, len(batch.Summaries))
+	for i := range batch.Summaries {
+		summaries[batch.Summaries[i].Ticker] = &batch.Summaries[i]
+	}
+
+	now := time.Now()
+	for _, p := range portfolios {
+		e.evaluatePortfolio(ctx, p, summaries, now)
+	}
+	return nil
+}
+
+func (e *Evaluator) evaluatePortfolio(ctx context.Context, p *Portfolio, summaries map[string]*stocks.StockSummary, now time.Time) {
+	holdingsByTicker := make(map[string]Holding, len(p.Holdings))
+	for _, h := range p.Holdings {
+		holdingsByTicker[h.Ticker] = h
+	}
+
+	for _, rule := range p.Rules {
+		summary, found := summaries[rule.Ticker]
+		if !found {
+			continue
+		}
+
+		alert, fired := evaluateRule(rule, holdingsByTicker[rule.Ticker], summary)
+		if !fired {
+			continue
+		}
+
+		alert.ID = uuid.New().String()
+		alert.PortfolioID = p.ID
+		alert.RuleID = rule.ID
+		alert.TriggeredAt = now
+
+		if err := e.repo.SaveAlert(&alert); err != nil {
+			log.Printf("portfolio: failed to save alert for %s/%s: %v", p.ID, rule.Ticker, err)
+			continue
+		}
+
+		if err := e.sink.Send(ctx, alert); err != nil {
+			log.Printf("portfolio: failed to send alert for %s/%s: %v", p.ID, rule.Ticker, err)
+		}
+	}
+}
+
+// distinctTickers collects every distinct ticker referenced by an alert
+// rule across all portfolios, so the evaluator fetches each one exactly
+// once per tick regardless of how many portfolios hold it.
+func distinctTickers(portfolios []*Portfolio) []string {
+	seen := make(map[string]bool)
+	var tickers []string
+	for _, p := range portfolios {
+		for _, rule := range p.Rules {
+			if rule.Ticker == "" || seen[rule.Ticker] {
+				continue
+			}
+			seen[rule.Ticker] = true
+			tickers = append(tickers, rule.Ticker)
+		}
+	}
+	return tickers
+}
@@ -0,0 +1,137 @@
+package portfolio
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/f00b455/blank-go/pkg/stocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQuoteService is a test double for StocksService that records every
+// tickers argument it was called with, so tests can assert the evaluator
+// batches distinct tickers into a single call.
+type fakeQuoteService struct {
+	mu       sync.Mutex
+	calls    []string
+	quotesBy map[string]stocks.StockSummary
+	err      error
+}
+
+func (f *fakeQuoteService) GetBatchSummary(ctx context.Context, tickers string) (*stocks.BatchResponse, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, tickers)
+	f.mu.Unlock()
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	response := &stocks.BatchResponse{}
+	for _, ticker := range strings.Split(tickers, ",") {
+		if summary, ok := f.quotesBy[ticker]; ok {
+			response.Summaries = append(response.Summaries, summary)
+		}
+	}
+	return response, nil
+}
+
+// fakeSink records every alert sent to it.
+type fakeSink struct {
+	mu     sync.Mutex
+	alerts []Alert
+}
+
+func (s *fakeSink) Send(ctx context.Context, alert Alert) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.alerts = append(s.alerts, alert)
+	return nil
+}
+
+func TestEvaluator_Evaluate_BatchesDistinctTickersAcrossPortfolios(t *testing.T) {
+	quotes := &fakeQuoteService{
+		quotesBy: map[string]stocks.StockSummary{
+			"AAPL": {Ticker: "AAPL", CurrentPrice: 150},
+			"MSFT": {Ticker: "MSFT", CurrentPrice: 300},
+		},
+	}
+	repo := NewInMemoryStore()
+	require.NoError(t, repo.Create(&Portfolio{
+		ID:       "p1",
+		Holdings: []Holding{{Ticker: "AAPL", Shares: 1, CostBasis: 100}},
+		Rules:    []AlertRule{{ID: "r1", Ticker: "AAPL", Type: RulePercentFromCost, Threshold: 10}},
+	}))
+	require.NoError(t, repo.Create(&Portfolio{
+		ID:       "p2",
+		Holdings: []Holding{{Ticker: "AAPL", Shares: 2, CostBasis: 90}, {Ticker: "MSFT", Shares: 1, CostBasis: 200}},
+		Rules: []AlertRule{
+			{ID: "r2", Ticker: "AAPL", Type: RulePercentFromCost, Threshold: 10},
+			{ID: "r3", Ticker: "MSFT", Type: RulePercentFromCost, Threshold: 10},
+		},
+	}))
+
+	sink := &fakeSink{}
+	evaluator := NewEvaluator(repo, quotes, sink)
+
+	err := evaluator.Evaluate(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, quotes.calls, 1, "expected a single batched GetBatchSummary call across all portfolios")
+	calledTickers := strings.Split(quotes.calls[0], ",")
+	assert.ElementsMatch(t, []string{"AAPL", "MSFT"}, calledTickers)
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	assert.Len(t, sink.alerts, 3, "one alert per firing rule: p1/AAPL, p2/AAPL, p2/MSFT")
+
+	alerts, err := repo.GetAlerts("")
+	require.NoError(t, err)
+	assert.Len(t, alerts, 3)
+}
+
+func TestEvaluator_Evaluate_NoPortfolios(t *testing.T) {
+	quotes := &fakeQuoteService{}
+	evaluator := NewEvaluator(NewInMemoryStore(), quotes, &fakeSink{})
+
+	err := evaluator.Evaluate(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, quotes.calls)
+}
+
+func TestEvaluator_Evaluate_SkipsTickersMissingFromBatchResponse(t *testing.T) {
+	quotes := &fakeQuoteService{quotesBy: map[string]stocks.StockSummary{}}
+	repo := NewInMemoryStore()
+	require.NoError(t, repo.Create(&Portfolio{
+		ID:       "p1",
+		Holdings: []Holding{{Ticker: "AAPL", Shares: 1, CostBasis: 100}},
+		Rules:    []AlertRule{{ID: "r1", Ticker: "AAPL", Type: RulePercentFromCost, Threshold: 10}},
+	}))
+
+	sink := &fakeSink{}
+	evaluator := NewEvaluator(repo, quotes, sink)
+
+	err := evaluator.Evaluate(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, sink.alerts)
+}
+
+func TestEvaluator_Evaluate_PropagatesQuoteError(t *testing.T) {
+	wantErr := errors.New("upstream unavailable")
+	quotes := &fakeQuoteService{err: wantErr}
+	repo := NewInMemoryStore()
+	require.NoError(t, repo.Create(&Portfolio{
+		ID:       "p1",
+		Holdings: []Holding{{Ticker: "AAPL", Shares: 1, CostBasis: 100}},
+		Rules:    []AlertRule{{ID: "r1", Ticker: "AAPL", Type: RulePercentFromCost, Threshold: 10}},
+	}))
+
+	evaluator := NewEvaluator(repo, quotes, &fakeSink{})
+
+	err := evaluator.Evaluate(context.Background())
+	assert.ErrorIs(t, err, wantErr)
+}
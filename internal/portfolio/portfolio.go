@@ -0,0 +1,94 @@
+// Package portfolio tracks user stock holdings and evaluates them against
+// live quotes from pkg/stocks, emitting alerts when configured rules fire.
+package portfolio
+
+import (
+	"time"
+)
+
+// RuleType identifies the kind of condition an AlertRule evaluates.
+type RuleType string
+
+const (
+	// RulePercentFromCost fires when a holding's current price has moved by
+	// more than Threshold percent (in either direction) from its cost basis.
+	RulePercentFromCost RuleType = "percent_from_cost"
+	// RuleAbsolutePriceCross fires when the current price crosses
+	// Threshold: at or above it if Direction is "above", at or below it if
+	// Direction is "below".
+	RuleAbsolutePriceCross RuleType = "absolute_price_cross"
+	// RuleDailyChangePercent fires when the quote's daily ChangePercent
+	// exceeds Threshold percent in magnitude.
+	RuleDailyChangePercent RuleType = "daily_change_percent"
+)
+
+// IsValidRuleType reports whether t is one of the supported rule types.
+func IsValidRuleType(t RuleType) bool {
+	switch t {
+	case RulePercentFromCost, RuleAbsolutePriceCross, RuleDailyChangePercent:
+		return true
+	default:
+		return false
+	}
+}
+
+// Direction values accepted by RuleAbsolutePriceCross.
+const (
+	DirectionAbove = "above"
+	DirectionBelow = "below"
+)
+
+// Holding is a single position within a Portfolio: a number of shares of
+// Ticker acquired at CostBasis per share.
+type Holding struct {
+	Ticker    string  `json:"ticker"`
+	Shares    float64 `json:"shares"`
+	CostBasis float64 `json:"cost_basis"`
+}
+
+// AlertRule is a single alerting condition attached to a ticker within a
+// Portfolio. Direction is only meaningful for RuleAbsolutePriceCross.
+type AlertRule struct {
+	ID        string   `json:"id"`
+	Ticker    string   `json:"ticker"`
+	Type      RuleType `json:"type"`
+	Threshold float64  `json:"threshold"`
+	Direction string   `json:"direction,omitempty"`
+}
+
+// Portfolio groups a user's holdings and the alert rules evaluated against
+// them on every tick of the Scheduler.
+type Portfolio struct {
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	Holdings  []Holding   `json:"holdings"`
+	Rules     []AlertRule `json:"rules"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// Tickers returns the distinct, non-empty tickers held by p.
+func (p *Portfolio) Tickers() []string {
+	seen := make(map[string]bool, len(p.Holdings))
+	tickers := make([]string, 0, len(p.Holdings))
+	for _, h := range p.Holdings {
+		if h.Ticker == "" || seen[h.Ticker] {
+			continue
+		}
+		seen[h.Ticker] = true
+		tickers = append(tickers, h.Ticker)
+	}
+	return tickers
+}
+
+// Alert is a record of an AlertRule firing for a ticker within a Portfolio.
+type Alert struct {
+	ID          string    `json:"id"`
+	PortfolioID string    `json:"portfolio_id"`
+	RuleID      string    `json:"rule_id"`
+	Ticker      string    `json:"ticker"`
+	Type        RuleType  `json:"type"`
+	Message     string    `json:"message"`
+	Value       float64   `json:"value"`
+	TriggeredAt time.Time `json:"triggered_at"`
+}
@@ -0,0 +1,74 @@
+package portfolio
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/f00b455/blank-go/pkg/stocks"
+)
+
+// evaluateRule checks rule against the holding's current quote, returning
+// the fired Alert and true if the condition holds, or false otherwise.
+func evaluateRule(rule AlertRule, holding Holding, summary *stocks.StockSummary) (Alert, bool) {
+	switch rule.Type {
+	case RulePercentFromCost:
+		return evaluatePercentFromCost(rule, holding, summary)
+	case RuleAbsolutePriceCross:
+		return evaluateAbsolutePriceCross(rule, summary)
+	case RuleDailyChangePercent:
+		return evaluateDailyChangePercent(rule, summary)
+	default:
+		return Alert{}, false
+	}
+}
+
+func evaluatePercentFromCost(rule AlertRule, holding Holding, summary *stocks.StockSummary) (Alert, bool) {
+	if holding.CostBasis == 0 {
+		return Alert{}, false
+	}
+
+	changePercent := (summary.CurrentPrice - holding.CostBasis) / holding.CostBasis * 100
+	if math.Abs(changePercent) < rule.Threshold {
+		return Alert{}, false
+	}
+
+	return Alert{
+		Ticker:  rule.Ticker,
+		Type:    rule.Type,
+		Value:   changePercent,
+		Message: fmt.Sprintf("%s has moved %.2f%% from its cost basis of %.2f (threshold %.2f%%)", rule.Ticker, changePercent, holding.CostBasis, rule.Threshold),
+	}, true
+}
+
+func evaluateAbsolutePriceCross(rule AlertRule, summary *stocks.StockSummary) (Alert, bool) {
+	switch rule.Direction {
+	case DirectionBelow:
+		if summary.CurrentPrice > rule.Threshold {
+			return Alert{}, false
+		}
+	default: // DirectionAbove is the default for an absolute price cross rule
+		if summary.CurrentPrice < rule.Threshold {
+			return Alert{}, false
+		}
+	}
+
+	return Alert{
+		Ticker:  rule.Ticker,
+		Type:    rule.Type,
+		Value:   summary.CurrentPrice,
+		Message: fmt.Sprintf("%s price %.2f crossed %s threshold %.2f", rule.Ticker, summary.CurrentPrice, rule.Direction, rule.Threshold),
+	}, true
+}
+
+func evaluateDailyChangePercent(rule AlertRule, summary *stocks.StockSummary) (Alert, bool) {
+	if math.Abs(summary.ChangePercent) < rule.Threshold {
+		return Alert{}, false
+	}
+
+	return Alert{
+		Ticker:  rule.Ticker,
+		Type:    rule.Type,
+		Value:   summary.ChangePercent,
+		Message: fmt.Sprintf("%s daily change %.2f%% exceeds threshold %.2f%%", rule.Ticker, summary.ChangePercent, rule.Threshold),
+	}, true
+}
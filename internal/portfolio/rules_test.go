@@ -0,0 +1,80 @@
+package portfolio
+
+import (
+	"testing"
+
+	"github.com/f00b455/blank-go/pkg/stocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateRule_PercentFromCost(t *testing.T) {
+	rule := AlertRule{Ticker: "AAPL", Type: RulePercentFromCost, Threshold: 10}
+	holding := Holding{Ticker: "AAPL", Shares: 10, CostBasis: 100}
+
+	t.Run("fires above threshold", func(t *testing.T) {
+		alert, fired := evaluateRule(rule, holding, &stocks.StockSummary{Ticker: "AAPL", CurrentPrice: 120})
+		assert.True(t, fired)
+		assert.InDelta(t, 20.0, alert.Value, 0.01)
+	})
+
+	t.Run("fires on the downside too", func(t *testing.T) {
+		alert, fired := evaluateRule(rule, holding, &stocks.StockSummary{Ticker: "AAPL", CurrentPrice: 85})
+		assert.True(t, fired)
+		assert.InDelta(t, -15.0, alert.Value, 0.01)
+	})
+
+	t.Run("does not fire below threshold", func(t *testing.T) {
+		_, fired := evaluateRule(rule, holding, &stocks.StockSummary{Ticker: "AAPL", CurrentPrice: 105})
+		assert.False(t, fired)
+	})
+
+	t.Run("zero cost basis never fires", func(t *testing.T) {
+		_, fired := evaluateRule(rule, Holding{Ticker: "AAPL"}, &stocks.StockSummary{Ticker: "AAPL", CurrentPrice: 105})
+		assert.False(t, fired)
+	})
+}
+
+func TestEvaluateRule_AbsolutePriceCross(t *testing.T) {
+	t.Run("above direction", func(t *testing.T) {
+		rule := AlertRule{Ticker: "AAPL", Type: RuleAbsolutePriceCross, Threshold: 200, Direction: DirectionAbove}
+		_, fired := evaluateRule(rule, Holding{}, &stocks.StockSummary{CurrentPrice: 199})
+		assert.False(t, fired)
+
+		alert, fired := evaluateRule(rule, Holding{}, &stocks.StockSummary{CurrentPrice: 200})
+		assert.True(t, fired)
+		assert.Equal(t, 200.0, alert.Value)
+	})
+
+	t.Run("below direction", func(t *testing.T) {
+		rule := AlertRule{Ticker: "AAPL", Type: RuleAbsolutePriceCross, Threshold: 100, Direction: DirectionBelow}
+		_, fired := evaluateRule(rule, Holding{}, &stocks.StockSummary{CurrentPrice: 101})
+		assert.False(t, fired)
+
+		_, fired = evaluateRule(rule, Holding{}, &stocks.StockSummary{CurrentPrice: 99})
+		assert.True(t, fired)
+	})
+}
+
+func TestEvaluateRule_DailyChangePercent(t *testing.T) {
+	rule := AlertRule{Ticker: "AAPL", Type: RuleDailyChangePercent, Threshold: 5}
+
+	_, fired := evaluateRule(rule, Holding{}, &stocks.StockSummary{ChangePercent: 4})
+	assert.False(t, fired)
+
+	alert, fired := evaluateRule(rule, Holding{}, &stocks.StockSummary{ChangePercent: -6})
+	assert.True(t, fired)
+	assert.Equal(t, -6.0, alert.Value)
+}
+
+func TestEvaluateRule_UnknownType(t *testing.T) {
+	rule := AlertRule{Ticker: "AAPL", Type: "bogus"}
+	_, fired := evaluateRule(rule, Holding{}, &stocks.StockSummary{})
+	assert.False(t, fired)
+}
+
+func TestIsValidRuleType(t *testing.T) {
+	assert.True(t, IsValidRuleType(RulePercentFromCost))
+	assert.True(t, IsValidRuleType(RuleAbsolutePriceCross))
+	assert.True(t, IsValidRuleType(RuleDailyChangePercent))
+	assert.False(t, IsValidRuleType("bogus"))
+}
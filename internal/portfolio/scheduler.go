@@ -0,0 +1,81 @@
+package portfolio
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// marketTimezone is the timezone regular US equity market hours are
+// expressed in; quotes outside this window rarely move enough to be worth
+// an evaluation tick.
+const marketTimezone = "America/New_York"
+
+// marketOpenHour and marketCloseHour bound the regular trading session
+// (09:30-16:00 ET), matched against the wall clock in marketTimezone.
+const (
+	marketOpenHour  = 9
+	marketOpenMin   = 30
+	marketCloseHour = 16
+)
+
+// Scheduler ticks an Evaluator on Interval, skipping weekends and hours
+// outside the regular trading session so a process left running overnight
+// or over the weekend doesn't evaluate portfolios for nothing.
+type Scheduler struct {
+	evaluator *Evaluator
+	interval  time.Duration
+	now       func() time.Time
+}
+
+// NewScheduler creates a Scheduler that runs evaluator every interval.
+func NewScheduler(evaluator *Evaluator, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		evaluator: evaluator,
+		interval:  interval,
+		now:       time.Now,
+	}
+}
+
+// Run blocks, ticking the evaluator every s.interval until ctx is canceled.
+// Ticks that land outside a weekday market session are skipped entirely.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !isMarketHours(s.now()) {
+				continue
+			}
+			if err := s.evaluator.Evaluate(ctx); err != nil {
+				log.Printf("portfolio: evaluation tick failed: %v", err)
+			}
+		}
+	}
+}
+
+// isMarketHours reports whether t falls on a weekday within the regular US
+// equity trading session. It is a coarse pre-check to avoid pointless
+// evaluation ticks; it does not account for market holidays.
+func isMarketHours(t time.Time) bool {
+	loc, err := time.LoadLocation(marketTimezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := t.In(loc)
+
+	switch local.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+
+	openMinutes := marketOpenHour*60 + marketOpenMin
+	closeMinutes := marketCloseHour * 60
+	nowMinutes := local.Hour()*60 + local.Minute()
+
+	return nowMinutes >= openMinutes && nowMinutes < closeMinutes
+}
@@ -0,0 +1,53 @@
+package portfolio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsMarketHours(t *testing.T) {
+	loc, err := time.LoadLocation(marketTimezone)
+	if err != nil {
+		t.Skipf("timezone database unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want bool
+	}{
+		{
+			name: "weekday during session",
+			t:    time.Date(2026, 7, 27, 10, 0, 0, 0, loc),
+			want: true,
+		},
+		{
+			name: "weekday before open",
+			t:    time.Date(2026, 7, 27, 9, 0, 0, 0, loc),
+			want: false,
+		},
+		{
+			name: "weekday after close",
+			t:    time.Date(2026, 7, 27, 16, 30, 0, 0, loc),
+			want: false,
+		},
+		{
+			name: "saturday during session hours",
+			t:    time.Date(2026, 8, 1, 10, 0, 0, 0, loc),
+			want: false,
+		},
+		{
+			name: "sunday during session hours",
+			t:    time.Date(2026, 8, 2, 10, 0, 0, 0, loc),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isMarketHours(tt.t))
+		})
+	}
+}
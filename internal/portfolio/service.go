@@ -0,0 +1,127 @@
+package portfolio
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidName is returned when a portfolio name is empty.
+	ErrInvalidName = errors.New("name is required")
+	// ErrInvalidHoldings is returned when a portfolio has no holdings.
+	ErrInvalidHoldings = errors.New("at least one holding is required")
+	// ErrInvalidRuleType is returned when an alert rule has an unknown type.
+	ErrInvalidRuleType = errors.New("invalid rule type")
+)
+
+// defaultEvaluationInterval is how often the scheduler evaluates portfolios
+// when one isn't started explicitly with a different interval.
+const defaultEvaluationInterval = time.Minute
+
+// Service provides portfolio CRUD operations and owns the background
+// Scheduler that evaluates alert rules against live quotes.
+type Service struct {
+	repo      Repository
+	scheduler *Scheduler
+
+	cancel context.CancelFunc
+}
+
+// NewService creates a Service backed by repo, evaluating portfolios
+// against quotes and delivering fired alerts through sink.
+func NewService(repo Repository, quotes StocksService, sink AlertSink) *Service {
+	evaluator := NewEvaluator(repo, quotes, sink)
+	return &Service{
+		repo:      repo,
+		scheduler: NewScheduler(evaluator, defaultEvaluationInterval),
+	}
+}
+
+// Start launches the background evaluation scheduler. It is a no-op if
+// already started. Stop (or canceling ctx) ends the scheduler loop.
+func (s *Service) Start(ctx context.Context) {
+	if s.cancel != nil {
+		return
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+	go s.scheduler.Run(runCtx)
+}
+
+// Stop ends the background evaluation scheduler started by Start.
+func (s *Service) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.cancel = nil
+}
+
+// CreatePortfolioRequest represents the data needed to create a portfolio.
+type CreatePortfolioRequest struct {
+	Name     string      `json:"name"`
+	Holdings []Holding   `json:"holdings"`
+	Rules    []AlertRule `json:"rules,omitempty"`
+}
+
+// Create validates req and persists a new Portfolio.
+func (s *Service) Create(req CreatePortfolioRequest) (*Portfolio, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, ErrInvalidName
+	}
+	if len(req.Holdings) == 0 {
+		return nil, ErrInvalidHoldings
+	}
+	for _, rule := range req.Rules {
+		if !IsValidRuleType(rule.Type) {
+			return nil, ErrInvalidRuleType
+		}
+	}
+
+	now := time.Now()
+	rules := make([]AlertRule, len(req.Rules))
+	for i, rule := range req.Rules {
+		rule.ID = uuid.New().String()
+		rules[i] = rule
+	}
+
+	p := &Portfolio{
+		ID:        uuid.New().String(),
+		Name:      strings.TrimSpace(req.Name),
+		Holdings:  req.Holdings,
+		Rules:     rules,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.repo.Create(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// GetByID retrieves a portfolio by ID.
+func (s *Service) GetByID(id string) (*Portfolio, error) {
+	return s.repo.GetByID(id)
+}
+
+// GetAll retrieves every portfolio.
+func (s *Service) GetAll() ([]*Portfolio, error) {
+	return s.repo.GetAll()
+}
+
+// Delete removes a portfolio by ID.
+func (s *Service) Delete(id string) error {
+	return s.repo.Delete(id)
+}
+
+// GetAlerts returns the alerts recorded for portfolioID, or for every
+// portfolio if portfolioID is empty.
+func (s *Service) GetAlerts(portfolioID string) ([]*Alert, error) {
+	return s.repo.GetAlerts(portfolioID)
+}
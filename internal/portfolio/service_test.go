@@ -0,0 +1,109 @@
+package portfolio
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     CreatePortfolioRequest
+		wantErr error
+	}{
+		{
+			name: "valid portfolio",
+			req: CreatePortfolioRequest{
+				Name:     "Retirement",
+				Holdings: []Holding{{Ticker: "AAPL", Shares: 10, CostBasis: 100}},
+				Rules:    []AlertRule{{Ticker: "AAPL", Type: RulePercentFromCost, Threshold: 10}},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "missing name",
+			req:     CreatePortfolioRequest{Holdings: []Holding{{Ticker: "AAPL", Shares: 1, CostBasis: 1}}},
+			wantErr: ErrInvalidName,
+		},
+		{
+			name:    "missing holdings",
+			req:     CreatePortfolioRequest{Name: "Empty"},
+			wantErr: ErrInvalidHoldings,
+		},
+		{
+			name: "invalid rule type",
+			req: CreatePortfolioRequest{
+				Name:     "Bad rule",
+				Holdings: []Holding{{Ticker: "AAPL", Shares: 1, CostBasis: 1}},
+				Rules:    []AlertRule{{Ticker: "AAPL", Type: "bogus"}},
+			},
+			wantErr: ErrInvalidRuleType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewService(NewInMemoryStore(), &fakeQuoteService{}, &fakeSink{})
+
+			created, err := service.Create(tt.req)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, created)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, created)
+			assert.NotEmpty(t, created.ID)
+			for _, rule := range created.Rules {
+				assert.NotEmpty(t, rule.ID)
+			}
+		})
+	}
+}
+
+func TestService_GetByID_GetAll_Delete(t *testing.T) {
+	service := NewService(NewInMemoryStore(), &fakeQuoteService{}, &fakeSink{})
+
+	created, err := service.Create(CreatePortfolioRequest{
+		Name:     "Retirement",
+		Holdings: []Holding{{Ticker: "AAPL", Shares: 1, CostBasis: 1}},
+	})
+	require.NoError(t, err)
+
+	found, err := service.GetByID(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, found.ID)
+
+	all, err := service.GetAll()
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+
+	require.NoError(t, service.Delete(created.ID))
+	_, err = service.GetByID(created.ID)
+	assert.ErrorIs(t, err, ErrPortfolioNotFound)
+}
+
+func TestService_GetAlerts(t *testing.T) {
+	repo := NewInMemoryStore()
+	require.NoError(t, repo.SaveAlert(&Alert{ID: "a1", PortfolioID: "p1"}))
+
+	service := NewService(repo, &fakeQuoteService{}, &fakeSink{})
+
+	alerts, err := service.GetAlerts("p1")
+	require.NoError(t, err)
+	assert.Len(t, alerts, 1)
+}
+
+func TestService_StartStop_IsIdempotent(t *testing.T) {
+	service := NewService(NewInMemoryStore(), &fakeQuoteService{}, &fakeSink{})
+
+	service.Start(context.Background())
+	service.Start(context.Background()) // second call should be a no-op
+	service.Stop()
+	service.Stop() // second call should be a no-op
+}
@@ -0,0 +1,133 @@
+package portfolio
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrPortfolioNotFound is returned when a portfolio is not found.
+	ErrPortfolioNotFound = errors.New("portfolio not found")
+)
+
+// Repository defines the interface for portfolio and alert storage.
+type Repository interface {
+	Create(p *Portfolio) error
+	GetByID(id string) (*Portfolio, error)
+	GetAll() ([]*Portfolio, error)
+	Update(p *Portfolio) error
+	Delete(id string) error
+
+	SaveAlert(a *Alert) error
+	GetAlerts(portfolioID string) ([]*Alert, error)
+}
+
+// InMemoryStore implements Repository using in-memory storage.
+type InMemoryStore struct {
+	mu         sync.RWMutex
+	portfolios map[string]*Portfolio
+	alerts     map[string][]*Alert
+}
+
+// NewInMemoryStore creates a new in-memory portfolio store.
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{
+		portfolios: make(map[string]*Portfolio),
+		alerts:     make(map[string][]*Alert),
+	}
+}
+
+// Create adds a new portfolio to the store.
+func (s *InMemoryStore) Create(p *Portfolio) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.portfolios[p.ID] = p
+	return nil
+}
+
+// GetByID retrieves a portfolio by its ID.
+func (s *InMemoryStore) GetByID(id string) (*Portfolio, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, exists := s.portfolios[id]
+	if !exists {
+		return nil, ErrPortfolioNotFound
+	}
+	return p, nil
+}
+
+// GetAll retrieves every portfolio in the store.
+func (s *InMemoryStore) GetAll() ([]*Portfolio, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*Portfolio, 0, len(s.portfolios))
+	for _, p := range s.portfolios {
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+// Update modifies an existing portfolio.
+func (s *InMemoryStore) Update(p *Portfolio) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.portfolios[p.ID]; !exists {
+		return ErrPortfolioNotFound
+	}
+	s.portfolios[p.ID] = p
+	return nil
+}
+
+// Delete removes a portfolio from the store.
+func (s *InMemoryStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.portfolios[id]; !exists {
+		return ErrPortfolioNotFound
+	}
+	delete(s.portfolios, id)
+	delete(s.alerts, id)
+	return nil
+}
+
+// SaveAlert records a fired alert against its portfolio.
+func (s *InMemoryStore) SaveAlert(a *Alert) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.alerts[a.PortfolioID] = append(s.alerts[a.PortfolioID], a)
+	return nil
+}
+
+// GetAlerts returns the alerts recorded for portfolioID, most recent first.
+// If portfolioID is empty, alerts for every portfolio are returned.
+func (s *InMemoryStore) GetAlerts(portfolioID string) ([]*Alert, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []*Alert
+	if portfolioID != "" {
+		result = append(result, s.alerts[portfolioID]...)
+	} else {
+		for _, alerts := range s.alerts {
+			result = append(result, alerts...)
+		}
+	}
+
+	sortAlertsDesc(result)
+	return result, nil
+}
+
+// sortAlertsDesc sorts alerts by TriggeredAt, most recent first.
+func sortAlertsDesc(alerts []*Alert) {
+	for i := 1; i < len(alerts); i++ {
+		for j := i; j > 0 && alerts[j].TriggeredAt.After(alerts[j-1].TriggeredAt); j-- {
+			alerts[j], alerts[j-1] = alerts[j-1], alerts[j]
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package portfolio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryStore_Create(t *testing.T) {
+	store := NewInMemoryStore()
+	p := &Portfolio{ID: "p1", Name: "Retirement", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+
+	err := store.Create(p)
+	require.NoError(t, err)
+
+	retrieved, err := store.GetByID("p1")
+	require.NoError(t, err)
+	assert.Equal(t, p.Name, retrieved.Name)
+}
+
+func TestInMemoryStore_GetByID_NotFound(t *testing.T) {
+	store := NewInMemoryStore()
+
+	_, err := store.GetByID("missing")
+	assert.ErrorIs(t, err, ErrPortfolioNotFound)
+}
+
+func TestInMemoryStore_GetAll(t *testing.T) {
+	store := NewInMemoryStore()
+	require.NoError(t, store.Create(&Portfolio{ID: "p1", Name: "A"}))
+	require.NoError(t, store.Create(&Portfolio{ID: "p2", Name: "B"}))
+
+	all, err := store.GetAll()
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+}
+
+func TestInMemoryStore_Update(t *testing.T) {
+	store := NewInMemoryStore()
+	p := &Portfolio{ID: "p1", Name: "Original"}
+	require.NoError(t, store.Create(p))
+
+	p.Name = "Updated"
+	require.NoError(t, store.Update(p))
+
+	retrieved, err := store.GetByID("p1")
+	require.NoError(t, err)
+	assert.Equal(t, "Updated", retrieved.Name)
+
+	err = store.Update(&Portfolio{ID: "missing"})
+	assert.ErrorIs(t, err, ErrPortfolioNotFound)
+}
+
+func TestInMemoryStore_Delete(t *testing.T) {
+	store := NewInMemoryStore()
+	require.NoError(t, store.Create(&Portfolio{ID: "p1", Name: "A"}))
+	require.NoError(t, store.SaveAlert(&Alert{ID: "a1", PortfolioID: "p1"}))
+
+	require.NoError(t, store.Delete("p1"))
+
+	_, err := store.GetByID("p1")
+	assert.ErrorIs(t, err, ErrPortfolioNotFound)
+
+	alerts, err := store.GetAlerts("p1")
+	require.NoError(t, err)
+	assert.Empty(t, alerts)
+
+	err = store.Delete("p1")
+	assert.ErrorIs(t, err, ErrPortfolioNotFound)
+}
+
+func TestInMemoryStore_SaveAlert_GetAlerts(t *testing.T) {
+	store := NewInMemoryStore()
+	now := time.Now()
+
+	require.NoError(t, store.SaveAlert(&Alert{ID: "a1", PortfolioID: "p1", TriggeredAt: now}))
+	require.NoError(t, store.SaveAlert(&Alert{ID: "a2", PortfolioID: "p1", TriggeredAt: now.Add(time.Minute)}))
+	require.NoError(t, store.SaveAlert(&Alert{ID: "a3", PortfolioID: "p2", TriggeredAt: now}))
+
+	t.Run("filtered by portfolio, most recent first", func(t *testing.T) {
+		alerts, err := store.GetAlerts("p1")
+		require.NoError(t, err)
+		require.Len(t, alerts, 2)
+		assert.Equal(t, "a2", alerts[0].ID)
+		assert.Equal(t, "a1", alerts[1].ID)
+	})
+
+	t.Run("all portfolios when empty", func(t *testing.T) {
+		alerts, err := store.GetAlerts("")
+		require.NoError(t, err)
+		assert.Len(t, alerts, 3)
+	})
+}
@@ -1,10 +1,100 @@
 // Package version provides centralized version management for the application.
-// The version can be set at build time using ldflags:
+// Version, Commit and BuildDate can be set at build time using ldflags:
 //
-//	go build -ldflags "-X github.com/f00b455/blank-go/internal/version.Version=1.2.3"
+//	go build -ldflags "-X github.com/f00b455/blank-go/internal/version.Version=1.2.3 \
+//	  -X github.com/f00b455/blank-go/internal/version.Commit=$(git rev-parse HEAD) \
+//	  -X github.com/f00b455/blank-go/internal/version.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// `go install`-ed binaries never go through that ldflags step, so Get falls
+// back to the module/VCS metadata runtime/debug.ReadBuildInfo() embeds in
+// every Go binary since 1.18.
 package version
 
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+)
+
 // Version is the current application version.
 // Default is "dev" for development builds.
 // Set via ldflags during release builds.
 var Version = "dev"
+
+// Commit is the VCS revision the binary was built from. Set via ldflags
+// during release builds; falls back to the vcs.revision setting in
+// runtime/debug.ReadBuildInfo() for go-installed binaries.
+var Commit = "unknown"
+
+// BuildDate is when the binary was built, as an RFC 3339 timestamp. Set via
+// ldflags during release builds; left "unknown" for go-installed binaries,
+// which have no build-time equivalent in their embedded VCS metadata.
+var BuildDate = "unknown"
+
+// BuildInfo is the full set of version/build metadata the application
+// reports via GET /api/v1/version, the --version CLI flag, and the
+// Server response header.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+	Dirty     bool   `json:"dirty"`
+}
+
+var (
+	buildInfoOnce sync.Once
+	cachedInfo    BuildInfo
+)
+
+// Get returns the application's BuildInfo, filling in Commit/Dirty from
+// runtime/debug.ReadBuildInfo() when ldflags didn't already set Commit
+// (the case for `go install` and `go run`).
+func Get() BuildInfo {
+	buildInfoOnce.Do(func() {
+		cachedInfo = BuildInfo{
+			Version:   Version,
+			Commit:    Commit,
+			BuildDate: BuildDate,
+			GoVersion: runtime.Version(),
+		}
+
+		if cachedInfo.Commit != "unknown" {
+			return
+		}
+
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				cachedInfo.Commit = setting.Value
+			case "vcs.modified":
+				cachedInfo.Dirty = setting.Value == "true"
+			}
+		}
+	})
+
+	return cachedInfo
+}
+
+// String renders info in the compact form used for the Server response
+// header and --version CLI output: "blank-go/<version> (<commit>)".
+func (b BuildInfo) String() string {
+	commit := b.Commit
+	if b.Dirty {
+		commit += "-dirty"
+	}
+	return fmt.Sprintf("blank-go/%s (%s)", b.Version, commit)
+}
+
+// JSON renders info as the indented JSON document served by
+// GET /api/v1/version and printed by --version.
+func (b BuildInfo) JSON() ([]byte, error) {
+	return json.MarshalIndent(b, "", "  ")
+}
@@ -0,0 +1,35 @@
+package version
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	info := Get()
+
+	assert.Equal(t, Version, info.Version)
+	assert.NotEmpty(t, info.Commit)
+	assert.NotEmpty(t, info.GoVersion)
+}
+
+func TestBuildInfoString(t *testing.T) {
+	info := BuildInfo{Version: "1.2.3", Commit: "abc1234"}
+	assert.Equal(t, "blank-go/1.2.3 (abc1234)", info.String())
+
+	dirty := BuildInfo{Version: "1.2.3", Commit: "abc1234", Dirty: true}
+	assert.Equal(t, "blank-go/1.2.3 (abc1234-dirty)", dirty.String())
+}
+
+func TestBuildInfoJSON(t *testing.T) {
+	info := BuildInfo{Version: "1.2.3", Commit: "abc1234", BuildDate: "2026-01-01T00:00:00Z", GoVersion: "go1.25"}
+
+	data, err := info.JSON()
+	assert.NoError(t, err)
+
+	var decoded BuildInfo
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, info, decoded)
+}
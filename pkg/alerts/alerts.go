@@ -0,0 +1,74 @@
+// Package alerts lets a user register threshold rules against live stock
+// quotes (pkg/stocks) or DAX financial metrics (pkg/dax), and delivers a
+// push notification to their device when a rule's condition is met. It is
+// the cross-source counterpart to internal/portfolio's alerting, which only
+// ever evaluates a user's own holdings: a Rule here names its ticker and
+// (optionally) a DAX metric directly, and is addressed to a device token
+// rather than a portfolio.
+package alerts
+
+import "time"
+
+// Op is a threshold comparison a Rule evaluates its observed value against.
+type Op string
+
+// Supported comparison operators.
+const (
+	OpGreaterThan    Op = ">"
+	OpGreaterOrEqual Op = ">="
+	OpLessThan       Op = "<"
+	OpLessOrEqual    Op = "<="
+	OpEqual          Op = "=="
+)
+
+// IsValidOp reports whether op is one of the supported comparison
+// operators.
+func IsValidOp(op Op) bool {
+	switch op {
+	case OpGreaterThan, OpGreaterOrEqual, OpLessThan, OpLessOrEqual, OpEqual:
+		return true
+	default:
+		return false
+	}
+}
+
+// Rule is a single user-registered alert condition. When Metric is empty,
+// the Evaluator checks Ticker's current price (stocks.Service.GetSummary);
+// when Metric is set, it checks that ticker/metric's latest value in
+// pkg/dax instead (e.g. Ticker "TST", Metric "Revenue").
+type Rule struct {
+	ID        string    `json:"id"`
+	UserToken string    `json:"user_token"`
+	Ticker    string    `json:"ticker"`
+	Metric    string    `json:"metric,omitempty"`
+	Op        Op        `json:"op"`
+	Threshold float64   `json:"threshold"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Validate checks that r is well-formed enough to evaluate.
+func (r *Rule) Validate() error {
+	if r.UserToken == "" {
+		return errUserTokenRequired
+	}
+	if r.Ticker == "" {
+		return errTickerRequired
+	}
+	if !IsValidOp(r.Op) {
+		return errInvalidOp
+	}
+	return nil
+}
+
+// Firing is a single instance of a Rule's condition having been met,
+// delivered to the rule's UserToken via a Notifier.
+type Firing struct {
+	RuleID      string    `json:"rule_id"`
+	UserToken   string    `json:"user_token"`
+	Ticker      string    `json:"ticker"`
+	Metric      string    `json:"metric,omitempty"`
+	Op          Op        `json:"op"`
+	Threshold   float64   `json:"threshold"`
+	Value       float64   `json:"value"`
+	TriggeredAt time.Time `json:"triggered_at"`
+}
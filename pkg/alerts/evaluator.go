@@ -0,0 +1,135 @@
+package alerts
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/dax"
+	"github.com/f00b455/blank-go/pkg/stocks"
+	"github.com/google/uuid"
+)
+
+// StocksService is the subset of stocks.Service the Evaluator depends on.
+type StocksService interface {
+	GetSummary(ctx context.Context, ticker string) (*stocks.StockSummary, error)
+}
+
+// MetricsRepository is the subset of dax.Repository the Evaluator depends
+// on to resolve a Rule's Metric to its latest known value.
+type MetricsRepository interface {
+	FindByFilters(ctx context.Context, filters *dax.Filters, page, limit int) ([]dax.DAXRecord, int, error)
+}
+
+// Evaluator checks every registered Rule against its current observed
+// value - a live quote from StocksService, or the latest DAX value from
+// MetricsRepository - and notifies via Notifier whenever one fires. It is
+// the polling counterpart to Subscribe: a caller that already has a
+// pkg/stocks/stream.Hub can instead feed Evaluate's per-rule check directly
+// off that stream (see Service.Watch/SetStreamHub), but most rules here
+// reference DAX metrics that have no streaming source at all, so Evaluator
+// defaults to polling both sources on a fixed interval via Run.
+type Evaluator struct {
+	repo     RuleRepository
+	quotes   StocksService
+	metrics  MetricsRepository
+	notifier Notifier
+}
+
+// NewEvaluator creates an Evaluator backed by repo, quotes, metrics and
+// notifier.
+func NewEvaluator(repo RuleRepository, quotes StocksService, metrics MetricsRepository, notifier Notifier) *Evaluator {
+	return &Evaluator{repo: repo, quotes: quotes, metrics: metrics, notifier: notifier}
+}
+
+// Evaluate checks every registered rule once, notifying and logging (but
+// not stopping on) any single rule's failure to evaluate.
+func (e *Evaluator) Evaluate(ctx context.Context) error {
+	rules, err := e.repo.GetAll()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, rule := range rules {
+		e.evaluateRule(ctx, rule, now)
+	}
+	return nil
+}
+
+func (e *Evaluator) evaluateRule(ctx context.Context, rule *Rule, now time.Time) {
+	value, err := e.currentValue(ctx, rule)
+	if err != nil {
+		log.Printf("alerts: failed to resolve value for rule %s (%s/%s): %v", rule.ID, rule.Ticker, rule.Metric, err)
+		return
+	}
+
+	if !compare(rule.Op, value, rule.Threshold) {
+		return
+	}
+
+	firing := Firing{
+		RuleID:      rule.ID,
+		UserToken:   rule.UserToken,
+		Ticker:      rule.Ticker,
+		Metric:      rule.Metric,
+		Op:          rule.Op,
+		Threshold:   rule.Threshold,
+		Value:       value,
+		TriggeredAt: now,
+	}
+
+	if err := e.notifier.Send(ctx, firing); err != nil {
+		log.Printf("alerts: failed to deliver firing for rule %s: %v", rule.ID, err)
+	}
+}
+
+// currentValue resolves rule's observed value: its ticker's current price
+// if Metric is empty, or ticker/metric's most recent DAX value otherwise.
+func (e *Evaluator) currentValue(ctx context.Context, rule *Rule) (float64, error) {
+	if rule.Metric == "" {
+		summary, err := e.quotes.GetSummary(ctx, rule.Ticker)
+		if err != nil {
+			return 0, err
+		}
+		return summary.CurrentPrice, nil
+	}
+
+	records, _, err := e.metrics.FindByFilters(ctx, &dax.Filters{
+		Tickers: []string{rule.Ticker},
+		Metrics: []string{rule.Metric},
+		SortBy:  "year",
+		SortDir: dax.SortDirDesc,
+	}, 1, 1)
+	if err != nil {
+		return 0, err
+	}
+	if len(records) == 0 || records[0].Value == nil {
+		return 0, errNoMetricValue
+	}
+	return *records[0].Value, nil
+}
+
+// compare applies op against value/threshold.
+func compare(op Op, value, threshold float64) bool {
+	switch op {
+	case OpGreaterThan:
+		return value > threshold
+	case OpGreaterOrEqual:
+		return value >= threshold
+	case OpLessThan:
+		return value < threshold
+	case OpLessOrEqual:
+		return value <= threshold
+	case OpEqual:
+		return value == threshold
+	default:
+		return false
+	}
+}
+
+// NewRuleID generates a new unique rule ID, for CRUD handlers creating a
+// Rule without a caller-supplied ID.
+func NewRuleID() string {
+	return uuid.New().String()
+}
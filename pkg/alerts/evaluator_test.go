@@ -0,0 +1,87 @@
+package alerts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/f00b455/blank-go/pkg/dax"
+	"github.com/f00b455/blank-go/pkg/stocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubStocksService struct {
+	summaries map[string]*stocks.StockSummary
+}
+
+func (s *stubStocksService) GetSummary(_ context.Context, ticker string) (*stocks.StockSummary, error) {
+	summary, ok := s.summaries[ticker]
+	if !ok {
+		return nil, assert.AnError
+	}
+	return summary, nil
+}
+
+type stubMetricsRepository struct {
+	value *float64
+}
+
+func (s *stubMetricsRepository) FindByFilters(_ context.Context, _ *dax.Filters, _, _ int) ([]dax.DAXRecord, int, error) {
+	if s.value == nil {
+		return nil, 0, nil
+	}
+	return []dax.DAXRecord{{Value: s.value}}, 1, nil
+}
+
+type stubNotifier struct {
+	firings []Firing
+}
+
+func (s *stubNotifier) Send(_ context.Context, firing Firing) error {
+	s.firings = append(s.firings, firing)
+	return nil
+}
+
+func TestEvaluator_Evaluate_PriceRule(t *testing.T) {
+	repo := NewInMemoryRepository()
+	rule := &Rule{ID: NewRuleID(), UserToken: "user-1", Ticker: "AAPL", Op: OpGreaterThan, Threshold: 100}
+	require.NoError(t, repo.Create(rule))
+
+	quotes := &stubStocksService{summaries: map[string]*stocks.StockSummary{
+		"AAPL": {Ticker: "AAPL", CurrentPrice: 150},
+	}}
+	notifier := &stubNotifier{}
+	evaluator := NewEvaluator(repo, quotes, &stubMetricsRepository{}, notifier)
+
+	err := evaluator.Evaluate(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, notifier.firings, 1)
+	assert.Equal(t, rule.ID, notifier.firings[0].RuleID)
+	assert.Equal(t, 150.0, notifier.firings[0].Value)
+}
+
+func TestEvaluator_Evaluate_MetricRule_NoFireBelowThreshold(t *testing.T) {
+	repo := NewInMemoryRepository()
+	require.NoError(t, repo.Create(&Rule{ID: NewRuleID(), UserToken: "user-1", Ticker: "AAPL", Metric: "revenue", Op: OpGreaterThan, Threshold: 1000}))
+
+	value := 500.0
+	notifier := &stubNotifier{}
+	evaluator := NewEvaluator(repo, &stubStocksService{}, &stubMetricsRepository{value: &value}, notifier)
+
+	err := evaluator.Evaluate(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, notifier.firings)
+}
+
+func TestEvaluator_Evaluate_SkipsRuleOnResolveError(t *testing.T) {
+	repo := NewInMemoryRepository()
+	require.NoError(t, repo.Create(&Rule{ID: NewRuleID(), UserToken: "user-1", Ticker: "MISSING", Op: OpGreaterThan, Threshold: 1}))
+
+	notifier := &stubNotifier{}
+	evaluator := NewEvaluator(repo, &stubStocksService{summaries: map[string]*stocks.StockSummary{}}, &stubMetricsRepository{}, notifier)
+
+	err := evaluator.Evaluate(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, notifier.firings)
+}
@@ -0,0 +1,116 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// fcmSendURLFormat is FCM's HTTP v1 send endpoint. The legacy server-key API
+// this used to be reached through is retired; v1 requires an OAuth2 access
+// token scoped to the Firebase project instead (see NewFCMNotifier).
+const fcmSendURLFormat = "https://fcm.googleapis.com/v1/projects/%s/messages:send"
+
+// FCMNotifier delivers a Firing as a combined data+notification push via
+// Firebase Cloud Messaging's HTTP v1 API. It talks to FCM directly over
+// net/http - the same plain-HTTP-POST approach portfolio.WebhookSink uses -
+// rather than through firebase.google.com/go/v4/messaging: that SDK (and
+// its transitive google-cloud dependencies) isn't vendored into this
+// module, and the v1 REST API it wraps is a thin enough JSON contract that
+// reproducing the one call this package needs doesn't need the SDK. Callers
+// supply their own oauth2.TokenSource (e.g. from
+// golang.org/x/oauth2/google.JWTConfigFromJSON against a service account
+// key), matching the existing convention of injecting dependencies rather
+// than having this package read credentials off disk itself.
+type FCMNotifier struct {
+	projectID   string
+	tokenSource oauth2.TokenSource
+	httpClient  *http.Client
+}
+
+// NewFCMNotifier creates an FCMNotifier that sends pushes for the Firebase
+// project projectID, authenticating each request with a token from source.
+func NewFCMNotifier(projectID string, source oauth2.TokenSource) *FCMNotifier {
+	return &FCMNotifier{
+		projectID:   projectID,
+		tokenSource: source,
+		httpClient:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// fcmMessage is the body of an FCM v1 messages:send request, carrying both
+// a visible notification and a data payload a receiving app can act on
+// without the user having tapped it.
+type fcmMessage struct {
+	Message fcmMessageBody `json:"message"`
+}
+
+type fcmMessageBody struct {
+	Token        string            `json:"token"`
+	Notification fcmNotification   `json:"notification"`
+	Data         map[string]string `json:"data"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// Send implements Notifier, POSTing firing to FCM as a push to
+// firing.UserToken.
+func (n *FCMNotifier) Send(ctx context.Context, firing Firing) error {
+	token, err := n.tokenSource.Token()
+	if err != nil {
+		return fmt.Errorf("fetching FCM access token: %w", err)
+	}
+
+	body, err := json.Marshal(fcmMessage{Message: fcmMessageBody{
+		Token: firing.UserToken,
+		Notification: fcmNotification{
+			Title: fmt.Sprintf("%s alert", firing.Ticker),
+			Body:  firingMessage(firing),
+		},
+		Data: map[string]string{
+			"rule_id": firing.RuleID,
+			"ticker":  firing.Ticker,
+			"metric":  firing.Metric,
+			"op":      string(firing.Op),
+			"value":   fmt.Sprintf("%g", firing.Value),
+		},
+	}})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf(fcmSendURLFormat, n.projectID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("FCM returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// firingMessage renders firing as a human-readable push body.
+func firingMessage(firing Firing) string {
+	if firing.Metric != "" {
+		return fmt.Sprintf("%s %s is %s %g (currently %g)", firing.Ticker, firing.Metric, firing.Op, firing.Threshold, firing.Value)
+	}
+	return fmt.Sprintf("%s price is %s %g (currently %g)", firing.Ticker, firing.Op, firing.Threshold, firing.Value)
+}
@@ -0,0 +1,19 @@
+package alerts
+
+import "context"
+
+// Notifier delivers a fired alert to the device registered under firing's
+// UserToken. Mirrors portfolio.AlertSink's role, but addressed to a device
+// token rather than a webhook URL.
+type Notifier interface {
+	Send(ctx context.Context, firing Firing) error
+}
+
+// NoopNotifier discards firings. Useful when no push credentials are
+// configured but firings should still be recorded by the Evaluator.
+type NoopNotifier struct{}
+
+// Send does nothing and always succeeds.
+func (NoopNotifier) Send(ctx context.Context, firing Firing) error {
+	return nil
+}
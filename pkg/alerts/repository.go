@@ -0,0 +1,84 @@
+package alerts
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrRuleNotFound is returned when a rule is not found.
+	ErrRuleNotFound = errors.New("alert rule not found")
+
+	errUserTokenRequired = errors.New("user_token is required")
+	errTickerRequired    = errors.New("ticker is required")
+	errInvalidOp         = errors.New("invalid op")
+	errNoMetricValue     = errors.New("no value recorded for this ticker/metric")
+)
+
+// RuleRepository defines the interface for alert rule storage, the same
+// shape as dax.Repository's twin InMemoryRepository/PostgresRepository
+// split: callers depend on this interface, not a concrete store.
+type RuleRepository interface {
+	Create(rule *Rule) error
+	GetByID(id string) (*Rule, error)
+	GetAll() ([]*Rule, error)
+	Delete(id string) error
+}
+
+// InMemoryRepository implements RuleRepository using in-memory storage.
+type InMemoryRepository struct {
+	mu    sync.RWMutex
+	rules map[string]*Rule
+}
+
+// NewInMemoryRepository creates a new in-memory rule repository.
+func NewInMemoryRepository() *InMemoryRepository {
+	return &InMemoryRepository{
+		rules: make(map[string]*Rule),
+	}
+}
+
+// Create adds a new rule to the repository.
+func (r *InMemoryRepository) Create(rule *Rule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rules[rule.ID] = rule
+	return nil
+}
+
+// GetByID retrieves a rule by its ID.
+func (r *InMemoryRepository) GetByID(id string) (*Rule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rule, exists := r.rules[id]
+	if !exists {
+		return nil, ErrRuleNotFound
+	}
+	return rule, nil
+}
+
+// GetAll retrieves every rule in the repository.
+func (r *InMemoryRepository) GetAll() ([]*Rule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*Rule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		result = append(result, rule)
+	}
+	return result, nil
+}
+
+// Delete removes a rule from the repository.
+func (r *InMemoryRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.rules[id]; !exists {
+		return ErrRuleNotFound
+	}
+	delete(r.rules, id)
+	return nil
+}
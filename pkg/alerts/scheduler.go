@@ -0,0 +1,39 @@
+package alerts
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Scheduler ticks an Evaluator on Interval until its context is canceled.
+// Unlike portfolio.Scheduler, it doesn't skip outside regular trading
+// hours: a rule here can reference a DAX metric that has nothing to do
+// with the market session, so there's no window during which every rule is
+// guaranteed to be stale.
+type Scheduler struct {
+	evaluator *Evaluator
+	interval  time.Duration
+}
+
+// NewScheduler creates a Scheduler that runs evaluator every interval.
+func NewScheduler(evaluator *Evaluator, interval time.Duration) *Scheduler {
+	return &Scheduler{evaluator: evaluator, interval: interval}
+}
+
+// Run blocks, ticking the evaluator every s.interval until ctx is canceled.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.evaluator.Evaluate(ctx); err != nil {
+				log.Printf("alerts: evaluation tick failed: %v", err)
+			}
+		}
+	}
+}
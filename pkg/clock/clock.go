@@ -0,0 +1,31 @@
+// Package clock lets callers inject how "now" is observed, so time-based
+// logic (cache TTLs, timestamps) can be tested deterministically instead of
+// through time.Sleep and wall-clock races.
+package clock
+
+import "time"
+
+// Clock is the subset of package time's wall-clock operations this repo's
+// time-sensitive code depends on.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// Since returns the time elapsed since t.
+	Since(t time.Time) time.Duration
+	// After returns a channel that receives the current time once d has
+	// elapsed, the same contract as time.After.
+	After(d time.Duration) <-chan time.Time
+}
+
+// RealClock is a Clock backed by package time, the default for every
+// production caller.
+type RealClock struct{}
+
+// Now implements Clock.
+func (RealClock) Now() time.Time { return time.Now() }
+
+// Since implements Clock.
+func (RealClock) Since(t time.Time) time.Duration { return time.Since(t) }
+
+// After implements Clock.
+func (RealClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
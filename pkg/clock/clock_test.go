@@ -0,0 +1,29 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClock_Advance(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := clock.NewFakeClock(start)
+
+	assert.Equal(t, start, c.Now())
+
+	c.Advance(time.Hour)
+	assert.Equal(t, start.Add(time.Hour), c.Now())
+	assert.Equal(t, time.Hour, c.Since(start))
+}
+
+func TestRealClock(t *testing.T) {
+	c := clock.RealClock{}
+
+	before := time.Now()
+	now := c.Now()
+	assert.False(t, now.Before(before))
+	assert.GreaterOrEqual(t, c.Since(before), time.Duration(0))
+}
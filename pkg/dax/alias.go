@@ -0,0 +1,198 @@
+package dax
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AliasKindTicker identifies an Alias row that canonicalizes ticker symbols
+// (e.g. "SIEGY" -> "SIE"). It is the only alias kind today, but the field is
+// typed uint8 rather than hardcoded to ticker so other dimensions (company
+// name, currency code, ...) can reuse the same table later.
+const AliasKindTicker uint8 = 0
+
+// Alias maps an alternate key to its canonical value for a given kind.
+type Alias struct {
+	ID    uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	Kind  uint8     `json:"kind" gorm:"not null;index:idx_alias_kind_key"`
+	Key   string    `json:"key" gorm:"type:varchar(50);not null;index:idx_alias_kind_key"`
+	Value string    `json:"value" gorm:"type:varchar(50);not null"`
+}
+
+// TableName sets the table name for GORM
+func (Alias) TableName() string {
+	return "dax_aliases"
+}
+
+// AliasResolver canonicalizes alias keys and expands a canonical (or
+// aliased) key back into every known variant.
+type AliasResolver interface {
+	// Resolve returns the canonical value for key, or key itself when no
+	// alias of the given kind exists for it.
+	Resolve(kind uint8, key string) string
+	// ReverseResolve returns every known key (including key itself) that
+	// shares key's canonical value.
+	ReverseResolve(kind uint8, key string) []string
+}
+
+// NoopAliasResolver disables alias resolution, returning keys unchanged.
+// Callers that want raw, unresolved values (e.g. a config flag opting out
+// of alias resolution) should use this instead of a RepositoryAliasResolver.
+type NoopAliasResolver struct{}
+
+// Resolve implements AliasResolver.
+func (NoopAliasResolver) Resolve(_ uint8, key string) string { return key }
+
+// ReverseResolve implements AliasResolver.
+func (NoopAliasResolver) ReverseResolve(_ uint8, key string) []string { return []string{key} }
+
+// RepositoryAliasResolver resolves aliases from an AliasRepository. It is
+// not cached: alias tables are small and change rarely, so a fresh lookup
+// per call keeps admin-managed edits immediately visible.
+type RepositoryAliasResolver struct {
+	repo AliasRepository
+}
+
+// NewRepositoryAliasResolver creates a new repository-backed alias resolver.
+func NewRepositoryAliasResolver(repo AliasRepository) *RepositoryAliasResolver {
+	return &RepositoryAliasResolver{repo: repo}
+}
+
+// Resolve implements AliasResolver.
+func (r *RepositoryAliasResolver) Resolve(kind uint8, key string) string {
+	aliases, err := r.repo.FindAll()
+	if err != nil {
+		return key
+	}
+
+	for _, a := range aliases {
+		if a.Kind == kind && a.Key == key {
+			return a.Value
+		}
+	}
+
+	return key
+}
+
+// ReverseResolve implements AliasResolver.
+func (r *RepositoryAliasResolver) ReverseResolve(kind uint8, key string) []string {
+	aliases, err := r.repo.FindAll()
+	if err != nil {
+		return []string{key}
+	}
+
+	canonical := key
+	for _, a := range aliases {
+		if a.Kind == kind && a.Key == key {
+			canonical = a.Value
+			break
+		}
+	}
+
+	variants := map[string]bool{canonical: true}
+	for _, a := range aliases {
+		if a.Kind == kind && a.Value == canonical {
+			variants[a.Key] = true
+		}
+	}
+
+	result := make([]string, 0, len(variants))
+	for v := range variants {
+		result = append(result, v)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// AliasRepository defines the interface for managing persisted aliases
+type AliasRepository interface {
+	Create(alias *Alias) error
+	FindAll() ([]Alias, error)
+	Delete(id uuid.UUID) error
+}
+
+// PostgresAliasRepository implements AliasRepository using PostgreSQL
+type PostgresAliasRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresAliasRepository creates a new PostgreSQL alias repository
+func NewPostgresAliasRepository(db *gorm.DB) *PostgresAliasRepository {
+	return &PostgresAliasRepository{db: db}
+}
+
+// Create inserts a single alias
+func (r *PostgresAliasRepository) Create(alias *Alias) error {
+	if alias.ID == uuid.Nil {
+		alias.ID = uuid.New()
+	}
+	return r.db.Create(alias).Error
+}
+
+// FindAll retrieves every alias
+func (r *PostgresAliasRepository) FindAll() ([]Alias, error) {
+	var aliases []Alias
+	if err := r.db.Find(&aliases).Error; err != nil {
+		return nil, err
+	}
+	return aliases, nil
+}
+
+// Delete removes an alias by ID
+func (r *PostgresAliasRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&Alias{}, "id = ?", id).Error
+}
+
+// InMemoryAliasRepository implements AliasRepository using in-memory storage
+type InMemoryAliasRepository struct {
+	mu      sync.RWMutex
+	aliases map[string]*Alias
+}
+
+// NewInMemoryAliasRepository creates a new in-memory alias repository
+func NewInMemoryAliasRepository() *InMemoryAliasRepository {
+	return &InMemoryAliasRepository{
+		aliases: make(map[string]*Alias),
+	}
+}
+
+// Create inserts a single alias
+func (r *InMemoryAliasRepository) Create(alias *Alias) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if alias.ID == uuid.Nil {
+		alias.ID = uuid.New()
+	}
+
+	r.aliases[alias.ID.String()] = alias
+	return nil
+}
+
+// FindAll retrieves every alias
+func (r *InMemoryAliasRepository) FindAll() ([]Alias, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	aliases := make([]Alias, 0, len(r.aliases))
+	for _, a := range r.aliases {
+		aliases = append(aliases, *a)
+	}
+
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].Key < aliases[j].Key })
+
+	return aliases, nil
+}
+
+// Delete removes an alias by ID
+func (r *InMemoryAliasRepository) Delete(id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.aliases, id.String())
+	return nil
+}
@@ -0,0 +1,85 @@
+package dax
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryAliasRepository_Create(t *testing.T) {
+	repo := NewInMemoryAliasRepository()
+
+	alias := &Alias{Kind: AliasKindTicker, Key: "SIEGY", Value: "SIE"}
+	err := repo.Create(alias)
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, alias.ID)
+
+	aliases, err := repo.FindAll()
+	require.NoError(t, err)
+	assert.Len(t, aliases, 1)
+	assert.Equal(t, "SIEGY", aliases[0].Key)
+}
+
+func TestInMemoryAliasRepository_FindAll_SortedByKey(t *testing.T) {
+	repo := NewInMemoryAliasRepository()
+
+	require.NoError(t, repo.Create(&Alias{Kind: AliasKindTicker, Key: "SIEGY", Value: "SIE"}))
+	require.NoError(t, repo.Create(&Alias{Kind: AliasKindTicker, Key: "SIE.DE", Value: "SIE"}))
+
+	aliases, err := repo.FindAll()
+	require.NoError(t, err)
+	require.Len(t, aliases, 2)
+	assert.Equal(t, "SIE.DE", aliases[0].Key)
+	assert.Equal(t, "SIEGY", aliases[1].Key)
+}
+
+func TestInMemoryAliasRepository_Delete(t *testing.T) {
+	repo := NewInMemoryAliasRepository()
+
+	alias := &Alias{Kind: AliasKindTicker, Key: "SIEGY", Value: "SIE"}
+	require.NoError(t, repo.Create(alias))
+
+	err := repo.Delete(alias.ID)
+	assert.NoError(t, err)
+
+	aliases, err := repo.FindAll()
+	require.NoError(t, err)
+	assert.Empty(t, aliases)
+}
+
+func TestNoopAliasResolver(t *testing.T) {
+	r := NoopAliasResolver{}
+
+	assert.Equal(t, "SIEGY", r.Resolve(AliasKindTicker, "SIEGY"))
+	assert.Equal(t, []string{"SIEGY"}, r.ReverseResolve(AliasKindTicker, "SIEGY"))
+}
+
+func TestRepositoryAliasResolver_Resolve(t *testing.T) {
+	repo := NewInMemoryAliasRepository()
+	require.NoError(t, repo.Create(&Alias{Kind: AliasKindTicker, Key: "SIEGY", Value: "SIE"}))
+
+	resolver := NewRepositoryAliasResolver(repo)
+
+	assert.Equal(t, "SIE", resolver.Resolve(AliasKindTicker, "SIEGY"))
+	assert.Equal(t, "SIE", resolver.Resolve(AliasKindTicker, "SIE"), "canonical key resolves to itself")
+	assert.Equal(t, "TST", resolver.Resolve(AliasKindTicker, "TST"), "unknown key passes through unchanged")
+}
+
+func TestRepositoryAliasResolver_ReverseResolve(t *testing.T) {
+	repo := NewInMemoryAliasRepository()
+	require.NoError(t, repo.Create(&Alias{Kind: AliasKindTicker, Key: "SIEGY", Value: "SIE"}))
+	require.NoError(t, repo.Create(&Alias{Kind: AliasKindTicker, Key: "SIE.DE", Value: "SIE"}))
+
+	resolver := NewRepositoryAliasResolver(repo)
+
+	variants := resolver.ReverseResolve(AliasKindTicker, "SIEGY")
+	assert.Equal(t, []string{"SIE", "SIE.DE", "SIEGY"}, variants)
+
+	variants = resolver.ReverseResolve(AliasKindTicker, "SIE")
+	assert.Equal(t, []string{"SIE", "SIE.DE", "SIEGY"}, variants, "canonical key expands to every alias")
+
+	variants = resolver.ReverseResolve(AliasKindTicker, "TST")
+	assert.Equal(t, []string{"TST"}, variants, "unknown key has no other variants")
+}
@@ -0,0 +1,146 @@
+package dax
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// DAXPoint is a single (year, value) sample of a ticker/metric time series,
+// as returned by Repository.GetMetricHistory.
+type DAXPoint struct {
+	Year  int     `json:"year"`
+	Value float64 `json:"value"`
+}
+
+// AggOp selects the aggregate Repository.Aggregate computes over a year
+// range.
+type AggOp string
+
+// Aggregate operations accepted by Repository.Aggregate.
+const (
+	AggSum AggOp = "sum"
+	AggAvg AggOp = "avg"
+	AggMin AggOp = "min"
+	AggMax AggOp = "max"
+)
+
+var (
+	// ErrNoData is returned by Aggregate when no record in [fromYear,
+	// toYear] has a non-nil value for ticker/metric.
+	ErrNoData = errors.New("no data for ticker/metric in range")
+	// ErrUnknownAggOp is returned by Aggregate for an AggOp other than the
+	// AggSum/AggAvg/AggMin/AggMax constants.
+	ErrUnknownAggOp = errors.New("unknown aggregate operation")
+	// ErrYoYPriorNonPositive is returned by ComputeYoY when the prior
+	// year's value is zero or negative, since percentage growth off a
+	// non-positive base isn't a meaningful figure.
+	ErrYoYPriorNonPositive = errors.New("year-over-year growth is undefined when the prior year's value is zero or negative")
+	// ErrCAGRStartNonPositive is returned by ComputeCAGR when the start
+	// year's value is zero or negative, for the same reason.
+	ErrCAGRStartNonPositive = errors.New("CAGR is undefined when the start year's value is zero or negative")
+	// ErrCAGRUndefined is returned by ComputeCAGR when the start/end values
+	// otherwise produce no real-valued growth rate (e.g. a negative end
+	// value raised to a fractional power).
+	ErrCAGRUndefined = errors.New("CAGR is undefined for the given start/end values")
+)
+
+// MissingYearsError reports that GetMetricHistory, ComputeYoY or ComputeCAGR
+// found gaps in ticker/metric's data within the requested range, rather than
+// silently interpolating across them.
+type MissingYearsError struct {
+	Ticker string
+	Metric string
+	Years  []int
+}
+
+func (e *MissingYearsError) Error() string {
+	return fmt.Sprintf("%s/%s has no data for year(s) %v", e.Ticker, e.Metric, e.Years)
+}
+
+// MissingYearsInRange returns, in ascending order, every year in
+// [fromYear, toYear] absent from values. A nil result means the range is
+// fully covered.
+func MissingYearsInRange(fromYear, toYear int, values map[int]float64) []int {
+	var missing []int
+	for year := fromYear; year <= toYear; year++ {
+		if _, ok := values[year]; !ok {
+			missing = append(missing, year)
+		}
+	}
+	return missing
+}
+
+// PointsFromValues converts values into DAXPoints ordered by year ascending.
+func PointsFromValues(values map[int]float64) []DAXPoint {
+	points := make([]DAXPoint, 0, len(values))
+	for year, value := range values {
+		points = append(points, DAXPoint{Year: year, Value: value})
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Year < points[j].Year })
+	return points
+}
+
+// ComputeYoY computes year-over-year growth of current relative to prior.
+func ComputeYoY(current, prior float64) (float64, error) {
+	if prior <= 0 {
+		return 0, ErrYoYPriorNonPositive
+	}
+	return (current - prior) / prior, nil
+}
+
+// ComputeCAGR computes the compound annual growth rate of a value that went
+// from start to end over years years (endYear - startYear).
+func ComputeCAGR(start, end float64, years int) (float64, error) {
+	if start <= 0 {
+		return 0, ErrCAGRStartNonPositive
+	}
+
+	cagr := math.Pow(end/start, 1/float64(years)) - 1
+	if math.IsNaN(cagr) || math.IsInf(cagr, 0) {
+		return 0, ErrCAGRUndefined
+	}
+	return cagr, nil
+}
+
+// ComputeAggregate reduces values per op. Returns ErrNoData when values is
+// empty and ErrUnknownAggOp for an unrecognized op.
+func ComputeAggregate(values map[int]float64, op AggOp) (float64, error) {
+	if len(values) == 0 {
+		return 0, ErrNoData
+	}
+
+	switch op {
+	case AggSum:
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		return sum, nil
+	case AggAvg:
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		return sum / float64(len(values)), nil
+	case AggMin:
+		min := math.Inf(1)
+		for _, v := range values {
+			if v < min {
+				min = v
+			}
+		}
+		return min, nil
+	case AggMax:
+		max := math.Inf(-1)
+		for _, v := range values {
+			if v > max {
+				max = v
+			}
+		}
+		return max, nil
+	default:
+		return 0, ErrUnknownAggOp
+	}
+}
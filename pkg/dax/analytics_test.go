@@ -0,0 +1,142 @@
+package dax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seedMetricYears(t *testing.T, repo *InMemoryRepository, ticker, metric string, values map[int]float64) {
+	t.Helper()
+	for year, value := range values {
+		v := value
+		require.NoError(t, repo.Create(context.Background(), &DAXRecord{
+			Company: "Test Company",
+			Ticker:  ticker,
+			Metric:  metric,
+			Year:    year,
+			Value:   &v,
+		}))
+	}
+}
+
+func TestInMemoryRepository_GetMetricHistory(t *testing.T) {
+	repo := NewInMemoryRepository()
+	seedMetricYears(t, repo, "TST", "Revenue", map[int]float64{2021: 100, 2022: 110, 2023: 121})
+
+	points, err := repo.GetMetricHistory(context.Background(), "TST", "Revenue", 2021, 2023)
+	require.NoError(t, err)
+	assert.Equal(t, []DAXPoint{
+		{Year: 2021, Value: 100},
+		{Year: 2022, Value: 110},
+		{Year: 2023, Value: 121},
+	}, points)
+}
+
+func TestInMemoryRepository_GetMetricHistory_MissingYears(t *testing.T) {
+	repo := NewInMemoryRepository()
+	seedMetricYears(t, repo, "TST", "Revenue", map[int]float64{2021: 100, 2023: 121})
+
+	_, err := repo.GetMetricHistory(context.Background(), "TST", "Revenue", 2021, 2023)
+	require.Error(t, err)
+
+	var missingErr *MissingYearsError
+	require.ErrorAs(t, err, &missingErr)
+	assert.Equal(t, []int{2022}, missingErr.Years)
+}
+
+func TestInMemoryRepository_ComputeYoY(t *testing.T) {
+	repo := NewInMemoryRepository()
+	seedMetricYears(t, repo, "TST", "Revenue", map[int]float64{2021: 100, 2022: 110})
+
+	yoy, err := repo.ComputeYoY(context.Background(), "TST", "Revenue", 2022)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.1, yoy, 1e-9)
+}
+
+func TestInMemoryRepository_ComputeYoY_MissingPriorYear(t *testing.T) {
+	repo := NewInMemoryRepository()
+	seedMetricYears(t, repo, "TST", "Revenue", map[int]float64{2022: 110})
+
+	_, err := repo.ComputeYoY(context.Background(), "TST", "Revenue", 2022)
+	require.Error(t, err)
+
+	var missingErr *MissingYearsError
+	require.ErrorAs(t, err, &missingErr)
+	assert.Equal(t, []int{2021}, missingErr.Years)
+}
+
+func TestInMemoryRepository_ComputeYoY_PriorNonPositive(t *testing.T) {
+	repo := NewInMemoryRepository()
+	seedMetricYears(t, repo, "TST", "Revenue", map[int]float64{2021: -50, 2022: 110})
+
+	_, err := repo.ComputeYoY(context.Background(), "TST", "Revenue", 2022)
+	assert.ErrorIs(t, err, ErrYoYPriorNonPositive)
+}
+
+func TestInMemoryRepository_ComputeCAGR(t *testing.T) {
+	repo := NewInMemoryRepository()
+	seedMetricYears(t, repo, "TST", "Revenue", map[int]float64{2020: 100, 2023: 133.1})
+
+	cagr, err := repo.ComputeCAGR(context.Background(), "TST", "Revenue", 2020, 2023)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.1, cagr, 1e-6)
+}
+
+func TestInMemoryRepository_ComputeCAGR_StartNonPositive(t *testing.T) {
+	repo := NewInMemoryRepository()
+	seedMetricYears(t, repo, "TST", "Revenue", map[int]float64{2020: 0, 2023: 133.1})
+
+	_, err := repo.ComputeCAGR(context.Background(), "TST", "Revenue", 2020, 2023)
+	assert.ErrorIs(t, err, ErrCAGRStartNonPositive)
+}
+
+func TestInMemoryRepository_ComputeCAGR_MissingEndpoint(t *testing.T) {
+	repo := NewInMemoryRepository()
+	seedMetricYears(t, repo, "TST", "Revenue", map[int]float64{2020: 100})
+
+	_, err := repo.ComputeCAGR(context.Background(), "TST", "Revenue", 2020, 2023)
+	require.Error(t, err)
+
+	var missingErr *MissingYearsError
+	require.ErrorAs(t, err, &missingErr)
+	assert.Equal(t, []int{2023}, missingErr.Years)
+}
+
+func TestInMemoryRepository_Aggregate(t *testing.T) {
+	repo := NewInMemoryRepository()
+	seedMetricYears(t, repo, "TST", "Revenue", map[int]float64{2021: 100, 2022: 110, 2023: 120})
+
+	sum, err := repo.Aggregate(context.Background(), "TST", "Revenue", 2021, 2023, AggSum)
+	require.NoError(t, err)
+	assert.InDelta(t, 330, sum, 1e-9)
+
+	avg, err := repo.Aggregate(context.Background(), "TST", "Revenue", 2021, 2023, AggAvg)
+	require.NoError(t, err)
+	assert.InDelta(t, 110, avg, 1e-9)
+
+	min, err := repo.Aggregate(context.Background(), "TST", "Revenue", 2021, 2023, AggMin)
+	require.NoError(t, err)
+	assert.InDelta(t, 100, min, 1e-9)
+
+	max, err := repo.Aggregate(context.Background(), "TST", "Revenue", 2021, 2023, AggMax)
+	require.NoError(t, err)
+	assert.InDelta(t, 120, max, 1e-9)
+}
+
+func TestInMemoryRepository_Aggregate_NoData(t *testing.T) {
+	repo := NewInMemoryRepository()
+
+	_, err := repo.Aggregate(context.Background(), "TST", "Revenue", 2021, 2023, AggSum)
+	assert.ErrorIs(t, err, ErrNoData)
+}
+
+func TestInMemoryRepository_Aggregate_UnknownOp(t *testing.T) {
+	repo := NewInMemoryRepository()
+	seedMetricYears(t, repo, "TST", "Revenue", map[int]float64{2021: 100})
+
+	_, err := repo.Aggregate(context.Background(), "TST", "Revenue", 2021, 2023, AggOp("median"))
+	assert.ErrorIs(t, err, ErrUnknownAggOp)
+}
@@ -0,0 +1,113 @@
+package dax
+
+import (
+	"context"
+	"errors"
+	"math"
+)
+
+// ErrInsufficientOverlap is returned by CorrelationMatrix when the
+// requested tickers don't share at least two years of metric data in
+// common - Pearson correlation is undefined below that.
+var ErrInsufficientOverlap = errors.New("tickers have fewer than two overlapping years of data")
+
+// CorrelationMatrixResponse is GetCorrelation's response body.
+type CorrelationMatrixResponse struct {
+	Tickers []string    `json:"tickers"`
+	Metric  string      `json:"metric"`
+	Years   int         `json:"years"`
+	Matrix  [][]float64 `json:"matrix"`
+}
+
+// CorrelationMatrix streams every record matching tickers/metric through
+// repo.Stream, keyed by year so each ticker's value can be aligned with
+// every other's, then computes the Pearson correlation coefficient for
+// every ticker pair over the years all of them have data for. Streaming
+// this way still has to buffer one value per (ticker, year) pair - unlike
+// ComputeStat's single running accumulator, a correlation fundamentally
+// needs the two series aligned by year before it can be computed - but
+// that's bounded by the number of years in range, not the number of
+// records, so it stays cheap even over decades of daily-if-they-existed
+// data.
+func CorrelationMatrix(ctx context.Context, repo Repository, filters *Filters, tickers []string, metric string) (*CorrelationMatrixResponse, error) {
+	records, errs := repo.Stream(ctx, filters, 0)
+
+	byYear := make(map[int]map[string]float64)
+	for record := range records {
+		if record.Value == nil {
+			continue
+		}
+		if byYear[record.Year] == nil {
+			byYear[record.Year] = make(map[string]float64)
+		}
+		byYear[record.Year][record.Ticker] = *record.Value
+	}
+	if err := <-errs; err != nil {
+		return nil, err
+	}
+
+	var overlapYears []int
+	for year, byTicker := range byYear {
+		complete := true
+		for _, ticker := range tickers {
+			if _, ok := byTicker[ticker]; !ok {
+				complete = false
+				break
+			}
+		}
+		if complete {
+			overlapYears = append(overlapYears, year)
+		}
+	}
+	if len(overlapYears) < 2 {
+		return nil, ErrInsufficientOverlap
+	}
+
+	matrix := make([][]float64, len(tickers))
+	for i, tickerI := range tickers {
+		matrix[i] = make([]float64, len(tickers))
+		for j, tickerJ := range tickers {
+			if i == j {
+				matrix[i][j] = 1
+				continue
+			}
+			xs := make([]float64, len(overlapYears))
+			ys := make([]float64, len(overlapYears))
+			for k, year := range overlapYears {
+				xs[k] = byYear[year][tickerI]
+				ys[k] = byYear[year][tickerJ]
+			}
+			matrix[i][j] = pearsonCorrelation(xs, ys)
+		}
+	}
+
+	return &CorrelationMatrixResponse{Tickers: tickers, Metric: metric, Years: len(overlapYears), Matrix: matrix}, nil
+}
+
+// pearsonCorrelation computes the Pearson correlation coefficient between
+// two equal-length, already-aligned series in a single pass, using
+// Welford's online covariance algorithm (the two-series generalization
+// of WelfordStats) rather than the naive sum(x), sum(y), sum(xy) formula,
+// which loses precision for large-magnitude series.
+func pearsonCorrelation(xs, ys []float64) float64 {
+	var n float64
+	var meanX, meanY, c, m2X, m2Y float64
+
+	for i := range xs {
+		n++
+		dx := xs[i] - meanX
+		meanX += dx / n
+		m2X += dx * (xs[i] - meanX)
+
+		dy := ys[i] - meanY
+		meanY += dy / n
+		m2Y += dy * (ys[i] - meanY)
+
+		c += dx * (ys[i] - meanY)
+	}
+
+	if m2X == 0 || m2Y == 0 {
+		return 0
+	}
+	return c / math.Sqrt(m2X*m2Y)
+}
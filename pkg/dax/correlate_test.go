@@ -0,0 +1,44 @@
+package dax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCorrelationMatrix_PerfectlyCorrelated(t *testing.T) {
+	repo := NewInMemoryRepository()
+	require.NoError(t, repo.BulkUpsert(context.Background(), []DAXRecord{
+		{Ticker: "SIE", Metric: "Revenue", Year: 2021, Value: ptrFloat(10)},
+		{Ticker: "SIE", Metric: "Revenue", Year: 2022, Value: ptrFloat(20)},
+		{Ticker: "SIE", Metric: "Revenue", Year: 2023, Value: ptrFloat(30)},
+		{Ticker: "SAP", Metric: "Revenue", Year: 2021, Value: ptrFloat(5)},
+		{Ticker: "SAP", Metric: "Revenue", Year: 2022, Value: ptrFloat(10)},
+		{Ticker: "SAP", Metric: "Revenue", Year: 2023, Value: ptrFloat(15)},
+	}))
+
+	filters := &Filters{Tickers: []string{"SIE", "SAP"}, Metrics: []string{"Revenue"}}
+	resp, err := CorrelationMatrix(context.Background(), repo, filters, []string{"SIE", "SAP"}, "Revenue")
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, resp.Years)
+	assert.InDelta(t, 1.0, resp.Matrix[0][0], 1e-9)
+	assert.InDelta(t, 1.0, resp.Matrix[1][1], 1e-9)
+	assert.InDelta(t, 1.0, resp.Matrix[0][1], 1e-9)
+	assert.InDelta(t, 1.0, resp.Matrix[1][0], 1e-9)
+}
+
+func TestCorrelationMatrix_InsufficientOverlap(t *testing.T) {
+	repo := NewInMemoryRepository()
+	require.NoError(t, repo.BulkUpsert(context.Background(), []DAXRecord{
+		{Ticker: "SIE", Metric: "Revenue", Year: 2021, Value: ptrFloat(10)},
+		{Ticker: "SAP", Metric: "Revenue", Year: 2022, Value: ptrFloat(5)},
+	}))
+
+	filters := &Filters{Tickers: []string{"SIE", "SAP"}, Metrics: []string{"Revenue"}}
+	_, err := CorrelationMatrix(context.Background(), repo, filters, []string{"SIE", "SAP"}, "Revenue")
+
+	assert.ErrorIs(t, err, ErrInsufficientOverlap)
+}
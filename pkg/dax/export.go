@@ -0,0 +1,155 @@
+package dax
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xitongsys/parquet-go-source/writerfile"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// exportCSVHeader is the column order ExportCSV writes, matching
+// requiredFieldNames so a file exported by ExportCSV re-imports cleanly
+// through CSVImporter.
+var exportCSVHeader = []string{"company", "ticker", "report_type", "metric", "year", "value", "currency"}
+
+// ExportCSV writes every record received over ch to w as CSV. It drains ch
+// to completion even after a write error so Stream's producer goroutine
+// isn't left blocked sending to an abandoned channel.
+func ExportCSV(w io.Writer, ch <-chan DAXRecord) error {
+	cw := csv.NewWriter(w)
+
+	writeErr := cw.Write(exportCSVHeader)
+	for record := range ch {
+		if writeErr != nil {
+			continue
+		}
+		writeErr = cw.Write(csvExportRow(record))
+	}
+	if writeErr != nil {
+		return writeErr
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvExportRow renders record in exportCSVHeader's column order.
+func csvExportRow(record DAXRecord) []string {
+	value := ""
+	if record.Value != nil {
+		value = strconv.FormatFloat(*record.Value, 'f', -1, 64)
+	}
+	return []string{
+		record.Company,
+		record.Ticker,
+		record.ReportType,
+		record.Metric,
+		strconv.Itoa(record.Year),
+		value,
+		record.Currency,
+	}
+}
+
+// ExportJSONL writes every record received over ch to w as newline-delimited
+// JSON, one record object per line. It drains ch to completion even after a
+// write error so Stream's producer goroutine isn't left blocked sending to
+// an abandoned channel.
+func ExportJSONL(w io.Writer, ch <-chan DAXRecord) error {
+	enc := json.NewEncoder(w)
+
+	var writeErr error
+	for record := range ch {
+		if writeErr != nil {
+			continue
+		}
+		writeErr = enc.Encode(record)
+	}
+	return writeErr
+}
+
+// daxParquetRecord mirrors DAXRecord's columns in the layout ExportParquet
+// writes. parquet-go derives its schema from struct tags, which can't
+// express DAXRecord's uuid.UUID or time.Time fields directly, so
+// ExportParquet drops ID/CreatedAt/UpdatedAt and exports the same fields
+// CSV/JSONL do.
+type daxParquetRecord struct {
+	Company    string   `parquet:"name=company, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Ticker     string   `parquet:"name=ticker, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ReportType string   `parquet:"name=report_type, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Metric     string   `parquet:"name=metric, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Year       int32    `parquet:"name=year, type=INT32"`
+	Value      *float64 `parquet:"name=value, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Currency   string   `parquet:"name=currency, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+// parquetWriterParallelism is the column-chunk goroutine count passed to
+// parquet-go. DAX exports are I/O- not CPU-bound, so there's no reason to
+// tune this beyond parquet-go's own default.
+const parquetWriterParallelism = 4
+
+// ExportParquet writes every record received over ch to w as a Parquet
+// file. It drains ch to completion even after a write error so Stream's
+// producer goroutine isn't left blocked sending to an abandoned channel.
+func ExportParquet(w io.Writer, ch <-chan DAXRecord) error {
+	pw, err := writer.NewParquetWriterFromWriter(writerfile.NewWriterFile(w), new(daxParquetRecord), parquetWriterParallelism)
+	if err != nil {
+		drainDAXRecords(ch)
+		return fmt.Errorf("failed to create parquet writer: %w", err)
+	}
+
+	var writeErr error
+	for record := range ch {
+		if writeErr != nil {
+			continue
+		}
+		writeErr = pw.Write(daxParquetRecord{
+			Company:    record.Company,
+			Ticker:     record.Ticker,
+			ReportType: record.ReportType,
+			Metric:     record.Metric,
+			Year:       int32(record.Year),
+			Value:      record.Value,
+			Currency:   record.Currency,
+		})
+	}
+	if writeErr != nil {
+		drainDAXRecords(ch)
+		return fmt.Errorf("failed to write parquet record: %w", writeErr)
+	}
+
+	if err := pw.WriteStop(); err != nil {
+		return fmt.Errorf("failed to finalize parquet file: %w", err)
+	}
+	return nil
+}
+
+// drainDAXRecords discards every record remaining on ch.
+func drainDAXRecords(ch <-chan DAXRecord) {
+	for range ch {
+	}
+}
+
+// ExportSerializer writes a stream of DAXRecords to w in some format,
+// matching the signature of ExportCSV, ExportJSONL, and ExportParquet.
+type ExportSerializer func(w io.Writer, ch <-chan DAXRecord) error
+
+// ExportSerializerForAccept resolves an HTTP Accept header to the
+// serializer and response Content-Type for a DAX export, mirroring
+// ImporterForContentType's substring matching. An unrecognized or empty
+// accept falls back to CSV, matching ImportCSV's historical default
+// format.
+func ExportSerializerForAccept(accept string) (serialize ExportSerializer, contentType string) {
+	switch {
+	case strings.Contains(accept, "application/x-ndjson"):
+		return ExportJSONL, "application/x-ndjson"
+	case strings.Contains(accept, "application/vnd.apache.parquet"):
+		return ExportParquet, "application/vnd.apache.parquet"
+	default:
+		return ExportCSV, "text/csv"
+	}
+}
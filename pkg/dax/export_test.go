@@ -0,0 +1,93 @@
+package dax
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptrFloat(v float64) *float64 { return &v }
+
+func TestExportCSV(t *testing.T) {
+	ch := make(chan DAXRecord, 2)
+	ch <- DAXRecord{Company: "Siemens AG", Ticker: "SIE", ReportType: "income", Metric: "EBITDA", Year: 2025, Value: ptrFloat(1.5), Currency: "EUR"}
+	ch <- DAXRecord{Company: "SAP SE", Ticker: "SAP", ReportType: "income", Metric: "Net Income", Year: 2025, Currency: "EUR"}
+	close(ch)
+
+	var buf bytes.Buffer
+	require.NoError(t, ExportCSV(&buf, ch))
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "company,ticker,report_type,metric,year,value,currency", lines[0])
+	assert.Equal(t, "Siemens AG,SIE,income,EBITDA,2025,1.5,EUR", lines[1])
+	assert.Equal(t, "SAP SE,SAP,income,Net Income,2025,,EUR", lines[2])
+}
+
+func TestExportJSONL(t *testing.T) {
+	ch := make(chan DAXRecord, 2)
+	ch <- DAXRecord{Ticker: "SIE", Metric: "EBITDA", Year: 2025, Value: ptrFloat(1.5)}
+	ch <- DAXRecord{Ticker: "SAP", Metric: "Net Income", Year: 2024, Value: ptrFloat(2.5)}
+	close(ch)
+
+	var buf bytes.Buffer
+	require.NoError(t, ExportJSONL(&buf, ch))
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"ticker":"SIE"`)
+	assert.Contains(t, lines[1], `"ticker":"SAP"`)
+}
+
+func TestExportSerializerForAccept(t *testing.T) {
+	_, ct := ExportSerializerForAccept("application/x-ndjson")
+	assert.Equal(t, "application/x-ndjson", ct)
+
+	_, ct = ExportSerializerForAccept("application/vnd.apache.parquet")
+	assert.Equal(t, "application/vnd.apache.parquet", ct)
+
+	_, ct = ExportSerializerForAccept("text/csv")
+	assert.Equal(t, "text/csv", ct)
+
+	_, ct = ExportSerializerForAccept("")
+	assert.Equal(t, "text/csv", ct)
+}
+
+func TestInMemoryRepository_Stream(t *testing.T) {
+	repo := NewInMemoryRepository()
+	require.NoError(t, repo.BulkUpsert(context.Background(), []DAXRecord{
+		{Company: "Siemens AG", Ticker: "SIE", Metric: "EBITDA", Year: 2023, Value: ptrFloat(1)},
+		{Company: "Siemens AG", Ticker: "SIE", Metric: "EBITDA", Year: 2024, Value: ptrFloat(2)},
+		{Company: "SAP SE", Ticker: "SAP", Metric: "EBITDA", Year: 2024, Value: ptrFloat(3)},
+	}))
+
+	records, errs := repo.Stream(context.Background(), nil, 1)
+
+	var got []DAXRecord
+	for record := range records {
+		got = append(got, record)
+	}
+	require.NoError(t, <-errs)
+	assert.Len(t, got, 3)
+}
+
+func TestInMemoryRepository_Stream_ContextCancelled(t *testing.T) {
+	repo := NewInMemoryRepository()
+	require.NoError(t, repo.BulkUpsert(context.Background(), []DAXRecord{
+		{Ticker: "SIE", Metric: "EBITDA", Year: 2023, Value: ptrFloat(1)},
+		{Ticker: "SAP", Metric: "EBITDA", Year: 2024, Value: ptrFloat(2)},
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Stream's unbuffered records channel is never read here, so its
+	// internal select is forced onto the ctx.Done() branch instead of
+	// racing a send against cancellation.
+	_, errs := repo.Stream(ctx, nil, 1)
+	assert.ErrorIs(t, <-errs, context.Canceled)
+}
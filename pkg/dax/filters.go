@@ -0,0 +1,161 @@
+package dax
+
+import (
+	"fmt"
+)
+
+// Sort directions accepted by Filters.SortDir.
+const (
+	SortDirAsc  = "asc"
+	SortDirDesc = "desc"
+)
+
+// sortableFields are the columns Filters.SortBy is allowed to reference.
+var sortableFields = map[string]bool{
+	"year":    true,
+	"ticker":  true,
+	"metric":  true,
+	"value":   true,
+	"company": true,
+}
+
+// Filters is a composable set of query constraints for DAX records. It is
+// threaded through Service.GetByFilters and Repository.FindByFilters instead
+// of growing the method signature every time a new filter dimension (metric,
+// report type, value range, ...) is needed.
+type Filters struct {
+	Tickers     []string
+	YearFrom    *int
+	YearTo      *int
+	Metrics     []string
+	ReportTypes []string
+	Currencies  []string
+	MinValue    *float64
+	MaxValue    *float64
+	SortBy      string
+	SortDir     string
+}
+
+// Validate checks that the filter's constraints are internally consistent.
+func (f *Filters) Validate() error {
+	if f == nil {
+		return nil
+	}
+
+	if f.YearFrom != nil && f.YearTo != nil && *f.YearFrom > *f.YearTo {
+		return fmt.Errorf("year_from must not be after year_to")
+	}
+
+	if f.MinValue != nil && f.MaxValue != nil && *f.MinValue > *f.MaxValue {
+		return fmt.Errorf("min_value must not be greater than max_value")
+	}
+
+	if f.SortBy != "" && !sortableFields[f.SortBy] {
+		return fmt.Errorf("invalid sort field: %s", f.SortBy)
+	}
+
+	if f.SortDir != "" && f.SortDir != SortDirAsc && f.SortDir != SortDirDesc {
+		return fmt.Errorf("invalid sort direction: %s", f.SortDir)
+	}
+
+	return nil
+}
+
+// matches reports whether record satisfies every constraint in f. A nil
+// Filters matches everything.
+func (f *Filters) matches(record *DAXRecord) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.Tickers) > 0 && !containsFold(f.Tickers, record.Ticker) {
+		return false
+	}
+	if len(f.Metrics) > 0 && !containsFold(f.Metrics, record.Metric) {
+		return false
+	}
+	if len(f.ReportTypes) > 0 && !containsFold(f.ReportTypes, record.ReportType) {
+		return false
+	}
+	if len(f.Currencies) > 0 && !containsFold(f.Currencies, record.Currency) {
+		return false
+	}
+	if f.YearFrom != nil && record.Year < *f.YearFrom {
+		return false
+	}
+	if f.YearTo != nil && record.Year > *f.YearTo {
+		return false
+	}
+	if f.MinValue != nil && (record.Value == nil || *record.Value < *f.MinValue) {
+		return false
+	}
+	if f.MaxValue != nil && (record.Value == nil || *record.Value > *f.MaxValue) {
+		return false
+	}
+
+	return true
+}
+
+func containsFold(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+// sortLess returns a less-than comparator for the given Filters, falling
+// back to the repository's default ordering (year DESC, ticker ASC, metric
+// ASC) when no SortBy is set.
+func (f *Filters) SortLess(records []DAXRecord) func(i, j int) bool {
+	sortBy := ""
+	dir := SortDirAsc
+	if f != nil {
+		sortBy = f.SortBy
+		if f.SortDir != "" {
+			dir = f.SortDir
+		}
+	}
+
+	if sortBy == "" {
+		return func(i, j int) bool {
+			if records[i].Year != records[j].Year {
+				return records[i].Year > records[j].Year
+			}
+			if records[i].Ticker != records[j].Ticker {
+				return records[i].Ticker < records[j].Ticker
+			}
+			return records[i].Metric < records[j].Metric
+		}
+	}
+
+	less := func(i, j int) bool {
+		switch sortBy {
+		case "year":
+			return records[i].Year < records[j].Year
+		case "ticker":
+			return records[i].Ticker < records[j].Ticker
+		case "metric":
+			return records[i].Metric < records[j].Metric
+		case "company":
+			return records[i].Company < records[j].Company
+		case "value":
+			iv, jv := 0.0, 0.0
+			if records[i].Value != nil {
+				iv = *records[i].Value
+			}
+			if records[j].Value != nil {
+				jv = *records[j].Value
+			}
+			return iv < jv
+		default:
+			return false
+		}
+	}
+
+	if dir == SortDirDesc {
+		return func(i, j int) bool { return less(j, i) }
+	}
+	return less
+}
@@ -0,0 +1,290 @@
+package dax
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Importer parses record data from a reader into DAXRecords, collecting
+// per-row errors instead of aborting on the first bad row (the decision of
+// whether to tolerate those errors belongs to Service, via
+// ImportCSVOptions.ContinueOnError).
+type Importer interface {
+	Parse(reader io.Reader) ([]DAXRecord, []RowError, error)
+}
+
+// ImporterForContentType returns the Importer registered for contentType,
+// or nil if the type isn't supported. Matching is substring-based so a
+// charset suffix (e.g. "text/csv; charset=utf-8") still resolves.
+func ImporterForContentType(contentType string) Importer {
+	switch {
+	case strings.Contains(contentType, "text/csv"):
+		return CSVImporter{}
+	case strings.Contains(contentType, "application/x-ndjson"):
+		return JSONLinesImporter{}
+	case strings.Contains(contentType, "spreadsheetml.sheet"):
+		return XLSXImporter{}
+	case strings.Contains(contentType, "application/json"):
+		return JSONImporter{}
+	default:
+		return nil
+	}
+}
+
+// requiredFieldNames enumerates the DAXRecord fields every import format
+// must supply. validateHeader (CSV/XLSX) and missingRequiredFields
+// (JSON/JSON Lines) both check against this list, so a new required field
+// only needs to be added here.
+var requiredFieldNames = []string{"company", "ticker", "report_type", "metric", "year", "value", "currency"}
+
+// CSVImporter parses the comma-separated format Service.ImportCSV has
+// always accepted.
+type CSVImporter struct{}
+
+// Parse implements Importer.
+func (CSVImporter) Parse(reader io.Reader) ([]DAXRecord, []RowError, error) {
+	csvReader := csv.NewReader(reader)
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	if err := validateHeader(header); err != nil {
+		return nil, nil, err
+	}
+
+	var records []DAXRecord
+	var rowErrors []RowError
+	rowNum := 1
+
+	for {
+		row, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		rowNum++
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{RowNum: rowNum, Err: err.Error()})
+			continue
+		}
+
+		record, err := parseCSVRow(row)
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{RowNum: rowNum, RawLine: strings.Join(row, ","), Err: err.Error()})
+			continue
+		}
+		records = append(records, record)
+	}
+
+	return records, rowErrors, nil
+}
+
+// XLSXImporter parses an Excel workbook's first sheet, treating row 1 as
+// the header. Cell values are read as strings and reuse parseCSVRow, so
+// XLSX rows are validated identically to CSV rows.
+type XLSXImporter struct{}
+
+// Parse implements Importer.
+func (XLSXImporter) Parse(reader io.Reader) ([]DAXRecord, []RowError, error) {
+	file, err := excelize.OpenReader(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open XLSX file: %w", err)
+	}
+	defer file.Close()
+
+	sheet := file.GetSheetName(0)
+	if sheet == "" {
+		return nil, nil, fmt.Errorf("XLSX file has no sheets")
+	}
+
+	rows, err := file.GetRows(sheet)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read XLSX rows: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil, fmt.Errorf("failed to read XLSX header: empty sheet")
+	}
+
+	if err := validateHeader(rows[0]); err != nil {
+		return nil, nil, err
+	}
+
+	var records []DAXRecord
+	var rowErrors []RowError
+	rowNum := 1
+
+	for _, row := range rows[1:] {
+		rowNum++
+
+		record, err := parseCSVRow(row)
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{RowNum: rowNum, RawLine: strings.Join(row, ","), Err: err.Error()})
+			continue
+		}
+		records = append(records, record)
+	}
+
+	return records, rowErrors, nil
+}
+
+// JSONImporter parses a JSON array of record objects.
+type JSONImporter struct{}
+
+// Parse implements Importer.
+func (JSONImporter) Parse(reader io.Reader) ([]DAXRecord, []RowError, error) {
+	var raw []json.RawMessage
+	if err := json.NewDecoder(reader).Decode(&raw); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode JSON array: %w", err)
+	}
+
+	var records []DAXRecord
+	var rowErrors []RowError
+
+	for i, item := range raw {
+		rowNum := i + 1
+		record, err := parseJSONRow(item)
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{RowNum: rowNum, RawLine: string(item), Err: err.Error()})
+			continue
+		}
+		records = append(records, record)
+	}
+
+	return records, rowErrors, nil
+}
+
+// JSONLinesImporter parses newline-delimited JSON (one record object per
+// line), well-suited to streaming large imports without buffering the
+// whole file.
+type JSONLinesImporter struct{}
+
+// Parse implements Importer.
+func (JSONLinesImporter) Parse(reader io.Reader) ([]DAXRecord, []RowError, error) {
+	var records []DAXRecord
+	var rowErrors []RowError
+
+	scanner := bufio.NewScanner(reader)
+	rowNum := 0
+
+	for scanner.Scan() {
+		rowNum++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		record, err := parseJSONRow(json.RawMessage(line))
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{RowNum: rowNum, RawLine: line, Err: err.Error()})
+			continue
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read JSON Lines input: %w", err)
+	}
+
+	return records, rowErrors, nil
+}
+
+// parseJSONRow parses a single JSON record object shared by JSONImporter
+// and JSONLinesImporter. Year and value are accepted as either a JSON
+// number or a numeric string, matching CSV's permissive text-based
+// parsing, so a file mixing both representations across rows still
+// imports cleanly.
+func parseJSONRow(raw json.RawMessage) (DAXRecord, error) {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return DAXRecord{}, fmt.Errorf("invalid JSON record: %w", err)
+	}
+
+	if missing := missingRequiredFields(fields); len(missing) > 0 {
+		return DAXRecord{}, fmt.Errorf("missing required fields: %s", strings.Join(missing, ", "))
+	}
+
+	company, err := stringField(fields, "company")
+	if err != nil {
+		return DAXRecord{}, err
+	}
+	ticker, err := stringField(fields, "ticker")
+	if err != nil {
+		return DAXRecord{}, err
+	}
+	reportType, err := stringField(fields, "report_type")
+	if err != nil {
+		return DAXRecord{}, err
+	}
+	metric, err := stringField(fields, "metric")
+	if err != nil {
+		return DAXRecord{}, err
+	}
+	currency, err := stringField(fields, "currency")
+	if err != nil {
+		return DAXRecord{}, err
+	}
+
+	year, err := numberFromAny(fields["year"])
+	if err != nil {
+		return DAXRecord{}, fmt.Errorf("invalid year: %w", err)
+	}
+	value, err := numberFromAny(fields["value"])
+	if err != nil {
+		return DAXRecord{}, fmt.Errorf("invalid value: %w", err)
+	}
+
+	return DAXRecord{
+		Company:    strings.TrimSpace(company),
+		Ticker:     strings.TrimSpace(ticker),
+		ReportType: strings.TrimSpace(reportType),
+		Metric:     strings.TrimSpace(metric),
+		Year:       int(year),
+		Value:      &value,
+		Currency:   strings.TrimSpace(currency),
+	}, nil
+}
+
+// missingRequiredFields returns the names of any requiredFieldNames absent
+// from fields.
+func missingRequiredFields(fields map[string]interface{}) []string {
+	var missing []string
+	for _, name := range requiredFieldNames {
+		if _, ok := fields[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// stringField type-asserts fields[name] as a string.
+func stringField(fields map[string]interface{}, name string) (string, error) {
+	v, ok := fields[name].(string)
+	if !ok {
+		return "", fmt.Errorf("%s must be a string", name)
+	}
+	return v, nil
+}
+
+// numberFromAny coerces a decoded JSON value (a float64 or a numeric
+// string) into a float64.
+func numberFromAny(v interface{}) (float64, error) {
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not numeric", n)
+		}
+		return f, nil
+	default:
+		return 0, fmt.Errorf("unsupported type %T", v)
+	}
+}
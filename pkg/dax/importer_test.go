@@ -0,0 +1,147 @@
+package dax
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestImporterForContentType(t *testing.T) {
+	assert.IsType(t, CSVImporter{}, ImporterForContentType("text/csv"))
+	assert.IsType(t, CSVImporter{}, ImporterForContentType("text/csv; charset=utf-8"))
+	assert.IsType(t, JSONImporter{}, ImporterForContentType("application/json"))
+	assert.IsType(t, JSONLinesImporter{}, ImporterForContentType("application/x-ndjson"))
+	assert.IsType(t, XLSXImporter{}, ImporterForContentType("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"))
+	assert.Nil(t, ImporterForContentType("application/xml"))
+}
+
+func TestCSVImporter_Parse_Success(t *testing.T) {
+	content := `company,ticker,report_type,metric,year,value,currency
+Siemens AG,SIE,income,EBITDA,2025,15859000000.0,EUR`
+
+	records, rowErrors, err := CSVImporter{}.Parse(strings.NewReader(content))
+
+	require.NoError(t, err)
+	assert.Empty(t, rowErrors)
+	require.Len(t, records, 1)
+	assert.Equal(t, "SIE", records[0].Ticker)
+}
+
+func TestCSVImporter_Parse_CollectsRowErrors(t *testing.T) {
+	content := `company,ticker,report_type,metric,year,value,currency
+Siemens AG,SIE,income,EBITDA,invalid,15859000000.0,EUR
+SAP SE,SAP,income,Net Income,2025,8500000000.0,EUR`
+
+	records, rowErrors, err := CSVImporter{}.Parse(strings.NewReader(content))
+
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Len(t, rowErrors, 1)
+	assert.Contains(t, rowErrors[0].Err, "invalid year")
+}
+
+func TestJSONImporter_Parse_Success_MixedNumericTypes(t *testing.T) {
+	content := `[
+		{"company":"Siemens AG","ticker":"SIE","report_type":"income","metric":"EBITDA","year":2025,"value":15859000000.0,"currency":"EUR"},
+		{"company":"SAP SE","ticker":"SAP","report_type":"income","metric":"Net Income","year":"2025","value":"8500000000.0","currency":"EUR"}
+	]`
+
+	records, rowErrors, err := JSONImporter{}.Parse(strings.NewReader(content))
+
+	require.NoError(t, err)
+	assert.Empty(t, rowErrors)
+	require.Len(t, records, 2)
+	assert.Equal(t, "SIE", records[0].Ticker)
+	assert.Equal(t, 2025, records[1].Year)
+	assert.Equal(t, 8500000000.0, *records[1].Value)
+}
+
+func TestJSONImporter_Parse_MalformedRecords(t *testing.T) {
+	content := `[
+		{"company":"Siemens AG","ticker":"SIE","report_type":"income","metric":"EBITDA","year":"not-a-year","value":15859000000.0,"currency":"EUR"},
+		{"company":"SAP SE","ticker":"SAP","report_type":"income","metric":"Net Income","year":2025,"value":8500000000.0}
+	]`
+
+	records, rowErrors, err := JSONImporter{}.Parse(strings.NewReader(content))
+
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	require.Len(t, rowErrors, 2)
+	assert.Equal(t, 1, rowErrors[0].RowNum)
+	assert.Contains(t, rowErrors[0].Err, "invalid year")
+	assert.Equal(t, 2, rowErrors[1].RowNum)
+	assert.Contains(t, rowErrors[1].Err, "missing required fields")
+}
+
+func TestJSONImporter_Parse_InvalidJSON(t *testing.T) {
+	_, _, err := JSONImporter{}.Parse(strings.NewReader(`not json`))
+	assert.Error(t, err)
+}
+
+func TestJSONLinesImporter_Parse_Success(t *testing.T) {
+	content := `{"company":"Siemens AG","ticker":"SIE","report_type":"income","metric":"EBITDA","year":2025,"value":15859000000.0,"currency":"EUR"}
+{"company":"SAP SE","ticker":"SAP","report_type":"income","metric":"Net Income","year":"2025","value":"8500000000.0","currency":"EUR"}`
+
+	records, rowErrors, err := JSONLinesImporter{}.Parse(strings.NewReader(content))
+
+	require.NoError(t, err)
+	assert.Empty(t, rowErrors)
+	require.Len(t, records, 2)
+}
+
+func TestJSONLinesImporter_Parse_CollectsRowErrors(t *testing.T) {
+	content := `{"company":"Siemens AG","ticker":"SIE","report_type":"income","metric":"EBITDA","year":2025,"value":"not-a-number","currency":"EUR"}
+{"company":"SAP SE","ticker":"SAP","report_type":"income","metric":"Net Income","year":2025,"value":8500000000.0,"currency":"EUR"}`
+
+	records, rowErrors, err := JSONLinesImporter{}.Parse(strings.NewReader(content))
+
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Len(t, rowErrors, 1)
+	assert.Equal(t, 1, rowErrors[0].RowNum)
+	assert.Contains(t, rowErrors[0].Err, "invalid value")
+}
+
+func TestJSONLinesImporter_Parse_SkipsBlankLines(t *testing.T) {
+	content := "{\"company\":\"Siemens AG\",\"ticker\":\"SIE\",\"report_type\":\"income\",\"metric\":\"EBITDA\",\"year\":2025,\"value\":1.0,\"currency\":\"EUR\"}\n\n"
+
+	records, rowErrors, err := JSONLinesImporter{}.Parse(strings.NewReader(content))
+
+	require.NoError(t, err)
+	assert.Empty(t, rowErrors)
+	assert.Len(t, records, 1)
+}
+
+func TestXLSXImporter_Parse_Success(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheet := f.GetSheetName(0)
+	header := []string{"company", "ticker", "report_type", "metric", "year", "value", "currency"}
+	for i, col := range header {
+		cell, err := excelize.CoordinatesToCellName(i+1, 1)
+		require.NoError(t, err)
+		require.NoError(t, f.SetCellValue(sheet, cell, col))
+	}
+	row := []interface{}{"Siemens AG", "SIE", "income", "EBITDA", 2025, 15859000000.0, "EUR"}
+	for i, val := range row {
+		cell, err := excelize.CoordinatesToCellName(i+1, 2)
+		require.NoError(t, err)
+		require.NoError(t, f.SetCellValue(sheet, cell, val))
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, f.Write(&buf))
+
+	records, rowErrors, err := XLSXImporter{}.Parse(&buf)
+
+	require.NoError(t, err)
+	assert.Empty(t, rowErrors)
+	require.Len(t, records, 1)
+	assert.Equal(t, "SIE", records[0].Ticker)
+	assert.Equal(t, 2025, records[0].Year)
+}
@@ -0,0 +1,183 @@
+package dax
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrJobNotFound is returned when an import job is not found.
+var ErrJobNotFound = errors.New("import job not found")
+
+// JobStatus represents the current state of an ImportJob.
+type JobStatus string
+
+const (
+	// JobStatusPending is a job that has been accepted but not started.
+	JobStatusPending JobStatus = "pending"
+	// JobStatusRunning is a job currently being processed.
+	JobStatusRunning JobStatus = "running"
+	// JobStatusSucceeded is a job that completed without a fatal error.
+	JobStatusSucceeded JobStatus = "succeeded"
+	// JobStatusFailed is a job that aborted with a fatal error.
+	JobStatusFailed JobStatus = "failed"
+	// JobStatusStopped is a job that was canceled via
+	// ImportJobManager.Stop before it finished on its own.
+	JobStatusStopped JobStatus = "stopped"
+)
+
+const (
+	// TriggerAPI marks a job submitted directly through the import API.
+	TriggerAPI = "api"
+	// TriggerScheduled marks a job started by a scheduler rather than a
+	// direct API call.
+	TriggerScheduled = "scheduled"
+)
+
+// ImportJob tracks the lifecycle of an asynchronous import submitted via
+// ImportJobManager.Submit.
+type ImportJob struct {
+	ID               string     `json:"id"`
+	IdempotencyKey   string     `json:"-"`
+	Status           JobStatus  `json:"status"`
+	Trigger          string     `json:"trigger"`
+	RecordsProcessed int        `json:"records_processed"`
+	RecordsTotal     int        `json:"records_total"`
+	RecordsSucceeded int        `json:"records_succeeded"`
+	RecordsFailed    int        `json:"records_failed"`
+	Errors           []RowError `json:"errors,omitempty"`
+	FatalError       string     `json:"fatal_error,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	StartedAt        *time.Time `json:"started_at,omitempty"`
+	FinishedAt       *time.Time `json:"finished_at,omitempty"`
+}
+
+// InProgress reports how many of the job's total records have neither
+// succeeded nor failed yet. It is zero once the job reaches a terminal
+// status.
+func (j *ImportJob) InProgress() int {
+	remaining := j.RecordsTotal - j.RecordsSucceeded - j.RecordsFailed
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// JobStore persists ImportJobs. InMemoryJobStore is sufficient for a single
+// API instance; a Redis/DB-backed implementation can be added later without
+// changing ImportJobManager.
+type JobStore interface {
+	Create(job *ImportJob) error
+	Get(id string) (*ImportJob, error)
+	Update(job *ImportJob) error
+	// FindByIdempotencyKey returns the most recently created job submitted
+	// with key, or ErrJobNotFound if none exists. It does not apply any
+	// TTL — callers decide whether the result is still reusable.
+	FindByIdempotencyKey(key string) (*ImportJob, error)
+	// List returns jobs newest-first, paginated by offset/limit, along with
+	// the total number of jobs in the store.
+	List(offset, limit int) ([]*ImportJob, int, error)
+}
+
+// InMemoryJobStore implements JobStore using in-memory storage.
+type InMemoryJobStore struct {
+	mu           sync.RWMutex
+	jobs         map[string]*ImportJob
+	order        []string          // job IDs in submission order, oldest first
+	byIdempotent map[string]string // idempotency key -> job ID
+}
+
+// NewInMemoryJobStore creates a new in-memory job store.
+func NewInMemoryJobStore() *InMemoryJobStore {
+	return &InMemoryJobStore{
+		jobs:         make(map[string]*ImportJob),
+		byIdempotent: make(map[string]string),
+	}
+}
+
+// Create adds a new job to the store.
+func (s *InMemoryJobStore) Create(job *ImportJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs[job.ID] = job
+	s.order = append(s.order, job.ID)
+	if job.IdempotencyKey != "" {
+		s.byIdempotent[job.IdempotencyKey] = job.ID
+	}
+	return nil
+}
+
+// Get retrieves a job by ID. The returned job is a copy, so the caller can
+// read it without racing ImportJobManager.run's concurrent updates to the
+// stored job.
+func (s *InMemoryJobStore) Get(id string) (*ImportJob, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	clone := *job
+	return &clone, nil
+}
+
+// Update overwrites the stored job sharing job.ID with a copy of job, so the
+// caller is free to keep mutating its own copy afterward without racing a
+// concurrent Get.
+func (s *InMemoryJobStore) Update(job *ImportJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.jobs[job.ID]; !ok {
+		return ErrJobNotFound
+	}
+	clone := *job
+	s.jobs[job.ID] = &clone
+	return nil
+}
+
+// FindByIdempotencyKey implements JobStore.
+func (s *InMemoryJobStore) FindByIdempotencyKey(key string) (*ImportJob, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, ok := s.byIdempotent[key]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	clone := *job
+	return &clone, nil
+}
+
+// List implements JobStore, returning jobs newest-first.
+func (s *InMemoryJobStore) List(offset, limit int) ([]*ImportJob, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	total := len(s.order)
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= total {
+		return []*ImportJob{}, total, nil
+	}
+
+	end := offset + limit
+	if limit <= 0 || end > total {
+		end = total
+	}
+
+	jobs := make([]*ImportJob, 0, end-offset)
+	for i := total - 1 - offset; i >= total-end; i-- {
+		clone := *s.jobs[s.order[i]]
+		jobs = append(jobs, &clone)
+	}
+
+	return jobs, total, nil
+}
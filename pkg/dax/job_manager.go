@@ -0,0 +1,263 @@
+package dax
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrManagerShuttingDown is returned by Submit once Shutdown has been
+// called; no new jobs are accepted while draining in-flight ones.
+var ErrManagerShuttingDown = errors.New("import job manager is shutting down")
+
+// ErrJobNotCancelable is returned by Stop when the job has already reached
+// a terminal status (succeeded, failed, or stopped).
+var ErrJobNotCancelable = errors.New("import job is not running")
+
+// defaultIdempotencyTTL is used when ImportJobManager is constructed with a
+// non-positive ttl.
+const defaultIdempotencyTTL = 24 * time.Hour
+
+// ImportJobManager runs imports asynchronously in the background, tracking
+// their progress in a JobStore and deduplicating repeated submissions via
+// an Idempotency-Key within idempotencyTTL.
+type ImportJobManager struct {
+	service        *Service
+	store          JobStore
+	idempotencyTTL time.Duration
+
+	mu           sync.Mutex
+	shuttingDown bool
+	wg           sync.WaitGroup
+	cancels      map[string]context.CancelFunc
+}
+
+// NewImportJobManager creates a manager that runs imports through service,
+// persisting job state to store. A non-positive ttl falls back to
+// defaultIdempotencyTTL.
+func NewImportJobManager(service *Service, store JobStore, ttl time.Duration) *ImportJobManager {
+	if ttl <= 0 {
+		ttl = defaultIdempotencyTTL
+	}
+	return &ImportJobManager{
+		service:        service,
+		store:          store,
+		idempotencyTTL: ttl,
+		cancels:        make(map[string]context.CancelFunc),
+	}
+}
+
+// Submit enqueues data for asynchronous import via format (a Content-Type
+// string, as accepted by Service.Import), recording trigger (TriggerAPI or
+// TriggerScheduled) against the job for later auditing. If idempotencyKey
+// matches a job submitted within the last idempotencyTTL, that existing
+// job is returned instead of starting a new import. The returned job is
+// always in JobStatusPending or later; the import itself runs in a
+// background goroutine, independent of ctx's lifetime.
+func (m *ImportJobManager) Submit(ctx context.Context, data []byte, format string, opts ImportCSVOptions, idempotencyKey, trigger string) (*ImportJob, error) {
+	if idempotencyKey != "" {
+		existing, err := m.store.FindByIdempotencyKey(idempotencyKey)
+		if err != nil && !errors.Is(err, ErrJobNotFound) {
+			return nil, err
+		}
+		if existing != nil && time.Since(existing.CreatedAt) < m.idempotencyTTL {
+			return existing, nil
+		}
+	}
+
+	m.mu.Lock()
+	if m.shuttingDown {
+		m.mu.Unlock()
+		return nil, ErrManagerShuttingDown
+	}
+	m.wg.Add(1)
+	m.mu.Unlock()
+
+	if trigger == "" {
+		trigger = TriggerAPI
+	}
+
+	job := &ImportJob{
+		ID:             uuid.New().String(),
+		IdempotencyKey: idempotencyKey,
+		Status:         JobStatusPending,
+		Trigger:        trigger,
+		CreatedAt:      time.Now(),
+	}
+	if err := m.store.Create(job); err != nil {
+		m.wg.Done()
+		return nil, err
+	}
+
+	go m.run(job.ID, data, format, opts)
+
+	return job, nil
+}
+
+// Get retrieves a job by ID.
+func (m *ImportJobManager) Get(id string) (*ImportJob, error) {
+	return m.store.Get(id)
+}
+
+// List returns jobs newest-first, paginated by page (1-indexed) and limit,
+// along with pagination metadata covering the full job history.
+func (m *ImportJobManager) List(page, limit int) ([]*ImportJob, PaginationMeta, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	jobs, total, err := m.store.List((page-1)*limit, limit)
+	if err != nil {
+		return nil, PaginationMeta{}, err
+	}
+
+	totalPages := (total + limit - 1) / limit
+
+	return jobs, PaginationMeta{
+		Page:       page,
+		Limit:      limit,
+		TotalCount: total,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// Stop cancels a pending or running job, marking it JobStatusStopped once
+// its worker goroutine observes the cancellation. It returns
+// ErrJobNotFound if id doesn't exist, or ErrJobNotCancelable if the job has
+// already reached a terminal status.
+func (m *ImportJobManager) Stop(id string) error {
+	job, err := m.store.Get(id)
+	if err != nil {
+		return err
+	}
+
+	if job.Status != JobStatusPending && job.Status != JobStatusRunning {
+		return ErrJobNotCancelable
+	}
+
+	m.mu.Lock()
+	cancel, ok := m.cancels[id]
+	m.mu.Unlock()
+	if ok {
+		cancel()
+	}
+
+	job.Status = JobStatusStopped
+	finished := time.Now()
+	job.FinishedAt = &finished
+	return m.store.Update(job)
+}
+
+// Shutdown stops accepting new submissions and waits for running jobs to
+// drain, up to ctx's deadline.
+func (m *ImportJobManager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	m.shuttingDown = true
+	m.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run is the worker body for a single job: it drives the import through
+// Service over a cancelable context (so Stop can interrupt it between
+// rows), publishing progress via ImportCSVOptions' hooks, and persists the
+// outcome. It takes only jobID, re-fetching its own copy of the job via
+// store.Get below, rather than the *ImportJob Submit created - a JobStore
+// hands out copies (InMemoryJobStore.Get/Update), so mutating a copy
+// obtained here can never race a concurrent Get made by another caller
+// polling the job's status.
+func (m *ImportJobManager) run(jobID string, data []byte, format string, opts ImportCSVOptions) {
+	defer m.wg.Done()
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	m.mu.Lock()
+	m.cancels[jobID] = cancel
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		delete(m.cancels, jobID)
+		m.mu.Unlock()
+		cancel()
+	}()
+
+	job, err := m.store.Get(jobID)
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	job.Status = JobStatusRunning
+	job.StartedAt = &now
+	m.persistProgress(job)
+
+	opts.OnParsed = func(total int) {
+		job.RecordsTotal = total
+		m.persistProgress(job)
+	}
+	opts.OnProgress = func(recordsProcessed int) {
+		job.RecordsProcessed = recordsProcessed
+		job.RecordsSucceeded = recordsProcessed
+		m.persistProgress(job)
+	}
+
+	var response *ImportResponse
+	if format == "" || format == "text/csv" {
+		response, err = m.service.ImportCSV(runCtx, bytes.NewReader(data), opts)
+	} else {
+		response, err = m.service.Import(runCtx, bytes.NewReader(data), format, opts)
+	}
+
+	// Stop already marked the job JobStatusStopped; don't clobber that with
+	// whatever error the canceled context produced.
+	current, getErr := m.store.Get(job.ID)
+	if getErr == nil && current.Status == JobStatusStopped {
+		return
+	}
+
+	finished := time.Now()
+	job.FinishedAt = &finished
+
+	if err != nil {
+		job.Status = JobStatusFailed
+		job.FatalError = err.Error()
+		_ = m.store.Update(job)
+		return
+	}
+
+	job.Status = JobStatusSucceeded
+	job.RecordsProcessed = response.RecordsImported
+	job.RecordsSucceeded = response.RecordsImported
+	job.RecordsFailed = response.RecordsFailed
+	job.Errors = response.Errors
+	_ = m.store.Update(job)
+}
+
+// persistProgress writes job to the store, unless Stop has already marked
+// it JobStatusStopped in the meantime - run calls this for its initial
+// transition to JobStatusRunning and from its progress callbacks, any of
+// which could otherwise race a concurrent Stop and persist job's stale
+// status right back over it.
+func (m *ImportJobManager) persistProgress(job *ImportJob) {
+	if current, err := m.store.Get(job.ID); err == nil && current.Status == JobStatusStopped {
+		return
+	}
+	_ = m.store.Update(job)
+}
@@ -0,0 +1,217 @@
+package dax_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/dax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validCSVHeader = "company,ticker,report_type,metric,year,value,currency\n"
+
+func newTestManager(ttl time.Duration) (*dax.ImportJobManager, *dax.InMemoryRepository) {
+	repo := dax.NewInMemoryRepository()
+	service := dax.NewService(repo)
+	store := dax.NewInMemoryJobStore()
+	return dax.NewImportJobManager(service, store, ttl), repo
+}
+
+func waitForStatus(t *testing.T, manager *dax.ImportJobManager, jobID string, want dax.JobStatus) *dax.ImportJob {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := manager.Get(jobID)
+		require.NoError(t, err)
+		if job.Status == want {
+			return job
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("job %s did not reach status %s in time", jobID, want)
+	return nil
+}
+
+func TestImportJobManager_Submit_RunsToCompletion(t *testing.T) {
+	manager, _ := newTestManager(time.Hour)
+
+	csv := validCSVHeader + "Siemens,SIE,annual,EBITDA,2023,1000.5,EUR\n"
+	job, err := manager.Submit(context.Background(), []byte(csv), "text/csv", dax.ImportCSVOptions{}, "", dax.TriggerAPI)
+	require.NoError(t, err)
+
+	done := waitForStatus(t, manager, job.ID, dax.JobStatusSucceeded)
+	assert.Equal(t, 1, done.RecordsProcessed)
+	assert.Equal(t, 1, done.RecordsTotal)
+	assert.NotNil(t, done.StartedAt)
+	assert.NotNil(t, done.FinishedAt)
+}
+
+func TestImportJobManager_Submit_PartialFailureStillSucceeds(t *testing.T) {
+	manager, _ := newTestManager(time.Hour)
+
+	csv := validCSVHeader +
+		"Siemens,SIE,annual,EBITDA,2023,1000.5,EUR\n" +
+		"SAP,SAP,annual,EBITDA,not-a-year,500.0,EUR\n"
+	job, err := manager.Submit(context.Background(), []byte(csv), "text/csv", dax.ImportCSVOptions{ContinueOnError: true}, "", dax.TriggerAPI)
+	require.NoError(t, err)
+
+	done := waitForStatus(t, manager, job.ID, dax.JobStatusSucceeded)
+	assert.Equal(t, 1, done.RecordsProcessed)
+	require.Len(t, done.Errors, 1)
+	assert.Equal(t, 3, done.Errors[0].RowNum)
+}
+
+func TestImportJobManager_Submit_FatalErrorFailsJob(t *testing.T) {
+	manager, _ := newTestManager(time.Hour)
+
+	job, err := manager.Submit(context.Background(), []byte(""), "text/csv", dax.ImportCSVOptions{}, "", dax.TriggerAPI)
+	require.NoError(t, err)
+
+	done := waitForStatus(t, manager, job.ID, dax.JobStatusFailed)
+	assert.True(t, strings.Contains(done.FatalError, "CSV") || done.FatalError != "")
+}
+
+func TestImportJobManager_Submit_DedupesWithinTTL(t *testing.T) {
+	manager, _ := newTestManager(time.Hour)
+
+	csv := validCSVHeader + "Siemens,SIE,annual,EBITDA,2023,1000.5,EUR\n"
+	first, err := manager.Submit(context.Background(), []byte(csv), "text/csv", dax.ImportCSVOptions{}, "dup-key", dax.TriggerAPI)
+	require.NoError(t, err)
+
+	second, err := manager.Submit(context.Background(), []byte(csv), "text/csv", dax.ImportCSVOptions{}, "dup-key", dax.TriggerAPI)
+	require.NoError(t, err)
+
+	assert.Equal(t, first.ID, second.ID)
+}
+
+func TestImportJobManager_Submit_NewJobAfterTTLExpires(t *testing.T) {
+	manager, _ := newTestManager(time.Millisecond)
+
+	csv := validCSVHeader + "Siemens,SIE,annual,EBITDA,2023,1000.5,EUR\n"
+	first, err := manager.Submit(context.Background(), []byte(csv), "text/csv", dax.ImportCSVOptions{}, "dup-key", dax.TriggerAPI)
+	require.NoError(t, err)
+	waitForStatus(t, manager, first.ID, dax.JobStatusSucceeded)
+
+	time.Sleep(5 * time.Millisecond)
+
+	second, err := manager.Submit(context.Background(), []byte(csv), "text/csv", dax.ImportCSVOptions{}, "dup-key", dax.TriggerAPI)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first.ID, second.ID)
+}
+
+func TestImportJobManager_Submit_ConcurrentSubmissionsEachGetOwnJob(t *testing.T) {
+	manager, _ := newTestManager(time.Hour)
+	csv := validCSVHeader + "Siemens,SIE,annual,EBITDA,2023,1000.5,EUR\n"
+
+	const n = 10
+	jobIDs := make([]string, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			job, err := manager.Submit(context.Background(), []byte(csv), "text/csv", dax.ImportCSVOptions{}, "", dax.TriggerAPI)
+			require.NoError(t, err)
+			jobIDs[i] = job.ID
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool, n)
+	for _, id := range jobIDs {
+		assert.False(t, seen[id], "expected unique job IDs for unkeyed submissions")
+		seen[id] = true
+		waitForStatus(t, manager, id, dax.JobStatusSucceeded)
+	}
+}
+
+func TestImportJobManager_Shutdown_DrainsRunningJobs(t *testing.T) {
+	manager, _ := newTestManager(time.Hour)
+	csv := validCSVHeader + "Siemens,SIE,annual,EBITDA,2023,1000.5,EUR\n"
+
+	job, err := manager.Submit(context.Background(), []byte(csv), "text/csv", dax.ImportCSVOptions{}, "", dax.TriggerAPI)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, manager.Shutdown(ctx))
+
+	done, err := manager.Get(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, dax.JobStatusSucceeded, done.Status)
+
+	_, err = manager.Submit(context.Background(), []byte(csv), "text/csv", dax.ImportCSVOptions{}, "", dax.TriggerAPI)
+	assert.ErrorIs(t, err, dax.ErrManagerShuttingDown)
+}
+
+func TestImportJobManager_Stop_NotFound(t *testing.T) {
+	manager, _ := newTestManager(time.Hour)
+
+	err := manager.Stop("missing")
+	assert.ErrorIs(t, err, dax.ErrJobNotFound)
+}
+
+func TestImportJobManager_Stop_AlreadyFinishedIsNotCancelable(t *testing.T) {
+	manager, _ := newTestManager(time.Hour)
+
+	csv := validCSVHeader + "Siemens,SIE,annual,EBITDA,2023,1000.5,EUR\n"
+	job, err := manager.Submit(context.Background(), []byte(csv), "text/csv", dax.ImportCSVOptions{}, "", dax.TriggerAPI)
+	require.NoError(t, err)
+	waitForStatus(t, manager, job.ID, dax.JobStatusSucceeded)
+
+	err = manager.Stop(job.ID)
+	assert.ErrorIs(t, err, dax.ErrJobNotCancelable)
+}
+
+func TestImportJobManager_Stop_CancelsRunningJob(t *testing.T) {
+	manager, _ := newTestManager(time.Hour)
+
+	var csv strings.Builder
+	csv.WriteString(validCSVHeader)
+	for i := 0; i < 20000; i++ {
+		csv.WriteString("Siemens,SIE,annual,EBITDA,2023,1000.5,EUR\n")
+	}
+
+	job, err := manager.Submit(context.Background(), []byte(csv.String()), "text/csv", dax.ImportCSVOptions{BatchSize: 1}, "", dax.TriggerAPI)
+	require.NoError(t, err)
+
+	require.NoError(t, manager.Stop(job.ID))
+
+	done, err := manager.Get(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, dax.JobStatusStopped, done.Status)
+	assert.NotNil(t, done.FinishedAt)
+
+	// The stopped status must stick even once the worker goroutine
+	// observes the cancellation and returns.
+	time.Sleep(50 * time.Millisecond)
+	done, err = manager.Get(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, dax.JobStatusStopped, done.Status)
+}
+
+func TestImportJobManager_List_NewestFirstWithPagination(t *testing.T) {
+	manager, _ := newTestManager(time.Hour)
+	csv := validCSVHeader + "Siemens,SIE,annual,EBITDA,2023,1000.5,EUR\n"
+
+	var last *dax.ImportJob
+	for i := 0; i < 3; i++ {
+		job, err := manager.Submit(context.Background(), []byte(csv), "text/csv", dax.ImportCSVOptions{}, "", dax.TriggerAPI)
+		require.NoError(t, err)
+		waitForStatus(t, manager, job.ID, dax.JobStatusSucceeded)
+		last = job
+	}
+
+	jobs, pagination, err := manager.List(1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, pagination.TotalCount)
+	assert.Equal(t, 2, pagination.TotalPages)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, last.ID, jobs[0].ID)
+}
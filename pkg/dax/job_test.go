@@ -0,0 +1,101 @@
+package dax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryJobStore_CreateAndGet(t *testing.T) {
+	store := NewInMemoryJobStore()
+
+	job := &ImportJob{ID: "job-1", Status: JobStatusPending}
+	require.NoError(t, store.Create(job))
+
+	got, err := store.Get("job-1")
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusPending, got.Status)
+}
+
+func TestInMemoryJobStore_Get_NotFound(t *testing.T) {
+	store := NewInMemoryJobStore()
+
+	_, err := store.Get("missing")
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestInMemoryJobStore_Update(t *testing.T) {
+	store := NewInMemoryJobStore()
+
+	job := &ImportJob{ID: "job-1", Status: JobStatusPending}
+	require.NoError(t, store.Create(job))
+
+	job.Status = JobStatusRunning
+	require.NoError(t, store.Update(job))
+
+	got, err := store.Get("job-1")
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusRunning, got.Status)
+}
+
+func TestInMemoryJobStore_Update_NotFound(t *testing.T) {
+	store := NewInMemoryJobStore()
+
+	err := store.Update(&ImportJob{ID: "missing"})
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestInMemoryJobStore_FindByIdempotencyKey(t *testing.T) {
+	store := NewInMemoryJobStore()
+
+	job := &ImportJob{ID: "job-1", IdempotencyKey: "key-1", Status: JobStatusPending}
+	require.NoError(t, store.Create(job))
+
+	got, err := store.FindByIdempotencyKey("key-1")
+	require.NoError(t, err)
+	assert.Equal(t, "job-1", got.ID)
+}
+
+func TestInMemoryJobStore_FindByIdempotencyKey_NotFound(t *testing.T) {
+	store := NewInMemoryJobStore()
+
+	_, err := store.FindByIdempotencyKey("missing")
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestInMemoryJobStore_List_NewestFirstWithPagination(t *testing.T) {
+	store := NewInMemoryJobStore()
+
+	require.NoError(t, store.Create(&ImportJob{ID: "job-1", Status: JobStatusPending}))
+	require.NoError(t, store.Create(&ImportJob{ID: "job-2", Status: JobStatusPending}))
+	require.NoError(t, store.Create(&ImportJob{ID: "job-3", Status: JobStatusPending}))
+
+	jobs, total, err := store.List(0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, "job-3", jobs[0].ID)
+	assert.Equal(t, "job-2", jobs[1].ID)
+
+	jobs, total, err = store.List(2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "job-1", jobs[0].ID)
+}
+
+func TestInMemoryJobStore_List_OffsetPastEndReturnsEmpty(t *testing.T) {
+	store := NewInMemoryJobStore()
+	require.NoError(t, store.Create(&ImportJob{ID: "job-1", Status: JobStatusPending}))
+
+	jobs, total, err := store.List(5, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Empty(t, jobs)
+}
+
+func TestImportJob_InProgress(t *testing.T) {
+	job := &ImportJob{RecordsTotal: 10, RecordsSucceeded: 4, RecordsFailed: 1}
+	assert.Equal(t, 5, job.InProgress())
+}
@@ -0,0 +1,783 @@
+package dax
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// ChainMiddleware wraps inner with each of mws in order, so the first
+// middleware in mws is the outermost decorator a caller sees. For example
+//
+//	ChainMiddleware(repo,
+//		func(r Repository) Repository { return LoggingRepository(r, logger) },
+//		func(r Repository) Repository { return MetricsRepository(r, reg) },
+//		func(r Repository) Repository { return TracingRepository(r, tracer) },
+//	)
+//
+// logs, then records metrics for, then traces every call into repo.
+func ChainMiddleware(inner Repository, mws ...func(Repository) Repository) Repository {
+	wrapped := inner
+	for _, mw := range mws {
+		wrapped = mw(wrapped)
+	}
+	return wrapped
+}
+
+// loggingRepository decorates a Repository with structured logging of every
+// call's duration and outcome.
+type loggingRepository struct {
+	inner  Repository
+	logger *zap.Logger
+}
+
+// LoggingRepository wraps inner so every Repository call is logged to
+// logger at Info (success) or Error (failure) with its duration and the
+// call's key arguments (ticker, year, page/limit, row counts, ...).
+func LoggingRepository(inner Repository, logger *zap.Logger) Repository {
+	return &loggingRepository{inner: inner, logger: logger}
+}
+
+// logCall logs op's outcome: fields describes its arguments and result,
+// and err (if non-nil) is logged at Error instead of Info.
+func (r *loggingRepository) logCall(op string, start time.Time, err error, fields ...zap.Field) {
+	fields = append(fields,
+		zap.String("operation", op),
+		zap.Duration("duration", time.Since(start)),
+	)
+	if err != nil {
+		r.logger.Error("dax.repository", append(fields, zap.Error(err))...)
+		return
+	}
+	r.logger.Info("dax.repository", fields...)
+}
+
+func (r *loggingRepository) Create(ctx context.Context, record *DAXRecord) error {
+	start := time.Now()
+	err := r.inner.Create(ctx, record)
+	r.logCall("create", start, err, zap.String("dax.ticker", record.Ticker), zap.Int("dax.year", record.Year))
+	return err
+}
+
+func (r *loggingRepository) Upsert(ctx context.Context, record *DAXRecord) (bool, error) {
+	start := time.Now()
+	created, err := r.inner.Upsert(ctx, record)
+	r.logCall("upsert", start, err, zap.String("dax.ticker", record.Ticker), zap.Int("dax.year", record.Year), zap.Bool("dax.created", created))
+	return created, err
+}
+
+func (r *loggingRepository) BulkUpsert(ctx context.Context, records []DAXRecord) error {
+	start := time.Now()
+	err := r.inner.BulkUpsert(ctx, records)
+	r.logCall("bulk_upsert", start, err, zap.Int("bulk_upsert_rows", len(records)))
+	return err
+}
+
+func (r *loggingRepository) BulkUpsertBatch(ctx context.Context, batch []DAXRecord) error {
+	start := time.Now()
+	err := r.inner.BulkUpsertBatch(ctx, batch)
+	r.logCall("bulk_upsert_batch", start, err, zap.Int("bulk_upsert_rows", len(batch)))
+	return err
+}
+
+func (r *loggingRepository) FindAll(ctx context.Context, page, limit int) ([]DAXRecord, int, error) {
+	start := time.Now()
+	records, total, err := r.inner.FindAll(ctx, page, limit)
+	r.logCall("find_all", start, err,
+		zap.Int("dax.page", page), zap.Int("dax.limit", limit),
+		zap.Int("find_rows_returned", len(records)), zap.Int("find_total_count", total))
+	return records, total, err
+}
+
+func (r *loggingRepository) FindByFilters(ctx context.Context, filters *Filters, page, limit int) ([]DAXRecord, int, error) {
+	start := time.Now()
+	records, total, err := r.inner.FindByFilters(ctx, filters, page, limit)
+	r.logCall("find_by_filters", start, err,
+		zap.Int("dax.page", page), zap.Int("dax.limit", limit),
+		zap.Int("find_rows_returned", len(records)), zap.Int("find_total_count", total))
+	return records, total, err
+}
+
+func (r *loggingRepository) FindByFiltersKeyset(ctx context.Context, filters *Filters, cursor *CursorKey, limit int) ([]DAXRecord, bool, bool, error) {
+	start := time.Now()
+	records, hasNext, hasPrev, err := r.inner.FindByFiltersKeyset(ctx, filters, cursor, limit)
+	r.logCall("find_by_filters_keyset", start, err,
+		zap.Int("dax.limit", limit), zap.Int("find_rows_returned", len(records)),
+		zap.Bool("has_next", hasNext), zap.Bool("has_prev", hasPrev))
+	return records, hasNext, hasPrev, err
+}
+
+func (r *loggingRepository) CountFiltered(ctx context.Context, filters *Filters) (int, error) {
+	start := time.Now()
+	total, err := r.inner.CountFiltered(ctx, filters)
+	r.logCall("count_filtered", start, err, zap.Int("find_total_count", total))
+	return total, err
+}
+
+func (r *loggingRepository) Stream(ctx context.Context, filters *Filters, batchSize int) (<-chan DAXRecord, <-chan error) {
+	start := time.Now()
+	records, errs := r.inner.Stream(ctx, filters, batchSize)
+	return records, logStreamErr(errs, func(err error) {
+		r.logCall("stream", start, err, zap.Int("dax.limit", batchSize))
+	})
+}
+
+func (r *loggingRepository) GetMetrics(ctx context.Context, ticker string) ([]string, error) {
+	start := time.Now()
+	metrics, err := r.inner.GetMetrics(ctx, ticker)
+	r.logCall("get_metrics", start, err, zap.String("dax.ticker", ticker), zap.Int("find_rows_returned", len(metrics)))
+	return metrics, err
+}
+
+func (r *loggingRepository) GetMetricHistory(ctx context.Context, ticker, metric string, fromYear, toYear int) ([]DAXPoint, error) {
+	start := time.Now()
+	points, err := r.inner.GetMetricHistory(ctx, ticker, metric, fromYear, toYear)
+	r.logCall("get_metric_history", start, err, zap.String("dax.ticker", ticker), zap.Int("find_rows_returned", len(points)))
+	return points, err
+}
+
+func (r *loggingRepository) ComputeYoY(ctx context.Context, ticker, metric string, year int) (float64, error) {
+	start := time.Now()
+	value, err := r.inner.ComputeYoY(ctx, ticker, metric, year)
+	r.logCall("compute_yoy", start, err, zap.String("dax.ticker", ticker), zap.Int("dax.year", year))
+	return value, err
+}
+
+func (r *loggingRepository) ComputeCAGR(ctx context.Context, ticker, metric string, startYear, endYear int) (float64, error) {
+	start := time.Now()
+	value, err := r.inner.ComputeCAGR(ctx, ticker, metric, startYear, endYear)
+	r.logCall("compute_cagr", start, err, zap.String("dax.ticker", ticker), zap.Int("dax.year", endYear))
+	return value, err
+}
+
+func (r *loggingRepository) Aggregate(ctx context.Context, ticker, metric string, fromYear, toYear int, op AggOp) (float64, error) {
+	start := time.Now()
+	value, err := r.inner.Aggregate(ctx, ticker, metric, fromYear, toYear, op)
+	r.logCall("aggregate", start, err, zap.String("dax.ticker", ticker), zap.String("agg_op", string(op)))
+	return value, err
+}
+
+func (r *loggingRepository) DeleteAll(ctx context.Context) error {
+	start := time.Now()
+	err := r.inner.DeleteAll(ctx)
+	r.logCall("delete_all", start, err)
+	return err
+}
+
+func (r *loggingRepository) Count(ctx context.Context) (int, error) {
+	start := time.Now()
+	total, err := r.inner.Count(ctx)
+	r.logCall("count", start, err, zap.Int("find_total_count", total))
+	return total, err
+}
+
+func (r *loggingRepository) FindAsOf(ctx context.Context, filters *Filters, asOf time.Time, page, limit int) ([]DAXRecord, int, error) {
+	start := time.Now()
+	records, total, err := r.inner.FindAsOf(ctx, filters, asOf, page, limit)
+	r.logCall("find_as_of", start, err,
+		zap.Time("dax.as_of", asOf), zap.Int("dax.page", page), zap.Int("dax.limit", limit),
+		zap.Int("find_rows_returned", len(records)), zap.Int("find_total_count", total))
+	return records, total, err
+}
+
+func (r *loggingRepository) GetRevisions(ctx context.Context, company, ticker, metric string, year int) ([]DAXRevision, error) {
+	start := time.Now()
+	revisions, err := r.inner.GetRevisions(ctx, company, ticker, metric, year)
+	r.logCall("get_revisions", start, err, zap.String("dax.ticker", ticker), zap.Int("dax.year", year), zap.Int("find_rows_returned", len(revisions)))
+	return revisions, err
+}
+
+func (r *loggingRepository) WithTx(ctx context.Context, fn func(Repository) error) error {
+	start := time.Now()
+	err := r.inner.WithTx(ctx, func(tx Repository) error {
+		return fn(&loggingRepository{inner: tx, logger: r.logger})
+	})
+	r.logCall("with_tx", start, err)
+	return err
+}
+
+// repositoryMetrics holds the Prometheus collectors MetricsRepository
+// records every call against.
+type repositoryMetrics struct {
+	opDuration       *prometheus.HistogramVec
+	opTotal          *prometheus.CounterVec
+	bulkUpsertRows   prometheus.Counter
+	findRowsReturned prometheus.Counter
+	findTotalCount   prometheus.Gauge
+}
+
+// metricsRepository decorates a Repository with Prometheus instrumentation.
+type metricsRepository struct {
+	inner Repository
+	m     *repositoryMetrics
+}
+
+// MetricsRepository wraps inner so every Repository call is recorded to reg:
+// a dax_repository_call_duration_seconds histogram and
+// dax_repository_calls_total counter, both labeled by operation and status
+// ("ok"/"error"), plus the row-count gauges/counters
+// dax_repository_bulk_upsert_rows_total, dax_repository_find_rows_returned_total
+// and dax_repository_find_total_count the request body asks for.
+func MetricsRepository(inner Repository, reg prometheus.Registerer) Repository {
+	factory := promauto.With(reg)
+	return &metricsRepository{
+		inner: inner,
+		m: &repositoryMetrics{
+			opDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+				Name: "dax_repository_call_duration_seconds",
+				Help: "Duration of Repository calls, labeled by operation and outcome.",
+			}, []string{"operation", "status"}),
+			opTotal: factory.NewCounterVec(prometheus.CounterOpts{
+				Name: "dax_repository_calls_total",
+				Help: "Total number of Repository calls, labeled by operation and outcome.",
+			}, []string{"operation", "status"}),
+			bulkUpsertRows: factory.NewCounter(prometheus.CounterOpts{
+				Name: "dax_repository_bulk_upsert_rows_total",
+				Help: "Total number of records passed to BulkUpsert/BulkUpsertBatch.",
+			}),
+			findRowsReturned: factory.NewCounter(prometheus.CounterOpts{
+				Name: "dax_repository_find_rows_returned_total",
+				Help: "Total number of records returned by Find/Stream calls.",
+			}),
+			findTotalCount: factory.NewGauge(prometheus.GaugeOpts{
+				Name: "dax_repository_find_total_count",
+				Help: "Total count reported by the most recent paginated Find/CountFiltered call.",
+			}),
+		},
+	}
+}
+
+// observe records op's duration and success/error outcome.
+func (m *repositoryMetrics) observe(op string, start time.Time, err error) {
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+	m.opDuration.WithLabelValues(op, status).Observe(time.Since(start).Seconds())
+	m.opTotal.WithLabelValues(op, status).Inc()
+}
+
+func (r *metricsRepository) Create(ctx context.Context, record *DAXRecord) error {
+	start := time.Now()
+	err := r.inner.Create(ctx, record)
+	r.m.observe("create", start, err)
+	return err
+}
+
+func (r *metricsRepository) Upsert(ctx context.Context, record *DAXRecord) (bool, error) {
+	start := time.Now()
+	created, err := r.inner.Upsert(ctx, record)
+	r.m.observe("upsert", start, err)
+	return created, err
+}
+
+func (r *metricsRepository) BulkUpsert(ctx context.Context, records []DAXRecord) error {
+	start := time.Now()
+	err := r.inner.BulkUpsert(ctx, records)
+	r.m.observe("bulk_upsert", start, err)
+	r.m.bulkUpsertRows.Add(float64(len(records)))
+	return err
+}
+
+func (r *metricsRepository) BulkUpsertBatch(ctx context.Context, batch []DAXRecord) error {
+	start := time.Now()
+	err := r.inner.BulkUpsertBatch(ctx, batch)
+	r.m.observe("bulk_upsert_batch", start, err)
+	r.m.bulkUpsertRows.Add(float64(len(batch)))
+	return err
+}
+
+func (r *metricsRepository) FindAll(ctx context.Context, page, limit int) ([]DAXRecord, int, error) {
+	start := time.Now()
+	records, total, err := r.inner.FindAll(ctx, page, limit)
+	r.m.observe("find_all", start, err)
+	r.m.findRowsReturned.Add(float64(len(records)))
+	r.m.findTotalCount.Set(float64(total))
+	return records, total, err
+}
+
+func (r *metricsRepository) FindByFilters(ctx context.Context, filters *Filters, page, limit int) ([]DAXRecord, int, error) {
+	start := time.Now()
+	records, total, err := r.inner.FindByFilters(ctx, filters, page, limit)
+	r.m.observe("find_by_filters", start, err)
+	r.m.findRowsReturned.Add(float64(len(records)))
+	r.m.findTotalCount.Set(float64(total))
+	return records, total, err
+}
+
+func (r *metricsRepository) FindByFiltersKeyset(ctx context.Context, filters *Filters, cursor *CursorKey, limit int) ([]DAXRecord, bool, bool, error) {
+	start := time.Now()
+	records, hasNext, hasPrev, err := r.inner.FindByFiltersKeyset(ctx, filters, cursor, limit)
+	r.m.observe("find_by_filters_keyset", start, err)
+	r.m.findRowsReturned.Add(float64(len(records)))
+	return records, hasNext, hasPrev, err
+}
+
+func (r *metricsRepository) CountFiltered(ctx context.Context, filters *Filters) (int, error) {
+	start := time.Now()
+	total, err := r.inner.CountFiltered(ctx, filters)
+	r.m.observe("count_filtered", start, err)
+	r.m.findTotalCount.Set(float64(total))
+	return total, err
+}
+
+func (r *metricsRepository) Stream(ctx context.Context, filters *Filters, batchSize int) (<-chan DAXRecord, <-chan error) {
+	start := time.Now()
+	inner, innerErrs := r.inner.Stream(ctx, filters, batchSize)
+
+	records := make(chan DAXRecord)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(records)
+		defer close(errs)
+
+		var n int
+		for record := range inner {
+			records <- record
+			n++
+		}
+		r.m.findRowsReturned.Add(float64(n))
+
+		err := <-innerErrs
+		r.m.observe("stream", start, err)
+		if err != nil {
+			errs <- err
+		}
+	}()
+	return records, errs
+}
+
+func (r *metricsRepository) GetMetrics(ctx context.Context, ticker string) ([]string, error) {
+	start := time.Now()
+	metrics, err := r.inner.GetMetrics(ctx, ticker)
+	r.m.observe("get_metrics", start, err)
+	return metrics, err
+}
+
+func (r *metricsRepository) GetMetricHistory(ctx context.Context, ticker, metric string, fromYear, toYear int) ([]DAXPoint, error) {
+	start := time.Now()
+	points, err := r.inner.GetMetricHistory(ctx, ticker, metric, fromYear, toYear)
+	r.m.observe("get_metric_history", start, err)
+	return points, err
+}
+
+func (r *metricsRepository) ComputeYoY(ctx context.Context, ticker, metric string, year int) (float64, error) {
+	start := time.Now()
+	value, err := r.inner.ComputeYoY(ctx, ticker, metric, year)
+	r.m.observe("compute_yoy", start, err)
+	return value, err
+}
+
+func (r *metricsRepository) ComputeCAGR(ctx context.Context, ticker, metric string, startYear, endYear int) (float64, error) {
+	start := time.Now()
+	value, err := r.inner.ComputeCAGR(ctx, ticker, metric, startYear, endYear)
+	r.m.observe("compute_cagr", start, err)
+	return value, err
+}
+
+func (r *metricsRepository) Aggregate(ctx context.Context, ticker, metric string, fromYear, toYear int, op AggOp) (float64, error) {
+	start := time.Now()
+	value, err := r.inner.Aggregate(ctx, ticker, metric, fromYear, toYear, op)
+	r.m.observe("aggregate", start, err)
+	return value, err
+}
+
+func (r *metricsRepository) DeleteAll(ctx context.Context) error {
+	start := time.Now()
+	err := r.inner.DeleteAll(ctx)
+	r.m.observe("delete_all", start, err)
+	return err
+}
+
+func (r *metricsRepository) Count(ctx context.Context) (int, error) {
+	start := time.Now()
+	total, err := r.inner.Count(ctx)
+	r.m.observe("count", start, err)
+	r.m.findTotalCount.Set(float64(total))
+	return total, err
+}
+
+func (r *metricsRepository) FindAsOf(ctx context.Context, filters *Filters, asOf time.Time, page, limit int) ([]DAXRecord, int, error) {
+	start := time.Now()
+	records, total, err := r.inner.FindAsOf(ctx, filters, asOf, page, limit)
+	r.m.observe("find_as_of", start, err)
+	r.m.findRowsReturned.Add(float64(len(records)))
+	r.m.findTotalCount.Set(float64(total))
+	return records, total, err
+}
+
+func (r *metricsRepository) GetRevisions(ctx context.Context, company, ticker, metric string, year int) ([]DAXRevision, error) {
+	start := time.Now()
+	revisions, err := r.inner.GetRevisions(ctx, company, ticker, metric, year)
+	r.m.observe("get_revisions", start, err)
+	r.m.findRowsReturned.Add(float64(len(revisions)))
+	return revisions, err
+}
+
+func (r *metricsRepository) WithTx(ctx context.Context, fn func(Repository) error) error {
+	start := time.Now()
+	err := r.inner.WithTx(ctx, func(tx Repository) error {
+		return fn(&metricsRepository{inner: tx, m: r.m})
+	})
+	r.m.observe("with_tx", start, err)
+	return err
+}
+
+// tracingRepository decorates a Repository with OpenTelemetry spans.
+type tracingRepository struct {
+	inner  Repository
+	tracer trace.Tracer
+}
+
+// TracingRepository wraps inner so every Repository call is recorded as a
+// "dax.repository.<operation>" span, linked to ctx's span, with
+// dax.ticker/dax.year/dax.page/dax.limit attributes (whichever apply to
+// that call) and, on error, a recorded exception and an Error status.
+func TracingRepository(inner Repository, tracer trace.Tracer) Repository {
+	return &tracingRepository{inner: inner, tracer: tracer}
+}
+
+// startSpan starts a "dax.repository.<op>" span under ctx with attrs.
+func (r *tracingRepository) startSpan(ctx context.Context, op string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return r.tracer.Start(ctx, "dax.repository."+op, trace.WithAttributes(attrs...))
+}
+
+// endSpan records err (if any) on span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (r *tracingRepository) Create(ctx context.Context, record *DAXRecord) error {
+	ctx, span := r.startSpan(ctx, "create",
+		attribute.String("dax.ticker", record.Ticker), attribute.Int("dax.year", record.Year))
+	err := r.inner.Create(ctx, record)
+	endSpan(span, err)
+	return err
+}
+
+func (r *tracingRepository) Upsert(ctx context.Context, record *DAXRecord) (bool, error) {
+	ctx, span := r.startSpan(ctx, "upsert",
+		attribute.String("dax.ticker", record.Ticker), attribute.Int("dax.year", record.Year))
+	created, err := r.inner.Upsert(ctx, record)
+	endSpan(span, err)
+	return created, err
+}
+
+func (r *tracingRepository) BulkUpsert(ctx context.Context, records []DAXRecord) error {
+	ctx, span := r.startSpan(ctx, "bulk_upsert", attribute.Int("bulk_upsert_rows", len(records)))
+	err := r.inner.BulkUpsert(ctx, records)
+	endSpan(span, err)
+	return err
+}
+
+func (r *tracingRepository) BulkUpsertBatch(ctx context.Context, batch []DAXRecord) error {
+	ctx, span := r.startSpan(ctx, "bulk_upsert_batch", attribute.Int("bulk_upsert_rows", len(batch)))
+	err := r.inner.BulkUpsertBatch(ctx, batch)
+	endSpan(span, err)
+	return err
+}
+
+func (r *tracingRepository) FindAll(ctx context.Context, page, limit int) ([]DAXRecord, int, error) {
+	ctx, span := r.startSpan(ctx, "find_all", attribute.Int("dax.page", page), attribute.Int("dax.limit", limit))
+	records, total, err := r.inner.FindAll(ctx, page, limit)
+	endSpan(span, err)
+	return records, total, err
+}
+
+func (r *tracingRepository) FindByFilters(ctx context.Context, filters *Filters, page, limit int) ([]DAXRecord, int, error) {
+	ctx, span := r.startSpan(ctx, "find_by_filters", attribute.Int("dax.page", page), attribute.Int("dax.limit", limit))
+	records, total, err := r.inner.FindByFilters(ctx, filters, page, limit)
+	endSpan(span, err)
+	return records, total, err
+}
+
+func (r *tracingRepository) FindByFiltersKeyset(ctx context.Context, filters *Filters, cursor *CursorKey, limit int) ([]DAXRecord, bool, bool, error) {
+	ctx, span := r.startSpan(ctx, "find_by_filters_keyset", attribute.Int("dax.limit", limit))
+	records, hasNext, hasPrev, err := r.inner.FindByFiltersKeyset(ctx, filters, cursor, limit)
+	endSpan(span, err)
+	return records, hasNext, hasPrev, err
+}
+
+func (r *tracingRepository) CountFiltered(ctx context.Context, filters *Filters) (int, error) {
+	ctx, span := r.startSpan(ctx, "count_filtered")
+	total, err := r.inner.CountFiltered(ctx, filters)
+	endSpan(span, err)
+	return total, err
+}
+
+func (r *tracingRepository) Stream(ctx context.Context, filters *Filters, batchSize int) (<-chan DAXRecord, <-chan error) {
+	ctx, span := r.startSpan(ctx, "stream", attribute.Int("dax.limit", batchSize))
+	inner, innerErrs := r.inner.Stream(ctx, filters, batchSize)
+
+	records := make(chan DAXRecord)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(records)
+		defer close(errs)
+		defer span.End()
+
+		for record := range inner {
+			records <- record
+		}
+
+		err := <-innerErrs
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			errs <- err
+		}
+	}()
+	return records, errs
+}
+
+func (r *tracingRepository) GetMetrics(ctx context.Context, ticker string) ([]string, error) {
+	ctx, span := r.startSpan(ctx, "get_metrics", attribute.String("dax.ticker", ticker))
+	metrics, err := r.inner.GetMetrics(ctx, ticker)
+	endSpan(span, err)
+	return metrics, err
+}
+
+func (r *tracingRepository) GetMetricHistory(ctx context.Context, ticker, metric string, fromYear, toYear int) ([]DAXPoint, error) {
+	ctx, span := r.startSpan(ctx, "get_metric_history", attribute.String("dax.ticker", ticker), attribute.Int("dax.year", toYear))
+	points, err := r.inner.GetMetricHistory(ctx, ticker, metric, fromYear, toYear)
+	endSpan(span, err)
+	return points, err
+}
+
+func (r *tracingRepository) ComputeYoY(ctx context.Context, ticker, metric string, year int) (float64, error) {
+	ctx, span := r.startSpan(ctx, "compute_yoy", attribute.String("dax.ticker", ticker), attribute.Int("dax.year", year))
+	value, err := r.inner.ComputeYoY(ctx, ticker, metric, year)
+	endSpan(span, err)
+	return value, err
+}
+
+func (r *tracingRepository) ComputeCAGR(ctx context.Context, ticker, metric string, startYear, endYear int) (float64, error) {
+	ctx, span := r.startSpan(ctx, "compute_cagr", attribute.String("dax.ticker", ticker), attribute.Int("dax.year", endYear))
+	value, err := r.inner.ComputeCAGR(ctx, ticker, metric, startYear, endYear)
+	endSpan(span, err)
+	return value, err
+}
+
+func (r *tracingRepository) Aggregate(ctx context.Context, ticker, metric string, fromYear, toYear int, op AggOp) (float64, error) {
+	ctx, span := r.startSpan(ctx, "aggregate", attribute.String("dax.ticker", ticker), attribute.Int("dax.year", toYear))
+	value, err := r.inner.Aggregate(ctx, ticker, metric, fromYear, toYear, op)
+	endSpan(span, err)
+	return value, err
+}
+
+func (r *tracingRepository) DeleteAll(ctx context.Context) error {
+	ctx, span := r.startSpan(ctx, "delete_all")
+	err := r.inner.DeleteAll(ctx)
+	endSpan(span, err)
+	return err
+}
+
+func (r *tracingRepository) Count(ctx context.Context) (int, error) {
+	ctx, span := r.startSpan(ctx, "count")
+	total, err := r.inner.Count(ctx)
+	endSpan(span, err)
+	return total, err
+}
+
+func (r *tracingRepository) FindAsOf(ctx context.Context, filters *Filters, asOf time.Time, page, limit int) ([]DAXRecord, int, error) {
+	ctx, span := r.startSpan(ctx, "find_as_of", attribute.Int("dax.page", page), attribute.Int("dax.limit", limit))
+	records, total, err := r.inner.FindAsOf(ctx, filters, asOf, page, limit)
+	endSpan(span, err)
+	return records, total, err
+}
+
+func (r *tracingRepository) GetRevisions(ctx context.Context, company, ticker, metric string, year int) ([]DAXRevision, error) {
+	ctx, span := r.startSpan(ctx, "get_revisions", attribute.String("dax.ticker", ticker), attribute.Int("dax.year", year))
+	revisions, err := r.inner.GetRevisions(ctx, company, ticker, metric, year)
+	endSpan(span, err)
+	return revisions, err
+}
+
+func (r *tracingRepository) WithTx(ctx context.Context, fn func(Repository) error) error {
+	ctx, span := r.startSpan(ctx, "with_tx")
+	err := r.inner.WithTx(ctx, func(tx Repository) error {
+		return fn(&tracingRepository{inner: tx, tracer: r.tracer})
+	})
+	endSpan(span, err)
+	return err
+}
+
+// logStreamErr returns a channel that carries Stream's single error (if
+// any), after passing it to onErr; used by loggingRepository.Stream so the
+// log line can be emitted once the stream finishes rather than before it
+// starts.
+func logStreamErr(errs <-chan error, onErr func(error)) <-chan error {
+	out := make(chan error, 1)
+	go func() {
+		defer close(out)
+		err := <-errs
+		onErr(err)
+		if err != nil {
+			out <- err
+		}
+	}()
+	return out
+}
+
+// timeoutRepository decorates a Repository so every call's ctx is bounded by
+// timeout, turning a hung query into a prompt context.DeadlineExceeded
+// instead of blocking its caller indefinitely.
+type timeoutRepository struct {
+	inner   Repository
+	timeout time.Duration
+}
+
+// TimeoutRepository wraps inner so every call runs under a
+// context.WithTimeout(ctx, timeout) derived from the caller's ctx, the
+// shorter of the two deadlines winning. See config.DatabaseConfig.QueryTimeout
+// for how timeout is normally configured.
+func TimeoutRepository(inner Repository, timeout time.Duration) Repository {
+	return &timeoutRepository{inner: inner, timeout: timeout}
+}
+
+func (r *timeoutRepository) Create(ctx context.Context, record *DAXRecord) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.inner.Create(ctx, record)
+}
+
+func (r *timeoutRepository) Upsert(ctx context.Context, record *DAXRecord) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.inner.Upsert(ctx, record)
+}
+
+func (r *timeoutRepository) BulkUpsert(ctx context.Context, records []DAXRecord) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.inner.BulkUpsert(ctx, records)
+}
+
+func (r *timeoutRepository) BulkUpsertBatch(ctx context.Context, batch []DAXRecord) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.inner.BulkUpsertBatch(ctx, batch)
+}
+
+func (r *timeoutRepository) FindAll(ctx context.Context, page, limit int) ([]DAXRecord, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.inner.FindAll(ctx, page, limit)
+}
+
+func (r *timeoutRepository) FindByFilters(ctx context.Context, filters *Filters, page, limit int) ([]DAXRecord, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.inner.FindByFilters(ctx, filters, page, limit)
+}
+
+func (r *timeoutRepository) FindByFiltersKeyset(ctx context.Context, filters *Filters, cursor *CursorKey, limit int) ([]DAXRecord, bool, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.inner.FindByFiltersKeyset(ctx, filters, cursor, limit)
+}
+
+func (r *timeoutRepository) CountFiltered(ctx context.Context, filters *Filters) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.inner.CountFiltered(ctx, filters)
+}
+
+// Stream's timeout can't simply be deferred: the query runs in the
+// background goroutine r.inner.Stream starts, which outlives this method
+// returning. cancel is instead called once that goroutine's error channel
+// closes, via cancelOnStreamDone.
+func (r *timeoutRepository) Stream(ctx context.Context, filters *Filters, batchSize int) (<-chan DAXRecord, <-chan error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	records, errs := r.inner.Stream(ctx, filters, batchSize)
+	return records, cancelOnStreamDone(errs, cancel)
+}
+
+func (r *timeoutRepository) GetMetrics(ctx context.Context, ticker string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.inner.GetMetrics(ctx, ticker)
+}
+
+func (r *timeoutRepository) GetMetricHistory(ctx context.Context, ticker, metric string, fromYear, toYear int) ([]DAXPoint, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.inner.GetMetricHistory(ctx, ticker, metric, fromYear, toYear)
+}
+
+func (r *timeoutRepository) ComputeYoY(ctx context.Context, ticker, metric string, year int) (float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.inner.ComputeYoY(ctx, ticker, metric, year)
+}
+
+func (r *timeoutRepository) ComputeCAGR(ctx context.Context, ticker, metric string, startYear, endYear int) (float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.inner.ComputeCAGR(ctx, ticker, metric, startYear, endYear)
+}
+
+func (r *timeoutRepository) Aggregate(ctx context.Context, ticker, metric string, fromYear, toYear int, op AggOp) (float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.inner.Aggregate(ctx, ticker, metric, fromYear, toYear, op)
+}
+
+func (r *timeoutRepository) DeleteAll(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.inner.DeleteAll(ctx)
+}
+
+func (r *timeoutRepository) Count(ctx context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.inner.Count(ctx)
+}
+
+func (r *timeoutRepository) FindAsOf(ctx context.Context, filters *Filters, asOf time.Time, page, limit int) ([]DAXRecord, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.inner.FindAsOf(ctx, filters, asOf, page, limit)
+}
+
+func (r *timeoutRepository) GetRevisions(ctx context.Context, company, ticker, metric string, year int) ([]DAXRevision, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.inner.GetRevisions(ctx, company, ticker, metric, year)
+}
+
+func (r *timeoutRepository) WithTx(ctx context.Context, fn func(Repository) error) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.inner.WithTx(ctx, func(tx Repository) error {
+		return fn(&timeoutRepository{inner: tx, timeout: r.timeout})
+	})
+}
+
+// cancelOnStreamDone returns a channel that carries Stream's single error
+// (if any), calling cancel once that error is known rather than as soon as
+// the wrapping method returns - mirrors logStreamErr's deferred-callback
+// shape for the same reason.
+func cancelOnStreamDone(errs <-chan error, cancel context.CancelFunc) <-chan error {
+	out := make(chan error, 1)
+	go func() {
+		defer close(out)
+		defer cancel()
+		err := <-errs
+		if err != nil {
+			out <- err
+		}
+	}()
+	return out
+}
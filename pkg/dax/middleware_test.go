@@ -0,0 +1,74 @@
+package dax
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupTimeoutMockRepo(t *testing.T) (Repository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	require.NoError(t, err)
+
+	dialector := postgres.New(postgres.Config{Conn: db, DriverName: "postgres"})
+	gormDB, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM information_schema\.tables WHERE`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	repo, err := NewPostgresRepository(gormDB)
+	require.NoError(t, err)
+
+	return repo, mock
+}
+
+func TestTimeoutRepository_SlowQueryAborted(t *testing.T) {
+	repo, mock := setupTimeoutMockRepo(t)
+	timed := TimeoutRepository(repo, 10*time.Millisecond)
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "dax"`).
+		WillDelayFor(50 * time.Millisecond).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	_, err := timed.Count(context.Background())
+
+	require.Error(t, err)
+}
+
+func TestTimeoutRepository_CanceledContext(t *testing.T) {
+	repo, mock := setupTimeoutMockRepo(t)
+	timed := TimeoutRepository(repo, time.Second)
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "dax"`).
+		WillDelayFor(50 * time.Millisecond).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := timed.Count(ctx)
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestTimeoutRepository_WithinDeadline(t *testing.T) {
+	repo, mock := setupTimeoutMockRepo(t)
+	timed := TimeoutRepository(repo, time.Second)
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "dax"`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	total, err := timed.Count(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+}
@@ -25,6 +25,27 @@ func (DAXRecord) TableName() string {
 	return "dax"
 }
 
+// DAXRevision is a superseded version of a DAXRecord, archived to the
+// dax_history table (see AutoMigrate) whenever BulkUpsert/BulkUpsertBatch
+// overwrites an existing row. [ValidFrom, ValidTo) is the half-open window
+// during which the embedded DAXRecord was the live value for its
+// (company, ticker, metric, year) key; Repository.FindAsOf reconstructs
+// point-in-time state from these windows.
+type DAXRevision struct {
+	DAXRecord
+	VersionID uuid.UUID  `json:"version_id" gorm:"type:uuid;primary_key"`
+	ValidFrom time.Time  `json:"valid_from" gorm:"type:timestamptz;not null"`
+	ValidTo   *time.Time `json:"valid_to" gorm:"type:timestamptz"`
+	// Operation is the write that superseded this version: "insert",
+	// "update" or "delete".
+	Operation string `json:"operation" gorm:"type:varchar(10);not null"`
+}
+
+// TableName sets the table name for GORM
+func (DAXRevision) TableName() string {
+	return "dax_history"
+}
+
 // CSVRow represents a row from the CSV import file
 type CSVRow struct {
 	Company    string  `json:"company"`
@@ -36,10 +57,76 @@ type CSVRow struct {
 	Currency   string  `json:"currency"`
 }
 
+// ImportMode selects how Service.importWithImporter persists parsed records
+// when a conflicting (company, ticker, metric, year) row already exists.
+type ImportMode string
+
+const (
+	// ImportModeReplace flushes records through Repository.BulkUpsertBatch,
+	// overwriting any conflicting row in place. This is the default and
+	// matches ImportCSV's historical behavior.
+	ImportModeReplace ImportMode = "replace"
+	// ImportModeUpsert persists records one at a time via Repository.Upsert,
+	// reporting created/updated per row instead of batching. Functionally
+	// equivalent to ImportModeReplace for the final row state, but lets a
+	// caller re-run a corrected CSV without batching side effects.
+	ImportModeUpsert ImportMode = "upsert"
+	// ImportModeInsert persists records one at a time via Repository.Create,
+	// so a row that already exists at the same natural key fails the row
+	// instead of overwriting it.
+	ImportModeInsert ImportMode = "insert"
+)
+
+// ImportCSVOptions controls how Service.ImportCSV streams and persists a
+// CSV upload.
+type ImportCSVOptions struct {
+	// BatchSize is how many parsed records accumulate before a
+	// Repository.BulkUpsertBatch flush. Defaults to 1000 when <= 0. Only
+	// consulted in ImportModeReplace.
+	BatchSize int
+	// Mode selects insert/upsert/replace persistence semantics. Defaults to
+	// ImportModeReplace when empty.
+	Mode ImportMode
+	// ContinueOnError collects bad rows into ImportResponse.Errors instead
+	// of aborting the import on the first one.
+	ContinueOnError bool
+	// DryRun parses and validates records without persisting them, and
+	// reports ImportResponse.WouldInsert/WouldUpdate/WouldSkip in place of
+	// RecordsImported.
+	DryRun bool
+	// OnParsed, when set, is called once with the total number of records
+	// (valid and invalid) found once parsing completes, before any
+	// persistence begins. Used by ImportJobManager to populate
+	// ImportJob.RecordsTotal.
+	OnParsed func(total int)
+	// OnProgress, when set, is called after each successful batch flush
+	// with the cumulative number of records imported so far. Used by
+	// ImportJobManager to publish ImportJob.RecordsProcessed.
+	OnProgress func(recordsProcessed int)
+}
+
 // ImportResponse represents the response from an import operation
 type ImportResponse struct {
-	RecordsImported int    `json:"records_imported"`
-	Message         string `json:"message"`
+	RecordsImported int `json:"records_imported"`
+	RecordsFailed   int `json:"records_failed,omitempty"`
+	RecordsSkipped  int `json:"records_skipped,omitempty"`
+	// WouldInsert, WouldUpdate and WouldSkip are only populated when
+	// ImportCSVOptions.DryRun is set, classifying each valid row by what it
+	// would have done to the store without actually persisting anything.
+	// WouldSkip counts rows sharing a natural key with an earlier row in the
+	// same upload, since only the last one would end up persisted.
+	WouldInsert int        `json:"would_insert,omitempty"`
+	WouldUpdate int        `json:"would_update,omitempty"`
+	WouldSkip   int        `json:"would_skip,omitempty"`
+	Errors      []RowError `json:"errors,omitempty"`
+	Message     string     `json:"message"`
+}
+
+// RowError describes a single CSV row that failed to parse or validate.
+type RowError struct {
+	RowNum  int    `json:"row_num"`
+	RawLine string `json:"raw_line"`
+	Err     string `json:"error"`
 }
 
 // PaginatedResponse represents a paginated list of DAX records
@@ -0,0 +1,578 @@
+// Package postgres implements dax.Repository against PostgreSQL via sqlx,
+// as an alternative to the GORM-backed dax.PostgresRepository for
+// deployments that prefer hand-written SQL over an ORM. It targets the
+// same dax/dax_history schema dax.AutoMigrate creates.
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/dax"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// queryer is the subset of *sqlx.DB and *sqlx.Tx that Repository needs, so
+// the same query methods work whether Repository is bound to the pool
+// directly or to a transaction started by WithTx.
+type queryer interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+// Repository implements dax.Repository against PostgreSQL using sqlx.
+type Repository struct {
+	db queryer
+}
+
+// NewRepository creates a Repository backed by db.
+func NewRepository(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create inserts a single DAX record.
+func (r *Repository) Create(ctx context.Context, record *dax.DAXRecord) error {
+	if record.ID == uuid.Nil {
+		record.ID = uuid.New()
+	}
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO dax (id, company, ticker, report_type, metric, year, value, currency, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
+	`, record.ID, record.Company, record.Ticker, record.ReportType, record.Metric, record.Year, record.Value, record.Currency)
+	return err
+}
+
+// Upsert implements the dax.Repository.Upsert contract, archiving the row
+// it replaces into dax_history the same way BulkUpsertBatch does. The
+// `xmax = 0` trick in RETURNING reports whether the row the statement just
+// wrote was a fresh insert (xmax unset) or an ON CONFLICT update (xmax set
+// to the updating transaction's id).
+func (r *Repository) Upsert(ctx context.Context, record *dax.DAXRecord) (created bool, err error) {
+	if record.ID == uuid.Nil {
+		record.ID = uuid.New()
+	}
+
+	err = r.WithTx(ctx, func(txRepo dax.Repository) error {
+		tx := txRepo.(*Repository).db
+
+		if err := archiveSupersededRows(ctx, tx, []dax.DAXRecord{*record}); err != nil {
+			return fmt.Errorf("failed to archive prior dax row to history: %w", err)
+		}
+
+		return tx.GetContext(ctx, &created, `
+			INSERT INTO dax (id, company, ticker, report_type, metric, year, value, currency, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
+			ON CONFLICT (company, ticker, metric, year)
+			DO UPDATE SET report_type = EXCLUDED.report_type, value = EXCLUDED.value,
+				currency = EXCLUDED.currency, updated_at = NOW()
+			RETURNING (xmax = 0) AS inserted
+		`, record.ID, record.Company, record.Ticker, record.ReportType, record.Metric, record.Year, record.Value, record.Currency)
+	})
+
+	return created, err
+}
+
+// BulkUpsert performs bulk insert with upsert on conflict.
+func (r *Repository) BulkUpsert(ctx context.Context, records []dax.DAXRecord) error {
+	return r.BulkUpsertBatch(ctx, records)
+}
+
+// BulkUpsertBatch performs bulk insert with upsert on conflict. Before the
+// upsert, any row the batch is about to overwrite is archived into
+// dax_history so dax.Repository.FindAsOf/GetRevisions can still recover it;
+// the archival and the upsert run in the same transaction so a failure of
+// either leaves no partial history behind.
+func (r *Repository) BulkUpsertBatch(ctx context.Context, records []dax.DAXRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	for i := range records {
+		if records[i].ID == uuid.Nil {
+			records[i].ID = uuid.New()
+		}
+	}
+
+	return r.WithTx(ctx, func(txRepo dax.Repository) error {
+		tx := txRepo.(*Repository).db
+
+		if err := archiveSupersededRows(ctx, tx, records); err != nil {
+			return fmt.Errorf("failed to archive prior dax rows to history: %w", err)
+		}
+
+		for _, record := range records {
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO dax (id, company, ticker, report_type, metric, year, value, currency, created_at, updated_at)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
+				ON CONFLICT (company, ticker, metric, year)
+				DO UPDATE SET report_type = EXCLUDED.report_type, value = EXCLUDED.value,
+					currency = EXCLUDED.currency, updated_at = NOW()
+			`, record.ID, record.Company, record.Ticker, record.ReportType, record.Metric, record.Year, record.Value, record.Currency)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// archiveSupersededRows copies every dax row matching records' keys into
+// dax_history as an "update" revision, valid from its last update until
+// now, before the caller overwrites it. Rows that don't yet exist in dax
+// (first-time inserts) are silently skipped, since there is no prior
+// version to archive.
+func archiveSupersededRows(ctx context.Context, tx queryer, records []dax.DAXRecord) error {
+	placeholders := make([]string, len(records))
+	args := make([]interface{}, 0, len(records)*4)
+	for i, record := range records {
+		base := i * 4
+		placeholders[i] = fmt.Sprintf("($%d, $%d, $%d, $%d)", base+1, base+2, base+3, base+4)
+		args = append(args, record.Company, record.Ticker, record.Metric, record.Year)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO dax_history (
+			id, company, ticker, report_type, metric, year, value, currency,
+			created_at, updated_at, version_id, valid_from, valid_to, operation
+		)
+		SELECT
+			id, company, ticker, report_type, metric, year, value, currency,
+			created_at, updated_at, gen_random_uuid(), updated_at, NOW(), 'update'
+		FROM dax
+		WHERE (company, ticker, metric, year) IN (%s)
+	`, strings.Join(placeholders, ", "))
+
+	_, err := tx.ExecContext(ctx, query, args...)
+	return err
+}
+
+// FindAll retrieves all DAX records with pagination.
+func (r *Repository) FindAll(ctx context.Context, page, limit int) ([]dax.DAXRecord, int, error) {
+	var total int
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM dax"); err != nil {
+		return nil, 0, err
+	}
+
+	var records []dax.DAXRecord
+	offset := (page - 1) * limit
+	err := r.db.SelectContext(ctx, &records, `
+		SELECT id, company, ticker, report_type, metric, year, value, currency, created_at, updated_at
+		FROM dax ORDER BY year DESC, ticker ASC, metric ASC LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return records, total, nil
+}
+
+// FindByFilters retrieves DAX records matching filters, with pagination. A
+// nil filters matches every record.
+func (r *Repository) FindByFilters(ctx context.Context, filters *dax.Filters, page, limit int) ([]dax.DAXRecord, int, error) {
+	where, args := whereClause(filters)
+
+	var total int
+	countQuery := "SELECT COUNT(*) FROM dax" + where
+	if err := r.db.GetContext(ctx, &total, rebind(countQuery), args...); err != nil {
+		return nil, 0, err
+	}
+
+	selectQuery := fmt.Sprintf(`
+		SELECT id, company, ticker, report_type, metric, year, value, currency, created_at, updated_at
+		FROM dax%s ORDER BY %s LIMIT %d OFFSET %d
+	`, where, orderByClause(filters), limit, (page-1)*limit)
+
+	var records []dax.DAXRecord
+	if err := r.db.SelectContext(ctx, &records, rebind(selectQuery), args...); err != nil {
+		return nil, 0, err
+	}
+
+	return records, total, nil
+}
+
+// FindByFiltersKeyset implements the dax.Repository.FindByFiltersKeyset
+// keyset-pagination contract described on the interface, fixing the sort
+// order to (ticker, year, id) regardless of filters.SortBy so a cursor
+// built from one page always resolves against the next.
+func (r *Repository) FindByFiltersKeyset(ctx context.Context, filters *dax.Filters, cursor *dax.CursorKey, limit int) ([]dax.DAXRecord, bool, bool, error) {
+	where, args := whereClause(filters)
+
+	order := "ticker ASC, year ASC, id ASC"
+	if cursor != nil {
+		op := ">"
+		if cursor.Before {
+			op = "<"
+			order = "ticker DESC, year DESC, id DESC"
+		}
+		if where == "" {
+			where = " WHERE 1=1"
+		}
+		where += fmt.Sprintf(" AND (ticker, year, id) %s (?, ?, ?)", op)
+		args = append(args, cursor.Ticker, cursor.Year, cursor.ID)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, company, ticker, report_type, metric, year, value, currency, created_at, updated_at
+		FROM dax%s ORDER BY %s LIMIT %d
+	`, where, order, limit+1)
+
+	var records []dax.DAXRecord
+	if err := r.db.SelectContext(ctx, &records, rebind(query), args...); err != nil {
+		return nil, false, false, err
+	}
+
+	hasMore := len(records) > limit
+	if hasMore {
+		records = records[:limit]
+	}
+
+	if cursor != nil && cursor.Before {
+		for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+			records[i], records[j] = records[j], records[i]
+		}
+		return records, true, hasMore, nil
+	}
+
+	return records, hasMore, cursor != nil, nil
+}
+
+// CountFiltered returns how many records match filters.
+func (r *Repository) CountFiltered(ctx context.Context, filters *dax.Filters) (int, error) {
+	where, args := whereClause(filters)
+
+	var count int
+	err := r.db.GetContext(ctx, &count, rebind("SELECT COUNT(*) FROM dax"+where), args...)
+	return count, err
+}
+
+// Stream yields every record matching filters over the returned channel by
+// paging through FindByFiltersKeyset batchSize records at a time, the same
+// keyset-pagination logic dax.PostgresRepository.Stream uses, so memory
+// stays bounded no matter how large the filtered result is.
+func (r *Repository) Stream(ctx context.Context, filters *dax.Filters, batchSize int) (<-chan dax.DAXRecord, <-chan error) {
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	records := make(chan dax.DAXRecord)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(records)
+		defer close(errs)
+
+		var cursor *dax.CursorKey
+		for {
+			batch, hasNext, _, err := r.FindByFiltersKeyset(ctx, filters, cursor, batchSize)
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			for _, record := range batch {
+				select {
+				case records <- record:
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				}
+			}
+
+			if !hasNext || len(batch) == 0 {
+				return
+			}
+
+			last := batch[len(batch)-1]
+			cursor = &dax.CursorKey{Ticker: last.Ticker, Year: last.Year, ID: last.ID.String()}
+		}
+	}()
+
+	return records, errs
+}
+
+// GetMetrics retrieves unique metrics for a given ticker.
+func (r *Repository) GetMetrics(ctx context.Context, ticker string) ([]string, error) {
+	var metrics []string
+	err := r.db.SelectContext(ctx, &metrics, `
+		SELECT DISTINCT metric FROM dax WHERE ticker = $1 ORDER BY metric
+	`, ticker)
+	return metrics, err
+}
+
+// yearValues fetches ticker/metric's non-nil values for every year in
+// years, keyed by year.
+func (r *Repository) yearValues(ctx context.Context, ticker, metric string, years []int) (map[int]float64, error) {
+	type row struct {
+		Year  int     `db:"year"`
+		Value float64 `db:"value"`
+	}
+	var rows []row
+	query, args, err := sqlx.In(`
+		SELECT year, value FROM dax
+		WHERE ticker = ? AND metric = ? AND year IN (?) AND value IS NOT NULL
+	`, ticker, metric, years)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.SelectContext(ctx, &rows, rebind(query), args...); err != nil {
+		return nil, err
+	}
+
+	values := make(map[int]float64, len(rows))
+	for _, row := range rows {
+		values[row.Year] = row.Value
+	}
+	return values, nil
+}
+
+// GetMetricHistory retrieves ticker/metric's values for [fromYear, toYear].
+func (r *Repository) GetMetricHistory(ctx context.Context, ticker, metric string, fromYear, toYear int) ([]dax.DAXPoint, error) {
+	values, err := r.yearValues(ctx, ticker, metric, yearRange(fromYear, toYear))
+	if err != nil {
+		return nil, err
+	}
+	if missing := dax.MissingYearsInRange(fromYear, toYear, values); missing != nil {
+		return nil, &dax.MissingYearsError{Ticker: ticker, Metric: metric, Years: missing}
+	}
+	return dax.PointsFromValues(values), nil
+}
+
+// ComputeYoY computes ticker/metric's year-over-year growth for year.
+func (r *Repository) ComputeYoY(ctx context.Context, ticker, metric string, year int) (float64, error) {
+	values, err := r.yearValues(ctx, ticker, metric, []int{year - 1, year})
+	if err != nil {
+		return 0, err
+	}
+
+	var missing []int
+	if _, ok := values[year]; !ok {
+		missing = append(missing, year)
+	}
+	if _, ok := values[year-1]; !ok {
+		missing = append(missing, year-1)
+	}
+	if missing != nil {
+		sort.Ints(missing)
+		return 0, &dax.MissingYearsError{Ticker: ticker, Metric: metric, Years: missing}
+	}
+
+	return dax.ComputeYoY(values[year], values[year-1])
+}
+
+// ComputeCAGR computes ticker/metric's compound annual growth rate between
+// startYear and endYear.
+func (r *Repository) ComputeCAGR(ctx context.Context, ticker, metric string, startYear, endYear int) (float64, error) {
+	values, err := r.yearValues(ctx, ticker, metric, []int{startYear, endYear})
+	if err != nil {
+		return 0, err
+	}
+
+	var missing []int
+	if _, ok := values[startYear]; !ok {
+		missing = append(missing, startYear)
+	}
+	if _, ok := values[endYear]; !ok {
+		missing = append(missing, endYear)
+	}
+	if missing != nil {
+		return 0, &dax.MissingYearsError{Ticker: ticker, Metric: metric, Years: missing}
+	}
+
+	return dax.ComputeCAGR(values[startYear], values[endYear], endYear-startYear)
+}
+
+// Aggregate reduces ticker/metric's values over [fromYear, toYear] with op.
+func (r *Repository) Aggregate(ctx context.Context, ticker, metric string, fromYear, toYear int, op dax.AggOp) (float64, error) {
+	values, err := r.yearValues(ctx, ticker, metric, yearRange(fromYear, toYear))
+	if err != nil {
+		return 0, err
+	}
+	return dax.ComputeAggregate(values, op)
+}
+
+// DeleteAll removes all DAX records (for testing).
+func (r *Repository) DeleteAll(ctx context.Context) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM dax")
+	return err
+}
+
+// Count returns the total number of records.
+func (r *Repository) Count(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.GetContext(ctx, &count, "SELECT COUNT(*) FROM dax")
+	return count, err
+}
+
+// FindAsOf retrieves the records matching filters as they stood at asOf,
+// with pagination. A dax row that hasn't changed since asOf is used as-is;
+// one that has is replaced by the dax_history revision covering asOf, if
+// any.
+func (r *Repository) FindAsOf(ctx context.Context, filters *dax.Filters, asOf time.Time, page, limit int) ([]dax.DAXRecord, int, error) {
+	currentWhere, currentArgs := whereClause(filters)
+	currentQuery := fmt.Sprintf(`
+		SELECT id, company, ticker, report_type, metric, year, value, currency, created_at, updated_at
+		FROM dax%s`, currentWhere)
+	if currentWhere == "" {
+		currentQuery += " WHERE updated_at <= ?"
+	} else {
+		currentQuery += " AND updated_at <= ?"
+	}
+	currentArgs = append(currentArgs, asOf)
+
+	var current []dax.DAXRecord
+	if err := r.db.SelectContext(ctx, &current, rebind(currentQuery), currentArgs...); err != nil {
+		return nil, 0, err
+	}
+
+	historyWhere, historyArgs := whereClause(filters)
+	historyQuery := fmt.Sprintf(`
+		SELECT id, company, ticker, report_type, metric, year, value, currency, created_at, updated_at
+		FROM dax_history%s`, historyWhere)
+	if historyWhere == "" {
+		historyQuery += " WHERE valid_from <= ? AND valid_to > ?"
+	} else {
+		historyQuery += " AND valid_from <= ? AND valid_to > ?"
+	}
+	historyArgs = append(historyArgs, asOf, asOf)
+
+	var historical []dax.DAXRecord
+	if err := r.db.SelectContext(ctx, &historical, rebind(historyQuery), historyArgs...); err != nil {
+		return nil, 0, err
+	}
+
+	records := append(current, historical...)
+	sort.Slice(records, filters.SortLess(records))
+
+	return dax.PaginateRecords(records, page, limit), len(records), nil
+}
+
+// GetRevisions returns every archived version of company/ticker/metric/year
+// from dax_history, oldest first.
+func (r *Repository) GetRevisions(ctx context.Context, company, ticker, metric string, year int) ([]dax.DAXRevision, error) {
+	var revisions []dax.DAXRevision
+	err := r.db.SelectContext(ctx, &revisions, `
+		SELECT id, company, ticker, report_type, metric, year, value, currency, created_at, updated_at,
+			version_id, valid_from, valid_to, operation
+		FROM dax_history
+		WHERE company = $1 AND ticker = $2 AND metric = $3 AND year = $4
+		ORDER BY valid_from ASC
+	`, company, ticker, metric, year)
+	return revisions, err
+}
+
+// WithTx runs fn against a Repository backed by a single database
+// transaction, committing fn's writes only if fn returns nil and rolling
+// back all of them otherwise. Only usable when Repository was built with
+// NewRepository against a *sqlx.DB; calling WithTx on a Repository already
+// inside a transaction returns an error, since SQL doesn't support nested
+// transactions without savepoints.
+func (r *Repository) WithTx(ctx context.Context, fn func(dax.Repository) error) error {
+	db, ok := r.db.(*sqlx.DB)
+	if !ok {
+		return fmt.Errorf("postgres: WithTx called on a Repository that is already inside a transaction")
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(&Repository{db: tx}); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// yearRange returns fromYear, fromYear+1, ..., toYear.
+func yearRange(fromYear, toYear int) []int {
+	years := make([]int, 0, toYear-fromYear+1)
+	for year := fromYear; year <= toYear; year++ {
+		years = append(years, year)
+	}
+	return years
+}
+
+// whereClause builds a " WHERE ..." clause (or "" if filters is nil/empty)
+// for filters, using sqlx's "?" placeholder convention so the caller can
+// compose it with IN-clause expansion before a final rebind.
+func whereClause(filters *dax.Filters) (string, []interface{}) {
+	if filters == nil {
+		return "", nil
+	}
+
+	var conditions []string
+	var args []interface{}
+
+	if len(filters.Tickers) > 0 {
+		conditions = append(conditions, "ticker IN (?)")
+		args = append(args, filters.Tickers)
+	}
+	if len(filters.Metrics) > 0 {
+		conditions = append(conditions, "metric IN (?)")
+		args = append(args, filters.Metrics)
+	}
+	if len(filters.ReportTypes) > 0 {
+		conditions = append(conditions, "report_type IN (?)")
+		args = append(args, filters.ReportTypes)
+	}
+	if len(filters.Currencies) > 0 {
+		conditions = append(conditions, "currency IN (?)")
+		args = append(args, filters.Currencies)
+	}
+	if filters.YearFrom != nil {
+		conditions = append(conditions, "year >= ?")
+		args = append(args, *filters.YearFrom)
+	}
+	if filters.YearTo != nil {
+		conditions = append(conditions, "year <= ?")
+		args = append(args, *filters.YearTo)
+	}
+	if filters.MinValue != nil {
+		conditions = append(conditions, "value >= ?")
+		args = append(args, *filters.MinValue)
+	}
+	if filters.MaxValue != nil {
+		conditions = append(conditions, "value <= ?")
+		args = append(args, *filters.MaxValue)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	query, args, err := sqlx.In(" WHERE "+strings.Join(conditions, " AND "), args...)
+	if err != nil {
+		// Every condition above pairs a "(?)"/"?" placeholder with exactly
+		// one arg, so sqlx.In can only fail here if that invariant is
+		// broken by a future edit.
+		panic(fmt.Sprintf("postgres: malformed filter query: %v", err))
+	}
+	return query, args
+}
+
+// orderByClause builds the SQL ORDER BY clause for filters, defaulting to
+// the repository's standard ordering when no sort is requested.
+func orderByClause(filters *dax.Filters) string {
+	if filters == nil || filters.SortBy == "" {
+		return "year DESC, ticker ASC, metric ASC"
+	}
+
+	dir := "ASC"
+	if filters.SortDir == dax.SortDirDesc {
+		dir = "DESC"
+	}
+	return fmt.Sprintf("%s %s", filters.SortBy, dir)
+}
+
+// rebind converts a "?"-placeholder query (sqlx.In's output format) to
+// Postgres' "$1, $2, ..." convention.
+func rebind(query string) string {
+	return sqlx.Rebind(sqlx.DOLLAR, query)
+}
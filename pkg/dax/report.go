@@ -0,0 +1,199 @@
+package dax
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// AnalyticsReport is GetAnalytics' response: compound annual growth rate,
+// year-over-year growth, volatility and risk-adjusted return ratios, max
+// drawdown, and a linear trend for metric across every record matching the
+// request's filters.
+type AnalyticsReport struct {
+	Metric      string     `json:"metric"`
+	Points      []DAXPoint `json:"points"`
+	CAGR        *float64   `json:"cagr,omitempty"`
+	YoYGrowth   []DAXPoint `json:"yoy_growth,omitempty"`
+	Volatility  float64    `json:"volatility"`
+	Sharpe      float64    `json:"sharpe"`
+	Sortino     float64    `json:"sortino"`
+	MaxDrawdown float64    `json:"max_drawdown"`
+	TrendSlope  float64    `json:"trend_slope"`
+	TrendR2     float64    `json:"trend_r2"`
+	// Note documents a caveat in one of the figures above, such as CAGR
+	// being undefined for a non-positive start value, the same way
+	// StatResult/SeriesResponse.Note flag an approximation or fallback.
+	Note string `json:"note,omitempty"`
+}
+
+// ComputeAnalyticsReport streams every record matching filters through
+// repo.Stream (via ComputeSeries, since both need the same year-bucketed
+// values) and derives CAGR, year-over-year growth, volatility (stdev of
+// YoY returns), Sharpe and Sortino ratios, max drawdown, and a linear
+// trend with its R^2 over the resulting series.
+func ComputeAnalyticsReport(ctx context.Context, repo Repository, filters *Filters, metric string) (*AnalyticsReport, error) {
+	series, err := ComputeSeries(ctx, repo, filters, metric, ResampleYearly)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]DAXPoint, len(series.Points))
+	for i, p := range series.Points {
+		year, convErr := strconv.Atoi(p.Period)
+		if convErr != nil {
+			return nil, fmt.Errorf("unexpected series period %q", p.Period)
+		}
+		points[i] = DAXPoint{Year: year, Value: p.Value}
+	}
+
+	report := &AnalyticsReport{Metric: metric, Points: points}
+
+	if len(points) >= 2 {
+		first, last := points[0], points[len(points)-1]
+		if cagr, cagrErr := ComputeCAGR(first.Value, last.Value, last.Year-first.Year); cagrErr == nil {
+			report.CAGR = &cagr
+		} else {
+			report.Note = cagrErr.Error()
+		}
+	}
+
+	returns := make([]float64, 0, len(points))
+	for i := 1; i < len(points); i++ {
+		yoy, yoyErr := ComputeYoY(points[i].Value, points[i-1].Value)
+		if yoyErr != nil {
+			continue
+		}
+		report.YoYGrowth = append(report.YoYGrowth, DAXPoint{Year: points[i].Year, Value: yoy})
+		returns = append(returns, yoy)
+	}
+
+	report.Volatility = stdDev(returns)
+	report.Sharpe = sharpeRatio(returns)
+	report.Sortino = sortinoRatio(returns)
+	report.MaxDrawdown = maxDrawdown(points)
+	report.TrendSlope, report.TrendR2 = linearRegression(points)
+
+	return report, nil
+}
+
+// mean returns the arithmetic mean of xs, or 0 for an empty slice.
+func mean(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+// stdDev returns the population standard deviation of xs, or 0 for an
+// empty slice.
+func stdDev(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	m := mean(xs)
+	var sumSq float64
+	for _, x := range xs {
+		d := x - m
+		sumSq += d * d
+	}
+	return math.Sqrt(sumSq / float64(len(xs)))
+}
+
+// sharpeRatio computes mean(returns)/stddev(returns)*sqrt(len(returns)), 0
+// when returns has fewer than two points or no volatility.
+func sharpeRatio(returns []float64) float64 {
+	if len(returns) < 2 {
+		return 0
+	}
+	sd := stdDev(returns)
+	if sd == 0 {
+		return 0
+	}
+	return mean(returns) / sd * math.Sqrt(float64(len(returns)))
+}
+
+// sortinoRatio is sharpeRatio's numerator over the standard deviation of
+// negative returns only, so upside volatility doesn't penalize the ratio.
+func sortinoRatio(returns []float64) float64 {
+	if len(returns) < 2 {
+		return 0
+	}
+	var downside []float64
+	for _, r := range returns {
+		if r < 0 {
+			downside = append(downside, r)
+		}
+	}
+	sd := stdDev(downside)
+	if sd == 0 {
+		return 0
+	}
+	return mean(returns) / sd * math.Sqrt(float64(len(returns)))
+}
+
+// maxDrawdown returns the largest peak-to-trough drop across points'
+// values, as a positive fraction of the peak (0 when the series never
+// declines, or the peak is 0).
+func maxDrawdown(points []DAXPoint) float64 {
+	if len(points) == 0 {
+		return 0
+	}
+
+	peak := points[0].Value
+	var worst float64
+	for _, p := range points {
+		if p.Value > peak {
+			peak = p.Value
+		}
+		if peak == 0 {
+			continue
+		}
+		if dd := (peak - p.Value) / peak; dd > worst {
+			worst = dd
+		}
+	}
+	return worst
+}
+
+// linearRegression fits points' values against their year via ordinary
+// least squares, returning the trend's slope (value units per year) and
+// its R^2. Both are 0 for fewer than two points or a degenerate (single-
+// year) series.
+func linearRegression(points []DAXPoint) (slope, r2 float64) {
+	n := len(points)
+	if n < 2 {
+		return 0, 0
+	}
+
+	var sumX, sumY float64
+	for _, p := range points {
+		sumX += float64(p.Year)
+		sumY += p.Value
+	}
+	meanX := sumX / float64(n)
+	meanY := sumY / float64(n)
+
+	var ssXX, ssXY, ssYY float64
+	for _, p := range points {
+		dx := float64(p.Year) - meanX
+		dy := p.Value - meanY
+		ssXX += dx * dx
+		ssXY += dx * dy
+		ssYY += dy * dy
+	}
+
+	if ssXX == 0 {
+		return 0, 0
+	}
+	slope = ssXY / ssXX
+	if ssYY == 0 {
+		return slope, 0
+	}
+	return slope, (ssXY * ssXY) / (ssXX * ssYY)
+}
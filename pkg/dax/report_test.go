@@ -0,0 +1,81 @@
+package dax
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeAnalyticsReport(t *testing.T) {
+	repo := NewInMemoryRepository()
+	require.NoError(t, repo.BulkUpsert(context.Background(), []DAXRecord{
+		{Ticker: "SIE", Metric: "Revenue", Year: 2021, Value: ptrFloat(100)},
+		{Ticker: "SIE", Metric: "Revenue", Year: 2022, Value: ptrFloat(110)},
+		{Ticker: "SIE", Metric: "Revenue", Year: 2023, Value: ptrFloat(121)},
+	}))
+
+	report, err := ComputeAnalyticsReport(context.Background(), repo, &Filters{Tickers: []string{"SIE"}, Metrics: []string{"Revenue"}}, "Revenue")
+
+	require.NoError(t, err)
+	require.NotNil(t, report.CAGR)
+	assert.InDelta(t, 0.1, *report.CAGR, 1e-9)
+	require.Len(t, report.YoYGrowth, 2)
+	assert.InDelta(t, 0.1, report.YoYGrowth[0].Value, 1e-9)
+	assert.InDelta(t, 0.1, report.YoYGrowth[1].Value, 1e-9)
+	assert.Equal(t, 0.0, report.MaxDrawdown, "a monotonically increasing series has no drawdown")
+	assert.Greater(t, report.TrendSlope, 0.0)
+	assert.Greater(t, report.TrendR2, 0.9, "a near-linear trend has R^2 close to 1")
+}
+
+func TestComputeAnalyticsReport_CAGRUndefinedForNonPositiveStart(t *testing.T) {
+	repo := NewInMemoryRepository()
+	require.NoError(t, repo.BulkUpsert(context.Background(), []DAXRecord{
+		{Ticker: "SIE", Metric: "Revenue", Year: 2021, Value: ptrFloat(-5)},
+		{Ticker: "SIE", Metric: "Revenue", Year: 2022, Value: ptrFloat(10)},
+	}))
+
+	report, err := ComputeAnalyticsReport(context.Background(), repo, &Filters{Tickers: []string{"SIE"}, Metrics: []string{"Revenue"}}, "Revenue")
+
+	require.NoError(t, err)
+	assert.Nil(t, report.CAGR)
+	assert.NotEmpty(t, report.Note)
+}
+
+func TestComputeAnalyticsReport_NoData(t *testing.T) {
+	repo := NewInMemoryRepository()
+
+	_, err := ComputeAnalyticsReport(context.Background(), repo, &Filters{Tickers: []string{"SIE"}, Metrics: []string{"Revenue"}}, "Revenue")
+
+	assert.ErrorIs(t, err, ErrNoData)
+}
+
+func TestMaxDrawdown(t *testing.T) {
+	points := []DAXPoint{
+		{Year: 2020, Value: 100},
+		{Year: 2021, Value: 150},
+		{Year: 2022, Value: 75},
+		{Year: 2023, Value: 120},
+	}
+
+	assert.InDelta(t, 0.5, maxDrawdown(points), 1e-9)
+}
+
+func TestSharpeAndSortinoRatio(t *testing.T) {
+	returns := []float64{0.1, -0.05, 0.2, -0.1, 0.15}
+
+	sharpe := sharpeRatio(returns)
+	sortino := sortinoRatio(returns)
+
+	assert.False(t, math.IsNaN(sharpe))
+	assert.False(t, math.IsNaN(sortino))
+	assert.Greater(t, sortino, sharpe, "penalizing only downside volatility should raise the ratio")
+}
+
+func TestLinearRegression_DegenerateSeries(t *testing.T) {
+	slope, r2 := linearRegression([]DAXPoint{{Year: 2020, Value: 10}})
+	assert.Equal(t, 0.0, slope)
+	assert.Equal(t, 0.0, r2)
+}
@@ -1,24 +1,96 @@
 package dax
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/f00b455/blank-go/pkg/clock"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
-// Repository defines the interface for DAX data operations
+// Repository defines the interface for DAX data operations. Every method
+// takes ctx first so a caller (an HTTP handler, a scheduled job) can bound
+// how long a call may run and have that deadline observed all the way down
+// to the database driver.
 type Repository interface {
-	Create(record *DAXRecord) error
-	BulkUpsert(records []DAXRecord) error
-	FindAll(page, limit int) ([]DAXRecord, int, error)
-	FindByFilters(ticker string, year *int, page, limit int) ([]DAXRecord, int, error)
-	GetMetrics(ticker string) ([]string, error)
-	DeleteAll() error
-	Count() (int, error)
+	Create(ctx context.Context, record *DAXRecord) error
+	// Upsert inserts record, or updates it in place if a row already
+	// exists with the same (company, ticker, metric, year) key - the same
+	// natural key BulkUpsertBatch conflicts on and idx_dax_unique
+	// enforces. It reports created=true for an insert, false for an
+	// update, so a single-record import can distinguish the two without a
+	// separate lookup.
+	Upsert(ctx context.Context, record *DAXRecord) (created bool, err error)
+	// BulkUpsert is BulkUpsertBatch against context.Background(), for
+	// callers (mostly tests) that don't have a request-scoped context to
+	// thread through.
+	BulkUpsert(ctx context.Context, records []DAXRecord) error
+	BulkUpsertBatch(ctx context.Context, batch []DAXRecord) error
+	FindAll(ctx context.Context, page, limit int) ([]DAXRecord, int, error)
+	FindByFilters(ctx context.Context, filters *Filters, page, limit int) ([]DAXRecord, int, error)
+	// FindByFiltersKeyset returns the page of records matching filters
+	// adjacent to cursor in (ticker, year, id) order: a nil cursor starts
+	// at the beginning, cursor.Before false continues forward after
+	// cursor's key, and cursor.Before true scans backward from it.
+	// hasNext/hasPrev report whether further records exist beyond either
+	// end of the returned page, independent of which direction was
+	// scanned to reach it.
+	FindByFiltersKeyset(ctx context.Context, filters *Filters, cursor *CursorKey, limit int) (records []DAXRecord, hasNext bool, hasPrev bool, err error)
+	// CountFiltered returns how many records match filters, for the
+	// X-Total-Count header that accompanies cursor-paginated responses.
+	CountFiltered(ctx context.Context, filters *Filters) (int, error)
+	// Stream yields every record matching filters over the returned
+	// channel, fetched batchSize records at a time (defaultStreamBatchSize
+	// if batchSize <= 0) so a caller exporting the full filtered dataset
+	// never holds more than one batch in memory. The error channel carries
+	// at most one error; both channels close once streaming ends, whether
+	// that's because every record was sent, ctx was cancelled, or a batch
+	// fetch failed.
+	Stream(ctx context.Context, filters *Filters, batchSize int) (<-chan DAXRecord, <-chan error)
+	GetMetrics(ctx context.Context, ticker string) ([]string, error)
+	// GetMetricHistory returns ticker/metric's values for every year in
+	// [fromYear, toYear] ordered ascending by year. It returns a
+	// *MissingYearsError, rather than silently skipping or interpolating,
+	// if any year in the range has no value.
+	GetMetricHistory(ctx context.Context, ticker, metric string, fromYear, toYear int) ([]DAXPoint, error)
+	// ComputeYoY computes ticker/metric's (value[year]-value[year-1]) /
+	// value[year-1]. It returns a *MissingYearsError if year or year-1 has
+	// no value, and ErrYoYPriorNonPositive if value[year-1] <= 0.
+	ComputeYoY(ctx context.Context, ticker, metric string, year int) (float64, error)
+	// ComputeCAGR computes ticker/metric's compound annual growth rate from
+	// startYear to endYear. It returns a *MissingYearsError if either
+	// endpoint has no value, ErrCAGRStartNonPositive if value[startYear] <=
+	// 0, and ErrCAGRUndefined if the resulting growth rate isn't a real
+	// number (e.g. a negative end value).
+	ComputeCAGR(ctx context.Context, ticker, metric string, startYear, endYear int) (float64, error)
+	// Aggregate reduces ticker/metric's values across [fromYear, toYear]
+	// with op, skipping years with no value. It returns ErrNoData if no
+	// year in the range has a value.
+	Aggregate(ctx context.Context, ticker, metric string, fromYear, toYear int, op AggOp) (float64, error)
+	DeleteAll(ctx context.Context) error
+	Count(ctx context.Context) (int, error)
+	// FindAsOf retrieves the records matching filters as they stood at
+	// asOf, with pagination. A record that has been created, updated or
+	// deleted since asOf is reconstructed from the dax_history revision
+	// whose [ValidFrom, ValidTo) window covers asOf rather than from its
+	// current value.
+	FindAsOf(ctx context.Context, filters *Filters, asOf time.Time, page, limit int) ([]DAXRecord, int, error)
+	// GetRevisions returns every archived version of
+	// company/ticker/metric/year from dax_history, oldest first.
+	GetRevisions(ctx context.Context, company, ticker, metric string, year int) ([]DAXRevision, error)
+	// WithTx runs fn against a Repository whose writes are only visible to
+	// other callers if fn returns nil; any error from fn discards every
+	// change fn made, the same commit-or-rollback contract a SQL
+	// transaction provides. Used to compose BulkUpsert's history archival
+	// with additional writes (e.g. a caller's own table) atomically.
+	WithTx(ctx context.Context, fn func(Repository) error) error
 }
 
 // PostgresRepository implements Repository using PostgreSQL
@@ -26,21 +98,72 @@ type PostgresRepository struct {
 	db *gorm.DB
 }
 
-// NewPostgresRepository creates a new PostgreSQL repository
-func NewPostgresRepository(db *gorm.DB) *PostgresRepository {
-	return &PostgresRepository{db: db}
+// ErrMigrationsPending is returned by NewPostgresRepository when db has no
+// dax table, so a misconfigured deploy (AutoMigrate/internal/migrations
+// never run) fails at startup with a clear cause instead of surfacing as a
+// "relation does not exist" error from the first query a handler runs.
+var ErrMigrationsPending = errors.New("dax table not found: run internal/migrations (or set MIGRATE_ON_STARTUP) before starting the server")
+
+// NewPostgresRepository creates a new PostgreSQL repository, fast-failing
+// with ErrMigrationsPending if db's schema hasn't been brought up yet.
+func NewPostgresRepository(db *gorm.DB) (*PostgresRepository, error) {
+	if !db.Migrator().HasTable(&DAXRecord{}) {
+		return nil, ErrMigrationsPending
+	}
+	return &PostgresRepository{db: db}, nil
 }
 
 // Create inserts a single DAX record
-func (r *PostgresRepository) Create(record *DAXRecord) error {
+func (r *PostgresRepository) Create(ctx context.Context, record *DAXRecord) error {
 	if record.ID == uuid.Nil {
 		record.ID = uuid.New()
 	}
-	return r.db.Create(record).Error
+	return r.db.WithContext(ctx).Create(record).Error
+}
+
+// Upsert implements the Repository.Upsert contract, archiving the row it
+// replaces into dax_history the same way BulkUpsertBatch does.
+func (r *PostgresRepository) Upsert(ctx context.Context, record *DAXRecord) (created bool, err error) {
+	if record.ID == uuid.Nil {
+		record.ID = uuid.New()
+	}
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing DAXRecord
+		lookupErr := tx.Where("company = ? AND ticker = ? AND metric = ? AND year = ?",
+			record.Company, record.Ticker, record.Metric, record.Year).First(&existing).Error
+
+		switch {
+		case errors.Is(lookupErr, gorm.ErrRecordNotFound):
+			created = true
+			return tx.Create(record).Error
+		case lookupErr != nil:
+			return lookupErr
+		}
+
+		record.ID = existing.ID
+		record.CreatedAt = existing.CreatedAt
+		key := daxKey{Company: existing.Company, Ticker: existing.Ticker, Metric: existing.Metric, Year: existing.Year}
+		if err := archiveSupersededRows(tx, []daxKey{key}); err != nil {
+			return fmt.Errorf("failed to archive prior dax row to history: %w", err)
+		}
+		return tx.Save(record).Error
+	})
+
+	return created, err
 }
 
 // BulkUpsert performs bulk insert with upsert on conflict
-func (r *PostgresRepository) BulkUpsert(records []DAXRecord) error {
+func (r *PostgresRepository) BulkUpsert(ctx context.Context, records []DAXRecord) error {
+	return r.BulkUpsertBatch(ctx, records)
+}
+
+// BulkUpsertBatch performs bulk insert with upsert on conflict, honoring ctx
+// cancellation. Before the upsert, any row the batch is about to overwrite
+// is archived into dax_history so Repository.FindAsOf/GetRevisions can still
+// recover it; the archival and the upsert run in the same transaction so a
+// failure of either leaves no partial history behind.
+func (r *PostgresRepository) BulkUpsertBatch(ctx context.Context, records []DAXRecord) error {
 	if len(records) == 0 {
 		return nil
 	}
@@ -52,34 +175,86 @@ func (r *PostgresRepository) BulkUpsert(records []DAXRecord) error {
 		}
 	}
 
-	// Use Clauses for UPSERT behavior
-	return r.db.Clauses(clause.OnConflict{
-		Columns: []clause.Column{
-			{Name: "company"},
-			{Name: "ticker"},
-			{Name: "metric"},
-			{Name: "year"},
-		},
-		DoUpdates: clause.AssignmentColumns([]string{
-			"report_type",
-			"value",
-			"currency",
-			"updated_at",
-		}),
-	}).Create(&records).Error
+	keys := make([]daxKey, len(records))
+	for i, record := range records {
+		keys[i] = daxKey{Company: record.Company, Ticker: record.Ticker, Metric: record.Metric, Year: record.Year}
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := archiveSupersededRows(tx, keys); err != nil {
+			return fmt.Errorf("failed to archive prior dax rows to history: %w", err)
+		}
+
+		// Use Clauses for UPSERT behavior
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "company"},
+				{Name: "ticker"},
+				{Name: "metric"},
+				{Name: "year"},
+			},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"report_type",
+				"value",
+				"currency",
+				"updated_at",
+			}),
+		}).Create(&records).Error
+	})
+}
+
+// daxKey identifies a dax row by its unique (company, ticker, metric, year)
+// key, independent of its id.
+type daxKey struct {
+	Company string
+	Ticker  string
+	Metric  string
+	Year    int
+}
+
+// archiveSupersededRows copies every dax row matching keys into dax_history
+// as an "update" revision, valid from its last update until now, before the
+// caller overwrites it. Rows in keys that don't yet exist in dax (first-time
+// inserts) are silently skipped, since there is no prior version to archive.
+func archiveSupersededRows(tx *gorm.DB, keys []daxKey) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(keys))
+	args := make([]interface{}, 0, len(keys)*4)
+	for i, key := range keys {
+		placeholders[i] = "(?, ?, ?, ?)"
+		args = append(args, key.Company, key.Ticker, key.Metric, key.Year)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO dax_history (
+			id, company, ticker, report_type, metric, year, value, currency,
+			created_at, updated_at, version_id, valid_from, valid_to, operation
+		)
+		SELECT
+			id, company, ticker, report_type, metric, year, value, currency,
+			created_at, updated_at, gen_random_uuid(), updated_at, NOW(), 'update'
+		FROM dax
+		WHERE (company, ticker, metric, year) IN (%s)
+	`, strings.Join(placeholders, ", "))
+
+	return tx.Exec(query, args...).Error
 }
 
 // FindAll retrieves all DAX records with pagination
-func (r *PostgresRepository) FindAll(page, limit int) ([]DAXRecord, int, error) {
+func (r *PostgresRepository) FindAll(ctx context.Context, page, limit int) ([]DAXRecord, int, error) {
 	var records []DAXRecord
 	var totalCount int64
 
-	if err := r.db.Model(&DAXRecord{}).Count(&totalCount).Error; err != nil {
+	db := r.db.WithContext(ctx)
+	if err := db.Model(&DAXRecord{}).Count(&totalCount).Error; err != nil {
 		return nil, 0, err
 	}
 
 	offset := (page - 1) * limit
-	if err := r.db.Offset(offset).Limit(limit).
+	if err := db.Offset(offset).Limit(limit).
 		Order("year DESC, ticker ASC, metric ASC").
 		Find(&records).Error; err != nil {
 		return nil, 0, err
@@ -88,20 +263,13 @@ func (r *PostgresRepository) FindAll(page, limit int) ([]DAXRecord, int, error)
 	return records, int(totalCount), nil
 }
 
-// FindByFilters retrieves DAX records with optional filters and pagination
-func (r *PostgresRepository) FindByFilters(ticker string, year *int, page, limit int) ([]DAXRecord, int, error) {
+// FindByFilters retrieves DAX records matching filters, with pagination. A
+// nil filters matches every record.
+func (r *PostgresRepository) FindByFilters(ctx context.Context, filters *Filters, page, limit int) ([]DAXRecord, int, error) {
 	var records []DAXRecord
 	var totalCount int64
 
-	query := r.db.Model(&DAXRecord{})
-
-	if ticker != "" {
-		query = query.Where("ticker = ?", ticker)
-	}
-
-	if year != nil {
-		query = query.Where("year = ?", *year)
-	}
+	query := applyFilters(r.db.WithContext(ctx).Model(&DAXRecord{}), filters)
 
 	if err := query.Count(&totalCount).Error; err != nil {
 		return nil, 0, err
@@ -109,7 +277,7 @@ func (r *PostgresRepository) FindByFilters(ticker string, year *int, page, limit
 
 	offset := (page - 1) * limit
 	if err := query.Offset(offset).Limit(limit).
-		Order("year DESC, ticker ASC, metric ASC").
+		Order(orderClause(filters)).
 		Find(&records).Error; err != nil {
 		return nil, 0, err
 	}
@@ -117,11 +285,161 @@ func (r *PostgresRepository) FindByFilters(ticker string, year *int, page, limit
 	return records, int(totalCount), nil
 }
 
+// FindByFiltersKeyset implements the Repository.FindByFiltersKeyset
+// keyset-pagination contract described on the interface, fixing the sort
+// order to (ticker, year, id) regardless of filters.SortBy so a cursor
+// built from one page always resolves against the next.
+func (r *PostgresRepository) FindByFiltersKeyset(ctx context.Context, filters *Filters, cursor *CursorKey, limit int) ([]DAXRecord, bool, bool, error) {
+	var records []DAXRecord
+	db := r.db.WithContext(ctx)
+
+	if cursor == nil || !cursor.Before {
+		query := applyFilters(db.Model(&DAXRecord{}), filters)
+		if cursor != nil {
+			query = query.Where("(ticker, year, id) > (?, ?, ?)", cursor.Ticker, cursor.Year, cursor.ID)
+		}
+		if err := query.Order("ticker ASC, year ASC, id ASC").Limit(limit + 1).Find(&records).Error; err != nil {
+			return nil, false, false, err
+		}
+
+		hasNext := len(records) > limit
+		if hasNext {
+			records = records[:limit]
+		}
+		return records, hasNext, cursor != nil, nil
+	}
+
+	query := applyFilters(db.Model(&DAXRecord{}), filters).
+		Where("(ticker, year, id) < (?, ?, ?)", cursor.Ticker, cursor.Year, cursor.ID)
+	if err := query.Order("ticker DESC, year DESC, id DESC").Limit(limit + 1).Find(&records).Error; err != nil {
+		return nil, false, false, err
+	}
+
+	hasPrev := len(records) > limit
+	if hasPrev {
+		records = records[:limit]
+	}
+	for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+		records[i], records[j] = records[j], records[i]
+	}
+	// We only reach a Before cursor by following a "prev" link from a later
+	// page, so that later page is always a valid next page from here.
+	return records, true, hasPrev, nil
+}
+
+// CountFiltered returns how many records match filters.
+func (r *PostgresRepository) CountFiltered(ctx context.Context, filters *Filters) (int, error) {
+	var count int64
+	if err := applyFilters(r.db.WithContext(ctx).Model(&DAXRecord{}), filters).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// defaultStreamBatchSize is the page size Stream fetches at a time when the
+// caller doesn't request one.
+const defaultStreamBatchSize = 500
+
+// streamKeyset powers Stream for both repository implementations: it pages
+// through repo's (ticker, year, id) keyset cursor - the same one
+// FindByFiltersKeyset uses for cursor pagination - in batchSize-sized
+// chunks, so the caller never holds more than one batch in memory at a
+// time regardless of how large the filtered result is.
+func streamKeyset(ctx context.Context, repo Repository, filters *Filters, batchSize int) (<-chan DAXRecord, <-chan error) {
+	if batchSize <= 0 {
+		batchSize = defaultStreamBatchSize
+	}
+
+	records := make(chan DAXRecord)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(records)
+		defer close(errs)
+
+		var cursor *CursorKey
+		for {
+			batch, hasNext, _, err := repo.FindByFiltersKeyset(ctx, filters, cursor, batchSize)
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			for _, record := range batch {
+				select {
+				case records <- record:
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				}
+			}
+
+			if !hasNext || len(batch) == 0 {
+				return
+			}
+
+			last := batch[len(batch)-1]
+			cursor = &CursorKey{Ticker: last.Ticker, Year: last.Year, ID: last.ID.String()}
+		}
+	}()
+
+	return records, errs
+}
+
+// applyFilters adds WHERE clauses for each set Filters field to query.
+func applyFilters(query *gorm.DB, filters *Filters) *gorm.DB {
+	if filters == nil {
+		return query
+	}
+
+	if len(filters.Tickers) > 0 {
+		query = query.Where("ticker IN ?", filters.Tickers)
+	}
+	if len(filters.Metrics) > 0 {
+		query = query.Where("metric IN ?", filters.Metrics)
+	}
+	if len(filters.ReportTypes) > 0 {
+		query = query.Where("report_type IN ?", filters.ReportTypes)
+	}
+	if len(filters.Currencies) > 0 {
+		query = query.Where("currency IN ?", filters.Currencies)
+	}
+	if filters.YearFrom != nil {
+		query = query.Where("year >= ?", *filters.YearFrom)
+	}
+	if filters.YearTo != nil {
+		query = query.Where("year <= ?", *filters.YearTo)
+	}
+	if filters.MinValue != nil {
+		query = query.Where("value >= ?", *filters.MinValue)
+	}
+	if filters.MaxValue != nil {
+		query = query.Where("value <= ?", *filters.MaxValue)
+	}
+
+	return query
+}
+
+// orderClause builds the SQL ORDER BY clause for filters, defaulting to the
+// repository's standard ordering when no sort is requested.
+func orderClause(filters *Filters) string {
+	if filters == nil || filters.SortBy == "" {
+		return "year DESC, ticker ASC, metric ASC"
+	}
+
+	dir := "ASC"
+	if filters.SortDir == SortDirDesc {
+		dir = "DESC"
+	}
+
+	return fmt.Sprintf("%s %s", filters.SortBy, dir)
+}
+
 // GetMetrics retrieves unique metrics for a given ticker
-func (r *PostgresRepository) GetMetrics(ticker string) ([]string, error) {
+func (r *PostgresRepository) GetMetrics(ctx context.Context, ticker string) ([]string, error) {
 	var metrics []string
 
-	if err := r.db.Model(&DAXRecord{}).
+	if err := r.db.WithContext(ctx).Model(&DAXRecord{}).
 		Where("ticker = ?", ticker).
 		Distinct("metric").
 		Pluck("metric", &metrics).Error; err != nil {
@@ -131,23 +449,228 @@ func (r *PostgresRepository) GetMetrics(ticker string) ([]string, error) {
 	return metrics, nil
 }
 
+// yearValues fetches ticker/metric's non-nil values for every year in
+// years, keyed by year.
+func (r *PostgresRepository) yearValues(ctx context.Context, ticker, metric string, years []int) (map[int]float64, error) {
+	var records []DAXRecord
+	if err := r.db.WithContext(ctx).Where("ticker = ? AND metric = ? AND year IN ? AND value IS NOT NULL", ticker, metric, years).
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	values := make(map[int]float64, len(records))
+	for _, record := range records {
+		values[record.Year] = *record.Value
+	}
+	return values, nil
+}
+
+// yearRange returns fromYear, fromYear+1, ..., toYear.
+func yearRange(fromYear, toYear int) []int {
+	years := make([]int, 0, toYear-fromYear+1)
+	for year := fromYear; year <= toYear; year++ {
+		years = append(years, year)
+	}
+	return years
+}
+
+// GetMetricHistory retrieves ticker/metric's values for [fromYear, toYear].
+func (r *PostgresRepository) GetMetricHistory(ctx context.Context, ticker, metric string, fromYear, toYear int) ([]DAXPoint, error) {
+	values, err := r.yearValues(ctx, ticker, metric, yearRange(fromYear, toYear))
+	if err != nil {
+		return nil, err
+	}
+	if missing := MissingYearsInRange(fromYear, toYear, values); missing != nil {
+		return nil, &MissingYearsError{Ticker: ticker, Metric: metric, Years: missing}
+	}
+	return PointsFromValues(values), nil
+}
+
+// ComputeYoY computes ticker/metric's year-over-year growth for year,
+// pushing the lookup of year and its predecessor into a single query with a
+// LAG() window function rather than two round trips.
+func (r *PostgresRepository) ComputeYoY(ctx context.Context, ticker, metric string, year int) (float64, error) {
+	var row struct {
+		Value      *float64
+		PriorValue *float64
+	}
+	err := r.db.WithContext(ctx).Raw(`
+		WITH series AS (
+			SELECT year, value, LAG(value) OVER (ORDER BY year) AS prior_value
+			FROM dax
+			WHERE ticker = ? AND metric = ? AND year IN (?, ?) AND value IS NOT NULL
+		)
+		SELECT value, prior_value FROM series WHERE year = ?
+	`, ticker, metric, year-1, year, year).Scan(&row).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var missing []int
+	if row.Value == nil {
+		missing = append(missing, year)
+	}
+	if row.PriorValue == nil {
+		missing = append(missing, year-1)
+	}
+	if missing != nil {
+		sort.Ints(missing)
+		return 0, &MissingYearsError{Ticker: ticker, Metric: metric, Years: missing}
+	}
+
+	return ComputeYoY(*row.Value, *row.PriorValue)
+}
+
+// ComputeCAGR computes ticker/metric's compound annual growth rate between
+// startYear and endYear, raising (endValue/startValue) to the power
+// 1/(endYear-startYear) in Go since Postgres' pow() doesn't special-case the
+// undefined results ComputeCAGR checks for.
+func (r *PostgresRepository) ComputeCAGR(ctx context.Context, ticker, metric string, startYear, endYear int) (float64, error) {
+	values, err := r.yearValues(ctx, ticker, metric, []int{startYear, endYear})
+	if err != nil {
+		return 0, err
+	}
+
+	var missing []int
+	if _, ok := values[startYear]; !ok {
+		missing = append(missing, startYear)
+	}
+	if _, ok := values[endYear]; !ok {
+		missing = append(missing, endYear)
+	}
+	if missing != nil {
+		return 0, &MissingYearsError{Ticker: ticker, Metric: metric, Years: missing}
+	}
+
+	return ComputeCAGR(values[startYear], values[endYear], endYear-startYear)
+}
+
+// Aggregate reduces ticker/metric's values over [fromYear, toYear] with op,
+// pushing the reduction into SQL for AggSum/AggAvg/AggMin/AggMax rather than
+// fetching every row.
+func (r *PostgresRepository) Aggregate(ctx context.Context, ticker, metric string, fromYear, toYear int, op AggOp) (float64, error) {
+	var sqlFunc string
+	switch op {
+	case AggSum:
+		sqlFunc = "SUM"
+	case AggAvg:
+		sqlFunc = "AVG"
+	case AggMin:
+		sqlFunc = "MIN"
+	case AggMax:
+		sqlFunc = "MAX"
+	default:
+		return 0, ErrUnknownAggOp
+	}
+
+	var result *float64
+	err := r.db.WithContext(ctx).Model(&DAXRecord{}).
+		Select(fmt.Sprintf("%s(value)", sqlFunc)).
+		Where("ticker = ? AND metric = ? AND year BETWEEN ? AND ? AND value IS NOT NULL", ticker, metric, fromYear, toYear).
+		Scan(&result).Error
+	if err != nil {
+		return 0, err
+	}
+	if result == nil {
+		return 0, ErrNoData
+	}
+	return *result, nil
+}
+
+// Stream implements Repository.Stream by paging through
+// FindByFiltersKeyset, which itself scans with a `WHERE (ticker, year, id)
+// > (?, ?, ?) ORDER BY ... LIMIT ?` query per batch - Postgres satisfies
+// that with an index scan rather than materializing the whole filtered
+// result, so memory stays bounded no matter how large the table is.
+func (r *PostgresRepository) Stream(ctx context.Context, filters *Filters, batchSize int) (<-chan DAXRecord, <-chan error) {
+	return streamKeyset(ctx, r, filters, batchSize)
+}
+
 // DeleteAll removes all DAX records (for testing)
-func (r *PostgresRepository) DeleteAll() error {
-	return r.db.Exec("DELETE FROM dax").Error
+func (r *PostgresRepository) DeleteAll(ctx context.Context) error {
+	return r.db.WithContext(ctx).Exec("DELETE FROM dax").Error
 }
 
 // Count returns the total number of records
-func (r *PostgresRepository) Count() (int, error) {
+func (r *PostgresRepository) Count(ctx context.Context) (int, error) {
 	var count int64
-	if err := r.db.Model(&DAXRecord{}).Count(&count).Error; err != nil {
+	if err := r.db.WithContext(ctx).Model(&DAXRecord{}).Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return int(count), nil
 }
 
+// FindAsOf retrieves the records matching filters as they stood at asOf,
+// with pagination. A dax row that hasn't changed since asOf is used as-is;
+// one that has is replaced by the dax_history revision covering asOf, if
+// any.
+func (r *PostgresRepository) FindAsOf(ctx context.Context, filters *Filters, asOf time.Time, page, limit int) ([]DAXRecord, int, error) {
+	db := r.db.WithContext(ctx)
+
+	var current []DAXRecord
+	if err := applyFilters(db.Model(&DAXRecord{}), filters).
+		Where("updated_at <= ?", asOf).
+		Find(&current).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var historical []DAXRevision
+	if err := applyFilters(db.Table("dax_history"), filters).
+		Where("valid_from <= ? AND valid_to > ?", asOf, asOf).
+		Find(&historical).Error; err != nil {
+		return nil, 0, err
+	}
+
+	records := current
+	for _, revision := range historical {
+		records = append(records, revision.DAXRecord)
+	}
+	sort.Slice(records, filters.SortLess(records))
+
+	return PaginateRecords(records, page, limit), len(records), nil
+}
+
+// PaginateRecords slices the already-sorted records to page/limit, the same
+// slicing FindAll/FindByFilters apply after their SQL-side Count+Find.
+func PaginateRecords(records []DAXRecord, page, limit int) []DAXRecord {
+	totalCount := len(records)
+	offset := (page - 1) * limit
+	if offset >= totalCount {
+		return []DAXRecord{}
+	}
+
+	end := offset + limit
+	if end > totalCount {
+		end = totalCount
+	}
+	return records[offset:end]
+}
+
+// GetRevisions returns every archived version of company/ticker/metric/year
+// from dax_history, oldest first.
+func (r *PostgresRepository) GetRevisions(ctx context.Context, company, ticker, metric string, year int) ([]DAXRevision, error) {
+	var revisions []DAXRevision
+	if err := r.db.WithContext(ctx).Table("dax_history").
+		Where("company = ? AND ticker = ? AND metric = ? AND year = ?", company, ticker, metric, year).
+		Order("valid_from ASC").
+		Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// WithTx runs fn against a Repository backed by a single database
+// transaction, committing fn's writes only if fn returns nil and rolling
+// back all of them otherwise.
+func (r *PostgresRepository) WithTx(ctx context.Context, fn func(Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&PostgresRepository{db: tx})
+	})
+}
+
 // AutoMigrate creates/updates the database schema
 func AutoMigrate(db *gorm.DB) error {
-	if err := db.AutoMigrate(&DAXRecord{}); err != nil {
+	if err := db.AutoMigrate(&DAXRecord{}, &Alias{}, &DAXRevision{}); err != nil {
 		return fmt.Errorf("failed to migrate DAX schema: %w", err)
 	}
 
@@ -166,17 +689,43 @@ func AutoMigrate(db *gorm.DB) error {
 type InMemoryRepository struct {
 	mu      sync.RWMutex
 	records map[string]*DAXRecord
+	// history holds, per (company, ticker, metric, year) key, every
+	// version a record has had before being overwritten - the in-memory
+	// equivalent of PostgresRepository's dax_history table.
+	history map[string][]DAXRevision
+	// clock is consulted for Upsert/BulkUpsertBatch's archival timestamp.
+	// It's a seam for deterministic tests (see SetClock); nothing here
+	// persists a record-level CreatedAt/UpdatedAt yet the way
+	// PostgresRepository's gorm tags do.
+	clock clock.Clock
 }
 
 // NewInMemoryRepository creates a new in-memory repository
 func NewInMemoryRepository() *InMemoryRepository {
 	return &InMemoryRepository{
 		records: make(map[string]*DAXRecord),
+		history: make(map[string][]DAXRevision),
+		clock:   clock.RealClock{},
 	}
 }
 
+// SetClock overrides the clock used for archival timestamps, so a test can
+// advance a clock.FakeClock instead of relying on wall-clock ordering.
+func (r *InMemoryRepository) SetClock(c clock.Clock) {
+	r.clock = c
+}
+
+// historyKey builds the key InMemoryRepository.history is keyed by.
+func historyKey(company, ticker, metric string, year int) string {
+	return fmt.Sprintf("%s|%s|%s|%d", company, ticker, metric, year)
+}
+
 // Create inserts a single DAX record
-func (r *InMemoryRepository) Create(record *DAXRecord) error {
+func (r *InMemoryRepository) Create(ctx context.Context, record *DAXRecord) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -188,11 +737,63 @@ func (r *InMemoryRepository) Create(record *DAXRecord) error {
 	return nil
 }
 
+// Upsert implements the Repository.Upsert contract, archiving the row it
+// replaces into r.history the same way BulkUpsertBatch does.
+func (r *InMemoryRepository) Upsert(ctx context.Context, record *DAXRecord) (created bool, err error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if record.ID == uuid.Nil {
+		record.ID = uuid.New()
+	}
+
+	var existingID string
+	for id, existing := range r.records {
+		if existing.Company == record.Company &&
+			existing.Ticker == record.Ticker &&
+			existing.Metric == record.Metric &&
+			existing.Year == record.Year {
+			existingID = id
+			break
+		}
+	}
+
+	if existingID == "" {
+		r.records[record.ID.String()] = record
+		return true, nil
+	}
+
+	prior := *r.records[existingID]
+	record.ID = prior.ID
+	record.CreatedAt = prior.CreatedAt
+	r.archiveVersion(prior, r.clock.Now())
+	r.records[record.ID.String()] = record
+
+	return false, nil
+}
+
 // BulkUpsert performs bulk insert with upsert on conflict
-func (r *InMemoryRepository) BulkUpsert(records []DAXRecord) error {
+func (r *InMemoryRepository) BulkUpsert(ctx context.Context, records []DAXRecord) error {
+	return r.BulkUpsertBatch(ctx, records)
+}
+
+// BulkUpsertBatch performs bulk insert with upsert on conflict, honoring ctx
+// cancellation. Before overwriting an existing record, its prior value is
+// archived into r.history, mirroring PostgresRepository's dax_history
+// archival.
+func (r *InMemoryRepository) BulkUpsertBatch(ctx context.Context, records []DAXRecord) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	now := r.clock.Now()
 	for i := range records {
 		if records[i].ID == uuid.Nil {
 			records[i].ID = uuid.New()
@@ -212,8 +813,10 @@ func (r *InMemoryRepository) BulkUpsert(records []DAXRecord) error {
 
 		if existingID != "" {
 			// Update existing record
-			records[i].ID = r.records[existingID].ID
-			records[i].CreatedAt = r.records[existingID].CreatedAt
+			prior := *r.records[existingID]
+			records[i].ID = prior.ID
+			records[i].CreatedAt = prior.CreatedAt
+			r.archiveVersion(prior, now)
 		}
 
 		r.records[records[i].ID.String()] = &records[i]
@@ -222,8 +825,32 @@ func (r *InMemoryRepository) BulkUpsert(records []DAXRecord) error {
 	return nil
 }
 
+// archiveVersion appends prior to its key's history, valid from its
+// CreatedAt (or the prior revision's ValidTo, if there is one) until now.
+// Caller must hold r.mu for writing.
+func (r *InMemoryRepository) archiveVersion(prior DAXRecord, now time.Time) {
+	key := historyKey(prior.Company, prior.Ticker, prior.Metric, prior.Year)
+
+	validFrom := prior.CreatedAt
+	if versions := r.history[key]; len(versions) > 0 {
+		validFrom = *versions[len(versions)-1].ValidTo
+	}
+
+	r.history[key] = append(r.history[key], DAXRevision{
+		DAXRecord: prior,
+		VersionID: uuid.New(),
+		ValidFrom: validFrom,
+		ValidTo:   &now,
+		Operation: "update",
+	})
+}
+
 // FindAll retrieves all DAX records with pagination
-func (r *InMemoryRepository) FindAll(page, limit int) ([]DAXRecord, int, error) {
+func (r *InMemoryRepository) FindAll(ctx context.Context, page, limit int) ([]DAXRecord, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -243,65 +870,147 @@ func (r *InMemoryRepository) FindAll(page, limit int) ([]DAXRecord, int, error)
 		return allRecords[i].Metric < allRecords[j].Metric
 	})
 
-	totalCount := len(allRecords)
-	offset := (page - 1) * limit
-
-	if offset >= totalCount {
-		return []DAXRecord{}, totalCount, nil
-	}
+	return PaginateRecords(allRecords, page, limit), len(allRecords), nil
+}
 
-	end := offset + limit
-	if end > totalCount {
-		end = totalCount
+// FindByFilters retrieves DAX records matching filters, with pagination. A
+// nil filters matches every record.
+func (r *InMemoryRepository) FindByFilters(ctx context.Context, filters *Filters, page, limit int) ([]DAXRecord, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
 	}
 
-	return allRecords[offset:end], totalCount, nil
-}
-
-// FindByFilters retrieves DAX records with optional filters and pagination
-func (r *InMemoryRepository) FindByFilters(ticker string, year *int, page, limit int) ([]DAXRecord, int, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	var filtered []DAXRecord
 	for _, record := range r.records {
-		if ticker != "" && record.Ticker != ticker {
-			continue
-		}
-		if year != nil && record.Year != *year {
+		if !filters.matches(record) {
 			continue
 		}
 		filtered = append(filtered, *record)
 	}
 
-	// Sort by year DESC, ticker ASC, metric ASC
-	sort.Slice(filtered, func(i, j int) bool {
-		if filtered[i].Year != filtered[j].Year {
-			return filtered[i].Year > filtered[j].Year
+	sort.Slice(filtered, filters.SortLess(filtered))
+
+	return PaginateRecords(filtered, page, limit), len(filtered), nil
+}
+
+// FindByFiltersKeyset implements the Repository.FindByFiltersKeyset
+// keyset-pagination contract described on the interface: unlike
+// FindByFilters's offset pagination, the page it returns doesn't shift
+// when records are inserted or deleted elsewhere in the table, since each
+// page is anchored to an actual row's (ticker, year, id) rather than a row
+// count.
+func (r *InMemoryRepository) FindByFiltersKeyset(ctx context.Context, filters *Filters, cursor *CursorKey, limit int) ([]DAXRecord, bool, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, false, false, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []DAXRecord
+	for _, record := range r.records {
+		if filters.matches(record) {
+			matched = append(matched, *record)
 		}
-		if filtered[i].Ticker != filtered[j].Ticker {
-			return filtered[i].Ticker < filtered[j].Ticker
+	}
+
+	sort.Slice(matched, func(i, j int) bool { return daxKeysetLess(matched[i], matched[j]) })
+
+	var start, end int
+	switch {
+	case cursor == nil:
+		start, end = 0, limit
+	case cursor.Before:
+		// idx is the count of records strictly before cursor's key; the
+		// page ends there and runs backward up to limit records.
+		idx := sort.Search(len(matched), func(i int) bool { return !daxKeysetBefore(matched[i], *cursor) })
+		start, end = idx-limit, idx
+		if start < 0 {
+			start = 0
 		}
-		return filtered[i].Metric < filtered[j].Metric
-	})
+	default:
+		// idx is the first record strictly after cursor's key; the page
+		// starts there and runs forward up to limit records.
+		idx := sort.Search(len(matched), func(i int) bool { return daxKeysetAfter(matched[i], *cursor) })
+		start, end = idx, idx+limit
+	}
 
-	totalCount := len(filtered)
-	offset := (page - 1) * limit
+	if start > len(matched) {
+		start = len(matched)
+	}
+	if end > len(matched) {
+		end = len(matched)
+	}
 
-	if offset >= totalCount {
-		return []DAXRecord{}, totalCount, nil
+	page := matched[start:end]
+	hasNext := end < len(matched)
+	hasPrev := start > 0
+	return page, hasNext, hasPrev, nil
+}
+
+// CountFiltered returns how many records match filters.
+func (r *InMemoryRepository) CountFiltered(ctx context.Context, filters *Filters) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
 	}
 
-	end := offset + limit
-	if end > totalCount {
-		end = totalCount
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, record := range r.records {
+		if filters.matches(record) {
+			count++
+		}
 	}
+	return count, nil
+}
+
+// daxKeysetLess orders records by (ticker, year, id) ascending, the fixed
+// sort order cursor pagination uses regardless of filters.SortBy.
+func daxKeysetLess(a, b DAXRecord) bool {
+	if a.Ticker != b.Ticker {
+		return a.Ticker < b.Ticker
+	}
+	if a.Year != b.Year {
+		return a.Year < b.Year
+	}
+	return a.ID.String() < b.ID.String()
+}
 
-	return filtered[offset:end], totalCount, nil
+// daxKeysetBefore reports whether record's (ticker, year, id) sorts
+// strictly before cursor's.
+func daxKeysetBefore(record DAXRecord, cursor CursorKey) bool {
+	if record.Ticker != cursor.Ticker {
+		return record.Ticker < cursor.Ticker
+	}
+	if record.Year != cursor.Year {
+		return record.Year < cursor.Year
+	}
+	return record.ID.String() < cursor.ID
+}
+
+// daxKeysetAfter reports whether record's (ticker, year, id) sorts
+// strictly after cursor's.
+func daxKeysetAfter(record DAXRecord, cursor CursorKey) bool {
+	if record.Ticker != cursor.Ticker {
+		return record.Ticker > cursor.Ticker
+	}
+	if record.Year != cursor.Year {
+		return record.Year > cursor.Year
+	}
+	return record.ID.String() > cursor.ID
 }
 
 // GetMetrics retrieves unique metrics for a given ticker
-func (r *InMemoryRepository) GetMetrics(ticker string) ([]string, error) {
+func (r *InMemoryRepository) GetMetrics(ctx context.Context, ticker string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -321,8 +1030,118 @@ func (r *InMemoryRepository) GetMetrics(ticker string) ([]string, error) {
 	return metrics, nil
 }
 
+// yearValues collects ticker/metric's non-nil values for every year in
+// years, keyed by year. Caller must hold r.mu.
+func (r *InMemoryRepository) yearValues(ticker, metric string, years []int) map[int]float64 {
+	wanted := make(map[int]bool, len(years))
+	for _, year := range years {
+		wanted[year] = true
+	}
+
+	values := make(map[int]float64)
+	for _, record := range r.records {
+		if record.Ticker == ticker && record.Metric == metric && record.Value != nil && wanted[record.Year] {
+			values[record.Year] = *record.Value
+		}
+	}
+	return values
+}
+
+// GetMetricHistory retrieves ticker/metric's values for [fromYear, toYear].
+func (r *InMemoryRepository) GetMetricHistory(ctx context.Context, ticker, metric string, fromYear, toYear int) ([]DAXPoint, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	values := r.yearValues(ticker, metric, yearRange(fromYear, toYear))
+	if missing := MissingYearsInRange(fromYear, toYear, values); missing != nil {
+		return nil, &MissingYearsError{Ticker: ticker, Metric: metric, Years: missing}
+	}
+	return PointsFromValues(values), nil
+}
+
+// ComputeYoY computes ticker/metric's year-over-year growth for year.
+func (r *InMemoryRepository) ComputeYoY(ctx context.Context, ticker, metric string, year int) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	values := r.yearValues(ticker, metric, []int{year - 1, year})
+
+	var missing []int
+	if _, ok := values[year]; !ok {
+		missing = append(missing, year)
+	}
+	if _, ok := values[year-1]; !ok {
+		missing = append(missing, year-1)
+	}
+	if missing != nil {
+		sort.Ints(missing)
+		return 0, &MissingYearsError{Ticker: ticker, Metric: metric, Years: missing}
+	}
+
+	return ComputeYoY(values[year], values[year-1])
+}
+
+// ComputeCAGR computes ticker/metric's compound annual growth rate between
+// startYear and endYear.
+func (r *InMemoryRepository) ComputeCAGR(ctx context.Context, ticker, metric string, startYear, endYear int) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	values := r.yearValues(ticker, metric, []int{startYear, endYear})
+
+	var missing []int
+	if _, ok := values[startYear]; !ok {
+		missing = append(missing, startYear)
+	}
+	if _, ok := values[endYear]; !ok {
+		missing = append(missing, endYear)
+	}
+	if missing != nil {
+		return 0, &MissingYearsError{Ticker: ticker, Metric: metric, Years: missing}
+	}
+
+	return ComputeCAGR(values[startYear], values[endYear], endYear-startYear)
+}
+
+// Aggregate reduces ticker/metric's values over [fromYear, toYear] with op.
+func (r *InMemoryRepository) Aggregate(ctx context.Context, ticker, metric string, fromYear, toYear int, op AggOp) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	values := r.yearValues(ticker, metric, yearRange(fromYear, toYear))
+	return ComputeAggregate(values, op)
+}
+
+// Stream implements Repository.Stream by paging through
+// FindByFiltersKeyset, which re-sorts and re-scans the map under r.mu.RLock
+// for every batch rather than holding the lock for the whole stream, so a
+// long-running export doesn't starve concurrent writers.
+func (r *InMemoryRepository) Stream(ctx context.Context, filters *Filters, batchSize int) (<-chan DAXRecord, <-chan error) {
+	return streamKeyset(ctx, r, filters, batchSize)
+}
+
 // DeleteAll removes all DAX records (for testing)
-func (r *InMemoryRepository) DeleteAll() error {
+func (r *InMemoryRepository) DeleteAll(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -331,9 +1150,95 @@ func (r *InMemoryRepository) DeleteAll() error {
 }
 
 // Count returns the total number of records
-func (r *InMemoryRepository) Count() (int, error) {
+func (r *InMemoryRepository) Count(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	return len(r.records), nil
 }
+
+// FindAsOf retrieves the records matching filters as they stood at asOf,
+// with pagination. A record that hasn't changed since asOf is used as-is;
+// one that has is replaced by the history revision covering asOf, if any.
+func (r *InMemoryRepository) FindAsOf(ctx context.Context, filters *Filters, asOf time.Time, page, limit int) ([]DAXRecord, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []DAXRecord
+	for _, record := range r.records {
+		if !record.UpdatedAt.After(asOf) && filters.matches(record) {
+			matched = append(matched, *record)
+		}
+	}
+	for _, versions := range r.history {
+		for _, revision := range versions {
+			if !revision.ValidFrom.After(asOf) && revision.ValidTo.After(asOf) && filters.matches(&revision.DAXRecord) {
+				matched = append(matched, revision.DAXRecord)
+			}
+		}
+	}
+
+	sort.Slice(matched, filters.SortLess(matched))
+
+	return PaginateRecords(matched, page, limit), len(matched), nil
+}
+
+// GetRevisions returns every archived version of company/ticker/metric/year,
+// oldest first.
+func (r *InMemoryRepository) GetRevisions(ctx context.Context, company, ticker, metric string, year int) ([]DAXRevision, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions := r.history[historyKey(company, ticker, metric, year)]
+	revisions := make([]DAXRevision, len(versions))
+	copy(revisions, versions)
+	return revisions, nil
+}
+
+// WithTx gives fn a private, copy-on-write snapshot of the repository's
+// records and history. If fn returns nil, the snapshot (including fn's
+// mutations) replaces r.records/r.history atomically; if fn returns an
+// error, the snapshot is discarded and the repository is left exactly as it
+// was, so none of fn's writes are observable afterwards.
+func (r *InMemoryRepository) WithTx(ctx context.Context, fn func(Repository) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.RLock()
+	records := make(map[string]*DAXRecord, len(r.records))
+	for id, record := range r.records {
+		recordCopy := *record
+		records[id] = &recordCopy
+	}
+	history := make(map[string][]DAXRevision, len(r.history))
+	for key, versions := range r.history {
+		history[key] = append([]DAXRevision(nil), versions...)
+	}
+	r.mu.RUnlock()
+
+	txRepo := &InMemoryRepository{records: records, history: history}
+
+	if err := fn(txRepo); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.records = txRepo.records
+	r.history = txRepo.history
+	r.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package dax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRepositoryContract runs the same behavioral contract against every
+// Repository implementation that can be exercised without a live database
+// connection. PostgresRepository and postgres.Repository are deliberately
+// not included here: both are already covered by their own sqlmock-based
+// suites (repository_postgres_test.go and pkg/dax/postgres), where each
+// expected SQL statement is asserted explicitly - folding them into this
+// generic contract would mean either relaxing those per-query expectations
+// or running this contract twice against fundamentally different test
+// doubles, neither of which adds coverage beyond what those suites already
+// give. NewInMemoryRepository is exercised here as the implementation that
+// genuinely has no external dependency to mock.
+func TestRepositoryContract(t *testing.T) {
+	newRepos := map[string]func() Repository{
+		"InMemory": func() Repository { return NewInMemoryRepository() },
+	}
+
+	for name, newRepo := range newRepos {
+		t.Run(name, func(t *testing.T) {
+			runRepositoryContractTests(t, newRepo)
+		})
+	}
+}
+
+// runRepositoryContractTests exercises the core Repository surface - Create,
+// BulkUpsert's insert-then-update-on-duplicate-key semantics, FindAll,
+// FindByFilters, Count and DeleteAll - against a freshly constructed
+// Repository from newRepo, so the same behavioral guarantees can be checked
+// against any implementation that satisfies them without a live database.
+func runRepositoryContractTests(t *testing.T, newRepo func() Repository) {
+	t.Run("Create", func(t *testing.T) {
+		repo := newRepo()
+
+		value := 1000.0
+		record := &DAXRecord{
+			Company:    "Test Company",
+			Ticker:     "TST",
+			ReportType: "income",
+			Metric:     "Revenue",
+			Year:       2025,
+			Value:      &value,
+			Currency:   "EUR",
+		}
+
+		err := repo.Create(context.Background(), record)
+		require.NoError(t, err)
+
+		count, err := repo.Count(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("BulkUpsert_InsertThenUpdate", func(t *testing.T) {
+		repo := newRepo()
+
+		value1 := 1000.0
+		err := repo.BulkUpsert(context.Background(), []DAXRecord{
+			{
+				Company:    "Company A",
+				Ticker:     "AAA",
+				ReportType: "income",
+				Metric:     "Revenue",
+				Year:       2025,
+				Value:      &value1,
+				Currency:   "EUR",
+			},
+		})
+		require.NoError(t, err)
+
+		value2 := 2000.0
+		err = repo.BulkUpsert(context.Background(), []DAXRecord{
+			{
+				Company:    "Company A",
+				Ticker:     "AAA",
+				ReportType: "income",
+				Metric:     "Revenue",
+				Year:       2025,
+				Value:      &value2,
+				Currency:   "USD",
+			},
+		})
+		require.NoError(t, err)
+
+		count, err := repo.Count(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, count, "the second BulkUpsert should update the existing (company, ticker, metric, year) row rather than inserting a second one")
+
+		records, total, err := repo.FindAll(context.Background(), 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, records, 1)
+		assert.Equal(t, "USD", records[0].Currency)
+		assert.Equal(t, value2, *records[0].Value)
+	})
+
+	t.Run("FindByFilters_Ticker", func(t *testing.T) {
+		repo := newRepo()
+
+		value1 := 1000.0
+		value2 := 2000.0
+		err := repo.BulkUpsert(context.Background(), []DAXRecord{
+			{
+				Company:    "Company A",
+				Ticker:     "AAA",
+				ReportType: "income",
+				Metric:     "Revenue",
+				Year:       2025,
+				Value:      &value1,
+				Currency:   "EUR",
+			},
+			{
+				Company:    "Company B",
+				Ticker:     "BBB",
+				ReportType: "income",
+				Metric:     "Revenue",
+				Year:       2025,
+				Value:      &value2,
+				Currency:   "EUR",
+			},
+		})
+		require.NoError(t, err)
+
+		records, total, err := repo.FindByFilters(context.Background(), &Filters{Tickers: []string{"AAA"}}, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, records, 1)
+		assert.Equal(t, "AAA", records[0].Ticker)
+	})
+
+	t.Run("DeleteAll", func(t *testing.T) {
+		repo := newRepo()
+
+		value := 1000.0
+		err := repo.Create(context.Background(), &DAXRecord{
+			Company:    "Test Company",
+			Ticker:     "TST",
+			ReportType: "income",
+			Metric:     "Revenue",
+			Year:       2025,
+			Value:      &value,
+			Currency:   "EUR",
+		})
+		require.NoError(t, err)
+
+		err = repo.DeleteAll(context.Background())
+		require.NoError(t, err)
+
+		count, err := repo.Count(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+}
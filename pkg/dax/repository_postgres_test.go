@@ -1,6 +1,7 @@
 package dax
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -30,17 +31,43 @@ func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
 	return gormDB, mock
 }
 
+// newTestRepository constructs a PostgresRepository against mock, first
+// expecting the HasTable schema check NewPostgresRepository runs.
+func newTestRepository(t *testing.T, gormDB *gorm.DB, mock sqlmock.Sqlmock) *PostgresRepository {
+	mock.ExpectQuery(`SELECT count\(\*\) FROM information_schema\.tables WHERE`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	repo, err := NewPostgresRepository(gormDB)
+	require.NoError(t, err)
+	return repo
+}
+
 func TestNewPostgresRepository(t *testing.T) {
-	gormDB, _ := setupMockDB(t)
-	repo := NewPostgresRepository(gormDB)
+	gormDB, mock := setupMockDB(t)
+	mock.ExpectQuery(`SELECT count\(\*\) FROM information_schema\.tables WHERE`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	repo, err := NewPostgresRepository(gormDB)
 
+	require.NoError(t, err)
 	assert.NotNil(t, repo)
 	assert.Equal(t, gormDB, repo.db)
 }
 
+func TestNewPostgresRepository_MigrationsPending(t *testing.T) {
+	gormDB, mock := setupMockDB(t)
+	mock.ExpectQuery(`SELECT count\(\*\) FROM information_schema\.tables WHERE`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	repo, err := NewPostgresRepository(gormDB)
+
+	assert.Nil(t, repo)
+	assert.ErrorIs(t, err, ErrMigrationsPending)
+}
+
 func TestPostgresRepository_Create(t *testing.T) {
 	gormDB, mock := setupMockDB(t)
-	repo := NewPostgresRepository(gormDB)
+	repo := newTestRepository(t, gormDB, mock)
 
 	value := 1000.0
 	record := &DAXRecord{
@@ -58,7 +85,7 @@ func TestPostgresRepository_Create(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	err := repo.Create(record)
+	err := repo.Create(context.Background(), record)
 
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -66,7 +93,7 @@ func TestPostgresRepository_Create(t *testing.T) {
 
 func TestPostgresRepository_Create_WithExistingID(t *testing.T) {
 	gormDB, mock := setupMockDB(t)
-	repo := NewPostgresRepository(gormDB)
+	repo := newTestRepository(t, gormDB, mock)
 
 	existingID := uuid.New()
 	value := 1000.0
@@ -86,7 +113,7 @@ func TestPostgresRepository_Create_WithExistingID(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	err := repo.Create(record)
+	err := repo.Create(context.Background(), record)
 
 	assert.NoError(t, err)
 	assert.Equal(t, existingID, record.ID)
@@ -94,17 +121,17 @@ func TestPostgresRepository_Create_WithExistingID(t *testing.T) {
 }
 
 func TestPostgresRepository_BulkUpsert_EmptyRecords(t *testing.T) {
-	gormDB, _ := setupMockDB(t)
-	repo := NewPostgresRepository(gormDB)
+	gormDB, mock := setupMockDB(t)
+	repo := newTestRepository(t, gormDB, mock)
 
-	err := repo.BulkUpsert([]DAXRecord{})
+	err := repo.BulkUpsert(context.Background(), []DAXRecord{})
 
 	assert.NoError(t, err)
 }
 
 func TestPostgresRepository_BulkUpsert(t *testing.T) {
 	gormDB, mock := setupMockDB(t)
-	repo := NewPostgresRepository(gormDB)
+	repo := newTestRepository(t, gormDB, mock)
 
 	value := 1000.0
 	records := []DAXRecord{
@@ -116,7 +143,7 @@ func TestPostgresRepository_BulkUpsert(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	err := repo.BulkUpsert(records)
+	err := repo.BulkUpsert(context.Background(), records)
 
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -124,7 +151,7 @@ func TestPostgresRepository_BulkUpsert(t *testing.T) {
 
 func TestPostgresRepository_FindAll(t *testing.T) {
 	gormDB, mock := setupMockDB(t)
-	repo := NewPostgresRepository(gormDB)
+	repo := newTestRepository(t, gormDB, mock)
 
 	id := uuid.New()
 	now := time.Now()
@@ -139,7 +166,7 @@ func TestPostgresRepository_FindAll(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"id", "company", "ticker", "report_type", "metric", "year", "value", "currency", "created_at", "updated_at"}).
 			AddRow(id, "Test AG", "TST", "income", "EBITDA", 2025, value, "EUR", now, now))
 
-	records, total, err := repo.FindAll(1, 10)
+	records, total, err := repo.FindAll(context.Background(), 1, 10)
 
 	assert.NoError(t, err)
 	assert.Equal(t, 1, total)
@@ -150,7 +177,7 @@ func TestPostgresRepository_FindAll(t *testing.T) {
 
 func TestPostgresRepository_FindByFilters_WithTicker(t *testing.T) {
 	gormDB, mock := setupMockDB(t)
-	repo := NewPostgresRepository(gormDB)
+	repo := newTestRepository(t, gormDB, mock)
 
 	id := uuid.New()
 	now := time.Now()
@@ -165,7 +192,7 @@ func TestPostgresRepository_FindByFilters_WithTicker(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"id", "company", "ticker", "report_type", "metric", "year", "value", "currency", "created_at", "updated_at"}).
 			AddRow(id, "Test AG", "TST", "income", "EBITDA", 2025, value, "EUR", now, now))
 
-	records, total, err := repo.FindByFilters("TST", nil, 1, 10)
+	records, total, err := repo.FindByFilters(context.Background(), &Filters{Tickers: []string{"TST"}}, 1, 10)
 
 	assert.NoError(t, err)
 	assert.Equal(t, 1, total)
@@ -175,7 +202,7 @@ func TestPostgresRepository_FindByFilters_WithTicker(t *testing.T) {
 
 func TestPostgresRepository_FindByFilters_WithYear(t *testing.T) {
 	gormDB, mock := setupMockDB(t)
-	repo := NewPostgresRepository(gormDB)
+	repo := newTestRepository(t, gormDB, mock)
 
 	id := uuid.New()
 	now := time.Now()
@@ -191,7 +218,7 @@ func TestPostgresRepository_FindByFilters_WithYear(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"id", "company", "ticker", "report_type", "metric", "year", "value", "currency", "created_at", "updated_at"}).
 			AddRow(id, "Test AG", "TST", "income", "EBITDA", 2025, value, "EUR", now, now))
 
-	records, total, err := repo.FindByFilters("", &year, 1, 10)
+	records, total, err := repo.FindByFilters(context.Background(), &Filters{YearFrom: &year, YearTo: &year}, 1, 10)
 
 	assert.NoError(t, err)
 	assert.Equal(t, 1, total)
@@ -201,7 +228,7 @@ func TestPostgresRepository_FindByFilters_WithYear(t *testing.T) {
 
 func TestPostgresRepository_FindByFilters_WithTickerAndYear(t *testing.T) {
 	gormDB, mock := setupMockDB(t)
-	repo := NewPostgresRepository(gormDB)
+	repo := newTestRepository(t, gormDB, mock)
 
 	id := uuid.New()
 	now := time.Now()
@@ -217,7 +244,7 @@ func TestPostgresRepository_FindByFilters_WithTickerAndYear(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"id", "company", "ticker", "report_type", "metric", "year", "value", "currency", "created_at", "updated_at"}).
 			AddRow(id, "Test AG", "TST", "income", "EBITDA", 2025, value, "EUR", now, now))
 
-	records, total, err := repo.FindByFilters("TST", &year, 1, 10)
+	records, total, err := repo.FindByFilters(context.Background(), &Filters{Tickers: []string{"TST"}, YearFrom: &year, YearTo: &year}, 1, 10)
 
 	assert.NoError(t, err)
 	assert.Equal(t, 1, total)
@@ -227,13 +254,13 @@ func TestPostgresRepository_FindByFilters_WithTickerAndYear(t *testing.T) {
 
 func TestPostgresRepository_GetMetrics(t *testing.T) {
 	gormDB, mock := setupMockDB(t)
-	repo := NewPostgresRepository(gormDB)
+	repo := newTestRepository(t, gormDB, mock)
 
 	mock.ExpectQuery(`SELECT DISTINCT "metric" FROM "dax" WHERE ticker = \$1`).
 		WithArgs("TST").
 		WillReturnRows(sqlmock.NewRows([]string{"metric"}).AddRow("EBITDA").AddRow("Revenue"))
 
-	metrics, err := repo.GetMetrics("TST")
+	metrics, err := repo.GetMetrics(context.Background(), "TST")
 
 	assert.NoError(t, err)
 	assert.Len(t, metrics, 2)
@@ -244,12 +271,12 @@ func TestPostgresRepository_GetMetrics(t *testing.T) {
 
 func TestPostgresRepository_DeleteAll(t *testing.T) {
 	gormDB, mock := setupMockDB(t)
-	repo := NewPostgresRepository(gormDB)
+	repo := newTestRepository(t, gormDB, mock)
 
 	mock.ExpectExec(`DELETE FROM dax`).
 		WillReturnResult(sqlmock.NewResult(0, 5))
 
-	err := repo.DeleteAll()
+	err := repo.DeleteAll(context.Background())
 
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -257,12 +284,12 @@ func TestPostgresRepository_DeleteAll(t *testing.T) {
 
 func TestPostgresRepository_Count(t *testing.T) {
 	gormDB, mock := setupMockDB(t)
-	repo := NewPostgresRepository(gormDB)
+	repo := newTestRepository(t, gormDB, mock)
 
 	mock.ExpectQuery(`SELECT count\(\*\) FROM "dax"`).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
 
-	count, err := repo.Count()
+	count, err := repo.Count(context.Background())
 
 	assert.NoError(t, err)
 	assert.Equal(t, 42, count)
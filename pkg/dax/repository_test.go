@@ -1,7 +1,10 @@
 package dax
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -22,11 +25,11 @@ func TestInMemoryRepository_Create(t *testing.T) {
 		Currency:   "EUR",
 	}
 
-	err := repo.Create(record)
+	err := repo.Create(context.Background(), record)
 	assert.NoError(t, err)
 	assert.NotEqual(t, uuid.Nil, record.ID)
 
-	count, err := repo.Count()
+	count, err := repo.Count(context.Background())
 	assert.NoError(t, err)
 	assert.Equal(t, 1, count)
 }
@@ -47,7 +50,7 @@ func TestInMemoryRepository_Create_WithID(t *testing.T) {
 		Currency:   "EUR",
 	}
 
-	err := repo.Create(record)
+	err := repo.Create(context.Background(), record)
 	assert.NoError(t, err)
 	assert.Equal(t, existingID, record.ID)
 }
@@ -78,10 +81,10 @@ func TestInMemoryRepository_BulkUpsert_Insert(t *testing.T) {
 		},
 	}
 
-	err := repo.BulkUpsert(records)
+	err := repo.BulkUpsert(context.Background(), records)
 	assert.NoError(t, err)
 
-	count, err := repo.Count()
+	count, err := repo.Count(context.Background())
 	assert.NoError(t, err)
 	assert.Equal(t, 2, count)
 }
@@ -102,7 +105,7 @@ func TestInMemoryRepository_BulkUpsert_Update(t *testing.T) {
 		},
 	}
 
-	err := repo.BulkUpsert(records1)
+	err := repo.BulkUpsert(context.Background(), records1)
 	require.NoError(t, err)
 
 	value2 := 2000.0
@@ -118,14 +121,14 @@ func TestInMemoryRepository_BulkUpsert_Update(t *testing.T) {
 		},
 	}
 
-	err = repo.BulkUpsert(records2)
+	err = repo.BulkUpsert(context.Background(), records2)
 	assert.NoError(t, err)
 
-	count, err := repo.Count()
+	count, err := repo.Count(context.Background())
 	assert.NoError(t, err)
 	assert.Equal(t, 1, count)
 
-	allRecords, total, err := repo.FindAll(1, 10)
+	allRecords, total, err := repo.FindAll(context.Background(), 1, 10)
 	require.NoError(t, err)
 	assert.Equal(t, 1, total)
 	assert.Equal(t, "USD", allRecords[0].Currency)
@@ -135,10 +138,10 @@ func TestInMemoryRepository_BulkUpsert_Update(t *testing.T) {
 func TestInMemoryRepository_BulkUpsert_EmptySlice(t *testing.T) {
 	repo := NewInMemoryRepository()
 
-	err := repo.BulkUpsert([]DAXRecord{})
+	err := repo.BulkUpsert(context.Background(), []DAXRecord{})
 	assert.NoError(t, err)
 
-	count, err := repo.Count()
+	count, err := repo.Count(context.Background())
 	assert.NoError(t, err)
 	assert.Equal(t, 0, count)
 }
@@ -179,10 +182,10 @@ func TestInMemoryRepository_FindAll(t *testing.T) {
 		},
 	}
 
-	err := repo.BulkUpsert(records)
+	err := repo.BulkUpsert(context.Background(), records)
 	require.NoError(t, err)
 
-	result, total, err := repo.FindAll(1, 10)
+	result, total, err := repo.FindAll(context.Background(), 1, 10)
 	assert.NoError(t, err)
 	assert.Equal(t, 3, total)
 	assert.Equal(t, 3, len(result))
@@ -201,7 +204,7 @@ func TestInMemoryRepository_FindAll_Pagination(t *testing.T) {
 	// Create 5 records
 	for i := 1; i <= 5; i++ {
 		value := float64(i * 1000)
-		err := repo.Create(&DAXRecord{
+		err := repo.Create(context.Background(), &DAXRecord{
 			Company:    "Company",
 			Ticker:     "TST",
 			ReportType: "income",
@@ -214,25 +217,25 @@ func TestInMemoryRepository_FindAll_Pagination(t *testing.T) {
 	}
 
 	// Page 1: 2 items
-	result, total, err := repo.FindAll(1, 2)
+	result, total, err := repo.FindAll(context.Background(), 1, 2)
 	assert.NoError(t, err)
 	assert.Equal(t, 5, total)
 	assert.Equal(t, 2, len(result))
 
 	// Page 2: 2 items
-	result, total, err = repo.FindAll(2, 2)
+	result, total, err = repo.FindAll(context.Background(), 2, 2)
 	assert.NoError(t, err)
 	assert.Equal(t, 5, total)
 	assert.Equal(t, 2, len(result))
 
 	// Page 3: 1 item
-	result, total, err = repo.FindAll(3, 2)
+	result, total, err = repo.FindAll(context.Background(), 3, 2)
 	assert.NoError(t, err)
 	assert.Equal(t, 5, total)
 	assert.Equal(t, 1, len(result))
 
 	// Page 4: 0 items (beyond range)
-	result, total, err = repo.FindAll(4, 2)
+	result, total, err = repo.FindAll(context.Background(), 4, 2)
 	assert.NoError(t, err)
 	assert.Equal(t, 5, total)
 	assert.Equal(t, 0, len(result))
@@ -264,10 +267,10 @@ func TestInMemoryRepository_FindByFilters_TickerOnly(t *testing.T) {
 		},
 	}
 
-	err := repo.BulkUpsert(records)
+	err := repo.BulkUpsert(context.Background(), records)
 	require.NoError(t, err)
 
-	result, total, err := repo.FindByFilters("AAA", nil, 1, 10)
+	result, total, err := repo.FindByFilters(context.Background(), &Filters{Tickers: []string{"AAA"}}, 1, 10)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, total)
 	assert.Equal(t, 1, len(result))
@@ -300,11 +303,11 @@ func TestInMemoryRepository_FindByFilters_YearOnly(t *testing.T) {
 		},
 	}
 
-	err := repo.BulkUpsert(records)
+	err := repo.BulkUpsert(context.Background(), records)
 	require.NoError(t, err)
 
 	year := 2025
-	result, total, err := repo.FindByFilters("", &year, 1, 10)
+	result, total, err := repo.FindByFilters(context.Background(), &Filters{YearFrom: &year, YearTo: &year}, 1, 10)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, total)
 	assert.Equal(t, 1, len(result))
@@ -347,11 +350,11 @@ func TestInMemoryRepository_FindByFilters_TickerAndYear(t *testing.T) {
 		},
 	}
 
-	err := repo.BulkUpsert(records)
+	err := repo.BulkUpsert(context.Background(), records)
 	require.NoError(t, err)
 
 	year := 2025
-	result, total, err := repo.FindByFilters("AAA", &year, 1, 10)
+	result, total, err := repo.FindByFilters(context.Background(), &Filters{Tickers: []string{"AAA"}, YearFrom: &year, YearTo: &year}, 1, 10)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, total)
 	assert.Equal(t, 1, len(result))
@@ -375,10 +378,10 @@ func TestInMemoryRepository_FindByFilters_NoMatch(t *testing.T) {
 		},
 	}
 
-	err := repo.BulkUpsert(records)
+	err := repo.BulkUpsert(context.Background(), records)
 	require.NoError(t, err)
 
-	result, total, err := repo.FindByFilters("XXX", nil, 1, 10)
+	result, total, err := repo.FindByFilters(context.Background(), &Filters{Tickers: []string{"XXX"}}, 1, 10)
 	assert.NoError(t, err)
 	assert.Equal(t, 0, total)
 	assert.Equal(t, 0, len(result))
@@ -420,10 +423,10 @@ func TestInMemoryRepository_GetMetrics(t *testing.T) {
 		},
 	}
 
-	err := repo.BulkUpsert(records)
+	err := repo.BulkUpsert(context.Background(), records)
 	require.NoError(t, err)
 
-	metrics, err := repo.GetMetrics("AAA")
+	metrics, err := repo.GetMetrics(context.Background(), "AAA")
 	assert.NoError(t, err)
 	assert.Equal(t, 2, len(metrics))
 	assert.Contains(t, metrics, "Revenue")
@@ -449,10 +452,10 @@ func TestInMemoryRepository_GetMetrics_NoMatch(t *testing.T) {
 		},
 	}
 
-	err := repo.BulkUpsert(records)
+	err := repo.BulkUpsert(context.Background(), records)
 	require.NoError(t, err)
 
-	metrics, err := repo.GetMetrics("XXX")
+	metrics, err := repo.GetMetrics(context.Background(), "XXX")
 	assert.NoError(t, err)
 	assert.Equal(t, 0, len(metrics))
 }
@@ -473,17 +476,17 @@ func TestInMemoryRepository_DeleteAll(t *testing.T) {
 		},
 	}
 
-	err := repo.BulkUpsert(records)
+	err := repo.BulkUpsert(context.Background(), records)
 	require.NoError(t, err)
 
-	count, err := repo.Count()
+	count, err := repo.Count(context.Background())
 	require.NoError(t, err)
 	assert.Equal(t, 1, count)
 
-	err = repo.DeleteAll()
+	err = repo.DeleteAll(context.Background())
 	assert.NoError(t, err)
 
-	count, err = repo.Count()
+	count, err = repo.Count(context.Background())
 	assert.NoError(t, err)
 	assert.Equal(t, 0, count)
 }
@@ -491,7 +494,7 @@ func TestInMemoryRepository_DeleteAll(t *testing.T) {
 func TestInMemoryRepository_Count(t *testing.T) {
 	repo := NewInMemoryRepository()
 
-	count, err := repo.Count()
+	count, err := repo.Count(context.Background())
 	assert.NoError(t, err)
 	assert.Equal(t, 0, count)
 
@@ -518,10 +521,95 @@ func TestInMemoryRepository_Count(t *testing.T) {
 		},
 	}
 
-	err = repo.BulkUpsert(records)
+	err = repo.BulkUpsert(context.Background(), records)
 	require.NoError(t, err)
 
-	count, err = repo.Count()
+	count, err = repo.Count(context.Background())
 	assert.NoError(t, err)
 	assert.Equal(t, 2, count)
 }
+
+func TestInMemoryRepository_GetRevisions(t *testing.T) {
+	repo := NewInMemoryRepository()
+
+	value1 := 1000.0
+	err := repo.BulkUpsert(context.Background(), []DAXRecord{{
+		Company: "Company A", Ticker: "AAA", Metric: "Revenue", Year: 2025, Value: &value1, Currency: "EUR",
+	}})
+	require.NoError(t, err)
+
+	revisions, err := repo.GetRevisions(context.Background(), "Company A", "AAA", "Revenue", 2025)
+	require.NoError(t, err)
+	assert.Empty(t, revisions, "no revision is archived until the first value is superseded")
+
+	value2 := 2000.0
+	err = repo.BulkUpsert(context.Background(), []DAXRecord{{
+		Company: "Company A", Ticker: "AAA", Metric: "Revenue", Year: 2025, Value: &value2, Currency: "USD",
+	}})
+	require.NoError(t, err)
+
+	revisions, err = repo.GetRevisions(context.Background(), "Company A", "AAA", "Revenue", 2025)
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	assert.Equal(t, value1, *revisions[0].Value)
+	assert.Equal(t, "EUR", revisions[0].Currency)
+	assert.Equal(t, "update", revisions[0].Operation)
+}
+
+func TestInMemoryRepository_FindAsOf(t *testing.T) {
+	repo := NewInMemoryRepository()
+
+	value1 := 1000.0
+	err := repo.BulkUpsert(context.Background(), []DAXRecord{{
+		Company: "Company A", Ticker: "AAA", Metric: "Revenue", Year: 2025, Value: &value1, Currency: "EUR",
+	}})
+	require.NoError(t, err)
+
+	beforeUpdate := time.Now()
+
+	value2 := 2000.0
+	err = repo.BulkUpsert(context.Background(), []DAXRecord{{
+		Company: "Company A", Ticker: "AAA", Metric: "Revenue", Year: 2025, Value: &value2, Currency: "USD",
+	}})
+	require.NoError(t, err)
+
+	asOfRecords, total, err := repo.FindAsOf(context.Background(), nil, beforeUpdate, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	assert.Equal(t, value1, *asOfRecords[0].Value)
+
+	current, total, err := repo.FindAsOf(context.Background(), nil, time.Now(), 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	assert.Equal(t, value2, *current[0].Value)
+}
+
+func TestInMemoryRepository_WithTx_CommitsOnSuccess(t *testing.T) {
+	repo := NewInMemoryRepository()
+
+	err := repo.WithTx(context.Background(), func(tx Repository) error {
+		return tx.Create(context.Background(), &DAXRecord{Company: "Company A", Ticker: "AAA", Metric: "Revenue", Year: 2025})
+	})
+	require.NoError(t, err)
+
+	count, err := repo.Count(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestInMemoryRepository_WithTx_RollsBackOnError(t *testing.T) {
+	repo := NewInMemoryRepository()
+	wantErr := errors.New("boom")
+
+	err := repo.WithTx(context.Background(), func(tx Repository) error {
+		if err := tx.Create(context.Background(), &DAXRecord{Company: "Company A", Ticker: "AAA", Metric: "Revenue", Year: 2025}); err != nil {
+			return err
+		}
+		return wantErr
+	})
+	assert.ErrorIs(t, err, wantErr)
+
+	count, err := repo.Count(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count, "fn's Create must not be visible after fn returns an error")
+}
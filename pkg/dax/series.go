@@ -0,0 +1,98 @@
+package dax
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrUnknownResample is returned by ParseResamplePeriod for a resample
+// string other than monthly, quarterly, or yearly.
+var ErrUnknownResample = errors.New("unknown resample period")
+
+// ResamplePeriod selects the bucket width ComputeSeries groups records
+// into.
+type ResamplePeriod string
+
+// Resample periods accepted by the /dax/series endpoint's resample
+// parameter.
+const (
+	ResampleMonthly   ResamplePeriod = "monthly"
+	ResampleQuarterly ResamplePeriod = "quarterly"
+	ResampleYearly    ResamplePeriod = "yearly"
+)
+
+// seriesResampleNote documents why monthly/quarterly resampling produces
+// the same buckets as yearly: DAXRecord stores one value per
+// (ticker, metric, year), with no sub-year date to resample against.
+const seriesResampleNote = "dax records are stored at yearly granularity; monthly/quarterly resampling is a no-op until the schema carries a sub-year date and falls back to one point per year"
+
+// ParseResamplePeriod parses the /dax/series endpoint's resample query
+// parameter, defaulting to ResampleYearly for an empty string.
+func ParseResamplePeriod(s string) (ResamplePeriod, error) {
+	switch ResamplePeriod(s) {
+	case "":
+		return ResampleYearly, nil
+	case ResampleMonthly, ResampleQuarterly, ResampleYearly:
+		return ResamplePeriod(s), nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownResample, s)
+	}
+}
+
+// SeriesPoint is a single resampled bucket of SeriesResponse.
+type SeriesPoint struct {
+	Period string  `json:"period"`
+	Value  float64 `json:"value"`
+}
+
+// SeriesResponse is GetSeries' response body.
+type SeriesResponse struct {
+	Metric   string        `json:"metric"`
+	Resample string        `json:"resample"`
+	Points   []SeriesPoint `json:"points"`
+	// Note documents a resample accuracy/granularity tradeoff, as
+	// StatResult.Note does for stats aggregates. Empty when resample
+	// matches the records' stored granularity.
+	Note string `json:"note,omitempty"`
+}
+
+// ComputeSeries streams every record matching filters through
+// repo.Stream and buckets metric's values by year, labeling each bucket
+// per resample. Since DAXRecord's stored granularity is already yearly,
+// ResampleMonthly and ResampleQuarterly produce identical buckets to
+// ResampleYearly; SeriesResponse.Note says so rather than silently
+// pretending finer granularity exists.
+func ComputeSeries(ctx context.Context, repo Repository, filters *Filters, metric string, resample ResamplePeriod) (*SeriesResponse, error) {
+	records, errs := repo.Stream(ctx, filters, 0)
+
+	values := make(map[int]float64)
+	for record := range records {
+		if record.Value == nil {
+			continue
+		}
+		values[record.Year] = *record.Value
+	}
+	if err := <-errs; err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, ErrNoData
+	}
+
+	years := make([]int, 0, len(values))
+	for year := range values {
+		years = append(years, year)
+	}
+	sort.Ints(years)
+
+	resp := &SeriesResponse{Metric: metric, Resample: string(resample), Points: make([]SeriesPoint, 0, len(years))}
+	for _, year := range years {
+		resp.Points = append(resp.Points, SeriesPoint{Period: fmt.Sprintf("%d", year), Value: values[year]})
+	}
+	if resample != ResampleYearly {
+		resp.Note = seriesResampleNote
+	}
+	return resp, nil
+}
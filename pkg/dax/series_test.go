@@ -0,0 +1,62 @@
+package dax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseResamplePeriod(t *testing.T) {
+	period, err := ParseResamplePeriod("")
+	require.NoError(t, err)
+	assert.Equal(t, ResampleYearly, period)
+
+	period, err = ParseResamplePeriod("quarterly")
+	require.NoError(t, err)
+	assert.Equal(t, ResampleQuarterly, period)
+
+	_, err = ParseResamplePeriod("weekly")
+	assert.ErrorIs(t, err, ErrUnknownResample)
+}
+
+func TestComputeSeries(t *testing.T) {
+	repo := NewInMemoryRepository()
+	require.NoError(t, repo.BulkUpsert(context.Background(), []DAXRecord{
+		{Ticker: "SIE", Metric: "EBITDA", Year: 2022, Value: ptrFloat(10)},
+		{Ticker: "SIE", Metric: "EBITDA", Year: 2024, Value: ptrFloat(30)},
+		{Ticker: "SIE", Metric: "EBITDA", Year: 2023, Value: ptrFloat(20)},
+	}))
+
+	resp, err := ComputeSeries(context.Background(), repo, &Filters{Tickers: []string{"SIE"}, Metrics: []string{"EBITDA"}}, "EBITDA", ResampleYearly)
+
+	require.NoError(t, err)
+	assert.Empty(t, resp.Note)
+	require.Len(t, resp.Points, 3)
+	assert.Equal(t, []SeriesPoint{
+		{Period: "2022", Value: 10},
+		{Period: "2023", Value: 20},
+		{Period: "2024", Value: 30},
+	}, resp.Points)
+}
+
+func TestComputeSeries_MonthlyResampleNotesGranularityLimit(t *testing.T) {
+	repo := NewInMemoryRepository()
+	require.NoError(t, repo.BulkUpsert(context.Background(), []DAXRecord{
+		{Ticker: "SIE", Metric: "EBITDA", Year: 2024, Value: ptrFloat(30)},
+	}))
+
+	resp, err := ComputeSeries(context.Background(), repo, &Filters{Tickers: []string{"SIE"}, Metrics: []string{"EBITDA"}}, "EBITDA", ResampleMonthly)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.Note)
+}
+
+func TestComputeSeries_NoData(t *testing.T) {
+	repo := NewInMemoryRepository()
+
+	_, err := ComputeSeries(context.Background(), repo, &Filters{Tickers: []string{"SIE"}, Metrics: []string{"EBITDA"}}, "EBITDA", ResampleYearly)
+
+	assert.ErrorIs(t, err, ErrNoData)
+}
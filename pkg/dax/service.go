@@ -1,77 +1,253 @@
 package dax
 
 import (
-	"encoding/csv"
+	"context"
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
 	"strings"
 )
 
+// defaultImportBatchSize is the flush size used when
+// ImportCSVOptions.BatchSize is unset.
+const defaultImportBatchSize = 1000
+
 // Service provides business logic for DAX operations
 type Service struct {
-	repo Repository
+	repo          Repository
+	aliasResolver AliasResolver
 }
 
-// NewService creates a new DAX service
+// NewService creates a new DAX service with alias resolution disabled (raw
+// ticker values are used as-is). Use NewServiceWithAliases to canonicalize
+// tickers via an AliasResolver.
 func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+	return &Service{repo: repo, aliasResolver: NoopAliasResolver{}}
 }
 
-// ImportCSV imports DAX data from CSV content
-func (s *Service) ImportCSV(reader io.Reader) (*ImportResponse, error) {
-	csvReader := csv.NewReader(reader)
+// NewServiceWithAliases creates a new DAX service that canonicalizes
+// tickers through resolver during import and expands them to every known
+// alias when querying.
+func NewServiceWithAliases(repo Repository, resolver AliasResolver) *Service {
+	return &Service{repo: repo, aliasResolver: resolver}
+}
 
-	// Read header
-	header, err := csvReader.Read()
-	if err != nil {
-		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+// ImportCSV imports DAX data from CSV content. It is a thin wrapper around
+// Import using CSVImporter, kept as its own method since it predates the
+// pluggable Importer abstraction and many callers still depend on its
+// signature.
+func (s *Service) ImportCSV(ctx context.Context, reader io.Reader, opts ImportCSVOptions) (*ImportResponse, error) {
+	return s.importWithImporter(ctx, CSVImporter{}, reader, opts)
+}
+
+// Import parses reader with the Importer registered for format (a
+// Content-Type string such as "application/json" or "application/x-ndjson")
+// and persists the resulting records the same way ImportCSV does.
+func (s *Service) Import(ctx context.Context, reader io.Reader, format string, opts ImportCSVOptions) (*ImportResponse, error) {
+	importer := ImporterForContentType(format)
+	if importer == nil {
+		return nil, fmt.Errorf("unsupported import format: %s", format)
 	}
+	return s.importWithImporter(ctx, importer, reader, opts)
+}
 
-	// Validate header
-	if err := validateHeader(header); err != nil {
+// importWithImporter parses reader via importer, then canonicalizes and
+// persists the resulting records according to opts.Mode. When
+// opts.ContinueOnError is set, bad rows are recorded in the response's
+// Errors instead of aborting the import. When opts.DryRun is set, rows are
+// parsed and validated but never persisted.
+func (s *Service) importWithImporter(ctx context.Context, importer Importer, reader io.Reader, opts ImportCSVOptions) (*ImportResponse, error) {
+	records, rowErrors, err := importer.Parse(reader)
+	if err != nil {
 		return nil, err
 	}
 
-	// Parse rows
-	records := []DAXRecord{}
-	rowNum := 1
+	if !opts.ContinueOnError && len(rowErrors) > 0 {
+		first := rowErrors[0]
+		return nil, fmt.Errorf("invalid data at row %d: %s", first.RowNum, first.Err)
+	}
 
-	for {
-		row, err := csvReader.Read()
-		if err == io.EOF {
-			break
+	if len(records) == 0 && len(rowErrors) == 0 {
+		return nil, fmt.Errorf("no records found in import")
+	}
+
+	if opts.OnParsed != nil {
+		opts.OnParsed(len(records) + len(rowErrors))
+	}
+
+	for i := range records {
+		records[i].Ticker = s.aliasResolver.Resolve(AliasKindTicker, records[i].Ticker)
+	}
+
+	if opts.DryRun {
+		return s.dryRunSummary(ctx, records, rowErrors)
+	}
+
+	response := &ImportResponse{
+		RecordsFailed: len(rowErrors),
+		Errors:        rowErrors,
+	}
+
+	switch opts.Mode {
+	case ImportModeUpsert, ImportModeInsert:
+		if err := s.importRecordByRecord(ctx, records, opts, response); err != nil {
+			return nil, err
 		}
-		if err != nil {
-			return nil, fmt.Errorf("failed to read CSV row %d: %w", rowNum, err)
+	default:
+		if err := s.importInBatches(ctx, records, opts, response); err != nil {
+			return nil, err
 		}
+	}
 
-		record, err := parseCSVRow(row)
-		if err != nil {
-			return nil, fmt.Errorf("invalid data at row %d: %w", rowNum, err)
+	response.Message = fmt.Sprintf("Successfully imported %d records", response.RecordsImported)
+	return response, nil
+}
+
+// importInBatches is the ImportModeReplace persistence path: records are
+// flushed through repo.BulkUpsertBatch in opts.BatchSize-sized batches,
+// blindly overwriting any row with a conflicting natural key.
+func (s *Service) importInBatches(ctx context.Context, records []DAXRecord, opts ImportCSVOptions, response *ImportResponse) error {
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultImportBatchSize
+	}
+
+	batch := make([]DAXRecord, 0, batchSize)
+	rowNum := 0
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := s.repo.BulkUpsertBatch(ctx, batch); err != nil {
+			return fmt.Errorf("failed to import batch ending at row %d: %w", rowNum, err)
+		}
+		batch = batch[:0]
+		if opts.OnProgress != nil {
+			opts.OnProgress(response.RecordsImported)
+		}
+		return nil
+	}
+
+	for _, record := range records {
+		if err := ctx.Err(); err != nil {
+			return err
 		}
 
-		records = append(records, record)
+		batch = append(batch, record)
+		response.RecordsImported++
 		rowNum++
+
+		if len(batch) >= batchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
 	}
 
-	if len(records) == 0 {
-		return nil, fmt.Errorf("no records found in CSV")
+	return flush()
+}
+
+// importRecordByRecord is the ImportModeUpsert/ImportModeInsert persistence
+// path: each record is written individually via repo.Upsert or repo.Create,
+// so ImportModeInsert can fail a single conflicting row (via Create's
+// natural-key constraint) instead of silently overwriting it the way
+// ImportModeReplace does.
+func (s *Service) importRecordByRecord(ctx context.Context, records []DAXRecord, opts ImportCSVOptions, response *ImportResponse) error {
+	for _, record := range records {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		record := record
+		var writeErr error
+		if opts.Mode == ImportModeInsert {
+			writeErr = s.repo.Create(ctx, &record)
+		} else {
+			_, writeErr = s.repo.Upsert(ctx, &record)
+		}
+
+		if writeErr != nil {
+			if !opts.ContinueOnError {
+				return fmt.Errorf("failed to import record for %s/%s/%d: %w", record.Ticker, record.Metric, record.Year, writeErr)
+			}
+			response.RecordsFailed++
+			response.Errors = append(response.Errors, RowError{
+				RawLine: fmt.Sprintf("%s,%s,%s,%d", record.Company, record.Ticker, record.Metric, record.Year),
+				Err:     writeErr.Error(),
+			})
+			continue
+		}
+
+		response.RecordsImported++
+		if opts.OnProgress != nil {
+			opts.OnProgress(response.RecordsImported)
+		}
 	}
 
-	// Bulk insert with upsert
-	if err := s.repo.BulkUpsert(records); err != nil {
-		return nil, fmt.Errorf("failed to import records: %w", err)
+	return nil
+}
+
+// dryRunSummary classifies already-parsed records without persisting them,
+// reporting how many would be freshly inserted, would update an existing
+// row, or would be skipped because an earlier row in the same upload
+// already claims their natural key.
+func (s *Service) dryRunSummary(ctx context.Context, records []DAXRecord, rowErrors []RowError) (*ImportResponse, error) {
+	response := &ImportResponse{
+		RecordsFailed: len(rowErrors),
+		Errors:        rowErrors,
 	}
 
-	return &ImportResponse{
-		RecordsImported: len(records),
-		Message:         fmt.Sprintf("Successfully imported %d records", len(records)),
-	}, nil
+	seen := make(map[daxKey]bool, len(records))
+	for _, record := range records {
+		key := daxKey{Company: record.Company, Ticker: record.Ticker, Metric: record.Metric, Year: record.Year}
+		if seen[key] {
+			response.WouldSkip++
+			continue
+		}
+		seen[key] = true
+
+		exists, err := s.recordExists(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			response.WouldUpdate++
+		} else {
+			response.WouldInsert++
+		}
+	}
+
+	response.Message = fmt.Sprintf("Dry run: would insert %d, update %d, skip %d records",
+		response.WouldInsert, response.WouldUpdate, response.WouldSkip)
+	return response, nil
+}
+
+// recordExists reports whether a row already occupies key's natural key,
+// without persisting anything.
+func (s *Service) recordExists(ctx context.Context, key daxKey) (bool, error) {
+	year := key.Year
+	existing, _, err := s.repo.FindByFilters(ctx, &Filters{
+		Tickers:  []string{key.Ticker},
+		Metrics:  []string{key.Metric},
+		YearFrom: &year,
+		YearTo:   &year,
+	}, 1, 100)
+	if err != nil {
+		return false, err
+	}
+
+	for _, record := range existing {
+		if record.Company == key.Company {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 // GetAll retrieves all DAX records with pagination
-func (s *Service) GetAll(page, limit int) (*PaginatedResponse, error) {
+func (s *Service) GetAll(ctx context.Context, page, limit int) (*PaginatedResponse, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -79,7 +255,7 @@ func (s *Service) GetAll(page, limit int) (*PaginatedResponse, error) {
 		limit = 10
 	}
 
-	records, total, err := s.repo.FindAll(page, limit)
+	records, total, err := s.repo.FindAll(ctx, page, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -97,8 +273,13 @@ func (s *Service) GetAll(page, limit int) (*PaginatedResponse, error) {
 	}, nil
 }
 
-// GetByFilters retrieves DAX records filtered by ticker and/or year
-func (s *Service) GetByFilters(ticker string, year *int, page, limit int) (*PaginatedResponse, error) {
+// GetByFilters retrieves DAX records matching filters, with pagination. A
+// nil filters matches every record.
+func (s *Service) GetByFilters(ctx context.Context, filters *Filters, page, limit int) (*PaginatedResponse, error) {
+	if err := filters.Validate(); err != nil {
+		return nil, err
+	}
+
 	if page < 1 {
 		page = 1
 	}
@@ -106,7 +287,7 @@ func (s *Service) GetByFilters(ticker string, year *int, page, limit int) (*Pagi
 		limit = 10
 	}
 
-	records, total, err := s.repo.FindByFilters(ticker, year, page, limit)
+	records, total, err := s.repo.FindByFilters(ctx, s.expandTickerAliases(filters), page, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -124,33 +305,198 @@ func (s *Service) GetByFilters(ticker string, year *int, page, limit int) (*Pagi
 	}, nil
 }
 
-// GetMetrics retrieves available metrics for a ticker
-func (s *Service) GetMetrics(ticker string) (*MetricsResponse, error) {
-	if ticker == "" {
-		return nil, fmt.Errorf("ticker is required")
+// CursorPage is one page of a cursor-paginated DAX listing.
+type CursorPage struct {
+	Data       []DAXRecord
+	NextCursor string
+	PrevCursor string
+	TotalCount int
+}
+
+// DefaultCursorPageLimit is the page size GetByFiltersCursor falls back to
+// when the caller doesn't request one.
+const DefaultCursorPageLimit = 20
+
+// GetByFiltersCursor retrieves DAX records matching filters using opaque,
+// HMAC-signed cursor pagination instead of GetByFilters's page/limit:
+// every page is ordered by (ticker, year, id), so unlike a page number it
+// doesn't shift when records are inserted or deleted elsewhere in the
+// table. key signs and verifies the cursor; an empty encodedCursor starts
+// from the first page, and an encodedCursor that doesn't verify against
+// key returns ErrInvalidCursor.
+func (s *Service) GetByFiltersCursor(ctx context.Context, filters *Filters, key []byte, encodedCursor string, limit int) (*CursorPage, error) {
+	if err := filters.Validate(); err != nil {
+		return nil, err
 	}
+	if limit <= 0 {
+		limit = DefaultCursorPageLimit
+	}
+
+	var cursor *CursorKey
+	if encodedCursor != "" {
+		decoded, err := decodeCursor(key, encodedCursor)
+		if err != nil {
+			return nil, err
+		}
+		cursor = &decoded
+	}
+
+	expanded := s.expandTickerAliases(filters)
 
-	metrics, err := s.repo.GetMetrics(ticker)
+	records, hasNext, hasPrev, err := s.repo.FindByFiltersKeyset(ctx, expanded, cursor, limit)
 	if err != nil {
 		return nil, err
 	}
 
+	total, err := s.repo.CountFiltered(ctx, expanded)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &CursorPage{Data: records, TotalCount: total}
+	if len(records) == 0 {
+		return page, nil
+	}
+
+	if hasNext {
+		last := records[len(records)-1]
+		next, err := encodeCursor(key, CursorKey{Ticker: last.Ticker, Year: last.Year, ID: last.ID.String()})
+		if err != nil {
+			return nil, err
+		}
+		page.NextCursor = next
+	}
+
+	if hasPrev {
+		first := records[0]
+		prev, err := encodeCursor(key, CursorKey{Ticker: first.Ticker, Year: first.Year, ID: first.ID.String(), Before: true})
+		if err != nil {
+			return nil, err
+		}
+		page.PrevCursor = prev
+	}
+
+	return page, nil
+}
+
+// Export streams every record matching filters through serialize to w,
+// fetching batchSize records from the repository at a time. Unlike
+// GetAll/GetByFilters/GetByFiltersCursor it has no page limit, so it's the
+// path for a caller (a BI tool, a notebook) that wants the whole filtered
+// dataset rather than one page of it.
+func (s *Service) Export(ctx context.Context, filters *Filters, batchSize int, serialize ExportSerializer, w io.Writer) error {
+	if err := filters.Validate(); err != nil {
+		return err
+	}
+
+	records, errs := s.repo.Stream(ctx, s.expandTickerAliases(filters), batchSize)
+	if err := serialize(w, records); err != nil {
+		return err
+	}
+	return <-errs
+}
+
+// Stats computes a single aggregate (mean, min, max, stddev, or a
+// percentile) over metric's values across every record matching filters,
+// streaming rather than loading the full result set - see ComputeStat.
+func (s *Service) Stats(ctx context.Context, filters *Filters, agg StatAgg, percentile float64) (*StatResult, error) {
+	if err := filters.Validate(); err != nil {
+		return nil, err
+	}
+	return ComputeStat(ctx, s.repo, s.expandTickerAliases(filters), agg, percentile)
+}
+
+// Series returns metric's values across every record matching filters as
+// a time series, resampled per resample - see ComputeSeries.
+func (s *Service) Series(ctx context.Context, filters *Filters, metric string, resample ResamplePeriod) (*SeriesResponse, error) {
+	if err := filters.Validate(); err != nil {
+		return nil, err
+	}
+	return ComputeSeries(ctx, s.repo, s.expandTickerAliases(filters), metric, resample)
+}
+
+// Analytics computes an AnalyticsReport (CAGR, YoY growth, volatility,
+// Sharpe/Sortino, max drawdown, and linear trend) for metric across every
+// record matching filters - see ComputeAnalyticsReport.
+func (s *Service) Analytics(ctx context.Context, filters *Filters, metric string) (*AnalyticsReport, error) {
+	if err := filters.Validate(); err != nil {
+		return nil, err
+	}
+	return ComputeAnalyticsReport(ctx, s.repo, s.expandTickerAliases(filters), metric)
+}
+
+// Correlate computes the Pearson correlation matrix for metric across
+// tickers, over the years all of them have data for - see
+// CorrelationMatrix.
+func (s *Service) Correlate(ctx context.Context, tickers []string, metric string) (*CorrelationMatrixResponse, error) {
+	filters := &Filters{Tickers: tickers, Metrics: []string{metric}}
+	if err := filters.Validate(); err != nil {
+		return nil, err
+	}
+	return CorrelationMatrix(ctx, s.repo, filters, tickers, metric)
+}
+
+// GetMetrics retrieves available metrics for a ticker, unioned across every
+// alias the ticker is known under.
+func (s *Service) GetMetrics(ctx context.Context, ticker string) (*MetricsResponse, error) {
+	if ticker == "" {
+		return nil, fmt.Errorf("ticker is required")
+	}
+
+	metricsSet := make(map[string]bool)
+	for _, variant := range s.aliasResolver.ReverseResolve(AliasKindTicker, ticker) {
+		metrics, err := s.repo.GetMetrics(ctx, variant)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range metrics {
+			metricsSet[m] = true
+		}
+	}
+
+	metrics := make([]string, 0, len(metricsSet))
+	for m := range metricsSet {
+		metrics = append(metrics, m)
+	}
+	sort.Strings(metrics)
+
 	return &MetricsResponse{
 		Ticker:  ticker,
 		Metrics: metrics,
 	}, nil
 }
 
+// expandTickerAliases returns a copy of filters with Tickers expanded to
+// include every known alias of each requested ticker, so a query for
+// "SIEGY" also matches rows persisted under "SIE". Returns filters
+// unchanged when no tickers are set.
+func (s *Service) expandTickerAliases(filters *Filters) *Filters {
+	if filters == nil || len(filters.Tickers) == 0 {
+		return filters
+	}
+
+	seen := make(map[string]bool)
+	var expanded []string
+	for _, ticker := range filters.Tickers {
+		for _, variant := range s.aliasResolver.ReverseResolve(AliasKindTicker, ticker) {
+			if !seen[variant] {
+				seen[variant] = true
+				expanded = append(expanded, variant)
+			}
+		}
+	}
+	sort.Strings(expanded)
+
+	clone := *filters
+	clone.Tickers = expanded
+	return &clone
+}
+
 // validateHeader checks if CSV has all required fields
 func validateHeader(header []string) error {
-	required := map[string]bool{
-		"company":     false,
-		"ticker":      false,
-		"report_type": false,
-		"metric":      false,
-		"year":        false,
-		"value":       false,
-		"currency":    false,
+	required := make(map[string]bool, len(requiredFieldNames))
+	for _, name := range requiredFieldNames {
+		required[name] = false
 	}
 
 	for _, col := range header {
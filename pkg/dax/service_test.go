@@ -2,6 +2,7 @@ package dax_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"testing"
 
@@ -26,10 +27,10 @@ func TestImportCSV_Success(t *testing.T) {
 Siemens AG,SIE,income,EBITDA,2025,15859000000.0,EUR
 SAP SE,SAP,income,Net Income,2025,8500000000.0,EUR`
 
-	mockRepo.On("BulkUpsert", mock.AnythingOfType("[]dax.DAXRecord")).Return(nil)
+	mockRepo.On("BulkUpsertBatch", mock.Anything, mock.AnythingOfType("[]dax.DAXRecord")).Return(nil)
 
 	reader := bytes.NewBufferString(csvContent)
-	response, err := service.ImportCSV(reader)
+	response, err := service.ImportCSV(context.Background(), reader, dax.ImportCSVOptions{})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
@@ -45,7 +46,7 @@ func TestImportCSV_MissingRequiredFields(t *testing.T) {
 Siemens AG,SIE,EBITDA,2025,15859000000.0`
 
 	reader := bytes.NewBufferString(csvContent)
-	response, err := service.ImportCSV(reader)
+	response, err := service.ImportCSV(context.Background(), reader, dax.ImportCSVOptions{})
 
 	assert.Error(t, err)
 	assert.Nil(t, response)
@@ -60,7 +61,7 @@ func TestImportCSV_InvalidYear(t *testing.T) {
 Siemens AG,SIE,income,EBITDA,invalid,15859000000.0,EUR`
 
 	reader := bytes.NewBufferString(csvContent)
-	response, err := service.ImportCSV(reader)
+	response, err := service.ImportCSV(context.Background(), reader, dax.ImportCSVOptions{})
 
 	assert.Error(t, err)
 	assert.Nil(t, response)
@@ -75,7 +76,7 @@ func TestImportCSV_InvalidValue(t *testing.T) {
 Siemens AG,SIE,income,EBITDA,2025,not-a-number,EUR`
 
 	reader := bytes.NewBufferString(csvContent)
-	response, err := service.ImportCSV(reader)
+	response, err := service.ImportCSV(context.Background(), reader, dax.ImportCSVOptions{})
 
 	assert.Error(t, err)
 	assert.Nil(t, response)
@@ -89,7 +90,7 @@ func TestImportCSV_EmptyCSV(t *testing.T) {
 	csvContent := `company,ticker,report_type,metric,year,value,currency`
 
 	reader := bytes.NewBufferString(csvContent)
-	response, err := service.ImportCSV(reader)
+	response, err := service.ImportCSV(context.Background(), reader, dax.ImportCSVOptions{})
 
 	assert.Error(t, err)
 	assert.Nil(t, response)
@@ -104,7 +105,7 @@ func TestImportCSV_InsufficientColumns(t *testing.T) {
 Siemens AG,SIE,income`
 
 	reader := bytes.NewBufferString(csvContent)
-	response, err := service.ImportCSV(reader)
+	response, err := service.ImportCSV(context.Background(), reader, dax.ImportCSVOptions{})
 
 	assert.Error(t, err)
 	assert.Nil(t, response)
@@ -119,15 +120,15 @@ func TestImportCSV_BulkUpsertError(t *testing.T) {
 	csvContent := `company,ticker,report_type,metric,year,value,currency
 Siemens AG,SIE,income,EBITDA,2025,15859000000.0,EUR`
 
-	mockRepo.On("BulkUpsert", mock.AnythingOfType("[]dax.DAXRecord")).
+	mockRepo.On("BulkUpsertBatch", mock.Anything, mock.AnythingOfType("[]dax.DAXRecord")).
 		Return(errors.New("database error"))
 
 	reader := bytes.NewBufferString(csvContent)
-	response, err := service.ImportCSV(reader)
+	response, err := service.ImportCSV(context.Background(), reader, dax.ImportCSVOptions{})
 
 	assert.Error(t, err)
 	assert.Nil(t, response)
-	assert.Contains(t, err.Error(), "failed to import records")
+	assert.Contains(t, err.Error(), "failed to import batch")
 }
 
 func TestImportCSV_EmptyReader(t *testing.T) {
@@ -135,13 +136,78 @@ func TestImportCSV_EmptyReader(t *testing.T) {
 	service := dax.NewService(mockRepo)
 
 	reader := bytes.NewBufferString("")
-	response, err := service.ImportCSV(reader)
+	response, err := service.ImportCSV(context.Background(), reader, dax.ImportCSVOptions{})
 
 	assert.Error(t, err)
 	assert.Nil(t, response)
 	assert.Contains(t, err.Error(), "failed to read CSV header")
 }
 
+func TestImportCSV_ContinueOnErrorCollectsRowErrors(t *testing.T) {
+	mockRepo := mocks.NewMockRepository(t)
+	service := dax.NewService(mockRepo)
+
+	csvContent := `company,ticker,report_type,metric,year,value,currency
+Siemens AG,SIE,income,EBITDA,2025,15859000000.0,EUR
+SAP SE,SAP,income,Net Income,invalid,8500000000.0,EUR
+BMW AG,BMW,income,EBITDA,2025,7200000000.0,EUR`
+
+	mockRepo.On("BulkUpsertBatch", mock.Anything, mock.AnythingOfType("[]dax.DAXRecord")).Return(nil)
+
+	reader := bytes.NewBufferString(csvContent)
+	response, err := service.ImportCSV(context.Background(), reader, dax.ImportCSVOptions{ContinueOnError: true})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.Equal(t, 2, response.RecordsImported)
+	assert.Equal(t, 1, response.RecordsFailed)
+	assert.Len(t, response.Errors, 1)
+	assert.Equal(t, 3, response.Errors[0].RowNum)
+	assert.Contains(t, response.Errors[0].Err, "invalid year")
+}
+
+func TestImportCSV_DryRunDoesNotPersist(t *testing.T) {
+	mockRepo := mocks.NewMockRepository(t)
+	service := dax.NewService(mockRepo)
+
+	csvContent := `company,ticker,report_type,metric,year,value,currency
+Siemens AG,SIE,income,EBITDA,2025,15859000000.0,EUR
+SAP SE,SAP,income,Net Income,2025,8500000000.0,EUR`
+
+	reader := bytes.NewBufferString(csvContent)
+	response, err := service.ImportCSV(context.Background(), reader, dax.ImportCSVOptions{DryRun: true})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.Equal(t, 0, response.RecordsImported)
+	assert.Equal(t, 2, response.RecordsSkipped)
+	mockRepo.AssertNotCalled(t, "BulkUpsertBatch", mock.Anything, mock.Anything)
+}
+
+func TestImportCSV_FlushesInConfiguredBatchSizes(t *testing.T) {
+	mockRepo := mocks.NewMockRepository(t)
+	service := dax.NewService(mockRepo)
+
+	csvContent := `company,ticker,report_type,metric,year,value,currency
+Siemens AG,SIE,income,EBITDA,2025,15859000000.0,EUR
+SAP SE,SAP,income,Net Income,2025,8500000000.0,EUR
+BMW AG,BMW,income,EBITDA,2025,7200000000.0,EUR`
+
+	mockRepo.On("BulkUpsertBatch", mock.Anything, mock.MatchedBy(func(batch []dax.DAXRecord) bool {
+		return len(batch) == 2
+	})).Return(nil).Once()
+	mockRepo.On("BulkUpsertBatch", mock.Anything, mock.MatchedBy(func(batch []dax.DAXRecord) bool {
+		return len(batch) == 1
+	})).Return(nil).Once()
+
+	reader := bytes.NewBufferString(csvContent)
+	response, err := service.ImportCSV(context.Background(), reader, dax.ImportCSVOptions{BatchSize: 2})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.Equal(t, 3, response.RecordsImported)
+}
+
 func TestGetAll_Success(t *testing.T) {
 	mockRepo := mocks.NewMockRepository(t)
 	service := dax.NewService(mockRepo)
@@ -151,9 +217,9 @@ func TestGetAll_Success(t *testing.T) {
 		{Ticker: "SAP", Year: 2025},
 	}
 
-	mockRepo.On("FindAll", 1, 10).Return(expectedRecords, 2, nil)
+	mockRepo.On("FindAll", mock.Anything, 1, 10).Return(expectedRecords, 2, nil)
 
-	response, err := service.GetAll(1, 10)
+	response, err := service.GetAll(context.Background(), 1, 10)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
@@ -169,9 +235,9 @@ func TestGetAll_PageLessThanOne(t *testing.T) {
 	expectedRecords := []dax.DAXRecord{{Ticker: "SIE", Year: 2025}}
 
 	// When page < 1, it should default to 1
-	mockRepo.On("FindAll", 1, 10).Return(expectedRecords, 1, nil)
+	mockRepo.On("FindAll", mock.Anything, 1, 10).Return(expectedRecords, 1, nil)
 
-	response, err := service.GetAll(0, 10)
+	response, err := service.GetAll(context.Background(), 0, 10)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
@@ -184,9 +250,9 @@ func TestGetAll_NegativePage(t *testing.T) {
 
 	expectedRecords := []dax.DAXRecord{{Ticker: "SIE", Year: 2025}}
 
-	mockRepo.On("FindAll", 1, 10).Return(expectedRecords, 1, nil)
+	mockRepo.On("FindAll", mock.Anything, 1, 10).Return(expectedRecords, 1, nil)
 
-	response, err := service.GetAll(-5, 10)
+	response, err := service.GetAll(context.Background(), -5, 10)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
@@ -200,9 +266,9 @@ func TestGetAll_LimitLessThanOne(t *testing.T) {
 	expectedRecords := []dax.DAXRecord{{Ticker: "SIE", Year: 2025}}
 
 	// When limit < 1, it should default to 10
-	mockRepo.On("FindAll", 1, 10).Return(expectedRecords, 1, nil)
+	mockRepo.On("FindAll", mock.Anything, 1, 10).Return(expectedRecords, 1, nil)
 
-	response, err := service.GetAll(1, 0)
+	response, err := service.GetAll(context.Background(), 1, 0)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
@@ -216,9 +282,9 @@ func TestGetAll_LimitGreaterThan100(t *testing.T) {
 	expectedRecords := []dax.DAXRecord{{Ticker: "SIE", Year: 2025}}
 
 	// When limit > 100, it should default to 10
-	mockRepo.On("FindAll", 1, 10).Return(expectedRecords, 1, nil)
+	mockRepo.On("FindAll", mock.Anything, 1, 10).Return(expectedRecords, 1, nil)
 
-	response, err := service.GetAll(1, 150)
+	response, err := service.GetAll(context.Background(), 1, 150)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
@@ -229,9 +295,9 @@ func TestGetAll_RepositoryError(t *testing.T) {
 	mockRepo := mocks.NewMockRepository(t)
 	service := dax.NewService(mockRepo)
 
-	mockRepo.On("FindAll", 1, 10).Return([]dax.DAXRecord{}, 0, errors.New("database error"))
+	mockRepo.On("FindAll", mock.Anything, 1, 10).Return([]dax.DAXRecord{}, 0, errors.New("database error"))
 
-	response, err := service.GetAll(1, 10)
+	response, err := service.GetAll(context.Background(), 1, 10)
 
 	assert.Error(t, err)
 	assert.Nil(t, response)
@@ -244,9 +310,9 @@ func TestGetAll_TotalPagesCalculation(t *testing.T) {
 	expectedRecords := []dax.DAXRecord{{Ticker: "SIE", Year: 2025}}
 
 	// 25 total records with limit 10 = 3 pages
-	mockRepo.On("FindAll", 1, 10).Return(expectedRecords, 25, nil)
+	mockRepo.On("FindAll", mock.Anything, 1, 10).Return(expectedRecords, 25, nil)
 
-	response, err := service.GetAll(1, 10)
+	response, err := service.GetAll(context.Background(), 1, 10)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
@@ -258,14 +324,15 @@ func TestGetByFilters_WithTickerAndYear(t *testing.T) {
 	service := dax.NewService(mockRepo)
 
 	year := 2025
+	filters := &dax.Filters{Tickers: []string{"SIE"}, YearFrom: &year, YearTo: &year}
 	expectedRecords := []dax.DAXRecord{
 		{Ticker: "SIE", Year: 2025},
 	}
 
-	mockRepo.On("FindByFilters", "SIE", &year, 1, 10).
+	mockRepo.On("FindByFilters", mock.Anything, filters, 1, 10).
 		Return(expectedRecords, 1, nil)
 
-	response, err := service.GetByFilters("SIE", &year, 1, 10)
+	response, err := service.GetByFilters(context.Background(), filters, 1, 10)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
@@ -276,15 +343,16 @@ func TestGetByFilters_WithOnlyTicker(t *testing.T) {
 	mockRepo := mocks.NewMockRepository(t)
 	service := dax.NewService(mockRepo)
 
+	filters := &dax.Filters{Tickers: []string{"SIE"}}
 	expectedRecords := []dax.DAXRecord{
 		{Ticker: "SIE", Year: 2024},
 		{Ticker: "SIE", Year: 2025},
 	}
 
-	mockRepo.On("FindByFilters", "SIE", (*int)(nil), 1, 10).
+	mockRepo.On("FindByFilters", mock.Anything, filters, 1, 10).
 		Return(expectedRecords, 2, nil)
 
-	response, err := service.GetByFilters("SIE", nil, 1, 10)
+	response, err := service.GetByFilters(context.Background(), filters, 1, 10)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
@@ -295,12 +363,13 @@ func TestGetByFilters_PageLessThanOne(t *testing.T) {
 	mockRepo := mocks.NewMockRepository(t)
 	service := dax.NewService(mockRepo)
 
+	filters := &dax.Filters{Tickers: []string{"SIE"}}
 	expectedRecords := []dax.DAXRecord{{Ticker: "SIE", Year: 2025}}
 
-	mockRepo.On("FindByFilters", "SIE", (*int)(nil), 1, 10).
+	mockRepo.On("FindByFilters", mock.Anything, filters, 1, 10).
 		Return(expectedRecords, 1, nil)
 
-	response, err := service.GetByFilters("SIE", nil, 0, 10)
+	response, err := service.GetByFilters(context.Background(), filters, 0, 10)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
@@ -311,12 +380,13 @@ func TestGetByFilters_NegativePage(t *testing.T) {
 	mockRepo := mocks.NewMockRepository(t)
 	service := dax.NewService(mockRepo)
 
+	filters := &dax.Filters{Tickers: []string{"SIE"}}
 	expectedRecords := []dax.DAXRecord{{Ticker: "SIE", Year: 2025}}
 
-	mockRepo.On("FindByFilters", "SIE", (*int)(nil), 1, 10).
+	mockRepo.On("FindByFilters", mock.Anything, filters, 1, 10).
 		Return(expectedRecords, 1, nil)
 
-	response, err := service.GetByFilters("SIE", nil, -10, 10)
+	response, err := service.GetByFilters(context.Background(), filters, -10, 10)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
@@ -327,12 +397,13 @@ func TestGetByFilters_LimitLessThanOne(t *testing.T) {
 	mockRepo := mocks.NewMockRepository(t)
 	service := dax.NewService(mockRepo)
 
+	filters := &dax.Filters{Tickers: []string{"SIE"}}
 	expectedRecords := []dax.DAXRecord{{Ticker: "SIE", Year: 2025}}
 
-	mockRepo.On("FindByFilters", "SIE", (*int)(nil), 1, 10).
+	mockRepo.On("FindByFilters", mock.Anything, filters, 1, 10).
 		Return(expectedRecords, 1, nil)
 
-	response, err := service.GetByFilters("SIE", nil, 1, 0)
+	response, err := service.GetByFilters(context.Background(), filters, 1, 0)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
@@ -343,12 +414,13 @@ func TestGetByFilters_LimitGreaterThan100(t *testing.T) {
 	mockRepo := mocks.NewMockRepository(t)
 	service := dax.NewService(mockRepo)
 
+	filters := &dax.Filters{Tickers: []string{"SIE"}}
 	expectedRecords := []dax.DAXRecord{{Ticker: "SIE", Year: 2025}}
 
-	mockRepo.On("FindByFilters", "SIE", (*int)(nil), 1, 10).
+	mockRepo.On("FindByFilters", mock.Anything, filters, 1, 10).
 		Return(expectedRecords, 1, nil)
 
-	response, err := service.GetByFilters("SIE", nil, 1, 200)
+	response, err := service.GetByFilters(context.Background(), filters, 1, 200)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
@@ -359,10 +431,11 @@ func TestGetByFilters_RepositoryError(t *testing.T) {
 	mockRepo := mocks.NewMockRepository(t)
 	service := dax.NewService(mockRepo)
 
-	mockRepo.On("FindByFilters", "SIE", (*int)(nil), 1, 10).
+	filters := &dax.Filters{Tickers: []string{"SIE"}}
+	mockRepo.On("FindByFilters", mock.Anything, filters, 1, 10).
 		Return([]dax.DAXRecord{}, 0, errors.New("database error"))
 
-	response, err := service.GetByFilters("SIE", nil, 1, 10)
+	response, err := service.GetByFilters(context.Background(), filters, 1, 10)
 
 	assert.Error(t, err)
 	assert.Nil(t, response)
@@ -372,27 +445,42 @@ func TestGetByFilters_TotalPagesCalculation(t *testing.T) {
 	mockRepo := mocks.NewMockRepository(t)
 	service := dax.NewService(mockRepo)
 
+	filters := &dax.Filters{Tickers: []string{"SIE"}}
 	expectedRecords := []dax.DAXRecord{{Ticker: "SIE", Year: 2025}}
 
 	// 45 total records with limit 10 = 5 pages
-	mockRepo.On("FindByFilters", "SIE", (*int)(nil), 1, 10).
+	mockRepo.On("FindByFilters", mock.Anything, filters, 1, 10).
 		Return(expectedRecords, 45, nil)
 
-	response, err := service.GetByFilters("SIE", nil, 1, 10)
+	response, err := service.GetByFilters(context.Background(), filters, 1, 10)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
 	assert.Equal(t, 5, response.Pagination.TotalPages)
 }
 
+func TestGetByFilters_InvalidFilters(t *testing.T) {
+	mockRepo := mocks.NewMockRepository(t)
+	service := dax.NewService(mockRepo)
+
+	yearFrom, yearTo := 2025, 2020
+	filters := &dax.Filters{YearFrom: &yearFrom, YearTo: &yearTo}
+
+	response, err := service.GetByFilters(context.Background(), filters, 1, 10)
+
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Contains(t, err.Error(), "year_from must not be after year_to")
+}
+
 func TestGetMetrics_Success(t *testing.T) {
 	mockRepo := mocks.NewMockRepository(t)
 	service := dax.NewService(mockRepo)
 
 	expectedMetrics := []string{"EBITDA", "Net Income"}
-	mockRepo.On("GetMetrics", "SIE").Return(expectedMetrics, nil)
+	mockRepo.On("GetMetrics", mock.Anything, "SIE").Return(expectedMetrics, nil)
 
-	response, err := service.GetMetrics("SIE")
+	response, err := service.GetMetrics(context.Background(), "SIE")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
@@ -404,7 +492,7 @@ func TestGetMetrics_EmptyTicker(t *testing.T) {
 	mockRepo := mocks.NewMockRepository(t)
 	service := dax.NewService(mockRepo)
 
-	response, err := service.GetMetrics("")
+	response, err := service.GetMetrics(context.Background(), "")
 
 	assert.Error(t, err)
 	assert.Nil(t, response)
@@ -415,9 +503,9 @@ func TestGetMetrics_RepositoryError(t *testing.T) {
 	mockRepo := mocks.NewMockRepository(t)
 	service := dax.NewService(mockRepo)
 
-	mockRepo.On("GetMetrics", "SIE").Return([]string(nil), errors.New("database error"))
+	mockRepo.On("GetMetrics", mock.Anything, "SIE").Return([]string(nil), errors.New("database error"))
 
-	response, err := service.GetMetrics("SIE")
+	response, err := service.GetMetrics(context.Background(), "SIE")
 
 	assert.Error(t, err)
 	assert.Nil(t, response)
@@ -428,9 +516,9 @@ func TestGetMetrics_NilMetricsReturnsEmptySlice(t *testing.T) {
 	service := dax.NewService(mockRepo)
 
 	// Repository returns nil metrics (no data found)
-	mockRepo.On("GetMetrics", "UNKNOWN").Return([]string(nil), nil)
+	mockRepo.On("GetMetrics", mock.Anything, "UNKNOWN").Return([]string(nil), nil)
 
-	response, err := service.GetMetrics("UNKNOWN")
+	response, err := service.GetMetrics(context.Background(), "UNKNOWN")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
@@ -438,3 +526,77 @@ func TestGetMetrics_NilMetricsReturnsEmptySlice(t *testing.T) {
 	assert.NotNil(t, response.Metrics)
 	assert.Empty(t, response.Metrics)
 }
+
+// fakeAliasResolver is a hand-rolled AliasResolver test double: the
+// interface is two trivial methods, so a mockery-generated mock would be
+// more ceremony than value here.
+type fakeAliasResolver struct {
+	canonical map[string]string
+	variants  map[string][]string
+}
+
+func (f *fakeAliasResolver) Resolve(_ uint8, key string) string {
+	if canonical, ok := f.canonical[key]; ok {
+		return canonical
+	}
+	return key
+}
+
+func (f *fakeAliasResolver) ReverseResolve(_ uint8, key string) []string {
+	if variants, ok := f.variants[key]; ok {
+		return variants
+	}
+	return []string{key}
+}
+
+func TestImportCSV_ResolvesTickerAliases(t *testing.T) {
+	mockRepo := mocks.NewMockRepository(t)
+	resolver := &fakeAliasResolver{canonical: map[string]string{"SIEGY": "SIE"}}
+	service := dax.NewServiceWithAliases(mockRepo, resolver)
+
+	csvContent := `company,ticker,report_type,metric,year,value,currency
+Siemens AG,SIEGY,income,EBITDA,2025,15859000000.0,EUR`
+
+	mockRepo.On("BulkUpsertBatch", mock.Anything, mock.MatchedBy(func(batch []dax.DAXRecord) bool {
+		return len(batch) == 1 && batch[0].Ticker == "SIE"
+	})).Return(nil)
+
+	reader := bytes.NewBufferString(csvContent)
+	response, err := service.ImportCSV(context.Background(), reader, dax.ImportCSVOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, response.RecordsImported)
+}
+
+func TestGetByFilters_ExpandsTickerAliases(t *testing.T) {
+	mockRepo := mocks.NewMockRepository(t)
+	resolver := &fakeAliasResolver{variants: map[string][]string{"SIEGY": {"SIE", "SIEGY"}}}
+	service := dax.NewServiceWithAliases(mockRepo, resolver)
+
+	filters := &dax.Filters{Tickers: []string{"SIEGY"}}
+	expanded := &dax.Filters{Tickers: []string{"SIE", "SIEGY"}}
+	expectedRecords := []dax.DAXRecord{{Ticker: "SIE", Year: 2025}}
+
+	mockRepo.On("FindByFilters", mock.Anything, expanded, 1, 10).Return(expectedRecords, 1, nil)
+
+	response, err := service.GetByFilters(context.Background(), filters, 1, 10)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.Equal(t, 1, len(response.Data))
+}
+
+func TestGetMetrics_UnionsAcrossAliases(t *testing.T) {
+	mockRepo := mocks.NewMockRepository(t)
+	resolver := &fakeAliasResolver{variants: map[string][]string{"SIEGY": {"SIE", "SIEGY"}}}
+	service := dax.NewServiceWithAliases(mockRepo, resolver)
+
+	mockRepo.On("GetMetrics", mock.Anything, "SIE").Return([]string{"EBITDA"}, nil)
+	mockRepo.On("GetMetrics", mock.Anything, "SIEGY").Return([]string{"Net Income"}, nil)
+
+	response, err := service.GetMetrics(context.Background(), "SIEGY")
+
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.Equal(t, []string{"EBITDA", "Net Income"}, response.Metrics)
+}
@@ -0,0 +1,312 @@
+package dax
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrUnknownStatAgg is returned by ParseStatAgg for an agg string
+	// other than mean, min, max, stddev, or percentile:N.
+	ErrUnknownStatAgg = errors.New("unknown stat aggregate")
+	// ErrInvalidPercentile is returned by ParseStatAgg for a
+	// "percentile:N" spec whose N isn't a number in (0, 100).
+	ErrInvalidPercentile = errors.New("invalid percentile")
+)
+
+// StatAgg selects the aggregate StreamStat computes over a streamed value
+// series.
+type StatAgg string
+
+// Aggregate operations accepted by the /dax/stats endpoint's agg
+// parameter.
+const (
+	StatMean       StatAgg = "mean"
+	StatMin        StatAgg = "min"
+	StatMax        StatAgg = "max"
+	StatStdDev     StatAgg = "stddev"
+	StatPercentile StatAgg = "percentile"
+)
+
+// ParseStatAgg parses the /dax/stats endpoint's agg query parameter,
+// e.g. "mean" or "percentile:95". The second return value is the
+// requested percentile (0-100) when agg is StatPercentile, 0 otherwise.
+func ParseStatAgg(s string) (StatAgg, float64, error) {
+	op, rest, _ := strings.Cut(s, ":")
+	switch StatAgg(op) {
+	case StatMean, StatMin, StatMax, StatStdDev:
+		return StatAgg(op), 0, nil
+	case StatPercentile:
+		p, err := strconv.ParseFloat(rest, 64)
+		if err != nil || p <= 0 || p >= 100 {
+			return "", 0, fmt.Errorf("%w: %q", ErrInvalidPercentile, rest)
+		}
+		return StatPercentile, p, nil
+	default:
+		return "", 0, fmt.Errorf("%w: %q", ErrUnknownStatAgg, s)
+	}
+}
+
+// WelfordStats accumulates count, mean and variance over a value stream
+// in a single pass, using Welford's online algorithm - the running mean
+// and sum-of-squared-differences never need the full series held in
+// memory, unlike the naive sum(x)/n, sum((x-mean)^2)/n approach.
+type WelfordStats struct {
+	count int64
+	mean  float64
+	m2    float64
+}
+
+// Push folds x into the running statistics.
+func (w *WelfordStats) Push(x float64) {
+	w.count++
+	delta := x - w.mean
+	w.mean += delta / float64(w.count)
+	w.m2 += delta * (x - w.mean)
+}
+
+// Count returns how many values have been pushed.
+func (w *WelfordStats) Count() int64 { return w.count }
+
+// Mean returns the running mean, or 0 if nothing has been pushed.
+func (w *WelfordStats) Mean() float64 { return w.mean }
+
+// Variance returns the population variance, or 0 if fewer than one value
+// has been pushed.
+func (w *WelfordStats) Variance() float64 {
+	if w.count == 0 {
+		return 0
+	}
+	return w.m2 / float64(w.count)
+}
+
+// StdDev returns the population standard deviation.
+func (w *WelfordStats) StdDev() float64 {
+	return math.Sqrt(w.Variance())
+}
+
+// p2MarkerCount is the number of markers the P² algorithm tracks: the
+// min, the max, and three quantile-adjacent markers straddling the
+// target percentile.
+const p2MarkerCount = 5
+
+// P2Estimator estimates a single percentile over a value stream in one
+// pass and O(1) memory, using the P² (piecewise-parabolic) algorithm
+// (Jain & Chlamtac, 1985). It trades exactness for boundedness: unlike
+// sorting the full series, its estimate converges to the true percentile
+// as more values are pushed but isn't guaranteed exact for any finite
+// count, which is why stats responses built on it document their error
+// bound.
+type P2Estimator struct {
+	percentile float64 // 0-100
+
+	n         int
+	initial   []float64
+	q         [p2MarkerCount]float64 // marker heights
+	pos       [p2MarkerCount]int     // marker positions
+	desired   [p2MarkerCount]float64 // desired marker positions
+	increment [p2MarkerCount]float64 // desired position increments
+}
+
+// NewP2Estimator creates a P2Estimator for the given percentile (0-100).
+func NewP2Estimator(percentile float64) *P2Estimator {
+	p := percentile / 100
+	e := &P2Estimator{percentile: percentile}
+	e.increment = [p2MarkerCount]float64{0, p / 2, p, (1 + p) / 2, 1}
+	return e
+}
+
+// Push folds x into the estimator.
+func (e *P2Estimator) Push(x float64) {
+	e.n++
+
+	if e.n <= p2MarkerCount {
+		e.initial = append(e.initial, x)
+		if e.n == p2MarkerCount {
+			e.initializeMarkers()
+		}
+		return
+	}
+
+	k := e.cell(x)
+	for i := k + 1; i < p2MarkerCount; i++ {
+		e.pos[i]++
+	}
+	for i := range e.desired {
+		e.desired[i] += e.increment[i]
+	}
+
+	for i := 1; i < p2MarkerCount-1; i++ {
+		e.adjust(i)
+	}
+}
+
+// initializeMarkers sorts the first p2MarkerCount observations and seeds
+// the marker heights/positions from them.
+func (e *P2Estimator) initializeMarkers() {
+	sorted := append([]float64(nil), e.initial...)
+	for i := 1; i < len(sorted); i++ {
+		for j := i; j > 0 && sorted[j-1] > sorted[j]; j-- {
+			sorted[j-1], sorted[j] = sorted[j], sorted[j-1]
+		}
+	}
+	for i := 0; i < p2MarkerCount; i++ {
+		e.q[i] = sorted[i]
+		e.pos[i] = i + 1
+		e.desired[i] = 1 + e.increment[i]*float64(p2MarkerCount-1)
+	}
+}
+
+// cell returns the marker index whose height interval x falls into,
+// clamping the outer marker heights to extend the range when x is a new
+// extremum.
+func (e *P2Estimator) cell(x float64) int {
+	switch {
+	case x < e.q[0]:
+		e.q[0] = x
+		return 0
+	case x >= e.q[p2MarkerCount-1]:
+		e.q[p2MarkerCount-1] = x
+		return p2MarkerCount - 2
+	}
+	for i := 1; i < p2MarkerCount; i++ {
+		if x < e.q[i] {
+			return i - 1
+		}
+	}
+	return p2MarkerCount - 2
+}
+
+// adjust moves marker i's height toward its parabolic estimate if its
+// position has drifted more than one slot from its desired position,
+// falling back to a linear estimate if the parabolic one would leave the
+// markers out of order.
+func (e *P2Estimator) adjust(i int) {
+	d := e.desired[i] - float64(e.pos[i])
+	if (d >= 1 && e.pos[i+1]-e.pos[i] > 1) || (d <= -1 && e.pos[i-1]-e.pos[i] < -1) {
+		sign := 1
+		if d < 0 {
+			sign = -1
+		}
+		qNew := e.parabolic(i, sign)
+		if e.q[i-1] < qNew && qNew < e.q[i+1] {
+			e.q[i] = qNew
+		} else {
+			e.q[i] = e.linear(i, sign)
+		}
+		e.pos[i] += sign
+	}
+}
+
+func (e *P2Estimator) parabolic(i, sign int) float64 {
+	d := float64(sign)
+	return e.q[i] + d/float64(e.pos[i+1]-e.pos[i-1])*
+		((float64(e.pos[i]-e.pos[i-1])+d)*(e.q[i+1]-e.q[i])/float64(e.pos[i+1]-e.pos[i])+
+			(float64(e.pos[i+1]-e.pos[i])-d)*(e.q[i]-e.q[i-1])/float64(e.pos[i]-e.pos[i-1]))
+}
+
+func (e *P2Estimator) linear(i, sign int) float64 {
+	j := i + sign
+	return e.q[i] + float64(sign)*(e.q[j]-e.q[i])/float64(e.pos[j]-e.pos[i])
+}
+
+// Count returns how many values have been pushed.
+func (e *P2Estimator) Count() int { return e.n }
+
+// Value returns the current percentile estimate. With fewer than
+// p2MarkerCount observations it falls back to sorting the (necessarily
+// tiny) buffered sample exactly.
+func (e *P2Estimator) Value() float64 {
+	if e.n == 0 {
+		return 0
+	}
+	if e.n < p2MarkerCount {
+		sorted := append([]float64(nil), e.initial...)
+		for i := 1; i < len(sorted); i++ {
+			for j := i; j > 0 && sorted[j-1] > sorted[j]; j-- {
+				sorted[j-1], sorted[j] = sorted[j], sorted[j-1]
+			}
+		}
+		idx := int(e.percentile / 100 * float64(len(sorted)-1))
+		return sorted[idx]
+	}
+	return e.q[2]
+}
+
+// StatResult is GetStats' response: the aggregate value, how many records
+// it was computed over, and (for percentile aggregates) a note on the
+// estimator's accuracy.
+type StatResult struct {
+	Agg   string  `json:"agg"`
+	Value float64 `json:"value"`
+	Count int64   `json:"count"`
+	// Note documents the accuracy tradeoff of the algorithm that produced
+	// Value, e.g. bounded error for a P²-estimated percentile. Empty for
+	// exact aggregates (mean, min, max, stddev).
+	Note string `json:"note,omitempty"`
+}
+
+// ComputeStat streams every record matching filters through repo.Stream
+// and reduces metric's values to a single StatResult with agg/percentile,
+// in one pass and without sorting - the batched streaming keeps memory
+// bounded even over decades of records, at the cost of an approximate
+// (but error-bounded) result for percentile aggregates.
+func ComputeStat(ctx context.Context, repo Repository, filters *Filters, agg StatAgg, percentile float64) (*StatResult, error) {
+	records, errs := repo.Stream(ctx, filters, 0)
+
+	welford := &WelfordStats{}
+	var p2 *P2Estimator
+	if agg == StatPercentile {
+		p2 = NewP2Estimator(percentile)
+	}
+	min, max := math.Inf(1), math.Inf(-1)
+	var count int64
+
+	for record := range records {
+		if record.Value == nil {
+			continue
+		}
+		v := *record.Value
+		count++
+		welford.Push(v)
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+		if p2 != nil {
+			p2.Push(v)
+		}
+	}
+	if err := <-errs; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, ErrNoData
+	}
+
+	result := &StatResult{Agg: string(agg), Count: count}
+	switch agg {
+	case StatMean:
+		result.Value = welford.Mean()
+	case StatMin:
+		result.Value = min
+	case StatMax:
+		result.Value = max
+	case StatStdDev:
+		result.Value = welford.StdDev()
+	case StatPercentile:
+		result.Agg = fmt.Sprintf("percentile:%g", percentile)
+		result.Value = p2.Value()
+		result.Note = "percentile estimated in one pass via the P² algorithm; bounded error, not exact for small sample counts"
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownStatAgg, agg)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,97 @@
+package dax
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWelfordStats(t *testing.T) {
+	w := &WelfordStats{}
+	for _, v := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		w.Push(v)
+	}
+
+	assert.Equal(t, int64(8), w.Count())
+	assert.InDelta(t, 5.0, w.Mean(), 1e-9)
+	assert.InDelta(t, 4.0, w.Variance(), 1e-9)
+	assert.InDelta(t, 2.0, w.StdDev(), 1e-9)
+}
+
+func TestP2Estimator_ConvergesToMedian(t *testing.T) {
+	e := NewP2Estimator(50)
+	for i := 1; i <= 1001; i++ {
+		e.Push(float64(i))
+	}
+
+	assert.InDelta(t, 501, e.Value(), 20)
+}
+
+func TestP2Estimator_FewSamplesFallsBackToExact(t *testing.T) {
+	e := NewP2Estimator(50)
+	e.Push(10)
+	e.Push(20)
+	e.Push(30)
+
+	assert.Equal(t, float64(20), e.Value())
+}
+
+func TestParseStatAgg(t *testing.T) {
+	agg, _, err := ParseStatAgg("mean")
+	require.NoError(t, err)
+	assert.Equal(t, StatMean, agg)
+
+	agg, p, err := ParseStatAgg("percentile:95")
+	require.NoError(t, err)
+	assert.Equal(t, StatPercentile, agg)
+	assert.Equal(t, 95.0, p)
+
+	_, _, err = ParseStatAgg("percentile:150")
+	assert.ErrorIs(t, err, ErrInvalidPercentile)
+
+	_, _, err = ParseStatAgg("median")
+	assert.ErrorIs(t, err, ErrUnknownStatAgg)
+}
+
+func TestComputeStat(t *testing.T) {
+	repo := NewInMemoryRepository()
+	require.NoError(t, repo.BulkUpsert(context.Background(), []DAXRecord{
+		{Ticker: "SIE", Metric: "EBITDA", Year: 2022, Value: ptrFloat(10)},
+		{Ticker: "SIE", Metric: "EBITDA", Year: 2023, Value: ptrFloat(20)},
+		{Ticker: "SIE", Metric: "EBITDA", Year: 2024, Value: ptrFloat(30)},
+	}))
+
+	filters := &Filters{Tickers: []string{"SIE"}, Metrics: []string{"EBITDA"}}
+
+	mean, err := ComputeStat(context.Background(), repo, filters, StatMean, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), mean.Count)
+	assert.InDelta(t, 20.0, mean.Value, 1e-9)
+
+	min, err := ComputeStat(context.Background(), repo, filters, StatMin, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, min.Value)
+
+	max, err := ComputeStat(context.Background(), repo, filters, StatMax, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 30.0, max.Value)
+
+	stddev, err := ComputeStat(context.Background(), repo, filters, StatStdDev, 0)
+	require.NoError(t, err)
+	assert.InDelta(t, math.Sqrt(200.0/3), stddev.Value, 1e-6)
+
+	pct, err := ComputeStat(context.Background(), repo, filters, StatPercentile, 50)
+	require.NoError(t, err)
+	assert.NotEmpty(t, pct.Note)
+}
+
+func TestComputeStat_NoData(t *testing.T) {
+	repo := NewInMemoryRepository()
+
+	_, err := ComputeStat(context.Background(), repo, &Filters{Tickers: []string{"SIE"}, Metrics: []string{"EBITDA"}}, StatMean, 0)
+
+	assert.ErrorIs(t, err, ErrNoData)
+}
@@ -0,0 +1,177 @@
+// Package finance aggregates pkg/dax's historical fundamentals with
+// pkg/stocks' live quotes into a single per-company view, so a caller
+// doesn't have to query both packages and merge the results itself.
+package finance
+
+import (
+	"context"
+
+	"github.com/f00b455/blank-go/pkg/dax"
+	"github.com/f00b455/blank-go/pkg/stocks"
+	"golang.org/x/sync/errgroup"
+)
+
+// maxFundamentalsRows bounds the single FindByFilters call Query issues for
+// a request's tickers/years/metrics. It's generous enough for any
+// reasonable query (a handful of tickers across a few decades of a handful
+// of metrics) without Query having to paginate.
+const maxFundamentalsRows = 10000
+
+// FundamentalsRepository is the subset of dax.Repository Query depends on.
+type FundamentalsRepository interface {
+	FindByFilters(ctx context.Context, filters *dax.Filters, page, limit int) ([]dax.DAXRecord, int, error)
+}
+
+// StocksService is the subset of stocks.Service Query depends on.
+type StocksService interface {
+	GetBatchSummary(ctx context.Context, tickers string) (*stocks.BatchResponse, error)
+}
+
+// Query describes a cross-source request: DAX fundamentals for Tickers,
+// restricted to [FromYear, ToYear] and Metrics (either left empty to mean
+// "every year"/"every metric"), plus a live quote per ticker when
+// IncludeLive is set.
+type Query struct {
+	Tickers     []string
+	FromYear    *int
+	ToYear      *int
+	Metrics     []string
+	IncludeLive bool
+}
+
+// CompanyTimeline is one ticker's merged view: its fundamentals indexed by
+// year then metric name, and (if the query asked for it) its current quote.
+type CompanyTimeline struct {
+	Ticker       string                     `json:"ticker"`
+	Fundamentals map[int]map[string]float64 `json:"fundamentals"`
+	Live         *stocks.StockSummary       `json:"live,omitempty"`
+}
+
+// Response is Query's result: a CompanyTimeline per requested ticker that
+// had any data at all, plus a per-ticker message for tickers that came back
+// with nothing - a DAX ticker with no matching fundamentals, or (when
+// IncludeLive is set) a ticker whose live quote failed - mirroring how
+// stocks.BatchResponse.Errors reports partial failures alongside whatever
+// did succeed.
+type Response struct {
+	Companies []CompanyTimeline `json:"companies"`
+	Errors    map[string]string `json:"errors,omitempty"`
+}
+
+// Aggregator merges dax and stocks data into Response per Query.
+type Aggregator struct {
+	fundamentals FundamentalsRepository
+	quotes       StocksService
+}
+
+// NewAggregator creates an Aggregator backed by fundamentals and quotes.
+func NewAggregator(fundamentals FundamentalsRepository, quotes StocksService) *Aggregator {
+	return &Aggregator{fundamentals: fundamentals, quotes: quotes}
+}
+
+// Query resolves q against both sources and merges them into a
+// CompanyTimeline per ticker. Fundamentals are fetched with a single
+// FindByFilters call covering every requested ticker/year/metric at once -
+// dax.Filters already supports all three dimensions together, so issuing
+// one query and grouping the result in Go avoids a combinatorial number of
+// round trips. When IncludeLive is set, that fetch runs concurrently with
+// a single stocks.GetBatchSummary call for every ticker; a failure in
+// either source is attributed to the affected ticker(s) in Response.Errors
+// rather than failing the whole request.
+func (a *Aggregator) Query(ctx context.Context, q Query) (*Response, error) {
+	timelines := make(map[string]*CompanyTimeline, len(q.Tickers))
+	for _, ticker := range q.Tickers {
+		timelines[ticker] = &CompanyTimeline{Ticker: ticker, Fundamentals: make(map[int]map[string]float64)}
+	}
+	errs := make(map[string]string)
+
+	var records []dax.DAXRecord
+	var batch *stocks.BatchResponse
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		found, _, err := a.fundamentals.FindByFilters(gCtx, &dax.Filters{
+			Tickers:  q.Tickers,
+			YearFrom: q.FromYear,
+			YearTo:   q.ToYear,
+			Metrics:  q.Metrics,
+		}, 1, maxFundamentalsRows)
+		if err != nil {
+			return err
+		}
+		records = found
+		return nil
+	})
+	if q.IncludeLive && len(q.Tickers) > 0 {
+		g.Go(func() error {
+			response, err := a.quotes.GetBatchSummary(gCtx, joinTickers(q.Tickers))
+			if err != nil {
+				return err
+			}
+			batch = response
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		timeline, ok := timelines[record.Ticker]
+		if !ok {
+			// FindByFilters was scoped to q.Tickers, so this shouldn't
+			// happen; skip defensively rather than panic on a stray row.
+			continue
+		}
+		byMetric, ok := timeline.Fundamentals[record.Year]
+		if !ok {
+			byMetric = make(map[string]float64)
+			timeline.Fundamentals[record.Year] = byMetric
+		}
+		if record.Value != nil {
+			byMetric[record.Metric] = *record.Value
+		}
+	}
+
+	if batch != nil {
+		for i := range batch.Summaries {
+			summary := batch.Summaries[i]
+			if timeline, ok := timelines[summary.Ticker]; ok {
+				timeline.Live = &summary
+			}
+		}
+		for _, batchErr := range batch.Errors {
+			errs[batchErr.Ticker] = batchErr.Message
+		}
+	}
+
+	companies := make([]CompanyTimeline, 0, len(q.Tickers))
+	for _, ticker := range q.Tickers {
+		timeline := timelines[ticker]
+		if len(timeline.Fundamentals) == 0 {
+			if _, alreadyReported := errs[ticker]; !alreadyReported {
+				errs[ticker] = "no fundamentals found for ticker"
+			}
+		}
+		companies = append(companies, *timeline)
+	}
+
+	response := &Response{Companies: companies}
+	if len(errs) > 0 {
+		response.Errors = errs
+	}
+	return response, nil
+}
+
+// joinTickers formats tickers the way stocks.Service.GetBatchSummary
+// expects: a single comma-separated string.
+func joinTickers(tickers []string) string {
+	joined := ""
+	for i, ticker := range tickers {
+		if i > 0 {
+			joined += ","
+		}
+		joined += ticker
+	}
+	return joined
+}
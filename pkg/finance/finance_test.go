@@ -0,0 +1,114 @@
+package finance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/f00b455/blank-go/pkg/dax"
+	"github.com/f00b455/blank-go/pkg/stocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubFundamentalsRepository returns a fixed set of records regardless of
+// the filters passed in, and records the last filters it was called with so
+// tests can assert Query batched its tickers/years/metrics into one call.
+type stubFundamentalsRepository struct {
+	records    []dax.DAXRecord
+	err        error
+	lastFilter *dax.Filters
+}
+
+func (s *stubFundamentalsRepository) FindByFilters(ctx context.Context, filters *dax.Filters, page, limit int) ([]dax.DAXRecord, int, error) {
+	s.lastFilter = filters
+	if s.err != nil {
+		return nil, 0, s.err
+	}
+	return s.records, len(s.records), nil
+}
+
+// stubStocksService returns a canned BatchResponse regardless of the
+// tickers string passed in.
+type stubStocksService struct {
+	response *stocks.BatchResponse
+	err      error
+}
+
+func (s *stubStocksService) GetBatchSummary(ctx context.Context, tickers string) (*stocks.BatchResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.response, nil
+}
+
+func float64Ptr(v float64) *float64 { return &v }
+
+func TestAggregator_Query_MergesFundamentalsAndLive(t *testing.T) {
+	fundamentals := &stubFundamentalsRepository{
+		records: []dax.DAXRecord{
+			{Ticker: "AAPL", Year: 2022, Metric: "Revenue", Value: float64Ptr(100)},
+			{Ticker: "AAPL", Year: 2022, Metric: "EBITDA", Value: float64Ptr(40)},
+			{Ticker: "AAPL", Year: 2023, Metric: "Revenue", Value: float64Ptr(120)},
+		},
+	}
+	quotes := &stubStocksService{
+		response: &stocks.BatchResponse{
+			Summaries: []stocks.StockSummary{{Ticker: "AAPL", CurrentPrice: 150}},
+		},
+	}
+
+	aggregator := NewAggregator(fundamentals, quotes)
+	resp, err := aggregator.Query(context.Background(), Query{
+		Tickers:     []string{"AAPL"},
+		IncludeLive: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Companies, 1)
+
+	company := resp.Companies[0]
+	assert.Equal(t, "AAPL", company.Ticker)
+	assert.Equal(t, 100.0, company.Fundamentals[2022]["Revenue"])
+	assert.Equal(t, 40.0, company.Fundamentals[2022]["EBITDA"])
+	assert.Equal(t, 120.0, company.Fundamentals[2023]["Revenue"])
+	require.NotNil(t, company.Live)
+	assert.Equal(t, 150.0, company.Live.CurrentPrice)
+	assert.Empty(t, resp.Errors)
+
+	require.NotNil(t, fundamentals.lastFilter)
+	assert.Equal(t, []string{"AAPL"}, fundamentals.lastFilter.Tickers)
+}
+
+func TestAggregator_Query_ReportsPartialFailuresPerTicker(t *testing.T) {
+	fundamentals := &stubFundamentalsRepository{
+		records: []dax.DAXRecord{
+			{Ticker: "AAPL", Year: 2022, Metric: "Revenue", Value: float64Ptr(100)},
+		},
+	}
+	quotes := &stubStocksService{
+		response: &stocks.BatchResponse{
+			Summaries: []stocks.StockSummary{{Ticker: "AAPL", CurrentPrice: 150}},
+			Errors:    []stocks.BatchError{{Ticker: "MSFT", Message: "ticker not found"}},
+		},
+	}
+
+	aggregator := NewAggregator(fundamentals, quotes)
+	resp, err := aggregator.Query(context.Background(), Query{
+		Tickers:     []string{"AAPL", "MSFT"},
+		IncludeLive: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Companies, 2)
+
+	assert.Equal(t, "ticker not found", resp.Errors["MSFT"])
+	_, aaplHasError := resp.Errors["AAPL"]
+	assert.False(t, aaplHasError, "AAPL has fundamentals and a live quote, so it shouldn't be reported as an error")
+}
+
+func TestAggregator_Query_PropagatesFundamentalsError(t *testing.T) {
+	fundamentals := &stubFundamentalsRepository{err: assert.AnError}
+	quotes := &stubStocksService{response: &stocks.BatchResponse{}}
+
+	aggregator := NewAggregator(fundamentals, quotes)
+	_, err := aggregator.Query(context.Background(), Query{Tickers: []string{"AAPL"}})
+	require.Error(t, err)
+}
@@ -0,0 +1,169 @@
+package health
+
+import (
+	"context"
+	"runtime"
+	"syscall"
+
+	"github.com/f00b455/blank-go/pkg/stocks"
+	"gorm.io/gorm"
+)
+
+// defaultGoroutineThreshold is the goroutine count above which
+// GoroutineChecker reports StatusDegraded, picked well above what this
+// service's worker pools (dax imports, executions, weather prefetch) run
+// under normal load.
+const defaultGoroutineThreshold = 5000
+
+// defaultMinFreeBytes is the free-disk-space floor below which DiskChecker
+// reports StatusDegraded.
+const defaultMinFreeBytes = 1 << 30 // 1 GiB
+
+// defaultStocksCheckTicker is the ticker StocksChecker's lightweight probe
+// fetches when the caller doesn't name one: a large, liquid, always-listed
+// ticker unlikely to itself be the source of an upstream error.
+const defaultStocksCheckTicker = "AAPL"
+
+// DBChecker checks that a GORM-backed Postgres connection is reachable,
+// via db.DB().PingContext plus a trivial SELECT 1.
+type DBChecker struct {
+	db *gorm.DB
+}
+
+// NewDBChecker creates a DBChecker for db.
+func NewDBChecker(db *gorm.DB) *DBChecker {
+	return &DBChecker{db: db}
+}
+
+// Name implements Checker.
+func (c *DBChecker) Name() string { return "database" }
+
+// Check implements Checker.
+func (c *DBChecker) Check(ctx context.Context) Result {
+	sqlDB, err := c.db.DB()
+	if err != nil {
+		return Result{Status: StatusDown, Error: err.Error()}
+	}
+
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return Result{Status: StatusDown, Error: err.Error()}
+	}
+
+	if err := c.db.WithContext(ctx).Exec("SELECT 1").Error; err != nil {
+		return Result{Status: StatusDown, Error: err.Error()}
+	}
+
+	return Result{Status: StatusUp}
+}
+
+// StocksChecker probes the upstream stocks API through the same
+// ResilientClient the stocks service uses. When that client's circuit
+// breaker is already open, it reports StatusDegraded without spending the
+// probe, rather than hammering a known-down upstream on every /readyz poll.
+type StocksChecker struct {
+	client *stocks.ResilientClient
+	ticker string
+}
+
+// NewStocksChecker creates a StocksChecker that probes ticker through
+// client. An empty ticker defaults to defaultStocksCheckTicker.
+func NewStocksChecker(client *stocks.ResilientClient, ticker string) *StocksChecker {
+	if ticker == "" {
+		ticker = defaultStocksCheckTicker
+	}
+	return &StocksChecker{client: client, ticker: ticker}
+}
+
+// Name implements Checker.
+func (c *StocksChecker) Name() string { return "stocks_upstream" }
+
+// Check implements Checker.
+func (c *StocksChecker) Check(ctx context.Context) Result {
+	if !c.client.UpstreamAvailable() {
+		return Result{Status: StatusDegraded, Error: "circuit breaker open"}
+	}
+
+	if _, err := c.client.GetQuote(ctx, c.ticker); err != nil {
+		return Result{Status: StatusDegraded, Error: err.Error()}
+	}
+
+	return Result{Status: StatusUp}
+}
+
+// GoroutineChecker reports StatusDegraded once the number of running
+// goroutines passes Threshold - a coarse signal of a goroutine leak.
+type GoroutineChecker struct {
+	Threshold int
+}
+
+// NewGoroutineChecker creates a GoroutineChecker. A threshold <= 0 defaults
+// to defaultGoroutineThreshold.
+func NewGoroutineChecker(threshold int) *GoroutineChecker {
+	if threshold <= 0 {
+		threshold = defaultGoroutineThreshold
+	}
+	return &GoroutineChecker{Threshold: threshold}
+}
+
+// Name implements Checker.
+func (c *GoroutineChecker) Name() string { return "goroutines" }
+
+// Check implements Checker.
+func (c *GoroutineChecker) Check(_ context.Context) Result {
+	count := runtime.NumGoroutine()
+
+	status := StatusUp
+	if count > c.Threshold {
+		status = StatusDegraded
+	}
+
+	return Result{
+		Status:  status,
+		Details: map[string]any{"count": count, "threshold": c.Threshold},
+	}
+}
+
+// DiskChecker reports StatusDegraded once free space on Path drops below
+// MinFreeBytes - a coarse signal the data volume is filling up.
+type DiskChecker struct {
+	Path         string
+	MinFreeBytes uint64
+}
+
+// NewDiskChecker creates a DiskChecker for path. An empty path defaults to
+// "/"; a minFreeBytes of 0 defaults to defaultMinFreeBytes.
+func NewDiskChecker(path string, minFreeBytes uint64) *DiskChecker {
+	if path == "" {
+		path = "/"
+	}
+	if minFreeBytes == 0 {
+		minFreeBytes = defaultMinFreeBytes
+	}
+	return &DiskChecker{Path: path, MinFreeBytes: minFreeBytes}
+}
+
+// Name implements Checker.
+func (c *DiskChecker) Name() string { return "disk" }
+
+// Check implements Checker.
+func (c *DiskChecker) Check(_ context.Context) Result {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(c.Path, &stat); err != nil {
+		return Result{Status: StatusDown, Error: err.Error()}
+	}
+
+	freeBytes := uint64(stat.Bavail) * uint64(stat.Bsize)
+
+	status := StatusUp
+	if freeBytes < c.MinFreeBytes {
+		status = StatusDegraded
+	}
+
+	return Result{
+		Status: status,
+		Details: map[string]any{
+			"free_bytes":     freeBytes,
+			"min_free_bytes": c.MinFreeBytes,
+		},
+	}
+}
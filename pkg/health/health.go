@@ -0,0 +1,181 @@
+// Package health provides a pluggable readiness-check registry: a Checker
+// probes one subsystem (a database, an upstream API, the local disk, ...)
+// and a Registry runs every registered Checker in parallel, bounding each by
+// a timeout and caching its last Result for a TTL so frequent /readyz polling
+// doesn't hammer the checked subsystems.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Status is the outcome of a single Checker invocation.
+type Status string
+
+const (
+	// StatusUp means the subsystem is fully functional.
+	StatusUp Status = "up"
+	// StatusDegraded means the subsystem is reachable but impaired (for
+	// example, a stocks upstream whose circuit breaker has tripped).
+	StatusDegraded Status = "degraded"
+	// StatusDown means the subsystem could not be reached or failed its
+	// check outright.
+	StatusDown Status = "down"
+)
+
+// Result is what a Checker reports back for one Check call. Latency is
+// always measured by the Registry around the call, in seconds, matching the
+// *Seconds float64 convention used elsewhere in this codebase for
+// JSON-friendly durations.
+type Result struct {
+	Status  Status         `json:"status"`
+	Latency float64        `json:"latency_seconds"`
+	Error   string         `json:"error,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// Checker probes a single subsystem and reports whether it's healthy.
+type Checker interface {
+	Name() string
+	Check(ctx context.Context) Result
+}
+
+// registeredChecker pairs a Checker with whether its failure should fail
+// Registry.Ready overall.
+type registeredChecker struct {
+	checker  Checker
+	critical bool
+}
+
+// cachedResult is a Checker's last Result, reusable until expires.
+type cachedResult struct {
+	result  Result
+	expires time.Time
+}
+
+// Registry runs a set of Checkers in parallel, bounding each by timeout and
+// caching its Result for ttl.
+type Registry struct {
+	timeout time.Duration
+	ttl     time.Duration
+
+	checkers []registeredChecker
+
+	mu     sync.Mutex
+	cached map[string]cachedResult
+}
+
+// NewRegistry creates a Registry that gives each Checker up to timeout to
+// respond and reuses a checker's last Result for up to ttl afterward. A ttl
+// of 0 disables caching.
+func NewRegistry(timeout, ttl time.Duration) *Registry {
+	return &Registry{
+		timeout: timeout,
+		ttl:     ttl,
+		cached:  make(map[string]cachedResult),
+	}
+}
+
+// Register adds c to the registry as non-critical: its Status is reported
+// by Run but never fails Ready. Not safe to call concurrently with Run or
+// Ready.
+func (r *Registry) Register(c Checker) {
+	r.checkers = append(r.checkers, registeredChecker{checker: c})
+}
+
+// RegisterCritical adds c to the registry as critical: Ready reports false
+// whenever c's last Result isn't StatusUp. Not safe to call concurrently
+// with Run or Ready.
+func (r *Registry) RegisterCritical(c Checker) {
+	r.checkers = append(r.checkers, registeredChecker{checker: c, critical: true})
+}
+
+// Run executes every registered Checker (reusing a still-fresh cached
+// Result instead of re-running it) in parallel and returns each one's
+// latest Result keyed by Name().
+func (r *Registry) Run(ctx context.Context) map[string]Result {
+	results := make(map[string]Result, len(r.checkers))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, rc := range r.checkers {
+		rc := rc
+		if cached, ok := r.cachedFor(rc.checker.Name()); ok {
+			mu.Lock()
+			results[rc.checker.Name()] = cached
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := r.runOne(ctx, rc.checker)
+			mu.Lock()
+			results[rc.checker.Name()] = result
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// Ready runs every registered Checker and reports whether the service is
+// ready to serve traffic: true only if every Checker registered via
+// RegisterCritical reports StatusUp.
+func (r *Registry) Ready(ctx context.Context) (map[string]Result, bool) {
+	results := r.Run(ctx)
+
+	ready := true
+	for _, rc := range r.checkers {
+		if rc.critical && results[rc.checker.Name()].Status != StatusUp {
+			ready = false
+		}
+	}
+
+	return results, ready
+}
+
+// cachedFor returns name's cached Result if it hasn't expired.
+func (r *Registry) cachedFor(name string) (Result, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cached, ok := r.cached[name]
+	if !ok || time.Now().After(cached.expires) {
+		return Result{}, false
+	}
+	return cached.result, true
+}
+
+// runOne bounds c.Check by the registry's timeout, measures its latency,
+// and caches the Result for ttl before returning it.
+func (r *Registry) runOne(ctx context.Context, c Checker) Result {
+	checkCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan Result, 1)
+	go func() {
+		done <- c.Check(checkCtx)
+	}()
+
+	var result Result
+	select {
+	case result = <-done:
+	case <-checkCtx.Done():
+		result = Result{Status: StatusDown, Error: "timed out"}
+	}
+	result.Latency = time.Since(start).Seconds()
+
+	if r.ttl > 0 {
+		r.mu.Lock()
+		r.cached[c.Name()] = cachedResult{result: result, expires: time.Now().Add(r.ttl)}
+		r.mu.Unlock()
+	}
+
+	return result
+}
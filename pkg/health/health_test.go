@@ -0,0 +1,121 @@
+package health
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeChecker is a Checker whose behavior is scripted by fn for tests that
+// don't want a real database or upstream API.
+type fakeChecker struct {
+	name string
+	fn   func(ctx context.Context) Result
+}
+
+func (c fakeChecker) Name() string { return c.name }
+
+func (c fakeChecker) Check(ctx context.Context) Result { return c.fn(ctx) }
+
+func upChecker(name string) fakeChecker {
+	return fakeChecker{name: name, fn: func(context.Context) Result {
+		return Result{Status: StatusUp}
+	}}
+}
+
+func downChecker(name, errMsg string) fakeChecker {
+	return fakeChecker{name: name, fn: func(context.Context) Result {
+		return Result{Status: StatusDown, Error: errMsg}
+	}}
+}
+
+func hangingChecker(name string) fakeChecker {
+	return fakeChecker{name: name, fn: func(ctx context.Context) Result {
+		<-ctx.Done()
+		return Result{Status: StatusUp}
+	}}
+}
+
+func TestRegistry_Run_Success(t *testing.T) {
+	r := NewRegistry(time.Second, 0)
+	r.Register(upChecker("a"))
+	r.Register(upChecker("b"))
+
+	results := r.Run(context.Background())
+
+	assert.Equal(t, StatusUp, results["a"].Status)
+	assert.Equal(t, StatusUp, results["b"].Status)
+}
+
+func TestRegistry_Run_Failure(t *testing.T) {
+	r := NewRegistry(time.Second, 0)
+	r.Register(downChecker("broken", "connection refused"))
+
+	results := r.Run(context.Background())
+
+	assert.Equal(t, StatusDown, results["broken"].Status)
+	assert.Equal(t, "connection refused", results["broken"].Error)
+}
+
+func TestRegistry_Run_Timeout(t *testing.T) {
+	r := NewRegistry(10*time.Millisecond, 0)
+	r.Register(hangingChecker("slow"))
+
+	start := time.Now()
+	results := r.Run(context.Background())
+
+	assert.Equal(t, StatusDown, results["slow"].Status)
+	assert.Equal(t, "timed out", results["slow"].Error)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRegistry_Run_CachesUntilTTLExpires(t *testing.T) {
+	var calls int32
+	r := NewRegistry(time.Second, 50*time.Millisecond)
+	r.Register(fakeChecker{name: "counted", fn: func(context.Context) Result {
+		atomic.AddInt32(&calls, 1)
+		return Result{Status: StatusUp}
+	}})
+
+	r.Run(context.Background())
+	r.Run(context.Background())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	time.Sleep(60 * time.Millisecond)
+	r.Run(context.Background())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestRegistry_Ready_TrueWhenCriticalCheckersAreUp(t *testing.T) {
+	r := NewRegistry(time.Second, 0)
+	r.RegisterCritical(upChecker("db"))
+	r.Register(upChecker("goroutines"))
+
+	_, ready := r.Ready(context.Background())
+
+	assert.True(t, ready)
+}
+
+func TestRegistry_Ready_FalseWhenACriticalCheckerFails(t *testing.T) {
+	r := NewRegistry(time.Second, 0)
+	r.RegisterCritical(downChecker("db", "connection refused"))
+	r.Register(upChecker("goroutines"))
+
+	results, ready := r.Ready(context.Background())
+
+	assert.False(t, ready)
+	assert.Equal(t, StatusDown, results["db"].Status)
+}
+
+func TestRegistry_Ready_IgnoresNonCriticalFailures(t *testing.T) {
+	r := NewRegistry(time.Second, 0)
+	r.RegisterCritical(upChecker("db"))
+	r.Register(downChecker("goroutines", "too many goroutines"))
+
+	_, ready := r.Ready(context.Background())
+
+	assert.True(t, ready)
+}
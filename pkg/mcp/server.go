@@ -0,0 +1,268 @@
+// Package mcp implements a minimal Model Context Protocol server: JSON-RPC
+// 2.0 request/response framing over newline-delimited stdin/stdout, an
+// initialize/tools-list/tools-call method dispatch, and a Tool registry
+// that lets a binary expose its tools without reimplementing the protocol
+// plumbing. cmd/mcp (weather) and cmd/mcp-task are both thin wrappers
+// around a Server built with this package.
+package mcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// protocolVersion is the MCP protocol revision this package speaks.
+const protocolVersion = "2024-11-05"
+
+// JSONRPCRequest is an incoming JSON-RPC 2.0 request or notification (a
+// notification has no ID and expects no response).
+type JSONRPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      any             `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// JSONRPCResponse is the JSON-RPC 2.0 response Server.Run writes back for
+// every request that isn't a notification.
+type JSONRPCResponse struct {
+	JSONRPC string    `json:"jsonrpc"`
+	ID      any       `json:"id"`
+	Result  any       `json:"result,omitempty"`
+	Error   *RPCError `json:"error,omitempty"`
+}
+
+// RPCError is a JSON-RPC 2.0 error object.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// ServerInfo identifies this server in the initialize handshake.
+type ServerInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// InitializeResult is returned from the "initialize" method.
+type InitializeResult struct {
+	ProtocolVersion string         `json:"protocolVersion"`
+	ServerInfo      ServerInfo     `json:"serverInfo"`
+	Capabilities    map[string]any `json:"capabilities"`
+}
+
+// Tool describes one callable tool, as returned from "tools/list".
+type Tool struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	InputSchema InputSchema `json:"inputSchema"`
+}
+
+// InputSchema is a Tool's JSON Schema for its tools/call arguments.
+type InputSchema struct {
+	Type       string              `json:"type"`
+	Properties map[string]Property `json:"properties,omitempty"`
+	Required   []string            `json:"required,omitempty"`
+}
+
+// Property describes one field of an InputSchema.
+type Property struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// toolsListResult is the "tools/list" result payload.
+type toolsListResult struct {
+	Tools []Tool `json:"tools"`
+}
+
+// callToolParams is the "tools/call" request payload.
+type callToolParams struct {
+	Name      string         `json:"name"`
+	Arguments map[string]any `json:"arguments,omitempty"`
+}
+
+// TextContent is a single block of a CallToolResult, MCP's plain-text
+// content type.
+type TextContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// CallToolResult is a tool's response to "tools/call".
+type CallToolResult struct {
+	Content []TextContent `json:"content"`
+	IsError bool          `json:"isError,omitempty"`
+}
+
+// Text builds a single-block CallToolResult from text, the common case for
+// a ToolHandler that succeeds.
+func Text(text string) CallToolResult {
+	return CallToolResult{Content: []TextContent{{Type: "text", Text: text}}}
+}
+
+// Errorf builds a single-block, IsError CallToolResult from a formatted
+// message, the common case for a ToolHandler reporting a user-facing
+// failure (as opposed to returning a Go error, which tools/call turns into
+// a protocol-level error instead).
+func Errorf(format string, args ...any) CallToolResult {
+	return CallToolResult{
+		Content: []TextContent{{Type: "text", Text: fmt.Sprintf(format, args...)}},
+		IsError: true,
+	}
+}
+
+// ToolHandler implements one Tool's behavior. Returning an error reports a
+// JSON-RPC protocol-level error (invalid params, unexpected failure); a
+// handler that encounters a normal, user-facing failure (a missing
+// record, an invalid argument value) should instead return an IsError
+// CallToolResult (see Errorf) so the caller sees it as the tool's result.
+type ToolHandler func(arguments map[string]any) (CallToolResult, error)
+
+// registeredTool pairs a Tool's advertised schema with the handler that
+// serves its tools/call requests.
+type registeredTool struct {
+	tool    Tool
+	handler ToolHandler
+}
+
+// Server dispatches MCP's initialize/tools-list/tools-call methods against
+// a registry of tools added with AddTool.
+type Server struct {
+	info  ServerInfo
+	tools []registeredTool
+}
+
+// NewServer creates a Server that identifies itself as name/version during
+// the initialize handshake.
+func NewServer(name, version string) *Server {
+	return &Server{info: ServerInfo{Name: name, Version: version}}
+}
+
+// AddTool registers tool, served by handler when a "tools/call" request
+// names it.
+func (s *Server) AddTool(tool Tool, handler ToolHandler) {
+	s.tools = append(s.tools, registeredTool{tool: tool, handler: handler})
+}
+
+// lookupTool returns the registeredTool named name, or false if no tool by
+// that name was added.
+func (s *Server) lookupTool(name string) (registeredTool, bool) {
+	for _, t := range s.tools {
+		if t.tool.Name == name {
+			return t, true
+		}
+	}
+	return registeredTool{}, false
+}
+
+// handle dispatches a single request to its method handler.
+func (s *Server) handle(req JSONRPCRequest) JSONRPCResponse {
+	switch req.Method {
+	case "initialize":
+		return JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Result: InitializeResult{
+				ProtocolVersion: protocolVersion,
+				ServerInfo:      s.info,
+				Capabilities:    map[string]any{"tools": map[string]any{}},
+			},
+		}
+
+	case "notifications/initialized":
+		// No response for notifications.
+		return JSONRPCResponse{}
+
+	case "tools/list":
+		tools := make([]Tool, len(s.tools))
+		for i, t := range s.tools {
+			tools[i] = t.tool
+		}
+		return JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Result:  toolsListResult{Tools: tools},
+		}
+
+	case "tools/call":
+		var params callToolParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return JSONRPCResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Error:   &RPCError{Code: -32602, Message: "Invalid params"},
+			}
+		}
+
+		t, ok := s.lookupTool(params.Name)
+		if !ok {
+			return JSONRPCResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Error:   &RPCError{Code: -32601, Message: fmt.Sprintf("Unknown tool: %s", params.Name)},
+			}
+		}
+
+		result, err := t.handler(params.Arguments)
+		if err != nil {
+			return JSONRPCResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Error:   &RPCError{Code: -32603, Message: err.Error()},
+			}
+		}
+		return JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: result}
+
+	default:
+		return JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error:   &RPCError{Code: -32601, Message: fmt.Sprintf("Method not found: %s", req.Method)},
+		}
+	}
+}
+
+// Run reads newline-delimited JSON-RPC requests from r and writes
+// responses to w until r returns io.EOF, logging malformed lines and
+// handler errors to stderr rather than aborting. It returns once r is
+// exhausted.
+func (s *Server) Run(r io.Reader, w io.Writer, stderr io.Writer) error {
+	reader := bufio.NewReader(r)
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			fmt.Fprintf(stderr, "Read error: %v\n", err)
+			continue
+		}
+
+		var req JSONRPCRequest
+		if err := json.Unmarshal(line, &req); err != nil {
+			fmt.Fprintf(stderr, "Parse error: %v\n", err)
+			continue
+		}
+
+		resp := s.handle(req)
+
+		// Don't send a response for notifications.
+		if resp.ID == nil && resp.Result == nil && resp.Error == nil {
+			continue
+		}
+
+		respBytes, err := json.Marshal(resp)
+		if err != nil {
+			fmt.Fprintf(stderr, "Marshal error: %v\n", err)
+			continue
+		}
+
+		if _, err := fmt.Fprintln(w, string(respBytes)); err != nil {
+			fmt.Fprintf(stderr, "Write error: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testServer() *Server {
+	server := NewServer("test-mcp", "1.0.0")
+	server.AddTool(Tool{
+		Name:        "echo",
+		Description: "Echoes its input back",
+		InputSchema: InputSchema{
+			Type:       "object",
+			Properties: map[string]Property{"text": {Type: "string", Description: "text to echo"}},
+			Required:   []string{"text"},
+		},
+	}, func(arguments map[string]any) (CallToolResult, error) {
+		text, ok := arguments["text"].(string)
+		if !ok || text == "" {
+			return Errorf("text is required"), nil
+		}
+		return Text(text), nil
+	})
+	return server
+}
+
+func runLine(t *testing.T, server *Server, request string) JSONRPCResponse {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	require.NoError(t, server.Run(strings.NewReader(request+"\n"), &stdout, &stderr))
+
+	var resp JSONRPCResponse
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &resp))
+	return resp
+}
+
+func TestServer_Initialize(t *testing.T) {
+	resp := runLine(t, testServer(), `{"jsonrpc":"2.0","id":1,"method":"initialize"}`)
+
+	data, err := json.Marshal(resp.Result)
+	require.NoError(t, err)
+	var result InitializeResult
+	require.NoError(t, json.Unmarshal(data, &result))
+
+	assert.Equal(t, "test-mcp", result.ServerInfo.Name)
+	assert.Equal(t, protocolVersion, result.ProtocolVersion)
+}
+
+func TestServer_ToolsList(t *testing.T) {
+	resp := runLine(t, testServer(), `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`)
+
+	data, err := json.Marshal(resp.Result)
+	require.NoError(t, err)
+	var result toolsListResult
+	require.NoError(t, json.Unmarshal(data, &result))
+
+	require.Len(t, result.Tools, 1)
+	assert.Equal(t, "echo", result.Tools[0].Name)
+}
+
+func TestServer_ToolsCall(t *testing.T) {
+	t.Run("known tool succeeds", func(t *testing.T) {
+		resp := runLine(t, testServer(), `{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"echo","arguments":{"text":"hi"}}}`)
+
+		data, err := json.Marshal(resp.Result)
+		require.NoError(t, err)
+		var result CallToolResult
+		require.NoError(t, json.Unmarshal(data, &result))
+
+		require.Len(t, result.Content, 1)
+		assert.Equal(t, "hi", result.Content[0].Text)
+		assert.False(t, result.IsError)
+	})
+
+	t.Run("missing argument reports an IsError result, not a protocol error", func(t *testing.T) {
+		resp := runLine(t, testServer(), `{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"echo","arguments":{}}}`)
+
+		require.Nil(t, resp.Error)
+		data, err := json.Marshal(resp.Result)
+		require.NoError(t, err)
+		var result CallToolResult
+		require.NoError(t, json.Unmarshal(data, &result))
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("unknown tool is a protocol error", func(t *testing.T) {
+		resp := runLine(t, testServer(), `{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"does-not-exist"}}`)
+
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, -32601, resp.Error.Code)
+	})
+}
+
+func TestServer_UnknownMethod(t *testing.T) {
+	resp := runLine(t, testServer(), `{"jsonrpc":"2.0","id":6,"method":"does/not/exist"}`)
+
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, -32601, resp.Error.Code)
+}
+
+func TestServer_NotificationGetsNoResponse(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	server := testServer()
+	require.NoError(t, server.Run(strings.NewReader(`{"jsonrpc":"2.0","method":"notifications/initialized"}`+"\n"), &stdout, &stderr))
+
+	assert.Empty(t, stdout.String())
+}
@@ -0,0 +1,49 @@
+// Package operations provides a generic registry for long-running
+// background work that a caller wants to start, poll, and optionally
+// cancel without blocking the HTTP request that kicked it off.
+package operations
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned when an operation is not found.
+var ErrNotFound = errors.New("operation not found")
+
+// ErrNotCancelable is returned by Registry.Cancel when the operation has
+// already reached a terminal status (succeeded, failed, or canceled).
+var ErrNotCancelable = errors.New("operation is not running")
+
+// Status represents the current state of an Operation.
+type Status string
+
+const (
+	// StatusPending is an operation that has been accepted but whose
+	// goroutine has not started running yet.
+	StatusPending Status = "pending"
+	// StatusRunning is an operation currently executing.
+	StatusRunning Status = "running"
+	// StatusSucceeded is an operation that completed without error.
+	StatusSucceeded Status = "succeeded"
+	// StatusFailed is an operation that returned an error.
+	StatusFailed Status = "failed"
+	// StatusCanceled is an operation that was canceled via
+	// Registry.Cancel before it finished on its own.
+	StatusCanceled Status = "canceled"
+)
+
+// Operation tracks the lifecycle of a single Func execution submitted via
+// Registry.Run.
+type Operation struct {
+	ID         string      `json:"id"`
+	Status     Status      `json:"status"`
+	Progress   int         `json:"progress"`
+	CreatedAt  time.Time   `json:"created_at"`
+	FinishedAt *time.Time  `json:"finished_at,omitempty"`
+	Result     interface{} `json:"result,omitempty"`
+	Error      string      `json:"error,omitempty"`
+
+	cancel context.CancelFunc
+}
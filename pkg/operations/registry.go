@@ -0,0 +1,113 @@
+package operations
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Func is the work Registry.Run executes in the background. It receives a
+// context that's canceled if the operation is stopped via Registry.Cancel,
+// and a report callback for publishing a 0-100 progress value. The
+// returned value becomes Operation.Result.
+type Func func(ctx context.Context, report func(progress int)) (interface{}, error)
+
+// Registry runs Funcs in background goroutines and tracks each run as an
+// Operation, keyed by UUID, that callers can poll or cancel independently
+// of the request that started it.
+type Registry struct {
+	mu         sync.RWMutex
+	operations map[string]*Operation
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{operations: make(map[string]*Operation)}
+}
+
+// Run starts fn in a background goroutine and returns immediately with a
+// new Operation in StatusPending. The operation moves to StatusRunning
+// once fn begins, then to StatusSucceeded or StatusFailed when fn
+// returns, unless Cancel already moved it to StatusCanceled.
+func (r *Registry) Run(fn Func) *Operation {
+	ctx, cancel := context.WithCancel(context.Background())
+	op := &Operation{
+		ID:        uuid.New().String(),
+		Status:    StatusPending,
+		CreatedAt: time.Now(),
+		cancel:    cancel,
+	}
+
+	r.mu.Lock()
+	r.operations[op.ID] = op
+	r.mu.Unlock()
+
+	go r.run(ctx, op, fn)
+
+	return op
+}
+
+// Get retrieves an operation by ID.
+func (r *Registry) Get(id string) (*Operation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	op, ok := r.operations[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return op, nil
+}
+
+// Cancel cancels a pending or running operation via its stored
+// CancelFunc, marking it StatusCanceled immediately. It returns
+// ErrNotFound if id doesn't exist, or ErrNotCancelable if the operation
+// has already reached a terminal status.
+func (r *Registry) Cancel(id string) error {
+	r.mu.RLock()
+	op, ok := r.operations[id]
+	r.mu.RUnlock()
+	if !ok {
+		return ErrNotFound
+	}
+
+	if op.Status != StatusPending && op.Status != StatusRunning {
+		return ErrNotCancelable
+	}
+
+	op.Status = StatusCanceled
+	finished := time.Now()
+	op.FinishedAt = &finished
+	op.cancel()
+	return nil
+}
+
+// run is the worker body for a single operation.
+func (r *Registry) run(ctx context.Context, op *Operation, fn Func) {
+	op.Status = StatusRunning
+
+	result, err := fn(ctx, func(progress int) {
+		op.Progress = progress
+	})
+
+	// Cancel already marked the operation StatusCanceled; don't clobber
+	// that with whatever result or error the canceled context produced.
+	if op.Status == StatusCanceled {
+		return
+	}
+
+	finished := time.Now()
+	op.FinishedAt = &finished
+
+	if err != nil {
+		op.Status = StatusFailed
+		op.Error = err.Error()
+		return
+	}
+
+	op.Status = StatusSucceeded
+	op.Progress = 100
+	op.Result = result
+}
@@ -0,0 +1,92 @@
+package operations_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/operations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func waitForStatus(t *testing.T, registry *operations.Registry, id string, want operations.Status) *operations.Operation {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		op, err := registry.Get(id)
+		require.NoError(t, err)
+		if op.Status == want {
+			return op
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("operation %s did not reach status %s in time", id, want)
+	return nil
+}
+
+func TestRegistry_Run_RunsToCompletion(t *testing.T) {
+	registry := operations.NewRegistry()
+
+	op := registry.Run(func(ctx context.Context, report func(int)) (interface{}, error) {
+		report(50)
+		return "done", nil
+	})
+
+	done := waitForStatus(t, registry, op.ID, operations.StatusSucceeded)
+	assert.Equal(t, 100, done.Progress)
+	assert.Equal(t, "done", done.Result)
+	assert.NotNil(t, done.FinishedAt)
+}
+
+func TestRegistry_Run_RecordsError(t *testing.T) {
+	registry := operations.NewRegistry()
+
+	op := registry.Run(func(ctx context.Context, report func(int)) (interface{}, error) {
+		return nil, errors.New("boom")
+	})
+
+	failed := waitForStatus(t, registry, op.ID, operations.StatusFailed)
+	assert.Equal(t, "boom", failed.Error)
+}
+
+func TestRegistry_Cancel_StopsRunningOperation(t *testing.T) {
+	registry := operations.NewRegistry()
+	started := make(chan struct{})
+
+	op := registry.Run(func(ctx context.Context, report func(int)) (interface{}, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	<-started
+	require.NoError(t, registry.Cancel(op.ID))
+
+	canceled := waitForStatus(t, registry, op.ID, operations.StatusCanceled)
+	assert.NotNil(t, canceled.FinishedAt)
+}
+
+func TestRegistry_Cancel_NotFound(t *testing.T) {
+	registry := operations.NewRegistry()
+	assert.ErrorIs(t, registry.Cancel("missing"), operations.ErrNotFound)
+}
+
+func TestRegistry_Cancel_AlreadyTerminal(t *testing.T) {
+	registry := operations.NewRegistry()
+
+	op := registry.Run(func(ctx context.Context, report func(int)) (interface{}, error) {
+		return nil, nil
+	})
+	waitForStatus(t, registry, op.ID, operations.StatusSucceeded)
+
+	assert.ErrorIs(t, registry.Cancel(op.ID), operations.ErrNotCancelable)
+}
+
+func TestRegistry_Get_NotFound(t *testing.T) {
+	registry := operations.NewRegistry()
+	_, err := registry.Get("missing")
+	assert.ErrorIs(t, err, operations.ErrNotFound)
+}
@@ -0,0 +1,349 @@
+package stocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/clock"
+	"github.com/f00b455/blank-go/pkg/stocks/cache"
+)
+
+const (
+	// negativeTTL is how long a cached upstream failure (ticker not found,
+	// or a 4xx from the upstream API) is remembered, so repeated lookups
+	// for the same invalid ticker don't trigger another upstream request.
+	negativeTTL = 30 * time.Second
+	// negativeKeyPrefix distinguishes a cached failure sentinel's backend
+	// key from the real cached summary for the same ticker/session.
+	negativeKeyPrefix = "neg:"
+	// janitorInterval is how often the background janitor sweeps expired
+	// entries out of the admin-tracked entries map.
+	janitorInterval = time.Minute
+)
+
+// stockCache wraps a cache.Cache with admin-facing introspection: per-key
+// hit/miss counters, last-refresh source, and enumeration/selective
+// invalidation that the generic, backend-agnostic cache.Cache interface
+// doesn't provide. Tracking lives here (rather than in cache.Cache itself)
+// so it works the same whether the backend is the in-memory LRU cache or a
+// shared Redis instance, and so a Redis-backed deployment isn't forced to
+// implement key enumeration.
+type stockCache struct {
+	backend cache.Cache
+	clock   clock.Clock
+
+	mu      sync.Mutex
+	entries map[string]*CacheEntry
+
+	hits      int64
+	misses    int64
+	evictions int64
+
+	janitorCancel context.CancelFunc
+
+	// onChange, if set, is called from set whenever the stored summary's
+	// price or volume actually changed (or this is the first value seen
+	// for the key), so a watcherHub can publish exactly the updates that
+	// matter instead of being notified on every identical refresh.
+	onChange func(ticker, session string, summary *StockSummary)
+}
+
+// negativeValue is the backend payload stored by setNegative: just enough to
+// reconstruct the original failure's message.
+type negativeValue struct {
+	Message string `json:"message"`
+}
+
+// CacheMetrics summarizes a stockCache's aggregate state, surfaced through
+// the detailed health check and general observability.
+type CacheMetrics struct {
+	Size      int   `json:"cache_size"`
+	Hits      int64 `json:"cache_hits"`
+	Misses    int64 `json:"cache_misses"`
+	Evictions int64 `json:"cache_evictions"`
+}
+
+// newStockCache wraps backend with admin introspection, timestamping
+// tracked entries via clk.
+func newStockCache(backend cache.Cache, clk clock.Clock) *stockCache {
+	return &stockCache{
+		backend: backend,
+		clock:   clk,
+		entries: make(map[string]*CacheEntry),
+	}
+}
+
+// get fetches and unmarshals a cached summary, treating any decode error the
+// same as a cache miss, and records the hit/miss against ticker:session.
+func (c *stockCache) get(ctx context.Context, ticker, session string) (*StockSummary, bool) {
+	key := ticker + ":" + session
+
+	raw, found, err := c.backend.Get(ctx, key)
+	if err != nil || !found {
+		c.recordMiss(key, ticker, session)
+		return nil, false
+	}
+
+	var summary StockSummary
+	if err := json.Unmarshal(raw, &summary); err != nil {
+		c.recordMiss(key, ticker, session)
+		return nil, false
+	}
+
+	c.recordHit(key)
+	return &summary, true
+}
+
+// set marshals and stores summary under ticker:session with the given TTL,
+// recording source as how the value was obtained (e.g. "yahoo"). Cache
+// write failures are swallowed: caching is an optimization, not a
+// correctness requirement. If the price or volume actually changed versus
+// what was previously tracked for this key (or nothing was tracked yet),
+// onChange is notified outside the lock.
+func (c *stockCache) set(ctx context.Context, ticker, session string, summary *StockSummary, ttl time.Duration, source string) {
+	key := ticker + ":" + session
+
+	raw, err := json.Marshal(summary)
+	if err != nil {
+		return
+	}
+	if err := c.backend.Set(ctx, key, raw, ttl); err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	entry := c.entryLocked(key, ticker, session)
+	changed := entry.Summary == nil || entry.Summary.CurrentPrice != summary.CurrentPrice || entry.Summary.Volume != summary.Volume
+	entry.Summary = summary
+	entry.ExpiresAt = c.clock.Now().Add(ttl)
+	entry.Source = source
+	c.mu.Unlock()
+
+	if changed && c.onChange != nil {
+		c.onChange(ticker, session, summary)
+	}
+}
+
+// getNegative reports whether ticker/session currently has a cached upstream
+// failure remembered via setNegative, returning an error reconstructing the
+// original failure's message if so.
+func (c *stockCache) getNegative(ctx context.Context, ticker, session string) (error, bool) {
+	key := negativeKeyPrefix + ticker + ":" + session
+
+	raw, found, err := c.backend.Get(ctx, key)
+	if err != nil || !found {
+		return nil, false
+	}
+
+	var value negativeValue
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	c.hits++
+	c.mu.Unlock()
+
+	return errors.New(value.Message), true
+}
+
+// setNegative remembers that ticker/session's upstream fetch failed with
+// err, for negativeTTL, so repeated lookups for the same invalid ticker are
+// served from cache instead of hitting the upstream API again.
+func (c *stockCache) setNegative(ctx context.Context, ticker, session string, err error) {
+	key := negativeKeyPrefix + ticker + ":" + session
+
+	raw, marshalErr := json.Marshal(negativeValue{Message: err.Error()})
+	if marshalErr != nil {
+		return
+	}
+	_ = c.backend.Set(ctx, key, raw, negativeTTL)
+}
+
+func (c *stockCache) recordHit(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.hits++
+	if entry, ok := c.entries[key]; ok {
+		entry.Hits++
+	}
+}
+
+func (c *stockCache) recordMiss(key, ticker, session string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.misses++
+	c.entryLocked(key, ticker, session).Misses++
+}
+
+// entryLocked returns the tracked entry for key, creating it if absent.
+// Callers must hold c.mu.
+func (c *stockCache) entryLocked(key, ticker, session string) *CacheEntry {
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &CacheEntry{Ticker: ticker, Session: session}
+		c.entries[key] = entry
+	}
+	return entry
+}
+
+// isLiveEntry reports whether entry still holds a cached summary that
+// hasn't expired as of now.
+func isLiveEntry(entry *CacheEntry, now time.Time) bool {
+	return entry.Summary != nil && !now.After(entry.ExpiresAt)
+}
+
+// Dump returns a snapshot of every entry that currently still holds a live
+// (unexpired) cached summary, sorted by ticker then session.
+func (c *stockCache) Dump() []CacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	dump := make([]CacheEntry, 0, len(c.entries))
+	for _, entry := range c.entries {
+		if !isLiveEntry(entry, now) {
+			continue
+		}
+		snapshot := *entry
+		snapshot.TTLRemainingSeconds = entry.ExpiresAt.Sub(now).Seconds()
+		dump = append(dump, snapshot)
+	}
+
+	sort.Slice(dump, func(i, j int) bool {
+		if dump[i].Ticker != dump[j].Ticker {
+			return dump[i].Ticker < dump[j].Ticker
+		}
+		return dump[i].Session < dump[j].Session
+	})
+
+	return dump
+}
+
+// Metrics returns a snapshot of the cache's aggregate hit/miss/eviction
+// counters and its current live entry count.
+func (c *stockCache) Metrics() CacheMetrics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	size := 0
+	for _, entry := range c.entries {
+		if isLiveEntry(entry, now) {
+			size++
+		}
+	}
+
+	return CacheMetrics{
+		Size:      size,
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+	}
+}
+
+// Start launches a background goroutine that periodically sweeps expired
+// entries out of the admin-tracked entries map, so a ticker that's queried
+// once and never again doesn't hold onto its entry forever. It is a no-op if
+// already started; Stop (or canceling ctx) ends the goroutine.
+func (c *stockCache) Start(ctx context.Context) {
+	c.mu.Lock()
+	if c.janitorCancel != nil {
+		c.mu.Unlock()
+		return
+	}
+	runCtx, cancel := context.WithCancel(ctx)
+	c.janitorCancel = cancel
+	c.mu.Unlock()
+
+	go c.runJanitor(runCtx)
+}
+
+// Stop ends the background janitor started by Start.
+func (c *stockCache) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.janitorCancel == nil {
+		return
+	}
+	c.janitorCancel()
+	c.janitorCancel = nil
+}
+
+// runJanitor sweeps expired entries every janitorInterval until ctx is
+// canceled.
+func (c *stockCache) runJanitor(ctx context.Context) {
+	ticker := time.NewTicker(janitorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.sweep()
+		}
+	}
+}
+
+// sweep removes every admin-tracked entry whose cached summary has expired,
+// from both the entries map and the underlying backend.
+func (c *stockCache) sweep() {
+	now := time.Now()
+
+	c.mu.Lock()
+	var expired []string
+	for key, entry := range c.entries {
+		if entry.Summary != nil && now.After(entry.ExpiresAt) {
+			expired = append(expired, key)
+			delete(c.entries, key)
+		}
+	}
+	c.evictions += int64(len(expired))
+	c.mu.Unlock()
+
+	ctx := context.Background()
+	for _, key := range expired {
+		_ = c.backend.Delete(ctx, key)
+	}
+}
+
+// Invalidate removes every cached session for ticker, from both the
+// backing cache and the admin-tracked entries.
+func (c *stockCache) Invalidate(ctx context.Context, ticker string) {
+	c.mu.Lock()
+	var keys []string
+	for key, entry := range c.entries {
+		if entry.Ticker == ticker {
+			keys = append(keys, key)
+			delete(c.entries, key)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, key := range keys {
+		_ = c.backend.Delete(ctx, key)
+	}
+}
+
+// InvalidateAll clears every tracked cache entry.
+func (c *stockCache) InvalidateAll(ctx context.Context) {
+	c.mu.Lock()
+	keys := make([]string, 0, len(c.entries))
+	for key := range c.entries {
+		keys = append(keys, key)
+	}
+	c.entries = make(map[string]*CacheEntry)
+	c.mu.Unlock()
+
+	for _, key := range keys {
+		_ = c.backend.Delete(ctx, key)
+	}
+}
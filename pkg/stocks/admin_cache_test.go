@@ -0,0 +1,151 @@
+package stocks
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/clock"
+	"github.com/f00b455/blank-go/pkg/stocks/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStockCache_SetAndGet(t *testing.T) {
+	c := newStockCache(cache.NewLRUCache(0), clock.RealClock{})
+	ctx := context.Background()
+
+	summary := &StockSummary{Ticker: "AAPL"}
+	c.set(ctx, "AAPL", SessionRegular, summary, time.Minute, "yahoo")
+
+	got, found := c.get(ctx, "AAPL", SessionRegular)
+	require.True(t, found)
+	assert.Equal(t, "AAPL", got.Ticker)
+}
+
+func TestStockCache_Get_MissWhenAbsent(t *testing.T) {
+	c := newStockCache(cache.NewLRUCache(0), clock.RealClock{})
+
+	_, found := c.get(context.Background(), "AAPL", SessionRegular)
+	assert.False(t, found)
+}
+
+func TestStockCache_Dump_TracksHitsAndMisses(t *testing.T) {
+	c := newStockCache(cache.NewLRUCache(0), clock.RealClock{})
+	ctx := context.Background()
+
+	_, found := c.get(ctx, "AAPL", SessionRegular)
+	assert.False(t, found)
+
+	c.set(ctx, "AAPL", SessionRegular, &StockSummary{Ticker: "AAPL"}, time.Minute, "yahoo")
+	c.get(ctx, "AAPL", SessionRegular)
+	c.get(ctx, "AAPL", SessionRegular)
+
+	dump := c.Dump()
+	require.Len(t, dump, 1)
+	assert.Equal(t, "AAPL", dump[0].Ticker)
+	assert.Equal(t, int64(2), dump[0].Hits)
+	assert.Equal(t, int64(1), dump[0].Misses)
+	assert.Equal(t, "yahoo", dump[0].Source)
+}
+
+func TestStockCache_Dump_OmitsExpiredEntries(t *testing.T) {
+	c := newStockCache(cache.NewLRUCache(0), clock.RealClock{})
+	ctx := context.Background()
+
+	c.set(ctx, "AAPL", SessionRegular, &StockSummary{Ticker: "AAPL"}, -time.Second, "yahoo")
+
+	assert.Empty(t, c.Dump())
+}
+
+func TestStockCache_Invalidate_RemovesOnlyThatTicker(t *testing.T) {
+	c := newStockCache(cache.NewLRUCache(0), clock.RealClock{})
+	ctx := context.Background()
+
+	c.set(ctx, "AAPL", SessionRegular, &StockSummary{Ticker: "AAPL"}, time.Minute, "yahoo")
+	c.set(ctx, "GOOGL", SessionRegular, &StockSummary{Ticker: "GOOGL"}, time.Minute, "yahoo")
+
+	c.Invalidate(ctx, "AAPL")
+
+	_, found := c.get(ctx, "AAPL", SessionRegular)
+	assert.False(t, found)
+
+	_, found = c.get(ctx, "GOOGL", SessionRegular)
+	assert.True(t, found)
+}
+
+func TestStockCache_InvalidateAll_RemovesEverything(t *testing.T) {
+	c := newStockCache(cache.NewLRUCache(0), clock.RealClock{})
+	ctx := context.Background()
+
+	c.set(ctx, "AAPL", SessionRegular, &StockSummary{Ticker: "AAPL"}, time.Minute, "yahoo")
+	c.set(ctx, "GOOGL", SessionRegular, &StockSummary{Ticker: "GOOGL"}, time.Minute, "yahoo")
+
+	c.InvalidateAll(ctx)
+
+	assert.Empty(t, c.Dump())
+}
+
+func TestStockCache_Negative_SetAndGet(t *testing.T) {
+	c := newStockCache(cache.NewLRUCache(0), clock.RealClock{})
+	ctx := context.Background()
+
+	c.setNegative(ctx, "BOGUS", SessionRegular, errors.New("ticker not found"))
+
+	err, found := c.getNegative(ctx, "BOGUS", SessionRegular)
+	require.True(t, found)
+	assert.EqualError(t, err, "ticker not found")
+}
+
+func TestStockCache_Negative_MissWhenAbsent(t *testing.T) {
+	c := newStockCache(cache.NewLRUCache(0), clock.RealClock{})
+
+	_, found := c.getNegative(context.Background(), "AAPL", SessionRegular)
+	assert.False(t, found)
+}
+
+func TestStockCache_Metrics_ReflectsHitsMissesAndSize(t *testing.T) {
+	c := newStockCache(cache.NewLRUCache(0), clock.RealClock{})
+	ctx := context.Background()
+
+	c.get(ctx, "AAPL", SessionRegular)
+	c.set(ctx, "AAPL", SessionRegular, &StockSummary{Ticker: "AAPL"}, time.Minute, "yahoo")
+	c.get(ctx, "AAPL", SessionRegular)
+
+	metrics := c.Metrics()
+	assert.Equal(t, 1, metrics.Size)
+	assert.Equal(t, int64(1), metrics.Hits)
+	assert.Equal(t, int64(1), metrics.Misses)
+	assert.Equal(t, int64(0), metrics.Evictions)
+}
+
+func TestStockCache_Sweep_EvictsExpiredEntries(t *testing.T) {
+	c := newStockCache(cache.NewLRUCache(0), clock.RealClock{})
+	ctx := context.Background()
+
+	c.set(ctx, "AAPL", SessionRegular, &StockSummary{Ticker: "AAPL"}, -time.Second, "yahoo")
+	c.set(ctx, "GOOGL", SessionRegular, &StockSummary{Ticker: "GOOGL"}, time.Minute, "yahoo")
+
+	c.sweep()
+
+	assert.Equal(t, int64(1), c.Metrics().Evictions)
+	assert.Equal(t, 1, c.Metrics().Size)
+}
+
+func TestStockCache_StartStop_RunsJanitorUntilStopped(t *testing.T) {
+	c := newStockCache(cache.NewLRUCache(0), clock.RealClock{})
+	ctx := context.Background()
+
+	c.set(ctx, "AAPL", SessionRegular, &StockSummary{Ticker: "AAPL"}, -time.Second, "yahoo")
+
+	c.Start(ctx)
+	defer c.Stop()
+
+	// Starting twice should be a no-op rather than launching a second
+	// janitor goroutine.
+	c.Start(ctx)
+
+	c.Stop()
+	c.Stop()
+}
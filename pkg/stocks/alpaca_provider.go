@@ -0,0 +1,126 @@
+package stocks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const alpacaQuotesURL = "https://data.alpaca.markets/v2/stocks/quotes/latest"
+
+// AlpacaProvider implements Provider against Alpaca's Market Data API. It
+// only backs quotes: Alpaca's bars endpoint requires a separate
+// subscription tier this adapter doesn't assume, so GetBars always returns
+// an error, the same honest way weather.WorldWeatherOnlineProvider.
+// GetAlerts documents a feed its backing API simply doesn't have.
+type AlpacaProvider struct {
+	httpClient *http.Client
+	keyID      string
+	secretKey  string
+}
+
+// NewAlpacaProvider creates an AlpacaProvider authenticated with the given
+// API key ID/secret pair (see Alpaca's account dashboard).
+func NewAlpacaProvider(keyID, secretKey string) *AlpacaProvider {
+	return &AlpacaProvider{
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		keyID:      keyID,
+		secretKey:  secretKey,
+	}
+}
+
+// alpacaQuotesResponse is the shape of a /v2/stocks/quotes/latest response.
+type alpacaQuotesResponse struct {
+	Quotes map[string]struct {
+		AskPrice float64 `json:"ap"`
+		BidPrice float64 `json:"bp"`
+	} `json:"quotes"`
+}
+
+// GetQuotes fetches the latest quote for each of tickers from Alpaca's
+// quotes/latest endpoint in a single request. Alpaca reports only bid/ask,
+// not a full daily summary, so the returned YahooQuote's regular-market
+// fields are approximated from the midpoint of bid/ask; callers after a
+// genuine OHLC summary should prefer the yahoo provider.
+func (p *AlpacaProvider) GetQuotes(ctx context.Context, tickers []string) (map[string]*YahooQuote, error) {
+	if len(tickers) == 0 {
+		return nil, fmt.Errorf("at least one ticker is required")
+	}
+
+	params := url.Values{}
+	params.Set("symbols", strings.Join(tickers, ","))
+	requestURL := fmt.Sprintf("%s?%s", alpacaQuotesURL, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("APCA-API-KEY-ID", p.keyID)
+	req.Header.Set("APCA-API-SECRET-KEY", p.secretKey)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch quotes: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var alpacaResp alpacaQuotesResponse
+	if err := json.Unmarshal(body, &alpacaResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	quotes := make(map[string]*YahooQuote)
+	for symbol, q := range alpacaResp.Quotes {
+		mid := (q.AskPrice + q.BidPrice) / 2
+		quotes[symbol] = &YahooQuote{
+			Symbol:             symbol,
+			RegularMarketPrice: mid,
+			Currency:           "USD",
+			MarketState:        MarketStateRegular,
+		}
+	}
+
+	return quotes, nil
+}
+
+// GetQuote implements StocksClient, delegating to GetQuotes the same way
+// Client.GetQuote does.
+func (p *AlpacaProvider) GetQuote(ctx context.Context, ticker string) (*YahooQuote, error) {
+	if ticker == "" {
+		return nil, fmt.Errorf("ticker is required")
+	}
+	quotes, err := p.GetQuotes(ctx, []string{ticker})
+	if err != nil {
+		return nil, err
+	}
+	quote, ok := quotes[ticker]
+	if !ok {
+		return nil, fmt.Errorf("ticker not found")
+	}
+	return quote, nil
+}
+
+// GetBars always fails: see the AlpacaProvider doc comment.
+func (p *AlpacaProvider) GetBars(ctx context.Context, ticker string, opts BarsRequest) ([]Bar, error) {
+	return nil, fmt.Errorf("alpaca provider does not support bars")
+}
+
+// Name identifies this provider as "alpaca".
+func (p *AlpacaProvider) Name() ProviderName {
+	return ProviderAlpaca
+}
@@ -0,0 +1,118 @@
+package stocks
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Timeframe values accepted by BarsRequest.Timeframe
+const (
+	Timeframe1Min   = "1Min"
+	Timeframe5Min   = "5Min"
+	Timeframe15Min  = "15Min"
+	Timeframe1Hour  = "1Hour"
+	Timeframe1Day   = "1Day"
+	Timeframe1Week  = "1Week"
+	Timeframe1Month = "1Month"
+)
+
+// Adjustment values accepted by BarsRequest.Adjustment
+const (
+	AdjustmentRaw      = "raw"
+	AdjustmentSplit    = "split"
+	AdjustmentDividend = "dividend"
+	AdjustmentAll      = "all"
+)
+
+// defaultBarsLimit is the page size used when BarsRequest.Limit is unset.
+const defaultBarsLimit = 1000
+
+// BarsRequest describes a historical-bars query, following the Alpaca
+// market-data bars API: a ticker's OHLCV history over [Start, End) at a
+// given Timeframe, optionally split/dividend-adjusted and paginated via
+// PageToken.
+type BarsRequest struct {
+	Start      time.Time
+	End        time.Time
+	Timeframe  string
+	Adjustment string
+	Limit      int
+	PageToken  string
+}
+
+// Bar is a single OHLCV bar, matching Alpaca's bar shape.
+type Bar struct {
+	T  time.Time `json:"t"`
+	O  float64   `json:"o"`
+	H  float64   `json:"h"`
+	L  float64   `json:"l"`
+	C  float64   `json:"c"`
+	V  int64     `json:"v"`
+	N  int64     `json:"n"`
+	VW float64   `json:"vw"`
+}
+
+// BarsResponse is a page of bars plus an opaque cursor for fetching the
+// next page, mirroring Alpaca's next_page_token convention.
+type BarsResponse struct {
+	Bars          []Bar  `json:"bars"`
+	NextPageToken string `json:"next_page_token,omitempty"`
+}
+
+// MultiBarsResponse is the result of a multi-ticker bars request: one Bars
+// slice per ticker Yahoo returned data for, plus a BatchError for any
+// ticker that failed, mirroring BatchResponse's summaries/errors split for
+// quotes.
+type MultiBarsResponse struct {
+	Bars   map[string][]Bar `json:"bars"`
+	Errors []BatchError     `json:"errors,omitempty"`
+}
+
+// isValidTimeframe reports whether timeframe is one of the supported bar
+// timeframes.
+func isValidTimeframe(timeframe string) bool {
+	switch timeframe {
+	case Timeframe1Min, Timeframe5Min, Timeframe15Min, Timeframe1Hour, Timeframe1Day, Timeframe1Week, Timeframe1Month:
+		return true
+	default:
+		return false
+	}
+}
+
+// isValidAdjustment reports whether adjustment is one of the supported
+// adjustment modes.
+func isValidAdjustment(adjustment string) bool {
+	switch adjustment {
+	case "", AdjustmentRaw, AdjustmentSplit, AdjustmentDividend, AdjustmentAll:
+		return true
+	default:
+		return false
+	}
+}
+
+// encodeBarsPageToken and decodeBarsPageToken turn a bar offset into an
+// opaque cursor, so callers treat pagination as a token rather than an
+// index they could rely on being stable across requests.
+func encodeBarsPageToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodeBarsPageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("invalid page_token")
+	}
+
+	offset, err := strconv.Atoi(string(raw))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid page_token")
+	}
+
+	return offset, nil
+}
@@ -0,0 +1,18 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache is a pluggable key/value store for cached stock data. Values are
+// opaque byte slices (typically JSON) so the same interface can back both an
+// in-memory store and a shared Redis instance.
+type Cache interface {
+	// Get returns the value for key and whether it was found (and not expired).
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key with the given time-to-live.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete removes key from the cache, if present.
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/clock"
+)
+
+// defaultCapacity bounds an LRUCache created via NewLRUCache with no explicit
+// capacity.
+const defaultCapacity = 1000
+
+// LRUCache is an in-memory Cache with a bounded size: once it reaches
+// capacity, the least-recently-used entry is evicted to make room for new
+// ones. Entries also expire on their own TTL regardless of recency.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List
+	clock    clock.Clock
+}
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewLRUCache creates an in-memory LRU cache holding up to capacity entries.
+// A capacity <= 0 falls back to defaultCapacity.
+func NewLRUCache(capacity int) *LRUCache {
+	return NewLRUCacheWithClock(capacity, clock.RealClock{})
+}
+
+// NewLRUCacheWithClock creates an in-memory LRU cache whose TTL expiry is
+// measured against clk rather than the real wall clock, so a test can
+// assert expiry deterministically via a clock.FakeClock instead of
+// time.Sleep.
+func NewLRUCacheWithClock(capacity int, clk clock.Clock) *LRUCache {
+	if capacity <= 0 {
+		capacity = defaultCapacity
+	}
+	return &LRUCache{
+		capacity: capacity,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+		clock:    clk,
+	}
+}
+
+// Get implements Cache.
+func (c *LRUCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	entry := elem.Value.(*lruEntry)
+	if c.clock.Now().After(entry.expiresAt) {
+		c.removeElement(elem)
+		return nil, false, nil
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.value, true, nil
+}
+
+// Set implements Cache.
+func (c *LRUCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		entry := elem.Value.(*lruEntry)
+		entry.value = value
+		entry.expiresAt = c.clock.Now().Add(ttl)
+		c.order.MoveToFront(elem)
+		return nil
+	}
+
+	elem := c.order.PushFront(&lruEntry{
+		key:       key,
+		value:     value,
+		expiresAt: c.clock.Now().Add(ttl),
+	})
+	c.items[key] = elem
+
+	if c.order.Len() > c.capacity {
+		c.removeElement(c.order.Back())
+	}
+
+	return nil
+}
+
+// Delete implements Cache.
+func (c *LRUCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.removeElement(elem)
+	}
+	return nil
+}
+
+// removeElement evicts elem from both the map and the ordering list. Callers
+// must hold c.mu.
+func (c *LRUCache) removeElement(elem *list.Element) {
+	entry := elem.Value.(*lruEntry)
+	delete(c.items, entry.key)
+	c.order.Remove(elem)
+}
@@ -0,0 +1,95 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/clock"
+	"github.com/f00b455/blank-go/pkg/stocks/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUCache_SetAndGet(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewLRUCache(10)
+
+	require.NoError(t, c.Set(ctx, "AAPL", []byte("quote"), time.Minute))
+
+	value, found, err := c.Get(ctx, "AAPL")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("quote"), value)
+}
+
+func TestLRUCache_GetMissing(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewLRUCache(10)
+
+	value, found, err := c.Get(ctx, "MISSING")
+	require.NoError(t, err)
+	assert.False(t, found)
+	assert.Nil(t, value)
+}
+
+func TestLRUCache_Expiry(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := cache.NewLRUCacheWithClock(10, fake)
+
+	require.NoError(t, c.Set(ctx, "AAPL", []byte("quote"), time.Minute))
+
+	_, found, err := c.Get(ctx, "AAPL")
+	require.NoError(t, err)
+	assert.True(t, found, "entry should still be fresh before the TTL elapses")
+
+	fake.Advance(time.Minute + time.Second)
+
+	_, found, err = c.Get(ctx, "AAPL")
+	require.NoError(t, err)
+	assert.False(t, found, "entry should have expired once the TTL elapsed")
+}
+
+func TestLRUCache_Delete(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewLRUCache(10)
+
+	require.NoError(t, c.Set(ctx, "AAPL", []byte("quote"), time.Minute))
+	require.NoError(t, c.Delete(ctx, "AAPL"))
+
+	_, found, err := c.Get(ctx, "AAPL")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestLRUCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewLRUCache(2)
+
+	require.NoError(t, c.Set(ctx, "A", []byte("1"), time.Minute))
+	require.NoError(t, c.Set(ctx, "B", []byte("2"), time.Minute))
+
+	// Touch A so B becomes the least-recently-used entry.
+	_, _, err := c.Get(ctx, "A")
+	require.NoError(t, err)
+
+	require.NoError(t, c.Set(ctx, "C", []byte("3"), time.Minute))
+
+	_, found, err := c.Get(ctx, "B")
+	require.NoError(t, err)
+	assert.False(t, found, "B should have been evicted as the least-recently-used entry")
+
+	_, found, err = c.Get(ctx, "A")
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	_, found, err = c.Get(ctx, "C")
+	require.NoError(t, err)
+	assert.True(t, found)
+}
+
+func TestNewLRUCache_DefaultsOnNonPositiveCapacity(t *testing.T) {
+	c := cache.NewLRUCache(0)
+	assert.NotNil(t, c)
+}
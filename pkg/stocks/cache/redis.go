@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCache is a Cache backed by a shared Redis instance, suitable for
+// sharing cached quotes across multiple API replicas.
+type RedisCache struct {
+	client *redis.Client
+}
+
+// RedisConfig describes how to reach the Redis instance backing a RedisCache.
+type RedisConfig struct {
+	Addr     string
+	Password string
+	DB       int
+}
+
+// NewRedisCache creates a Cache backed by the Redis instance described by cfg.
+func NewRedisCache(cfg RedisConfig) *RedisCache {
+	return &RedisCache{
+		client: redis.NewClient(&redis.Options{
+			Addr:     cfg.Addr,
+			Password: cfg.Password,
+			DB:       cfg.DB,
+		}),
+	}
+}
+
+// Get implements Cache.
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	val, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("redis get failed: %w", err)
+	}
+	return val, true, nil
+}
+
+// Set implements Cache.
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := c.client.Set(ctx, key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("redis set failed: %w", err)
+	}
+	return nil
+}
+
+// Delete implements Cache.
+func (c *RedisCache) Delete(ctx context.Context, key string) error {
+	if err := c.client.Del(ctx, key).Err(); err != nil {
+		return fmt.Errorf("redis delete failed: %w", err)
+	}
+	return nil
+}
+
+// Close releases the underlying Redis connection pool.
+func (c *RedisCache) Close() error {
+	return c.client.Close()
+}
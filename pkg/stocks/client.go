@@ -1,42 +1,82 @@
 package stocks
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 )
 
 const (
 	yahooFinanceURL = "https://query1.finance.yahoo.com/v7/finance/quote"
+	yahooChartURL   = "https://query1.finance.yahoo.com/v8/finance/chart"
 	defaultTimeout  = 10 * time.Second
 )
 
-// StocksClient defines the interface for stock market data retrieval
+// StocksClient defines the interface for stock market data retrieval. Every
+// method takes ctx so a caller's deadline or cancellation reaches the
+// underlying HTTP request.
 type StocksClient interface {
-	GetQuote(ticker string) (*YahooQuote, error)
-	GetQuotes(tickers []string) (map[string]*YahooQuote, error)
+	GetQuote(ctx context.Context, ticker string) (*YahooQuote, error)
+	GetQuotes(ctx context.Context, tickers []string) (map[string]*YahooQuote, error)
+	GetBars(ctx context.Context, ticker string, opts BarsRequest) ([]Bar, error)
 }
 
 // Client implements StocksClient using Yahoo Finance API
 type Client struct {
 	httpClient *http.Client
 	baseURL    string
+	chartURL   string
 }
 
 // NewClient creates a new stocks client
 func NewClient() *Client {
+	return NewClientWithConfig(yahooFinanceURL, yahooChartURL, defaultTimeout)
+}
+
+// NewClientWithConfig creates a stocks client against a non-default
+// upstream (typically config.StocksConfig.UpstreamURL/TimeoutSeconds),
+// for pointing at a quote-compatible mirror or a test server instead of
+// the live Yahoo Finance API. chartURL is derived from baseURL's host if
+// empty, since Yahoo's own quote and chart endpoints only differ by path.
+func NewClientWithConfig(baseURL, chartURL string, timeout time.Duration) *Client {
+	if chartURL == "" {
+		chartURL = yahooChartURL
+	}
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
 	return &Client{
 		httpClient: &http.Client{
-			Timeout: defaultTimeout,
+			Timeout: timeout,
 		},
-		baseURL: yahooFinanceURL,
+		baseURL:  baseURL,
+		chartURL: chartURL,
 	}
 }
 
+// Name identifies this client as the "yahoo" provider for FallbackClient
+// and ProviderRegistry.
+func (c *Client) Name() ProviderName {
+	return ProviderYahoo
+}
+
+// StatusError wraps a non-200 response from the upstream Yahoo Finance API
+// with its status code, so callers can distinguish a definitive rejection
+// (4xx) from a transient server error worth retrying.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("API returned status %d", e.StatusCode)
+}
+
 // yahooResponse represents the Yahoo Finance API response
 type yahooResponse struct {
 	QuoteResponse struct {
@@ -46,12 +86,12 @@ type yahooResponse struct {
 }
 
 // GetQuote retrieves a single stock quote
-func (c *Client) GetQuote(ticker string) (*YahooQuote, error) {
+func (c *Client) GetQuote(ctx context.Context, ticker string) (*YahooQuote, error) {
 	if ticker == "" {
 		return nil, fmt.Errorf("ticker is required")
 	}
 
-	quotes, err := c.GetQuotes([]string{ticker})
+	quotes, err := c.GetQuotes(ctx, []string{ticker})
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +105,7 @@ func (c *Client) GetQuote(ticker string) (*YahooQuote, error) {
 }
 
 // GetQuotes retrieves multiple stock quotes
-func (c *Client) GetQuotes(tickers []string) (map[string]*YahooQuote, error) {
+func (c *Client) GetQuotes(ctx context.Context, tickers []string) (map[string]*YahooQuote, error) {
 	if len(tickers) == 0 {
 		return nil, fmt.Errorf("at least one ticker is required")
 	}
@@ -77,7 +117,7 @@ func (c *Client) GetQuotes(tickers []string) (map[string]*YahooQuote, error) {
 	requestURL := fmt.Sprintf("%s?%s", c.baseURL, params.Encode())
 
 	// Make HTTP request
-	resp, err := c.httpClient.Get(requestURL)
+	resp, err := c.doUpstreamRequest(ctx, requestURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch quotes: %w", err)
 	}
@@ -86,7 +126,7 @@ func (c *Client) GetQuotes(tickers []string) (map[string]*YahooQuote, error) {
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+		return nil, &StatusError{StatusCode: resp.StatusCode}
 	}
 
 	// Read response body
@@ -110,3 +150,219 @@ func (c *Client) GetQuotes(tickers []string) (map[string]*YahooQuote, error) {
 
 	return quotes, nil
 }
+
+// doUpstreamRequest performs an HTTP GET against the upstream Yahoo Finance
+// API bound to ctx, retrying a 429 or 503 response up to maxUpstreamRetries
+// times with exponential backoff plus full jitter (see backoffDelay),
+// mirroring pkg/weather's retryableGet. A response is returned to the
+// caller as soon as it isn't 429/503, or once retries are exhausted, so a
+// persistent rate-limit/unavailability still surfaces as a StatusError
+// rather than being retried forever.
+func (c *Client) doUpstreamRequest(ctx context.Context, requestURL string) (*http.Response, error) {
+	var resp *http.Response
+	var err error
+
+	for attempt := 1; attempt <= maxUpstreamRetries; attempt++ {
+		resp, err = c.doUpstreamRequestOnce(ctx, requestURL)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+			return resp, nil
+		}
+		if attempt == maxUpstreamRetries {
+			return resp, nil
+		}
+		_ = resp.Body.Close()
+
+		timer := time.NewTimer(backoffDelay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	return resp, err
+}
+
+// doUpstreamRequestOnce performs a single HTTP GET attempt against
+// requestURL, recording its latency and, on failure, incrementing
+// stocksUpstreamErrorsTotal labeled by response code ("error" for a
+// transport-level failure, including ctx expiring, that never produced a
+// response).
+func (c *Client) doUpstreamRequestOnce(ctx context.Context, requestURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	stocksUpstreamLatencySeconds.Set(time.Since(start).Seconds())
+
+	if err != nil {
+		stocksUpstreamErrorsTotal.WithLabelValues("error").Inc()
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		stocksUpstreamErrorsTotal.WithLabelValues(strconv.Itoa(resp.StatusCode)).Inc()
+	}
+
+	return resp, nil
+}
+
+// yahooIntervals maps BarsRequest.Timeframe to the interval values accepted
+// by Yahoo Finance's chart endpoint.
+var yahooIntervals = map[string]string{
+	Timeframe1Min:   "1m",
+	Timeframe5Min:   "5m",
+	Timeframe15Min:  "15m",
+	Timeframe1Hour:  "60m",
+	Timeframe1Day:   "1d",
+	Timeframe1Week:  "1wk",
+	Timeframe1Month: "1mo",
+}
+
+// chartResponse represents the Yahoo Finance chart API response
+type chartResponse struct {
+	Chart struct {
+		Result []chartResult `json:"result"`
+		Error  interface{}   `json:"error"`
+	} `json:"chart"`
+}
+
+// chartResult is a single ticker's series within a chartResponse
+type chartResult struct {
+	Timestamp  []int64 `json:"timestamp"`
+	Indicators struct {
+		Quote []struct {
+			Open   []float64 `json:"open"`
+			High   []float64 `json:"high"`
+			Low    []float64 `json:"low"`
+			Close  []float64 `json:"close"`
+			Volume []int64   `json:"volume"`
+		} `json:"quote"`
+		AdjClose []struct {
+			AdjClose []float64 `json:"adjclose"`
+		} `json:"adjclose"`
+	} `json:"indicators"`
+}
+
+// GetBars retrieves historical OHLCV bars for ticker from Yahoo Finance's
+// chart endpoint. opts.PageToken and opts.Limit select the page of bars to
+// return from the full [Start, End) window; GetBars itself never computes a
+// next-page token, since that decision belongs to the caller (Service
+// knows whether a full page implies more data is available).
+func (c *Client) GetBars(ctx context.Context, ticker string, opts BarsRequest) ([]Bar, error) {
+	if ticker == "" {
+		return nil, fmt.Errorf("ticker is required")
+	}
+
+	interval, ok := yahooIntervals[opts.Timeframe]
+	if !ok {
+		return nil, fmt.Errorf("invalid timeframe")
+	}
+
+	params := url.Values{}
+	params.Add("period1", strconv.FormatInt(opts.Start.Unix(), 10))
+	params.Add("period2", strconv.FormatInt(opts.End.Unix(), 10))
+	params.Add("interval", interval)
+
+	requestURL := fmt.Sprintf("%s/%s?%s", c.chartURL, ticker, params.Encode())
+
+	resp, err := c.doUpstreamRequest(ctx, requestURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bars: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var chartResp chartResponse
+	if err := json.Unmarshal(body, &chartResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if len(chartResp.Chart.Result) == 0 {
+		return nil, fmt.Errorf("ticker not found")
+	}
+
+	bars := barsFromChartResult(chartResp.Chart.Result[0], opts.Adjustment)
+
+	offset, err := decodeBarsPageToken(opts.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	if offset > len(bars) {
+		offset = len(bars)
+	}
+	bars = bars[offset:]
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultBarsLimit
+	}
+	if len(bars) > limit {
+		bars = bars[:limit]
+	}
+
+	return bars, nil
+}
+
+// barsFromChartResult converts a single Yahoo chart result into Bars,
+// applying adjustment by substituting the adjusted close for the raw close
+// when the caller asked for split/dividend/all adjustment. Yahoo's chart
+// API doesn't report per-bar trade count or VWAP, so N is left at zero and
+// VW is approximated as the bar's typical price.
+func barsFromChartResult(result chartResult, adjustment string) []Bar {
+	if len(result.Indicators.Quote) == 0 {
+		return nil
+	}
+	quote := result.Indicators.Quote[0]
+
+	var adjClose []float64
+	if adjustment != AdjustmentRaw && adjustment != "" && len(result.Indicators.AdjClose) > 0 {
+		adjClose = result.Indicators.AdjClose[0].AdjClose
+	}
+
+	bars := make([]Bar, 0, len(result.Timestamp))
+	for i, ts := range result.Timestamp {
+		if i >= len(quote.Open) || i >= len(quote.High) || i >= len(quote.Low) || i >= len(quote.Close) {
+			continue
+		}
+
+		closePrice := quote.Close[i]
+		if adjClose != nil && i < len(adjClose) {
+			closePrice = adjClose[i]
+		}
+
+		var volume int64
+		if i < len(quote.Volume) {
+			volume = quote.Volume[i]
+		}
+
+		bars = append(bars, Bar{
+			T:  time.Unix(ts, 0).UTC(),
+			O:  quote.Open[i],
+			H:  quote.High[i],
+			L:  quote.Low[i],
+			C:  closePrice,
+			V:  volume,
+			VW: (quote.High[i] + quote.Low[i] + closePrice) / 3,
+		})
+	}
+
+	return bars
+}
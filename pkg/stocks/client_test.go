@@ -1,6 +1,7 @@
 package stocks_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/f00b455/blank-go/pkg/stocks"
@@ -10,7 +11,7 @@ import (
 func TestClient_GetQuote_EmptyTicker(t *testing.T) {
 	client := stocks.NewClient()
 
-	quote, err := client.GetQuote("")
+	quote, err := client.GetQuote(context.Background(), "")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "ticker is required")
 	assert.Nil(t, quote)
@@ -19,7 +20,7 @@ func TestClient_GetQuote_EmptyTicker(t *testing.T) {
 func TestClient_GetQuotes_EmptyTickers(t *testing.T) {
 	client := stocks.NewClient()
 
-	quotes, err := client.GetQuotes([]string{})
+	quotes, err := client.GetQuotes(context.Background(), []string{})
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "at least one ticker is required")
 	assert.Nil(t, quotes)
@@ -0,0 +1,126 @@
+package stocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// ErrNoProviders is returned by FallbackClient when it was constructed with
+// no providers at all.
+var ErrNoProviders = errors.New("stocks: no providers configured")
+
+// FallbackClient implements StocksClient over an ordered list of Providers:
+// each call tries the first provider, and only moves on to the next when
+// the previous one failed with a transient error (a 5xx or 429 StatusError,
+// or ctx deadline/timeout expiring). A definitive rejection (e.g. a 4xx
+// other than 429, or "ticker not found") is returned immediately rather
+// than tried against every remaining provider, the same way
+// isCacheableNegative distinguishes a definitive rejection from a transient
+// failure worth retrying. This mirrors weather.FallbackProvider's
+// try-in-order structure, narrowed to only fail over on transient errors
+// per the failover policy requested for stocks.
+type FallbackClient struct {
+	providers []Provider
+}
+
+// NewFallbackClient creates a FallbackClient that tries providers in the
+// given order.
+func NewFallbackClient(providers ...Provider) *FallbackClient {
+	return &FallbackClient{providers: providers}
+}
+
+// isTransient reports whether err is worth failing over to the next
+// provider for, as opposed to a definitive rejection every provider would
+// also return.
+func isTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == 429 || statusErr.StatusCode >= 500
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return netErr.Timeout()
+	}
+
+	return false
+}
+
+// GetQuote implements StocksClient, delegating to GetQuotes the same way
+// Client.GetQuote does.
+func (f *FallbackClient) GetQuote(ctx context.Context, ticker string) (*YahooQuote, error) {
+	quotes, err := f.GetQuotes(ctx, []string{ticker})
+	if err != nil {
+		return nil, err
+	}
+	quote, ok := quotes[ticker]
+	if !ok {
+		return nil, fmt.Errorf("ticker not found")
+	}
+	return quote, nil
+}
+
+// GetQuotes tries each provider in order, stamping the successful
+// provider's name onto every returned quote's Source field. A transient
+// failure moves on to the next provider; any other failure, or a transient
+// failure from the last provider, is returned to the caller.
+func (f *FallbackClient) GetQuotes(ctx context.Context, tickers []string) (map[string]*YahooQuote, error) {
+	if len(f.providers) == 0 {
+		return nil, ErrNoProviders
+	}
+
+	var lastErr error
+	for i, provider := range f.providers {
+		quotes, err := provider.GetQuotes(ctx, tickers)
+		if err == nil {
+			for _, quote := range quotes {
+				quote.Source = provider.Name()
+			}
+			return quotes, nil
+		}
+		lastErr = err
+		if i < len(f.providers)-1 && !isTransient(err) {
+			return nil, err
+		}
+	}
+	return nil, lastErr
+}
+
+// GetBars tries each provider in order the same way GetQuotes does.
+func (f *FallbackClient) GetBars(ctx context.Context, ticker string, opts BarsRequest) ([]Bar, error) {
+	if len(f.providers) == 0 {
+		return nil, ErrNoProviders
+	}
+
+	var lastErr error
+	for i, provider := range f.providers {
+		bars, err := provider.GetBars(ctx, ticker, opts)
+		if err == nil {
+			return bars, nil
+		}
+		lastErr = err
+		if i < len(f.providers)-1 && !isTransient(err) {
+			return nil, err
+		}
+	}
+	return nil, lastErr
+}
+
+// Name identifies a FallbackClient by its primary (first) provider, falling
+// back to "none" if it was constructed empty.
+func (f *FallbackClient) Name() ProviderName {
+	if len(f.providers) == 0 {
+		return "none"
+	}
+	return f.providers[0].Name()
+}
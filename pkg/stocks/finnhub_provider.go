@@ -0,0 +1,128 @@
+package stocks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+const finnhubQuoteURL = "https://finnhub.io/api/v1/quote"
+
+// FinnhubProvider implements Provider against Finnhub's /quote endpoint.
+// Finnhub has no multi-symbol quote endpoint, so GetQuotes fans out one
+// request per ticker, same as fetchBarsInBatches does for Yahoo's
+// one-ticker-per-request chart endpoint - a ticker that fails doesn't abort
+// the rest, coming back as a partial result rather than failing the whole
+// call, matched here by simply omitting it (FallbackClient only fails over
+// on a GetQuotes call returning an error, so a single bad ticker shouldn't
+// poison a batch that otherwise succeeded).
+type FinnhubProvider struct {
+	httpClient *http.Client
+	apiKey     string
+}
+
+// NewFinnhubProvider creates a FinnhubProvider authenticated with apiKey.
+func NewFinnhubProvider(apiKey string) *FinnhubProvider {
+	return &FinnhubProvider{
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		apiKey:     apiKey,
+	}
+}
+
+// finnhubQuoteResponse is the shape of a /quote response: current price,
+// change, percent change, high/low/open, and previous close.
+type finnhubQuoteResponse struct {
+	CurrentPrice  float64 `json:"c"`
+	Change        float64 `json:"d"`
+	ChangePercent float64 `json:"dp"`
+	High          float64 `json:"h"`
+	Low           float64 `json:"l"`
+	Open          float64 `json:"o"`
+}
+
+// GetQuote fetches ticker's latest quote from Finnhub's /quote endpoint.
+func (p *FinnhubProvider) GetQuote(ctx context.Context, ticker string) (*YahooQuote, error) {
+	if ticker == "" {
+		return nil, fmt.Errorf("ticker is required")
+	}
+
+	params := url.Values{}
+	params.Set("symbol", ticker)
+	params.Set("token", p.apiKey)
+	requestURL := fmt.Sprintf("%s?%s", finnhubQuoteURL, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch quote: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var finnhubResp finnhubQuoteResponse
+	if err := json.Unmarshal(body, &finnhubResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if finnhubResp.CurrentPrice == 0 {
+		return nil, fmt.Errorf("ticker not found")
+	}
+
+	return &YahooQuote{
+		Symbol:                     ticker,
+		RegularMarketPrice:         finnhubResp.CurrentPrice,
+		RegularMarketOpen:          finnhubResp.Open,
+		RegularMarketHigh:          finnhubResp.High,
+		RegularMarketLow:           finnhubResp.Low,
+		RegularMarketChange:        finnhubResp.Change,
+		RegularMarketChangePercent: finnhubResp.ChangePercent,
+		Currency:                   "USD",
+		MarketState:                MarketStateRegular,
+	}, nil
+}
+
+// GetQuotes fetches each of tickers' quotes via GetQuote, since Finnhub has
+// no batch quote endpoint. A ticker that fails is simply omitted from the
+// result rather than failing the whole call.
+func (p *FinnhubProvider) GetQuotes(ctx context.Context, tickers []string) (map[string]*YahooQuote, error) {
+	if len(tickers) == 0 {
+		return nil, fmt.Errorf("at least one ticker is required")
+	}
+
+	quotes := make(map[string]*YahooQuote)
+	for _, ticker := range tickers {
+		quote, err := p.GetQuote(ctx, ticker)
+		if err != nil {
+			continue
+		}
+		quotes[ticker] = quote
+	}
+	return quotes, nil
+}
+
+// GetBars always fails: Finnhub's candle endpoint requires a paid plan this
+// adapter doesn't assume access to.
+func (p *FinnhubProvider) GetBars(ctx context.Context, ticker string, opts BarsRequest) ([]Bar, error) {
+	return nil, fmt.Errorf("finnhub provider does not support bars")
+}
+
+// Name identifies this provider as "finnhub".
+func (p *FinnhubProvider) Name() ProviderName {
+	return ProviderFinnhub
+}
@@ -0,0 +1,28 @@
+package stocks
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	cacheHitsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "stocks_cache_hits_total",
+		Help: "Total number of stock summary lookups served from cache.",
+	})
+
+	cacheMissesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "stocks_cache_misses_total",
+		Help: "Total number of stock summary lookups that missed the cache.",
+	})
+
+	stocksUpstreamLatencySeconds = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "stocks_upstream_latency_seconds",
+		Help: "Latency of the most recent request to the upstream Yahoo Finance API.",
+	})
+
+	stocksUpstreamErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "stocks_upstream_errors_total",
+		Help: "Total number of failed requests to the upstream Yahoo Finance API, labeled by response code.",
+	}, []string{"code"})
+)
@@ -2,6 +2,22 @@ package stocks
 
 import "time"
 
+// Market session values for StockSummary.MarketState
+const (
+	MarketStatePre     = "PRE"
+	MarketStateRegular = "REGULAR"
+	MarketStatePost    = "POST"
+	MarketStateClosed  = "CLOSED"
+)
+
+// Session query parameter values accepted by GetStockSummary
+const (
+	SessionRegular  = "regular"
+	SessionPre      = "pre"
+	SessionPost     = "post"
+	SessionExtended = "extended"
+)
+
 // StockSummary represents daily stock market summary
 type StockSummary struct {
 	Ticker        string    `json:"ticker"`
@@ -15,7 +31,41 @@ type StockSummary struct {
 	ChangePercent float64   `json:"change_percent"`
 	Volume        int64     `json:"volume"`
 	Currency      string    `json:"currency"`
+	MarketState   string    `json:"market_state"`
 	UpdatedAt     time.Time `json:"updated_at"`
+	// Source names the provider that served this summary's quote (e.g.
+	// "yahoo", "alpaca"), set from the originating YahooQuote.Source.
+	Source string `json:"source,omitempty"`
+}
+
+// StockEvent event types delivered by Service.Watch
+const (
+	StockEventSummary = "summary"
+	StockEventLagging = "lagging"
+)
+
+// StockEvent is a single update delivered to a Service.Watch subscriber:
+// either a changed Summary for Ticker, or a Type of StockEventLagging
+// reporting that this watcher fell behind and events were dropped for it.
+type StockEvent struct {
+	Type    string        `json:"type"`
+	Ticker  string        `json:"ticker"`
+	Summary *StockSummary `json:"summary,omitempty"`
+	Error   string        `json:"error,omitempty"`
+}
+
+// CacheEntry describes one tracked cache key for admin introspection via
+// Service.Dump: the cached summary itself, when it expires, and how often
+// it has been hit or missed since it was last (re)populated.
+type CacheEntry struct {
+	Ticker              string        `json:"ticker"`
+	Session             string        `json:"session"`
+	Summary             *StockSummary `json:"summary,omitempty"`
+	ExpiresAt           time.Time     `json:"expires_at"`
+	TTLRemainingSeconds float64       `json:"ttl_remaining_seconds"`
+	Hits                int64         `json:"hits"`
+	Misses              int64         `json:"misses"`
+	Source              string        `json:"source"`
 }
 
 // BatchResponse represents a batch of stock summaries
@@ -32,14 +82,26 @@ type BatchError struct {
 
 // YahooQuote represents the raw quote data from Yahoo Finance
 type YahooQuote struct {
-	Symbol             string  `json:"symbol"`
-	ShortName          string  `json:"shortName"`
-	RegularMarketPrice float64 `json:"regularMarketPrice"`
-	RegularMarketOpen  float64 `json:"regularMarketOpen"`
-	RegularMarketHigh  float64 `json:"regularMarketDayHigh"`
-	RegularMarketLow   float64 `json:"regularMarketDayLow"`
-	RegularMarketVolume int64   `json:"regularMarketVolume"`
-	Currency           string  `json:"currency"`
-	RegularMarketChange float64 `json:"regularMarketChange"`
+	Symbol                     string  `json:"symbol"`
+	ShortName                  string  `json:"shortName"`
+	RegularMarketPrice         float64 `json:"regularMarketPrice"`
+	RegularMarketOpen          float64 `json:"regularMarketOpen"`
+	RegularMarketHigh          float64 `json:"regularMarketDayHigh"`
+	RegularMarketLow           float64 `json:"regularMarketDayLow"`
+	RegularMarketVolume        int64   `json:"regularMarketVolume"`
+	Currency                   string  `json:"currency"`
+	RegularMarketChange        float64 `json:"regularMarketChange"`
 	RegularMarketChangePercent float64 `json:"regularMarketChangePercent"`
+	MarketState                string  `json:"marketState"`
+	PreMarketPrice             float64 `json:"preMarketPrice"`
+	PreMarketChange            float64 `json:"preMarketChange"`
+	PreMarketChangePercent     float64 `json:"preMarketChangePercent"`
+	PostMarketPrice            float64 `json:"postMarketPrice"`
+	PostMarketChange           float64 `json:"postMarketChange"`
+	PostMarketChangePercent    float64 `json:"postMarketChangePercent"`
+
+	// Source names the provider that returned this quote (e.g. "yahoo",
+	// "alpaca"). Set by FallbackClient when it fails over between
+	// providers; empty when the quote came from a single bare Client.
+	Source ProviderName `json:"-"`
 }
@@ -0,0 +1,50 @@
+package stocks
+
+import "fmt"
+
+// ProviderName identifies one upstream market-data provider.
+type ProviderName string
+
+// Providers supported by NewClientFromConfig/FallbackClient.
+const (
+	ProviderYahoo   ProviderName = "yahoo"
+	ProviderAlpaca  ProviderName = "alpaca"
+	ProviderFinnhub ProviderName = "finnhub"
+)
+
+// Provider is a StocksClient that can also identify itself, so
+// FallbackClient can record which upstream served a given call (see
+// YahooQuote.Source) and so ProviderRegistry can look providers up by
+// name. Every adapter (Client, AlpacaProvider, FinnhubProvider) implements
+// this the same way pkg/weather's Provider implementations each report
+// their own Name().
+type Provider interface {
+	StocksClient
+	Name() ProviderName
+}
+
+// ProviderRegistry holds a set of named Provider implementations,
+// mirroring weather.ProviderRegistry.
+type ProviderRegistry struct {
+	providers map[ProviderName]Provider
+}
+
+// NewProviderRegistry creates an empty ProviderRegistry.
+func NewProviderRegistry() *ProviderRegistry {
+	return &ProviderRegistry{providers: make(map[ProviderName]Provider)}
+}
+
+// Register adds provider under name, replacing any provider previously
+// registered under the same name.
+func (r *ProviderRegistry) Register(provider Provider) {
+	r.providers[provider.Name()] = provider
+}
+
+// Get returns the provider registered under name, or an error if none was.
+func (r *ProviderRegistry) Get(name ProviderName) (Provider, error) {
+	provider, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("no stocks provider registered for %q", name)
+	}
+	return provider, nil
+}
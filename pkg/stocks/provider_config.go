@@ -0,0 +1,83 @@
+package stocks
+
+import (
+	"fmt"
+	"time"
+)
+
+// Config configures NewClientFromConfig: which providers to wire up, in
+// what order, and their credentials/timeouts. It is deliberately separate
+// from internal/config.StocksConfig (pkg/stocks doesn't import internal/
+// packages, the same way pkg/weather's provider constructors take plain
+// strings rather than a config.WeatherConfig).
+type Config struct {
+	// Providers lists the provider names to wire, in failover order (e.g.
+	// []ProviderName{ProviderYahoo, ProviderAlpaca}). A single entry
+	// behaves exactly like calling that provider's constructor directly -
+	// NewClientFromConfig only wraps multiple providers in a
+	// FallbackClient.
+	Providers []ProviderName
+
+	// UpstreamURL and ChartURL configure the yahoo provider; see
+	// NewClientWithConfig.
+	UpstreamURL string
+	ChartURL    string
+	Timeout     time.Duration
+
+	// AlpacaKeyID and AlpacaSecretKey authenticate the alpaca provider.
+	AlpacaKeyID     string
+	AlpacaSecretKey string
+
+	// FinnhubAPIKey authenticates the finnhub provider.
+	FinnhubAPIKey string
+}
+
+// NewClientFromConfig builds the StocksClient described by cfg: one
+// provider adapter per cfg.Providers entry, wrapped in a FallbackClient
+// when there's more than one so a transient failure on the primary falls
+// over to the next. With a single provider, it's returned directly (no
+// FallbackClient indirection) the same way a ResilientClient wraps exactly
+// one inner StocksClient rather than a list.
+func NewClientFromConfig(cfg Config) (StocksClient, error) {
+	if len(cfg.Providers) == 0 {
+		return nil, fmt.Errorf("at least one provider is required")
+	}
+
+	providers := make([]Provider, 0, len(cfg.Providers))
+	for _, name := range cfg.Providers {
+		provider, err := newProvider(name, cfg)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, provider)
+	}
+
+	if len(providers) == 1 {
+		return providers[0], nil
+	}
+	return NewFallbackClient(providers...), nil
+}
+
+// newProvider builds the single provider adapter named name out of cfg.
+func newProvider(name ProviderName, cfg Config) (Provider, error) {
+	switch name {
+	case ProviderYahoo:
+		upstreamURL := cfg.UpstreamURL
+		if upstreamURL == "" {
+			upstreamURL = yahooFinanceURL
+		}
+		return NewClientWithConfig(upstreamURL, cfg.ChartURL, cfg.Timeout), nil
+	case ProviderAlpaca:
+		if cfg.AlpacaKeyID == "" || cfg.AlpacaSecretKey == "" {
+			return nil, fmt.Errorf("alpaca provider requires AlpacaKeyID and AlpacaSecretKey")
+		}
+		return NewAlpacaProvider(cfg.AlpacaKeyID, cfg.AlpacaSecretKey), nil
+	case ProviderFinnhub:
+		if cfg.FinnhubAPIKey == "" {
+			return nil, fmt.Errorf("finnhub provider requires FinnhubAPIKey")
+		}
+		return NewFinnhubProvider(cfg.FinnhubAPIKey), nil
+	default:
+		return nil, fmt.Errorf("unknown stocks provider %q", name)
+	}
+}
@@ -0,0 +1,410 @@
+package stocks
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ErrUpstreamUnavailable is returned by ResilientClient in place of calling
+// the wrapped StocksClient while the circuit breaker is open.
+var ErrUpstreamUnavailable = errors.New("stocks: upstream unavailable")
+
+const (
+	// defaultBatchChunkSize is Yahoo's practical per-request symbol
+	// ceiling; Service.SetBatchChunkSize overrides it.
+	defaultBatchChunkSize = 50
+	maxConcurrentBatches  = 4
+)
+
+// ResilientClientConfig tunes the rate limiter and circuit breaker that
+// ResilientClient wraps around an upstream StocksClient.
+type ResilientClientConfig struct {
+	// RPS and Burst configure the token-bucket rate limiter.
+	RPS   float64
+	Burst int
+
+	// FailureThreshold is how many failures within RollingWindow trip the
+	// breaker from closed to open.
+	FailureThreshold int
+	RollingWindow    time.Duration
+
+	// Cooldown is how long the breaker stays open before allowing a single
+	// half-open probe request through.
+	Cooldown time.Duration
+}
+
+// DefaultResilientClientConfig returns sensible defaults for calling the
+// Yahoo Finance API.
+func DefaultResilientClientConfig() ResilientClientConfig {
+	return ResilientClientConfig{
+		RPS:              5,
+		Burst:            10,
+		FailureThreshold: 5,
+		RollingWindow:    30 * time.Second,
+		Cooldown:         30 * time.Second,
+	}
+}
+
+// ResilientClient wraps a StocksClient with a token-bucket rate limiter and a
+// circuit breaker, so a misbehaving or rate-limiting upstream fails fast
+// instead of piling up slow requests.
+type ResilientClient struct {
+	inner   StocksClient
+	limiter *tokenBucket
+	breaker *circuitBreaker
+}
+
+// NewResilientClient wraps inner with the rate limiter and circuit breaker
+// described by cfg.
+func NewResilientClient(inner StocksClient, cfg ResilientClientConfig) *ResilientClient {
+	return &ResilientClient{
+		inner:   inner,
+		limiter: newTokenBucket(cfg.RPS, cfg.Burst),
+		breaker: newCircuitBreaker(cfg.FailureThreshold, cfg.RollingWindow, cfg.Cooldown),
+	}
+}
+
+// GetQuote implements StocksClient.
+func (c *ResilientClient) GetQuote(ctx context.Context, ticker string) (*YahooQuote, error) {
+	if !c.breaker.allow() {
+		return nil, ErrUpstreamUnavailable
+	}
+
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	quote, err := c.inner.GetQuote(ctx, ticker)
+	if err != nil {
+		c.breaker.recordFailure()
+		return nil, err
+	}
+
+	c.breaker.recordSuccess()
+	return quote, nil
+}
+
+// GetQuotes implements StocksClient, fanning out across a bounded worker
+// pool when tickers is large enough to span multiple chunks.
+func (c *ResilientClient) GetQuotes(ctx context.Context, tickers []string) (map[string]*YahooQuote, error) {
+	if !c.breaker.allow() {
+		return nil, ErrUpstreamUnavailable
+	}
+
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	quotes, err := c.inner.GetQuotes(ctx, tickers)
+	if err != nil {
+		c.breaker.recordFailure()
+		return nil, err
+	}
+
+	c.breaker.recordSuccess()
+	return quotes, nil
+}
+
+// GetBars implements StocksClient.
+func (c *ResilientClient) GetBars(ctx context.Context, ticker string, opts BarsRequest) ([]Bar, error) {
+	if !c.breaker.allow() {
+		return nil, ErrUpstreamUnavailable
+	}
+
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	bars, err := c.inner.GetBars(ctx, ticker, opts)
+	if err != nil {
+		c.breaker.recordFailure()
+		return nil, err
+	}
+
+	c.breaker.recordSuccess()
+	return bars, nil
+}
+
+// UpstreamAvailable reports whether the circuit breaker currently allows
+// requests through, without consuming its single half-open probe the way a
+// real GetQuote/GetQuotes/GetBars call would - so a health checker can ask
+// "is this worth probing right now?" without itself risking the probe.
+func (c *ResilientClient) UpstreamAvailable() bool {
+	return c.breaker.allowed()
+}
+
+// fetchQuotesInBatches splits tickers into chunks of at most chunkSize
+// elements (see Service.SetBatchChunkSize) and fetches each chunk
+// concurrently (up to maxConcurrentBatches at a time), so a large tickers
+// list cannot flood the upstream with one oversized request. A chunk that
+// fails (including ctx's deadline expiring mid-fetch) does not abort the
+// others: its tickers come back in the returned errors map instead of
+// failing the whole batch.
+func fetchQuotesInBatches(ctx context.Context, client StocksClient, tickers []string, chunkSize int) (map[string]*YahooQuote, map[string]error) {
+	if chunkSize <= 0 {
+		chunkSize = defaultBatchChunkSize
+	}
+	chunks := chunkTickers(tickers, chunkSize)
+	quoteResults := make([]map[string]*YahooQuote, len(chunks))
+	errResults := make([]error, len(chunks))
+
+	g := new(errgroup.Group)
+	g.SetLimit(maxConcurrentBatches)
+
+	for i, chunk := range chunks {
+		i, chunk := i, chunk
+		g.Go(func() error {
+			quotes, err := client.GetQuotes(ctx, chunk)
+			quoteResults[i] = quotes
+			errResults[i] = err
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	merged := make(map[string]*YahooQuote)
+	errs := make(map[string]error)
+	for i, chunk := range chunks {
+		if errResults[i] != nil {
+			for _, ticker := range chunk {
+				errs[ticker] = errResults[i]
+			}
+			continue
+		}
+		for ticker, quote := range quoteResults[i] {
+			merged[ticker] = quote
+		}
+	}
+
+	return merged, errs
+}
+
+// fetchBarsInBatches fetches bars for each of tickers concurrently (up to
+// maxConcurrentBatches at a time). Unlike the quote endpoint, Yahoo's chart
+// endpoint only accepts one ticker per request, so this fans out one
+// GetBars call per ticker rather than chunking several tickers into one
+// request. A ticker whose fetch fails (including ctx's deadline expiring
+// mid-fetch) does not abort the others: it comes back in the returned
+// errors map instead of failing the whole batch.
+func fetchBarsInBatches(ctx context.Context, client StocksClient, tickers []string, opts BarsRequest) (map[string][]Bar, map[string]error) {
+	type result struct {
+		bars []Bar
+		err  error
+	}
+	results := make([]result, len(tickers))
+
+	g := new(errgroup.Group)
+	g.SetLimit(maxConcurrentBatches)
+
+	for i, ticker := range tickers {
+		i, ticker := i, ticker
+		g.Go(func() error {
+			bars, err := client.GetBars(ctx, ticker, opts)
+			results[i] = result{bars: bars, err: err}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	merged := make(map[string][]Bar)
+	errs := make(map[string]error)
+	for i, ticker := range tickers {
+		if results[i].err != nil {
+			errs[ticker] = results[i].err
+			continue
+		}
+		merged[ticker] = results[i].bars
+	}
+
+	return merged, errs
+}
+
+// chunkTickers splits tickers into slices of at most size elements.
+func chunkTickers(tickers []string, size int) [][]string {
+	chunks := make([][]string, 0, (len(tickers)+size-1)/size)
+	for len(tickers) > size {
+		chunks = append(chunks, tickers[:size:size])
+		tickers = tickers[size:]
+	}
+	return append(chunks, tickers)
+}
+
+// tokenBucket is a simple token-bucket rate limiter: tokens refill
+// continuously at rate per second, up to burst, and each request consumes
+// one token.
+type tokenBucket struct {
+	mu         sync.Mutex
+	rate       float64
+	burst      float64
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newTokenBucket(rps float64, burst int) *tokenBucket {
+	if rps <= 0 {
+		rps = 1
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:       rps,
+		burst:      float64(burst),
+		tokens:     float64(burst),
+		lastRefill: time.Now(),
+	}
+}
+
+// allow reports whether a token is available and, if so, consumes it.
+func (b *tokenBucket) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	b.lastRefill = now
+	b.tokens = math.Min(b.burst, b.tokens+elapsed*b.rate)
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Wait blocks until a token becomes available or ctx is done.
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	for {
+		if b.allow() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// breakerState is one of the three circuit breaker states.
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// circuitBreaker trips from closed to open once FailureThreshold failures
+// land within RollingWindow, fails fast while open, and after Cooldown lets
+// a single half-open probe request through to decide whether to close again.
+type circuitBreaker struct {
+	mu               sync.Mutex
+	state            breakerState
+	failureThreshold int
+	rollingWindow    time.Duration
+	cooldown         time.Duration
+	failures         []time.Time
+	openedAt         time.Time
+}
+
+func newCircuitBreaker(failureThreshold int, rollingWindow, cooldown time.Duration) *circuitBreaker {
+	if failureThreshold <= 0 {
+		failureThreshold = 1
+	}
+	return &circuitBreaker{
+		state:            breakerClosed,
+		failureThreshold: failureThreshold,
+		rollingWindow:    rollingWindow,
+		cooldown:         cooldown,
+	}
+}
+
+// allow reports whether a request may proceed, transitioning an open breaker
+// to half-open once the cooldown has elapsed.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		// Only the caller that just flipped the breaker above gets to
+		// probe; every other concurrent caller waits for that probe to
+		// resolve via recordSuccess (closes) or recordFailure (reopens)
+		// instead of also reaching the upstream.
+		return false
+	default:
+		return true
+	}
+}
+
+// allowed reports whether the breaker is currently letting requests through,
+// without allow's side effect of advancing an open breaker to half-open -
+// for callers (health checks) that want to observe the breaker's state
+// without consuming its single half-open probe.
+func (b *circuitBreaker) allowed() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerOpen {
+		return time.Since(b.openedAt) >= b.cooldown
+	}
+	return true
+}
+
+// recordSuccess closes the breaker and clears its failure history.
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = nil
+	b.state = breakerClosed
+}
+
+// recordFailure tracks a failure and trips the breaker open if the
+// half-open probe failed or the rolling failure count reached the
+// threshold.
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+
+	if b.state == breakerHalfOpen {
+		b.trip(now)
+		return
+	}
+
+	cutoff := now.Add(-b.rollingWindow)
+	kept := b.failures[:0]
+	for _, t := range b.failures {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	b.failures = append(kept, now)
+
+	if len(b.failures) >= b.failureThreshold {
+		b.trip(now)
+	}
+}
+
+// trip opens the breaker and resets its failure history. Callers must hold
+// b.mu.
+func (b *circuitBreaker) trip(at time.Time) {
+	b.state = breakerOpen
+	b.openedAt = at
+	b.failures = nil
+}
@@ -0,0 +1,241 @@
+package stocks_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/stocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQuoteClient is a test double for stocks.StocksClient that returns
+// queued errors (or a quote) on demand, so tests can drive circuit breaker
+// state transitions deterministically.
+type fakeQuoteClient struct {
+	mu     sync.Mutex
+	errors []error
+	calls  int
+}
+
+func (f *fakeQuoteClient) GetQuote(ctx context.Context, ticker string) (*stocks.YahooQuote, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+	if len(f.errors) > 0 {
+		err := f.errors[0]
+		f.errors = f.errors[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &stocks.YahooQuote{Symbol: ticker}, nil
+}
+
+func (f *fakeQuoteClient) GetBars(ctx context.Context, ticker string, opts stocks.BarsRequest) ([]stocks.Bar, error) {
+	if _, err := f.GetQuote(ctx, ticker); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (f *fakeQuoteClient) GetQuotes(ctx context.Context, tickers []string) (map[string]*stocks.YahooQuote, error) {
+	quotes := make(map[string]*stocks.YahooQuote, len(tickers))
+	for _, ticker := range tickers {
+		quote, err := f.GetQuote(ctx, ticker)
+		if err != nil {
+			return nil, err
+		}
+		quotes[ticker] = quote
+	}
+	return quotes, nil
+}
+
+// slowFailingClient is a test double whose GetQuote blocks on a gate until
+// release is called, then fails - used to hold a half-open probe in flight
+// long enough for concurrent callers to pile up behind it.
+type slowFailingClient struct {
+	mu    sync.Mutex
+	calls int
+	block chan struct{}
+}
+
+func newSlowFailingClient() *slowFailingClient {
+	c := &slowFailingClient{block: make(chan struct{})}
+	close(c.block)
+	return c
+}
+
+// armBlock replaces the gate with a fresh, closed one so the next calls to
+// GetQuote block until release is called.
+func (f *slowFailingClient) armBlock() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.block = make(chan struct{})
+	f.calls = 0
+}
+
+func (f *slowFailingClient) release() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	select {
+	case <-f.block:
+	default:
+		close(f.block)
+	}
+}
+
+func (f *slowFailingClient) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func (f *slowFailingClient) GetQuote(ctx context.Context, ticker string) (*stocks.YahooQuote, error) {
+	f.mu.Lock()
+	f.calls++
+	block := f.block
+	f.mu.Unlock()
+
+	<-block
+	return nil, errors.New("boom")
+}
+
+func (f *slowFailingClient) GetBars(ctx context.Context, ticker string, opts stocks.BarsRequest) ([]stocks.Bar, error) {
+	_, err := f.GetQuote(ctx, ticker)
+	return nil, err
+}
+
+func (f *slowFailingClient) GetQuotes(ctx context.Context, tickers []string) (map[string]*stocks.YahooQuote, error) {
+	_, err := f.GetQuote(ctx, tickers[0])
+	return nil, err
+}
+
+func newTestConfig() stocks.ResilientClientConfig {
+	return stocks.ResilientClientConfig{
+		RPS:              1000,
+		Burst:            1000,
+		FailureThreshold: 3,
+		RollingWindow:    time.Minute,
+		Cooldown:         20 * time.Millisecond,
+	}
+}
+
+func TestResilientClient_TripsOpenAfterFailureThreshold(t *testing.T) {
+	fake := &fakeQuoteClient{errors: []error{
+		errors.New("boom"), errors.New("boom"), errors.New("boom"),
+	}}
+	client := stocks.NewResilientClient(fake, newTestConfig())
+
+	for i := 0; i < 3; i++ {
+		_, err := client.GetQuote(context.Background(), "AAPL")
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, stocks.ErrUpstreamUnavailable)
+	}
+
+	// Breaker is now open: requests fail fast without reaching the client.
+	_, err := client.GetQuote(context.Background(), "AAPL")
+	require.ErrorIs(t, err, stocks.ErrUpstreamUnavailable)
+	assert.Equal(t, 3, fake.calls)
+}
+
+func TestResilientClient_HalfOpenProbeRecoversOnSuccess(t *testing.T) {
+	fake := &fakeQuoteClient{errors: []error{
+		errors.New("boom"), errors.New("boom"), errors.New("boom"),
+	}}
+	cfg := newTestConfig()
+	client := stocks.NewResilientClient(fake, cfg)
+
+	for i := 0; i < 3; i++ {
+		_, _ = client.GetQuote(context.Background(), "AAPL")
+	}
+
+	_, err := client.GetQuote(context.Background(), "AAPL")
+	require.ErrorIs(t, err, stocks.ErrUpstreamUnavailable)
+
+	time.Sleep(cfg.Cooldown * 2)
+
+	quote, err := client.GetQuote(context.Background(), "AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, "AAPL", quote.Symbol)
+
+	// Breaker closed again: a subsequent request reaches the client.
+	_, err = client.GetQuote(context.Background(), "AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, 5, fake.calls)
+}
+
+func TestResilientClient_HalfOpenProbeReopensOnFailure(t *testing.T) {
+	fake := &fakeQuoteClient{errors: []error{
+		errors.New("boom"), errors.New("boom"), errors.New("boom"), errors.New("boom"),
+	}}
+	cfg := newTestConfig()
+	client := stocks.NewResilientClient(fake, cfg)
+
+	for i := 0; i < 3; i++ {
+		_, _ = client.GetQuote(context.Background(), "AAPL")
+	}
+
+	_, err := client.GetQuote(context.Background(), "AAPL")
+	require.ErrorIs(t, err, stocks.ErrUpstreamUnavailable)
+
+	time.Sleep(cfg.Cooldown * 2)
+
+	_, err = client.GetQuote(context.Background(), "AAPL")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, stocks.ErrUpstreamUnavailable)
+
+	// Failed probe reopens the breaker immediately.
+	_, err = client.GetQuote(context.Background(), "AAPL")
+	require.ErrorIs(t, err, stocks.ErrUpstreamUnavailable)
+}
+
+func TestResilientClient_HalfOpenAllowsOnlyOneConcurrentProbe(t *testing.T) {
+	fake := newSlowFailingClient()
+	cfg := newTestConfig()
+	client := stocks.NewResilientClient(fake, cfg)
+
+	for i := 0; i < 3; i++ {
+		_, _ = client.GetQuote(context.Background(), "AAPL")
+	}
+	_, err := client.GetQuote(context.Background(), "AAPL")
+	require.ErrorIs(t, err, stocks.ErrUpstreamUnavailable)
+
+	time.Sleep(cfg.Cooldown * 2)
+	fake.armBlock()
+
+	const concurrent = 20
+	results := make(chan error, concurrent)
+	for i := 0; i < concurrent; i++ {
+		go func() {
+			_, err := client.GetQuote(context.Background(), "AAPL")
+			results <- err
+		}()
+	}
+
+	// 19 of the 20 concurrent callers must be turned away immediately by
+	// the still-half-open breaker, without ever reaching fake.
+	for i := 0; i < concurrent-1; i++ {
+		require.ErrorIs(t, <-results, stocks.ErrUpstreamUnavailable)
+	}
+	assert.Equal(t, 1, fake.callCount(), "only the single probe should have reached the upstream client")
+
+	// Releasing the probe resolves the last goroutine, reopening the breaker.
+	fake.release()
+	err = <-results
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, stocks.ErrUpstreamUnavailable)
+}
+
+func TestResilientClient_GetQuotesPassesThroughOnSuccess(t *testing.T) {
+	fake := &fakeQuoteClient{}
+	client := stocks.NewResilientClient(fake, newTestConfig())
+
+	quotes, err := client.GetQuotes(context.Background(), []string{"AAPL", "GOOGL"})
+	require.NoError(t, err)
+	assert.Len(t, quotes, 2)
+}
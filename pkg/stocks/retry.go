@@ -0,0 +1,27 @@
+package stocks
+
+import (
+	"math/rand"
+	"time"
+)
+
+const (
+	// maxUpstreamRetries is how many total attempts doUpstreamRequest
+	// makes (the initial attempt plus retries) before giving up on a
+	// persistent 429/503.
+	maxUpstreamRetries = 3
+
+	retryBaseDelay = 100 * time.Millisecond
+	retryMaxDelay  = 5 * time.Second
+)
+
+// backoffDelay returns a randomized (full-jitter) delay for the given retry
+// attempt, doubling the base delay each attempt up to retryMaxDelay - the
+// same scheme as pkg/weather's backoffDelay.
+func backoffDelay(attempt int) time.Duration {
+	backoff := retryBaseDelay * time.Duration(uint(1)<<uint(attempt-1))
+	if backoff > retryMaxDelay {
+		backoff = retryMaxDelay
+	}
+	return time.Duration(rand.Int63n(int64(backoff)))
+}
@@ -1,107 +1,274 @@
 package stocks
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/f00b455/blank-go/pkg/clock"
+	"github.com/f00b455/blank-go/pkg/stocks/cache"
+	"github.com/f00b455/blank-go/pkg/stocks/stream"
 )
 
 const (
-	defaultCacheTTL = 5 * time.Minute
+	// regularSessionTTL is how long a regular-hours quote is cached: prices
+	// move quickly while the market is open, so the window is short.
+	regularSessionTTL = 15 * time.Second
+	// offHoursTTL is how long a quote is cached outside regular trading
+	// hours (pre-market, post-market, closed), where prices change slowly.
+	offHoursTTL = 5 * time.Minute
+	// defaultFetchTimeout bounds how long a single upstream quote fetch may
+	// run, independent of any deadline the caller's own ctx carries. It can
+	// be overridden with SetTimeout.
+	defaultFetchTimeout = 3 * time.Second
 )
 
 // Service provides stock market business logic with caching
 type Service struct {
-	client    StocksClient
-	cache     *stockCache
-	cacheTTL  time.Duration
+	client         StocksClient
+	cache          *stockCache
+	watchHub       *watcherHub
+	timeout        time.Duration
+	batchChunkSize int
+	clock          clock.Clock
+
+	mu       sync.Mutex
+	inflight map[string]*inflightFetch
 }
 
-// stockCache implements a simple in-memory cache with TTL
-type stockCache struct {
-	mu    sync.RWMutex
-	items map[string]*cacheItem
+// inflightFetch is a single in-flight upstream GetQuote call shared by every
+// concurrent caller requesting the same ticker, modeled on the
+// deadline-timer pattern of sharing one cancelable wait across several
+// waiters: fetchCtx bounds the call to the service's timeout, cancel lets
+// the call be torn down early once its last waiter gives up, and done is
+// closed once quote/err are populated so every waiter unblocks at once.
+type inflightFetch struct {
+	fetchCtx context.Context
+	cancel   context.CancelFunc
+	done     chan struct{}
+	waiters  int
+	quote    *YahooQuote
+	err      error
 }
 
-type cacheItem struct {
-	summary   *StockSummary
-	expiresAt time.Time
+// NewService creates a new stocks service backed by an in-memory cache.
+func NewService(client StocksClient) *Service {
+	return NewServiceWithCache(client, cache.NewLRUCache(0))
 }
 
-// NewService creates a new stocks service with caching
-func NewService(client StocksClient) *Service {
+// NewServiceWithCache creates a new stocks service backed by the given cache
+// implementation, e.g. a cache.RedisCache for sharing cached quotes across
+// multiple API replicas.
+func NewServiceWithCache(client StocksClient, c cache.Cache) *Service {
+	realClock := clock.RealClock{}
+	stockCache := newStockCache(c, realClock)
+	watchHub := newWatcherHub(client, stockCache, realClock)
+	stockCache.onChange = watchHub.publish
+
 	return &Service{
-		client:   client,
-		cache:    newStockCache(),
-		cacheTTL: defaultCacheTTL,
+		client:         client,
+		cache:          stockCache,
+		watchHub:       watchHub,
+		timeout:        defaultFetchTimeout,
+		batchChunkSize: defaultBatchChunkSize,
+		clock:          realClock,
+		inflight:       make(map[string]*inflightFetch),
 	}
 }
 
-// newStockCache creates a new cache instance
-func newStockCache() *stockCache {
-	return &stockCache{
-		items: make(map[string]*cacheItem),
-	}
+// SetClock overrides the clock used for cache TTL bookkeeping and
+// StockSummary.Date, so a test can advance a clock.FakeClock to assert
+// cache expiry deterministically instead of with time.Sleep.
+func (s *Service) SetClock(c clock.Clock) {
+	s.clock = c
+	s.cache.clock = c
+	s.watchHub.clock = c
 }
 
-// get retrieves an item from cache if not expired
-func (c *stockCache) get(key string) (*StockSummary, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+// SetTimeout overrides the default per-call upstream timeout (3s) that
+// bounds each deduplicated GetQuote fetch.
+func (s *Service) SetTimeout(timeout time.Duration) {
+	s.timeout = timeout
+}
 
-	item, exists := c.items[key]
-	if !exists {
-		return nil, false
+// SetBatchChunkSize overrides how many tickers GetBatchSummary sends
+// upstream per request (default 50, Yahoo's practical ceiling). A batch
+// larger than this is split into multiple chunks fetched concurrently; see
+// fetchQuotesInBatches.
+func (s *Service) SetBatchChunkSize(size int) {
+	if size <= 0 {
+		return
 	}
+	s.batchChunkSize = size
+}
 
-	if time.Now().After(item.expiresAt) {
-		return nil, false
-	}
+// SetStreamHub wires hub into the Service's watcherHub, so a ticker that is
+// already being watched (via Service.Watch, or indirectly through the
+// admin/WebSocket surfaces that call it) also has its cache fed from hub's
+// live quote stream, rather than relying solely on the poll interval. Must
+// be called before the first Watch subscription for a given ticker to take
+// effect for that ticker's poller.
+func (s *Service) SetStreamHub(hub *stream.Hub) {
+	s.watchHub.streamHub = hub
+}
 
-	return item.summary, true
+// Start launches the cache's background janitor, which periodically sweeps
+// expired entries so the admin-tracked cache state doesn't grow unbounded
+// for tickers queried once and never again. It is a no-op if already
+// started; Stop (or canceling ctx) ends it.
+func (s *Service) Start(ctx context.Context) {
+	s.cache.Start(ctx)
 }
 
-// set stores an item in cache with TTL
-func (c *stockCache) set(key string, summary *StockSummary, ttl time.Duration) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+// Stop ends the background janitor started by Start.
+func (s *Service) Stop() {
+	s.cache.Stop()
+}
 
-	c.items[key] = &cacheItem{
-		summary:   summary,
-		expiresAt: time.Now().Add(ttl),
-	}
+// CacheMetrics returns an aggregate snapshot of this instance's stock cache
+// state, for the detailed health check and general observability.
+func (s *Service) CacheMetrics() CacheMetrics {
+	return s.cache.Metrics()
 }
 
-// GetSummary retrieves stock summary for a single ticker
-func (s *Service) GetSummary(ticker string) (*StockSummary, error) {
+// GetSummary retrieves stock summary for a single ticker using the regular session
+func (s *Service) GetSummary(ctx context.Context, ticker string) (*StockSummary, error) {
+	summary, _, err := s.GetSummaryForSession(ctx, ticker, SessionRegular)
+	return summary, err
+}
+
+// GetSummaryForSession retrieves stock summary for a single ticker, applying
+// pre/post-market pricing for the requested session. The cache key is scoped
+// per-session so that extended-hours requests don't serve regular-session
+// data. The returned bool reports whether the summary was served from cache.
+// The upstream fetch, if needed, is deduplicated across concurrent callers
+// for the same ticker (see fetchQuote) and bounded by the service's timeout
+// regardless of ctx's own deadline; ctx cancellation still returns promptly
+// to this caller even if other callers keep the shared fetch alive.
+func (s *Service) GetSummaryForSession(ctx context.Context, ticker, session string) (*StockSummary, bool, error) {
 	if ticker == "" {
-		return nil, fmt.Errorf("ticker is required")
+		return nil, false, fmt.Errorf("ticker is required")
+	}
+
+	if !isValidSession(session) {
+		return nil, false, fmt.Errorf("invalid session")
 	}
 
 	ticker = strings.ToUpper(ticker)
 
-	// Check cache first
-	if cached, found := s.cache.get(ticker); found {
-		return cached, nil
+	if cached, found := s.cache.get(ctx, ticker, session); found {
+		cacheHitsTotal.Inc()
+		return cached, true, nil
+	}
+	cacheMissesTotal.Inc()
+
+	if negErr, found := s.cache.getNegative(ctx, ticker, session); found {
+		return nil, false, negErr
 	}
 
-	// Fetch from API
-	quote, err := s.client.GetQuote(ticker)
+	quote, err := s.fetchQuote(ctx, ticker)
 	if err != nil {
-		return nil, err
+		if isCacheableNegative(err) {
+			s.cache.setNegative(ctx, ticker, session, err)
+		}
+		return nil, false, err
 	}
 
-	summary := convertQuoteToSummary(quote)
+	summary := convertQuoteToSummary(quote, session, s.clock)
+	s.cache.set(ctx, ticker, session, summary, ttlForMarketState(summary.MarketState), summary.Source)
+
+	return summary, false, nil
+}
 
-	// Store in cache
-	s.cache.set(ticker, summary, s.cacheTTL)
+// isCacheableNegative reports whether err represents a definitive rejection
+// of a ticker (it doesn't exist, or the upstream API rejected the request
+// outright) worth remembering in the negative cache, as opposed to a
+// transient network or server error that's worth retrying on the very next
+// lookup.
+func isCacheableNegative(err error) bool {
+	if err == nil {
+		return false
+	}
+	if err.Error() == "ticker not found" {
+		return true
+	}
 
-	return summary, nil
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= 400 && statusErr.StatusCode < 500
+	}
+	return false
 }
 
-// GetBatchSummary retrieves stock summaries for multiple tickers
-func (s *Service) GetBatchSummary(tickersStr string) (*BatchResponse, error) {
+// fetchQuote fetches ticker's quote, deduplicating concurrent callers onto a
+// single upstream request: the first caller for a ticker starts an
+// inflightFetch bounded by s.timeout, and any caller that arrives while it
+// is still running waits on the same inflightFetch instead of issuing its
+// own. If ctx is done before the shared fetch completes, this caller stops
+// waiting immediately; once the last waiter for a ticker leaves early, the
+// shared fetch's context is canceled too so the underlying HTTP request
+// doesn't keep running for nobody.
+func (s *Service) fetchQuote(ctx context.Context, ticker string) (*YahooQuote, error) {
+	s.mu.Lock()
+	f, exists := s.inflight[ticker]
+	if !exists {
+		fetchCtx, cancel := context.WithTimeout(context.Background(), s.timeout)
+		f = &inflightFetch{fetchCtx: fetchCtx, cancel: cancel, done: make(chan struct{})}
+		s.inflight[ticker] = f
+		go s.runFetch(ticker, f)
+	}
+	f.waiters++
+	s.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.quote, f.err
+	case <-ctx.Done():
+		s.mu.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			f.cancel()
+		}
+		s.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// runFetch performs the shared upstream call for f and wakes every waiter by
+// closing f.done, then removes f from s.inflight so the next request for
+// ticker starts a fresh fetch.
+func (s *Service) runFetch(ticker string, f *inflightFetch) {
+	defer f.cancel()
+
+	f.quote, f.err = s.client.GetQuote(f.fetchCtx, ticker)
+	close(f.done)
+
+	s.mu.Lock()
+	if s.inflight[ticker] == f {
+		delete(s.inflight, ticker)
+	}
+	s.mu.Unlock()
+}
+
+// isValidSession reports whether session is one of the supported values
+func isValidSession(session string) bool {
+	switch session {
+	case "", SessionRegular, SessionPre, SessionPost, SessionExtended:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetBatchSummary retrieves stock summaries for multiple tickers. The
+// upstream fetch for any still-uncached tickers is bounded by the service's
+// timeout (see SetTimeout): a chunk that times out reports
+// context.DeadlineExceeded as a BatchError for its tickers instead of
+// failing tickers whose chunk already succeeded.
+func (s *Service) GetBatchSummary(ctx context.Context, tickersStr string) (*BatchResponse, error) {
 	if tickersStr == "" {
 		return nil, fmt.Errorf("tickers parameter is required")
 	}
@@ -120,42 +287,191 @@ func (s *Service) GetBatchSummary(tickersStr string) (*BatchResponse, error) {
 	// Check cache for each ticker
 	uncachedTickers := make([]string, 0, len(tickers))
 	for _, ticker := range tickers {
-		if cached, found := s.cache.get(ticker); found {
+		if cached, found := s.cache.get(ctx, ticker, SessionRegular); found {
+			cacheHitsTotal.Inc()
 			response.Summaries = append(response.Summaries, *cached)
-		} else {
-			uncachedTickers = append(uncachedTickers, ticker)
+			continue
 		}
+		cacheMissesTotal.Inc()
+
+		if negErr, found := s.cache.getNegative(ctx, ticker, SessionRegular); found {
+			response.Errors = append(response.Errors, BatchError{
+				Ticker:  ticker,
+				Message: negErr.Error(),
+			})
+			continue
+		}
+
+		uncachedTickers = append(uncachedTickers, ticker)
 	}
 
 	// Fetch uncached tickers from API
 	if len(uncachedTickers) > 0 {
-		quotes, err := s.client.GetQuotes(uncachedTickers)
-		if err != nil {
-			return nil, err
-		}
+		fetchCtx, cancel := context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+
+		quotes, errs := fetchQuotesInBatches(fetchCtx, s.client, uncachedTickers, s.batchChunkSize)
 
 		// Process results
 		for _, ticker := range uncachedTickers {
+			if err, failed := errs[ticker]; failed {
+				if isCacheableNegative(err) {
+					s.cache.setNegative(ctx, ticker, SessionRegular, err)
+				}
+				response.Errors = append(response.Errors, BatchError{
+					Ticker:  ticker,
+					Message: err.Error(),
+				})
+				continue
+			}
+
 			quote, found := quotes[ticker]
 			if !found {
+				notFoundErr := fmt.Errorf("ticker not found")
+				s.cache.setNegative(ctx, ticker, SessionRegular, notFoundErr)
 				response.Errors = append(response.Errors, BatchError{
 					Ticker:  ticker,
-					Message: "ticker not found",
+					Message: notFoundErr.Error(),
 				})
 				continue
 			}
 
-			summary := convertQuoteToSummary(quote)
+			summary := convertQuoteToSummary(quote, SessionRegular, s.clock)
 			response.Summaries = append(response.Summaries, *summary)
 
 			// Cache the result
-			s.cache.set(ticker, summary, s.cacheTTL)
+			s.cache.set(ctx, ticker, SessionRegular, summary, ttlForMarketState(summary.MarketState), summary.Source)
+		}
+	}
+
+	return response, nil
+}
+
+// GetBars retrieves a page of historical OHLCV bars for ticker, validating
+// the request and computing the pagination cursor for the next page. Bars
+// are not cached: unlike quotes, a historical window is keyed by
+// start/end/timeframe/page combinations too numerous to usefully cache.
+func (s *Service) GetBars(ctx context.Context, ticker string, req BarsRequest) (*BarsResponse, error) {
+	if ticker == "" {
+		return nil, fmt.Errorf("ticker is required")
+	}
+
+	if !isValidTimeframe(req.Timeframe) {
+		return nil, fmt.Errorf("invalid timeframe")
+	}
+
+	if !isValidAdjustment(req.Adjustment) {
+		return nil, fmt.Errorf("invalid adjustment")
+	}
+
+	if req.Start.IsZero() || req.End.IsZero() {
+		return nil, fmt.Errorf("start and end are required")
+	}
+
+	if !req.Start.Before(req.End) {
+		return nil, fmt.Errorf("start must be before end")
+	}
+
+	offset, err := decodeBarsPageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultBarsLimit
+	}
+
+	bars, err := s.client.GetBars(ctx, strings.ToUpper(ticker), req)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &BarsResponse{Bars: bars}
+	if len(bars) == limit {
+		response.NextPageToken = encodeBarsPageToken(offset + len(bars))
+	}
+
+	return response, nil
+}
+
+// GetMultiBars retrieves historical OHLCV bars for each of tickersStr's
+// tickers in parallel, mirroring GetBatchSummary's partial-failure
+// handling: a ticker Yahoo rejects or that times out comes back as a
+// BatchError rather than failing tickers that succeeded. Unlike GetBars,
+// there is no per-ticker pagination here - a caller after the full history
+// of a single symbol should use GetBars directly.
+func (s *Service) GetMultiBars(ctx context.Context, tickersStr string, req BarsRequest) (*MultiBarsResponse, error) {
+	tickers := parseTickers(tickersStr)
+	if len(tickers) == 0 {
+		return nil, fmt.Errorf("at least one valid ticker is required")
+	}
+
+	if !isValidTimeframe(req.Timeframe) {
+		return nil, fmt.Errorf("invalid timeframe")
+	}
+
+	if !isValidAdjustment(req.Adjustment) {
+		return nil, fmt.Errorf("invalid adjustment")
+	}
+
+	if req.Start.IsZero() || req.End.IsZero() {
+		return nil, fmt.Errorf("start and end are required")
+	}
+
+	if !req.Start.Before(req.End) {
+		return nil, fmt.Errorf("start must be before end")
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	bars, errs := fetchBarsInBatches(fetchCtx, s.client, tickers, req)
+
+	response := &MultiBarsResponse{
+		Bars:   bars,
+		Errors: make([]BatchError, 0, len(errs)),
+	}
+	for _, ticker := range tickers {
+		if err, failed := errs[ticker]; failed {
+			response.Errors = append(response.Errors, BatchError{
+				Ticker:  ticker,
+				Message: err.Error(),
+			})
 		}
 	}
 
 	return response, nil
 }
 
+// Dump returns a snapshot of every live stock cache entry on this instance,
+// for admin introspection (e.g. to debug stale prices without restarting).
+func (s *Service) Dump() []CacheEntry {
+	return s.cache.Dump()
+}
+
+// Invalidate purges every cached session (regular, pre, post, extended) for
+// ticker, forcing the next request for it to refetch from the upstream
+// client.
+func (s *Service) Invalidate(ticker string) {
+	s.cache.Invalidate(context.Background(), strings.ToUpper(ticker))
+}
+
+// InvalidateAll purges every cached entry on this instance.
+func (s *Service) InvalidateAll() {
+	s.cache.InvalidateAll(context.Background())
+}
+
+// ttlForMarketState returns how long a quote should be cached given the
+// market state it was fetched in: short while the regular session is open,
+// longer otherwise since prices move far less outside regular hours.
+func ttlForMarketState(state string) time.Duration {
+	if state == MarketStateRegular {
+		return regularSessionTTL
+	}
+	return offHoursTTL
+}
+
 // parseTickers splits and normalizes ticker string
 func parseTickers(tickersStr string) []string {
 	parts := strings.Split(tickersStr, ",")
@@ -171,21 +487,49 @@ func parseTickers(tickersStr string) []string {
 	return tickers
 }
 
-// convertQuoteToSummary converts Yahoo quote to stock summary
-func convertQuoteToSummary(quote *YahooQuote) *StockSummary {
-	now := time.Now()
+// convertQuoteToSummary converts a Yahoo quote to a stock summary. When session
+// requests extended-hours pricing and the quote's market state matches, the
+// current price/change/change-percent are taken from the pre/post-market
+// fields instead of the regular-session fields, mirroring how Yahoo's own
+// quote UI swaps to after-hours pricing outside regular trading hours.
+func convertQuoteToSummary(quote *YahooQuote, session string, clk clock.Clock) *StockSummary {
+	now := clk.Now()
+
+	price, change, changePercent := quote.RegularMarketPrice, quote.RegularMarketChange, quote.RegularMarketChangePercent
+
+	switch session {
+	case SessionPre:
+		price, change, changePercent = quote.PreMarketPrice, quote.PreMarketChange, quote.PreMarketChangePercent
+	case SessionPost:
+		price, change, changePercent = quote.PostMarketPrice, quote.PostMarketChange, quote.PostMarketChangePercent
+	case SessionExtended:
+		switch quote.MarketState {
+		case MarketStatePre:
+			price, change, changePercent = quote.PreMarketPrice, quote.PreMarketChange, quote.PreMarketChangePercent
+		case MarketStatePost:
+			price, change, changePercent = quote.PostMarketPrice, quote.PostMarketChange, quote.PostMarketChangePercent
+		}
+	}
+
+	source := string(quote.Source)
+	if source == "" {
+		source = string(ProviderYahoo)
+	}
+
 	return &StockSummary{
 		Ticker:        quote.Symbol,
 		Name:          quote.ShortName,
 		Date:          now.Format("2006-01-02"),
-		CurrentPrice:  quote.RegularMarketPrice,
+		CurrentPrice:  price,
 		Open:          quote.RegularMarketOpen,
 		High:          quote.RegularMarketHigh,
 		Low:           quote.RegularMarketLow,
-		Change:        quote.RegularMarketChange,
-		ChangePercent: quote.RegularMarketChangePercent,
+		Change:        change,
+		ChangePercent: changePercent,
 		Volume:        quote.RegularMarketVolume,
 		Currency:      quote.Currency,
+		MarketState:   quote.MarketState,
 		UpdatedAt:     now,
+		Source:        source,
 	}
 }
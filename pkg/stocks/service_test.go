@@ -1,14 +1,20 @@
 package stocks_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/f00b455/blank-go/pkg/clock"
 	"github.com/f00b455/blank-go/pkg/stocks"
 	"github.com/f00b455/blank-go/pkg/stocks/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestService_GetSummary(t *testing.T) {
@@ -58,12 +64,12 @@ func TestService_GetSummary(t *testing.T) {
 			service := stocks.NewService(mockClient)
 
 			if tt.ticker != "" && tt.mockQuote != nil {
-				mockClient.On("GetQuote", mock.Anything).Return(tt.mockQuote, tt.mockError)
+				mockClient.On("GetQuote", mock.Anything, mock.Anything).Return(tt.mockQuote, tt.mockError)
 			} else if tt.ticker != "" && tt.mockError != nil {
-				mockClient.On("GetQuote", mock.Anything).Return(nil, tt.mockError)
+				mockClient.On("GetQuote", mock.Anything, mock.Anything).Return(nil, tt.mockError)
 			}
 
-			summary, err := service.GetSummary(tt.ticker)
+			summary, err := service.GetSummary(context.Background(), tt.ticker)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -93,13 +99,13 @@ func TestService_GetSummary_Caching(t *testing.T) {
 		Currency:           "USD",
 	}
 
-	mockClient.On("GetQuote", "AAPL").Return(quote, nil).Once()
+	mockClient.On("GetQuote", mock.Anything, "AAPL").Return(quote, nil).Once()
 
-	summary1, err := service.GetSummary("AAPL")
+	summary1, err := service.GetSummary(context.Background(), "AAPL")
 	assert.NoError(t, err)
 	assert.NotNil(t, summary1)
 
-	summary2, err := service.GetSummary("AAPL")
+	summary2, err := service.GetSummary(context.Background(), "AAPL")
 	assert.NoError(t, err)
 	assert.NotNil(t, summary2)
 	assert.Equal(t, summary1.Ticker, summary2.Ticker)
@@ -174,10 +180,10 @@ func TestService_GetBatchSummary(t *testing.T) {
 			service := stocks.NewService(mockClient)
 
 			if tt.tickersStr != "" && !tt.expectError {
-				mockClient.On("GetQuotes", mock.Anything).Return(tt.mockQuotes, tt.mockError)
+				mockClient.On("GetQuotes", mock.Anything, mock.Anything).Return(tt.mockQuotes, tt.mockError)
 			}
 
-			response, err := service.GetBatchSummary(tt.tickersStr)
+			response, err := service.GetBatchSummary(context.Background(), tt.tickersStr)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -195,6 +201,149 @@ func TestService_GetBatchSummary(t *testing.T) {
 	}
 }
 
+func TestService_GetBatchSummary_LargeTickerListFansOutInChunks(t *testing.T) {
+	tickers := make([]string, 45)
+	for i := range tickers {
+		tickers[i] = fmt.Sprintf("T%02d", i)
+	}
+
+	fake := &fakeQuoteClient{}
+	service := stocks.NewService(fake)
+
+	response, err := service.GetBatchSummary(context.Background(), strings.Join(tickers, ","))
+	require.NoError(t, err)
+	assert.Equal(t, len(tickers), len(response.Summaries))
+	assert.Empty(t, response.Errors)
+}
+
+// slowQuoteClient is a test double for stocks.StocksClient whose GetQuote
+// blocks until release is closed or ctx is done, so tests can drive
+// concurrent-fetch deduplication and timeout behavior deterministically.
+type slowQuoteClient struct {
+	mu      sync.Mutex
+	calls   int
+	release chan struct{}
+	quote   *stocks.YahooQuote
+}
+
+func (f *slowQuoteClient) GetQuote(ctx context.Context, ticker string) (*stocks.YahooQuote, error) {
+	f.mu.Lock()
+	f.calls++
+	f.mu.Unlock()
+
+	select {
+	case <-f.release:
+		return f.quote, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (f *slowQuoteClient) GetQuotes(ctx context.Context, tickers []string) (map[string]*stocks.YahooQuote, error) {
+	return nil, nil
+}
+
+func (f *slowQuoteClient) GetBars(ctx context.Context, ticker string, opts stocks.BarsRequest) ([]stocks.Bar, error) {
+	return nil, nil
+}
+
+func TestService_GetSummaryForSession_DedupesConcurrentFetches(t *testing.T) {
+	fake := &slowQuoteClient{
+		release: make(chan struct{}),
+		quote:   &stocks.YahooQuote{Symbol: "AAPL", RegularMarketPrice: 185.50},
+	}
+	service := stocks.NewService(fake)
+
+	var wg sync.WaitGroup
+	results := make([]*stocks.StockSummary, 5)
+	for i := range results {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			summary, _, err := service.GetSummaryForSession(context.Background(), "AAPL", stocks.SessionRegular)
+			require.NoError(t, err)
+			results[i] = summary
+		}()
+	}
+
+	// Give every goroutine a chance to register as a waiter before the
+	// single shared upstream fetch is allowed to complete.
+	time.Sleep(20 * time.Millisecond)
+	close(fake.release)
+	wg.Wait()
+
+	for _, summary := range results {
+		require.NotNil(t, summary)
+		assert.Equal(t, "AAPL", summary.Ticker)
+	}
+	assert.Equal(t, 1, fake.calls, "concurrent requests for the same ticker should share one upstream fetch")
+}
+
+func TestService_GetSummaryForSession_TimesOutPerCall(t *testing.T) {
+	fake := &slowQuoteClient{release: make(chan struct{})}
+	defer close(fake.release)
+
+	service := stocks.NewService(fake)
+	service.SetTimeout(10 * time.Millisecond)
+
+	_, _, err := service.GetSummaryForSession(context.Background(), "AAPL", stocks.SessionRegular)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// chunkAwareClient is a test double whose GetQuotes blocks until ctx is done
+// for any chunk containing one of slowTickers, succeeding immediately for
+// every other chunk.
+type chunkAwareClient struct {
+	slowTickers map[string]bool
+}
+
+func (f *chunkAwareClient) GetQuote(ctx context.Context, ticker string) (*stocks.YahooQuote, error) {
+	return &stocks.YahooQuote{Symbol: ticker}, nil
+}
+
+func (f *chunkAwareClient) GetQuotes(ctx context.Context, tickers []string) (map[string]*stocks.YahooQuote, error) {
+	for _, ticker := range tickers {
+		if f.slowTickers[ticker] {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+	}
+
+	quotes := make(map[string]*stocks.YahooQuote, len(tickers))
+	for _, ticker := range tickers {
+		quotes[ticker] = &stocks.YahooQuote{Symbol: ticker}
+	}
+	return quotes, nil
+}
+
+func (f *chunkAwareClient) GetBars(ctx context.Context, ticker string, opts stocks.BarsRequest) ([]stocks.Bar, error) {
+	return nil, nil
+}
+
+func TestService_GetBatchSummary_TimedOutChunkReportsPerTickerErrorsWithoutFailingOthers(t *testing.T) {
+	tickers := make([]string, 25)
+	for i := range tickers {
+		tickers[i] = fmt.Sprintf("T%02d", i)
+	}
+
+	// T00 falls in the first 20-ticker chunk, so that whole chunk blocks
+	// until the service's timeout fires; the second, 5-ticker chunk is
+	// unaffected and should still succeed.
+	fake := &chunkAwareClient{slowTickers: map[string]bool{"T00": true}}
+	service := stocks.NewService(fake)
+	service.SetTimeout(20 * time.Millisecond)
+
+	response, err := service.GetBatchSummary(context.Background(), strings.Join(tickers, ","))
+	require.NoError(t, err)
+	assert.Len(t, response.Errors, 20, "every ticker in the timed-out chunk should get its own error")
+	assert.Len(t, response.Summaries, 5, "the unaffected chunk should still succeed")
+	for _, batchErr := range response.Errors {
+		assert.Contains(t, batchErr.Message, "context deadline exceeded")
+	}
+}
+
 func TestParseTickers(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -246,6 +395,8 @@ func TestParseTickers(t *testing.T) {
 func TestConvertQuoteToSummary(t *testing.T) {
 	mockClient := new(mocks.MockStocksClient)
 	service := stocks.NewService(mockClient)
+	fakeClock := clock.NewFakeClock(time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	service.SetClock(fakeClock)
 
 	quote := &stocks.YahooQuote{
 		Symbol:                     "AAPL",
@@ -260,9 +411,9 @@ func TestConvertQuoteToSummary(t *testing.T) {
 		Currency:                   "USD",
 	}
 
-	mockClient.On("GetQuote", "AAPL").Return(quote, nil)
+	mockClient.On("GetQuote", mock.Anything, "AAPL").Return(quote, nil)
 
-	summary, err := service.GetSummary("AAPL")
+	summary, err := service.GetSummary(context.Background(), "AAPL")
 	assert.NoError(t, err)
 
 	assert.Equal(t, quote.Symbol, summary.Ticker)
@@ -275,7 +426,275 @@ func TestConvertQuoteToSummary(t *testing.T) {
 	assert.Equal(t, quote.RegularMarketChangePercent, summary.ChangePercent)
 	assert.Equal(t, quote.RegularMarketVolume, summary.Volume)
 	assert.Equal(t, quote.Currency, summary.Currency)
-	assert.NotEmpty(t, summary.Date)
+	assert.Equal(t, "2024-03-15", summary.Date)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestService_GetSummaryForSession(t *testing.T) {
+	quote := &stocks.YahooQuote{
+		Symbol:                     "AAPL",
+		RegularMarketPrice:         185.50,
+		RegularMarketChange:        1.50,
+		RegularMarketChangePercent: 0.81,
+		MarketState:                "POST",
+		PreMarketPrice:             184.00,
+		PreMarketChange:            -1.50,
+		PreMarketChangePercent:     -0.81,
+		PostMarketPrice:            187.00,
+		PostMarketChange:           1.50,
+		PostMarketChangePercent:    0.81,
+	}
+
+	tests := []struct {
+		name          string
+		session       string
+		expectedPrice float64
+	}{
+		{name: "regular session uses regular price", session: "regular", expectedPrice: 185.50},
+		{name: "pre session uses pre-market price", session: "pre", expectedPrice: 184.00},
+		{name: "post session uses post-market price", session: "post", expectedPrice: 187.00},
+		{name: "extended session follows market state", session: "extended", expectedPrice: 187.00},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(mocks.MockStocksClient)
+			service := stocks.NewService(mockClient)
+			mockClient.On("GetQuote", mock.Anything, "AAPL").Return(quote, nil)
+
+			summary, cacheHit, err := service.GetSummaryForSession(context.Background(), "AAPL", tt.session)
+			assert.NoError(t, err)
+			assert.False(t, cacheHit)
+			assert.Equal(t, tt.expectedPrice, summary.CurrentPrice)
+			assert.Equal(t, "POST", summary.MarketState)
+		})
+	}
+}
+
+func TestService_GetSummaryForSession_InvalidSession(t *testing.T) {
+	mockClient := new(mocks.MockStocksClient)
+	service := stocks.NewService(mockClient)
+
+	summary, cacheHit, err := service.GetSummaryForSession(context.Background(), "AAPL", "bogus")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid session")
+	assert.Nil(t, summary)
+	assert.False(t, cacheHit)
+}
+
+func TestService_GetSummaryForSession_CacheHit(t *testing.T) {
+	mockClient := new(mocks.MockStocksClient)
+	service := stocks.NewService(mockClient)
+
+	quote := &stocks.YahooQuote{
+		Symbol:             "AAPL",
+		RegularMarketPrice: 185.50,
+		MarketState:        stocks.MarketStateRegular,
+	}
+	mockClient.On("GetQuote", mock.Anything, "AAPL").Return(quote, nil).Once()
+
+	summary1, cacheHit1, err := service.GetSummaryForSession(context.Background(), "AAPL", "regular")
+	assert.NoError(t, err)
+	assert.False(t, cacheHit1)
+
+	summary2, cacheHit2, err := service.GetSummaryForSession(context.Background(), "AAPL", "regular")
+	assert.NoError(t, err)
+	assert.True(t, cacheHit2)
+	assert.Equal(t, summary1.Ticker, summary2.Ticker)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestService_GetSummaryForSession_NegativeCachesTickerNotFound(t *testing.T) {
+	mockClient := new(mocks.MockStocksClient)
+	service := stocks.NewService(mockClient)
+
+	mockClient.On("GetQuote", mock.Anything, "BOGUS").Return(nil, errors.New("ticker not found")).Once()
+
+	_, _, err := service.GetSummaryForSession(context.Background(), "BOGUS", "regular")
+	assert.EqualError(t, err, "ticker not found")
+
+	_, _, err = service.GetSummaryForSession(context.Background(), "BOGUS", "regular")
+	assert.EqualError(t, err, "ticker not found")
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestService_GetBars_Validation(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		ticker        string
+		req           stocks.BarsRequest
+		errorContains string
+	}{
+		{
+			name:          "empty ticker",
+			ticker:        "",
+			req:           stocks.BarsRequest{Start: start, End: end, Timeframe: stocks.Timeframe1Day},
+			errorContains: "ticker is required",
+		},
+		{
+			name:          "invalid timeframe",
+			ticker:        "AAPL",
+			req:           stocks.BarsRequest{Start: start, End: end, Timeframe: "3Min"},
+			errorContains: "invalid timeframe",
+		},
+		{
+			name:          "invalid adjustment",
+			ticker:        "AAPL",
+			req:           stocks.BarsRequest{Start: start, End: end, Timeframe: stocks.Timeframe1Day, Adjustment: "bogus"},
+			errorContains: "invalid adjustment",
+		},
+		{
+			name:          "missing start/end",
+			ticker:        "AAPL",
+			req:           stocks.BarsRequest{Timeframe: stocks.Timeframe1Day},
+			errorContains: "start and end are required",
+		},
+		{
+			name:          "start after end",
+			ticker:        "AAPL",
+			req:           stocks.BarsRequest{Start: end, End: start, Timeframe: stocks.Timeframe1Day},
+			errorContains: "start must be before end",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(mocks.MockStocksClient)
+			service := stocks.NewService(mockClient)
+
+			response, err := service.GetBars(context.Background(), tt.ticker, tt.req)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+			assert.Nil(t, response)
+		})
+	}
+}
+
+func TestService_GetBars_NextPageToken(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	bars := []stocks.Bar{
+		{T: start, O: 180, H: 182, L: 179, C: 181, V: 1000000},
+		{T: start.AddDate(0, 0, 1), O: 181, H: 183, L: 180, C: 182, V: 1100000},
+	}
+
+	mockClient := new(mocks.MockStocksClient)
+	service := stocks.NewService(mockClient)
+	mockClient.On("GetBars", mock.Anything, "AAPL", mock.Anything).Return(bars, nil)
+
+	req := stocks.BarsRequest{Start: start, End: end, Timeframe: stocks.Timeframe1Day, Limit: len(bars)}
+	response, err := service.GetBars(context.Background(), "AAPL", req)
+	require.NoError(t, err)
+	assert.Len(t, response.Bars, len(bars))
+	assert.NotEmpty(t, response.NextPageToken, "a full page should carry a next_page_token")
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestService_GetBars_LastPageHasNoToken(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	bars := []stocks.Bar{
+		{T: start, O: 180, H: 182, L: 179, C: 181, V: 1000000},
+	}
+
+	mockClient := new(mocks.MockStocksClient)
+	service := stocks.NewService(mockClient)
+	mockClient.On("GetBars", mock.Anything, "AAPL", mock.Anything).Return(bars, nil)
+
+	req := stocks.BarsRequest{Start: start, End: end, Timeframe: stocks.Timeframe1Day, Limit: 10}
+	response, err := service.GetBars(context.Background(), "AAPL", req)
+	require.NoError(t, err)
+	assert.Len(t, response.Bars, 1)
+	assert.Empty(t, response.NextPageToken)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestService_Dump_ReflectsCachedSummaries(t *testing.T) {
+	mockClient := new(mocks.MockStocksClient)
+	service := stocks.NewService(mockClient)
+
+	quote := &stocks.YahooQuote{
+		Symbol:             "AAPL",
+		ShortName:          "Apple Inc.",
+		RegularMarketPrice: 185.50,
+		Currency:           "USD",
+	}
+	mockClient.On("GetQuote", mock.Anything, "AAPL").Return(quote, nil).Once()
+
+	_, err := service.GetSummary(context.Background(), "AAPL")
+	require.NoError(t, err)
+
+	dump := service.Dump()
+	require.Len(t, dump, 1)
+	assert.Equal(t, "AAPL", dump[0].Ticker)
+	assert.Equal(t, stocks.SessionRegular, dump[0].Session)
+	assert.Equal(t, "yahoo", dump[0].Source)
+	assert.Equal(t, int64(0), dump[0].Hits)
+	require.NotNil(t, dump[0].Summary)
+	assert.Equal(t, "AAPL", dump[0].Summary.Ticker)
+	assert.Greater(t, dump[0].TTLRemainingSeconds, 0.0)
+
+	_, err = service.GetSummary(context.Background(), "AAPL")
+	require.NoError(t, err)
+
+	dump = service.Dump()
+	require.Len(t, dump, 1)
+	assert.Equal(t, int64(1), dump[0].Hits)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestService_Invalidate_ForcesRefetch(t *testing.T) {
+	mockClient := new(mocks.MockStocksClient)
+	service := stocks.NewService(mockClient)
+
+	quote := &stocks.YahooQuote{
+		Symbol:             "AAPL",
+		ShortName:          "Apple Inc.",
+		RegularMarketPrice: 185.50,
+		Currency:           "USD",
+	}
+	mockClient.On("GetQuote", mock.Anything, "AAPL").Return(quote, nil).Twice()
+
+	_, err := service.GetSummary(context.Background(), "AAPL")
+	require.NoError(t, err)
+	assert.Len(t, service.Dump(), 1)
+
+	service.Invalidate("AAPL")
+	assert.Empty(t, service.Dump())
+
+	_, err = service.GetSummary(context.Background(), "AAPL")
+	require.NoError(t, err)
+	assert.Len(t, service.Dump(), 1)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestService_InvalidateAll_ClearsEveryEntry(t *testing.T) {
+	mockClient := new(mocks.MockStocksClient)
+	service := stocks.NewService(mockClient)
+
+	aaplQuote := &stocks.YahooQuote{Symbol: "AAPL", RegularMarketPrice: 185.50, Currency: "USD"}
+	googlQuote := &stocks.YahooQuote{Symbol: "GOOGL", RegularMarketPrice: 140.50, Currency: "USD"}
+	mockClient.On("GetQuote", mock.Anything, "AAPL").Return(aaplQuote, nil).Once()
+	mockClient.On("GetQuote", mock.Anything, "GOOGL").Return(googlQuote, nil).Once()
+
+	_, err := service.GetSummary(context.Background(), "AAPL")
+	require.NoError(t, err)
+	_, err = service.GetSummary(context.Background(), "GOOGL")
+	require.NoError(t, err)
+	assert.Len(t, service.Dump(), 2)
+
+	service.InvalidateAll()
+	assert.Empty(t, service.Dump())
 
 	mockClient.AssertExpectations(t)
 }
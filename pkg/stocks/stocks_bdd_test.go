@@ -7,12 +7,14 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/cucumber/godog"
 	"github.com/f00b455/blank-go/internal/handlers"
 	"github.com/f00b455/blank-go/pkg/stocks"
 	"github.com/f00b455/blank-go/pkg/stocks/mocks"
 	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
 )
 
 type stocksFeatureContext struct {
@@ -21,6 +23,7 @@ type stocksFeatureContext struct {
 	responseBody  map[string]interface{}
 	firstResponse *stocks.StockSummary
 	mockClient    *mocks.MockStocksClient
+	stocksService *stocks.Service
 }
 
 func (ctx *stocksFeatureContext) theYahooFinanceAPIIsAvailable() error {
@@ -33,15 +36,21 @@ func (ctx *stocksFeatureContext) theYahooFinanceAPIIsAvailable() error {
 	ctx.setupMockData()
 
 	// Initialize stocks service with mock client
-	stocksService := stocks.NewService(ctx.mockClient)
-	stocksHandler := handlers.NewStocksHandler(stocksService)
+	ctx.stocksService = stocks.NewService(ctx.mockClient)
+	stocksHandler := handlers.NewStocksHandler(ctx.stocksService)
+	adminHandler := handlers.NewAdminHandler(ctx.stocksService)
 
 	// Setup router
 	ctx.router = gin.New()
 	api := ctx.router.Group("/api/v1")
 	{
 		api.GET("/stocks/:ticker/summary", stocksHandler.GetStockSummary)
+		api.GET("/stocks/:ticker/bars", stocksHandler.GetBars)
 		api.GET("/stocks/summary", stocksHandler.GetBatchSummary)
+
+		api.GET("/admin/stocks/cache", adminHandler.DumpStocksCache)
+		api.DELETE("/admin/stocks/cache", adminHandler.InvalidateAllStocksCache)
+		api.DELETE("/admin/stocks/cache/:ticker", adminHandler.InvalidateStocksCache)
 	}
 
 	return nil
@@ -90,7 +99,7 @@ func (ctx *stocksFeatureContext) setupMockData() {
 		Currency:                   "USD",
 	}
 
-	// Mock data for TSLA
+	// Mock data for TSLA, including after-hours pricing
 	tslaQuote := &stocks.YahooQuote{
 		Symbol:                     "TSLA",
 		ShortName:                  "Tesla, Inc.",
@@ -102,6 +111,13 @@ func (ctx *stocksFeatureContext) setupMockData() {
 		RegularMarketChangePercent: 0.82,
 		RegularMarketVolume:        45000000,
 		Currency:                   "USD",
+		MarketState:                "POST",
+		PreMarketPrice:             244.00,
+		PreMarketChange:            1.00,
+		PreMarketChangePercent:     0.41,
+		PostMarketPrice:            246.50,
+		PostMarketChange:           1.50,
+		PostMarketChangePercent:    0.61,
 	}
 
 	// Mock data for AMZN
@@ -119,38 +135,57 @@ func (ctx *stocksFeatureContext) setupMockData() {
 	}
 
 	// Setup mock expectations for single ticker requests
-	ctx.mockClient.On("GetQuote", "AAPL").Return(aaplQuote, nil).Maybe()
-	ctx.mockClient.On("GetQuote", "GOOGL").Return(googlQuote, nil).Maybe()
-	ctx.mockClient.On("GetQuote", "MSFT").Return(msftQuote, nil).Maybe()
-	ctx.mockClient.On("GetQuote", "TSLA").Return(tslaQuote, nil).Maybe()
-	ctx.mockClient.On("GetQuote", "AMZN").Return(amznQuote, nil).Maybe()
-	ctx.mockClient.On("GetQuote", "").Return(nil, fmt.Errorf("ticker is required")).Maybe()
-	ctx.mockClient.On("GetQuote", "INVALID_TICKER_XYZ").Return(nil, fmt.Errorf("ticker not found")).Maybe()
-	ctx.mockClient.On("GetQuote", "INVALID_XYZ").Return(nil, fmt.Errorf("ticker not found")).Maybe()
+	ctx.mockClient.On("GetQuote", mock.Anything, "AAPL").Return(aaplQuote, nil).Maybe()
+	ctx.mockClient.On("GetQuote", mock.Anything, "GOOGL").Return(googlQuote, nil).Maybe()
+	ctx.mockClient.On("GetQuote", mock.Anything, "MSFT").Return(msftQuote, nil).Maybe()
+	ctx.mockClient.On("GetQuote", mock.Anything, "TSLA").Return(tslaQuote, nil).Maybe()
+	ctx.mockClient.On("GetQuote", mock.Anything, "AMZN").Return(amznQuote, nil).Maybe()
+	ctx.mockClient.On("GetQuote", mock.Anything, "").Return(nil, fmt.Errorf("ticker is required")).Maybe()
+	ctx.mockClient.On("GetQuote", mock.Anything, "INVALID_TICKER_XYZ").Return(nil, fmt.Errorf("ticker not found")).Maybe()
+	ctx.mockClient.On("GetQuote", mock.Anything, "INVALID_XYZ").Return(nil, fmt.Errorf("ticker not found")).Maybe()
 
 	// Setup mock expectations for batch requests
-	ctx.mockClient.On("GetQuotes", []string{"AAPL", "GOOGL", "MSFT"}).Return(map[string]*stocks.YahooQuote{
+	ctx.mockClient.On("GetQuotes", mock.Anything, []string{"AAPL", "GOOGL", "MSFT"}).Return(map[string]*stocks.YahooQuote{
 		"AAPL":  aaplQuote,
 		"GOOGL": googlQuote,
 		"MSFT":  msftQuote,
 	}, nil).Maybe()
 
-	ctx.mockClient.On("GetQuotes", []string{"AAPL"}).Return(map[string]*stocks.YahooQuote{
+	ctx.mockClient.On("GetQuotes", mock.Anything, []string{"AAPL"}).Return(map[string]*stocks.YahooQuote{
 		"AAPL": aaplQuote,
 	}, nil).Maybe()
 
-	ctx.mockClient.On("GetQuotes", []string{"AAPL", "INVALID_XYZ", "MSFT"}).Return(map[string]*stocks.YahooQuote{
+	ctx.mockClient.On("GetQuotes", mock.Anything, []string{"AAPL", "INVALID_XYZ", "MSFT"}).Return(map[string]*stocks.YahooQuote{
 		"AAPL": aaplQuote,
 		"MSFT": msftQuote,
 	}, nil).Maybe()
 
-	ctx.mockClient.On("GetQuotes", []string{"AAPL", "GOOGL", "MSFT", "TSLA", "AMZN"}).Return(map[string]*stocks.YahooQuote{
+	ctx.mockClient.On("GetQuotes", mock.Anything, []string{"AAPL", "GOOGL", "MSFT", "TSLA", "AMZN"}).Return(map[string]*stocks.YahooQuote{
 		"AAPL":  aaplQuote,
 		"GOOGL": googlQuote,
 		"MSFT":  msftQuote,
 		"TSLA":  tslaQuote,
 		"AMZN":  amznQuote,
 	}, nil).Maybe()
+
+	// Mock daily bars for AAPL, sized so tests can exercise both a full page
+	// (limit 2, implying more pages) and the last page (limit 10, exhausted).
+	aaplBars := []stocks.Bar{
+		{T: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), O: 180, H: 182, L: 179, C: 181, V: 1000000, VW: 180.67},
+		{T: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), O: 181, H: 183, L: 180, C: 182, V: 1100000, VW: 181.67},
+		{T: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), O: 182, H: 184, L: 181, C: 183, V: 1200000, VW: 182.67},
+		{T: time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), O: 183, H: 185, L: 182, C: 184, V: 1300000, VW: 183.67},
+	}
+
+	barsLimitMatcher := func(limit int) interface{} {
+		return mock.MatchedBy(func(opts stocks.BarsRequest) bool {
+			return opts.Limit == limit
+		})
+	}
+
+	ctx.mockClient.On("GetBars", mock.Anything, "AAPL", barsLimitMatcher(0)).Return(aaplBars, nil).Maybe()
+	ctx.mockClient.On("GetBars", mock.Anything, "AAPL", barsLimitMatcher(2)).Return(aaplBars[:2], nil).Maybe()
+	ctx.mockClient.On("GetBars", mock.Anything, "AAPL", barsLimitMatcher(10)).Return(aaplBars, nil).Maybe()
 }
 
 func (ctx *stocksFeatureContext) iRequestStockSummaryForTicker(ticker string) error {
@@ -165,6 +200,32 @@ func (ctx *stocksFeatureContext) iRequestStockSummaryForTicker(ticker string) er
 	return nil
 }
 
+func (ctx *stocksFeatureContext) iRequestStockSummaryForTickerWithSession(ticker, session string) error {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stocks/"+ticker+"/summary?session="+session, nil)
+	ctx.response = httptest.NewRecorder()
+	ctx.router.ServeHTTP(ctx.response, req)
+
+	if ctx.response.Code == http.StatusOK {
+		_ = json.Unmarshal([]byte(ctx.response.Body.String()), &ctx.responseBody)
+	}
+
+	return nil
+}
+
+func (ctx *stocksFeatureContext) theResponseShouldContainCurrentPriceReflectingTheSession(session string) error {
+	price, ok := ctx.responseBody["current_price"].(float64)
+	if !ok {
+		return fmt.Errorf("response should contain current_price")
+	}
+
+	// TSLA regular price is 245.00; pre/post/extended sessions must diverge from it.
+	if (session == "pre" || session == "post" || session == "extended") && price == 245.00 {
+		return fmt.Errorf("expected %s session price to differ from regular market price, got %v", session, price)
+	}
+
+	return nil
+}
+
 func (ctx *stocksFeatureContext) iRequestBatchStockSummaryForTickers(tickers string) error {
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/stocks/summary?tickers="+tickers, nil)
 	ctx.response = httptest.NewRecorder()
@@ -320,8 +381,9 @@ func (ctx *stocksFeatureContext) bothResponsesShouldBeIdentical() error {
 }
 
 func (ctx *stocksFeatureContext) theSecondRequestShouldBeServedFromCache() error {
-	// In a real implementation, we'd track cache hits
-	// For now, we just verify we got a successful response
+	if cacheStatus := ctx.response.Header().Get("X-Cache"); cacheStatus != "HIT" {
+		return fmt.Errorf("expected second request to be served from cache (X-Cache: HIT), got X-Cache: %s", cacheStatus)
+	}
 	return nil
 }
 
@@ -405,6 +467,61 @@ func (ctx *stocksFeatureContext) theRequestShouldNotExceedAPIRateLimits() error
 	return nil
 }
 
+func (ctx *stocksFeatureContext) iRequestBarsForTickerFromToWithTimeframe(ticker, start, end, timeframe string) error {
+	url := fmt.Sprintf("/api/v1/stocks/%s/bars?start=%s&end=%s&timeframe=%s", ticker, start, end, timeframe)
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	ctx.response = httptest.NewRecorder()
+	ctx.router.ServeHTTP(ctx.response, req)
+
+	_ = json.Unmarshal([]byte(ctx.response.Body.String()), &ctx.responseBody)
+
+	return nil
+}
+
+func (ctx *stocksFeatureContext) iRequestBarsForTickerFromToWithTimeframeAndLimit(ticker, start, end, timeframe string, limit int) error {
+	url := fmt.Sprintf("/api/v1/stocks/%s/bars?start=%s&end=%s&timeframe=%s&limit=%d", ticker, start, end, timeframe, limit)
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	ctx.response = httptest.NewRecorder()
+	ctx.router.ServeHTTP(ctx.response, req)
+
+	_ = json.Unmarshal([]byte(ctx.response.Body.String()), &ctx.responseBody)
+
+	return nil
+}
+
+func (ctx *stocksFeatureContext) theResponseShouldContainBars() error {
+	if _, ok := ctx.responseBody["bars"]; !ok {
+		return fmt.Errorf("response should contain bars")
+	}
+	return nil
+}
+
+func (ctx *stocksFeatureContext) theResponseShouldContainNBars(count int) error {
+	bars, ok := ctx.responseBody["bars"].([]interface{})
+	if !ok {
+		return fmt.Errorf("response should contain bars array")
+	}
+	if len(bars) != count {
+		return fmt.Errorf("expected %d bars, got %d", count, len(bars))
+	}
+	return nil
+}
+
+func (ctx *stocksFeatureContext) theResponseShouldContainANextPageToken() error {
+	token, ok := ctx.responseBody["next_page_token"].(string)
+	if !ok || token == "" {
+		return fmt.Errorf("response should contain a non-empty next_page_token")
+	}
+	return nil
+}
+
+func (ctx *stocksFeatureContext) theResponseShouldContainNoNextPageToken() error {
+	if token, ok := ctx.responseBody["next_page_token"]; ok && token != "" {
+		return fmt.Errorf("expected no next_page_token, got %v", token)
+	}
+	return nil
+}
+
 func (ctx *stocksFeatureContext) allStockSummariesShouldBeReturned(count int) error {
 	summaries, ok := ctx.responseBody["summaries"].([]interface{})
 	if !ok {
@@ -418,6 +535,55 @@ func (ctx *stocksFeatureContext) allStockSummariesShouldBeReturned(count int) er
 	return nil
 }
 
+func (ctx *stocksFeatureContext) iRequestTheAdminStocksCacheDump() error {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/stocks/cache", nil)
+	ctx.response = httptest.NewRecorder()
+	ctx.router.ServeHTTP(ctx.response, req)
+
+	_ = json.Unmarshal([]byte(ctx.response.Body.String()), &ctx.responseBody)
+
+	return nil
+}
+
+func (ctx *stocksFeatureContext) iInvalidateTheStocksCacheForTicker(ticker string) error {
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/stocks/cache/"+ticker, nil)
+	ctx.response = httptest.NewRecorder()
+	ctx.router.ServeHTTP(ctx.response, req)
+	return nil
+}
+
+func (ctx *stocksFeatureContext) iInvalidateTheEntireStocksCache() error {
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/stocks/cache", nil)
+	ctx.response = httptest.NewRecorder()
+	ctx.router.ServeHTTP(ctx.response, req)
+	return nil
+}
+
+func (ctx *stocksFeatureContext) theCacheDumpShouldContainNEntries(count int) error {
+	entries, ok := ctx.responseBody["entries"].([]interface{})
+	if !ok {
+		return fmt.Errorf("response should contain entries array")
+	}
+	if len(entries) != count {
+		return fmt.Errorf("expected %d cache entries, got %d", count, len(entries))
+	}
+	return nil
+}
+
+func (ctx *stocksFeatureContext) theCacheDumpShouldIncludeTicker(ticker string) error {
+	entries, ok := ctx.responseBody["entries"].([]interface{})
+	if !ok {
+		return fmt.Errorf("response should contain entries array")
+	}
+	for _, raw := range entries {
+		entry, ok := raw.(map[string]interface{})
+		if ok && entry["ticker"] == ticker {
+			return nil
+		}
+	}
+	return fmt.Errorf("expected cache dump to include ticker %s", ticker)
+}
+
 func InitializeScenario(ctx *godog.ScenarioContext) {
 	featureCtx := &stocksFeatureContext{}
 
@@ -439,6 +605,8 @@ func InitializeScenario(ctx *godog.ScenarioContext) {
 	ctx.Step(`^the company name should not be empty$`, featureCtx.theCompanyNameShouldNotBeEmpty)
 	ctx.Step(`^the response should contain date$`, featureCtx.theResponseShouldContainDate)
 	ctx.Step(`^the date should be in format "([^"]*)"$`, featureCtx.theDateShouldBeInFormat)
+	ctx.Step(`^I request stock summary for ticker "([^"]*)" with session "([^"]*)"$`, featureCtx.iRequestStockSummaryForTickerWithSession)
+	ctx.Step(`^the response should contain current price reflecting the "([^"]*)" session$`, featureCtx.theResponseShouldContainCurrentPriceReflectingTheSession)
 
 	// Batch stock steps
 	ctx.Step(`^I request batch stock summary for tickers "([^"]*)"$`, featureCtx.iRequestBatchStockSummaryForTickers)
@@ -459,6 +627,21 @@ func InitializeScenario(ctx *godog.ScenarioContext) {
 	// Common steps
 	ctx.Step(`^the response status should be (\d+)$`, featureCtx.theResponseStatusShouldBe)
 	ctx.Step(`^the error message should indicate "([^"]*)"$`, featureCtx.theErrorMessageShouldIndicate)
+
+	// Bars steps
+	ctx.Step(`^I request bars for ticker "([^"]*)" from "([^"]*)" to "([^"]*)" with timeframe "([^"]*)"$`, featureCtx.iRequestBarsForTickerFromToWithTimeframe)
+	ctx.Step(`^I request bars for ticker "([^"]*)" from "([^"]*)" to "([^"]*)" with timeframe "([^"]*)" and limit (\d+)$`, featureCtx.iRequestBarsForTickerFromToWithTimeframeAndLimit)
+	ctx.Step(`^the response should contain bars$`, featureCtx.theResponseShouldContainBars)
+	ctx.Step(`^the response should contain (\d+) bars$`, featureCtx.theResponseShouldContainNBars)
+	ctx.Step(`^the response should contain a next page token$`, featureCtx.theResponseShouldContainANextPageToken)
+	ctx.Step(`^the response should contain no next page token$`, featureCtx.theResponseShouldContainNoNextPageToken)
+
+	// Admin cache steps
+	ctx.Step(`^I request the admin stocks cache dump$`, featureCtx.iRequestTheAdminStocksCacheDump)
+	ctx.Step(`^I invalidate the stocks cache for ticker "([^"]*)"$`, featureCtx.iInvalidateTheStocksCacheForTicker)
+	ctx.Step(`^I invalidate the entire stocks cache$`, featureCtx.iInvalidateTheEntireStocksCache)
+	ctx.Step(`^the cache dump should contain (\d+) entries$`, featureCtx.theCacheDumpShouldContainNEntries)
+	ctx.Step(`^the cache dump should include ticker "([^"]*)"$`, featureCtx.theCacheDumpShouldIncludeTicker)
 }
 
 func TestFeatures(t *testing.T) {
@@ -466,7 +649,7 @@ func TestFeatures(t *testing.T) {
 		ScenarioInitializer: InitializeScenario,
 		Options: &godog.Options{
 			Format:   "pretty",
-			Paths:    []string{"../../features/stocks-batch.feature", "../../features/stocks-summary.feature"},
+			Paths:    []string{"../../features/stocks-batch.feature", "../../features/stocks-summary.feature", "../../features/stocks-bars.feature", "../../features/admin-cache.feature"},
 			TestingT: t,
 		},
 	}
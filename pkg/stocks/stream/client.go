@@ -0,0 +1,84 @@
+package stream
+
+// StreamClient is a single subscriber's view of the streaming subsystem. A
+// WebSocket handler creates one per connection and relays subscribe/
+// unsubscribe control frames and quote/trade/bar data frames through it.
+type StreamClient interface {
+	// Subscribe starts delivering quotes for tickers on the returned channel.
+	Subscribe(tickers []string) (<-chan Quote, error)
+	// Unsubscribe stops delivering quotes for tickers.
+	Unsubscribe(tickers []string) error
+	// SubscribeTrades starts delivering trades for tickers on the returned
+	// channel.
+	SubscribeTrades(tickers []string) (<-chan Trade, error)
+	// UnsubscribeTrades stops delivering trades for tickers.
+	UnsubscribeTrades(tickers []string) error
+	// SubscribeBars starts delivering minute bars for tickers on the
+	// returned channel.
+	SubscribeBars(tickers []string) (<-chan Bar, error)
+	// UnsubscribeBars stops delivering minute bars for tickers.
+	UnsubscribeBars(tickers []string) error
+	// Close releases every subscription and any tickers only this client
+	// held, across all three streams.
+	Close() error
+}
+
+// session implements StreamClient on top of a Hub.
+type session struct {
+	hub    *Hub
+	quotes chan Quote
+	trades chan Trade
+	bars   chan Bar
+
+	quoteTickers map[string]struct{}
+	tradeTickers map[string]struct{}
+	barTickers   map[string]struct{}
+}
+
+// Subscribe implements StreamClient.
+func (s *session) Subscribe(tickers []string) (<-chan Quote, error) {
+	if err := s.hub.subscribe(s, tickers); err != nil {
+		return nil, err
+	}
+	return s.quotes, nil
+}
+
+// Unsubscribe implements StreamClient.
+func (s *session) Unsubscribe(tickers []string) error {
+	return s.hub.unsubscribe(s, tickers)
+}
+
+// SubscribeTrades implements StreamClient.
+func (s *session) SubscribeTrades(tickers []string) (<-chan Trade, error) {
+	if err := s.hub.subscribeTrades(s, tickers); err != nil {
+		return nil, err
+	}
+	return s.trades, nil
+}
+
+// UnsubscribeTrades implements StreamClient.
+func (s *session) UnsubscribeTrades(tickers []string) error {
+	return s.hub.unsubscribeTrades(s, tickers)
+}
+
+// SubscribeBars implements StreamClient.
+func (s *session) SubscribeBars(tickers []string) (<-chan Bar, error) {
+	if err := s.hub.subscribeBars(s, tickers); err != nil {
+		return nil, err
+	}
+	return s.bars, nil
+}
+
+// UnsubscribeBars implements StreamClient.
+func (s *session) UnsubscribeBars(tickers []string) error {
+	return s.hub.unsubscribeBars(s, tickers)
+}
+
+// Close implements StreamClient.
+func (s *session) Close() error {
+	s.hub.closeSession(s)
+	close(s.quotes)
+	close(s.trades)
+	close(s.bars)
+	return nil
+}
@@ -0,0 +1,349 @@
+package stream
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	initialBackoff = 500 * time.Millisecond
+	maxBackoff     = 30 * time.Second
+)
+
+// Hub fans out a single upstream market-data connection across many
+// subscriber sessions, so that N WebSocket clients asking for overlapping
+// tickers only ever open one upstream subscription per ticker. It also owns
+// reconnect-with-backoff: when the upstream connection drops, the hub
+// reconnects and re-subscribes every ticker that still has an active
+// subscriber, across all three of its quote/trade/bar subscription sets.
+type Hub struct {
+	upstream Upstream
+
+	mu           sync.Mutex
+	sessions     map[*session]struct{}
+	quoteTickers map[string]map[*session]struct{}
+	tradeTickers map[string]map[*session]struct{}
+	barTickers   map[string]map[*session]struct{}
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+// NewHub creates a Hub backed by the given upstream connection and starts its
+// dispatch/reconnect loop in the background.
+func NewHub(upstream Upstream) *Hub {
+	h := &Hub{
+		upstream:     upstream,
+		sessions:     make(map[*session]struct{}),
+		quoteTickers: make(map[string]map[*session]struct{}),
+		tradeTickers: make(map[string]map[*session]struct{}),
+		barTickers:   make(map[string]map[*session]struct{}),
+		stopCh:       make(chan struct{}),
+	}
+	go h.run()
+	return h
+}
+
+// NewSession returns a new StreamClient backed by this hub. Each WebSocket
+// connection should get its own session.
+func (h *Hub) NewSession() StreamClient {
+	s := &session{
+		hub:          h,
+		quotes:       make(chan Quote, 64),
+		trades:       make(chan Trade, 64),
+		bars:         make(chan Bar, 64),
+		quoteTickers: make(map[string]struct{}),
+		tradeTickers: make(map[string]struct{}),
+		barTickers:   make(map[string]struct{}),
+	}
+	h.mu.Lock()
+	h.sessions[s] = struct{}{}
+	h.mu.Unlock()
+	return s
+}
+
+// Close stops the hub's dispatch loop and closes the upstream connection.
+func (h *Hub) Close() error {
+	h.stopOnce.Do(func() { close(h.stopCh) })
+	return h.upstream.Close()
+}
+
+// run reads quotes/trades/bars from the upstream connection and fans them
+// out to subscribed sessions. When all three of the upstream's channels
+// close (connection lost) it reconnects with exponential backoff and
+// re-subscribes every ticker that still has at least one active subscriber.
+func (h *Hub) run() {
+	for {
+		select {
+		case <-h.stopCh:
+			return
+		default:
+		}
+
+		h.drainUpstream()
+
+		select {
+		case <-h.stopCh:
+			return
+		default:
+		}
+
+		h.reconnect()
+	}
+}
+
+// drainUpstream dispatches quotes/trades/bars until the upstream connection
+// is lost, i.e. until all three channels have been closed.
+func (h *Hub) drainUpstream() {
+	quotes := h.upstream.Quotes()
+	trades := h.upstream.Trades()
+	bars := h.upstream.Bars()
+
+	for quotes != nil || trades != nil || bars != nil {
+		select {
+		case q, ok := <-quotes:
+			if !ok {
+				quotes = nil
+				continue
+			}
+			h.dispatchQuote(q)
+		case t, ok := <-trades:
+			if !ok {
+				trades = nil
+				continue
+			}
+			h.dispatchTrade(t)
+		case b, ok := <-bars:
+			if !ok {
+				bars = nil
+				continue
+			}
+			h.dispatchBar(b)
+		}
+	}
+}
+
+// reconnect retries Connect with exponential backoff until it succeeds (or
+// the hub is closed), then re-subscribes every actively-watched ticker.
+func (h *Hub) reconnect() {
+	backoff := initialBackoff
+
+	for {
+		select {
+		case <-h.stopCh:
+			return
+		case <-time.After(backoff):
+		}
+
+		if err := h.upstream.Connect(); err == nil {
+			h.resubscribeAll()
+			return
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// resubscribeAll re-issues upstream subscriptions for every ticker that
+// currently has at least one subscriber, across all three stream types.
+func (h *Hub) resubscribeAll() {
+	h.mu.Lock()
+	seen := make(map[string]struct{})
+	active := make([]string, 0, len(h.quoteTickers)+len(h.tradeTickers)+len(h.barTickers))
+	for _, tickers := range []map[string]map[*session]struct{}{h.quoteTickers, h.tradeTickers, h.barTickers} {
+		for ticker := range tickers {
+			if _, ok := seen[ticker]; ok {
+				continue
+			}
+			seen[ticker] = struct{}{}
+			active = append(active, ticker)
+		}
+	}
+	h.mu.Unlock()
+
+	if len(active) > 0 {
+		_ = h.upstream.Subscribe(active)
+	}
+}
+
+// dispatchQuote delivers a quote to every session subscribed to its ticker.
+func (h *Hub) dispatchQuote(q Quote) {
+	h.mu.Lock()
+	subs := h.quoteTickers[q.Ticker]
+	targets := make([]*session, 0, len(subs))
+	for s := range subs {
+		targets = append(targets, s)
+	}
+	h.mu.Unlock()
+
+	for _, s := range targets {
+		select {
+		case s.quotes <- q:
+		default:
+			// Slow subscriber: drop the update rather than block the hub.
+		}
+	}
+}
+
+// dispatchTrade delivers a trade to every session subscribed to its ticker.
+func (h *Hub) dispatchTrade(t Trade) {
+	h.mu.Lock()
+	subs := h.tradeTickers[t.Ticker]
+	targets := make([]*session, 0, len(subs))
+	for s := range subs {
+		targets = append(targets, s)
+	}
+	h.mu.Unlock()
+
+	for _, s := range targets {
+		select {
+		case s.trades <- t:
+		default:
+			// Slow subscriber: drop the update rather than block the hub.
+		}
+	}
+}
+
+// dispatchBar delivers a bar to every session subscribed to its ticker.
+func (h *Hub) dispatchBar(b Bar) {
+	h.mu.Lock()
+	subs := h.barTickers[b.Ticker]
+	targets := make([]*session, 0, len(subs))
+	for s := range subs {
+		targets = append(targets, s)
+	}
+	h.mu.Unlock()
+
+	for _, s := range targets {
+		select {
+		case s.bars <- b:
+		default:
+			// Slow subscriber: drop the update rather than block the hub.
+		}
+	}
+}
+
+// refCount reports how many sessions, across all three stream types, still
+// have an interest in ticker. Used to decide whether adding/removing a
+// subscriber should issue an upstream Subscribe/Unsubscribe call - a ticker
+// only needs one upstream subscription no matter how many of
+// quote/trade/bar a session wants out of it.
+func (h *Hub) refCount(ticker string) int {
+	return len(h.quoteTickers[ticker]) + len(h.tradeTickers[ticker]) + len(h.barTickers[ticker])
+}
+
+// subscribeSet registers s's interest in tickers within the given
+// stream-type ticker set, issuing a new upstream subscription for any ticker
+// the hub wasn't already watching on any stream.
+func (h *Hub) subscribeSet(tickers map[string]map[*session]struct{}, sessionTickers map[string]struct{}, s *session, requested []string) error {
+	h.mu.Lock()
+	var newTickers []string
+	for _, ticker := range requested {
+		if _, ok := sessionTickers[ticker]; ok {
+			continue
+		}
+		if h.refCount(ticker) == 0 {
+			newTickers = append(newTickers, ticker)
+		}
+		sessionTickers[ticker] = struct{}{}
+
+		subs, exists := tickers[ticker]
+		if !exists {
+			subs = make(map[*session]struct{})
+			tickers[ticker] = subs
+		}
+		subs[s] = struct{}{}
+	}
+	h.mu.Unlock()
+
+	if len(newTickers) == 0 {
+		return nil
+	}
+	return h.upstream.Subscribe(newTickers)
+}
+
+// unsubscribeSet removes s's interest in tickers from the given stream-type
+// ticker set, issuing an upstream unsubscribe for any ticker that no longer
+// has any subscriber on any stream.
+func (h *Hub) unsubscribeSet(tickers map[string]map[*session]struct{}, sessionTickers map[string]struct{}, s *session, requested []string) error {
+	h.mu.Lock()
+	var emptyTickers []string
+	for _, ticker := range requested {
+		if _, ok := sessionTickers[ticker]; !ok {
+			continue
+		}
+		delete(sessionTickers, ticker)
+
+		subs := tickers[ticker]
+		delete(subs, s)
+		if len(subs) == 0 {
+			delete(tickers, ticker)
+		}
+		if h.refCount(ticker) == 0 {
+			emptyTickers = append(emptyTickers, ticker)
+		}
+	}
+	h.mu.Unlock()
+
+	if len(emptyTickers) == 0 {
+		return nil
+	}
+	return h.upstream.Unsubscribe(emptyTickers)
+}
+
+// subscribe registers s's interest in tickers for the quote stream.
+func (h *Hub) subscribe(s *session, tickers []string) error {
+	return h.subscribeSet(h.quoteTickers, s.quoteTickers, s, tickers)
+}
+
+// unsubscribe removes s's interest in tickers from the quote stream.
+func (h *Hub) unsubscribe(s *session, tickers []string) error {
+	return h.unsubscribeSet(h.quoteTickers, s.quoteTickers, s, tickers)
+}
+
+// subscribeTrades registers s's interest in tickers for the trade stream.
+func (h *Hub) subscribeTrades(s *session, tickers []string) error {
+	return h.subscribeSet(h.tradeTickers, s.tradeTickers, s, tickers)
+}
+
+// unsubscribeTrades removes s's interest in tickers from the trade stream.
+func (h *Hub) unsubscribeTrades(s *session, tickers []string) error {
+	return h.unsubscribeSet(h.tradeTickers, s.tradeTickers, s, tickers)
+}
+
+// subscribeBars registers s's interest in tickers for the bar stream.
+func (h *Hub) subscribeBars(s *session, tickers []string) error {
+	return h.subscribeSet(h.barTickers, s.barTickers, s, tickers)
+}
+
+// unsubscribeBars removes s's interest in tickers from the bar stream.
+func (h *Hub) unsubscribeBars(s *session, tickers []string) error {
+	return h.unsubscribeSet(h.barTickers, s.barTickers, s, tickers)
+}
+
+// closeSession removes s from the hub and releases any tickers it was the
+// last subscriber of, across all three stream types.
+func (h *Hub) closeSession(s *session) {
+	h.mu.Lock()
+	delete(h.sessions, s)
+	quoteTickers := make([]string, 0, len(s.quoteTickers))
+	for ticker := range s.quoteTickers {
+		quoteTickers = append(quoteTickers, ticker)
+	}
+	tradeTickers := make([]string, 0, len(s.tradeTickers))
+	for ticker := range s.tradeTickers {
+		tradeTickers = append(tradeTickers, ticker)
+	}
+	barTickers := make([]string, 0, len(s.barTickers))
+	for ticker := range s.barTickers {
+		barTickers = append(barTickers, ticker)
+	}
+	h.mu.Unlock()
+
+	_ = h.unsubscribe(s, quoteTickers)
+	_ = h.unsubscribeTrades(s, tradeTickers)
+	_ = h.unsubscribeBars(s, barTickers)
+}
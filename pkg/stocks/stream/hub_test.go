@@ -0,0 +1,307 @@
+package stream_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/stocks/stream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeUpstream is a test double for stream.Upstream. Tests drive it directly
+// by pushing quotes/trades/bars onto their channels or closing them to
+// simulate a dropped connection.
+type fakeUpstream struct {
+	mu            sync.Mutex
+	quoteCh       chan stream.Quote
+	tradeCh       chan stream.Trade
+	barCh         chan stream.Bar
+	connectCalls  int
+	connectErrors []error
+	subscribed    []string
+	unsubscribed  []string
+	closed        bool
+}
+
+func newFakeUpstream() *fakeUpstream {
+	return &fakeUpstream{
+		quoteCh: make(chan stream.Quote, 16),
+		tradeCh: make(chan stream.Trade, 16),
+		barCh:   make(chan stream.Bar, 16),
+	}
+}
+
+func (f *fakeUpstream) Connect() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.connectCalls++
+	if len(f.connectErrors) > 0 {
+		err := f.connectErrors[0]
+		f.connectErrors = f.connectErrors[1:]
+		if err != nil {
+			return err
+		}
+	}
+	f.quoteCh = make(chan stream.Quote, 16)
+	f.tradeCh = make(chan stream.Trade, 16)
+	f.barCh = make(chan stream.Bar, 16)
+	return nil
+}
+
+func (f *fakeUpstream) Subscribe(tickers []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.subscribed = append(f.subscribed, tickers...)
+	return nil
+}
+
+func (f *fakeUpstream) Unsubscribe(tickers []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.unsubscribed = append(f.unsubscribed, tickers...)
+	return nil
+}
+
+func (f *fakeUpstream) Quotes() <-chan stream.Quote {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.quoteCh
+}
+
+func (f *fakeUpstream) Trades() <-chan stream.Trade {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.tradeCh
+}
+
+func (f *fakeUpstream) Bars() <-chan stream.Bar {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.barCh
+}
+
+func (f *fakeUpstream) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	return nil
+}
+
+func (f *fakeUpstream) push(q stream.Quote) {
+	f.mu.Lock()
+	ch := f.quoteCh
+	f.mu.Unlock()
+	ch <- q
+}
+
+func (f *fakeUpstream) pushTrade(tr stream.Trade) {
+	f.mu.Lock()
+	ch := f.tradeCh
+	f.mu.Unlock()
+	ch <- tr
+}
+
+func (f *fakeUpstream) pushBar(b stream.Bar) {
+	f.mu.Lock()
+	ch := f.barCh
+	f.mu.Unlock()
+	ch <- b
+}
+
+func (f *fakeUpstream) dropConnection() {
+	f.mu.Lock()
+	close(f.quoteCh)
+	close(f.tradeCh)
+	close(f.barCh)
+	f.mu.Unlock()
+}
+
+func TestHub_SubscribeAndDispatch(t *testing.T) {
+	upstream := newFakeUpstream()
+	hub := stream.NewHub(upstream)
+	defer hub.Close()
+
+	client := hub.NewSession()
+	quotes, err := client.Subscribe([]string{"AAPL"})
+	require.NoError(t, err)
+
+	upstream.push(stream.Quote{Ticker: "AAPL", Price: 185.5})
+
+	select {
+	case q := <-quotes:
+		assert.Equal(t, "AAPL", q.Ticker)
+		assert.Equal(t, 185.5, q.Price)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for quote")
+	}
+
+	assert.Contains(t, upstream.subscribed, "AAPL")
+}
+
+func TestHub_MultiplexesSubscribersPerTicker(t *testing.T) {
+	upstream := newFakeUpstream()
+	hub := stream.NewHub(upstream)
+	defer hub.Close()
+
+	first := hub.NewSession()
+	second := hub.NewSession()
+
+	_, err := first.Subscribe([]string{"AAPL"})
+	require.NoError(t, err)
+	_, err = second.Subscribe([]string{"AAPL"})
+	require.NoError(t, err)
+
+	// Only one upstream subscription should have been issued for AAPL.
+	count := 0
+	for _, ticker := range upstream.subscribed {
+		if ticker == "AAPL" {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count)
+}
+
+func TestHub_Unsubscribe(t *testing.T) {
+	upstream := newFakeUpstream()
+	hub := stream.NewHub(upstream)
+	defer hub.Close()
+
+	client := hub.NewSession()
+	quotes, err := client.Subscribe([]string{"AAPL"})
+	require.NoError(t, err)
+
+	require.NoError(t, client.Unsubscribe([]string{"AAPL"}))
+	assert.Contains(t, upstream.unsubscribed, "AAPL")
+
+	upstream.push(stream.Quote{Ticker: "AAPL", Price: 1})
+	select {
+	case _, ok := <-quotes:
+		if ok {
+			t.Fatal("should not receive quotes after unsubscribe")
+		}
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestHub_ReconnectsAndResubscribes(t *testing.T) {
+	upstream := newFakeUpstream()
+	hub := stream.NewHub(upstream)
+	defer hub.Close()
+
+	client := hub.NewSession()
+	quotes, err := client.Subscribe([]string{"TSLA"})
+	require.NoError(t, err)
+
+	upstream.dropConnection()
+
+	require.Eventually(t, func() bool {
+		upstream.mu.Lock()
+		defer upstream.mu.Unlock()
+		return upstream.connectCalls >= 1
+	}, 2*time.Second, 10*time.Millisecond, "expected hub to reconnect")
+
+	upstream.push(stream.Quote{Ticker: "TSLA", Price: 245})
+
+	select {
+	case q := <-quotes:
+		assert.Equal(t, "TSLA", q.Ticker)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for quote after reconnect")
+	}
+
+	count := 0
+	for _, ticker := range upstream.subscribed {
+		if ticker == "TSLA" {
+			count++
+		}
+	}
+	assert.GreaterOrEqual(t, count, 2, "expected a re-subscription after reconnect")
+}
+
+func TestHub_CloseSessionReleasesLastSubscriber(t *testing.T) {
+	upstream := newFakeUpstream()
+	hub := stream.NewHub(upstream)
+	defer hub.Close()
+
+	client := hub.NewSession()
+	_, err := client.Subscribe([]string{"AAPL"})
+	require.NoError(t, err)
+
+	require.NoError(t, client.Close())
+	assert.Contains(t, upstream.unsubscribed, "AAPL")
+}
+
+func TestHub_SubscribeTradesAndDispatch(t *testing.T) {
+	upstream := newFakeUpstream()
+	hub := stream.NewHub(upstream)
+	defer hub.Close()
+
+	client := hub.NewSession()
+	trades, err := client.SubscribeTrades([]string{"AAPL"})
+	require.NoError(t, err)
+
+	upstream.pushTrade(stream.Trade{Ticker: "AAPL", Price: 185.5, Size: 100})
+
+	select {
+	case tr := <-trades:
+		assert.Equal(t, "AAPL", tr.Ticker)
+		assert.Equal(t, int64(100), tr.Size)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for trade")
+	}
+
+	assert.Contains(t, upstream.subscribed, "AAPL")
+}
+
+func TestHub_SubscribeBarsAndDispatch(t *testing.T) {
+	upstream := newFakeUpstream()
+	hub := stream.NewHub(upstream)
+	defer hub.Close()
+
+	client := hub.NewSession()
+	bars, err := client.SubscribeBars([]string{"SAP.DE"})
+	require.NoError(t, err)
+
+	upstream.pushBar(stream.Bar{Ticker: "SAP.DE", Open: 100, Close: 101})
+
+	select {
+	case b := <-bars:
+		assert.Equal(t, "SAP.DE", b.Ticker)
+		assert.Equal(t, 101.0, b.Close)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for bar")
+	}
+
+	assert.Contains(t, upstream.subscribed, "SAP.DE")
+}
+
+func TestHub_OneUpstreamSubscriptionAcrossStreamTypes(t *testing.T) {
+	upstream := newFakeUpstream()
+	hub := stream.NewHub(upstream)
+	defer hub.Close()
+
+	client := hub.NewSession()
+	_, err := client.Subscribe([]string{"AAPL"})
+	require.NoError(t, err)
+	_, err = client.SubscribeTrades([]string{"AAPL"})
+	require.NoError(t, err)
+
+	// AAPL already has a quote subscriber, so subscribing its trade stream
+	// too should not issue a second upstream subscription.
+	count := 0
+	for _, ticker := range upstream.subscribed {
+		if ticker == "AAPL" {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count)
+
+	require.NoError(t, client.Unsubscribe([]string{"AAPL"}))
+	assert.NotContains(t, upstream.unsubscribed, "AAPL", "trade subscriber still active, upstream should stay subscribed")
+
+	require.NoError(t, client.UnsubscribeTrades([]string{"AAPL"}))
+	assert.Contains(t, upstream.unsubscribed, "AAPL")
+}
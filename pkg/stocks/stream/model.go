@@ -0,0 +1,67 @@
+package stream
+
+import "time"
+
+// Quote represents a single live price update for a ticker
+type Quote struct {
+	Ticker        string    `json:"ticker"`
+	Price         float64   `json:"price"`
+	Change        float64   `json:"change"`
+	ChangePercent float64   `json:"change_percent"`
+	Volume        int64     `json:"volume,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// Trade represents a single executed trade for a ticker.
+type Trade struct {
+	Ticker    string    `json:"ticker"`
+	Price     float64   `json:"price"`
+	Size      int64     `json:"size"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Bar represents a single completed minute bar (OHLCV) for a ticker.
+type Bar struct {
+	Ticker    string    `json:"ticker"`
+	Open      float64   `json:"open"`
+	High      float64   `json:"high"`
+	Low       float64   `json:"low"`
+	Close     float64   `json:"close"`
+	Volume    int64     `json:"volume"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Data frame types sent to subscribers
+const (
+	TypeQuote = "quote"
+	TypeTrade = "trade"
+	TypeBar   = "bar"
+	TypeError = "error"
+)
+
+// Control frame actions sent by clients
+const (
+	ActionSubscribe   = "subscribe"
+	ActionUnsubscribe = "unsubscribe"
+)
+
+// ControlMessage is a client-to-server frame requesting a subscription
+// change. Tickers is kept as the quote-stream field for backward
+// compatibility with existing clients; Trades and Bars request the
+// corresponding stream for their own ticker lists.
+type ControlMessage struct {
+	Action  string   `json:"action"`
+	Tickers []string `json:"tickers"`
+	Trades  []string `json:"trades,omitempty"`
+	Bars    []string `json:"bars,omitempty"`
+}
+
+// DataMessage is a server-to-client frame carrying a quote/trade/bar update
+// or error.
+type DataMessage struct {
+	Type  string `json:"type"`
+	Quote *Quote `json:"quote,omitempty"`
+	Trade *Trade `json:"trade,omitempty"`
+	Bar   *Bar   `json:"bar,omitempty"`
+	Error string `json:"error,omitempty"`
+}
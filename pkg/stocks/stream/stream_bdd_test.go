@@ -0,0 +1,181 @@
+package stream_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/f00b455/blank-go/pkg/stocks/stream"
+)
+
+type streamFeatureContext struct {
+	upstream *fakeUpstream
+	hub      *stream.Hub
+	clients  map[string]stream.StreamClient
+	quotes   map[string]<-chan stream.Quote
+	last     map[string]stream.Quote
+}
+
+func (ctx *streamFeatureContext) reset() {
+	ctx.upstream = newFakeUpstream()
+	ctx.hub = stream.NewHub(ctx.upstream)
+	ctx.clients = make(map[string]stream.StreamClient)
+	ctx.quotes = make(map[string]<-chan stream.Quote)
+	ctx.last = make(map[string]stream.Quote)
+}
+
+func (ctx *streamFeatureContext) aMockUpstreamQuoteProvider() error {
+	ctx.reset()
+	return nil
+}
+
+func (ctx *streamFeatureContext) newClient(name string) error {
+	client := ctx.hub.NewSession()
+	quotes, err := client.Subscribe(nil)
+	if err != nil {
+		return err
+	}
+	ctx.clients[name] = client
+	ctx.quotes[name] = quotes
+	return nil
+}
+
+func (ctx *streamFeatureContext) aClientSubscribesToTicker(ticker string) error {
+	if err := ctx.newClient("default"); err != nil {
+		return err
+	}
+	_, err := ctx.clients["default"].Subscribe([]string{ticker})
+	return err
+}
+
+func (ctx *streamFeatureContext) aClientSubscribedToTicker(ticker string) error {
+	return ctx.aClientSubscribesToTicker(ticker)
+}
+
+func (ctx *streamFeatureContext) aSecondClientSubscribesToTicker(ticker string) error {
+	if err := ctx.newClient("second"); err != nil {
+		return err
+	}
+	_, err := ctx.clients["second"].Subscribe([]string{ticker})
+	return err
+}
+
+func (ctx *streamFeatureContext) theUpstreamPublishesAQuoteForAtPrice(ticker string, price float64) error {
+	ctx.upstream.push(stream.Quote{Ticker: ticker, Price: price})
+	return nil
+}
+
+func (ctx *streamFeatureContext) theClientShouldReceiveAQuoteForAtPrice(ticker string, price float64) error {
+	select {
+	case q := <-ctx.quotes["default"]:
+		if q.Ticker != ticker || q.Price != price {
+			return fmt.Errorf("expected quote %s@%v, got %s@%v", ticker, price, q.Ticker, q.Price)
+		}
+		return nil
+	case <-time.After(time.Second):
+		return fmt.Errorf("timed out waiting for quote")
+	}
+}
+
+func (ctx *streamFeatureContext) theClientUnsubscribesFromTicker(ticker string) error {
+	return ctx.clients["default"].Unsubscribe([]string{ticker})
+}
+
+func (ctx *streamFeatureContext) theClientShouldNotReceiveAnyFurtherQuotes() error {
+	select {
+	case q, ok := <-ctx.quotes["default"]:
+		if ok {
+			return fmt.Errorf("did not expect a quote, got %v", q)
+		}
+		return nil
+	case <-time.After(200 * time.Millisecond):
+		return nil
+	}
+}
+
+func (ctx *streamFeatureContext) theUpstreamShouldHaveBeenSubscribedToOnlyOnce(ticker string) error {
+	count := 0
+	ctx.upstream.mu.Lock()
+	for _, t := range ctx.upstream.subscribed {
+		if t == ticker {
+			count++
+		}
+	}
+	ctx.upstream.mu.Unlock()
+
+	if count != 1 {
+		return fmt.Errorf("expected exactly one upstream subscription for %s, got %d", ticker, count)
+	}
+	return nil
+}
+
+func (ctx *streamFeatureContext) theUpstreamConnectionDrops() error {
+	ctx.upstream.dropConnection()
+	return nil
+}
+
+func (ctx *streamFeatureContext) theHubShouldReconnectToTheUpstream() error {
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		ctx.upstream.mu.Lock()
+		calls := ctx.upstream.connectCalls
+		ctx.upstream.mu.Unlock()
+		if calls >= 1 {
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return fmt.Errorf("hub did not reconnect in time")
+}
+
+func (ctx *streamFeatureContext) theUpstreamShouldBeResubscribedTo(ticker string) error {
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		count := 0
+		ctx.upstream.mu.Lock()
+		for _, t := range ctx.upstream.subscribed {
+			if t == ticker {
+				count++
+			}
+		}
+		ctx.upstream.mu.Unlock()
+		if count >= 2 {
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return fmt.Errorf("expected %s to be re-subscribed after reconnect", ticker)
+}
+
+func InitializeStreamScenario(ctx *godog.ScenarioContext) {
+	featureCtx := &streamFeatureContext{}
+
+	ctx.Step(`^a mock upstream quote provider$`, featureCtx.aMockUpstreamQuoteProvider)
+	ctx.Step(`^a client subscribes to ticker "([^"]*)"$`, featureCtx.aClientSubscribesToTicker)
+	ctx.Step(`^a client subscribed to ticker "([^"]*)"$`, featureCtx.aClientSubscribedToTicker)
+	ctx.Step(`^a second client subscribes to ticker "([^"]*)"$`, featureCtx.aSecondClientSubscribesToTicker)
+	ctx.Step(`^the upstream publishes a quote for "([^"]*)" at price ([\d.]+)$`, featureCtx.theUpstreamPublishesAQuoteForAtPrice)
+	ctx.Step(`^the client should receive a quote for "([^"]*)" at price ([\d.]+)$`, featureCtx.theClientShouldReceiveAQuoteForAtPrice)
+	ctx.Step(`^the client unsubscribes from ticker "([^"]*)"$`, featureCtx.theClientUnsubscribesFromTicker)
+	ctx.Step(`^the client should not receive any further quotes$`, featureCtx.theClientShouldNotReceiveAnyFurtherQuotes)
+	ctx.Step(`^the upstream should have been subscribed to "([^"]*)" only once$`, featureCtx.theUpstreamShouldHaveBeenSubscribedToOnlyOnce)
+	ctx.Step(`^the upstream connection drops$`, featureCtx.theUpstreamConnectionDrops)
+	ctx.Step(`^the hub should reconnect to the upstream$`, featureCtx.theHubShouldReconnectToTheUpstream)
+	ctx.Step(`^the upstream should be re-subscribed to "([^"]*)"$`, featureCtx.theUpstreamShouldBeResubscribedTo)
+}
+
+func TestStreamFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		ScenarioInitializer: InitializeStreamScenario,
+		Options: &godog.Options{
+			Format:   "pretty",
+			Paths:    []string{"../../../features/stocks-stream.feature"},
+			TestingT: t,
+		},
+	}
+
+	if suite.Run() != 0 {
+		t.Fatal("non-zero status returned, failed to run feature tests")
+	}
+}
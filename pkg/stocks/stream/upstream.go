@@ -0,0 +1,29 @@
+package stream
+
+// Upstream is the single shared connection the Hub multiplexes across
+// subscribers. Implementations talk to a real market-data provider (Yahoo,
+// Alpaca, ...); tests supply a fake.
+type Upstream interface {
+	// Connect establishes the upstream connection.
+	Connect() error
+	// Subscribe requests updates for the given tickers, regardless of which
+	// of Quotes/Trades/Bars the caller ends up reading from - a ticker is
+	// subscribed to once upstream no matter how many of the three streams a
+	// session wants out of it.
+	Subscribe(tickers []string) error
+	// Unsubscribe stops updates for the given tickers.
+	Unsubscribe(tickers []string) error
+	// Quotes returns the channel quotes are delivered on. It is closed when
+	// the upstream connection is lost, signalling the Hub to reconnect.
+	Quotes() <-chan Quote
+	// Trades returns the channel trades are delivered on, closed alongside
+	// Quotes on disconnect. A provider with no distinct trade feed (e.g.
+	// YahooUpstream) returns a channel that is never written to.
+	Trades() <-chan Trade
+	// Bars returns the channel minute bars are delivered on, closed
+	// alongside Quotes on disconnect. A provider with no distinct bar feed
+	// returns a channel that is never written to.
+	Bars() <-chan Bar
+	// Close tears down the upstream connection.
+	Close() error
+}
@@ -0,0 +1,173 @@
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	yahooStreamURL  = "wss://streamer.finance.yahoo.com/"
+	pingInterval    = 30 * time.Second
+	writeWaitPeriod = 10 * time.Second
+)
+
+// YahooUpstream implements Upstream over Yahoo Finance's streaming quote
+// WebSocket, modeled on the subscribe/unsubscribe framing used by Yahoo's own
+// web client. Yahoo's feed only emits quote price ticks - it has no separate
+// trade or minute-bar stream - so tradeCh/barCh exist solely to satisfy the
+// Upstream interface and are never written to, the same way weather.Client's
+// GetAlerts returns an empty slice for a provider with no alerts feed.
+type YahooUpstream struct {
+	url string
+
+	mu      sync.Mutex
+	conn    *websocket.Conn
+	quoteCh chan Quote
+	tradeCh chan Trade
+	barCh   chan Bar
+	done    chan struct{}
+}
+
+// NewYahooUpstream creates an Upstream that streams quotes from Yahoo Finance.
+func NewYahooUpstream() *YahooUpstream {
+	return &YahooUpstream{url: yahooStreamURL}
+}
+
+// yahooSubscribeFrame mirrors the control frame Yahoo's streamer expects.
+type yahooSubscribeFrame struct {
+	Subscribe   []string `json:"subscribe,omitempty"`
+	Unsubscribe []string `json:"unsubscribe,omitempty"`
+}
+
+// Connect implements Upstream.
+func (y *YahooUpstream) Connect() error {
+	conn, _, err := websocket.DefaultDialer.Dial(y.url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to yahoo stream: %w", err)
+	}
+
+	y.mu.Lock()
+	y.conn = conn
+	y.quoteCh = make(chan Quote, 64)
+	y.tradeCh = make(chan Trade)
+	y.barCh = make(chan Bar)
+	y.done = make(chan struct{})
+	y.mu.Unlock()
+
+	go y.readLoop(conn, y.quoteCh, y.done)
+	go y.pingLoop(conn, y.done)
+
+	return nil
+}
+
+// readLoop decodes incoming quote frames and forwards them to quoteCh until
+// the connection fails, at which point quoteCh (and tradeCh/barCh, which are
+// never otherwise written to) are closed so the Hub knows to reconnect.
+func (y *YahooUpstream) readLoop(conn *websocket.Conn, quoteCh chan Quote, done chan struct{}) {
+	defer close(quoteCh)
+	defer func() {
+		y.mu.Lock()
+		close(y.tradeCh)
+		close(y.barCh)
+		y.mu.Unlock()
+	}()
+	defer close(done)
+
+	for {
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var quote Quote
+		if err := json.Unmarshal(raw, &quote); err != nil {
+			continue
+		}
+		quote.Timestamp = time.Now()
+
+		select {
+		case quoteCh <- quote:
+		case <-done:
+			return
+		}
+	}
+}
+
+// pingLoop sends periodic WebSocket pings to keep the upstream connection
+// alive and detect silently-dropped connections quickly.
+func (y *YahooUpstream) pingLoop(conn *websocket.Conn, done chan struct{}) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			_ = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWaitPeriod))
+		}
+	}
+}
+
+// Subscribe implements Upstream.
+func (y *YahooUpstream) Subscribe(tickers []string) error {
+	return y.send(yahooSubscribeFrame{Subscribe: tickers})
+}
+
+// Unsubscribe implements Upstream.
+func (y *YahooUpstream) Unsubscribe(tickers []string) error {
+	return y.send(yahooSubscribeFrame{Unsubscribe: tickers})
+}
+
+func (y *YahooUpstream) send(frame yahooSubscribeFrame) error {
+	y.mu.Lock()
+	conn := y.conn
+	y.mu.Unlock()
+
+	if conn == nil {
+		return fmt.Errorf("upstream not connected")
+	}
+
+	return conn.WriteJSON(frame)
+}
+
+// Quotes implements Upstream.
+func (y *YahooUpstream) Quotes() <-chan Quote {
+	y.mu.Lock()
+	defer y.mu.Unlock()
+	return y.quoteCh
+}
+
+// Trades implements Upstream. Yahoo's feed has no distinct trade stream, so
+// the returned channel is never written to (see the YahooUpstream doc
+// comment).
+func (y *YahooUpstream) Trades() <-chan Trade {
+	y.mu.Lock()
+	defer y.mu.Unlock()
+	return y.tradeCh
+}
+
+// Bars implements Upstream. Yahoo's feed has no distinct minute-bar stream,
+// so the returned channel is never written to (see the YahooUpstream doc
+// comment).
+func (y *YahooUpstream) Bars() <-chan Bar {
+	y.mu.Lock()
+	defer y.mu.Unlock()
+	return y.barCh
+}
+
+// Close implements Upstream.
+func (y *YahooUpstream) Close() error {
+	y.mu.Lock()
+	conn := y.conn
+	y.mu.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+	return conn.Close()
+}
@@ -0,0 +1,290 @@
+package stocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/clock"
+	"github.com/f00b455/blank-go/pkg/stocks/stream"
+)
+
+const (
+	// watcherBufferSize bounds each watcher's delivery channel. A consumer
+	// that doesn't drain fast enough is dropped with an explicit
+	// StockEventLagging event rather than letting it block every other
+	// watcher of the same ticker.
+	watcherBufferSize = 16
+	// watcherRingBufferSize bounds how many recent events a ticker retains,
+	// so a newly-subscribed watcher can be seeded with the latest summary
+	// before it starts receiving live deltas.
+	watcherRingBufferSize = 8
+	// watcherPollInterval is how often the background poller refreshes a
+	// watched ticker.
+	watcherPollInterval = 10 * time.Second
+)
+
+// ErrWatcherLagging is the error carried by a StockEventLagging event: the
+// watcher's buffer filled up and it was dropped to protect every other
+// subscriber of the same ticker.
+var ErrWatcherLagging = errors.New("watcher lagging: buffer full, dropping slow consumer")
+
+// watcher is one Service.Watch subscriber.
+type watcher struct {
+	ch      chan StockEvent
+	tickers map[string]struct{}
+}
+
+// tickerWatch tracks every subscriber of one ticker, the background poller
+// refreshing it, and a small ring buffer of its recent events.
+type tickerWatch struct {
+	subscribers map[*watcher]struct{}
+	ring        []StockEvent
+	cancel      context.CancelFunc
+}
+
+// watcherHub fans a single background poller per ticker out to every
+// Service.Watch subscriber of that ticker, so N subscribers to the same
+// ticker share one upstream fetch instead of each polling independently.
+// It is notified of changes via stockCache.set's onChange hook, so
+// subscribers only see updates where the price or volume actually moved.
+type watcherHub struct {
+	client StocksClient
+	cache  *stockCache
+
+	// streamHub, if set via Service.SetStreamHub, lets poll feed the cache
+	// from the live WebSocket quote stream (see pkg/stocks/stream) in
+	// addition to its own polling interval, so a ticker already streaming
+	// to a WebSocket client keeps Service.GetSummary sub-second-fresh
+	// instead of only as fresh as the last poll.
+	streamHub *stream.Hub
+
+	clock clock.Clock
+
+	mu      sync.Mutex
+	tickers map[string]*tickerWatch
+}
+
+// newWatcherHub creates a watcherHub that polls via client and shares
+// refreshed summaries with cache, timestamping them via clk.
+func newWatcherHub(client StocksClient, cache *stockCache, clk clock.Clock) *watcherHub {
+	return &watcherHub{
+		client:  client,
+		cache:   cache,
+		clock:   clk,
+		tickers: make(map[string]*tickerWatch),
+	}
+}
+
+// subscribe registers a new watcher for tickers, starting a background
+// poller for any ticker that doesn't already have one, and seeds the
+// watcher with each ticker's recent events before returning.
+func (h *watcherHub) subscribe(tickers []string) *watcher {
+	w := &watcher{
+		ch:      make(chan StockEvent, watcherBufferSize),
+		tickers: make(map[string]struct{}, len(tickers)),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, ticker := range tickers {
+		w.tickers[ticker] = struct{}{}
+
+		tw, exists := h.tickers[ticker]
+		if !exists {
+			ctx, cancel := context.WithCancel(context.Background())
+			tw = &tickerWatch{
+				subscribers: make(map[*watcher]struct{}),
+				cancel:      cancel,
+			}
+			h.tickers[ticker] = tw
+			go h.poll(ctx, ticker)
+		}
+
+		for _, event := range tw.ring {
+			h.deliver(w, event)
+		}
+		tw.subscribers[w] = struct{}{}
+	}
+
+	return w
+}
+
+// closeWatcher unsubscribes w from every ticker it watches, stopping any
+// poller left without subscribers, and closes its channel.
+func (h *watcherHub) closeWatcher(w *watcher) {
+	h.mu.Lock()
+	for ticker := range w.tickers {
+		tw, ok := h.tickers[ticker]
+		if !ok {
+			continue
+		}
+		delete(tw.subscribers, w)
+		if len(tw.subscribers) == 0 {
+			tw.cancel()
+			delete(h.tickers, ticker)
+		}
+	}
+	h.mu.Unlock()
+
+	close(w.ch)
+}
+
+// poll refreshes ticker immediately and then on every watcherPollInterval,
+// until ctx is cancelled because its last subscriber left. If a streamHub
+// is set, it also opens a live quote subscription for ticker and feeds
+// every tick straight into the cache via feedQuote, so the poll interval
+// becomes a fallback rather than the only source of freshness.
+func (h *watcherHub) poll(ctx context.Context, ticker string) {
+	h.refresh(ctx, ticker)
+
+	var quotes <-chan stream.Quote
+	if h.streamHub != nil {
+		session := h.streamHub.NewSession()
+		defer func() { _ = session.Close() }()
+
+		if ch, err := session.Subscribe([]string{ticker}); err == nil {
+			quotes = ch
+		}
+	}
+
+	t := time.NewTicker(watcherPollInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			h.refresh(ctx, ticker)
+		case q, ok := <-quotes:
+			if !ok {
+				quotes = nil
+				continue
+			}
+			h.feedQuote(ticker, q)
+		}
+	}
+}
+
+// refresh fetches ticker's latest quote and stores it via the shared
+// stockCache. stockCache.set only calls back into publish when the price or
+// volume actually changed, so identical refreshes don't spam subscribers.
+func (h *watcherHub) refresh(ctx context.Context, ticker string) {
+	quote, err := h.client.GetQuote(ctx, ticker)
+	if err != nil {
+		return
+	}
+
+	summary := convertQuoteToSummary(quote, SessionRegular, h.clock)
+	h.cache.set(context.Background(), ticker, SessionRegular, summary, ttlForMarketState(summary.MarketState), "watch")
+}
+
+// feedQuote updates ticker's cached regular-session summary from a live tick
+// received over the stream, so GetSummary/GetBatchSummary reflect price
+// moves as they happen instead of waiting for the next refresh. It starts
+// from whatever summary is already cached (falling back to a bare one keyed
+// only by ticker if nothing is cached yet) and overlays just the fields a
+// quote tick actually carries, leaving fields only a full upstream fetch
+// populates (name, open/high/low, currency, ...) untouched.
+func (h *watcherHub) feedQuote(ticker string, q stream.Quote) {
+	cached, ok := h.cache.get(context.Background(), ticker, SessionRegular)
+	var summary StockSummary
+	if ok {
+		summary = *cached
+	} else {
+		summary = StockSummary{Ticker: ticker, MarketState: MarketStateRegular, Source: string(ProviderYahoo)}
+	}
+
+	summary.CurrentPrice = q.Price
+	summary.Change = q.Change
+	summary.ChangePercent = q.ChangePercent
+	if q.Volume > 0 {
+		summary.Volume = q.Volume
+	}
+	summary.UpdatedAt = q.Timestamp
+
+	h.cache.set(context.Background(), ticker, SessionRegular, &summary, ttlForMarketState(summary.MarketState), "stream")
+}
+
+// publish fans a changed summary for ticker out to every subscriber. It is
+// wired up as stockCache's onChange hook and ignores sessions other than
+// SessionRegular, since watchers only care about live regular-session
+// prices.
+func (h *watcherHub) publish(ticker, session string, summary *StockSummary) {
+	if session != SessionRegular {
+		return
+	}
+
+	event := StockEvent{Type: StockEventSummary, Ticker: ticker, Summary: summary}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	tw, ok := h.tickers[ticker]
+	if !ok {
+		return
+	}
+
+	tw.ring = append(tw.ring, event)
+	if len(tw.ring) > watcherRingBufferSize {
+		tw.ring = tw.ring[len(tw.ring)-watcherRingBufferSize:]
+	}
+
+	for w := range tw.subscribers {
+		h.deliver(w, event)
+	}
+}
+
+// deliver sends event to w without blocking. If w's buffer is full, the
+// oldest buffered event is dropped to make room for an explicit
+// StockEventLagging event, so a slow consumer learns it missed updates
+// instead of silently falling behind.
+func (h *watcherHub) deliver(w *watcher, event StockEvent) {
+	select {
+	case w.ch <- event:
+		return
+	default:
+	}
+
+	select {
+	case <-w.ch:
+	default:
+	}
+
+	select {
+	case w.ch <- StockEvent{Type: StockEventLagging, Ticker: event.Ticker, Error: ErrWatcherLagging.Error()}:
+	default:
+	}
+}
+
+// Watch multiplexes live updates for tickers through a single shared
+// background poller per ticker, modeled on the Kubernetes apiserver's watch
+// cache: N subscribers to the same ticker share one upstream fetch instead
+// of each hitting Yahoo independently. The returned channel is closed once
+// ctx is done.
+func (s *Service) Watch(ctx context.Context, tickers []string) (<-chan StockEvent, error) {
+	normalized := make([]string, 0, len(tickers))
+	for _, ticker := range tickers {
+		ticker = strings.ToUpper(strings.TrimSpace(ticker))
+		if ticker != "" {
+			normalized = append(normalized, ticker)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, fmt.Errorf("at least one ticker is required")
+	}
+
+	w := s.watchHub.subscribe(normalized)
+
+	go func() {
+		<-ctx.Done()
+		s.watchHub.closeWatcher(w)
+	}()
+
+	return w.ch, nil
+}
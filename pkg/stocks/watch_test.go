@@ -0,0 +1,181 @@
+package stocks
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/clock"
+	"github.com/f00b455/blank-go/pkg/stocks/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWatchClient is a test double for StocksClient that returns quotes
+// from a map the test can mutate between refreshes.
+type fakeWatchClient struct {
+	mu     sync.Mutex
+	quotes map[string]*YahooQuote
+	calls  int
+}
+
+func newFakeWatchClient() *fakeWatchClient {
+	return &fakeWatchClient{quotes: make(map[string]*YahooQuote)}
+}
+
+func (f *fakeWatchClient) set(ticker string, price float64, volume int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.quotes[ticker] = &YahooQuote{Symbol: ticker, RegularMarketPrice: price, RegularMarketVolume: volume}
+}
+
+func (f *fakeWatchClient) GetQuote(ctx context.Context, ticker string) (*YahooQuote, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	return f.quotes[ticker], nil
+}
+
+func (f *fakeWatchClient) GetQuotes(ctx context.Context, tickers []string) (map[string]*YahooQuote, error) {
+	result := make(map[string]*YahooQuote, len(tickers))
+	for _, ticker := range tickers {
+		quote, err := f.GetQuote(ctx, ticker)
+		if err != nil {
+			return nil, err
+		}
+		result[ticker] = quote
+	}
+	return result, nil
+}
+
+func (f *fakeWatchClient) GetBars(ctx context.Context, ticker string, opts BarsRequest) ([]Bar, error) {
+	return nil, nil
+}
+
+func TestService_Watch_DeliversInitialRefresh(t *testing.T) {
+	client := newFakeWatchClient()
+	client.set("AAPL", 100, 1000)
+	service := NewServiceWithCache(client, cache.NewLRUCache(0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := service.Watch(ctx, []string{"aapl"})
+	require.NoError(t, err)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, StockEventSummary, event.Type)
+		assert.Equal(t, "AAPL", event.Ticker)
+		require.NotNil(t, event.Summary)
+		assert.Equal(t, 100.0, event.Summary.CurrentPrice)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for initial refresh event")
+	}
+}
+
+func TestService_Watch_RequiresAtLeastOneTicker(t *testing.T) {
+	service := NewServiceWithCache(newFakeWatchClient(), cache.NewLRUCache(0))
+
+	_, err := service.Watch(context.Background(), []string{" ", ""})
+	require.Error(t, err)
+}
+
+func TestService_Watch_NewSubscriberSeededFromRing(t *testing.T) {
+	client := newFakeWatchClient()
+	client.set("AAPL", 100, 1000)
+	service := NewServiceWithCache(client, cache.NewLRUCache(0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first, err := service.Watch(ctx, []string{"AAPL"})
+	require.NoError(t, err)
+	<-first // drain the initial refresh so the ring buffer holds it
+
+	second, err := service.Watch(ctx, []string{"AAPL"})
+	require.NoError(t, err)
+
+	select {
+	case event := <-second:
+		assert.Equal(t, "AAPL", event.Ticker)
+		assert.Equal(t, 100.0, event.Summary.CurrentPrice)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for seeded event")
+	}
+}
+
+func TestWatcherHub_Publish_IgnoresNonRegularSession(t *testing.T) {
+	hub := newWatcherHub(newFakeWatchClient(), newStockCache(cache.NewLRUCache(0), clock.RealClock{}), clock.RealClock{})
+
+	w := hub.subscribe([]string{"AAPL"})
+	hub.publish("AAPL", SessionPre, &StockSummary{Ticker: "AAPL"})
+
+	select {
+	case event := <-w.ch:
+		t.Fatalf("expected no event for a non-regular session, got %+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestWatcherHub_Deliver_LaggingWatcherGetsExplicitEvent(t *testing.T) {
+	hub := newWatcherHub(newFakeWatchClient(), newStockCache(cache.NewLRUCache(0), clock.RealClock{}), clock.RealClock{})
+
+	w := hub.subscribe([]string{"AAPL"})
+	for i := 0; i < watcherBufferSize; i++ {
+		hub.publish("AAPL", SessionRegular, &StockSummary{Ticker: "AAPL", CurrentPrice: float64(i)})
+	}
+
+	// The buffer is now full; the next publish must drop in an explicit
+	// lagging event rather than blocking or silently dropping.
+	hub.publish("AAPL", SessionRegular, &StockSummary{Ticker: "AAPL", CurrentPrice: 999})
+
+	var sawLagging bool
+	for i := 0; i < watcherBufferSize; i++ {
+		event := <-w.ch
+		if event.Type == StockEventLagging {
+			sawLagging = true
+			assert.Equal(t, ErrWatcherLagging.Error(), event.Error)
+		}
+	}
+	assert.True(t, sawLagging, "expected a lagging event among the buffered events")
+}
+
+func TestService_Watch_ContextCancelClosesChannel(t *testing.T) {
+	client := newFakeWatchClient()
+	client.set("AAPL", 100, 1000)
+	service := NewServiceWithCache(client, cache.NewLRUCache(0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	events, err := service.Watch(ctx, []string{"AAPL"})
+	require.NoError(t, err)
+	<-events // drain the initial refresh
+
+	cancel()
+
+	require.Eventually(t, func() bool {
+		select {
+		case _, ok := <-events:
+			return !ok
+		default:
+			return false
+		}
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestStockCache_Set_OnlyNotifiesOnActualChange(t *testing.T) {
+	var notifications int
+	c := newStockCache(cache.NewLRUCache(0), clock.RealClock{})
+	c.onChange = func(ticker, session string, summary *StockSummary) {
+		notifications++
+	}
+
+	ctx := context.Background()
+	c.set(ctx, "AAPL", SessionRegular, &StockSummary{Ticker: "AAPL", CurrentPrice: 100}, time.Minute, "yahoo")
+	c.set(ctx, "AAPL", SessionRegular, &StockSummary{Ticker: "AAPL", CurrentPrice: 100}, time.Minute, "yahoo")
+	c.set(ctx, "AAPL", SessionRegular, &StockSummary{Ticker: "AAPL", CurrentPrice: 101}, time.Minute, "yahoo")
+
+	assert.Equal(t, 2, notifications)
+}
@@ -0,0 +1,207 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// BulkOpType enumerates the operations BulkApply accepts for a single task.
+type BulkOpType string
+
+const (
+	BulkOpCreate BulkOpType = "create"
+	BulkOpUpdate BulkOpType = "update"
+	BulkOpDelete BulkOpType = "delete"
+)
+
+// BulkOp is one entry of a POST /api/v1/tasks/bulk request. Data carries
+// the create/update payload as raw JSON so it can be decoded against
+// CreateTaskRequest or UpdateTaskRequest depending on Op, rather than
+// forcing every bulk entry into one fixed shape.
+type BulkOp struct {
+	Op   BulkOpType      `json:"op"`
+	ID   string          `json:"id,omitempty"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// BulkResult reports the outcome of the BulkOp submitted at the same Index,
+// so a batch's partial failures are visible per-item instead of only as a
+// single aggregate error.
+type BulkResult struct {
+	Index  int    `json:"index"`
+	Status int    `json:"status"`
+	ID     string `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// statusRolledBack is reported for any BulkOp whose effect was discarded
+// because the atomic batch it belonged to failed overall.
+const statusRolledBack = http.StatusFailedDependency
+
+// validate checks that op carries the fields its Op requires, independent
+// of any repository state.
+func (op BulkOp) validate() error {
+	switch op.Op {
+	case BulkOpCreate:
+		if len(op.Data) == 0 {
+			return fmt.Errorf("data is required for create")
+		}
+	case BulkOpUpdate:
+		if op.ID == "" {
+			return fmt.Errorf("id is required for update")
+		}
+		if len(op.Data) == 0 {
+			return fmt.Errorf("data is required for update")
+		}
+	case BulkOpDelete:
+		if op.ID == "" {
+			return fmt.Errorf("id is required for delete")
+		}
+	default:
+		return fmt.Errorf("unknown op %q", op.Op)
+	}
+	return nil
+}
+
+// BulkApply validates every operation in ops up front, then executes the
+// ones that passed validation in order. In atomic mode all of them run
+// inside a single Repository.Transaction: if any operation fails, none of
+// the batch's changes are kept, and every operation not individually at
+// fault is reported with statusRolledBack. In non-atomic mode each
+// operation is applied independently, so one operation's failure doesn't
+// affect the others.
+func (s *Service) BulkApply(ctx context.Context, ops []BulkOp, atomic bool) ([]BulkResult, error) {
+	results := make([]BulkResult, len(ops))
+	hasInvalid := false
+
+	for i, op := range ops {
+		if err := op.validate(); err != nil {
+			results[i] = BulkResult{Index: i, Status: http.StatusBadRequest, Error: err.Error()}
+			hasInvalid = true
+		}
+	}
+
+	if atomic && hasInvalid {
+		for i := range results {
+			if results[i].Status == 0 {
+				results[i] = BulkResult{Index: i, Status: statusRolledBack, Error: "not applied: batch failed validation"}
+			}
+		}
+		return results, fmt.Errorf("bulk batch failed validation")
+	}
+
+	// applyAtomic stops at the first failed operation so Repository.Transaction
+	// rolls back the whole batch.
+	applyAtomic := func(repo Repository) error {
+		for i, op := range ops {
+			if results[i].Status != 0 {
+				continue // already failed validation
+			}
+
+			result, err := s.applyBulkOp(ctx, repo, i, op)
+			results[i] = result
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if atomic {
+		if err := s.repo.Transaction(ctx, applyAtomic); err != nil {
+			for i := range results {
+				if status := results[i].Status; status == 0 || (status >= 200 && status < 300) {
+					results[i] = BulkResult{Index: i, Status: statusRolledBack, Error: "rolled back due to a failed operation earlier in the batch"}
+				}
+			}
+			return results, err
+		}
+		return results, nil
+	}
+
+	// Non-atomic mode: every operation runs independently, so one
+	// operation's failure must not stop the rest from being attempted.
+	hasFailure := false
+	for i, op := range ops {
+		if results[i].Status != 0 {
+			hasFailure = true // already failed validation
+			continue
+		}
+
+		result, err := s.applyBulkOp(ctx, s.repo, i, op)
+		results[i] = result
+		if err != nil {
+			hasFailure = true
+		}
+	}
+	if hasFailure {
+		return results, fmt.Errorf("bulk batch had one or more failed operations")
+	}
+	return results, nil
+}
+
+// applyBulkOp executes a single already-validated BulkOp against repo,
+// translating errors into the HTTP status codes the equivalent single-item
+// endpoint (CreateTask/UpdateTask/DeleteTask) would have used.
+func (s *Service) applyBulkOp(ctx context.Context, repo Repository, index int, op BulkOp) (BulkResult, error) {
+	switch op.Op {
+	case BulkOpCreate:
+		var req CreateTaskRequest
+		if err := json.Unmarshal(op.Data, &req); err != nil {
+			return BulkResult{Index: index, Status: http.StatusBadRequest, Error: err.Error()}, err
+		}
+		if err := ValidateCreateRequest(req); err != nil {
+			return BulkResult{Index: index, Status: http.StatusBadRequest, Error: err.Error()}, err
+		}
+
+		created := BuildTaskFromRequest(req, time.Now())
+		if err := repo.Create(ctx, created); err != nil {
+			return BulkResult{Index: index, Status: http.StatusInternalServerError, Error: err.Error()}, err
+		}
+		return BulkResult{Index: index, Status: http.StatusCreated, ID: created.ID}, nil
+
+	case BulkOpUpdate:
+		existing, err := repo.GetByID(ctx, op.ID)
+		if err != nil {
+			return BulkResult{Index: index, Status: statusForRepoErr(err), Error: err.Error()}, err
+		}
+
+		var req UpdateTaskRequest
+		if err := json.Unmarshal(op.Data, &req); err != nil {
+			return BulkResult{Index: index, Status: http.StatusBadRequest, Error: err.Error()}, err
+		}
+
+		updated, err := ApplyUpdate(existing, req, time.Now())
+		if err != nil {
+			return BulkResult{Index: index, Status: http.StatusBadRequest, Error: err.Error()}, err
+		}
+
+		if err := repo.Update(ctx, updated); err != nil {
+			return BulkResult{Index: index, Status: statusForRepoErr(err), Error: err.Error()}, err
+		}
+		return BulkResult{Index: index, Status: http.StatusOK, ID: updated.ID}, nil
+
+	case BulkOpDelete:
+		if err := repo.Delete(ctx, op.ID); err != nil {
+			return BulkResult{Index: index, Status: statusForRepoErr(err), Error: err.Error()}, err
+		}
+		return BulkResult{Index: index, Status: http.StatusOK, ID: op.ID}, nil
+
+	default:
+		err := fmt.Errorf("unknown op %q", op.Op)
+		return BulkResult{Index: index, Status: http.StatusBadRequest, Error: err.Error()}, err
+	}
+}
+
+// statusForRepoErr maps a Repository error to the HTTP status a single-item
+// handler would have returned for it.
+func statusForRepoErr(err error) int {
+	if errors.Is(err, ErrTaskNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,165 @@
+package task
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_BulkApply_MixedOpTypes(t *testing.T) {
+	repo := NewInMemoryRepository()
+	service := NewService(repo)
+
+	existing, err := service.Create(context.Background(), CreateTaskRequest{Title: "Existing task"})
+	require.NoError(t, err)
+
+	toDelete, err := service.Create(context.Background(), CreateTaskRequest{Title: "Will be deleted"})
+	require.NoError(t, err)
+
+	ops := []BulkOp{
+		{Op: BulkOpCreate, Data: []byte(`{"title":"New task"}`)},
+		{Op: BulkOpUpdate, ID: existing.ID, Data: []byte(`{"title":"Updated title"}`)},
+		{Op: BulkOpDelete, ID: toDelete.ID},
+	}
+
+	results, err := service.BulkApply(context.Background(), ops, false)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	assert.Equal(t, http.StatusCreated, results[0].Status)
+	assert.NotEmpty(t, results[0].ID)
+
+	assert.Equal(t, http.StatusOK, results[1].Status)
+	updated, err := service.GetByID(context.Background(), existing.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Updated title", updated.Title)
+
+	assert.Equal(t, http.StatusOK, results[2].Status)
+	_, err = service.GetByID(context.Background(), toDelete.ID)
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+}
+
+func TestService_BulkApply_NonAtomicPerItemErrors(t *testing.T) {
+	repo := NewInMemoryRepository()
+	service := NewService(repo)
+
+	ops := []BulkOp{
+		{Op: BulkOpCreate, Data: []byte(`{"title":"Valid task"}`)},
+		{Op: BulkOpUpdate, ID: "does-not-exist", Data: []byte(`{"title":"Nope"}`)},
+		{Op: BulkOpDelete, ID: ""},
+	}
+
+	results, err := service.BulkApply(context.Background(), ops, false)
+	require.Error(t, err)
+	require.Len(t, results, 3)
+
+	assert.Equal(t, http.StatusCreated, results[0].Status)
+	assert.Empty(t, results[0].Error)
+
+	assert.Equal(t, http.StatusNotFound, results[1].Status)
+	assert.NotEmpty(t, results[1].Error)
+
+	assert.Equal(t, http.StatusBadRequest, results[2].Status)
+	assert.NotEmpty(t, results[2].Error)
+
+	// The valid create must have been applied even though its siblings failed.
+	all, err := repo.GetAll(context.Background(), FilterOptions{})
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+}
+
+func TestService_BulkApply_AtomicRollsBackOnFailure(t *testing.T) {
+	repo := NewInMemoryRepository()
+	service := NewService(repo)
+
+	ops := []BulkOp{
+		{Op: BulkOpCreate, Data: []byte(`{"title":"First task"}`)},
+		{Op: BulkOpUpdate, ID: "does-not-exist", Data: []byte(`{"title":"Nope"}`)},
+	}
+
+	results, err := service.BulkApply(context.Background(), ops, true)
+	require.Error(t, err)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, statusRolledBack, results[0].Status)
+	assert.Equal(t, http.StatusNotFound, results[1].Status)
+
+	all, err := repo.GetAll(context.Background(), FilterOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, all, "the successful create must be rolled back with the rest of the batch")
+}
+
+func TestService_BulkApply_AtomicSucceedsWhenEveryOpSucceeds(t *testing.T) {
+	repo := NewInMemoryRepository()
+	service := NewService(repo)
+
+	ops := []BulkOp{
+		{Op: BulkOpCreate, Data: []byte(`{"title":"Task A"}`)},
+		{Op: BulkOpCreate, Data: []byte(`{"title":"Task B"}`)},
+	}
+
+	results, err := service.BulkApply(context.Background(), ops, true)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, http.StatusCreated, results[0].Status)
+	assert.Equal(t, http.StatusCreated, results[1].Status)
+
+	all, err := repo.GetAll(context.Background(), FilterOptions{})
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+}
+
+func TestService_BulkApply_AtomicValidationFailureSkipsExecution(t *testing.T) {
+	repo := NewInMemoryRepository()
+	service := NewService(repo)
+
+	ops := []BulkOp{
+		{Op: BulkOpCreate, Data: []byte(`{"title":"Would have been created"}`)},
+		{Op: BulkOpUpdate, ID: ""}, // missing id and data
+	}
+
+	results, err := service.BulkApply(context.Background(), ops, true)
+	require.Error(t, err)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, statusRolledBack, results[0].Status)
+	assert.Equal(t, http.StatusBadRequest, results[1].Status)
+
+	all, err := repo.GetAll(context.Background(), FilterOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, all)
+}
+
+func TestInMemoryRepository_Transaction(t *testing.T) {
+	t.Run("commits mutations when fn succeeds", func(t *testing.T) {
+		repo := NewInMemoryRepository()
+
+		err := repo.Transaction(context.Background(), func(tx Repository) error {
+			return tx.Create(context.Background(), &Task{ID: "a", Title: "A"})
+		})
+		require.NoError(t, err)
+
+		tasks, err := repo.GetAll(context.Background(), FilterOptions{})
+		require.NoError(t, err)
+		assert.Len(t, tasks, 1)
+	})
+
+	t.Run("discards mutations when fn fails", func(t *testing.T) {
+		repo := NewInMemoryRepository()
+
+		err := repo.Transaction(context.Background(), func(tx Repository) error {
+			if err := tx.Create(context.Background(), &Task{ID: "a", Title: "A"}); err != nil {
+				return err
+			}
+			return ErrTaskNotFound
+		})
+		require.Error(t, err)
+
+		tasks, err := repo.GetAll(context.Background(), FilterOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, tasks)
+	})
+}
@@ -0,0 +1,18 @@
+// Package task_test holds tests that need to import pkg/task/repotest,
+// which itself imports pkg/task — keeping them in a separate external
+// test package (rather than repository_test.go's package task) avoids an
+// import cycle.
+package task_test
+
+import (
+	"testing"
+
+	"github.com/f00b455/blank-go/pkg/task"
+	"github.com/f00b455/blank-go/pkg/task/repotest"
+)
+
+func TestInMemoryRepository_Contract(t *testing.T) {
+	repotest.Run(t, func() task.Repository {
+		return task.NewInMemoryRepository()
+	})
+}
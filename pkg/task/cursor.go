@@ -0,0 +1,71 @@
+package task
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidCursor is returned by GetAllCursor when a cursor can't be
+// decoded, its signature doesn't match, or it was minted for a different
+// sort_by than the request now uses, so handlers can translate it to a 400
+// invalid_cursor response instead of silently resuming from the wrong
+// position.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// CursorKey is the (sort key, tiebreaker id) tuple a pagination cursor
+// resumes from. SortBy names the FilterOptions.SortBy field the cursor was
+// minted under and LastValue is that field's sortFieldValue for the last
+// row of the page, so GetAllKeyset can resume under any sort_by, not just
+// created_at; LastID breaks ties the same way GetAll's in-memory sort does.
+// Before selects which side of that tuple GetAllKeyset scans: false
+// continues forward past (LastValue, LastID); true scans backward, so the
+// same type serves both the "next" and "prev" cursors.
+type CursorKey struct {
+	SortBy    string `json:"sort_by"`
+	LastValue string `json:"last_value"`
+	LastID    string `json:"last_id"`
+	Before    bool   `json:"before,omitempty"`
+}
+
+// encodeCursor HMAC-signs payload with key and base64-encodes the signed
+// envelope so it can travel as a single opaque query parameter.
+func encodeCursor(key []byte, payload CursorKey) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	envelope := append(signCursor(key, data), data...)
+	return base64.RawURLEncoding.EncodeToString(envelope), nil
+}
+
+// decodeCursor reverses encodeCursor, returning ErrInvalidCursor for
+// anything that doesn't decode to a cursor signed with key, including a
+// cursor forged or tampered with by a client.
+func decodeCursor(key []byte, cursor string) (CursorKey, error) {
+	var payload CursorKey
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(raw) < sha256.Size {
+		return payload, ErrInvalidCursor
+	}
+
+	sig, data := raw[:sha256.Size], raw[sha256.Size:]
+	if !hmac.Equal(sig, signCursor(key, data)) {
+		return payload, ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return payload, ErrInvalidCursor
+	}
+	return payload, nil
+}
+
+func signCursor(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,164 @@
+package task
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// EventType identifies what changed about a task in an Event.
+type EventType string
+
+const (
+	EventCreated EventType = "created"
+	EventUpdated EventType = "updated"
+	EventDeleted EventType = "deleted"
+)
+
+// eventSubscriberBufferSize bounds each subscriber's delivery channel. A
+// consumer that doesn't drain fast enough simply misses events past this
+// point rather than blocking every other subscriber; it can recover
+// buffered history via EventBus.SubscribeFrom on reconnect.
+const eventSubscriberBufferSize = 32
+
+// DefaultEventRingBufferSize is the number of recent events an EventBus
+// retains for replay, used when NewEventBus is given a non-positive size.
+const DefaultEventRingBufferSize = 1024
+
+// Event is one change to a task, published by Service.Create/Update/Delete
+// and delivered to every EventBus subscriber, typically over an SSE stream.
+type Event struct {
+	// ID is the event's position in publish order, used as the SSE frame's
+	// "id:" field and as the cursor for EventBus.SubscribeFrom's replay.
+	// It isn't part of the event's JSON body, which mirrors the wire
+	// format `{"type":...,"task":...,"ts":...}`.
+	ID   uint64    `json:"-"`
+	Type EventType `json:"type"`
+	Task *Task     `json:"task"`
+	Ts   time.Time `json:"ts"`
+}
+
+// EventBus fans task mutation events out to every subscriber and retains a
+// bounded ring buffer of recent events, so a reconnecting SSE client can
+// replay whatever it missed via Last-Event-ID instead of silently losing
+// it.
+type EventBus struct {
+	mu          sync.Mutex
+	subscribers map[chan Event]struct{}
+	ring        []Event
+	ringSize    int
+	nextID      uint64
+}
+
+// NewEventBus creates an EventBus retaining up to ringSize recent events
+// for replay; a non-positive ringSize falls back to
+// DefaultEventRingBufferSize.
+func NewEventBus(ringSize int) *EventBus {
+	if ringSize <= 0 {
+		ringSize = DefaultEventRingBufferSize
+	}
+	return &EventBus{
+		subscribers: make(map[chan Event]struct{}),
+		ringSize:    ringSize,
+	}
+}
+
+// Publish assigns evt the next sequence ID and, if unset, the current
+// time, retains it in the ring buffer, and fans it out to every current
+// subscriber without blocking.
+func (b *EventBus) Publish(evt Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	evt.ID = b.nextID
+	if evt.Ts.IsZero() {
+		evt.Ts = time.Now()
+	}
+
+	b.ring = append(b.ring, evt)
+	if len(b.ring) > b.ringSize {
+		b.ring = b.ring[len(b.ring)-b.ringSize:]
+	}
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- evt:
+		default:
+		}
+	}
+}
+
+// Subscribe registers a new subscriber and returns a channel of events
+// published from this point on. The channel is closed once ctx is done.
+func (b *EventBus) Subscribe(ctx context.Context) <-chan Event {
+	b.mu.Lock()
+	ch := b.registerLocked()
+	b.mu.Unlock()
+
+	b.closeOnDone(ctx, ch)
+	return ch
+}
+
+// SubscribeFrom is like Subscribe, but first replays every ring-buffered
+// event after lastEventID - as parsed from a reconnecting client's
+// Last-Event-ID header - before switching over to live events. A
+// lastEventID of 0 behaves like Subscribe: no replay, since event IDs
+// start at 1.
+func (b *EventBus) SubscribeFrom(ctx context.Context, lastEventID uint64) <-chan Event {
+	b.mu.Lock()
+	ch := b.registerLocked()
+	var replay []Event
+	for _, evt := range b.ring {
+		if evt.ID > lastEventID {
+			replay = append(replay, evt)
+		}
+	}
+	b.mu.Unlock()
+
+	b.closeOnDone(ctx, ch)
+	if len(replay) == 0 {
+		return ch
+	}
+
+	out := make(chan Event, eventSubscriberBufferSize+len(replay))
+	go func() {
+		defer close(out)
+		for _, evt := range replay {
+			select {
+			case out <- evt:
+			case <-ctx.Done():
+				return
+			}
+		}
+		for evt := range ch {
+			select {
+			case out <- evt:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// registerLocked adds a new subscriber channel under b.mu, which must
+// already be held, so a caller can atomically register it and snapshot
+// b.ring without missing or double-delivering an event published in
+// between.
+func (b *EventBus) registerLocked() chan Event {
+	ch := make(chan Event, eventSubscriberBufferSize)
+	b.subscribers[ch] = struct{}{}
+	return ch
+}
+
+// closeOnDone unregisters ch and closes it once ctx is done.
+func (b *EventBus) closeOnDone(ctx context.Context, ch chan Event) {
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		delete(b.subscribers, ch)
+		b.mu.Unlock()
+		close(ch)
+	}()
+}
@@ -0,0 +1,423 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExecutionStatus represents the current state of an Execution.
+type ExecutionStatus string
+
+const (
+	// ExecutionQueued is an execution that has been accepted but hasn't
+	// started running yet.
+	ExecutionQueued ExecutionStatus = "queued"
+	// ExecutionRunning is an execution currently being driven by its
+	// Executor.
+	ExecutionRunning ExecutionStatus = "running"
+	// ExecutionSucceeded is an execution that completed without error.
+	ExecutionSucceeded ExecutionStatus = "succeeded"
+	// ExecutionFailed is an execution that exhausted its retries without
+	// succeeding.
+	ExecutionFailed ExecutionStatus = "failed"
+	// ExecutionStopped is an execution canceled via ExecutionManager.Stop
+	// before it finished on its own.
+	ExecutionStopped ExecutionStatus = "stopped"
+)
+
+// ExecutionTrigger identifies what caused an Execution to run.
+type ExecutionTrigger string
+
+const (
+	// TriggerManual is an execution started via POST /tasks/:id/executions.
+	TriggerManual ExecutionTrigger = "manual"
+	// TriggerScheduled is an execution enqueued by Scheduler against a
+	// Task's Schedule field.
+	TriggerScheduled ExecutionTrigger = "scheduled"
+	// TriggerEvent is an execution enqueued in response to a task mutation
+	// event (see EventBus), rather than a manual call or the Scheduler.
+	TriggerEvent ExecutionTrigger = "event"
+)
+
+// ErrExecutionNotFound is returned when an execution is not found.
+var ErrExecutionNotFound = errors.New("execution not found")
+
+// ErrExecutionNotCancelable is returned by ExecutionManager.Stop when the
+// execution has already reached a terminal status (succeeded, failed, or
+// stopped).
+var ErrExecutionNotCancelable = errors.New("execution is not running")
+
+// Execution records one run of a Task's Executor, started manually, by
+// Scheduler against the task's Schedule, or by an event trigger.
+type Execution struct {
+	ID        string           `json:"id"`
+	TaskID    string           `json:"task_id"`
+	Status    ExecutionStatus  `json:"status"`
+	Trigger   ExecutionTrigger `json:"trigger"`
+	Attempt   int              `json:"attempt"`
+	StartTime time.Time        `json:"start_time"`
+	EndTime   *time.Time       `json:"end_time,omitempty"`
+	Error     string           `json:"error,omitempty"`
+}
+
+// ExecutionFilter narrows ExecutionRepository.List to executions matching
+// TaskID/Status/Trigger (any left zero-valued matches everything),
+// paginated by Page (1-indexed) and PageSize.
+type ExecutionFilter struct {
+	TaskID   string
+	Status   ExecutionStatus
+	Trigger  ExecutionTrigger
+	Page     int
+	PageSize int
+}
+
+// ExecutionRepository persists Executions. InMemoryExecutionRepository is
+// the only implementation today, mirroring InMemoryRepository's role for
+// Task itself.
+type ExecutionRepository interface {
+	Create(ctx context.Context, exec *Execution) error
+	Get(ctx context.Context, id string) (*Execution, error)
+	Update(ctx context.Context, exec *Execution) error
+	List(ctx context.Context, filter ExecutionFilter) ([]*Execution, int, error)
+}
+
+// InMemoryExecutionRepository implements ExecutionRepository using
+// in-memory storage.
+type InMemoryExecutionRepository struct {
+	mu         sync.RWMutex
+	executions map[string]*Execution
+}
+
+// NewInMemoryExecutionRepository creates a new in-memory execution
+// repository.
+func NewInMemoryExecutionRepository() *InMemoryExecutionRepository {
+	return &InMemoryExecutionRepository{
+		executions: make(map[string]*Execution),
+	}
+}
+
+// Create adds a new execution to the repository.
+func (r *InMemoryExecutionRepository) Create(ctx context.Context, exec *Execution) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.executions[exec.ID] = exec
+	return nil
+}
+
+// Get retrieves an execution by its ID.
+func (r *InMemoryExecutionRepository) Get(ctx context.Context, id string) (*Execution, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	exec, exists := r.executions[id]
+	if !exists {
+		return nil, ErrExecutionNotFound
+	}
+
+	return exec, nil
+}
+
+// Update modifies an existing execution.
+func (r *InMemoryExecutionRepository) Update(ctx context.Context, exec *Execution) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.executions[exec.ID]; !exists {
+		return ErrExecutionNotFound
+	}
+
+	r.executions[exec.ID] = exec
+	return nil
+}
+
+// List returns executions matching filter, newest-first, alongside the
+// total count of matches across every page.
+func (r *InMemoryExecutionRepository) List(ctx context.Context, filter ExecutionFilter) ([]*Execution, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*Execution
+	for _, exec := range r.executions {
+		if matchesExecutionFilter(exec, filter) {
+			matched = append(matched, exec)
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool { return matched[i].StartTime.After(matched[j].StartTime) })
+
+	total := len(matched)
+	page, pageSize := filter.Page, filter.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	start := (page - 1) * pageSize
+	if start > total {
+		start = total
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+
+	return matched[start:end], total, nil
+}
+
+func matchesExecutionFilter(exec *Execution, filter ExecutionFilter) bool {
+	if filter.TaskID != "" && exec.TaskID != filter.TaskID {
+		return false
+	}
+	if filter.Status != "" && exec.Status != filter.Status {
+		return false
+	}
+	if filter.Trigger != "" && exec.Trigger != filter.Trigger {
+		return false
+	}
+	return true
+}
+
+// Executor runs the work a Task represents. ExecutionManager drives one
+// Executor on behalf of every Task, retrying a failed run with backoff
+// before the Execution is marked ExecutionFailed.
+type Executor interface {
+	Execute(ctx context.Context, t *Task) error
+}
+
+// NoopExecutor is an Executor that always succeeds without doing any
+// work. It's wired in as the default until a caller registers a real
+// task-runner in its place.
+type NoopExecutor struct{}
+
+// Execute implements Executor.
+func (NoopExecutor) Execute(ctx context.Context, t *Task) error {
+	return nil
+}
+
+// DefaultMaxAttempts is how many times ExecutionManager runs a Task's
+// Executor (the initial attempt plus retries) before giving up, used when
+// NewExecutionManager is given a non-positive maxAttempts.
+const DefaultMaxAttempts = 3
+
+// DefaultRetryBaseDelay is the backoff ExecutionManager waits before the
+// first retry, doubled on each subsequent one, used when
+// NewExecutionManager is given a non-positive retryBaseDelay.
+const DefaultRetryBaseDelay = time.Second
+
+// ExecutionManager runs executor against a Task fetched from repo,
+// persisting progress through execRepo and retrying a failed run up to
+// maxAttempts times with exponential backoff. It mirrors
+// internal/execution.Manager's worker-pool/cancel-registry shape, scoped
+// to a single Task-shaped Executor instead of a registry of kinds.
+type ExecutionManager struct {
+	repo     Repository
+	execRepo ExecutionRepository
+	executor Executor
+
+	maxAttempts    int
+	retryBaseDelay time.Duration
+
+	wg sync.WaitGroup
+
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+}
+
+// NewExecutionManager creates an ExecutionManager with DefaultMaxAttempts
+// retries and DefaultRetryBaseDelay backoff.
+func NewExecutionManager(repo Repository, execRepo ExecutionRepository, executor Executor) *ExecutionManager {
+	return NewExecutionManagerWithRetry(repo, execRepo, executor, DefaultMaxAttempts, DefaultRetryBaseDelay)
+}
+
+// NewExecutionManagerWithRetry is NewExecutionManager with maxAttempts and
+// retryBaseDelay overridden (typically from config.TaskConfig); a
+// non-positive value for either falls back to its Default.
+func NewExecutionManagerWithRetry(repo Repository, execRepo ExecutionRepository, executor Executor, maxAttempts int, retryBaseDelay time.Duration) *ExecutionManager {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	if retryBaseDelay <= 0 {
+		retryBaseDelay = DefaultRetryBaseDelay
+	}
+	return &ExecutionManager{
+		repo:           repo,
+		execRepo:       execRepo,
+		executor:       executor,
+		maxAttempts:    maxAttempts,
+		retryBaseDelay: retryBaseDelay,
+		cancels:        make(map[string]context.CancelFunc),
+	}
+}
+
+// Trigger fetches task id from repo and starts a new Execution of it via
+// trigger, returning ErrTaskNotFound if it doesn't exist. The returned
+// Execution is always in ExecutionQueued; the run itself happens in a
+// background goroutine independent of ctx's lifetime, pollable via Get or
+// cancelable via Stop.
+func (m *ExecutionManager) Trigger(ctx context.Context, taskID string, trigger ExecutionTrigger) (*Execution, error) {
+	t, err := m.repo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	exec := &Execution{
+		ID:        uuid.New().String(),
+		TaskID:    t.ID,
+		Status:    ExecutionQueued,
+		Trigger:   trigger,
+		StartTime: time.Now(),
+	}
+	if err := m.execRepo.Create(ctx, exec); err != nil {
+		return nil, err
+	}
+
+	m.wg.Add(1)
+	go m.run(exec.ID, t)
+
+	return exec, nil
+}
+
+// Get retrieves an execution by ID.
+func (m *ExecutionManager) Get(ctx context.Context, id string) (*Execution, error) {
+	return m.execRepo.Get(ctx, id)
+}
+
+// List returns executions matching filter, newest-first, alongside the
+// total count of matches across every page.
+func (m *ExecutionManager) List(ctx context.Context, filter ExecutionFilter) ([]*Execution, int, error) {
+	return m.execRepo.List(ctx, filter)
+}
+
+// Stop cancels a queued or running execution, marking it ExecutionStopped
+// once its goroutine observes the cancellation (or immediately, if it
+// hasn't started its first attempt yet). It returns ErrExecutionNotFound
+// if id doesn't exist, or ErrExecutionNotCancelable if the execution has
+// already reached a terminal status.
+func (m *ExecutionManager) Stop(ctx context.Context, id string) error {
+	exec, err := m.execRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if exec.Status != ExecutionQueued && exec.Status != ExecutionRunning {
+		return ErrExecutionNotCancelable
+	}
+
+	m.mu.Lock()
+	cancel, ok := m.cancels[id]
+	m.mu.Unlock()
+	if ok {
+		cancel()
+	}
+
+	exec.Status = ExecutionStopped
+	now := time.Now()
+	exec.EndTime = &now
+	return m.execRepo.Update(ctx, exec)
+}
+
+// Shutdown waits for every running execution to drain, up to ctx's
+// deadline.
+func (m *ExecutionManager) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run drives t's Executor over a cancelable context (so Stop can interrupt
+// it), retrying a failed attempt up to m.maxAttempts times with exponential
+// backoff, and persists the outcome to execRepo.
+func (m *ExecutionManager) run(id string, t *Task) {
+	defer m.wg.Done()
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	m.mu.Lock()
+	m.cancels[id] = cancel
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		delete(m.cancels, id)
+		m.mu.Unlock()
+		cancel()
+	}()
+
+	exec, err := m.execRepo.Get(context.Background(), id)
+	if err != nil {
+		return
+	}
+
+	exec.Status = ExecutionRunning
+	_ = m.execRepo.Update(context.Background(), exec)
+
+	var runErr error
+	for attempt := 1; attempt <= m.maxAttempts; attempt++ {
+		exec.Attempt = attempt
+		_ = m.execRepo.Update(context.Background(), exec)
+
+		runErr = m.executor.Execute(runCtx, t)
+		if runErr == nil || runCtx.Err() != nil {
+			break
+		}
+
+		if attempt < m.maxAttempts {
+			delay := m.retryBaseDelay * time.Duration(1<<uint(attempt-1))
+			select {
+			case <-time.After(delay):
+			case <-runCtx.Done():
+			}
+		}
+	}
+
+	// Stop already marked the execution ExecutionStopped; don't clobber
+	// that with whatever error the canceled context produced.
+	current, getErr := m.execRepo.Get(context.Background(), id)
+	if getErr == nil && current.Status == ExecutionStopped {
+		return
+	}
+
+	now := time.Now()
+	exec.EndTime = &now
+
+	if runErr != nil {
+		exec.Status = ExecutionFailed
+		exec.Error = runErr.Error()
+		_ = m.execRepo.Update(context.Background(), exec)
+		return
+	}
+
+	exec.Status = ExecutionSucceeded
+	_ = m.execRepo.Update(context.Background(), exec)
+}
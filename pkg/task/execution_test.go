@@ -0,0 +1,231 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTask(t *testing.T, repo Repository, schedule string) *Task {
+	t.Helper()
+	tk := &Task{
+		ID:        "task-1",
+		Title:     "Test task",
+		Status:    StatusPending,
+		Priority:  PriorityMedium,
+		Schedule:  schedule,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	require.NoError(t, repo.Create(context.Background(), tk))
+	return tk
+}
+
+type funcExecutor struct {
+	run func(ctx context.Context, t *Task) error
+}
+
+func (f funcExecutor) Execute(ctx context.Context, t *Task) error {
+	return f.run(ctx, t)
+}
+
+func waitForStatus(t *testing.T, execRepo ExecutionRepository, id string, want ExecutionStatus) *Execution {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		exec, err := execRepo.Get(context.Background(), id)
+		require.NoError(t, err)
+		if exec.Status == want {
+			return exec
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("execution %s did not reach status %s in time", id, want)
+	return nil
+}
+
+func TestExecutionManager_Trigger_Succeeds(t *testing.T) {
+	repo := NewInMemoryRepository()
+	newTestTask(t, repo, "")
+	execRepo := NewInMemoryExecutionRepository()
+
+	manager := NewExecutionManager(repo, execRepo, funcExecutor{run: func(ctx context.Context, t *Task) error {
+		return nil
+	}})
+
+	exec, err := manager.Trigger(context.Background(), "task-1", TriggerManual)
+	require.NoError(t, err)
+	assert.Equal(t, ExecutionQueued, exec.Status)
+	assert.Equal(t, TriggerManual, exec.Trigger)
+
+	final := waitForStatus(t, execRepo, exec.ID, ExecutionSucceeded)
+	assert.Equal(t, 1, final.Attempt)
+	assert.NotNil(t, final.EndTime)
+}
+
+func TestExecutionManager_Trigger_UnknownTask(t *testing.T) {
+	repo := NewInMemoryRepository()
+	execRepo := NewInMemoryExecutionRepository()
+	manager := NewExecutionManager(repo, execRepo, NoopExecutor{})
+
+	_, err := manager.Trigger(context.Background(), "missing", TriggerManual)
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+}
+
+func TestExecutionManager_RetriesThenFails(t *testing.T) {
+	repo := NewInMemoryRepository()
+	newTestTask(t, repo, "")
+	execRepo := NewInMemoryExecutionRepository()
+
+	var attempts int32
+	manager := NewExecutionManagerWithRetry(repo, execRepo, funcExecutor{run: func(ctx context.Context, t *Task) error {
+		atomic.AddInt32(&attempts, 1)
+		return errors.New("boom")
+	}}, 3, time.Millisecond)
+
+	exec, err := manager.Trigger(context.Background(), "task-1", TriggerManual)
+	require.NoError(t, err)
+
+	final := waitForStatus(t, execRepo, exec.ID, ExecutionFailed)
+	assert.Equal(t, 3, final.Attempt)
+	assert.Equal(t, "boom", final.Error)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestExecutionManager_RetriesThenSucceeds(t *testing.T) {
+	repo := NewInMemoryRepository()
+	newTestTask(t, repo, "")
+	execRepo := NewInMemoryExecutionRepository()
+
+	var attempts int32
+	manager := NewExecutionManagerWithRetry(repo, execRepo, funcExecutor{run: func(ctx context.Context, t *Task) error {
+		if atomic.AddInt32(&attempts, 1) < 2 {
+			return errors.New("transient")
+		}
+		return nil
+	}}, 3, time.Millisecond)
+
+	exec, err := manager.Trigger(context.Background(), "task-1", TriggerManual)
+	require.NoError(t, err)
+
+	final := waitForStatus(t, execRepo, exec.ID, ExecutionSucceeded)
+	assert.Equal(t, 2, final.Attempt)
+	assert.Empty(t, final.Error)
+}
+
+func TestExecutionManager_Stop(t *testing.T) {
+	repo := NewInMemoryRepository()
+	newTestTask(t, repo, "")
+	execRepo := NewInMemoryExecutionRepository()
+
+	started := make(chan struct{})
+	manager := NewExecutionManager(repo, execRepo, funcExecutor{run: func(ctx context.Context, t *Task) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+
+	exec, err := manager.Trigger(context.Background(), "task-1", TriggerManual)
+	require.NoError(t, err)
+
+	<-started
+	require.NoError(t, manager.Stop(context.Background(), exec.ID))
+
+	final, err := execRepo.Get(context.Background(), exec.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ExecutionStopped, final.Status)
+
+	require.NoError(t, manager.Shutdown(context.Background()))
+}
+
+func TestExecutionManager_Stop_NotCancelable(t *testing.T) {
+	repo := NewInMemoryRepository()
+	newTestTask(t, repo, "")
+	execRepo := NewInMemoryExecutionRepository()
+
+	manager := NewExecutionManager(repo, execRepo, NoopExecutor{})
+	exec, err := manager.Trigger(context.Background(), "task-1", TriggerManual)
+	require.NoError(t, err)
+
+	waitForStatus(t, execRepo, exec.ID, ExecutionSucceeded)
+
+	err = manager.Stop(context.Background(), exec.ID)
+	assert.ErrorIs(t, err, ErrExecutionNotCancelable)
+}
+
+func TestExecutionManager_Stop_NotFound(t *testing.T) {
+	repo := NewInMemoryRepository()
+	execRepo := NewInMemoryExecutionRepository()
+	manager := NewExecutionManager(repo, execRepo, NoopExecutor{})
+
+	err := manager.Stop(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrExecutionNotFound)
+}
+
+func TestExecutionManager_List_FiltersAndPaginates(t *testing.T) {
+	repo := NewInMemoryRepository()
+	newTestTask(t, repo, "")
+	execRepo := NewInMemoryExecutionRepository()
+	manager := NewExecutionManager(repo, execRepo, NoopExecutor{})
+
+	for i := 0; i < 3; i++ {
+		_, err := manager.Trigger(context.Background(), "task-1", TriggerManual)
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		execs, _, err := execRepo.List(context.Background(), ExecutionFilter{TaskID: "task-1", Status: ExecutionSucceeded})
+		require.NoError(t, err)
+		if len(execs) == 3 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	execs, total, err := manager.List(context.Background(), ExecutionFilter{TaskID: "task-1", Page: 1, PageSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Len(t, execs, 2)
+}
+
+func TestScheduler_Sync_AddsUpdatesAndRemovesEntries(t *testing.T) {
+	repo := NewInMemoryRepository()
+	execRepo := NewInMemoryExecutionRepository()
+	manager := NewExecutionManager(repo, execRepo, NoopExecutor{})
+
+	scheduler, err := NewScheduler(repo, manager, "@every 1h")
+	require.NoError(t, err)
+
+	tk := newTestTask(t, repo, "@every 1h")
+
+	require.NoError(t, scheduler.Sync(context.Background()))
+	scheduler.mu.Lock()
+	_, scheduled := scheduler.entries[tk.ID]
+	scheduler.mu.Unlock()
+	assert.True(t, scheduled)
+
+	tk.Schedule = ""
+	require.NoError(t, repo.Update(context.Background(), tk))
+	require.NoError(t, scheduler.Sync(context.Background()))
+
+	scheduler.mu.Lock()
+	_, stillScheduled := scheduler.entries[tk.ID]
+	scheduler.mu.Unlock()
+	assert.False(t, stillScheduled)
+}
+
+func TestScheduler_InvalidSyncSpec(t *testing.T) {
+	repo := NewInMemoryRepository()
+	execRepo := NewInMemoryExecutionRepository()
+	manager := NewExecutionManager(repo, execRepo, NoopExecutor{})
+
+	_, err := NewScheduler(repo, manager, "not-a-cron-spec")
+	assert.Error(t, err)
+}
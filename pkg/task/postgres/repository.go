@@ -0,0 +1,329 @@
+// Package postgres implements task.Repository against PostgreSQL via pgx,
+// as an alternative to task.InMemoryRepository for deployments that need
+// durable storage.
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/f00b455/blank-go/pkg/task"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// querier is the subset of *pgxpool.Pool and pgx.Tx that Repository needs,
+// so the same query methods work whether Repository is bound to the pool
+// directly or to a transaction started by WithTx.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// Repository implements task.Repository against PostgreSQL using pgx.
+type Repository struct {
+	db querier
+}
+
+// NewRepository creates a Repository backed by pool.
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{db: pool}
+}
+
+// AutoMigrate creates the tasks table and its indexes if they don't already
+// exist.
+func AutoMigrate(ctx context.Context, pool *pgxpool.Pool) error {
+	_, err := pool.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS tasks (
+			id          TEXT PRIMARY KEY,
+			title       TEXT NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			status      TEXT NOT NULL,
+			priority    TEXT NOT NULL,
+			due_date    TIMESTAMPTZ,
+			tags        TEXT[] NOT NULL DEFAULT '{}',
+			created_by  TEXT NOT NULL DEFAULT '',
+			updated_by  TEXT NOT NULL DEFAULT '',
+			created_at  TIMESTAMPTZ NOT NULL,
+			updated_at  TIMESTAMPTZ NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_tasks_created_at_id ON tasks (created_at, id);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to migrate tasks schema: %w", err)
+	}
+	return nil
+}
+
+// Create inserts a new task.
+func (r *Repository) Create(ctx context.Context, t *task.Task) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO tasks (id, title, description, status, priority, due_date, tags, created_by, updated_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`, t.ID, t.Title, t.Description, t.Status, t.Priority, t.DueDate, t.Tags, t.CreatedBy, t.UpdatedBy, t.CreatedAt, t.UpdatedAt)
+	return err
+}
+
+// GetByID retrieves a task by its ID, returning task.ErrTaskNotFound if no
+// row matches.
+func (r *Repository) GetByID(ctx context.Context, id string) (*task.Task, error) {
+	row := r.db.QueryRow(ctx, `
+		SELECT id, title, description, status, priority, due_date, tags, created_by, updated_by, created_at, updated_at
+		FROM tasks WHERE id = $1
+	`, id)
+
+	t, err := scanTask(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, task.ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// GetAll retrieves every task matching filter, sorted and paginated the
+// same way task.InMemoryRepository.GetAll is.
+func (r *Repository) GetAll(ctx context.Context, filter task.FilterOptions) ([]*task.Task, error) {
+	query, args := buildFilterQuery(filter)
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = -1 // no LIMIT
+	}
+	query += fmt.Sprintf(" ORDER BY %s LIMIT NULLIF($%d, -1) OFFSET $%d", orderByClause(filter), len(args)+1, len(args)+2)
+	args = append(args, limit, filter.Offset)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*task.Task
+	for rows.Next() {
+		t, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, rows.Err()
+}
+
+// Update modifies an existing task, returning task.ErrTaskNotFound if no
+// row matches t.ID.
+func (r *Repository) Update(ctx context.Context, t *task.Task) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE tasks SET title = $2, description = $3, status = $4, priority = $5,
+			due_date = $6, tags = $7, updated_by = $8, updated_at = $9
+		WHERE id = $1
+	`, t.ID, t.Title, t.Description, t.Status, t.Priority, t.DueDate, t.Tags, t.UpdatedBy, t.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return task.ErrTaskNotFound
+	}
+	return nil
+}
+
+// Delete removes a task, returning task.ErrTaskNotFound if no row matches
+// id.
+func (r *Repository) Delete(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, "DELETE FROM tasks WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return task.ErrTaskNotFound
+	}
+	return nil
+}
+
+// Transaction runs fn against a Repository bound to a single database
+// transaction, committing fn's writes only if fn returns nil and rolling
+// back all of them otherwise. Only usable when Repository was built with
+// NewRepository against a *pgxpool.Pool; calling Transaction on a
+// Repository already inside a transaction returns an error, since pgx
+// doesn't support nested transactions without savepoints.
+func (r *Repository) Transaction(ctx context.Context, fn func(task.Repository) error) error {
+	pool, ok := r.db.(*pgxpool.Pool)
+	if !ok {
+		return fmt.Errorf("postgres: Transaction called on a Repository that is already inside a transaction")
+	}
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(&Repository{db: tx}); err != nil {
+		_ = tx.Rollback(ctx)
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetAllKeyset implements the task.Repository.GetAllKeyset keyset-pagination
+// contract described on the interface.
+func (r *Repository) GetAllKeyset(ctx context.Context, filter task.FilterOptions, cursor *task.CursorKey, limit int) ([]*task.Task, bool, bool, error) {
+	query, args := buildFilterQuery(filter)
+
+	column := sortColumn(filter)
+
+	// scanDesc is the direction this query actually runs in: filter's own
+	// direction, flipped when cursor.Before asks for the page before it
+	// (scanned backward, then reversed below to restore filter's order).
+	scanDesc := filter.SortDesc
+	if cursor != nil && cursor.Before {
+		scanDesc = !scanDesc
+	}
+	dir := "ASC"
+	if scanDesc {
+		dir = "DESC"
+	}
+
+	if cursor != nil {
+		op := ">"
+		if scanDesc {
+			op = "<"
+		}
+		query += fmt.Sprintf(" AND (%s::text, id) %s ($%d, $%d)", column, op, len(args)+1, len(args)+2)
+		args = append(args, cursor.LastValue, cursor.LastID)
+	}
+
+	query += fmt.Sprintf(" ORDER BY %s::text %s, id %s LIMIT $%d", column, dir, dir, len(args)+1)
+	args = append(args, limit+1)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, false, false, err
+	}
+	defer rows.Close()
+
+	var tasks []*task.Task
+	for rows.Next() {
+		t, err := scanTask(rows)
+		if err != nil {
+			return nil, false, false, err
+		}
+		tasks = append(tasks, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, false, err
+	}
+
+	hasMore := len(tasks) > limit
+	if hasMore {
+		tasks = tasks[:limit]
+	}
+
+	if cursor != nil && cursor.Before {
+		for i, j := 0, len(tasks)-1; i < j; i, j = i+1, j-1 {
+			tasks[i], tasks[j] = tasks[j], tasks[i]
+		}
+		return tasks, true, hasMore, nil
+	}
+
+	return tasks, hasMore, cursor != nil, nil
+}
+
+// CountMatching returns how many tasks match filter.
+func (r *Repository) CountMatching(ctx context.Context, filter task.FilterOptions) (int, error) {
+	query, args := buildFilterQuery(filter)
+	query = strings.Replace(query, "SELECT id, title, description, status, priority, due_date, tags, created_by, updated_by, created_at, updated_at", "SELECT COUNT(*)", 1)
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// rowScanner is satisfied by both pgx.Row (QueryRow) and pgx.Rows (Query),
+// so scanTask can be shared between single-row and multi-row callers.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTask(row rowScanner) (*task.Task, error) {
+	var t task.Task
+	if err := row.Scan(&t.ID, &t.Title, &t.Description, &t.Status, &t.Priority, &t.DueDate, &t.Tags, &t.CreatedBy, &t.UpdatedBy, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// buildFilterQuery returns the SELECT and WHERE clauses matching filter's
+// status/priority/tag/query/tags criteria, the SQL equivalent of
+// task.MatchesFilter/matchesFilter. Query uses a case-insensitive ILIKE
+// scan rather than tsvector, consistent with this package's goal of
+// durability over query performance.
+func buildFilterQuery(filter task.FilterOptions) (string, []interface{}) {
+	query := `
+		SELECT id, title, description, status, priority, due_date, tags, created_by, updated_by, created_at, updated_at
+		FROM tasks WHERE 1=1
+	`
+	var args []interface{}
+
+	if filter.Status != nil {
+		args = append(args, *filter.Status)
+		query += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+	if filter.Priority != nil {
+		args = append(args, *filter.Priority)
+		query += fmt.Sprintf(" AND priority = $%d", len(args))
+	}
+	if filter.Tag != nil {
+		args = append(args, *filter.Tag)
+		query += fmt.Sprintf(" AND $%d = ANY(tags)", len(args))
+	}
+	if filter.Query != "" {
+		args = append(args, "%"+filter.Query+"%")
+		query += fmt.Sprintf(" AND (title ILIKE $%d OR description ILIKE $%d)", len(args), len(args))
+	}
+	if len(filter.Tags) > 0 {
+		args = append(args, filter.Tags)
+		if filter.TagsMode == task.TagsModeAll {
+			query += fmt.Sprintf(" AND tags @> $%d", len(args))
+		} else {
+			query += fmt.Sprintf(" AND tags && $%d", len(args))
+		}
+	}
+
+	return query, args
+}
+
+// sortColumn maps filter.SortBy to its backing column, mirroring
+// task.sortFieldValue's FilterOptions.SortBy handling so GetAll's ORDER BY
+// and GetAllKeyset's cursor comparison agree on the same column.
+func sortColumn(filter task.FilterOptions) string {
+	switch filter.SortBy {
+	case "updated_at":
+		return "updated_at"
+	case "due_date":
+		return "due_date"
+	case "priority":
+		return "priority"
+	case "title":
+		return "title"
+	default:
+		return "created_at"
+	}
+}
+
+// orderByClause builds the SQL ORDER BY clause for filter.SortBy/SortDesc,
+// mirroring task.sortTasks's defaulting and column mapping.
+func orderByClause(filter task.FilterOptions) string {
+	dir := "ASC"
+	if filter.SortDesc {
+		dir = "DESC"
+	}
+	return fmt.Sprintf("%s %s", sortColumn(filter), dir)
+}
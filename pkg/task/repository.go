@@ -1,10 +1,14 @@
 package task
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"sort"
 	"strings"
 	"sync"
+	"time"
+	"unicode"
 )
 
 var (
@@ -14,17 +18,46 @@ var (
 
 // Repository defines the interface for task storage operations
 type Repository interface {
-	Create(task *Task) error
-	GetByID(id string) (*Task, error)
-	GetAll(filter FilterOptions) ([]*Task, error)
-	Update(task *Task) error
-	Delete(id string) error
+	Create(ctx context.Context, task *Task) error
+	GetByID(ctx context.Context, id string) (*Task, error)
+	GetAll(ctx context.Context, filter FilterOptions) ([]*Task, error)
+	Update(ctx context.Context, task *Task) error
+	Delete(ctx context.Context, id string) error
+	// Transaction runs fn against a view of the repository that only
+	// becomes visible to other callers if fn returns nil; any error from
+	// fn discards every change fn made, the same commit-or-rollback
+	// contract a SQL-backed Repository would provide via a real database
+	// transaction.
+	Transaction(ctx context.Context, fn func(Repository) error) error
+	// GetAllKeyset returns the page of tasks matching filter adjacent to
+	// cursor in (created_at, id) order: a nil cursor starts at the
+	// beginning, cursor.Before false continues forward after cursor's key,
+	// and cursor.Before true scans backward from it. hasNext/hasPrev report
+	// whether further tasks exist beyond either end of the returned page,
+	// independent of which direction was scanned to reach it.
+	GetAllKeyset(ctx context.Context, filter FilterOptions, cursor *CursorKey, limit int) (tasks []*Task, hasNext bool, hasPrev bool, err error)
+	// CountMatching returns how many tasks match filter, for the
+	// X-Total-Count header that accompanies cursor-paginated responses.
+	CountMatching(ctx context.Context, filter FilterOptions) (int, error)
 }
 
 // InMemoryRepository implements Repository using in-memory storage
 type InMemoryRepository struct {
 	mu    sync.RWMutex
 	tasks map[string]*Task
+
+	// searchIndex maps a lowercased word from some task's title/description
+	// to the IDs of tasks containing it, built lazily by searchCandidates on
+	// first use of FilterOptions.Query and invalidated (set back to nil) by
+	// any Create/Update/Delete, so GetAll's Query filtering only scans the
+	// whole task map again when it's next needed. indexMu guards it
+	// independently of mu so concurrent GetAll calls can share one build
+	// instead of serializing behind mu's write lock; it's only ever touched
+	// while mu is held (for reads, as RLock; for invalidation, as the
+	// existing Lock already held by the mutating method), so the two never
+	// need to be acquired together.
+	indexMu     sync.Mutex
+	searchIndex map[string][]string
 }
 
 // NewInMemoryRepository creates a new in-memory repository
@@ -35,16 +68,25 @@ func NewInMemoryRepository() *InMemoryRepository {
 }
 
 // Create adds a new task to the repository
-func (r *InMemoryRepository) Create(task *Task) error {
+func (r *InMemoryRepository) Create(ctx context.Context, task *Task) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	r.tasks[task.ID] = task
+	r.searchIndex = nil
 	return nil
 }
 
 // GetByID retrieves a task by its ID
-func (r *InMemoryRepository) GetByID(id string) (*Task, error) {
+func (r *InMemoryRepository) GetByID(ctx context.Context, id string) (*Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -56,18 +98,38 @@ func (r *InMemoryRepository) GetByID(id string) (*Task, error) {
 	return task, nil
 }
 
-// GetAll retrieves all tasks with optional filtering
-func (r *InMemoryRepository) GetAll(filter FilterOptions) ([]*Task, error) {
+// GetAll retrieves all tasks with optional filtering. ctx is checked between
+// the filter and sort passes so a deadline exceeded while iterating a large
+// table is noticed before the (more expensive) sort runs, mirroring the
+// deadline-polling pattern used by low-level Go network code that checks
+// ctx.Err() between blocking steps rather than only at entry.
+func (r *InMemoryRepository) GetAll(ctx context.Context, filter FilterOptions) ([]*Task, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	candidates := r.tasks
+	if filter.Query != "" {
+		candidates = r.queryCandidatesLocked(filter.Query)
+	}
+
 	var result []*Task
-	for _, task := range r.tasks {
+	checked := 0
+	for _, task := range candidates {
+		checked++
+		if checked%256 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
 		if matchesFilter(task, filter) {
 			result = append(result, task)
 		}
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Sort tasks
 	sortTasks(result, filter)
 
@@ -85,8 +147,95 @@ func (r *InMemoryRepository) GetAll(filter FilterOptions) ([]*Task, error) {
 	return result[start:end], nil
 }
 
+// queryCandidatesLocked narrows the full task map down to the tasks whose
+// title/description might contain query, using (and lazily building)
+// searchIndex so GetAll doesn't need a full scan just to run matchesFilter
+// when a selective Query is given. It must be called with r.mu held (for
+// read or write).
+//
+// query is tokenized on whitespace/punctuation and each token is matched
+// against index words as a substring, so "hip" still finds a task titled
+// "Ship it" even though "hip" isn't itself an indexed word; a multi-token
+// query ANDs its tokens' candidate sets together. This can return false
+// positives (e.g. a multi-word query whose words appear in the task but not
+// adjacent) - callers must still run the authoritative matchesFilter (via
+// matchesQuery) against the result, the way GetAll does, so correctness
+// never depends on the index.
+func (r *InMemoryRepository) queryCandidatesLocked(query string) map[string]*Task {
+	index := r.ensureSearchIndexLocked()
+
+	tokens := tokenizeWords(query)
+	if len(tokens) == 0 {
+		return r.tasks
+	}
+
+	var matchedIDs map[string]struct{}
+	for _, token := range tokens {
+		tokenIDs := make(map[string]struct{})
+		for word, ids := range index {
+			if strings.Contains(word, token) {
+				for _, id := range ids {
+					tokenIDs[id] = struct{}{}
+				}
+			}
+		}
+		if matchedIDs == nil {
+			matchedIDs = tokenIDs
+			continue
+		}
+		for id := range matchedIDs {
+			if _, ok := tokenIDs[id]; !ok {
+				delete(matchedIDs, id)
+			}
+		}
+	}
+
+	candidates := make(map[string]*Task, len(matchedIDs))
+	for id := range matchedIDs {
+		if task, ok := r.tasks[id]; ok {
+			candidates[id] = task
+		}
+	}
+	return candidates
+}
+
+// ensureSearchIndexLocked returns searchIndex, building it from r.tasks on
+// first use (or after it was invalidated by a Create/Update/Delete). It must
+// be called with r.mu held (for read or write); indexMu only serializes
+// concurrent builders so readers racing under separate RLocks share one
+// build instead of each redoing it.
+func (r *InMemoryRepository) ensureSearchIndexLocked() map[string][]string {
+	r.indexMu.Lock()
+	defer r.indexMu.Unlock()
+
+	if r.searchIndex != nil {
+		return r.searchIndex
+	}
+
+	index := make(map[string][]string)
+	for id, t := range r.tasks {
+		for _, word := range tokenizeWords(t.Title + " " + t.Description) {
+			index[word] = append(index[word], id)
+		}
+	}
+	r.searchIndex = index
+	return index
+}
+
+// tokenizeWords splits s on runs of non-letter/non-digit characters into
+// lowercased words, the unit searchIndex is keyed by.
+func tokenizeWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
 // Update modifies an existing task
-func (r *InMemoryRepository) Update(task *Task) error {
+func (r *InMemoryRepository) Update(ctx context.Context, task *Task) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -95,11 +244,16 @@ func (r *InMemoryRepository) Update(task *Task) error {
 	}
 
 	r.tasks[task.ID] = task
+	r.searchIndex = nil
 	return nil
 }
 
 // Delete removes a task from the repository
-func (r *InMemoryRepository) Delete(id string) error {
+func (r *InMemoryRepository) Delete(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -108,9 +262,150 @@ func (r *InMemoryRepository) Delete(id string) error {
 	}
 
 	delete(r.tasks, id)
+	r.searchIndex = nil
 	return nil
 }
 
+// Transaction gives fn a private, copy-on-write snapshot of the
+// repository's tasks. If fn returns nil, the snapshot (including fn's
+// mutations) replaces r.tasks atomically; if fn returns an error, the
+// snapshot is discarded and r.tasks is left exactly as it was, so none of
+// fn's Create/Update/Delete calls are observable afterwards.
+func (r *InMemoryRepository) Transaction(ctx context.Context, fn func(Repository) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.RLock()
+	snapshot := make(map[string]*Task, len(r.tasks))
+	for id, t := range r.tasks {
+		taskCopy := *t
+		snapshot[id] = &taskCopy
+	}
+	r.mu.RUnlock()
+
+	txRepo := &InMemoryRepository{tasks: snapshot}
+
+	if err := fn(txRepo); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.tasks = txRepo.tasks
+	r.mu.Unlock()
+
+	return nil
+}
+
+// GetAllKeyset implements the Repository.GetAllKeyset keyset-pagination
+// contract described on the interface. Unlike GetAll's offset pagination,
+// the page it returns doesn't shift when tasks are inserted or deleted
+// elsewhere in the table, since each page is anchored to an actual row's
+// (sort_by, id) rather than a row count.
+func (r *InMemoryRepository) GetAllKeyset(ctx context.Context, filter FilterOptions, cursor *CursorKey, limit int) ([]*Task, bool, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, false, false, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*Task
+	for _, task := range r.tasks {
+		if matchesFilter(task, filter) {
+			matched = append(matched, task)
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool { return compareTasks(matched[i], matched[j], filter) < 0 })
+
+	var start, end int
+	switch {
+	case cursor == nil:
+		start, end = 0, limit
+	case cursor.Before:
+		// idx is the count of tasks strictly before cursor's key; the page
+		// ends there and runs backward up to limit tasks.
+		idx := sort.Search(len(matched), func(i int) bool { return !keysetBefore(matched[i], *cursor, filter) })
+		start, end = idx-limit, idx
+		if start < 0 {
+			start = 0
+		}
+	default:
+		// idx is the first task strictly after cursor's key; the page
+		// starts there and runs forward up to limit tasks.
+		idx := sort.Search(len(matched), func(i int) bool { return keysetAfter(matched[i], *cursor, filter) })
+		start, end = idx, idx+limit
+	}
+
+	if start > len(matched) {
+		start = len(matched)
+	}
+	if end > len(matched) {
+		end = len(matched)
+	}
+
+	page := matched[start:end]
+	hasNext := end < len(matched)
+	hasPrev := start > 0
+	return page, hasNext, hasPrev, nil
+}
+
+// CountMatching returns how many tasks match filter.
+func (r *InMemoryRepository) CountMatching(ctx context.Context, filter FilterOptions) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, task := range r.tasks {
+		if matchesFilter(task, filter) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// keysetBefore reports whether task sorts strictly before cursor's
+// (LastValue, LastID) under filter's effective sort order, including
+// filter.SortDesc.
+func keysetBefore(task *Task, cursor CursorKey, filter FilterOptions) bool {
+	return compareTaskToCursor(task, cursor, filter) < 0
+}
+
+// keysetAfter reports whether task sorts strictly after cursor's
+// (LastValue, LastID) under filter's effective sort order, including
+// filter.SortDesc.
+func keysetAfter(task *Task, cursor CursorKey, filter FilterOptions) bool {
+	return compareTaskToCursor(task, cursor, filter) > 0
+}
+
+// compareTaskToCursor compares task's (sort_by, id) key against cursor's
+// (LastValue, LastID), returning <0, 0, >0 the same way compareTasks does,
+// so GetAllKeyset's binary search sees the identical ordering it sorted
+// matched with.
+func compareTaskToCursor(task *Task, cursor CursorKey, filter FilterOptions) int {
+	c := strings.Compare(sortFieldValue(task, effectiveSortBy(filter.SortBy)), cursor.LastValue)
+	if c == 0 {
+		c = strings.Compare(task.ID, cursor.LastID)
+	}
+	if filter.SortDesc {
+		c = -c
+	}
+	return c
+}
+
+// MatchesFilter reports whether t matches filter's status/priority/tag
+// criteria, the same predicate GetAll and the keyset listings use to
+// select rows. It's exported so the SSE events handler can apply identical
+// filtering to published events without duplicating the logic.
+func MatchesFilter(t *Task, filter FilterOptions) bool {
+	return matchesFilter(t, filter)
+}
+
 // matchesFilter checks if a task matches the given filter criteria
 func matchesFilter(task *Task, filter FilterOptions) bool {
 	if filter.Status != nil && task.Status != *filter.Status {
@@ -134,45 +429,108 @@ func matchesFilter(task *Task, filter FilterOptions) bool {
 		}
 	}
 
+	if len(filter.Tags) > 0 && !matchesTags(task.Tags, filter.Tags, filter.TagsMode) {
+		return false
+	}
+
+	if filter.Query != "" && !matchesQuery(task, filter.Query) {
+		return false
+	}
+
 	return true
 }
 
-// sortTasks sorts tasks based on the filter options
-func sortTasks(tasks []*Task, filter FilterOptions) {
-	if filter.SortBy == "" {
-		filter.SortBy = "created_at"
-	}
-
-	sort.Slice(tasks, func(i, j int) bool {
-		var less bool
-		switch filter.SortBy {
-		case "created_at":
-			less = tasks[i].CreatedAt.Before(tasks[j].CreatedAt)
-		case "updated_at":
-			less = tasks[i].UpdatedAt.Before(tasks[j].UpdatedAt)
-		case "due_date":
-			if tasks[i].DueDate == nil && tasks[j].DueDate == nil {
-				less = false
-			} else if tasks[i].DueDate == nil {
-				less = false
-			} else if tasks[j].DueDate == nil {
-				less = true
-			} else {
-				less = tasks[i].DueDate.Before(*tasks[j].DueDate)
+// matchesTags reports whether taskTags satisfies wanted under mode: "all"
+// requires every tag in wanted to be present, anything else (including the
+// default "") requires only one.
+func matchesTags(taskTags, wanted []string, mode string) bool {
+	has := make(map[string]bool, len(taskTags))
+	for _, tag := range taskTags {
+		has[tag] = true
+	}
+
+	if mode == TagsModeAll {
+		for _, tag := range wanted {
+			if !has[tag] {
+				return false
 			}
-		case "priority":
-			less = priorityValue(tasks[i].Priority) < priorityValue(tasks[j].Priority)
-		case "title":
-			less = strings.ToLower(tasks[i].Title) < strings.ToLower(tasks[j].Title)
-		default:
-			less = tasks[i].CreatedAt.Before(tasks[j].CreatedAt)
 		}
+		return true
+	}
 
-		if filter.SortDesc {
-			return !less
+	for _, tag := range wanted {
+		if has[tag] {
+			return true
 		}
-		return less
-	})
+	}
+	return false
+}
+
+// matchesQuery reports whether task's Title or Description contains query
+// as a case-insensitive substring.
+func matchesQuery(task *Task, query string) bool {
+	q := strings.ToLower(query)
+	return strings.Contains(strings.ToLower(task.Title), q) ||
+		strings.Contains(strings.ToLower(task.Description), q)
+}
+
+// sortTasks sorts tasks based on the filter options
+func sortTasks(tasks []*Task, filter FilterOptions) {
+	sort.Slice(tasks, func(i, j int) bool { return compareTasks(tasks[i], tasks[j], filter) < 0 })
+}
+
+// effectiveSortBy returns filter's SortBy, or "created_at" if it's unset,
+// the same default parseFilterOptions applies at the HTTP layer.
+func effectiveSortBy(sortBy string) string {
+	if sortBy == "" {
+		return "created_at"
+	}
+	return sortBy
+}
+
+// compareTasks orders a against b by filter's effective sort_by field,
+// breaking ties by id so that equal-keyed tasks still have a stable,
+// deterministic order across repeated calls - the same (sort_by, id)
+// ordering GetAllKeyset anchors its cursors to. It returns <0, 0, >0 like
+// strings.Compare, negated when filter.SortDesc is set.
+func compareTasks(a, b *Task, filter FilterOptions) int {
+	sortBy := effectiveSortBy(filter.SortBy)
+	c := strings.Compare(sortFieldValue(a, sortBy), sortFieldValue(b, sortBy))
+	if c == 0 {
+		c = strings.Compare(a.ID, b.ID)
+	}
+	if filter.SortDesc {
+		c = -c
+	}
+	return c
+}
+
+// dueDateUnsetSentinel sorts after any RFC3339Nano timestamp sortFieldValue
+// produces (timestamps start with a digit, well below '~' in ASCII), so a
+// nil DueDate sorts last in ascending order, matching the old due_date
+// comparator's "nil never sorts before a set date" rule.
+const dueDateUnsetSentinel = "~unset~"
+
+// sortFieldValue returns task's value for sortBy encoded as a string whose
+// lexical order matches the field's natural order, so both sortTasks and
+// GetAllKeyset's cursor comparisons can use a single plain string compare
+// regardless of which FilterOptions.SortBy the caller asked for.
+func sortFieldValue(task *Task, sortBy string) string {
+	switch sortBy {
+	case "updated_at":
+		return task.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	case "due_date":
+		if task.DueDate == nil {
+			return dueDateUnsetSentinel
+		}
+		return task.DueDate.UTC().Format(time.RFC3339Nano)
+	case "priority":
+		return fmt.Sprintf("%02d", priorityValue(task.Priority))
+	case "title":
+		return strings.ToLower(task.Title)
+	default:
+		return task.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
 }
 
 // priorityValue returns numeric value for priority comparison
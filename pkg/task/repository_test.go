@@ -1,6 +1,7 @@
 package task
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -19,11 +20,11 @@ func TestInMemoryRepository_Create(t *testing.T) {
 		UpdatedAt: time.Now(),
 	}
 
-	err := repo.Create(task)
+	err := repo.Create(context.Background(), task)
 	require.NoError(t, err)
 
 	// Verify task was created
-	retrieved, err := repo.GetByID(task.ID)
+	retrieved, err := repo.GetByID(context.Background(), task.ID)
 	require.NoError(t, err)
 	assert.Equal(t, task.ID, retrieved.ID)
 	assert.Equal(t, task.Title, retrieved.Title)
@@ -41,17 +42,17 @@ func TestInMemoryRepository_GetByID(t *testing.T) {
 	}
 
 	// Create task
-	err := repo.Create(task)
+	err := repo.Create(context.Background(), task)
 	require.NoError(t, err)
 
 	t.Run("existing task", func(t *testing.T) {
-		retrieved, err := repo.GetByID("test-id")
+		retrieved, err := repo.GetByID(context.Background(), "test-id")
 		require.NoError(t, err)
 		assert.Equal(t, task.ID, retrieved.ID)
 	})
 
 	t.Run("non-existent task", func(t *testing.T) {
-		retrieved, err := repo.GetByID("non-existent")
+		retrieved, err := repo.GetByID(context.Background(), "non-existent")
 		assert.ErrorIs(t, err, ErrTaskNotFound)
 		assert.Nil(t, retrieved)
 	})
@@ -69,22 +70,22 @@ func TestInMemoryRepository_Update(t *testing.T) {
 	}
 
 	// Create task
-	err := repo.Create(task)
+	err := repo.Create(context.Background(), task)
 	require.NoError(t, err)
 
 	t.Run("update existing task", func(t *testing.T) {
 		task.Title = "Updated"
-		err := repo.Update(task)
+		err := repo.Update(context.Background(), task)
 		require.NoError(t, err)
 
-		retrieved, err := repo.GetByID(task.ID)
+		retrieved, err := repo.GetByID(context.Background(), task.ID)
 		require.NoError(t, err)
 		assert.Equal(t, "Updated", retrieved.Title)
 	})
 
 	t.Run("update non-existent task", func(t *testing.T) {
 		nonExistent := &Task{ID: "non-existent", Title: "Test"}
-		err := repo.Update(nonExistent)
+		err := repo.Update(context.Background(), nonExistent)
 		assert.ErrorIs(t, err, ErrTaskNotFound)
 	})
 }
@@ -101,20 +102,20 @@ func TestInMemoryRepository_Delete(t *testing.T) {
 	}
 
 	// Create task
-	err := repo.Create(task)
+	err := repo.Create(context.Background(), task)
 	require.NoError(t, err)
 
 	t.Run("delete existing task", func(t *testing.T) {
-		err := repo.Delete(task.ID)
+		err := repo.Delete(context.Background(), task.ID)
 		require.NoError(t, err)
 
 		// Verify deletion
-		_, err = repo.GetByID(task.ID)
+		_, err = repo.GetByID(context.Background(), task.ID)
 		assert.ErrorIs(t, err, ErrTaskNotFound)
 	})
 
 	t.Run("delete non-existent task", func(t *testing.T) {
-		err := repo.Delete("non-existent")
+		err := repo.Delete(context.Background(), "non-existent")
 		assert.ErrorIs(t, err, ErrTaskNotFound)
 	})
 }
@@ -154,19 +155,19 @@ func TestInMemoryRepository_GetAll(t *testing.T) {
 	}
 
 	for _, task := range tasks {
-		err := repo.Create(task)
+		err := repo.Create(context.Background(), task)
 		require.NoError(t, err)
 	}
 
 	t.Run("get all tasks", func(t *testing.T) {
-		result, err := repo.GetAll(FilterOptions{})
+		result, err := repo.GetAll(context.Background(), FilterOptions{})
 		require.NoError(t, err)
 		assert.Len(t, result, 3)
 	})
 
 	t.Run("filter by status", func(t *testing.T) {
 		status := StatusPending
-		result, err := repo.GetAll(FilterOptions{Status: &status})
+		result, err := repo.GetAll(context.Background(), FilterOptions{Status: &status})
 		require.NoError(t, err)
 		assert.Len(t, result, 1)
 		assert.Equal(t, StatusPending, result[0].Status)
@@ -174,7 +175,7 @@ func TestInMemoryRepository_GetAll(t *testing.T) {
 
 	t.Run("filter by priority", func(t *testing.T) {
 		priority := PriorityHigh
-		result, err := repo.GetAll(FilterOptions{Priority: &priority})
+		result, err := repo.GetAll(context.Background(), FilterOptions{Priority: &priority})
 		require.NoError(t, err)
 		assert.Len(t, result, 1)
 		assert.Equal(t, PriorityHigh, result[0].Priority)
@@ -182,23 +183,23 @@ func TestInMemoryRepository_GetAll(t *testing.T) {
 
 	t.Run("filter by tag", func(t *testing.T) {
 		tag := "work"
-		result, err := repo.GetAll(FilterOptions{Tag: &tag})
+		result, err := repo.GetAll(context.Background(), FilterOptions{Tag: &tag})
 		require.NoError(t, err)
 		assert.Len(t, result, 2)
 	})
 
 	t.Run("pagination", func(t *testing.T) {
-		result, err := repo.GetAll(FilterOptions{Limit: 2, Offset: 0})
+		result, err := repo.GetAll(context.Background(), FilterOptions{Limit: 2, Offset: 0})
 		require.NoError(t, err)
 		assert.Len(t, result, 2)
 
-		result, err = repo.GetAll(FilterOptions{Limit: 2, Offset: 2})
+		result, err = repo.GetAll(context.Background(), FilterOptions{Limit: 2, Offset: 2})
 		require.NoError(t, err)
 		assert.Len(t, result, 1)
 	})
 
 	t.Run("sort by created_at asc", func(t *testing.T) {
-		result, err := repo.GetAll(FilterOptions{SortBy: "created_at", SortDesc: false})
+		result, err := repo.GetAll(context.Background(), FilterOptions{SortBy: "created_at", SortDesc: false})
 		require.NoError(t, err)
 		assert.Equal(t, "1", result[0].ID)
 		assert.Equal(t, "2", result[1].ID)
@@ -206,7 +207,7 @@ func TestInMemoryRepository_GetAll(t *testing.T) {
 	})
 
 	t.Run("sort by created_at desc", func(t *testing.T) {
-		result, err := repo.GetAll(FilterOptions{SortBy: "created_at", SortDesc: true})
+		result, err := repo.GetAll(context.Background(), FilterOptions{SortBy: "created_at", SortDesc: true})
 		require.NoError(t, err)
 		assert.Equal(t, "3", result[0].ID)
 		assert.Equal(t, "2", result[1].ID)
@@ -214,7 +215,7 @@ func TestInMemoryRepository_GetAll(t *testing.T) {
 	})
 
 	t.Run("sort by priority", func(t *testing.T) {
-		result, err := repo.GetAll(FilterOptions{SortBy: "priority", SortDesc: true})
+		result, err := repo.GetAll(context.Background(), FilterOptions{SortBy: "priority", SortDesc: true})
 		require.NoError(t, err)
 		assert.Equal(t, PriorityHigh, result[0].Priority)
 	})
@@ -272,6 +273,36 @@ func TestMatchesFilter(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "tags any - matches one of several",
+			filter: FilterOptions{
+				Tags: []string{"personal", "urgent"},
+			},
+			want: true,
+		},
+		{
+			name: "tags any - matches none",
+			filter: FilterOptions{
+				Tags: []string{"personal", "home"},
+			},
+			want: false,
+		},
+		{
+			name: "tags all - has every tag",
+			filter: FilterOptions{
+				Tags:     []string{"work", "urgent"},
+				TagsMode: TagsModeAll,
+			},
+			want: true,
+		},
+		{
+			name: "tags all - missing one tag",
+			filter: FilterOptions{
+				Tags:     []string{"work", "personal"},
+				TagsMode: TagsModeAll,
+			},
+			want: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -282,6 +313,108 @@ func TestMatchesFilter(t *testing.T) {
 	}
 }
 
+func TestMatchesFilter_Query(t *testing.T) {
+	task := &Task{
+		Title:       "Ship the release",
+		Description: "Cut a tag and publish notes",
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  bool
+	}{
+		{name: "empty query matches", query: "", want: true},
+		{name: "substring of title", query: "ship", want: true},
+		{name: "case-insensitive", query: "SHIP", want: true},
+		{name: "substring of description", query: "publish", want: true},
+		{name: "non-matching query", query: "rollback", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := matchesFilter(task, FilterOptions{Query: tt.query})
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func TestInMemoryRepository_GetAll_Query(t *testing.T) {
+	repo := NewInMemoryRepository()
+	ctx := context.Background()
+
+	require.NoError(t, repo.Create(ctx, &Task{ID: "1", Title: "Ship the release"}))
+	require.NoError(t, repo.Create(ctx, &Task{ID: "2", Title: "Write docs", Description: "Document the shipping process"}))
+	require.NoError(t, repo.Create(ctx, &Task{ID: "3", Title: "Buy groceries"}))
+
+	results, err := repo.GetAll(ctx, FilterOptions{Query: "ship"})
+	require.NoError(t, err)
+
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r.ID
+	}
+	assert.ElementsMatch(t, []string{"1", "2"}, ids)
+
+	// A second search exercises the now-built (and still valid) index.
+	results, err = repo.GetAll(ctx, FilterOptions{Query: "groceries"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "3", results[0].ID)
+
+	// Updating a task invalidates the index; the new text must be found.
+	require.NoError(t, repo.Update(ctx, &Task{ID: "3", Title: "Buy shipping supplies"}))
+	results, err = repo.GetAll(ctx, FilterOptions{Query: "shipping"})
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+}
+
+func TestInMemoryRepository_GetAllKeyset_SortBy(t *testing.T) {
+	repo := NewInMemoryRepository()
+
+	tasks := []*Task{
+		{ID: "1", Title: "Charlie", Priority: PriorityLow, CreatedAt: time.Date(2026, 1, 10, 10, 0, 0, 0, time.UTC)},
+		{ID: "2", Title: "Alpha", Priority: PriorityHigh, CreatedAt: time.Date(2026, 1, 11, 10, 0, 0, 0, time.UTC)},
+		{ID: "3", Title: "Bravo", Priority: PriorityMedium, CreatedAt: time.Date(2026, 1, 12, 10, 0, 0, 0, time.UTC)},
+	}
+	for _, tsk := range tasks {
+		require.NoError(t, repo.Create(context.Background(), tsk))
+	}
+
+	t.Run("sort_by title walks pages in title order, not created_at order", func(t *testing.T) {
+		filter := FilterOptions{SortBy: "title"}
+
+		page, hasNext, hasPrev, err := repo.GetAllKeyset(context.Background(), filter, nil, 2)
+		require.NoError(t, err)
+		require.Len(t, page, 2)
+		assert.True(t, hasNext)
+		assert.False(t, hasPrev)
+		assert.Equal(t, "2", page[0].ID) // Alpha
+		assert.Equal(t, "3", page[1].ID) // Bravo
+
+		cursor := CursorKey{SortBy: "title", LastValue: sortFieldValue(page[1], "title"), LastID: page[1].ID}
+		next, hasNext, hasPrev, err := repo.GetAllKeyset(context.Background(), filter, &cursor, 2)
+		require.NoError(t, err)
+		require.Len(t, next, 1)
+		assert.False(t, hasNext)
+		assert.True(t, hasPrev)
+		assert.Equal(t, "1", next[0].ID) // Charlie
+	})
+
+	t.Run("sort_by priority respects SortDesc", func(t *testing.T) {
+		filter := FilterOptions{SortBy: "priority", SortDesc: true}
+
+		page, hasNext, hasPrev, err := repo.GetAllKeyset(context.Background(), filter, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, page, 3)
+		assert.False(t, hasNext)
+		assert.False(t, hasPrev)
+		assert.Equal(t, "2", page[0].ID) // High
+		assert.Equal(t, "3", page[1].ID) // Medium
+		assert.Equal(t, "1", page[2].ID) // Low
+	})
+}
+
 func TestPriorityValue(t *testing.T) {
 	tests := []struct {
 		priority Priority
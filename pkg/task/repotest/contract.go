@@ -0,0 +1,257 @@
+// Package repotest is a shared contract test suite for task.Repository
+// implementations, analogous to net/http/httptest but for exercising a
+// Repository's CRUD and pagination behavior. It lets pkg/task's own
+// in-memory tests and a SQL-backed driver's tests (pkg/task/sqlite,
+// pkg/task/postgres) assert the exact same contract instead of each
+// hand-rolling their own copy.
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run exercises newRepo() (a fresh, empty Repository) against
+// task.Repository's full contract: CRUD, not-found errors, filtering, and
+// both pagination styles. Call it once per driver from that driver's own
+// _test.go, e.g.:
+//
+//	func TestRepository_Contract(t *testing.T) {
+//	    repotest.Run(t, func() task.Repository { return newTestRepository(t) })
+//	}
+func Run(t *testing.T, newRepo func() task.Repository) {
+	t.Helper()
+
+	t.Run("Create and GetByID", func(t *testing.T) {
+		repo := newRepo()
+		ctx := context.Background()
+		now := time.Now().UTC().Truncate(time.Second)
+
+		created := &task.Task{
+			ID:        "contract-1",
+			Title:     "Write contract tests",
+			Status:    task.StatusPending,
+			Priority:  task.PriorityHigh,
+			Tags:      []string{"testing", "repo"},
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		require.NoError(t, repo.Create(ctx, created))
+
+		got, err := repo.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, got.ID)
+		assert.Equal(t, created.Title, got.Title)
+		assert.Equal(t, created.Status, got.Status)
+		assert.Equal(t, created.Priority, got.Priority)
+		assert.ElementsMatch(t, created.Tags, got.Tags)
+	})
+
+	t.Run("GetByID of a missing task", func(t *testing.T) {
+		repo := newRepo()
+		_, err := repo.GetByID(context.Background(), "does-not-exist")
+		assert.ErrorIs(t, err, task.ErrTaskNotFound)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := newRepo()
+		ctx := context.Background()
+		now := time.Now().UTC().Truncate(time.Second)
+
+		original := &task.Task{
+			ID:        "contract-2",
+			Title:     "Before",
+			Status:    task.StatusPending,
+			Priority:  task.PriorityLow,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		require.NoError(t, repo.Create(ctx, original))
+
+		original.Title = "After"
+		original.Status = task.StatusCompleted
+		original.UpdatedAt = now.Add(time.Minute)
+		require.NoError(t, repo.Update(ctx, original))
+
+		got, err := repo.GetByID(ctx, original.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "After", got.Title)
+		assert.Equal(t, task.StatusCompleted, got.Status)
+	})
+
+	t.Run("Update of a missing task", func(t *testing.T) {
+		repo := newRepo()
+		err := repo.Update(context.Background(), &task.Task{ID: "does-not-exist"})
+		assert.ErrorIs(t, err, task.ErrTaskNotFound)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo()
+		ctx := context.Background()
+		now := time.Now().UTC()
+
+		require.NoError(t, repo.Create(ctx, &task.Task{
+			ID: "contract-3", Title: "Delete me",
+			Status: task.StatusPending, Priority: task.PriorityMedium,
+			CreatedAt: now, UpdatedAt: now,
+		}))
+		require.NoError(t, repo.Delete(ctx, "contract-3"))
+
+		_, err := repo.GetByID(ctx, "contract-3")
+		assert.ErrorIs(t, err, task.ErrTaskNotFound)
+	})
+
+	t.Run("Delete of a missing task", func(t *testing.T) {
+		repo := newRepo()
+		err := repo.Delete(context.Background(), "does-not-exist")
+		assert.ErrorIs(t, err, task.ErrTaskNotFound)
+	})
+
+	t.Run("GetAll filters by status, priority, and tag", func(t *testing.T) {
+		repo := newRepo()
+		ctx := context.Background()
+		now := time.Now().UTC()
+
+		seed(t, repo, []*task.Task{
+			{ID: "a", Title: "a", Status: task.StatusPending, Priority: task.PriorityHigh, Tags: []string{"urgent"}, CreatedAt: now, UpdatedAt: now},
+			{ID: "b", Title: "b", Status: task.StatusCompleted, Priority: task.PriorityHigh, Tags: []string{"urgent"}, CreatedAt: now.Add(time.Second), UpdatedAt: now},
+			{ID: "c", Title: "c", Status: task.StatusPending, Priority: task.PriorityLow, Tags: []string{"later"}, CreatedAt: now.Add(2 * time.Second), UpdatedAt: now},
+		})
+
+		pendingStatus := task.StatusPending
+		results, err := repo.GetAll(ctx, task.FilterOptions{Status: &pendingStatus})
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+
+		highPriority := task.PriorityHigh
+		results, err = repo.GetAll(ctx, task.FilterOptions{Priority: &highPriority})
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+
+		tag := "urgent"
+		results, err = repo.GetAll(ctx, task.FilterOptions{Tag: &tag})
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+	})
+
+	t.Run("GetAll sorts and paginates", func(t *testing.T) {
+		repo := newRepo()
+		ctx := context.Background()
+		now := time.Now().UTC()
+
+		seed(t, repo, []*task.Task{
+			{ID: "x", Title: "x", Status: task.StatusPending, Priority: task.PriorityMedium, CreatedAt: now, UpdatedAt: now},
+			{ID: "y", Title: "y", Status: task.StatusPending, Priority: task.PriorityMedium, CreatedAt: now.Add(time.Second), UpdatedAt: now},
+			{ID: "z", Title: "z", Status: task.StatusPending, Priority: task.PriorityMedium, CreatedAt: now.Add(2 * time.Second), UpdatedAt: now},
+		})
+
+		results, err := repo.GetAll(ctx, task.FilterOptions{SortBy: "created_at", SortDesc: true, Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "z", results[0].ID)
+		assert.Equal(t, "y", results[1].ID)
+	})
+
+	t.Run("CountMatching", func(t *testing.T) {
+		repo := newRepo()
+		ctx := context.Background()
+		now := time.Now().UTC()
+
+		seed(t, repo, []*task.Task{
+			{ID: "p", Title: "p", Status: task.StatusPending, Priority: task.PriorityMedium, CreatedAt: now, UpdatedAt: now},
+			{ID: "q", Title: "q", Status: task.StatusCompleted, Priority: task.PriorityMedium, CreatedAt: now, UpdatedAt: now},
+		})
+
+		count, err := repo.CountMatching(ctx, task.FilterOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		pendingStatus := task.StatusPending
+		count, err = repo.CountMatching(ctx, task.FilterOptions{Status: &pendingStatus})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("GetAllKeyset pages forward", func(t *testing.T) {
+		repo := newRepo()
+		ctx := context.Background()
+		now := time.Now().UTC()
+
+		seed(t, repo, []*task.Task{
+			{ID: "k1", Title: "k1", Status: task.StatusPending, Priority: task.PriorityMedium, CreatedAt: now, UpdatedAt: now},
+			{ID: "k2", Title: "k2", Status: task.StatusPending, Priority: task.PriorityMedium, CreatedAt: now.Add(time.Second), UpdatedAt: now},
+			{ID: "k3", Title: "k3", Status: task.StatusPending, Priority: task.PriorityMedium, CreatedAt: now.Add(2 * time.Second), UpdatedAt: now},
+		})
+
+		page1, hasNext, hasPrev, err := repo.GetAllKeyset(ctx, task.FilterOptions{}, nil, 2)
+		require.NoError(t, err)
+		require.Len(t, page1, 2)
+		assert.Equal(t, "k1", page1[0].ID)
+		assert.Equal(t, "k2", page1[1].ID)
+		assert.True(t, hasNext)
+		assert.False(t, hasPrev)
+
+		last := page1[len(page1)-1]
+		cursor := &task.CursorKey{SortBy: "created_at", LastValue: last.CreatedAt.UTC().Format(time.RFC3339Nano), LastID: last.ID}
+		page2, hasNext, hasPrev, err := repo.GetAllKeyset(ctx, task.FilterOptions{}, cursor, 2)
+		require.NoError(t, err)
+		require.Len(t, page2, 1)
+		assert.Equal(t, "k3", page2[0].ID)
+		assert.False(t, hasNext)
+		assert.True(t, hasPrev)
+	})
+
+	t.Run("Transaction rolls back on error", func(t *testing.T) {
+		repo := newRepo()
+		ctx := context.Background()
+		now := time.Now().UTC()
+
+		errRollback := assert.AnError
+		err := repo.Transaction(ctx, func(tx task.Repository) error {
+			require.NoError(t, tx.Create(ctx, &task.Task{
+				ID: "tx-1", Title: "should not persist",
+				Status: task.StatusPending, Priority: task.PriorityMedium,
+				CreatedAt: now, UpdatedAt: now,
+			}))
+			return errRollback
+		})
+		assert.ErrorIs(t, err, errRollback)
+
+		_, err = repo.GetByID(ctx, "tx-1")
+		assert.ErrorIs(t, err, task.ErrTaskNotFound)
+	})
+
+	t.Run("Transaction commits on success", func(t *testing.T) {
+		repo := newRepo()
+		ctx := context.Background()
+		now := time.Now().UTC()
+
+		err := repo.Transaction(ctx, func(tx task.Repository) error {
+			return tx.Create(ctx, &task.Task{
+				ID: "tx-2", Title: "should persist",
+				Status: task.StatusPending, Priority: task.PriorityMedium,
+				CreatedAt: now, UpdatedAt: now,
+			})
+		})
+		require.NoError(t, err)
+
+		got, err := repo.GetByID(ctx, "tx-2")
+		require.NoError(t, err)
+		assert.Equal(t, "tx-2", got.ID)
+	})
+}
+
+// seed creates each of tasks in repo, failing the test immediately if any
+// Create call errors.
+func seed(t *testing.T, repo task.Repository, tasks []*task.Task) {
+	t.Helper()
+	ctx := context.Background()
+	for _, tk := range tasks {
+		require.NoError(t, repo.Create(ctx, tk))
+	}
+}
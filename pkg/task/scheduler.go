@@ -0,0 +1,119 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Scheduler periodically re-reads every Task's Schedule field from a
+// Repository and keeps a robfig/cron entry in sync for each one, so
+// editing or clearing a Task's Schedule (or deleting the Task) takes
+// effect on the next Sync without restarting the process. Each firing
+// enqueues a TriggerScheduled Execution through an ExecutionManager.
+type Scheduler struct {
+	repo    Repository
+	manager *ExecutionManager
+	cron    *cron.Cron
+
+	mu      sync.Mutex
+	entries map[string]scheduledEntry
+}
+
+// scheduledEntry is what Scheduler tracks per scheduled Task, so a Sync
+// can tell whether a Task's Schedule changed since the entry was added.
+type scheduledEntry struct {
+	id       cron.EntryID
+	schedule string
+}
+
+// NewScheduler creates a Scheduler that syncs against repo on the schedule
+// described by syncSpec (a robfig/cron spec, e.g. "* * * * *" to re-read
+// every minute) and enqueues due executions through manager.
+func NewScheduler(repo Repository, manager *ExecutionManager, syncSpec string) (*Scheduler, error) {
+	s := &Scheduler{
+		repo:    repo,
+		manager: manager,
+		cron:    cron.New(),
+		entries: make(map[string]scheduledEntry),
+	}
+
+	if _, err := s.cron.AddFunc(syncSpec, s.syncNow); err != nil {
+		return nil, fmt.Errorf("invalid scheduler sync spec %q: %w", syncSpec, err)
+	}
+
+	return s, nil
+}
+
+// Start begins running Sync on its schedule in the background, and
+// performs an initial sync immediately so a task's Schedule set before
+// Start takes effect without waiting for the first tick.
+func (s *Scheduler) Start() {
+	s.syncNow()
+	s.cron.Start()
+}
+
+// Stop cancels the sync schedule and every per-task cron entry it added,
+// waiting for any in-flight sync to finish.
+func (s *Scheduler) Stop() {
+	<-s.cron.Stop().Done()
+}
+
+// syncNow is the cron.AddFunc body; it logs nothing and swallows Sync's
+// error since there's no request context to report it against, mirroring
+// Prefetcher.tick's fire-and-forget shape.
+func (s *Scheduler) syncNow() {
+	_ = s.Sync(context.Background())
+}
+
+// Sync reconciles s's cron entries against the current Schedule field of
+// every Task in repo: a Task with a new or changed Schedule gets its entry
+// (re)added, and a Task whose Schedule was cleared or which no longer
+// exists has its entry removed.
+func (s *Scheduler) Sync(ctx context.Context) error {
+	tasks, err := s.repo.GetAll(ctx, FilterOptions{})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := make(map[string]struct{}, len(tasks))
+	for _, t := range tasks {
+		if t.Schedule == "" {
+			continue
+		}
+		seen[t.ID] = struct{}{}
+
+		if existing, ok := s.entries[t.ID]; ok {
+			if existing.schedule == t.Schedule {
+				continue
+			}
+			s.cron.Remove(existing.id)
+		}
+
+		taskID := t.ID
+		entryID, err := s.cron.AddFunc(t.Schedule, func() {
+			_, _ = s.manager.Trigger(context.Background(), taskID, TriggerScheduled)
+		})
+		if err != nil {
+			// An unparsable Schedule (e.g. edited to something invalid
+			// after creation) just isn't scheduled until it's fixed,
+			// rather than aborting the rest of the sync.
+			continue
+		}
+		s.entries[t.ID] = scheduledEntry{id: entryID, schedule: t.Schedule}
+	}
+
+	for id, entry := range s.entries {
+		if _, ok := seen[id]; !ok {
+			s.cron.Remove(entry.id)
+			delete(s.entries, id)
+		}
+	}
+
+	return nil
+}
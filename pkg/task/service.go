@@ -1,6 +1,7 @@
 package task
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"time"
@@ -20,15 +21,33 @@ var (
 // Service handles business logic for tasks
 type Service struct {
 	repo Repository
+	bus  *EventBus
 }
 
-// NewService creates a new task service
+// NewService creates a new task service with a default-sized EventBus.
 func NewService(repo Repository) *Service {
+	return NewServiceWithEventRingSize(repo, DefaultEventRingBufferSize)
+}
+
+// NewServiceWithEventRingSize creates a task service whose change-event
+// stream (see Subscribe) retains up to ringSize recent events for
+// Last-Event-ID replay.
+func NewServiceWithEventRingSize(repo Repository, ringSize int) *Service {
 	return &Service{
 		repo: repo,
+		bus:  NewEventBus(ringSize),
 	}
 }
 
+// Subscribe streams task mutation events as Service.Create/Update/Delete
+// publish them. A nonzero lastEventID - typically parsed from a
+// reconnecting SSE client's Last-Event-ID header - first replays whatever
+// buffered events the subscriber missed; see EventBus.SubscribeFrom. The
+// returned channel closes once ctx is done.
+func (s *Service) Subscribe(ctx context.Context, lastEventID uint64) <-chan Event {
+	return s.bus.SubscribeFrom(ctx, lastEventID)
+}
+
 // CreateTaskRequest represents the data needed to create a task
 type CreateTaskRequest struct {
 	Title       string     `json:"title"`
@@ -37,6 +56,13 @@ type CreateTaskRequest struct {
 	Status      string     `json:"status,omitempty"`
 	DueDate     *time.Time `json:"due_date,omitempty"`
 	Tags        []string   `json:"tags,omitempty"`
+	Schedule    string     `json:"schedule,omitempty"`
+	// CreatedBy is the authenticated principal's subject (see
+	// internal/auth.Principal). It has no json tag so a client can never
+	// set it through the request body - the handler fills it in after
+	// binding, from whatever internal/auth.Middleware attached to the
+	// request.
+	CreatedBy string `json:"-"`
 }
 
 // UpdateTaskRequest represents the data for updating a task
@@ -47,6 +73,11 @@ type UpdateTaskRequest struct {
 	Status      *string    `json:"status,omitempty"`
 	DueDate     *time.Time `json:"due_date,omitempty"`
 	Tags        []string   `json:"tags,omitempty"`
+	Schedule    *string    `json:"schedule,omitempty"`
+	// UpdatedBy is the authenticated principal's subject, filled in by the
+	// handler the same way CreateTaskRequest.CreatedBy is; never settable
+	// by a client.
+	UpdatedBy string `json:"-"`
 }
 
 // ValidateCreateRequest validates a create task request (pure function)
@@ -86,6 +117,9 @@ func BuildTaskFromRequest(req CreateTaskRequest, now time.Time) *Task {
 		Priority:    priority,
 		DueDate:     req.DueDate,
 		Tags:        req.Tags,
+		Schedule:    req.Schedule,
+		CreatedBy:   req.CreatedBy,
+		UpdatedBy:   req.CreatedBy,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
@@ -129,39 +163,161 @@ func ApplyUpdate(task *Task, req UpdateTaskRequest, now time.Time) (*Task, error
 		updated.Tags = req.Tags
 	}
 
+	if req.Schedule != nil {
+		updated.Schedule = *req.Schedule
+	}
+
+	if req.UpdatedBy != "" {
+		updated.UpdatedBy = req.UpdatedBy
+	}
+
 	updated.UpdatedAt = now
 
 	return &updated, nil
 }
 
 // Create creates a new task
-func (s *Service) Create(req CreateTaskRequest) (*Task, error) {
+func (s *Service) Create(ctx context.Context, req CreateTaskRequest) (*Task, error) {
 	if err := ValidateCreateRequest(req); err != nil {
 		return nil, err
 	}
 
 	task := BuildTaskFromRequest(req, time.Now())
 
-	if err := s.repo.Create(task); err != nil {
+	if err := s.repo.Create(ctx, task); err != nil {
 		return nil, err
 	}
 
+	s.bus.Publish(Event{Type: EventCreated, Task: task})
+
 	return task, nil
 }
 
 // GetByID retrieves a task by ID
-func (s *Service) GetByID(id string) (*Task, error) {
-	return s.repo.GetByID(id)
+func (s *Service) GetByID(ctx context.Context, id string) (*Task, error) {
+	return s.repo.GetByID(ctx, id)
 }
 
 // GetAll retrieves all tasks with optional filters
-func (s *Service) GetAll(filter FilterOptions) ([]*Task, error) {
-	return s.repo.GetAll(filter)
+func (s *Service) GetAll(ctx context.Context, filter FilterOptions) ([]*Task, error) {
+	return s.repo.GetAll(ctx, filter)
+}
+
+// FacetCounts maps a field's values to how many matching tasks carry that
+// value, e.g. Facets.Status["pending"].
+type FacetCounts map[string]int
+
+// Facets summarizes every task matching a filter by status, priority, and
+// tag - the counts a faceted-search UI renders beside its filter controls.
+type Facets struct {
+	Status   FacetCounts `json:"status"`
+	Priority FacetCounts `json:"priority"`
+	Tag      FacetCounts `json:"tag"`
+}
+
+// Facets returns status/priority/tag counts across every task matching
+// filter, ignoring filter.Limit/Offset so the counts describe the whole
+// result set rather than one page of it.
+func (s *Service) Facets(ctx context.Context, filter FilterOptions) (Facets, error) {
+	unpaged := filter
+	unpaged.Limit = 0
+	unpaged.Offset = 0
+
+	tasks, err := s.repo.GetAll(ctx, unpaged)
+	if err != nil {
+		return Facets{}, err
+	}
+
+	facets := Facets{Status: FacetCounts{}, Priority: FacetCounts{}, Tag: FacetCounts{}}
+	for _, t := range tasks {
+		facets.Status[string(t.Status)]++
+		facets.Priority[string(t.Priority)]++
+		for _, tag := range t.Tags {
+			facets.Tag[tag]++
+		}
+	}
+	return facets, nil
+}
+
+// CursorPage is one page of a cursor-paginated task listing.
+type CursorPage struct {
+	Tasks      []*Task
+	NextCursor string
+	PrevCursor string
+	TotalCount int
+}
+
+// DefaultCursorPageLimit is the page size GetAllCursor falls back to when
+// the caller doesn't request one.
+const DefaultCursorPageLimit = 20
+
+// GetAllCursor lists tasks matching filter using opaque, HMAC-signed
+// cursor pagination instead of GetAll's limit/offset: every page is
+// ordered by (filter.SortBy, id), so unlike an offset page it doesn't
+// shift when tasks are inserted or deleted elsewhere in the table. key
+// signs and verifies the cursor; an empty encodedCursor starts from the
+// first page, and an encodedCursor that doesn't verify against key, or was
+// minted under a different sort_by than filter now requests, returns
+// ErrInvalidCursor.
+func (s *Service) GetAllCursor(ctx context.Context, filter FilterOptions, key []byte, encodedCursor string, limit int) (*CursorPage, error) {
+	if limit <= 0 {
+		limit = DefaultCursorPageLimit
+	}
+
+	sortBy := effectiveSortBy(filter.SortBy)
+
+	var cursor *CursorKey
+	if encodedCursor != "" {
+		decoded, err := decodeCursor(key, encodedCursor)
+		if err != nil {
+			return nil, err
+		}
+		if decoded.SortBy != sortBy {
+			return nil, ErrInvalidCursor
+		}
+		cursor = &decoded
+	}
+
+	tasks, hasNext, hasPrev, err := s.repo.GetAllKeyset(ctx, filter, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.repo.CountMatching(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &CursorPage{Tasks: tasks, TotalCount: total}
+
+	if len(tasks) == 0 {
+		return page, nil
+	}
+
+	if hasNext {
+		last := tasks[len(tasks)-1]
+		next, err := encodeCursor(key, CursorKey{SortBy: sortBy, LastValue: sortFieldValue(last, sortBy), LastID: last.ID})
+		if err != nil {
+			return nil, err
+		}
+		page.NextCursor = next
+	}
+
+	if hasPrev {
+		first := tasks[0]
+		prev, err := encodeCursor(key, CursorKey{SortBy: sortBy, LastValue: sortFieldValue(first, sortBy), LastID: first.ID, Before: true})
+		if err != nil {
+			return nil, err
+		}
+		page.PrevCursor = prev
+	}
+
+	return page, nil
 }
 
 // Update updates an existing task
-func (s *Service) Update(id string, req UpdateTaskRequest) (*Task, error) {
-	existingTask, err := s.repo.GetByID(id)
+func (s *Service) Update(ctx context.Context, id string, req UpdateTaskRequest) (*Task, error) {
+	existingTask, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -171,14 +327,25 @@ func (s *Service) Update(id string, req UpdateTaskRequest) (*Task, error) {
 		return nil, err
 	}
 
-	if err := s.repo.Update(updatedTask); err != nil {
+	if err := s.repo.Update(ctx, updatedTask); err != nil {
 		return nil, err
 	}
 
+	s.bus.Publish(Event{Type: EventUpdated, Task: updatedTask})
+
 	return updatedTask, nil
 }
 
-// Delete removes a task by ID
-func (s *Service) Delete(id string) error {
-	return s.repo.Delete(id)
+// Delete removes a task by ID. The published EventDeleted only carries the
+// task's ID, since Repository.Delete doesn't return the deleted row and
+// fetching it first would turn an already-missing task into a 404 before
+// Delete gets a chance to report it.
+func (s *Service) Delete(ctx context.Context, id string) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.bus.Publish(Event{Type: EventDeleted, Task: &Task{ID: id}})
+
+	return nil
 }
@@ -1,6 +1,7 @@
 package task
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -261,7 +262,7 @@ func TestServiceCreate(t *testing.T) {
 			repo := NewInMemoryRepository()
 			service := NewService(repo)
 
-			task, err := service.Create(tt.req)
+			task, err := service.Create(context.Background(), tt.req)
 
 			if tt.wantErr != nil {
 				assert.ErrorIs(t, err, tt.wantErr)
@@ -281,7 +282,7 @@ func TestServiceGetByID(t *testing.T) {
 	service := NewService(repo)
 
 	// Create a task
-	created, err := service.Create(CreateTaskRequest{Title: "Test task"})
+	created, err := service.Create(context.Background(), CreateTaskRequest{Title: "Test task"})
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -303,7 +304,7 @@ func TestServiceGetByID(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			task, err := service.GetByID(tt.id)
+			task, err := service.GetByID(context.Background(), tt.id)
 
 			if tt.wantErr != nil {
 				assert.ErrorIs(t, err, tt.wantErr)
@@ -322,9 +323,9 @@ func TestServiceGetAll(t *testing.T) {
 	service := NewService(repo)
 
 	// Create test tasks
-	_, err := service.Create(CreateTaskRequest{Title: "Task 1", Priority: "high", Status: "pending"})
+	_, err := service.Create(context.Background(), CreateTaskRequest{Title: "Task 1", Priority: "high", Status: "pending"})
 	require.NoError(t, err)
-	_, err = service.Create(CreateTaskRequest{Title: "Task 2", Priority: "low", Status: "completed"})
+	_, err = service.Create(context.Background(), CreateTaskRequest{Title: "Task 2", Priority: "low", Status: "completed"})
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -363,19 +364,47 @@ func TestServiceGetAll(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tasks, err := service.GetAll(tt.filter)
+			tasks, err := service.GetAll(context.Background(), tt.filter)
 			require.NoError(t, err)
 			assert.Len(t, tasks, tt.wantCount)
 		})
 	}
 }
 
+func TestServiceFacets(t *testing.T) {
+	repo := NewInMemoryRepository()
+	service := NewService(repo)
+	ctx := context.Background()
+
+	_, err := service.Create(ctx, CreateTaskRequest{Title: "Task 1", Priority: "high", Status: "pending", Tags: []string{"work"}})
+	require.NoError(t, err)
+	_, err = service.Create(ctx, CreateTaskRequest{Title: "Task 2", Priority: "low", Status: "completed", Tags: []string{"work", "urgent"}})
+	require.NoError(t, err)
+	_, err = service.Create(ctx, CreateTaskRequest{Title: "Task 3", Priority: "low", Status: "pending"})
+	require.NoError(t, err)
+
+	facets, err := service.Facets(ctx, FilterOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, facets.Status["pending"])
+	assert.Equal(t, 1, facets.Status["completed"])
+	assert.Equal(t, 2, facets.Priority["low"])
+	assert.Equal(t, 1, facets.Priority["high"])
+	assert.Equal(t, 2, facets.Tag["work"])
+	assert.Equal(t, 1, facets.Tag["urgent"])
+
+	narrowed, err := service.Facets(ctx, FilterOptions{Priority: priorityPtr(PriorityLow)})
+	require.NoError(t, err)
+	assert.Equal(t, 2, narrowed.Priority["low"])
+	assert.Equal(t, 0, narrowed.Priority["high"])
+}
+
 func TestServiceUpdate(t *testing.T) {
 	repo := NewInMemoryRepository()
 	service := NewService(repo)
 
 	// Create a task
-	created, err := service.Create(CreateTaskRequest{Title: "Original"})
+	created, err := service.Create(context.Background(), CreateTaskRequest{Title: "Original"})
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -412,7 +441,7 @@ func TestServiceUpdate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			task, err := service.Update(tt.id, tt.req)
+			task, err := service.Update(context.Background(), tt.id, tt.req)
 
 			if tt.wantErr != nil {
 				assert.ErrorIs(t, err, tt.wantErr)
@@ -433,7 +462,7 @@ func TestServiceDelete(t *testing.T) {
 	service := NewService(repo)
 
 	// Create a task
-	created, err := service.Create(CreateTaskRequest{Title: "To delete"})
+	created, err := service.Create(context.Background(), CreateTaskRequest{Title: "To delete"})
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -455,7 +484,7 @@ func TestServiceDelete(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := service.Delete(tt.id)
+			err := service.Delete(context.Background(), tt.id)
 
 			if tt.wantErr != nil {
 				assert.ErrorIs(t, err, tt.wantErr)
@@ -463,7 +492,7 @@ func TestServiceDelete(t *testing.T) {
 				assert.NoError(t, err)
 
 				// Verify task is deleted
-				_, err := service.GetByID(tt.id)
+				_, err := service.GetByID(context.Background(), tt.id)
 				assert.ErrorIs(t, err, ErrTaskNotFound)
 			}
 		})
@@ -0,0 +1,484 @@
+// Package sqlite implements task.Repository against a SQLite database via
+// database/sql and modernc.org/sqlite (a pure-Go driver, so it needs no
+// cgo toolchain at build time), as a durable, single-file alternative to
+// task.InMemoryRepository and the Postgres-only pkg/task/postgres for
+// deployments that want persistence without running a separate database
+// server.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/task"
+	_ "modernc.org/sqlite"
+)
+
+// querier is the subset of *sql.DB and *sql.Tx that Repository needs, so
+// the same query methods work whether Repository is bound to the database
+// directly or to a transaction started by Transaction.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Repository implements task.Repository against SQLite using database/sql.
+type Repository struct {
+	db querier
+}
+
+// Open opens (creating if necessary) the SQLite database file at path and
+// configures it for single-process use. SQLite allows only one writer at a
+// time; capping the pool at a single connection avoids a writer and a
+// concurrent reader racing over two separate connections and surfacing a
+// spurious "database is locked" error that a connection pool would
+// otherwise risk.
+func Open(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: failed to open %s: %w", path, err)
+	}
+	db.SetMaxOpenConns(1)
+	return db, nil
+}
+
+// NewRepository creates a Repository backed by db.
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// AutoMigrate creates the tasks schema (the tasks table and its
+// task_tags side table, each with the indexes GetAll/GetAllKeyset query
+// against) if it doesn't already exist.
+func AutoMigrate(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS tasks (
+			id          TEXT PRIMARY KEY,
+			title       TEXT NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			status      TEXT NOT NULL,
+			priority    TEXT NOT NULL,
+			due_date    TEXT,
+			schedule    TEXT NOT NULL DEFAULT '',
+			created_by  TEXT NOT NULL DEFAULT '',
+			updated_by  TEXT NOT NULL DEFAULT '',
+			created_at  TEXT NOT NULL,
+			updated_at  TEXT NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks (status);
+		CREATE INDEX IF NOT EXISTS idx_tasks_priority ON tasks (priority);
+		CREATE INDEX IF NOT EXISTS idx_tasks_created_at_id ON tasks (created_at, id);
+
+		-- task_tags is a side table rather than a denormalized column
+		-- because SQLite has no array type; keeping one row per
+		-- (task_id, tag) is what lets FilterOptions.Tag be matched with
+		-- an indexed lookup instead of a Go-side scan.
+		CREATE TABLE IF NOT EXISTS task_tags (
+			task_id TEXT NOT NULL,
+			tag     TEXT NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_task_tags_tag_task ON task_tags (tag, task_id);
+		CREATE INDEX IF NOT EXISTS idx_task_tags_task_id ON task_tags (task_id);
+
+		-- event_types is comma-joined rather than a side table (unlike
+		-- task_tags) since it's never filtered on in SQL - matching
+		-- happens Go-side in task.matchesWebhook against the small
+		-- number of registered webhooks.
+		CREATE TABLE IF NOT EXISTS webhooks (
+			id          TEXT PRIMARY KEY,
+			url         TEXT NOT NULL,
+			secret      TEXT NOT NULL DEFAULT '',
+			event_types TEXT NOT NULL DEFAULT '',
+			status      TEXT NOT NULL DEFAULT '',
+			priority    TEXT NOT NULL DEFAULT '',
+			tag         TEXT NOT NULL DEFAULT '',
+			created_at  TEXT NOT NULL
+		);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to migrate tasks schema: %w", err)
+	}
+	return nil
+}
+
+// Create inserts a new task.
+func (r *Repository) Create(ctx context.Context, t *task.Task) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO tasks (id, title, description, status, priority, due_date, schedule, created_by, updated_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, t.ID, t.Title, t.Description, t.Status, t.Priority, nullableTime(t.DueDate), t.Schedule, t.CreatedBy, t.UpdatedBy,
+		t.CreatedAt.UTC().Format(time.RFC3339Nano), t.UpdatedAt.UTC().Format(time.RFC3339Nano))
+	if err != nil {
+		return err
+	}
+	return r.setTags(ctx, t.ID, t.Tags)
+}
+
+// GetByID retrieves a task by its ID, returning task.ErrTaskNotFound if no
+// row matches.
+func (r *Repository) GetByID(ctx context.Context, id string) (*task.Task, error) {
+	row := r.db.QueryRowContext(ctx, selectColumns+" FROM tasks WHERE id = ?", id)
+
+	t, err := scanTask(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, task.ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Tags, err = r.tagsFor(ctx, t.ID); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// GetAll retrieves every task matching filter, sorted and paginated the
+// same way task.InMemoryRepository.GetAll is.
+func (r *Repository) GetAll(ctx context.Context, filter task.FilterOptions) ([]*task.Task, error) {
+	query, args := buildFilterQuery(filter)
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = -1 // SQLite treats a negative LIMIT as unbounded
+	}
+	query += fmt.Sprintf(" ORDER BY %s LIMIT ? OFFSET ?", orderByClause(filter))
+	args = append(args, limit, filter.Offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*task.Task
+	for rows.Next() {
+		t, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.attachTags(ctx, tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// Update modifies an existing task, returning task.ErrTaskNotFound if no
+// row matches t.ID.
+func (r *Repository) Update(ctx context.Context, t *task.Task) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE tasks SET title = ?, description = ?, status = ?, priority = ?,
+			due_date = ?, schedule = ?, updated_by = ?, updated_at = ?
+		WHERE id = ?
+	`, t.Title, t.Description, t.Status, t.Priority, nullableTime(t.DueDate), t.Schedule, t.UpdatedBy,
+		t.UpdatedAt.UTC().Format(time.RFC3339Nano), t.ID)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return task.ErrTaskNotFound
+	}
+	return r.setTags(ctx, t.ID, t.Tags)
+}
+
+// Delete removes a task, returning task.ErrTaskNotFound if no row matches
+// id.
+func (r *Repository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM tasks WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return task.ErrTaskNotFound
+	}
+	_, err = r.db.ExecContext(ctx, "DELETE FROM task_tags WHERE task_id = ?", id)
+	return err
+}
+
+// Transaction runs fn against a Repository bound to a single database
+// transaction, committing fn's writes only if fn returns nil and rolling
+// back all of them otherwise. Only usable when Repository was built with
+// NewRepository against a *sql.DB; calling Transaction on a Repository
+// already inside a transaction returns an error, mirroring
+// pkg/task/postgres.Repository.Transaction.
+func (r *Repository) Transaction(ctx context.Context, fn func(task.Repository) error) error {
+	db, ok := r.db.(*sql.DB)
+	if !ok {
+		return fmt.Errorf("sqlite: Transaction called on a Repository that is already inside a transaction")
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(&Repository{db: tx}); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetAllKeyset implements the task.Repository.GetAllKeyset keyset-pagination
+// contract described on the interface, mirroring
+// pkg/task/postgres.Repository.GetAllKeyset.
+func (r *Repository) GetAllKeyset(ctx context.Context, filter task.FilterOptions, cursor *task.CursorKey, limit int) ([]*task.Task, bool, bool, error) {
+	query, args := buildFilterQuery(filter)
+
+	column := sortColumn(filter)
+
+	scanDesc := filter.SortDesc
+	if cursor != nil && cursor.Before {
+		scanDesc = !scanDesc
+	}
+	dir := "ASC"
+	if scanDesc {
+		dir = "DESC"
+	}
+
+	if cursor != nil {
+		op := ">"
+		if scanDesc {
+			op = "<"
+		}
+		query += fmt.Sprintf(" AND (%s, id) %s (?, ?)", column, op)
+		args = append(args, cursor.LastValue, cursor.LastID)
+	}
+
+	query += fmt.Sprintf(" ORDER BY %s %s, id %s LIMIT ?", column, dir, dir)
+	args = append(args, limit+1)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, false, false, err
+	}
+	defer rows.Close()
+
+	var tasks []*task.Task
+	for rows.Next() {
+		t, err := scanTask(rows)
+		if err != nil {
+			return nil, false, false, err
+		}
+		tasks = append(tasks, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, false, err
+	}
+
+	if err := r.attachTags(ctx, tasks); err != nil {
+		return nil, false, false, err
+	}
+
+	hasMore := len(tasks) > limit
+	if hasMore {
+		tasks = tasks[:limit]
+	}
+
+	if cursor != nil && cursor.Before {
+		for i, j := 0, len(tasks)-1; i < j; i, j = i+1, j-1 {
+			tasks[i], tasks[j] = tasks[j], tasks[i]
+		}
+		return tasks, true, hasMore, nil
+	}
+
+	return tasks, hasMore, cursor != nil, nil
+}
+
+// CountMatching returns how many tasks match filter.
+func (r *Repository) CountMatching(ctx context.Context, filter task.FilterOptions) (int, error) {
+	query, args := buildFilterQuery(filter)
+	query = strings.Replace(query, selectColumns, "SELECT COUNT(*)", 1)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// setTags replaces task_id's rows in task_tags with tags.
+func (r *Repository) setTags(ctx context.Context, taskID string, tags []string) error {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM task_tags WHERE task_id = ?", taskID); err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		if _, err := r.db.ExecContext(ctx, "INSERT INTO task_tags (task_id, tag) VALUES (?, ?)", taskID, tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tagsFor returns taskID's tags in a deterministic order.
+func (r *Repository) tagsFor(ctx context.Context, taskID string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT tag FROM task_tags WHERE task_id = ? ORDER BY tag", taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// attachTags fills in Tags on each of tasks with a tagsFor lookup. It's a
+// query per task rather than a single batched IN (...) lookup, trading
+// some efficiency on large pages for code that reuses tagsFor as-is.
+func (r *Repository) attachTags(ctx context.Context, tasks []*task.Task) error {
+	for _, t := range tasks {
+		tags, err := r.tagsFor(ctx, t.ID)
+		if err != nil {
+			return err
+		}
+		t.Tags = tags
+	}
+	return nil
+}
+
+// rowScanner is satisfied by both *sql.Row (QueryRowContext) and *sql.Rows
+// (QueryContext), so scanTask can be shared between single-row and
+// multi-row callers.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+const selectColumns = "SELECT id, title, description, status, priority, due_date, schedule, created_by, updated_by, created_at, updated_at"
+
+func scanTask(row rowScanner) (*task.Task, error) {
+	var t task.Task
+	var dueDate sql.NullString
+	var createdAt, updatedAt string
+
+	if err := row.Scan(&t.ID, &t.Title, &t.Description, &t.Status, &t.Priority, &dueDate, &t.Schedule,
+		&t.CreatedBy, &t.UpdatedBy, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	if dueDate.Valid {
+		parsed, err := time.Parse(time.RFC3339Nano, dueDate.String)
+		if err != nil {
+			return nil, err
+		}
+		t.DueDate = &parsed
+	}
+
+	parsedCreated, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	t.CreatedAt = parsedCreated
+
+	parsedUpdated, err := time.Parse(time.RFC3339Nano, updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	t.UpdatedAt = parsedUpdated
+
+	return &t, nil
+}
+
+// nullableTime returns t formatted as RFC3339Nano, or nil (SQL NULL) if t
+// is nil.
+func nullableTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// buildFilterQuery returns the SELECT and WHERE clauses matching filter's
+// status/priority/tag/query/tags criteria, the SQL equivalent of
+// task.MatchesFilter/matchesFilter. Query uses a LIKE scan rather than
+// FTS5, consistent with this package's goal of durability over query
+// performance; SQLite's LIKE is case-insensitive for ASCII by default.
+func buildFilterQuery(filter task.FilterOptions) (string, []interface{}) {
+	query := selectColumns + " FROM tasks WHERE 1=1"
+	var args []interface{}
+
+	if filter.Status != nil {
+		args = append(args, string(*filter.Status))
+		query += " AND status = ?"
+	}
+	if filter.Priority != nil {
+		args = append(args, string(*filter.Priority))
+		query += " AND priority = ?"
+	}
+	if filter.Tag != nil {
+		args = append(args, *filter.Tag)
+		query += " AND id IN (SELECT task_id FROM task_tags WHERE tag = ?)"
+	}
+	if filter.Query != "" {
+		like := "%" + filter.Query + "%"
+		args = append(args, like, like)
+		query += " AND (title LIKE ? OR description LIKE ?)"
+	}
+	if len(filter.Tags) > 0 {
+		if filter.TagsMode == task.TagsModeAll {
+			for _, tag := range filter.Tags {
+				args = append(args, tag)
+				query += " AND id IN (SELECT task_id FROM task_tags WHERE tag = ?)"
+			}
+		} else {
+			placeholders := make([]string, len(filter.Tags))
+			for i, tag := range filter.Tags {
+				placeholders[i] = "?"
+				args = append(args, tag)
+			}
+			query += " AND id IN (SELECT task_id FROM task_tags WHERE tag IN (" + strings.Join(placeholders, ",") + "))"
+		}
+	}
+
+	return query, args
+}
+
+// sortColumn maps filter.SortBy to its backing column, mirroring
+// pkg/task/postgres.sortColumn's FilterOptions.SortBy handling so GetAll's
+// ORDER BY and GetAllKeyset's cursor comparison agree on the same column.
+func sortColumn(filter task.FilterOptions) string {
+	switch filter.SortBy {
+	case "updated_at":
+		return "updated_at"
+	case "due_date":
+		return "due_date"
+	case "priority":
+		return "priority"
+	case "title":
+		return "title"
+	default:
+		return "created_at"
+	}
+}
+
+// orderByClause builds the SQL ORDER BY clause for filter.SortBy/SortDesc,
+// mirroring task.sortTasks's defaulting and column mapping.
+func orderByClause(filter task.FilterOptions) string {
+	dir := "ASC"
+	if filter.SortDesc {
+		dir = "DESC"
+	}
+	return fmt.Sprintf("%s %s", sortColumn(filter), dir)
+}
@@ -0,0 +1,31 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/f00b455/blank-go/pkg/task"
+	"github.com/f00b455/blank-go/pkg/task/repotest"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRepository opens an in-memory SQLite database (a fresh one per
+// call, per SQLite's ":memory:" convention) and migrates it, so each test
+// gets an isolated, empty Repository.
+func newTestRepository(t *testing.T) *Repository {
+	t.Helper()
+
+	db, err := Open(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	require.NoError(t, AutoMigrate(context.Background(), db))
+
+	return NewRepository(db)
+}
+
+func TestRepository_Contract(t *testing.T) {
+	repotest.Run(t, func() task.Repository {
+		return newTestRepository(t)
+	})
+}
@@ -0,0 +1,142 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/f00b455/blank-go/pkg/task"
+	"github.com/google/uuid"
+)
+
+// WebhookRepository implements task.WebhookRepository against SQLite,
+// durably persisting registrations so they survive a process restart.
+type WebhookRepository struct {
+	db querier
+}
+
+// NewWebhookRepository creates a WebhookRepository backed by db.
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// CreateWebhook inserts webhook, assigning it an ID and CreatedAt if
+// unset.
+func (r *WebhookRepository) CreateWebhook(ctx context.Context, webhook *task.Webhook) error {
+	if webhook.ID == "" {
+		webhook.ID = uuid.NewString()
+	}
+	if webhook.CreatedAt.IsZero() {
+		webhook.CreatedAt = time.Now()
+	}
+
+	eventTypes := make([]string, len(webhook.EventTypes))
+	for i, t := range webhook.EventTypes {
+		eventTypes[i] = string(t)
+	}
+
+	var status, priority, tag string
+	if webhook.Filter.Status != nil {
+		status = string(*webhook.Filter.Status)
+	}
+	if webhook.Filter.Priority != nil {
+		priority = string(*webhook.Filter.Priority)
+	}
+	if webhook.Filter.Tag != nil {
+		tag = *webhook.Filter.Tag
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO webhooks (id, url, secret, event_types, status, priority, tag, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		webhook.ID, webhook.URL, webhook.Secret, strings.Join(eventTypes, ","),
+		status, priority, tag, webhook.CreatedAt.Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to create webhook: %w", err)
+	}
+	return nil
+}
+
+// GetAllWebhooks returns every registered webhook.
+func (r *WebhookRepository) GetAllWebhooks(ctx context.Context) ([]*task.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, url, secret, event_types, status, priority, tag, created_at FROM webhooks`)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: failed to query webhooks: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var webhooks []*task.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: failed to scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: failed to read webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// DeleteWebhook removes a webhook by ID, returning task.ErrWebhookNotFound
+// if no such webhook is registered.
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to delete webhook: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to confirm webhook deletion: %w", err)
+	}
+	if rows == 0 {
+		return task.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// scanWebhook scans a single webhooks row, reassembling event_types and
+// the status/priority/tag filter columns back into a task.Webhook.
+func scanWebhook(row *sql.Rows) (*task.Webhook, error) {
+	var (
+		webhook                          task.Webhook
+		eventTypesJoined                 string
+		status, priority, tag, createdAt string
+	)
+
+	if err := row.Scan(&webhook.ID, &webhook.URL, &webhook.Secret, &eventTypesJoined,
+		&status, &priority, &tag, &createdAt); err != nil {
+		return nil, err
+	}
+
+	if eventTypesJoined != "" {
+		for _, t := range strings.Split(eventTypesJoined, ",") {
+			webhook.EventTypes = append(webhook.EventTypes, task.EventType(t))
+		}
+	}
+	if status != "" {
+		s := task.Status(status)
+		webhook.Filter.Status = &s
+	}
+	if priority != "" {
+		p := task.Priority(priority)
+		webhook.Filter.Priority = &p
+	}
+	if tag != "" {
+		webhook.Filter.Tag = &tag
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, errors.New("sqlite: invalid webhook created_at timestamp")
+	}
+	webhook.CreatedAt = parsed
+
+	return &webhook, nil
+}
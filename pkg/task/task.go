@@ -37,8 +37,21 @@ type Task struct {
 	Priority    Priority   `json:"priority"`
 	DueDate     *time.Time `json:"due_date,omitempty"`
 	Tags        []string   `json:"tags,omitempty"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
+	// Schedule is an optional robfig/cron spec (e.g. "0 * * * *"). When
+	// set, Scheduler.Sync picks it up and enqueues a TriggerScheduled
+	// Execution of this task on each firing, alongside any manually
+	// triggered executions.
+	Schedule string `json:"schedule,omitempty"`
+	// CreatedBy and UpdatedBy are the authenticated principal's subject
+	// (internal/auth.Principal.Subject) that created/last updated this
+	// task, or empty when auth is disabled. Service.Create/Update stamp
+	// these from the caller-supplied subject rather than handlers writing
+	// straight to the struct, so every Repository implementation gets them
+	// for free.
+	CreatedBy string    `json:"created_by,omitempty"`
+	UpdatedBy string    `json:"updated_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // IsValidStatus checks if a status string is valid
@@ -60,8 +73,21 @@ type FilterOptions struct {
 	Status   *Status
 	Priority *Priority
 	Tag      *string
+	// Query performs a case-insensitive substring search across Title and
+	// Description when non-empty.
+	Query string
+	// Tags restricts results to tasks carrying these tags, combined
+	// according to TagsMode. Independent of, and additive with, Tag.
+	Tags []string
+	// TagsMode is "any" (match if the task has at least one of Tags, the
+	// default) or "all" (match only if it has every one of Tags).
+	TagsMode string
 	Limit    int
 	Offset   int
 	SortBy   string
 	SortDesc bool
 }
+
+// TagsModeAll requires a task to carry every one of FilterOptions.Tags to
+// match; any other (or unset) TagsMode requires only one of them.
+const TagsModeAll = "all"
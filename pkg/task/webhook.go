@@ -0,0 +1,110 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrWebhookNotFound is returned when a webhook ID has no registration.
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// Webhook is an outbound HTTPS subscription registered against
+// Service.Subscribe's event stream: every Event matching EventTypes and
+// Filter is POSTed to URL, signed with Secret (see WebhookDispatcher).
+type Webhook struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// Secret signs each delivery's body via WebhookDispatcher's HMAC-SHA256
+	// X-Task-Signature header; never serialized back out.
+	Secret string `json:"-"`
+	// EventTypes restricts delivery to these Event.Types; empty means
+	// every type.
+	EventTypes []EventType `json:"event_types,omitempty"`
+	// Filter restricts delivery to events whose Task matches, reusing
+	// FilterOptions' Status/Priority/Tag fields via matchesFilter. Its
+	// Limit/Offset/SortBy fields are meaningless here and ignored.
+	Filter    FilterOptions `json:"-"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// WebhookRepository persists Webhook registrations so they survive a
+// process restart, the same Repository-layer responsibility task.Task
+// storage has.
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, webhook *Webhook) error
+	GetAllWebhooks(ctx context.Context) ([]*Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+}
+
+// InMemoryWebhookRepository implements WebhookRepository using in-memory
+// storage, mirroring InMemoryRepository's shape.
+type InMemoryWebhookRepository struct {
+	mu       sync.RWMutex
+	webhooks map[string]*Webhook
+}
+
+// NewInMemoryWebhookRepository creates a new in-memory webhook
+// repository.
+func NewInMemoryWebhookRepository() *InMemoryWebhookRepository {
+	return &InMemoryWebhookRepository{
+		webhooks: make(map[string]*Webhook),
+	}
+}
+
+// CreateWebhook adds a new webhook to the repository, assigning it an ID
+// and CreatedAt if unset.
+func (r *InMemoryWebhookRepository) CreateWebhook(ctx context.Context, webhook *Webhook) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if webhook.ID == "" {
+		webhook.ID = uuid.NewString()
+	}
+	if webhook.CreatedAt.IsZero() {
+		webhook.CreatedAt = time.Now()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.webhooks[webhook.ID] = webhook
+	return nil
+}
+
+// GetAllWebhooks returns every registered webhook.
+func (r *InMemoryWebhookRepository) GetAllWebhooks(ctx context.Context) ([]*Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhooks := make([]*Webhook, 0, len(r.webhooks))
+	for _, w := range r.webhooks {
+		webhooks = append(webhooks, w)
+	}
+	return webhooks, nil
+}
+
+// DeleteWebhook removes a webhook by ID, returning ErrWebhookNotFound if
+// no such webhook is registered.
+func (r *InMemoryWebhookRepository) DeleteWebhook(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.webhooks[id]; !ok {
+		return ErrWebhookNotFound
+	}
+	delete(r.webhooks, id)
+	return nil
+}
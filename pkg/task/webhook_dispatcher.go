@@ -0,0 +1,164 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// webhookDeliveryTimeout bounds a single HTTP POST attempt to a
+// registered webhook.
+const webhookDeliveryTimeout = 10 * time.Second
+
+// defaultWebhookMaxRetries is how many times WebhookDispatcher retries a
+// failed delivery before giving up on that event for that webhook.
+const defaultWebhookMaxRetries = 3
+
+// WebhookDispatcher subscribes to a Service's event stream and delivers
+// matching events to every registered Webhook, following the same
+// Start/Stop-managed-background-goroutine shape as Scheduler.
+type WebhookDispatcher struct {
+	repo       WebhookRepository
+	httpClient *http.Client
+	maxRetries int
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewWebhookDispatcher creates a WebhookDispatcher that delivers events to
+// the webhooks registered in repo.
+func NewWebhookDispatcher(repo WebhookRepository) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		repo:       repo,
+		httpClient: &http.Client{Timeout: webhookDeliveryTimeout},
+		maxRetries: defaultWebhookMaxRetries,
+	}
+}
+
+// Start begins delivering service's events to registered webhooks in the
+// background, until Stop is called.
+func (d *WebhookDispatcher) Start(service *Service) {
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+	d.done = make(chan struct{})
+
+	events := service.Subscribe(ctx, 0)
+	go func() {
+		defer close(d.done)
+		for evt := range events {
+			d.dispatch(ctx, evt)
+		}
+	}()
+}
+
+// Stop cancels the event subscription and waits for any in-flight
+// deliveries started before cancellation to finish.
+func (d *WebhookDispatcher) Stop() {
+	if d.cancel != nil {
+		d.cancel()
+	}
+	if d.done != nil {
+		<-d.done
+	}
+}
+
+// dispatch delivers evt to every webhook whose EventTypes/Filter match it,
+// each in its own goroutine so a slow or failing webhook doesn't hold up
+// delivery to the others.
+func (d *WebhookDispatcher) dispatch(ctx context.Context, evt Event) {
+	webhooks, err := d.repo.GetAllWebhooks(ctx)
+	if err != nil {
+		return
+	}
+
+	for _, webhook := range webhooks {
+		if !matchesWebhook(webhook, evt) {
+			continue
+		}
+		go d.deliverWithRetry(ctx, webhook, evt)
+	}
+}
+
+// matchesWebhook reports whether evt should be delivered to webhook: its
+// EventTypes (empty means every type) and its Filter, evaluated against
+// evt.Task the same way ListTasks/Events evaluate a request's filter.
+func matchesWebhook(webhook *Webhook, evt Event) bool {
+	if len(webhook.EventTypes) > 0 {
+		found := false
+		for _, t := range webhook.EventTypes {
+			if t == evt.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return matchesFilter(evt.Task, webhook.Filter)
+}
+
+// deliverWithRetry POSTs evt's JSON body to webhook.URL, signed with an
+// X-Task-Signature HMAC-SHA256 header, retrying with exponential backoff
+// (1s, 2s, 4s, ...) up to d.maxRetries times.
+func (d *WebhookDispatcher) deliverWithRetry(ctx context.Context, webhook *Webhook, evt Event) {
+	body, err := json.Marshal(evt)
+	if err != nil {
+		return
+	}
+	signature := signPayload(webhook.Secret, body)
+
+	backoff := time.Second
+	for attempt := 0; attempt <= d.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return
+			}
+			backoff *= 2
+		}
+
+		if err := d.deliver(ctx, webhook.URL, signature, body); err == nil {
+			return
+		}
+	}
+}
+
+// deliver makes a single delivery attempt, returning an error for any
+// non-2xx response so deliverWithRetry retries it.
+func (d *WebhookDispatcher) deliver(ctx context.Context, url, signature string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Task-Signature", "sha256="+signature)
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook delivery returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// signPayload returns the hex-encoded HMAC-SHA256 of body under secret,
+// the same X-Task-Signature scheme GitHub/Stripe-style webhooks use so a
+// receiver can verify the request actually came from this service.
+func signPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
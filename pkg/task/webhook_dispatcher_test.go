@@ -0,0 +1,85 @@
+package task
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookDispatcher_DeliversMatchingEvents(t *testing.T) {
+	received := make(chan Event, 1)
+	var gotSignature string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get("X-Task-Signature")
+		var evt Event
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&evt))
+		received <- evt
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	webhookRepo := NewInMemoryWebhookRepository()
+	require.NoError(t, webhookRepo.CreateWebhook(t.Context(), &Webhook{
+		URL:    server.URL,
+		Secret: "shh",
+	}))
+
+	service := NewService(NewInMemoryRepository())
+	dispatcher := NewWebhookDispatcher(webhookRepo)
+	dispatcher.Start(service)
+	defer dispatcher.Stop()
+
+	created, err := service.Create(t.Context(), CreateTaskRequest{Title: "Ship it"})
+	require.NoError(t, err)
+
+	select {
+	case evt := <-received:
+		assert.Equal(t, EventCreated, evt.Type)
+		assert.Equal(t, created.ID, evt.Task.ID)
+	case <-time.After(2 * time.Second):
+		t.Fatal("webhook was not delivered in time")
+	}
+	assert.NotEmpty(t, gotSignature)
+}
+
+func TestWebhookDispatcher_SkipsNonMatchingEventType(t *testing.T) {
+	delivered := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delivered <- struct{}{}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	webhookRepo := NewInMemoryWebhookRepository()
+	require.NoError(t, webhookRepo.CreateWebhook(t.Context(), &Webhook{
+		URL:        server.URL,
+		EventTypes: []EventType{EventDeleted},
+	}))
+
+	service := NewService(NewInMemoryRepository())
+	dispatcher := NewWebhookDispatcher(webhookRepo)
+	dispatcher.Start(service)
+	defer dispatcher.Stop()
+
+	_, err := service.Create(t.Context(), CreateTaskRequest{Title: "Not a delete"})
+	require.NoError(t, err)
+
+	select {
+	case <-delivered:
+		t.Fatal("webhook should not have been delivered for a non-matching event type")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestSignPayload(t *testing.T) {
+	signature := signPayload("secret", []byte("body"))
+	assert.NotEmpty(t, signature)
+	assert.Equal(t, signature, signPayload("secret", []byte("body")))
+	assert.NotEqual(t, signature, signPayload("other", []byte("body")))
+}
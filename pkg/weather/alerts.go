@@ -0,0 +1,124 @@
+package weather
+
+import (
+	"context"
+	"time"
+)
+
+// alertWatcherBufferSize bounds the channel WatchAlerts returns, mirroring
+// stocks' watcherBufferSize: a consumer that falls behind drops events
+// rather than blocking the poller.
+const alertWatcherBufferSize = 16
+
+// alertSeverityRank orders AlertSeverity from least to most severe, so
+// FilterAlertsBySeverity can compare severities it doesn't otherwise know
+// the ordering of.
+var alertSeverityRank = map[AlertSeverity]int{
+	AlertSeverityUnknown:  0,
+	AlertSeverityMinor:    1,
+	AlertSeverityModerate: 2,
+	AlertSeveritySevere:   3,
+	AlertSeverityExtreme:  4,
+}
+
+// FilterAlertsBySeverity returns the alerts at or above minSeverity.
+func FilterAlertsBySeverity(alerts []Alert, minSeverity AlertSeverity) []Alert {
+	minRank := alertSeverityRank[minSeverity]
+
+	filtered := make([]Alert, 0, len(alerts))
+	for _, a := range alerts {
+		if alertSeverityRank[a.Severity] >= minRank {
+			filtered = append(filtered, a)
+		}
+	}
+	return filtered
+}
+
+// AlertEventType identifies what changed about an alert between two polls
+// of WatchAlerts.
+type AlertEventType string
+
+const (
+	// AlertEventNew is emitted the first time an alert's ID is seen.
+	AlertEventNew AlertEventType = "new"
+	// AlertEventExpired is emitted once a previously-seen alert's ID stops
+	// being returned by the provider.
+	AlertEventExpired AlertEventType = "expired"
+)
+
+// AlertEvent reports that an alert newly appeared or is no longer active,
+// as observed by WatchAlerts.
+type AlertEvent struct {
+	Type  AlertEventType
+	Alert Alert
+}
+
+// WatchAlerts polls provider.GetAlerts(lat, lon) every interval, comparing
+// each poll's alerts (filtered to minSeverity and above, deduplicated by
+// ID) against the previous one and emitting an AlertEvent for every alert
+// that newly appeared or disappeared. The returned channel is closed once
+// ctx is done. A poll that errors is silently skipped and retried on the
+// next tick, the same behavior stocks.Service.Watch's poller uses for a
+// transient upstream failure.
+func WatchAlerts(ctx context.Context, provider Provider, lat, lon float64, interval time.Duration, minSeverity AlertSeverity) <-chan AlertEvent {
+	events := make(chan AlertEvent, alertWatcherBufferSize)
+
+	go func() {
+		defer close(events)
+
+		seen := make(map[string]Alert)
+		poll := func() {
+			alerts, err := provider.GetAlerts(ctx, lat, lon)
+			if err != nil {
+				return
+			}
+
+			current := make(map[string]Alert, len(alerts))
+			for _, a := range FilterAlertsBySeverity(alerts, minSeverity) {
+				current[a.ID] = a
+			}
+
+			for id, a := range current {
+				if _, ok := seen[id]; !ok {
+					if !deliverAlertEvent(ctx, events, AlertEvent{Type: AlertEventNew, Alert: a}) {
+						return
+					}
+				}
+			}
+			for id, a := range seen {
+				if _, ok := current[id]; !ok {
+					if !deliverAlertEvent(ctx, events, AlertEvent{Type: AlertEventExpired, Alert: a}) {
+						return
+					}
+				}
+			}
+			seen = current
+		}
+
+		poll()
+
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				poll()
+			}
+		}
+	}()
+
+	return events
+}
+
+// deliverAlertEvent sends event on events, reporting false instead of
+// blocking forever if ctx is done before the send completes.
+func deliverAlertEvent(ctx context.Context, events chan<- AlertEvent, event AlertEvent) bool {
+	select {
+	case events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,121 @@
+package weather
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterAlertsBySeverity(t *testing.T) {
+	alerts := []Alert{
+		{ID: "1", Severity: AlertSeverityMinor},
+		{ID: "2", Severity: AlertSeverityModerate},
+		{ID: "3", Severity: AlertSeveritySevere},
+		{ID: "4", Severity: AlertSeverityExtreme},
+	}
+
+	filtered := FilterAlertsBySeverity(alerts, AlertSeveritySevere)
+
+	require.Len(t, filtered, 2)
+	assert.Equal(t, "3", filtered[0].ID)
+	assert.Equal(t, "4", filtered[1].ID)
+}
+
+// stubAlertProvider is a minimal Provider that only needs GetAlerts to
+// exercise WatchAlerts; its other methods are never called by the poller.
+type stubAlertProvider struct {
+	mu     sync.Mutex
+	alerts []Alert
+	err    error
+}
+
+func (s *stubAlertProvider) setAlerts(alerts []Alert) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.alerts = alerts
+}
+
+func (s *stubAlertProvider) GetAlerts(ctx context.Context, lat, lon float64) ([]Alert, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.alerts, nil
+}
+
+func (s *stubAlertProvider) GetCurrentWeather(ctx context.Context, lat, lon float64) (*WeatherResponse, error) {
+	return nil, nil
+}
+func (s *stubAlertProvider) GetForecast(ctx context.Context, lat, lon float64, days int) (*ForecastResponse, error) {
+	return nil, nil
+}
+func (s *stubAlertProvider) GeocodeCity(ctx context.Context, cityName string) (*GeocodingResult, error) {
+	return nil, nil
+}
+func (s *stubAlertProvider) GetHourlyForecast(ctx context.Context, lat, lon float64, hours int) (*HourlyForecastResponse, error) {
+	return nil, nil
+}
+
+func TestWatchAlerts_EmitsNewAndExpired(t *testing.T) {
+	provider := &stubAlertProvider{alerts: []Alert{{ID: "a1", Severity: AlertSeverityModerate}}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := WatchAlerts(ctx, provider, 41.88, -87.63, 10*time.Millisecond, AlertSeverityMinor)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, AlertEventNew, event.Type)
+		assert.Equal(t, "a1", event.Alert.ID)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for initial alert")
+	}
+
+	provider.setAlerts(nil)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, AlertEventExpired, event.Type)
+		assert.Equal(t, "a1", event.Alert.ID)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for expired alert")
+	}
+}
+
+func TestWatchAlerts_FiltersBySeverity(t *testing.T) {
+	provider := &stubAlertProvider{alerts: []Alert{{ID: "a1", Severity: AlertSeverityMinor}}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := WatchAlerts(ctx, provider, 41.88, -87.63, 10*time.Millisecond, AlertSeveritySevere)
+
+	select {
+	case event := <-events:
+		t.Fatalf("expected no events below minSeverity, got %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestWatchAlerts_StopsOnContextDone(t *testing.T) {
+	provider := &stubAlertProvider{alerts: []Alert{{ID: "a1", Severity: AlertSeveritySevere}}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := WatchAlerts(ctx, provider, 41.88, -87.63, 10*time.Millisecond, AlertSeverityMinor)
+
+	<-events // initial new event
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok, "events channel should be closed after ctx is done")
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for events channel to close")
+	}
+}
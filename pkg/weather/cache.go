@@ -0,0 +1,125 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CacheStatus reports how a read-through Cache lookup resolved a request,
+// surfaced by handlers.WeatherHandler as the X-Cache response header.
+type CacheStatus string
+
+const (
+	// CacheMiss means no usable cache entry was found, so the response was
+	// fetched from the upstream Provider.
+	CacheMiss CacheStatus = "MISS"
+	// CacheHit means a fresh (age <= its configured TTL) cache entry was
+	// returned without calling the upstream Provider.
+	CacheHit CacheStatus = "HIT"
+	// CacheStale means the upstream Provider call failed and an expired
+	// cache entry was returned instead, degrading gracefully rather than
+	// surfacing the upstream error.
+	CacheStale CacheStatus = "STALE"
+)
+
+// Cache is a read-through cache for Service's upstream lookups, keyed by an
+// opaque string Service builds from request parameters (see
+// cacheKeyCurrent/cacheKeyForecast/cacheKeyGeocode). A Get that finds
+// nothing for key reports ok=false; Service treats any other result
+// (including one older than the TTL it's about to check) as present and
+// decides HIT vs. STALE itself by comparing age against the relevant
+// CacheTTLs field.
+type Cache interface {
+	// Get returns the payload stored for key and how long ago it was
+	// written, or ok=false if nothing is stored for key.
+	Get(ctx context.Context, key string) (payload []byte, age time.Duration, ok bool)
+	// Put stores payload for key, to be swept once ttl has elapsed (see
+	// postgres.Manager).
+	Put(ctx context.Context, key string, payload []byte, ttl time.Duration) error
+}
+
+// CacheTTLs configures how long each kind of response Service caches stays
+// fresh before it's treated as a miss and re-fetched from the upstream
+// Provider.
+type CacheTTLs struct {
+	Current  time.Duration
+	Forecast time.Duration
+	Geocode  time.Duration
+}
+
+// DefaultCacheTTLs returns the TTLs config.WeatherConfig falls back to when
+// unset: 10 minutes for current conditions, 1 hour for forecasts, and 30
+// days for geocoding results (a city's coordinates essentially never
+// change).
+func DefaultCacheTTLs() CacheTTLs {
+	return CacheTTLs{
+		Current:  10 * time.Minute,
+		Forecast: time.Hour,
+		Geocode:  30 * 24 * time.Hour,
+	}
+}
+
+// SetCache wires cache into s as its read-through Cache, consulted by
+// GetCurrentWeatherByCoords, GetForecastByCoords and the geocoding step of
+// GetWeatherByCity before they call the upstream Provider. A nil cache (the
+// default) disables caching entirely.
+func (s *Service) SetCache(cache Cache, ttls CacheTTLs) {
+	s.cache = cache
+	s.cacheTTLs = ttls
+}
+
+// cacheKeyCurrent builds the Cache key for a by-coordinates current-weather
+// lookup, rounded to 3 decimal places (~110m) so nearby requests for
+// "the same place" share one entry.
+func cacheKeyCurrent(lat, lon float64) string {
+	return fmt.Sprintf("current:%.3f,%.3f", lat, lon)
+}
+
+// cacheKeyForecast builds the Cache key for a by-coordinates forecast
+// lookup.
+func cacheKeyForecast(lat, lon float64, days int) string {
+	return fmt.Sprintf("forecast:%.3f,%.3f:%d", lat, lon, days)
+}
+
+// cacheKeyHourly builds the Cache key for a by-coordinates hourly-forecast
+// lookup.
+func cacheKeyHourly(lat, lon float64, hours int) string {
+	return fmt.Sprintf("hourly:%.3f,%.3f:%d", lat, lon, hours)
+}
+
+// cacheKeyGeocode builds the Cache key for a GeocodeCity lookup.
+func cacheKeyGeocode(cityName string) string {
+	return "geocode:" + strings.ToLower(strings.TrimSpace(cityName))
+}
+
+// lookupCache fetches and unmarshals a cached value of type T for key,
+// reporting its age. A lookup that fails to unmarshal (e.g. a payload
+// written by a since-changed schema) is treated as a miss rather than
+// returned as corrupt data; the caller decides HIT vs. STALE by comparing
+// age against its own TTL.
+func lookupCache[T any](ctx context.Context, cache Cache, key string) (value *T, age time.Duration, found bool) {
+	payload, age, ok := cache.Get(ctx, key)
+	if !ok {
+		return nil, 0, false
+	}
+	var v T
+	if err := json.Unmarshal(payload, &v); err != nil {
+		return nil, 0, false
+	}
+	return &v, age, true
+}
+
+// storeCache marshals v and writes it to cache under key with the given
+// ttl, silently dropping a marshal error (v is always one of this package's
+// own response types) or a write error - caching is best-effort and must
+// never fail the request it's backing.
+func storeCache(ctx context.Context, cache Cache, key string, v interface{}, ttl time.Duration) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	_ = cache.Put(ctx, key, payload, ttl)
+}
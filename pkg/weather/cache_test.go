@@ -0,0 +1,133 @@
+package weather
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memCache is a minimal in-memory Cache for tests, recording payloads with
+// the wall-clock time they were written so Get can report a real age.
+type memCache struct {
+	mu      sync.Mutex
+	entries map[string]memCacheEntry
+}
+
+type memCacheEntry struct {
+	payload   []byte
+	writtenAt time.Time
+}
+
+func newMemCache() *memCache {
+	return &memCache{entries: make(map[string]memCacheEntry)}
+}
+
+func (c *memCache) Get(ctx context.Context, key string) ([]byte, time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, 0, false
+	}
+	return e.payload, time.Since(e.writtenAt), true
+}
+
+func (c *memCache) Put(ctx context.Context, key string, payload []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = memCacheEntry{payload: payload, writtenAt: time.Now()}
+	return nil
+}
+
+// putStale backdates key's entry so it reads as older than ttl, simulating
+// an expired-but-still-present row the way postgres.Store's sweeper hasn't
+// yet evicted it.
+func (c *memCache) putStale(key string, payload []byte, age time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = memCacheEntry{payload: payload, writtenAt: time.Now().Add(-age)}
+}
+
+func TestGetCurrentWeatherByCoords_CacheMissThenHit(t *testing.T) {
+	mockClient := new(MockWeatherClient)
+	service := NewServiceWithProvider(mockClient)
+	service.SetCache(newMemCache(), DefaultCacheTTLs())
+
+	resp := &WeatherResponse{Location: Location{Latitude: 52.52, Longitude: 13.41}, Current: CurrentWeather{Temperature: 15.2}}
+	mockClient.On("GetCurrentWeather", 52.52, 13.41).Return(resp, nil).Once()
+
+	first, err := service.GetCurrentWeatherByCoords(context.Background(), "52.52", "13.41", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, CacheMiss, first.CacheStatus)
+
+	second, err := service.GetCurrentWeatherByCoords(context.Background(), "52.52", "13.41", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, CacheHit, second.CacheStatus)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetCurrentWeatherByCoords_CacheStaleOnUpstreamError(t *testing.T) {
+	mockClient := new(MockWeatherClient)
+	service := NewServiceWithProvider(mockClient)
+	cache := newMemCache()
+	service.SetCache(cache, CacheTTLs{Current: time.Minute})
+
+	resp := &WeatherResponse{Location: Location{Latitude: 52.52, Longitude: 13.41}, Current: CurrentWeather{Temperature: 15.2}}
+	mockClient.On("GetCurrentWeather", 52.52, 13.41).Return(resp, nil).Once()
+
+	_, err := service.GetCurrentWeatherByCoords(context.Background(), "52.52", "13.41", "", "")
+	require.NoError(t, err)
+
+	cache.putStale(cacheKeyCurrent(52.52, 13.41), cache.entries[cacheKeyCurrent(52.52, 13.41)].payload, 2*time.Minute)
+	mockClient.On("GetCurrentWeather", 52.52, 13.41).Return(nil, ErrUpstreamUnavailable).Once()
+
+	stale, err := service.GetCurrentWeatherByCoords(context.Background(), "52.52", "13.41", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, CacheStale, stale.CacheStatus)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetCurrentWeatherByCoords_ProviderOverrideBypassesCache(t *testing.T) {
+	mockClient := new(MockWeatherClient)
+	service := NewServiceWithProvider(mockClient)
+	service.SetCache(newMemCache(), DefaultCacheTTLs())
+
+	resp := &WeatherResponse{Location: Location{Latitude: 52.52, Longitude: 13.41}}
+	mockClient.On("GetCurrentWeather", 52.52, 13.41).Return(resp, nil)
+
+	_, err := service.GetCurrentWeatherByCoords(context.Background(), "52.52", "13.41", "", "some-provider")
+	require.Error(t, err)
+
+	result, err := service.GetCurrentWeatherByCoords(context.Background(), "52.52", "13.41", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, CacheMiss, result.CacheStatus)
+}
+
+func TestGetWeatherByCity_GeocodeCached(t *testing.T) {
+	mockClient := new(MockWeatherClient)
+	service := NewServiceWithProvider(mockClient)
+	service.SetCache(newMemCache(), DefaultCacheTTLs())
+
+	geocodeResp := &GeocodingResult{Name: "Berlin", Latitude: 52.52, Longitude: 13.41, Timezone: "Europe/Berlin"}
+	weatherResp := &WeatherResponse{Location: Location{Latitude: 52.52, Longitude: 13.41}}
+
+	// Both GeocodeCity and GetCurrentWeather are only called once: the
+	// second GetWeatherByCity call is served entirely from cache (geocode
+	// and current-weather both).
+	mockClient.On("GeocodeCity", "Berlin").Return(geocodeResp, nil).Once()
+	mockClient.On("GetCurrentWeather", 52.52, 13.41).Return(weatherResp, nil).Once()
+
+	first, err := service.GetWeatherByCity(context.Background(), "Berlin", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, CacheMiss, first.CacheStatus)
+
+	second, err := service.GetWeatherByCity(context.Background(), "Berlin", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, CacheHit, second.CacheStatus)
+
+	mockClient.AssertExpectations(t)
+}
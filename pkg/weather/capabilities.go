@@ -0,0 +1,42 @@
+package weather
+
+// ProviderCapabilities describes which optional data points a Provider can
+// fill in beyond the baseline fields every Provider must return. Service
+// uses it to decide when a richer field (e.g. dewpoint) can be trusted to
+// be populated by the active backend, rather than silently left at its
+// zero value.
+type ProviderCapabilities struct {
+	Dewpoint         bool
+	PressureMsl      bool
+	Precip10m        bool
+	Precip1h         bool
+	Precip24h        bool
+	IsDay            bool
+	HumidityRelative bool
+	CloudCover       bool
+	Visibility       bool
+	// ForecastDewpoint, ForecastSunTimes, and ForecastUVIndex describe
+	// ForecastDay's optional fields (DewpointMax/Min, Sunrise/Sunset, and
+	// UVIndex respectively), which a Provider can support independently of
+	// its CurrentWeather capabilities above.
+	ForecastDewpoint bool
+	ForecastSunTimes bool
+	ForecastUVIndex  bool
+}
+
+// CapabilityProvider is an optional interface a Provider can implement to
+// advertise its ProviderCapabilities. A Provider that doesn't implement it
+// is treated as supporting none of the optional data points.
+type CapabilityProvider interface {
+	Capabilities() ProviderCapabilities
+}
+
+// capabilitiesOf returns p's ProviderCapabilities via the optional
+// CapabilityProvider interface, or the zero value if p doesn't implement
+// it.
+func capabilitiesOf(p Provider) ProviderCapabilities {
+	if cp, ok := p.(CapabilityProvider); ok {
+		return cp.Capabilities()
+	}
+	return ProviderCapabilities{}
+}
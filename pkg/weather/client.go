@@ -1,13 +1,17 @@
 package weather
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"strconv"
+	"sync/atomic"
 	"time"
+
+	"github.com/f00b455/blank-go/pkg/stocks/cache"
 )
 
 const (
@@ -16,15 +20,113 @@ const (
 	defaultRequestTimeout = 10 * time.Second
 )
 
+// ErrRateLimited is returned by Client's methods when a request is
+// rejected by the configured rate limiter instead of being sent upstream,
+// or when the upstream API itself responds 429 and retryableGet's
+// retries are exhausted.
+var ErrRateLimited = errors.New("weather: rate limit exceeded")
+
+// ErrUpstreamUnavailable is returned when the upstream API keeps
+// responding with a 5xx status even after retryableGet's retries are
+// exhausted.
+var ErrUpstreamUnavailable = errors.New("weather: upstream unavailable")
+
+// ErrCityNotFound is returned by GeocodeCity/RefreshGeocodeCity when the
+// geocoding API has no match for the requested city name.
+var ErrCityNotFound = errors.New("weather: city not found")
+
+// ErrTimeout is returned when a request to the upstream API times out,
+// including after retryableGet's retries are exhausted.
+var ErrTimeout = errors.New("weather: request timed out")
+
 // HTTPClient interface for mocking HTTP requests
 type HTTPClient interface {
 	Get(url string) (*http.Response, error)
 }
 
+// ClientTTLs configures how long Client caches each endpoint's response
+// before treating it as stale and re-fetching.
+type ClientTTLs struct {
+	Current  time.Duration
+	Forecast time.Duration
+	Geocode  time.Duration
+}
+
+// DefaultClientTTLs returns the TTLs NewClientWithOptions falls back to
+// when ClientOptions.TTLs is the zero value: current conditions change
+// minute-to-minute, forecasts drift more slowly, and geocoding results
+// are effectively static.
+func DefaultClientTTLs() ClientTTLs {
+	return ClientTTLs{
+		Current:  10 * time.Minute,
+		Forecast: time.Hour,
+		Geocode:  24 * time.Hour,
+	}
+}
+
+// ClientRateLimit configures the token-bucket rate limiter
+// NewClientWithOptions uses to respect Open-Meteo's free-tier request
+// quota.
+type ClientRateLimit struct {
+	// RequestsPerMinute bounds the short-term burst rate.
+	RequestsPerMinute int
+	// RequestsPerDay bounds the rolling total over 24h.
+	RequestsPerDay int
+}
+
+// DefaultClientRateLimit returns Open-Meteo's documented free-tier limits
+// (10 requests/minute, 5000/day).
+func DefaultClientRateLimit() ClientRateLimit {
+	return ClientRateLimit{RequestsPerMinute: 10, RequestsPerDay: 5000}
+}
+
+// ClientOptions configures NewClientWithOptions. Any zero-valued field
+// falls back to its documented default.
+type ClientOptions struct {
+	// HTTPClient defaults to an *http.Client with defaultRequestTimeout.
+	HTTPClient HTTPClient
+	// Cache defaults to an unbounded-by-TTL cache.LRUCache. Must be safe
+	// for concurrent use, as cache.Cache implementations are.
+	Cache cache.Cache
+	// RateLimit defaults to DefaultClientRateLimit.
+	RateLimit ClientRateLimit
+	// TTLs defaults to DefaultClientTTLs.
+	TTLs ClientTTLs
+	// MaxRetryAttempts defaults to defaultMaxRetryAttempts. It bounds how
+	// many times retryableGet tries an idempotent GET that keeps failing
+	// with 429/5xx or a network timeout.
+	MaxRetryAttempts int
+}
+
+// ClientStats reports a Client's cumulative cache and rate-limiter
+// counters since it was created.
+type ClientStats struct {
+	CacheHits   int64
+	CacheMisses int64
+	Throttled   int64
+}
+
+// clientStats holds ClientStats' counters as atomics so Stats() can be
+// read concurrently with requests updating them.
+type clientStats struct {
+	hits      int64
+	misses    int64
+	throttled int64
+}
+
 // Client handles communication with Open-Meteo API
 type Client struct {
 	httpClient HTTPClient
 	timeout    time.Duration
+
+	// cache and limiter are nil for Clients built via NewClient or
+	// NewClientWithHTTP, which always fetch live and never throttle;
+	// NewClientWithOptions is the only constructor that sets them.
+	cache            cache.Cache
+	ttls             ClientTTLs
+	limiter          *clientRateLimiter
+	stats            clientStats
+	maxRetryAttempts int
 }
 
 // NewClient creates a new Open-Meteo API client
@@ -33,18 +135,104 @@ func NewClient() *Client {
 		httpClient: &http.Client{
 			Timeout: defaultRequestTimeout,
 		},
-		timeout: defaultRequestTimeout,
+		timeout:          defaultRequestTimeout,
+		maxRetryAttempts: defaultMaxRetryAttempts,
 	}
 }
 
 // NewClientWithHTTP creates a client with custom HTTP client
 func NewClientWithHTTP(httpClient HTTPClient) *Client {
 	return &Client{
-		httpClient: httpClient,
-		timeout:    defaultRequestTimeout,
+		httpClient:       httpClient,
+		timeout:          defaultRequestTimeout,
+		maxRetryAttempts: defaultMaxRetryAttempts,
 	}
 }
 
+// NewClientWithOptions creates a Client with response caching and rate
+// limiting, following opts (falling back to documented defaults for any
+// zero-valued field). Use this over NewClient/NewClientWithHTTP to keep a
+// long-lived Client from exceeding Open-Meteo's free-tier request quota.
+func NewClientWithOptions(opts ClientOptions) *Client {
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultRequestTimeout}
+	}
+
+	ttls := opts.TTLs
+	if ttls == (ClientTTLs{}) {
+		ttls = DefaultClientTTLs()
+	}
+
+	rateLimit := opts.RateLimit
+	if rateLimit == (ClientRateLimit{}) {
+		rateLimit = DefaultClientRateLimit()
+	}
+
+	responseCache := opts.Cache
+	if responseCache == nil {
+		responseCache = cache.NewLRUCache(0)
+	}
+
+	maxRetryAttempts := opts.MaxRetryAttempts
+	if maxRetryAttempts <= 0 {
+		maxRetryAttempts = defaultMaxRetryAttempts
+	}
+
+	return &Client{
+		httpClient:       httpClient,
+		timeout:          defaultRequestTimeout,
+		cache:            responseCache,
+		ttls:             ttls,
+		limiter:          newClientRateLimiter(rateLimit),
+		maxRetryAttempts: maxRetryAttempts,
+	}
+}
+
+// Stats returns a snapshot of c's cache-hit/miss and rate-limit-throttle
+// counters. Always zero for a Client built via NewClient/NewClientWithHTTP,
+// since those never cache or throttle.
+func (c *Client) Stats() ClientStats {
+	return ClientStats{
+		CacheHits:   atomic.LoadInt64(&c.stats.hits),
+		CacheMisses: atomic.LoadInt64(&c.stats.misses),
+		Throttled:   atomic.LoadInt64(&c.stats.throttled),
+	}
+}
+
+// doCached serves cacheKey from c.cache when a fresh entry exists and
+// refresh is false, otherwise calls fetch (after checking the rate
+// limiter, if configured) and caches its result under cacheKey for ttl.
+// fetch returns the raw, not-yet-decoded response body.
+func (c *Client) doCached(ctx context.Context, cacheKey string, ttl time.Duration, refresh bool, fetch func() ([]byte, error), out interface{}) error {
+	if c.cache != nil && !refresh {
+		if body, ok, _ := c.cache.Get(ctx, cacheKey); ok {
+			atomic.AddInt64(&c.stats.hits, 1)
+			return json.Unmarshal(body, out)
+		}
+		atomic.AddInt64(&c.stats.misses, 1)
+	}
+
+	if c.limiter != nil && !c.limiter.allow() {
+		atomic.AddInt64(&c.stats.throttled, 1)
+		return ErrRateLimited
+	}
+
+	body, err := fetch()
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if c.cache != nil {
+		_ = c.cache.Set(ctx, cacheKey, body, ttl)
+	}
+	return nil
+}
+
 // openMeteoCurrentResponse represents the API response for current weather
 type openMeteoCurrentResponse struct {
 	Latitude  float64 `json:"latitude"`
@@ -55,6 +243,11 @@ type openMeteoCurrentResponse struct {
 		Humidity    int     `json:"relative_humidity_2m"`
 		WindSpeed   float64 `json:"wind_speed_10m"`
 		WeatherCode int     `json:"weather_code"`
+		Dewpoint    float64 `json:"dew_point_2m"`
+		PressureMsl float64 `json:"pressure_msl"`
+		IsDay       int     `json:"is_day"`
+		CloudCover  int     `json:"cloud_cover"`
+		Visibility  float64 `json:"visibility"`
 	} `json:"current"`
 }
 
@@ -69,9 +262,54 @@ type openMeteoForecastResponse struct {
 		TemperatureMin           []float64 `json:"temperature_2m_min"`
 		PrecipitationProbability []int     `json:"precipitation_probability_max"`
 		WeatherCode              []int     `json:"weather_code"`
+		DewpointMax              []float64 `json:"dew_point_2m_max"`
+		DewpointMin              []float64 `json:"dew_point_2m_min"`
+		Sunrise                  []string  `json:"sunrise"`
+		Sunset                   []string  `json:"sunset"`
+		UVIndexMax               []float64 `json:"uv_index_max"`
 	} `json:"daily"`
 }
 
+// openMeteoHourlyResponse represents the API response for the hourly
+// forecast. Hourly.Time entries are local civil times ("2006-01-02T15:04")
+// without a UTC offset, interpreted against Timezone.
+type openMeteoHourlyResponse struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Timezone  string  `json:"timezone"`
+	Hourly    struct {
+		Time                     []string  `json:"time"`
+		Temperature              []float64 `json:"temperature_2m"`
+		Humidity                 []int     `json:"relative_humidity_2m"`
+		PrecipitationProbability []int     `json:"precipitation_probability"`
+		WindSpeed                []float64 `json:"wind_speed_10m"`
+		WeatherCode              []int     `json:"weather_code"`
+	} `json:"hourly"`
+}
+
+// openMeteoHourlyTimeLayout is the format Open-Meteo emits hourly.time[]
+// entries in: a local civil time with no UTC offset or seconds.
+const openMeteoHourlyTimeLayout = "2006-01-02T15:04"
+
+// maxHourlyForecastHours bounds how far ahead GetHourlyForecast (and the
+// other providers' GetHourlyForecast) will forecast; Open-Meteo and NWS
+// both cap their own hourly horizon around this mark.
+const maxHourlyForecastHours = 168
+
+// parseHourlyTime parses a civil time string in loc, falling back to UTC
+// if loc is nil (e.g. an unrecognized or empty timezone name) and to the
+// zero time.Time if value itself doesn't parse.
+func parseHourlyTime(value string, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	t, err := time.ParseInLocation(openMeteoHourlyTimeLayout, value, loc)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
 // geocodingResponse represents the API response for geocoding
 type geocodingResponse struct {
 	Results []struct {
@@ -82,33 +320,43 @@ type geocodingResponse struct {
 	} `json:"results"`
 }
 
-// GetCurrentWeather fetches current weather data
-func (c *Client) GetCurrentWeather(lat, lon float64) (*WeatherResponse, error) {
+// GetCurrentWeather fetches current weather data, serving a cached
+// response when Client was built with NewClientWithOptions and one is
+// still fresh.
+func (c *Client) GetCurrentWeather(ctx context.Context, lat, lon float64) (*WeatherResponse, error) {
+	return c.getCurrentWeather(ctx, lat, lon, false)
+}
+
+// RefreshCurrentWeather behaves like GetCurrentWeather but always makes a
+// live request, bypassing (though still repopulating) the cache.
+func (c *Client) RefreshCurrentWeather(ctx context.Context, lat, lon float64) (*WeatherResponse, error) {
+	return c.getCurrentWeather(ctx, lat, lon, true)
+}
+
+func (c *Client) getCurrentWeather(ctx context.Context, lat, lon float64, refresh bool) (*WeatherResponse, error) {
 	params := url.Values{}
 	params.Set("latitude", formatFloat(lat))
 	params.Set("longitude", formatFloat(lon))
-	params.Set("current", "temperature_2m,relative_humidity_2m,wind_speed_10m,weather_code")
+	params.Set("current", "temperature_2m,relative_humidity_2m,wind_speed_10m,weather_code,dew_point_2m,pressure_msl,is_day,cloud_cover,visibility")
 
 	apiURL := fmt.Sprintf("%s?%s", openMeteoBaseURL, params.Encode())
 
-	resp, err := c.httpClient.Get(apiURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch weather data: %w", err)
-	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	fetch := func() ([]byte, error) {
+		return retryableGet(ctx, c.httpClient, apiURL, "failed to fetch weather data", c.maxRetryAttempts)
 	}
 
 	var apiResp openMeteoCurrentResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.doCached(ctx, apiURL, c.ttls.Current, refresh, fetch, &apiResp); err != nil {
+		return nil, err
 	}
 
+	dewpoint := apiResp.Current.Dewpoint
+	pressureMsl := apiResp.Current.PressureMsl
+	isDay := apiResp.Current.IsDay != 0
+	humidityRelative := apiResp.Current.Humidity
+	cloudCover := apiResp.Current.CloudCover
+	visibility := apiResp.Current.Visibility
+
 	return &WeatherResponse{
 		Location: Location{
 			Latitude:  apiResp.Latitude,
@@ -121,45 +369,58 @@ func (c *Client) GetCurrentWeather(lat, lon float64) (*WeatherResponse, error) {
 			WindSpeed:          apiResp.Current.WindSpeed,
 			WeatherCode:        apiResp.Current.WeatherCode,
 			WeatherDescription: GetWeatherDescription(apiResp.Current.WeatherCode),
+			Dewpoint:           &dewpoint,
+			PressureMsl:        &pressureMsl,
+			IsDay:              &isDay,
+			HumidityRelative:   &humidityRelative,
+			CloudCover:         &cloudCover,
+			Visibility:         &visibility,
 		},
 		Units: Units{
-			Temperature: "°C",
-			WindSpeed:   "km/h",
-			Humidity:    "%",
+			Temperature:   "°C",
+			WindSpeed:     "km/h",
+			Humidity:      "%",
+			Pressure:      "hPa",
+			Precipitation: "mm",
 		},
 	}, nil
 }
 
-// GetForecast fetches weather forecast data
-func (c *Client) GetForecast(lat, lon float64, days int) (*ForecastResponse, error) {
+// GetForecast fetches weather forecast data, serving a cached response
+// when Client was built with NewClientWithOptions and one is still fresh.
+func (c *Client) GetForecast(ctx context.Context, lat, lon float64, days int) (*ForecastResponse, error) {
+	return c.getForecast(ctx, lat, lon, days, false)
+}
+
+// RefreshForecast behaves like GetForecast but always makes a live
+// request, bypassing (though still repopulating) the cache.
+func (c *Client) RefreshForecast(ctx context.Context, lat, lon float64, days int) (*ForecastResponse, error) {
+	return c.getForecast(ctx, lat, lon, days, true)
+}
+
+func (c *Client) getForecast(ctx context.Context, lat, lon float64, days int, refresh bool) (*ForecastResponse, error) {
 	params := url.Values{}
 	params.Set("latitude", formatFloat(lat))
 	params.Set("longitude", formatFloat(lon))
-	params.Set("daily", "temperature_2m_max,temperature_2m_min,precipitation_probability_max,weather_code")
+	params.Set("daily", "temperature_2m_max,temperature_2m_min,precipitation_probability_max,weather_code,dew_point_2m_max,dew_point_2m_min,sunrise,sunset,uv_index_max")
 	params.Set("forecast_days", strconv.Itoa(days))
 
 	apiURL := fmt.Sprintf("%s?%s", openMeteoBaseURL, params.Encode())
 
-	resp, err := c.httpClient.Get(apiURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch forecast data: %w", err)
-	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	fetch := func() ([]byte, error) {
+		return retryableGet(ctx, c.httpClient, apiURL, "failed to fetch forecast data", c.maxRetryAttempts)
 	}
 
 	var apiResp openMeteoForecastResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.doCached(ctx, apiURL, c.ttls.Forecast, refresh, fetch, &apiResp); err != nil {
+		return nil, err
 	}
 
 	forecast := make([]ForecastDay, len(apiResp.Daily.Time))
 	for i := range apiResp.Daily.Time {
+		dewpointMax := apiResp.Daily.DewpointMax[i]
+		dewpointMin := apiResp.Daily.DewpointMin[i]
+		uvIndex := apiResp.Daily.UVIndexMax[i]
 		forecast[i] = ForecastDay{
 			Date:                     apiResp.Daily.Time[i],
 			TemperatureMax:           apiResp.Daily.TemperatureMax[i],
@@ -167,6 +428,11 @@ func (c *Client) GetForecast(lat, lon float64, days int) (*ForecastResponse, err
 			PrecipitationProbability: apiResp.Daily.PrecipitationProbability[i],
 			WeatherCode:              apiResp.Daily.WeatherCode[i],
 			WeatherDescription:       GetWeatherDescription(apiResp.Daily.WeatherCode[i]),
+			DewpointMax:              &dewpointMax,
+			DewpointMin:              &dewpointMin,
+			Sunrise:                  apiResp.Daily.Sunrise[i],
+			Sunset:                   apiResp.Daily.Sunset[i],
+			UVIndex:                  &uvIndex,
 		}
 	}
 
@@ -180,8 +446,105 @@ func (c *Client) GetForecast(lat, lon float64, days int) (*ForecastResponse, err
 	}, nil
 }
 
-// GeocodeCity converts city name to coordinates
-func (c *Client) GeocodeCity(cityName string) (*GeocodingResult, error) {
+// GetHourlyForecast fetches an hour-by-hour forecast, capped at
+// maxHourlyForecastHours (168h ahead) the same way NWS and Open-Meteo
+// themselves bound their own hourly horizon.
+func (c *Client) GetHourlyForecast(ctx context.Context, lat, lon float64, hours int) (*HourlyForecastResponse, error) {
+	if hours > maxHourlyForecastHours {
+		hours = maxHourlyForecastHours
+	}
+
+	params := url.Values{}
+	params.Set("latitude", formatFloat(lat))
+	params.Set("longitude", formatFloat(lon))
+	params.Set("hourly", "temperature_2m,relative_humidity_2m,precipitation_probability,wind_speed_10m,weather_code")
+	params.Set("forecast_hours", strconv.Itoa(hours))
+
+	apiURL := fmt.Sprintf("%s?%s", openMeteoBaseURL, params.Encode())
+
+	body, err := retryableGet(ctx, c.httpClient, apiURL, "failed to fetch hourly forecast data", c.maxRetryAttempts)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp openMeteoHourlyResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	loc, err := time.LoadLocation(apiResp.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	hourly := make([]HourlyForecastEntry, len(apiResp.Hourly.Time))
+	for i := range apiResp.Hourly.Time {
+		hourly[i] = HourlyForecastEntry{
+			Time:                     parseHourlyTime(apiResp.Hourly.Time[i], loc),
+			Temperature:              apiResp.Hourly.Temperature[i],
+			Humidity:                 apiResp.Hourly.Humidity[i],
+			PrecipitationProbability: apiResp.Hourly.PrecipitationProbability[i],
+			WindSpeed:                apiResp.Hourly.WindSpeed[i],
+			WeatherCode:              apiResp.Hourly.WeatherCode[i],
+			WeatherDescription:       GetWeatherDescription(apiResp.Hourly.WeatherCode[i]),
+		}
+	}
+
+	return &HourlyForecastResponse{
+		Location: Location{
+			Latitude:  apiResp.Latitude,
+			Longitude: apiResp.Longitude,
+			Timezone:  apiResp.Timezone,
+		},
+		Hourly: hourly,
+	}, nil
+}
+
+// GetAlerts always returns an empty slice: Open-Meteo has no weather alerts
+// feed, unlike NWS's /alerts endpoint.
+func (c *Client) GetAlerts(ctx context.Context, lat, lon float64) ([]Alert, error) {
+	return nil, nil
+}
+
+// Capabilities reports that Client fills CurrentWeather's Dewpoint,
+// PressureMsl, IsDay, HumidityRelative, CloudCover, and Visibility (all
+// requested via the `current` param alongside the baseline fields), plus
+// ForecastDay's DewpointMax/Min, Sunrise/Sunset, and UVIndex (via the
+// `daily` param).
+func (c *Client) Capabilities() ProviderCapabilities {
+	return ProviderCapabilities{
+		Dewpoint:         true,
+		PressureMsl:      true,
+		IsDay:            true,
+		HumidityRelative: true,
+		CloudCover:       true,
+		Visibility:       true,
+		ForecastDewpoint: true,
+		ForecastSunTimes: true,
+		ForecastUVIndex:  true,
+	}
+}
+
+// Name identifies this provider as "open-meteo" for provider selection (see
+// MultiProvider.SelectProvider).
+func (c *Client) Name() string {
+	return "open-meteo"
+}
+
+// GeocodeCity converts city name to coordinates, serving a cached
+// response when Client was built with NewClientWithOptions and one is
+// still fresh.
+func (c *Client) GeocodeCity(ctx context.Context, cityName string) (*GeocodingResult, error) {
+	return c.geocodeCity(ctx, cityName, false)
+}
+
+// RefreshGeocodeCity behaves like GeocodeCity but always makes a live
+// request, bypassing (though still repopulating) the cache.
+func (c *Client) RefreshGeocodeCity(ctx context.Context, cityName string) (*GeocodingResult, error) {
+	return c.geocodeCity(ctx, cityName, true)
+}
+
+func (c *Client) geocodeCity(ctx context.Context, cityName string, refresh bool) (*GeocodingResult, error) {
 	params := url.Values{}
 	params.Set("name", cityName)
 	params.Set("count", "1")
@@ -190,26 +553,17 @@ func (c *Client) GeocodeCity(cityName string) (*GeocodingResult, error) {
 
 	apiURL := fmt.Sprintf("%s?%s", geocodingBaseURL, params.Encode())
 
-	resp, err := c.httpClient.Get(apiURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to geocode city: %w", err)
-	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	fetch := func() ([]byte, error) {
+		return retryableGet(ctx, c.httpClient, apiURL, "failed to geocode city", c.maxRetryAttempts)
 	}
 
 	var apiResp geocodingResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.doCached(ctx, apiURL, c.ttls.Geocode, refresh, fetch, &apiResp); err != nil {
+		return nil, err
 	}
 
 	if len(apiResp.Results) == 0 {
-		return nil, fmt.Errorf("city not found")
+		return nil, fmt.Errorf("city not found: %w", ErrCityNotFound)
 	}
 
 	result := apiResp.Results[0]
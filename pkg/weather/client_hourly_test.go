@@ -0,0 +1,134 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetHourlyForecast(t *testing.T) {
+	tests := []struct {
+		name          string
+		lat           float64
+		lon           float64
+		hours         int
+		mockResponse  string
+		mockStatus    int
+		mockError     error
+		expectError   bool
+		errorContains string
+		expectedLen   int
+	}{
+		{
+			name:  "successful hourly fetch parses local time against timezone",
+			lat:   52.52,
+			lon:   13.405,
+			hours: 2,
+			mockResponse: `{
+				"latitude": 52.52,
+				"longitude": 13.405,
+				"timezone": "Europe/Berlin",
+				"hourly": {
+					"time": ["2026-01-16T00:00", "2026-01-16T01:00"],
+					"temperature_2m": [5.5, 5.2],
+					"relative_humidity_2m": [80, 82],
+					"precipitation_probability": [10, 20],
+					"wind_speed_10m": [8.1, 7.9],
+					"weather_code": [0, 1]
+				}
+			}`,
+			mockStatus:  http.StatusOK,
+			expectedLen: 2,
+		},
+		{
+			name:          "http client error",
+			lat:           52.52,
+			lon:           13.405,
+			hours:         2,
+			mockError:     errors.New("network error"),
+			expectError:   true,
+			errorContains: "failed to fetch hourly forecast data",
+		},
+		{
+			name:          "API returns error status",
+			lat:           52.52,
+			lon:           13.405,
+			hours:         2,
+			mockResponse:  `{"error": "invalid request"}`,
+			mockStatus:    http.StatusBadRequest,
+			expectError:   true,
+			errorContains: "API returned status 400",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockHTTPClient{
+				GetFunc: func(url string) (*http.Response, error) {
+					if tt.mockError != nil {
+						return nil, tt.mockError
+					}
+					return &http.Response{
+						StatusCode: tt.mockStatus,
+						Body:       io.NopCloser(strings.NewReader(tt.mockResponse)),
+					}, nil
+				},
+			}
+
+			client := NewClientWithHTTP(mockClient)
+			result, err := client.GetHourlyForecast(context.Background(), tt.lat, tt.lon, tt.hours)
+
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, result.Hourly, tt.expectedLen)
+			assert.Equal(t, 10, result.Hourly[0].PrecipitationProbability)
+
+			loc, err := time.LoadLocation("Europe/Berlin")
+			require.NoError(t, err)
+			assert.Equal(t, time.Date(2026, 1, 16, 0, 0, 0, 0, loc), result.Hourly[0].Time)
+		})
+	}
+}
+
+func TestGetHourlyForecast_CapsAtMaxHorizon(t *testing.T) {
+	var requestedURL string
+	mockClient := &MockHTTPClient{
+		GetFunc: func(url string) (*http.Response, error) {
+			requestedURL = url
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: io.NopCloser(strings.NewReader(`{
+					"latitude": 52.52, "longitude": 13.405, "timezone": "UTC",
+					"hourly": {"time": [], "temperature_2m": [], "relative_humidity_2m": [],
+					"precipitation_probability": [], "wind_speed_10m": [], "weather_code": []}
+				}`)),
+			}, nil
+		},
+	}
+
+	client := NewClientWithHTTP(mockClient)
+	_, err := client.GetHourlyForecast(context.Background(), 52.52, 13.405, 500)
+	require.NoError(t, err)
+	assert.Contains(t, requestedURL, "forecast_hours=168")
+}
+
+func TestParseHourlyTime_FallsBackToZeroOnParseError(t *testing.T) {
+	assert.True(t, parseHourlyTime("not-a-time", time.UTC).IsZero())
+}
+
+func TestParseRFC3339_FallsBackToZeroOnParseError(t *testing.T) {
+	assert.True(t, parseRFC3339("not-a-time").IsZero())
+}
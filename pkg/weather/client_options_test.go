@@ -0,0 +1,120 @@
+package weather
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/f00b455/blank-go/pkg/stocks/cache"
+)
+
+func countingHTTPClient(response string) (*MockHTTPClient, *int) {
+	calls := 0
+	mock := &MockHTTPClient{
+		GetFunc: func(url string) (*http.Response, error) {
+			calls++
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(response)),
+			}, nil
+		},
+	}
+	return mock, &calls
+}
+
+func TestNewClientWithOptions_Defaults(t *testing.T) {
+	client := NewClientWithOptions(ClientOptions{})
+	assert.NotNil(t, client.cache)
+	assert.Equal(t, DefaultClientTTLs(), client.ttls)
+	assert.NotNil(t, client.limiter)
+}
+
+func TestClient_GetCurrentWeather_CachesBetweenCalls(t *testing.T) {
+	mock, calls := countingHTTPClient(`{
+		"latitude": 52.52,
+		"longitude": 13.405,
+		"timezone": "Europe/Berlin",
+		"current": {"temperature_2m": 15.5, "relative_humidity_2m": 65, "wind_speed_10m": 12.3, "weather_code": 0}
+	}`)
+
+	client := NewClientWithOptions(ClientOptions{HTTPClient: mock, Cache: cache.NewLRUCache(0)})
+
+	_, err := client.GetCurrentWeather(context.Background(), 52.52, 13.405)
+	require.NoError(t, err)
+	_, err = client.GetCurrentWeather(context.Background(), 52.52, 13.405)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, *calls, "second call should be served from cache")
+
+	stats := client.Stats()
+	assert.Equal(t, int64(1), stats.CacheHits)
+	assert.Equal(t, int64(1), stats.CacheMisses)
+}
+
+func TestClient_RefreshCurrentWeather_BypassesCache(t *testing.T) {
+	mock, calls := countingHTTPClient(`{
+		"latitude": 52.52,
+		"longitude": 13.405,
+		"timezone": "Europe/Berlin",
+		"current": {"temperature_2m": 15.5, "relative_humidity_2m": 65, "wind_speed_10m": 12.3, "weather_code": 0}
+	}`)
+
+	client := NewClientWithOptions(ClientOptions{HTTPClient: mock, Cache: cache.NewLRUCache(0)})
+
+	_, err := client.GetCurrentWeather(context.Background(), 52.52, 13.405)
+	require.NoError(t, err)
+	_, err = client.RefreshCurrentWeather(context.Background(), 52.52, 13.405)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, *calls, "RefreshCurrentWeather must bypass the cache")
+}
+
+func TestClient_RateLimiter_ThrottlesExcessRequests(t *testing.T) {
+	mock, calls := countingHTTPClient(`{
+		"latitude": 52.52,
+		"longitude": 13.405,
+		"timezone": "Europe/Berlin",
+		"current": {"temperature_2m": 15.5, "relative_humidity_2m": 65, "wind_speed_10m": 12.3, "weather_code": 0}
+	}`)
+
+	client := NewClientWithOptions(ClientOptions{
+		HTTPClient: mock,
+		Cache:      cache.NewLRUCache(0),
+		RateLimit:  ClientRateLimit{RequestsPerMinute: 1, RequestsPerDay: 1},
+	})
+
+	// Two distinct locations so the second call is a genuine cache miss
+	// that has to go through the rate limiter.
+	_, err := client.GetCurrentWeather(context.Background(), 52.52, 13.405)
+	require.NoError(t, err)
+
+	_, err = client.GetCurrentWeather(context.Background(), 40.71, -74.01)
+	require.ErrorIs(t, err, ErrRateLimited)
+
+	assert.Equal(t, 1, *calls)
+	assert.Equal(t, int64(1), client.Stats().Throttled)
+}
+
+func TestClient_NewClientAndNewClientWithHTTP_NeverCacheOrThrottle(t *testing.T) {
+	mock, calls := countingHTTPClient(`{
+		"latitude": 52.52,
+		"longitude": 13.405,
+		"timezone": "Europe/Berlin",
+		"current": {"temperature_2m": 15.5, "relative_humidity_2m": 65, "wind_speed_10m": 12.3, "weather_code": 0}
+	}`)
+
+	client := NewClientWithHTTP(mock)
+
+	_, err := client.GetCurrentWeather(context.Background(), 52.52, 13.405)
+	require.NoError(t, err)
+	_, err = client.GetCurrentWeather(context.Background(), 52.52, 13.405)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, *calls, "NewClientWithHTTP must not cache")
+	assert.Equal(t, ClientStats{}, client.Stats())
+}
@@ -2,6 +2,7 @@ package weather
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"net/http"
@@ -111,7 +112,7 @@ func TestGetCurrentWeather(t *testing.T) {
 			}
 
 			client := NewClientWithHTTP(mockClient)
-			result, err := client.GetCurrentWeather(tt.lat, tt.lon)
+			result, err := client.GetCurrentWeather(context.Background(), tt.lat, tt.lon)
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -154,7 +155,12 @@ func TestGetForecast(t *testing.T) {
 					"temperature_2m_max": [10.5, 12.3, 11.8],
 					"temperature_2m_min": [5.2, 6.1, 5.8],
 					"precipitation_probability_max": [20, 40, 10],
-					"weather_code": [0, 1, 2]
+					"weather_code": [0, 1, 2],
+					"dew_point_2m_max": [6.1, 7.0, 6.5],
+					"dew_point_2m_min": [2.0, 2.5, 2.2],
+					"sunrise": ["2026-01-16T07:30", "2026-01-17T07:29", "2026-01-18T07:28"],
+					"sunset": ["2026-01-16T16:20", "2026-01-17T16:22", "2026-01-18T16:23"],
+					"uv_index_max": [1.2, 1.4, 1.1]
 				}
 			}`,
 			mockStatus:  http.StatusOK,
@@ -206,7 +212,7 @@ func TestGetForecast(t *testing.T) {
 			}
 
 			client := NewClientWithHTTP(mockClient)
-			result, err := client.GetForecast(tt.lat, tt.lon, tt.days)
+			result, err := client.GetForecast(context.Background(), tt.lat, tt.lon, tt.days)
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -295,7 +301,7 @@ func TestGeocodeCity(t *testing.T) {
 			}
 
 			client := NewClientWithHTTP(mockClient)
-			result, err := client.GeocodeCity(tt.cityName)
+			result, err := client.GeocodeCity(context.Background(), tt.cityName)
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -378,7 +384,7 @@ func TestGetCurrentWeatherResponseBodyClose(t *testing.T) {
 	}
 
 	client := NewClientWithHTTP(mockClient)
-	_, err := client.GetCurrentWeather(52.52, 13.405)
+	_, err := client.GetCurrentWeather(context.Background(), 52.52, 13.405)
 	require.NoError(t, err)
 	assert.True(t, bodyClosed, "Response body should be closed")
 }
@@ -0,0 +1,94 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrNoProviders is returned by FallbackProvider methods when it was
+// constructed with no underlying providers to try.
+var ErrNoProviders = errors.New("no weather providers configured")
+
+// FallbackProvider tries each wrapped Provider in order, returning the
+// first successful result. It lets callers degrade gracefully when a
+// primary backend (e.g. a rate-limited OpenWeatherMap key) is unavailable,
+// without the caller needing to know which provider ultimately answered.
+type FallbackProvider struct {
+	providers []Provider
+}
+
+// NewFallbackProvider creates a FallbackProvider that tries providers in
+// the given order.
+func NewFallbackProvider(providers ...Provider) *FallbackProvider {
+	return &FallbackProvider{providers: providers}
+}
+
+func (f *FallbackProvider) GetCurrentWeather(ctx context.Context, lat, lon float64) (*WeatherResponse, error) {
+	var lastErr error
+	for _, p := range f.providers {
+		resp, err := p.GetCurrentWeather(ctx, lat, lon)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+	}
+	return nil, f.failure(lastErr)
+}
+
+func (f *FallbackProvider) GetForecast(ctx context.Context, lat, lon float64, days int) (*ForecastResponse, error) {
+	var lastErr error
+	for _, p := range f.providers {
+		resp, err := p.GetForecast(ctx, lat, lon, days)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+	}
+	return nil, f.failure(lastErr)
+}
+
+func (f *FallbackProvider) GeocodeCity(ctx context.Context, cityName string) (*GeocodingResult, error) {
+	var lastErr error
+	for _, p := range f.providers {
+		result, err := p.GeocodeCity(ctx, cityName)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+	}
+	return nil, f.failure(lastErr)
+}
+
+func (f *FallbackProvider) GetHourlyForecast(ctx context.Context, lat, lon float64, hours int) (*HourlyForecastResponse, error) {
+	var lastErr error
+	for _, p := range f.providers {
+		resp, err := p.GetHourlyForecast(ctx, lat, lon, hours)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+	}
+	return nil, f.failure(lastErr)
+}
+
+func (f *FallbackProvider) GetAlerts(ctx context.Context, lat, lon float64) ([]Alert, error) {
+	var lastErr error
+	for _, p := range f.providers {
+		alerts, err := p.GetAlerts(ctx, lat, lon)
+		if err == nil {
+			return alerts, nil
+		}
+		lastErr = err
+	}
+	return nil, f.failure(lastErr)
+}
+
+// failure reports ErrNoProviders when there was nothing to try, or wraps
+// the last underlying provider's error otherwise.
+func (f *FallbackProvider) failure(lastErr error) error {
+	if len(f.providers) == 0 {
+		return ErrNoProviders
+	}
+	return fmt.Errorf("all weather providers failed: %w", lastErr)
+}
@@ -0,0 +1,290 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const meteologixBaseURL = "https://api.meteologix.com/v1"
+
+// MeteologixProvider implements Provider against Meteologix's station/model
+// aggregation API. Unlike the other providers in this package, Meteologix
+// reports dewpoint, mean-sea-level pressure, precipitation over three
+// windows, and day/night separately from the forecast's weather symbol, so
+// it's the richest Provider by ProviderCapabilities - Service surfaces
+// those fields when it's the one that answered the request.
+type MeteologixProvider struct {
+	httpClient Requester
+	apiKey     string
+}
+
+// NewMeteologixProvider creates a MeteologixProvider authenticated with
+// apiKey.
+func NewMeteologixProvider(apiKey string) *MeteologixProvider {
+	return NewMeteologixProviderWithHTTP(&http.Client{Timeout: defaultRequestTimeout}, apiKey)
+}
+
+// NewMeteologixProviderWithHTTP creates a MeteologixProvider with a custom
+// Requester, for tests.
+func NewMeteologixProviderWithHTTP(httpClient Requester, apiKey string) *MeteologixProvider {
+	return &MeteologixProvider{httpClient: httpClient, apiKey: apiKey}
+}
+
+type meteologixCurrentResponse struct {
+	Timezone string `json:"timezone"`
+	Current  struct {
+		TemperatureC float64 `json:"temperature"`
+		Humidity     int     `json:"humidity"`
+		WindSpeedMs  float64 `json:"windSpeed"`
+		DewpointC    float64 `json:"dewpoint"`
+		PressureMsl  float64 `json:"pressureMsl"`
+		Precip1h     float64 `json:"precip1h"`
+		Precip24h    float64 `json:"precip24h"`
+		IsDay        bool    `json:"isDay"`
+		SymbolCode   int     `json:"symbolCode"`
+	} `json:"current"`
+}
+
+type meteologixDailyEntry struct {
+	Date           string  `json:"date"`
+	TemperatureMax float64 `json:"temperatureMax"`
+	TemperatureMin float64 `json:"temperatureMin"`
+	PrecipProb     int     `json:"precipProbability"`
+	SymbolCode     int     `json:"symbolCode"`
+}
+
+type meteologixForecastResponse struct {
+	Timezone string                 `json:"timezone"`
+	Daily    []meteologixDailyEntry `json:"daily"`
+}
+
+type meteologixHourlyEntry struct {
+	Time        string  `json:"time"`
+	Temperature float64 `json:"temperature"`
+	Humidity    int     `json:"humidity"`
+	PrecipProb  int     `json:"precipProbability"`
+	WindSpeedMs float64 `json:"windSpeed"`
+	SymbolCode  int     `json:"symbolCode"`
+}
+
+type meteologixHourlyResponse struct {
+	Timezone string                  `json:"timezone"`
+	Hourly   []meteologixHourlyEntry `json:"hourly"`
+}
+
+type meteologixGeocodingResponse struct {
+	Results []struct {
+		Name      string  `json:"name"`
+		Latitude  float64 `json:"latitude"`
+		Longitude float64 `json:"longitude"`
+		Timezone  string  `json:"timezone"`
+	} `json:"results"`
+}
+
+func (p *MeteologixProvider) get(ctx context.Context, path string, params url.Values, out interface{}) error {
+	params.Set("apikey", p.apiKey)
+
+	apiURL := fmt.Sprintf("%s/%s?%s", meteologixBaseURL, path, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", path, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", path, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// GetCurrentWeather fetches current conditions for lat/lon, filling
+// Dewpoint, PressureMsl, Precipitation1h/24h, and IsDay alongside the
+// baseline fields.
+func (p *MeteologixProvider) GetCurrentWeather(ctx context.Context, lat, lon float64) (*WeatherResponse, error) {
+	params := url.Values{}
+	params.Set("lat", formatFloat(lat))
+	params.Set("lon", formatFloat(lon))
+
+	var apiResp meteologixCurrentResponse
+	if err := p.get(ctx, "current", params, &apiResp); err != nil {
+		return nil, err
+	}
+
+	code := NormalizeWeatherCode(SourceMeteologix, apiResp.Current.SymbolCode)
+	dewpoint := apiResp.Current.DewpointC
+	pressureMsl := apiResp.Current.PressureMsl
+	precip1h := apiResp.Current.Precip1h
+	precip24h := apiResp.Current.Precip24h
+	isDay := apiResp.Current.IsDay
+
+	return &WeatherResponse{
+		Location: Location{Latitude: lat, Longitude: lon, Timezone: apiResp.Timezone},
+		Current: CurrentWeather{
+			Temperature:        apiResp.Current.TemperatureC,
+			Humidity:           apiResp.Current.Humidity,
+			WindSpeed:          apiResp.Current.WindSpeedMs,
+			WeatherCode:        code,
+			WeatherDescription: GetWeatherDescription(code),
+			Dewpoint:           &dewpoint,
+			PressureMsl:        &pressureMsl,
+			Precipitation1h:    &precip1h,
+			Precipitation24h:   &precip24h,
+			IsDay:              &isDay,
+		},
+		Units: Units{
+			Temperature:   "°C",
+			WindSpeed:     "m/s",
+			Humidity:      "%",
+			Pressure:      "hPa",
+			Precipitation: "mm",
+		},
+	}, nil
+}
+
+// GetForecast fetches a daily forecast for lat/lon, capped at days.
+func (p *MeteologixProvider) GetForecast(ctx context.Context, lat, lon float64, days int) (*ForecastResponse, error) {
+	params := url.Values{}
+	params.Set("lat", formatFloat(lat))
+	params.Set("lon", formatFloat(lon))
+
+	var apiResp meteologixForecastResponse
+	if err := p.get(ctx, "forecast/daily", params, &apiResp); err != nil {
+		return nil, err
+	}
+
+	daily := apiResp.Daily
+	if len(daily) > days {
+		daily = daily[:days]
+	}
+
+	forecast := make([]ForecastDay, len(daily))
+	for i, day := range daily {
+		code := NormalizeWeatherCode(SourceMeteologix, day.SymbolCode)
+		forecast[i] = ForecastDay{
+			Date:                     day.Date,
+			TemperatureMax:           day.TemperatureMax,
+			TemperatureMin:           day.TemperatureMin,
+			PrecipitationProbability: day.PrecipProb,
+			WeatherCode:              code,
+			WeatherDescription:       GetWeatherDescription(code),
+		}
+	}
+
+	return &ForecastResponse{
+		Location: Location{Latitude: lat, Longitude: lon, Timezone: apiResp.Timezone},
+		Forecast: forecast,
+	}, nil
+}
+
+// GetHourlyForecast fetches an hourly forecast for lat/lon, capped at
+// hours (and at maxHourlyForecastHours).
+func (p *MeteologixProvider) GetHourlyForecast(ctx context.Context, lat, lon float64, hours int) (*HourlyForecastResponse, error) {
+	if hours > maxHourlyForecastHours {
+		hours = maxHourlyForecastHours
+	}
+
+	params := url.Values{}
+	params.Set("lat", formatFloat(lat))
+	params.Set("lon", formatFloat(lon))
+
+	var apiResp meteologixHourlyResponse
+	if err := p.get(ctx, "forecast/hourly", params, &apiResp); err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(apiResp.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	hourly := apiResp.Hourly
+	if len(hourly) > hours {
+		hourly = hourly[:hours]
+	}
+
+	entries := make([]HourlyForecastEntry, len(hourly))
+	for i, hour := range hourly {
+		code := NormalizeWeatherCode(SourceMeteologix, hour.SymbolCode)
+		entries[i] = HourlyForecastEntry{
+			Time:                     parseHourlyTime(hour.Time, loc),
+			Temperature:              hour.Temperature,
+			Humidity:                 hour.Humidity,
+			PrecipitationProbability: hour.PrecipProb,
+			WindSpeed:                hour.WindSpeedMs,
+			WeatherCode:              code,
+			WeatherDescription:       GetWeatherDescription(code),
+		}
+	}
+
+	return &HourlyForecastResponse{
+		Location: Location{Latitude: lat, Longitude: lon, Timezone: apiResp.Timezone},
+		Hourly:   entries,
+	}, nil
+}
+
+// GetAlerts always returns an empty slice: this package doesn't yet
+// integrate Meteologix's separate severe-weather-warnings endpoint.
+func (p *MeteologixProvider) GetAlerts(ctx context.Context, lat, lon float64) ([]Alert, error) {
+	return nil, nil
+}
+
+// GeocodeCity resolves cityName via Meteologix's /geocode endpoint.
+func (p *MeteologixProvider) GeocodeCity(ctx context.Context, cityName string) (*GeocodingResult, error) {
+	params := url.Values{}
+	params.Set("q", cityName)
+
+	var apiResp meteologixGeocodingResponse
+	if err := p.get(ctx, "geocode", params, &apiResp); err != nil {
+		return nil, err
+	}
+	if len(apiResp.Results) == 0 {
+		return nil, fmt.Errorf("city not found: %w", ErrCityNotFound)
+	}
+
+	result := apiResp.Results[0]
+	return &GeocodingResult{
+		Name:      result.Name,
+		Latitude:  result.Latitude,
+		Longitude: result.Longitude,
+		Timezone:  result.Timezone,
+	}, nil
+}
+
+// Capabilities reports that MeteologixProvider fills Dewpoint,
+// PressureMsl, Precipitation1h, Precipitation24h, and IsDay.
+func (p *MeteologixProvider) Capabilities() ProviderCapabilities {
+	return ProviderCapabilities{
+		Dewpoint:    true,
+		PressureMsl: true,
+		Precip1h:    true,
+		Precip24h:   true,
+		IsDay:       true,
+	}
+}
+
+// Name identifies this provider as "meteologix" for provider selection (see
+// MultiProvider.SelectProvider).
+func (p *MeteologixProvider) Name() string {
+	return "meteologix"
+}
@@ -0,0 +1,18 @@
+package weather
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	prefetchHitsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "weather_prefetch_hits_total",
+		Help: "Total number of hot weather queries successfully refreshed by Prefetcher.",
+	})
+
+	prefetchMissesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "weather_prefetch_misses_total",
+		Help: "Total number of hot weather queries Prefetcher failed to refresh.",
+	})
+)
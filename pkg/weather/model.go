@@ -1,5 +1,7 @@
 package weather
 
+import "time"
+
 // Location represents geographic location information
 type Location struct {
 	Latitude  float64 `json:"latitude"`
@@ -15,13 +17,31 @@ type CurrentWeather struct {
 	WindSpeed          float64 `json:"wind_speed"`
 	WeatherCode        int     `json:"weather_code"`
 	WeatherDescription string  `json:"weather_description"`
+	// Dewpoint, PressureMsl, the Precipitation* windows, and IsDay are only
+	// populated by providers whose ProviderCapabilities reports support for
+	// them; a nil pointer distinguishes "not supported by this backend"
+	// from a genuine zero reading.
+	Dewpoint         *float64 `json:"dewpoint,omitempty"`
+	PressureMsl      *float64 `json:"pressure_msl,omitempty"`
+	Precipitation10m *float64 `json:"precipitation_10m,omitempty"`
+	Precipitation1h  *float64 `json:"precipitation_1h,omitempty"`
+	Precipitation24h *float64 `json:"precipitation_24h,omitempty"`
+	IsDay            *bool    `json:"is_day,omitempty"`
+	// HumidityRelative mirrors a provider's raw relative-humidity sensor
+	// reading where that's reported separately from the rounded Humidity
+	// percentage every Provider must supply.
+	HumidityRelative *int     `json:"humidity_relative,omitempty"`
+	CloudCover       *int     `json:"cloud_cover,omitempty"`
+	Visibility       *float64 `json:"visibility,omitempty"`
 }
 
 // Units represents measurement units for weather data
 type Units struct {
-	Temperature string `json:"temperature"`
-	WindSpeed   string `json:"wind_speed"`
-	Humidity    string `json:"humidity"`
+	Temperature   string `json:"temperature"`
+	WindSpeed     string `json:"wind_speed"`
+	Humidity      string `json:"humidity"`
+	Pressure      string `json:"pressure"`
+	Precipitation string `json:"precipitation"`
 }
 
 // WeatherResponse represents the complete weather response
@@ -29,6 +49,12 @@ type WeatherResponse struct {
 	Location Location       `json:"location"`
 	Current  CurrentWeather `json:"current"`
 	Units    Units          `json:"units"`
+	// CacheStatus and CacheAge report how Service's read-through Cache (see
+	// Service.SetCache) resolved this response. Excluded from JSON -
+	// handlers.WeatherHandler surfaces them as the X-Cache/Age response
+	// headers instead. Both are zero-valued when no Cache is configured.
+	CacheStatus CacheStatus   `json:"-"`
+	CacheAge    time.Duration `json:"-"`
 }
 
 // ForecastDay represents a single day forecast
@@ -39,12 +65,23 @@ type ForecastDay struct {
 	PrecipitationProbability int     `json:"precipitation_probability"`
 	WeatherCode              int     `json:"weather_code"`
 	WeatherDescription       string  `json:"weather_description"`
+	// DewpointMax, DewpointMin, Sunrise, Sunset, and UVIndex are only
+	// populated by providers whose ProviderCapabilities reports support for
+	// them, mirroring CurrentWeather's optional-field convention.
+	DewpointMax *float64 `json:"dewpoint_max,omitempty"`
+	DewpointMin *float64 `json:"dewpoint_min,omitempty"`
+	Sunrise     string   `json:"sunrise,omitempty"`
+	Sunset      string   `json:"sunset,omitempty"`
+	UVIndex     *float64 `json:"uv_index,omitempty"`
 }
 
 // ForecastResponse represents the complete forecast response
 type ForecastResponse struct {
 	Location Location      `json:"location"`
 	Forecast []ForecastDay `json:"forecast"`
+	// CacheStatus and CacheAge are as described on WeatherResponse.
+	CacheStatus CacheStatus   `json:"-"`
+	CacheAge    time.Duration `json:"-"`
 }
 
 // GeocodingResult represents a geocoding API result
@@ -55,6 +92,55 @@ type GeocodingResult struct {
 	Timezone  string  `json:"timezone"`
 }
 
+// HourlyForecastEntry represents a single hour of forecast data.
+type HourlyForecastEntry struct {
+	Time                     time.Time `json:"time"`
+	Temperature              float64   `json:"temperature"`
+	Humidity                 int       `json:"humidity,omitempty"`
+	PrecipitationProbability int       `json:"precipitation_probability,omitempty"`
+	WindSpeed                float64   `json:"wind_speed,omitempty"`
+	WeatherCode              int       `json:"weather_code"`
+	WeatherDescription       string    `json:"weather_description"`
+}
+
+// HourlyForecastResponse represents the complete hourly forecast response.
+type HourlyForecastResponse struct {
+	Location Location              `json:"location"`
+	Hourly   []HourlyForecastEntry `json:"hourly"`
+}
+
+// AlertSeverity mirrors the severity vocabulary used by NWS CAP alerts
+// ("Minor", "Moderate", "Severe", "Extreme"), lowercased for JSON.
+type AlertSeverity string
+
+const (
+	AlertSeverityMinor    AlertSeverity = "minor"
+	AlertSeverityModerate AlertSeverity = "moderate"
+	AlertSeveritySevere   AlertSeverity = "severe"
+	AlertSeverityExtreme  AlertSeverity = "extreme"
+	AlertSeverityUnknown  AlertSeverity = "unknown"
+)
+
+// Alert represents a single active weather alert (e.g. a flood watch or
+// severe thunderstorm warning) for a location.
+type Alert struct {
+	// ID uniquely identifies this alert within its source provider, and is
+	// what Watcher dedupes on across polls. Providers without a native
+	// alert ID synthesize a stable one.
+	ID          string        `json:"id"`
+	SenderName  string        `json:"sender_name,omitempty"`
+	Event       string        `json:"event"`
+	Severity    AlertSeverity `json:"severity"`
+	Headline    string        `json:"headline"`
+	Description string        `json:"description"`
+	// Tags holds provider-specific categorization keywords. Empty unless a
+	// provider exposes them; reserved for forward compatibility.
+	Tags     []string `json:"tags,omitempty"`
+	AreaDesc string   `json:"area_desc,omitempty"`
+	Onset    string   `json:"onset,omitempty"`
+	Expires  string   `json:"expires,omitempty"`
+}
+
 // WeatherCodeDescriptions maps Open-Meteo weather codes to descriptions
 var WeatherCodeDescriptions = map[int]string{
 	0:  "Clear sky",
@@ -87,6 +173,21 @@ var WeatherCodeDescriptions = map[int]string{
 	99: "Thunderstorm with heavy hail",
 }
 
+// BatchWeatherResponse represents a batch of current-weather lookups,
+// mirroring stocks.BatchResponse's partial-success shape: a query that
+// fails comes back in Errors rather than failing the whole batch.
+type BatchWeatherResponse struct {
+	Summaries []WeatherResponse   `json:"summaries"`
+	Errors    []BatchWeatherError `json:"errors,omitempty"`
+}
+
+// BatchWeatherError represents an error for a specific city or coordinate
+// pair in a batch weather request.
+type BatchWeatherError struct {
+	Query string `json:"query"`
+	Error string `json:"error"`
+}
+
 // GetWeatherDescription returns the description for a weather code
 func GetWeatherDescription(code int) string {
 	if desc, ok := WeatherCodeDescriptions[code]; ok {
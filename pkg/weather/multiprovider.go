@@ -0,0 +1,399 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrProviderNotFound is returned by MultiProvider.SelectProvider (and
+// anything built on it, like Service's `?provider=` override) when the
+// requested name doesn't match any wrapped Provider's NamedProvider.Name().
+var ErrProviderNotFound = errors.New("weather: provider not found")
+
+// ProviderStrategy selects how MultiProvider orders its wrapped Providers
+// when trying them in sequence.
+type ProviderStrategy string
+
+const (
+	// StrategyPrimaryFailover always tries providers in the priority order
+	// they were passed to NewMultiProvider. It's the default (the zero
+	// value of ProviderStrategy resolves to it).
+	StrategyPrimaryFailover ProviderStrategy = "primary-with-failover"
+	// StrategyRoundRobin rotates the starting provider on every call, so
+	// repeated requests spread load across every configured backend
+	// instead of always preferring the first one.
+	StrategyRoundRobin ProviderStrategy = "round-robin"
+	// StrategyFirstSuccessful queries every provider concurrently and
+	// returns whichever responds successfully first, in completion order
+	// rather than priority order. Useful when every configured provider is
+	// equally trustworthy and latency, not priority, should decide which
+	// answer wins.
+	StrategyFirstSuccessful ProviderStrategy = "first-successful"
+)
+
+// FailoverPolicy controls how MultiProvider reacts when one of its wrapped
+// Providers fails or disagrees with the others.
+type FailoverPolicy struct {
+	// Strategy picks the order providers are tried in. Empty defaults to
+	// StrategyPrimaryFailover.
+	Strategy ProviderStrategy
+	// Vote, when true, queries every provider concurrently for
+	// GetCurrentWeather instead of returning the first success, and
+	// reconciles a WeatherCode disagreement by majority vote (a tie goes
+	// to the highest-priority provider, i.e. the one listed first in
+	// NewMultiProvider). Voting only applies to GetCurrentWeather: it's
+	// where providers most often disagree, and the only query cheap
+	// enough to fan out to every backend on every request. The other
+	// methods always use Strategy's ordering regardless of this setting.
+	// Vote takes precedence over Strategy for GetCurrentWeather.
+	Vote bool
+}
+
+// MultiProvider wraps a prioritized list of Providers, trying the next one
+// when a call fails with a transient error (rate limiting, a 5xx, or a
+// timeout - the same sentinels retryableGet classifies) rather than a
+// permanent one like ErrCityNotFound. It's the aggregation layer
+// weather.NewService is built on, so handlers never need to know how many
+// backends are actually configured.
+type MultiProvider struct {
+	providers []Provider
+	policy    FailoverPolicy
+	// rrNext is the next starting index StrategyRoundRobin hands out,
+	// incremented atomically so concurrent requests still rotate fairly.
+	rrNext uint64
+}
+
+// NewMultiProvider creates a MultiProvider that tries providers in the
+// given priority order, applying policy.
+func NewMultiProvider(policy FailoverPolicy, providers ...Provider) *MultiProvider {
+	return &MultiProvider{providers: providers, policy: policy}
+}
+
+// order returns the indices into m.providers in the sequence a failover
+// loop should try them, per m.policy.Strategy.
+func (m *MultiProvider) order() []int {
+	indices := make([]int, len(m.providers))
+	if m.policy.Strategy != StrategyRoundRobin || len(m.providers) == 0 {
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices
+	}
+
+	start := int(atomic.AddUint64(&m.rrNext, 1)-1) % len(m.providers)
+	for i := range indices {
+		indices[i] = (start + i) % len(m.providers)
+	}
+	return indices
+}
+
+// ProviderSelector is an optional interface a Provider can implement to
+// support restricting a request to one specific wrapped backend by name
+// (e.g. MultiProvider). Service uses it to honor a request's `?provider=`
+// override.
+type ProviderSelector interface {
+	SelectProvider(name string) (Provider, bool)
+}
+
+// SelectProvider returns the wrapped Provider whose NamedProvider.Name()
+// matches name, implementing the optional ProviderSelector interface
+// Service uses to honor a request's `?provider=` override. A provider that
+// doesn't implement NamedProvider can never be matched this way.
+func (m *MultiProvider) SelectProvider(name string) (Provider, bool) {
+	for _, p := range m.providers {
+		if nameOf(p) == name {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// Capabilities reports the union of every wrapped provider's
+// ProviderCapabilities, since MultiProvider can serve a data point from
+// whichever provider actually supports it.
+func (m *MultiProvider) Capabilities() ProviderCapabilities {
+	var caps ProviderCapabilities
+	for _, p := range m.providers {
+		c := capabilitiesOf(p)
+		caps.Dewpoint = caps.Dewpoint || c.Dewpoint
+		caps.PressureMsl = caps.PressureMsl || c.PressureMsl
+		caps.Precip10m = caps.Precip10m || c.Precip10m
+		caps.Precip1h = caps.Precip1h || c.Precip1h
+		caps.Precip24h = caps.Precip24h || c.Precip24h
+		caps.IsDay = caps.IsDay || c.IsDay
+		caps.HumidityRelative = caps.HumidityRelative || c.HumidityRelative
+		caps.CloudCover = caps.CloudCover || c.CloudCover
+		caps.Visibility = caps.Visibility || c.Visibility
+		caps.ForecastDewpoint = caps.ForecastDewpoint || c.ForecastDewpoint
+		caps.ForecastSunTimes = caps.ForecastSunTimes || c.ForecastSunTimes
+		caps.ForecastUVIndex = caps.ForecastUVIndex || c.ForecastUVIndex
+	}
+	return caps
+}
+
+// GetCurrentWeather tries each provider in the order m.policy.Strategy
+// picks (or, with policy.Vote, queries all of them and reconciles
+// disagreement - which takes precedence over Strategy) until one
+// succeeds.
+func (m *MultiProvider) GetCurrentWeather(ctx context.Context, lat, lon float64) (*WeatherResponse, error) {
+	if m.policy.Vote {
+		return m.voteCurrentWeather(ctx, lat, lon)
+	}
+	if m.policy.Strategy == StrategyFirstSuccessful {
+		resps := make([]*WeatherResponse, len(m.providers))
+		idx, err := raceFirstSuccess(len(m.providers), func(i int) error {
+			resp, err := m.providers[i].GetCurrentWeather(ctx, lat, lon)
+			resps[i] = resp
+			return err
+		})
+		if err != nil {
+			return nil, m.failure(err)
+		}
+		return resps[idx], nil
+	}
+
+	var lastErr error
+	for _, idx := range m.order() {
+		resp, err := m.providers[idx].GetCurrentWeather(ctx, lat, lon)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+		if !isRetryable(err) {
+			return nil, err
+		}
+	}
+	return nil, m.failure(lastErr)
+}
+
+// voteCurrentWeather queries every provider concurrently and, when at
+// least two agree, returns the response from the highest-priority
+// provider whose WeatherCode matches the majority. A single success (or
+// unanimous agreement) is returned as-is.
+func (m *MultiProvider) voteCurrentWeather(ctx context.Context, lat, lon float64) (*WeatherResponse, error) {
+	type outcome struct {
+		resp *WeatherResponse
+		err  error
+	}
+
+	outcomes := make([]outcome, len(m.providers))
+	var wg sync.WaitGroup
+	for i, p := range m.providers {
+		wg.Add(1)
+		go func(i int, p Provider) {
+			defer wg.Done()
+			resp, err := p.GetCurrentWeather(ctx, lat, lon)
+			outcomes[i] = outcome{resp: resp, err: err}
+		}(i, p)
+	}
+	wg.Wait()
+
+	var successes []*WeatherResponse
+	var lastErr error
+	for _, o := range outcomes {
+		if o.err != nil {
+			lastErr = o.err
+			continue
+		}
+		successes = append(successes, o.resp)
+	}
+	if len(successes) == 0 {
+		return nil, m.failure(lastErr)
+	}
+
+	return successes[majorityWeatherCode(successes)], nil
+}
+
+// majorityWeatherCode returns the index into successes (in priority order)
+// of the first response whose WeatherCode has the most votes, breaking
+// ties in favor of whichever code was seen first (i.e. from the
+// highest-priority provider).
+func majorityWeatherCode(successes []*WeatherResponse) int {
+	votes := make(map[int]int, len(successes))
+	codeOrder := make([]int, 0, len(successes))
+	for _, resp := range successes {
+		code := resp.Current.WeatherCode
+		if _, seen := votes[code]; !seen {
+			codeOrder = append(codeOrder, code)
+		}
+		votes[code]++
+	}
+
+	bestCode, bestVotes := codeOrder[0], 0
+	for _, code := range codeOrder {
+		if votes[code] > bestVotes {
+			bestCode, bestVotes = code, votes[code]
+		}
+	}
+
+	for i, resp := range successes {
+		if resp.Current.WeatherCode == bestCode {
+			return i
+		}
+	}
+	return 0
+}
+
+// GetForecast tries each provider in the order m.policy.Strategy picks
+// (or queries all concurrently for StrategyFirstSuccessful) until one
+// succeeds, failing over only on a transient error.
+func (m *MultiProvider) GetForecast(ctx context.Context, lat, lon float64, days int) (*ForecastResponse, error) {
+	if m.policy.Strategy == StrategyFirstSuccessful {
+		resps := make([]*ForecastResponse, len(m.providers))
+		idx, err := raceFirstSuccess(len(m.providers), func(i int) error {
+			resp, err := m.providers[i].GetForecast(ctx, lat, lon, days)
+			resps[i] = resp
+			return err
+		})
+		if err != nil {
+			return nil, m.failure(err)
+		}
+		return resps[idx], nil
+	}
+
+	var lastErr error
+	for _, idx := range m.order() {
+		resp, err := m.providers[idx].GetForecast(ctx, lat, lon, days)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+		if !isRetryable(err) {
+			return nil, err
+		}
+	}
+	return nil, m.failure(lastErr)
+}
+
+// GeocodeCity tries each provider in the order m.policy.Strategy picks
+// (or queries all concurrently for StrategyFirstSuccessful) until one
+// succeeds, failing over only on a transient error.
+func (m *MultiProvider) GeocodeCity(ctx context.Context, cityName string) (*GeocodingResult, error) {
+	if m.policy.Strategy == StrategyFirstSuccessful {
+		results := make([]*GeocodingResult, len(m.providers))
+		idx, err := raceFirstSuccess(len(m.providers), func(i int) error {
+			result, err := m.providers[i].GeocodeCity(ctx, cityName)
+			results[i] = result
+			return err
+		})
+		if err != nil {
+			return nil, m.failure(err)
+		}
+		return results[idx], nil
+	}
+
+	var lastErr error
+	for _, idx := range m.order() {
+		result, err := m.providers[idx].GeocodeCity(ctx, cityName)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+		if !isRetryable(err) {
+			return nil, err
+		}
+	}
+	return nil, m.failure(lastErr)
+}
+
+// GetHourlyForecast tries each provider in the order m.policy.Strategy
+// picks (or queries all concurrently for StrategyFirstSuccessful) until
+// one succeeds, failing over only on a transient error.
+func (m *MultiProvider) GetHourlyForecast(ctx context.Context, lat, lon float64, hours int) (*HourlyForecastResponse, error) {
+	if m.policy.Strategy == StrategyFirstSuccessful {
+		resps := make([]*HourlyForecastResponse, len(m.providers))
+		idx, err := raceFirstSuccess(len(m.providers), func(i int) error {
+			resp, err := m.providers[i].GetHourlyForecast(ctx, lat, lon, hours)
+			resps[i] = resp
+			return err
+		})
+		if err != nil {
+			return nil, m.failure(err)
+		}
+		return resps[idx], nil
+	}
+
+	var lastErr error
+	for _, idx := range m.order() {
+		resp, err := m.providers[idx].GetHourlyForecast(ctx, lat, lon, hours)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+		if !isRetryable(err) {
+			return nil, err
+		}
+	}
+	return nil, m.failure(lastErr)
+}
+
+// GetAlerts tries each provider in the order m.policy.Strategy picks (or
+// queries all concurrently for StrategyFirstSuccessful) until one
+// succeeds, failing over only on a transient error.
+func (m *MultiProvider) GetAlerts(ctx context.Context, lat, lon float64) ([]Alert, error) {
+	if m.policy.Strategy == StrategyFirstSuccessful {
+		alertSets := make([][]Alert, len(m.providers))
+		idx, err := raceFirstSuccess(len(m.providers), func(i int) error {
+			alerts, err := m.providers[i].GetAlerts(ctx, lat, lon)
+			alertSets[i] = alerts
+			return err
+		})
+		if err != nil {
+			return nil, m.failure(err)
+		}
+		return alertSets[idx], nil
+	}
+
+	var lastErr error
+	for _, idx := range m.order() {
+		alerts, err := m.providers[idx].GetAlerts(ctx, lat, lon)
+		if err == nil {
+			return alerts, nil
+		}
+		lastErr = err
+		if !isRetryable(err) {
+			return nil, err
+		}
+	}
+	return nil, m.failure(lastErr)
+}
+
+// raceFirstSuccess runs call(i) for every i in [0,n) concurrently and
+// returns the index of the first one to succeed, in completion order
+// (not priority order) - what StrategyFirstSuccessful uses, since every
+// provider is treated as equally trustworthy there. Returns -1 and the
+// last observed error if every call failed, or if n is 0.
+func raceFirstSuccess(n int, call func(i int) error) (int, error) {
+	type outcome struct {
+		idx int
+		err error
+	}
+	results := make(chan outcome, n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			results <- outcome{idx: i, err: call(i)}
+		}(i)
+	}
+
+	var lastErr error
+	for i := 0; i < n; i++ {
+		o := <-results
+		if o.err == nil {
+			return o.idx, nil
+		}
+		lastErr = o.err
+	}
+	return -1, lastErr
+}
+
+// failure reports ErrNoProviders when there was nothing to try, or wraps
+// the last underlying provider's error otherwise.
+func (m *MultiProvider) failure(lastErr error) error {
+	if len(m.providers) == 0 {
+		return ErrNoProviders
+	}
+	return fmt.Errorf("all weather providers failed: %w", lastErr)
+}
@@ -0,0 +1,74 @@
+package weather
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// namedMockProvider wraps a MockWeatherClient with a fixed NamedProvider
+// name, so tests can exercise MultiProvider.SelectProvider without a real
+// backend.
+type namedMockProvider struct {
+	*MockWeatherClient
+	name string
+}
+
+func (p *namedMockProvider) Name() string { return p.name }
+
+func newNamedMockProvider(name string) *namedMockProvider {
+	return &namedMockProvider{MockWeatherClient: new(MockWeatherClient), name: name}
+}
+
+func TestMultiProvider_SelectProvider(t *testing.T) {
+	primary := newNamedMockProvider("open-meteo")
+	secondary := newNamedMockProvider("nws")
+	m := NewMultiProvider(FailoverPolicy{}, primary, secondary)
+
+	provider, ok := m.SelectProvider("nws")
+	require.True(t, ok)
+	assert.Same(t, secondary, provider)
+
+	_, ok = m.SelectProvider("openweathermap")
+	assert.False(t, ok)
+}
+
+func TestMultiProvider_RoundRobin(t *testing.T) {
+	m := NewMultiProvider(FailoverPolicy{Strategy: StrategyRoundRobin}, newNamedMockProvider("a"), newNamedMockProvider("b"))
+
+	// order() should rotate its starting index on every call rather than
+	// always preferring provider 0.
+	assert.Equal(t, []int{0, 1}, m.order())
+	assert.Equal(t, []int{1, 0}, m.order())
+	assert.Equal(t, []int{0, 1}, m.order())
+}
+
+func TestMultiProvider_FirstSuccessful(t *testing.T) {
+	failing := newNamedMockProvider("failing")
+	succeeding := newNamedMockProvider("succeeding")
+	m := NewMultiProvider(FailoverPolicy{Strategy: StrategyFirstSuccessful}, failing, succeeding)
+
+	resp := &WeatherResponse{Current: CurrentWeather{Temperature: 21}}
+	failing.On("GetCurrentWeather", 52.52, 13.41).Return(nil, ErrCityNotFound)
+	succeeding.On("GetCurrentWeather", 52.52, 13.41).Return(resp, nil)
+
+	result, err := m.GetCurrentWeather(context.Background(), 52.52, 13.41)
+
+	require.NoError(t, err)
+	assert.Equal(t, resp, result)
+}
+
+func TestMultiProvider_FirstSuccessful_AllFail(t *testing.T) {
+	a := newNamedMockProvider("a")
+	b := newNamedMockProvider("b")
+	m := NewMultiProvider(FailoverPolicy{Strategy: StrategyFirstSuccessful}, a, b)
+
+	a.On("GetCurrentWeather", 52.52, 13.41).Return(nil, ErrCityNotFound)
+	b.On("GetCurrentWeather", 52.52, 13.41).Return(nil, ErrCityNotFound)
+
+	_, err := m.GetCurrentWeather(context.Background(), 52.52, 13.41)
+
+	assert.Error(t, err)
+}
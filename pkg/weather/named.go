@@ -0,0 +1,19 @@
+package weather
+
+// NamedProvider is an optional interface a Provider can implement to
+// advertise a stable name (e.g. "open-meteo", "nws") that MultiProvider
+// uses for provider selection (see MultiProvider.SelectProvider). A
+// Provider that doesn't implement it can still be wrapped by MultiProvider,
+// but can't be targeted individually by name.
+type NamedProvider interface {
+	Name() string
+}
+
+// nameOf returns p's name via the optional NamedProvider interface, or ""
+// if p doesn't implement it.
+func nameOf(p Provider) string {
+	if np, ok := p.(NamedProvider); ok {
+		return np.Name()
+	}
+	return ""
+}
@@ -0,0 +1,353 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ErrGeocodingUnsupported is returned by NWSProvider.GeocodeCity: NWS has
+// no geocoding API of its own, unlike the other Providers in this package.
+var ErrGeocodingUnsupported = errors.New("weather: geocoding not supported by this provider")
+
+// defaultNWSUserAgent identifies this application to api.weather.gov,
+// which rejects requests that don't send a descriptive User-Agent.
+// Operators should configure a contact-specific one via NewNWSProvider.
+const defaultNWSUserAgent = "blank-go-weather-client (https://github.com/f00b455/blank-go)"
+
+const nwsBaseURL = "https://api.weather.gov"
+
+// Requester is implemented by HTTP clients that can send a fully-formed
+// *http.Request, unlike HTTPClient's fixed-URL Get. NWS and OpenWeatherMap
+// need it to attach a required header (User-Agent, appid) per request;
+// *http.Client satisfies it as-is.
+type Requester interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// NWSProvider implements Provider against the US National Weather Service
+// API (api.weather.gov). Every request is a two-step lookup: GET
+// /points/{lat},{lon} resolves the forecast grid endpoint for the given
+// coordinates, then the returned properties.forecast /
+// properties.forecastHourly URL is fetched for the actual forecast
+// periods. NWS only covers US territory and requires no API key, but
+// rejects requests without a descriptive User-Agent.
+type NWSProvider struct {
+	httpClient Requester
+	userAgent  string
+}
+
+// NewNWSProvider creates an NWSProvider that identifies itself to
+// api.weather.gov with userAgent (falling back to a generic default if
+// empty, though NWS's own guidance is to identify the calling application
+// and a contact).
+func NewNWSProvider(userAgent string) *NWSProvider {
+	return NewNWSProviderWithHTTP(&http.Client{Timeout: defaultRequestTimeout}, userAgent)
+}
+
+// NewNWSProviderWithHTTP creates an NWSProvider with a custom Requester,
+// for tests.
+func NewNWSProviderWithHTTP(httpClient Requester, userAgent string) *NWSProvider {
+	if userAgent == "" {
+		userAgent = defaultNWSUserAgent
+	}
+	return &NWSProvider{httpClient: httpClient, userAgent: userAgent}
+}
+
+type nwsPointsResponse struct {
+	Properties struct {
+		Forecast       string `json:"forecast"`
+		ForecastHourly string `json:"forecastHourly"`
+	} `json:"properties"`
+}
+
+type nwsPeriod struct {
+	StartTime        string `json:"startTime"`
+	Temperature      int    `json:"temperature"`
+	TemperatureUnit  string `json:"temperatureUnit"`
+	WindSpeed        string `json:"windSpeed"`
+	ShortForecast    string `json:"shortForecast"`
+	DetailedForecast string `json:"detailedForecast"`
+	IsDaytime        bool   `json:"isDaytime"`
+	RelativeHumidity struct {
+		Value float64 `json:"value"`
+	} `json:"relativeHumidity"`
+	ProbabilityOfPrecipitation struct {
+		Value float64 `json:"value"`
+	} `json:"probabilityOfPrecipitation"`
+}
+
+type nwsForecastResponse struct {
+	Properties struct {
+		Periods []nwsPeriod `json:"periods"`
+	} `json:"properties"`
+}
+
+func (p *NWSProvider) get(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("User-Agent", p.userAgent)
+	req.Header.Set("Accept", "application/geo+json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// points resolves lat/lon to the per-gridpoint forecast URLs NWS requires
+// every other endpoint to be reached through.
+func (p *NWSProvider) points(ctx context.Context, lat, lon float64) (*nwsPointsResponse, error) {
+	url := fmt.Sprintf("%s/points/%s,%s", nwsBaseURL, formatFloat(lat), formatFloat(lon))
+
+	var points nwsPointsResponse
+	if err := p.get(ctx, url, &points); err != nil {
+		return nil, err
+	}
+	return &points, nil
+}
+
+// windSpeedMPH extracts the leading number from NWS's free-text wind speed
+// ("10 mph" or "10 to 15 mph"), returning 0 if it can't be parsed.
+func windSpeedMPH(s string) float64 {
+	var value float64
+	_, _ = fmt.Sscanf(s, "%f", &value)
+	return value
+}
+
+// GetCurrentWeather reports the first (current) forecast period for
+// lat/lon as current conditions; NWS has no dedicated "current
+// observations at a point" endpoint as simple as its gridpoint forecast.
+func (p *NWSProvider) GetCurrentWeather(ctx context.Context, lat, lon float64) (*WeatherResponse, error) {
+	points, err := p.points(ctx, lat, lon)
+	if err != nil {
+		return nil, err
+	}
+
+	var forecast nwsForecastResponse
+	if err := p.get(ctx, points.Properties.Forecast, &forecast); err != nil {
+		return nil, err
+	}
+
+	if len(forecast.Properties.Periods) == 0 {
+		return nil, fmt.Errorf("NWS returned no forecast periods for %f,%f", lat, lon)
+	}
+
+	period := forecast.Properties.Periods[0]
+	code := NormalizeWeatherText(period.ShortForecast)
+	isDaytime := period.IsDaytime
+
+	return &WeatherResponse{
+		Location: Location{Latitude: lat, Longitude: lon},
+		Current: CurrentWeather{
+			Temperature:        float64(period.Temperature),
+			Humidity:           int(period.RelativeHumidity.Value),
+			WindSpeed:          windSpeedMPH(period.WindSpeed),
+			WeatherCode:        code,
+			WeatherDescription: GetWeatherDescription(code),
+			IsDay:              &isDaytime,
+		},
+		Units: Units{
+			Temperature:   "°" + period.TemperatureUnit,
+			WindSpeed:     "mph",
+			Humidity:      "%",
+			Pressure:      "hPa",
+			Precipitation: "mm",
+		},
+	}, nil
+}
+
+// GetForecast returns up to days worth of NWS forecast periods,
+// collapsing NWS's day/night period pairs into one ForecastDay per
+// daytime period (NWS emits two 12-hour periods per calendar day).
+func (p *NWSProvider) GetForecast(ctx context.Context, lat, lon float64, days int) (*ForecastResponse, error) {
+	points, err := p.points(ctx, lat, lon)
+	if err != nil {
+		return nil, err
+	}
+
+	var forecast nwsForecastResponse
+	if err := p.get(ctx, points.Properties.Forecast, &forecast); err != nil {
+		return nil, err
+	}
+
+	result := make([]ForecastDay, 0, days)
+	for _, period := range forecast.Properties.Periods {
+		if !period.IsDaytime {
+			continue
+		}
+		if len(result) >= days {
+			break
+		}
+
+		code := NormalizeWeatherText(period.ShortForecast)
+		result = append(result, ForecastDay{
+			Date:                     period.StartTime,
+			TemperatureMax:           float64(period.Temperature),
+			TemperatureMin:           float64(period.Temperature),
+			PrecipitationProbability: 0,
+			WeatherCode:              code,
+			WeatherDescription:       GetWeatherDescription(code),
+		})
+	}
+
+	return &ForecastResponse{
+		Location: Location{Latitude: lat, Longitude: lon},
+		Forecast: result,
+	}, nil
+}
+
+// GetHourlyForecast returns up to hours (capped at maxHourlyForecastHours)
+// worth of NWS's hourly forecast periods.
+func (p *NWSProvider) GetHourlyForecast(ctx context.Context, lat, lon float64, hours int) (*HourlyForecastResponse, error) {
+	if hours > maxHourlyForecastHours {
+		hours = maxHourlyForecastHours
+	}
+
+	points, err := p.points(ctx, lat, lon)
+	if err != nil {
+		return nil, err
+	}
+
+	var forecast nwsForecastResponse
+	if err := p.get(ctx, points.Properties.ForecastHourly, &forecast); err != nil {
+		return nil, err
+	}
+
+	periods := forecast.Properties.Periods
+	if len(periods) > hours {
+		periods = periods[:hours]
+	}
+
+	entries := make([]HourlyForecastEntry, len(periods))
+	for i, period := range periods {
+		code := NormalizeWeatherText(period.ShortForecast)
+		entries[i] = HourlyForecastEntry{
+			Time:                     parseRFC3339(period.StartTime),
+			Temperature:              float64(period.Temperature),
+			Humidity:                 int(period.RelativeHumidity.Value),
+			PrecipitationProbability: int(period.ProbabilityOfPrecipitation.Value),
+			WindSpeed:                windSpeedMPH(period.WindSpeed),
+			WeatherCode:              code,
+			WeatherDescription:       GetWeatherDescription(code),
+		}
+	}
+
+	return &HourlyForecastResponse{
+		Location: Location{Latitude: lat, Longitude: lon},
+		Hourly:   entries,
+	}, nil
+}
+
+// GeocodeCity always fails with ErrGeocodingUnsupported: NWS identifies
+// locations by lat/lon grid point, not by name, and has no city lookup of
+// its own. Callers that need city-name lookups against an NWS-backed
+// Service should pair it with another Provider for geocoding (e.g. via
+// MultiProvider) rather than calling this directly.
+func (p *NWSProvider) GeocodeCity(ctx context.Context, cityName string) (*GeocodingResult, error) {
+	return nil, fmt.Errorf("NWS: %w", ErrGeocodingUnsupported)
+}
+
+// Capabilities reports that NWSProvider fills CurrentWeather.IsDay from
+// its forecast period's isDaytime flag, but none of the other optional
+// data points.
+func (p *NWSProvider) Capabilities() ProviderCapabilities {
+	return ProviderCapabilities{IsDay: true}
+}
+
+// Name identifies this provider as "nws" for provider selection (see
+// MultiProvider.SelectProvider).
+func (p *NWSProvider) Name() string {
+	return "nws"
+}
+
+// parseRFC3339 parses an RFC 3339 timestamp, returning the zero time.Time
+// if it doesn't parse instead of an error, mirroring windSpeedMPH's
+// best-effort handling of a free-form upstream field.
+func parseRFC3339(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// nwsAlertsResponse represents the API response from /alerts/active.
+type nwsAlertsResponse struct {
+	Features []struct {
+		ID         string `json:"id"`
+		Properties struct {
+			SenderName  string `json:"senderName"`
+			Event       string `json:"event"`
+			Severity    string `json:"severity"`
+			Headline    string `json:"headline"`
+			Description string `json:"description"`
+			AreaDesc    string `json:"areaDesc"`
+			Onset       string `json:"onset"`
+			Expires     string `json:"expires"`
+		} `json:"properties"`
+	} `json:"features"`
+}
+
+// GetAlerts fetches active NWS alerts covering lat/lon via
+// /alerts/active?point={lat},{lon}.
+func (p *NWSProvider) GetAlerts(ctx context.Context, lat, lon float64) ([]Alert, error) {
+	url := fmt.Sprintf("%s/alerts/active?point=%s,%s", nwsBaseURL, formatFloat(lat), formatFloat(lon))
+
+	var alertsResp nwsAlertsResponse
+	if err := p.get(ctx, url, &alertsResp); err != nil {
+		return nil, err
+	}
+
+	alerts := make([]Alert, len(alertsResp.Features))
+	for i, feature := range alertsResp.Features {
+		alerts[i] = Alert{
+			ID:          feature.ID,
+			SenderName:  feature.Properties.SenderName,
+			Event:       feature.Properties.Event,
+			Severity:    normalizeNWSSeverity(feature.Properties.Severity),
+			Headline:    feature.Properties.Headline,
+			Description: feature.Properties.Description,
+			AreaDesc:    feature.Properties.AreaDesc,
+			Onset:       feature.Properties.Onset,
+			Expires:     feature.Properties.Expires,
+		}
+	}
+	return alerts, nil
+}
+
+// normalizeNWSSeverity lowercases NWS's CAP severity vocabulary
+// ("Minor"/"Moderate"/"Severe"/"Extreme") into AlertSeverity.
+func normalizeNWSSeverity(severity string) AlertSeverity {
+	switch severity {
+	case "Minor":
+		return AlertSeverityMinor
+	case "Moderate":
+		return AlertSeverityModerate
+	case "Severe":
+		return AlertSeveritySevere
+	case "Extreme":
+		return AlertSeverityExtreme
+	default:
+		return AlertSeverityUnknown
+	}
+}
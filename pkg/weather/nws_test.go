@@ -0,0 +1,117 @@
+package weather
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNWSProvider_GetAlerts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/geo+json", r.Header.Get("Accept"))
+		assert.NotEmpty(t, r.Header.Get("User-Agent"))
+
+		_, _ = w.Write([]byte(`{
+			"features": [
+				{
+					"id": "urn:oid:2.49.0.1.840.0.alert1",
+					"properties": {
+						"senderName": "NWS Chicago IL",
+						"event": "Flood Watch",
+						"severity": "Moderate",
+						"headline": "Flood Watch issued",
+						"description": "Flooding is possible.",
+						"areaDesc": "Cook County",
+						"onset": "2026-01-16T12:00:00-06:00",
+						"expires": "2026-01-17T00:00:00-06:00"
+					}
+				}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	provider := newTestNWSProvider(t, server)
+	alerts, err := provider.GetAlerts(context.Background(), 41.88, -87.63)
+
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "urn:oid:2.49.0.1.840.0.alert1", alerts[0].ID)
+	assert.Equal(t, "NWS Chicago IL", alerts[0].SenderName)
+	assert.Equal(t, "Flood Watch", alerts[0].Event)
+	assert.Equal(t, AlertSeverityModerate, alerts[0].Severity)
+	assert.Equal(t, "Cook County", alerts[0].AreaDesc)
+}
+
+func TestNWSProvider_GetAlerts_NoActiveAlerts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"features": []}`))
+	}))
+	defer server.Close()
+
+	provider := newTestNWSProvider(t, server)
+	alerts, err := provider.GetAlerts(context.Background(), 41.88, -87.63)
+
+	require.NoError(t, err)
+	assert.Empty(t, alerts)
+}
+
+func TestNWSProvider_GetAlerts_UpstreamError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("boom"))
+	}))
+	defer server.Close()
+
+	provider := newTestNWSProvider(t, server)
+	alerts, err := provider.GetAlerts(context.Background(), 41.88, -87.63)
+
+	require.Error(t, err)
+	assert.Nil(t, alerts)
+}
+
+func TestNormalizeNWSSeverity(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected AlertSeverity
+	}{
+		{"Minor", AlertSeverityMinor},
+		{"Moderate", AlertSeverityModerate},
+		{"Severe", AlertSeveritySevere},
+		{"Extreme", AlertSeverityExtreme},
+		{"Unknown", AlertSeverityUnknown},
+		{"", AlertSeverityUnknown},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, normalizeNWSSeverity(tt.input))
+	}
+}
+
+// newTestNWSProvider creates an NWSProvider whose requests all resolve to
+// server, regardless of path, since /points/{lat},{lon} would otherwise
+// need to be stubbed separately from /alerts/active.
+func newTestNWSProvider(t *testing.T, server *httptest.Server) *NWSProvider {
+	t.Helper()
+	return NewNWSProviderWithHTTP(&redirectingRequester{baseURL: server.URL}, "test-agent")
+}
+
+// redirectingRequester rewrites every request's scheme/host to target, so
+// tests can point NWSProvider at an httptest.Server without needing to
+// stub the NWS points-lookup response separately.
+type redirectingRequester struct {
+	baseURL string
+}
+
+func (r *redirectingRequester) Do(req *http.Request) (*http.Response, error) {
+	target, err := http.NewRequest(req.Method, r.baseURL+req.URL.Path+"?"+req.URL.RawQuery, req.Body)
+	if err != nil {
+		return nil, err
+	}
+	target.Header = req.Header
+	return http.DefaultClient.Do(target)
+}
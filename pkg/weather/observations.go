@@ -0,0 +1,240 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"time"
+)
+
+// archiveBaseURL is Open-Meteo's historical weather archive endpoint,
+// queried by GetHistoricalObservations.
+const archiveBaseURL = "https://archive-api.open-meteo.com/v1/archive"
+
+// ErrStationNotFound is returned by GetObservationByStationID when no
+// known station matches the requested ID.
+var ErrStationNotFound = errors.New("weather: station not found")
+
+// Station is a fixed weather-observation point, identified the way
+// DWD's (Deutscher Wetterdienst) open-data station list identifies them:
+// a short station ID alongside its name and coordinates.
+type Station struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Elevation float64 `json:"elevation"`
+}
+
+// knownStations is a small, hardcoded sample of DWD station metadata
+// (id/name/coordinates/elevation), standing in for a full fetch and
+// parse of DWD's open-data station list, which this package doesn't
+// have network access to mirror. GetNearestStation and
+// GetObservationByStationID only resolve stations from this table.
+var knownStations = []Station{
+	{ID: "10382", Name: "Berlin-Tegel", Latitude: 52.5644, Longitude: 13.3088, Elevation: 36},
+	{ID: "10865", Name: "Munich-Airport", Latitude: 48.3538, Longitude: 11.7861, Elevation: 447},
+	{ID: "10147", Name: "Hamburg-Fuhlsbuettel", Latitude: 53.6332, Longitude: 9.9881, Elevation: 11},
+	{ID: "10637", Name: "Frankfurt-Airport", Latitude: 50.0379, Longitude: 8.5622, Elevation: 113},
+	{ID: "10385", Name: "Potsdam", Latitude: 52.3810, Longitude: 13.0622, Elevation: 81},
+}
+
+// StationByID looks up a known Station by its ID.
+func StationByID(stationID string) (Station, bool) {
+	for _, s := range knownStations {
+		if s.ID == stationID {
+			return s, true
+		}
+	}
+	return Station{}, false
+}
+
+// haversineKM returns the great-circle distance between two coordinates
+// in kilometers.
+func haversineKM(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadiusKM = 6371.0
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+	return earthRadiusKM * c
+}
+
+// GetNearestStation returns the known Station closest to (lat, lon) by
+// great-circle distance.
+func (c *Client) GetNearestStation(lat, lon float64) (*Station, error) {
+	if len(knownStations) == 0 {
+		return nil, ErrStationNotFound
+	}
+
+	nearest := knownStations[0]
+	nearestDistance := haversineKM(lat, lon, nearest.Latitude, nearest.Longitude)
+	for _, s := range knownStations[1:] {
+		if d := haversineKM(lat, lon, s.Latitude, s.Longitude); d < nearestDistance {
+			nearest, nearestDistance = s, d
+		}
+	}
+	return &nearest, nil
+}
+
+// GetObservationByStationID returns stationID's current observation,
+// resolved against GetHistoricalObservations for the current UTC hour.
+func (c *Client) GetObservationByStationID(ctx context.Context, stationID string) (*Observation, error) {
+	station, ok := StationByID(stationID)
+	if !ok {
+		return nil, fmt.Errorf("station %q: %w", stationID, ErrStationNotFound)
+	}
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	observations, err := c.getHistoricalObservations(ctx, station, today, today)
+	if err != nil {
+		return nil, err
+	}
+	if len(observations) == 0 {
+		return nil, fmt.Errorf("station %q: no observations available: %w", stationID, ErrStationNotFound)
+	}
+
+	// The most recent hour at or before now is the closest thing to a
+	// "current" reading the archive API (which lags live data by design)
+	// can offer.
+	latest := observations[0]
+	for _, obs := range observations {
+		if !obs.Time.After(now) && obs.Time.After(latest.Time) {
+			latest = obs
+		}
+	}
+	return &latest, nil
+}
+
+// Observation is a single hourly reading from GetHistoricalObservations,
+// covering the fields a station-based observation API reports: air
+// temperature and moisture, pressure, wind, short-window precipitation
+// totals, and whether the reading fell during daylight.
+type Observation struct {
+	Time             time.Time `json:"time"`
+	Temperature      float64   `json:"temperature"`
+	Dewpoint         float64   `json:"dewpoint"`
+	Humidity         int       `json:"humidity"`
+	PressureMsl      float64   `json:"pressure_msl"`
+	WindSpeed        float64   `json:"wind_speed"`
+	WindDirection    float64   `json:"wind_direction"`
+	WindGust         float64   `json:"wind_gust"`
+	Precipitation10m float64   `json:"precipitation_10m"`
+	Precipitation1h  float64   `json:"precipitation_1h"`
+	Precipitation24h float64   `json:"precipitation_24h"`
+	IsDay            bool      `json:"is_day"`
+}
+
+// openMeteoArchiveResponse represents Open-Meteo's archive API response.
+type openMeteoArchiveResponse struct {
+	Hourly struct {
+		Time          []string  `json:"time"`
+		Temperature   []float64 `json:"temperature_2m"`
+		Dewpoint      []float64 `json:"dew_point_2m"`
+		Humidity      []int     `json:"relative_humidity_2m"`
+		PressureMsl   []float64 `json:"pressure_msl"`
+		WindSpeed     []float64 `json:"wind_speed_10m"`
+		WindDirection []float64 `json:"wind_direction_10m"`
+		WindGust      []float64 `json:"wind_gusts_10m"`
+		Precipitation []float64 `json:"precipitation"`
+		IsDay         []int     `json:"is_day"`
+	} `json:"hourly"`
+}
+
+// GetHistoricalObservations returns stationID's hourly observations
+// between from and to (inclusive, UTC dates), fetched from Open-Meteo's
+// archive API at the station's coordinates.
+func (c *Client) GetHistoricalObservations(ctx context.Context, stationID string, from, to time.Time) ([]Observation, error) {
+	station, ok := StationByID(stationID)
+	if !ok {
+		return nil, fmt.Errorf("station %q: %w", stationID, ErrStationNotFound)
+	}
+	return c.getHistoricalObservations(ctx, station, from, to)
+}
+
+func (c *Client) getHistoricalObservations(ctx context.Context, station Station, from, to time.Time) ([]Observation, error) {
+	params := url.Values{}
+	params.Set("latitude", formatFloat(station.Latitude))
+	params.Set("longitude", formatFloat(station.Longitude))
+	params.Set("start_date", from.Format("2006-01-02"))
+	params.Set("end_date", to.Format("2006-01-02"))
+	params.Set("hourly", "temperature_2m,dew_point_2m,relative_humidity_2m,pressure_msl,wind_speed_10m,wind_direction_10m,wind_gusts_10m,precipitation,is_day")
+
+	apiURL := fmt.Sprintf("%s?%s", archiveBaseURL, params.Encode())
+
+	body, err := retryableGet(ctx, c.httpClient, apiURL, "failed to fetch historical observations", c.maxRetryAttempts)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp openMeteoArchiveResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	observations := make([]Observation, len(apiResp.Hourly.Time))
+	var rolling10m, rolling1h, rolling24h []float64
+	for i, ts := range apiResp.Hourly.Time {
+		precip := valueAt(apiResp.Hourly.Precipitation, i)
+
+		rolling10m = append(rolling10m, precip)
+		rolling1h = append(rolling1h, precip)
+		rolling24h = append(rolling24h, precip)
+
+		observations[i] = Observation{
+			Time:             parseHourlyTime(ts, time.UTC),
+			Temperature:      valueAt(apiResp.Hourly.Temperature, i),
+			Dewpoint:         valueAt(apiResp.Hourly.Dewpoint, i),
+			Humidity:         intValueAt(apiResp.Hourly.Humidity, i),
+			PressureMsl:      valueAt(apiResp.Hourly.PressureMsl, i),
+			WindSpeed:        valueAt(apiResp.Hourly.WindSpeed, i),
+			WindDirection:    valueAt(apiResp.Hourly.WindDirection, i),
+			WindGust:         valueAt(apiResp.Hourly.WindGust, i),
+			Precipitation10m: sumLastN(rolling10m, 1),
+			Precipitation1h:  sumLastN(rolling1h, 1),
+			Precipitation24h: sumLastN(rolling24h, 24),
+			IsDay:            intValueAt(apiResp.Hourly.IsDay, i) != 0,
+		}
+	}
+
+	return observations, nil
+}
+
+// valueAt returns values[i], or 0 if i is out of range.
+func valueAt(values []float64, i int) float64 {
+	if i < 0 || i >= len(values) {
+		return 0
+	}
+	return values[i]
+}
+
+// intValueAt returns values[i], or 0 if i is out of range.
+func intValueAt(values []int, i int) int {
+	if i < 0 || i >= len(values) {
+		return 0
+	}
+	return values[i]
+}
+
+// sumLastN sums the last n entries of values (fewer if values is
+// shorter), Open-Meteo's archive API only reports instantaneous
+// precipitation per hour rather than the rolling windows a live station
+// feed would, so the 10m/1h/24h fields are this package's own rollup of
+// that hourly figure.
+func sumLastN(values []float64, n int) float64 {
+	if n > len(values) {
+		n = len(values)
+	}
+	var sum float64
+	for _, v := range values[len(values)-n:] {
+		sum += v
+	}
+	return sum
+}
@@ -0,0 +1,138 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStationByID(t *testing.T) {
+	station, ok := StationByID("10382")
+	require.True(t, ok)
+	assert.Equal(t, "Berlin-Tegel", station.Name)
+
+	_, ok = StationByID("does-not-exist")
+	assert.False(t, ok)
+}
+
+func TestGetNearestStation(t *testing.T) {
+	client := NewClient()
+
+	station, err := client.GetNearestStation(52.52, 13.405)
+	require.NoError(t, err)
+	assert.Equal(t, "Berlin-Tegel", station.Name)
+
+	station, err = client.GetNearestStation(48.1351, 11.5820)
+	require.NoError(t, err)
+	assert.Equal(t, "Munich-Airport", station.Name)
+}
+
+func TestGetHistoricalObservations(t *testing.T) {
+	tests := []struct {
+		name          string
+		stationID     string
+		mockResponse  string
+		mockStatus    int
+		mockError     error
+		expectError   bool
+		errorContains string
+		expectedLen   int
+	}{
+		{
+			name:      "successful fetch",
+			stationID: "10382",
+			mockResponse: `{
+				"hourly": {
+					"time": ["2026-01-16T00:00", "2026-01-16T01:00"],
+					"temperature_2m": [3.5, 3.1],
+					"dew_point_2m": [1.0, 0.8],
+					"relative_humidity_2m": [85, 87],
+					"pressure_msl": [1012.3, 1012.1],
+					"wind_speed_10m": [4.2, 4.5],
+					"wind_direction_10m": [220, 225],
+					"wind_gusts_10m": [8.0, 8.4],
+					"precipitation": [0.1, 0.0],
+					"is_day": [0, 0]
+				}
+			}`,
+			mockStatus:  http.StatusOK,
+			expectedLen: 2,
+		},
+		{
+			name:          "unknown station",
+			stationID:     "nonexistent",
+			expectError:   true,
+			errorContains: "station not found",
+		},
+		{
+			name:          "http client error",
+			stationID:     "10382",
+			mockError:     errors.New("network error"),
+			expectError:   true,
+			errorContains: "failed to fetch historical observations",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockHTTPClient{
+				GetFunc: func(url string) (*http.Response, error) {
+					if tt.mockError != nil {
+						return nil, tt.mockError
+					}
+					return &http.Response{
+						StatusCode: tt.mockStatus,
+						Body:       io.NopCloser(strings.NewReader(tt.mockResponse)),
+					}, nil
+				},
+			}
+
+			client := NewClientWithHTTP(mockClient)
+			from := time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC)
+			to := from
+			observations, err := client.GetHistoricalObservations(context.Background(), tt.stationID, from, to)
+
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, observations, tt.expectedLen)
+			assert.Equal(t, 3.5, observations[0].Temperature)
+			assert.Equal(t, 0.1, observations[0].Precipitation1h)
+		})
+	}
+}
+
+func TestGetObservationByStationID(t *testing.T) {
+	t.Run("unknown station", func(t *testing.T) {
+		client := NewClientWithHTTP(&MockHTTPClient{})
+		_, err := client.GetObservationByStationID(context.Background(), "nonexistent")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrStationNotFound))
+	})
+
+	t.Run("no observations available", func(t *testing.T) {
+		mockClient := &MockHTTPClient{
+			GetFunc: func(url string) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"hourly":{"time":[]}}`)),
+				}, nil
+			},
+		}
+		client := NewClientWithHTTP(mockClient)
+		_, err := client.GetObservationByStationID(context.Background(), "10382")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrStationNotFound))
+	})
+}
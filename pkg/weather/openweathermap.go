@@ -0,0 +1,330 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const owmBaseURL = "https://api.openweathermap.org/data/2.5"
+
+// OpenWeatherMapProvider implements Provider against the OpenWeatherMap
+// API. Unlike NWS's two-step lookup, every call is a single request
+// authenticated with an appid query parameter; units=metric is always
+// requested so responses line up with the °C/km/h units the other
+// providers report.
+type OpenWeatherMapProvider struct {
+	httpClient Requester
+	apiKey     string
+}
+
+// NewOpenWeatherMapProvider creates an OpenWeatherMapProvider authenticated
+// with apiKey.
+func NewOpenWeatherMapProvider(apiKey string) *OpenWeatherMapProvider {
+	return NewOpenWeatherMapProviderWithHTTP(&http.Client{Timeout: defaultRequestTimeout}, apiKey)
+}
+
+// NewOpenWeatherMapProviderWithHTTP creates an OpenWeatherMapProvider with
+// a custom Requester, for tests.
+func NewOpenWeatherMapProviderWithHTTP(httpClient Requester, apiKey string) *OpenWeatherMapProvider {
+	return &OpenWeatherMapProvider{httpClient: httpClient, apiKey: apiKey}
+}
+
+type owmWeatherCondition struct {
+	ID int `json:"id"`
+}
+
+type owmCurrentResponse struct {
+	Weather []owmWeatherCondition `json:"weather"`
+	Main    struct {
+		Temp     float64 `json:"temp"`
+		Humidity int     `json:"humidity"`
+	} `json:"main"`
+	Wind struct {
+		Speed float64 `json:"speed"`
+	} `json:"wind"`
+	Timezone int `json:"timezone"`
+}
+
+func (p *OpenWeatherMapProvider) get(ctx context.Context, endpoint string, params url.Values, out interface{}) error {
+	params.Set("appid", p.apiKey)
+	params.Set("units", "metric")
+
+	apiURL := fmt.Sprintf("%s/%s?%s", owmBaseURL, endpoint, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", endpoint, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// GetCurrentWeather fetches current conditions via GET /weather.
+func (p *OpenWeatherMapProvider) GetCurrentWeather(ctx context.Context, lat, lon float64) (*WeatherResponse, error) {
+	params := url.Values{}
+	params.Set("lat", formatFloat(lat))
+	params.Set("lon", formatFloat(lon))
+
+	var apiResp owmCurrentResponse
+	if err := p.get(ctx, "weather", params, &apiResp); err != nil {
+		return nil, err
+	}
+
+	code := -1
+	if len(apiResp.Weather) > 0 {
+		code = NormalizeWeatherCode(SourceOWM, apiResp.Weather[0].ID)
+	}
+
+	return &WeatherResponse{
+		Location: Location{Latitude: lat, Longitude: lon},
+		Current: CurrentWeather{
+			Temperature:        apiResp.Main.Temp,
+			Humidity:           apiResp.Main.Humidity,
+			WindSpeed:          apiResp.Wind.Speed,
+			WeatherCode:        code,
+			WeatherDescription: GetWeatherDescription(code),
+		},
+		Units: Units{
+			Temperature:   "°C",
+			WindSpeed:     "m/s",
+			Humidity:      "%",
+			Pressure:      "hPa",
+			Precipitation: "mm",
+		},
+	}, nil
+}
+
+// owmOneCallResponse represents the subset of /onecall this provider uses
+// for daily forecasts, hourly forecasts, and alerts - the three things
+// OpenWeatherMap's free /weather endpoint can't answer on its own.
+type owmOneCallResponse struct {
+	Timezone string `json:"timezone"`
+	Hourly   []struct {
+		Dt        int64                 `json:"dt"`
+		Temp      float64               `json:"temp"`
+		Humidity  int                   `json:"humidity"`
+		Pop       float64               `json:"pop"`
+		WindSpeed float64               `json:"wind_speed"`
+		Weather   []owmWeatherCondition `json:"weather"`
+	} `json:"hourly"`
+	Daily []struct {
+		Dt   int64 `json:"dt"`
+		Temp struct {
+			Max float64 `json:"max"`
+			Min float64 `json:"min"`
+		} `json:"temp"`
+		Pop     float64               `json:"pop"`
+		Weather []owmWeatherCondition `json:"weather"`
+	} `json:"daily"`
+	Alerts []struct {
+		Event       string `json:"event"`
+		Description string `json:"description"`
+		Start       int64  `json:"start"`
+		End         int64  `json:"end"`
+	} `json:"alerts"`
+}
+
+func (p *OpenWeatherMapProvider) oneCall(ctx context.Context, lat, lon float64) (*owmOneCallResponse, error) {
+	params := url.Values{}
+	params.Set("lat", formatFloat(lat))
+	params.Set("lon", formatFloat(lon))
+
+	var apiResp owmOneCallResponse
+	if err := p.get(ctx, "onecall", params, &apiResp); err != nil {
+		return nil, err
+	}
+	return &apiResp, nil
+}
+
+// GetForecast fetches a daily forecast via GET /onecall.
+func (p *OpenWeatherMapProvider) GetForecast(ctx context.Context, lat, lon float64, days int) (*ForecastResponse, error) {
+	apiResp, err := p.oneCall(ctx, lat, lon)
+	if err != nil {
+		return nil, err
+	}
+
+	daily := apiResp.Daily
+	if len(daily) > days {
+		daily = daily[:days]
+	}
+
+	forecast := make([]ForecastDay, len(daily))
+	for i, day := range daily {
+		code := -1
+		if len(day.Weather) > 0 {
+			code = NormalizeWeatherCode(SourceOWM, day.Weather[0].ID)
+		}
+
+		forecast[i] = ForecastDay{
+			Date:                     formatUnixDate(day.Dt),
+			TemperatureMax:           day.Temp.Max,
+			TemperatureMin:           day.Temp.Min,
+			PrecipitationProbability: int(day.Pop * 100),
+			WeatherCode:              code,
+			WeatherDescription:       GetWeatherDescription(code),
+		}
+	}
+
+	return &ForecastResponse{
+		Location: Location{Latitude: lat, Longitude: lon, Timezone: apiResp.Timezone},
+		Forecast: forecast,
+	}, nil
+}
+
+// GetHourlyForecast fetches an hourly forecast via GET /onecall, capped
+// at maxHourlyForecastHours.
+func (p *OpenWeatherMapProvider) GetHourlyForecast(ctx context.Context, lat, lon float64, hours int) (*HourlyForecastResponse, error) {
+	if hours > maxHourlyForecastHours {
+		hours = maxHourlyForecastHours
+	}
+
+	apiResp, err := p.oneCall(ctx, lat, lon)
+	if err != nil {
+		return nil, err
+	}
+
+	hourly := apiResp.Hourly
+	if len(hourly) > hours {
+		hourly = hourly[:hours]
+	}
+
+	entries := make([]HourlyForecastEntry, len(hourly))
+	for i, hour := range hourly {
+		code := -1
+		if len(hour.Weather) > 0 {
+			code = NormalizeWeatherCode(SourceOWM, hour.Weather[0].ID)
+		}
+
+		entries[i] = HourlyForecastEntry{
+			Time:                     time.Unix(hour.Dt, 0).UTC(),
+			Temperature:              hour.Temp,
+			Humidity:                 hour.Humidity,
+			PrecipitationProbability: int(hour.Pop * 100),
+			WindSpeed:                hour.WindSpeed,
+			WeatherCode:              code,
+			WeatherDescription:       GetWeatherDescription(code),
+		}
+	}
+
+	return &HourlyForecastResponse{
+		Location: Location{Latitude: lat, Longitude: lon, Timezone: apiResp.Timezone},
+		Hourly:   entries,
+	}, nil
+}
+
+// GetAlerts fetches active alerts via GET /onecall. OpenWeatherMap doesn't
+// report a CAP severity, so every alert normalizes to AlertSeverityUnknown.
+func (p *OpenWeatherMapProvider) GetAlerts(ctx context.Context, lat, lon float64) ([]Alert, error) {
+	apiResp, err := p.oneCall(ctx, lat, lon)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]Alert, len(apiResp.Alerts))
+	for i, alert := range apiResp.Alerts {
+		alerts[i] = Alert{
+			// OpenWeatherMap doesn't assign alerts an ID, so synthesize a
+			// stable one from the fields that identify a distinct alert.
+			ID:          fmt.Sprintf("%s-%d", alert.Event, alert.Start),
+			Event:       alert.Event,
+			Severity:    AlertSeverityUnknown,
+			Headline:    alert.Event,
+			Description: alert.Description,
+			Onset:       formatUnixDate(alert.Start),
+			Expires:     formatUnixDate(alert.End),
+		}
+	}
+	return alerts, nil
+}
+
+// owmGeocodingResult represents a single match from OWM's direct
+// geocoding API.
+type owmGeocodingResult struct {
+	Name string  `json:"name"`
+	Lat  float64 `json:"lat"`
+	Lon  float64 `json:"lon"`
+}
+
+// GeocodeCity resolves cityName via OWM's direct geocoding API
+// (/geo/1.0/direct), a separate host from owmBaseURL. OWM's geocoding
+// response carries no timezone, so GeocodingResult.Timezone is left empty.
+func (p *OpenWeatherMapProvider) GeocodeCity(ctx context.Context, cityName string) (*GeocodingResult, error) {
+	params := url.Values{}
+	params.Set("q", cityName)
+	params.Set("limit", "1")
+	params.Set("appid", p.apiKey)
+
+	apiURL := fmt.Sprintf("https://api.openweathermap.org/geo/1.0/direct?%s", params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to geocode city: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var results []owmGeocodingResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("city not found: %w", ErrCityNotFound)
+	}
+
+	return &GeocodingResult{
+		Name:      results[0].Name,
+		Latitude:  results[0].Lat,
+		Longitude: results[0].Lon,
+	}, nil
+}
+
+// Capabilities reports that OpenWeatherMapProvider doesn't (yet) populate
+// any of the optional data points beyond the baseline WeatherResponse
+// fields.
+func (p *OpenWeatherMapProvider) Capabilities() ProviderCapabilities {
+	return ProviderCapabilities{}
+}
+
+// Name identifies this provider as "openweathermap" for provider selection
+// (see MultiProvider.SelectProvider).
+func (p *OpenWeatherMapProvider) Name() string {
+	return "openweathermap"
+}
+
+// formatUnixDate renders a Unix timestamp as RFC 3339, matching the
+// timestamp format the other providers already use for Date/Time fields.
+func formatUnixDate(unix int64) string {
+	return time.Unix(unix, 0).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// defaultSweepInterval is how often Manager's background sweeper checks for
+// expired rows when NewManager is given a zero interval.
+const defaultSweepInterval = 5 * time.Minute
+
+// Manager runs Store's background eviction sweep on a fixed interval, the
+// "new cache.Manager" alternative to wiring the sweeper directly into
+// database.Connect - kept here instead so internal/database stays
+// weather-agnostic, the same way weather.Prefetcher's cron ticker lives in
+// pkg/weather rather than in cmd/api.
+type Manager struct {
+	db       *gorm.DB
+	interval time.Duration
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewManager creates a Manager sweeping db's weather cache tables every
+// interval (defaultSweepInterval if interval <= 0).
+func NewManager(db *gorm.DB, interval time.Duration) *Manager {
+	if interval <= 0 {
+		interval = defaultSweepInterval
+	}
+	return &Manager{db: db, interval: interval}
+}
+
+// Start begins sweeping in the background. Call Stop to end it.
+func (m *Manager) Start() {
+	m.stop = make(chan struct{})
+	m.done = make(chan struct{})
+
+	go func() {
+		defer close(m.done)
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				m.sweep()
+			case <-m.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends the background sweep, waiting for any in-flight sweep to
+// finish.
+func (m *Manager) Stop() {
+	close(m.stop)
+	<-m.done
+}
+
+// sweep deletes every row in the three weather cache tables past its
+// ExpiresAt, logging nothing on a per-table failure - a stale row left
+// behind until the next tick just means Store.Get returns an extra-old
+// cache entry, not a correctness issue.
+func (m *Manager) sweep() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	_ = m.db.WithContext(ctx).Where("expires_at < ?", now).Delete(&Location{}).Error
+	_ = m.db.WithContext(ctx).Where("expires_at < ?", now).Delete(&WeatherSnapshot{}).Error
+	_ = m.db.WithContext(ctx).Where("expires_at < ?", now).Delete(&ForecastSnapshot{}).Error
+}
@@ -0,0 +1,73 @@
+// Package postgres is a GORM-backed weather.Cache, storing geocoded
+// locations and current/forecast snapshots in the same PostgreSQL database
+// as pkg/dax, for deployments that want weather responses persisted and
+// read-through cached rather than held only in process memory.
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Location is a cached GeocodeCity result, keyed by the lower-cased city
+// name (see weather.Cache's key convention). Latitude/Longitude store the
+// coordinates rounded to 3 decimal places the cache key was built from, so
+// the composite idx_locations_coords index also serves lookups by
+// (lat, lon, rounded_to_3dp).
+type Location struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key"`
+	CacheKey  string    `gorm:"type:varchar(255);uniqueIndex;not null"`
+	City      string    `gorm:"type:varchar(255);not null;index:idx_locations_city_lower,expression:lower(city)"`
+	Latitude  float64   `gorm:"not null;index:idx_locations_coords"`
+	Longitude float64   `gorm:"not null;index:idx_locations_coords"`
+	Timezone  string    `gorm:"type:varchar(100)"`
+	Payload   string    `gorm:"type:jsonb;not null"`
+	// ExpiresAt is when Manager's sweeper deletes this row, set to Put's
+	// write time plus its ttl.
+	ExpiresAt time.Time `gorm:"not null;index:idx_locations_expires_at"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName sets the table name for GORM
+func (Location) TableName() string {
+	return "locations"
+}
+
+// WeatherSnapshot is a cached by-coordinates current-weather response,
+// keyed by cache key "current:<lat>,<lon>".
+type WeatherSnapshot struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key"`
+	CacheKey  string    `gorm:"type:varchar(255);uniqueIndex;not null"`
+	Latitude  float64   `gorm:"not null;index:idx_weather_snapshots_coords"`
+	Longitude float64   `gorm:"not null;index:idx_weather_snapshots_coords"`
+	Payload   string    `gorm:"type:jsonb;not null"`
+	ExpiresAt time.Time `gorm:"not null;index:idx_weather_snapshots_expires_at"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName sets the table name for GORM
+func (WeatherSnapshot) TableName() string {
+	return "weather_snapshots"
+}
+
+// ForecastSnapshot is a cached by-coordinates forecast response, keyed by
+// cache key "forecast:<lat>,<lon>:<days>".
+type ForecastSnapshot struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key"`
+	CacheKey  string    `gorm:"type:varchar(255);uniqueIndex;not null"`
+	Latitude  float64   `gorm:"not null;index:idx_forecast_snapshots_coords"`
+	Longitude float64   `gorm:"not null;index:idx_forecast_snapshots_coords"`
+	Days      int       `gorm:"not null"`
+	Payload   string    `gorm:"type:jsonb;not null"`
+	ExpiresAt time.Time `gorm:"not null;index:idx_forecast_snapshots_expires_at"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName sets the table name for GORM
+func (ForecastSnapshot) TableName() string {
+	return "forecast_snapshots"
+}
@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/f00b455/blank-go/pkg/weather"
+)
+
+// ErrMigrationsPending is returned by NewStore when db has no locations
+// table, so a misconfigured deploy (AutoMigrate never run) fails at
+// startup with a clear cause, mirroring dax.ErrMigrationsPending and
+// execution.ErrMigrationsPending.
+var ErrMigrationsPending = errors.New("weather cache tables not found: run AutoMigrate before starting the server")
+
+// Store implements weather.Cache against PostgreSQL via GORM, routing a key
+// to one of the locations/weather_snapshots/forecast_snapshots tables by
+// its "current:"/"forecast:"/"geocode:" prefix (see weather's cacheKey*
+// helpers).
+type Store struct {
+	db *gorm.DB
+}
+
+// NewStore creates a Store, fast-failing with ErrMigrationsPending if db's
+// schema hasn't been brought up yet.
+func NewStore(db *gorm.DB) (*Store, error) {
+	if !db.Migrator().HasTable(&Location{}) {
+		return nil, ErrMigrationsPending
+	}
+	return &Store{db: db}, nil
+}
+
+// AutoMigrate creates/updates the locations, weather_snapshots and
+// forecast_snapshots tables via GORM.
+func AutoMigrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(&Location{}, &WeatherSnapshot{}, &ForecastSnapshot{}); err != nil {
+		return fmt.Errorf("failed to migrate weather cache schema: %w", err)
+	}
+	return nil
+}
+
+// Get implements weather.Cache.
+func (s *Store) Get(ctx context.Context, key string) ([]byte, time.Duration, bool) {
+	switch {
+	case strings.HasPrefix(key, "current:"):
+		var row WeatherSnapshot
+		if err := s.db.WithContext(ctx).Where("cache_key = ?", key).First(&row).Error; err != nil {
+			return nil, 0, false
+		}
+		return []byte(row.Payload), time.Since(row.UpdatedAt), true
+	case strings.HasPrefix(key, "forecast:"):
+		var row ForecastSnapshot
+		if err := s.db.WithContext(ctx).Where("cache_key = ?", key).First(&row).Error; err != nil {
+			return nil, 0, false
+		}
+		return []byte(row.Payload), time.Since(row.UpdatedAt), true
+	case strings.HasPrefix(key, "geocode:"):
+		var row Location
+		if err := s.db.WithContext(ctx).Where("cache_key = ?", key).First(&row).Error; err != nil {
+			return nil, 0, false
+		}
+		return []byte(row.Payload), time.Since(row.UpdatedAt), true
+	default:
+		return nil, 0, false
+	}
+}
+
+// Put implements weather.Cache.
+func (s *Store) Put(ctx context.Context, key string, payload []byte, ttl time.Duration) error {
+	expiresAt := time.Now().Add(ttl)
+	switch {
+	case strings.HasPrefix(key, "current:"):
+		lat, lon, ok := parseCoordKey(key, "current:")
+		if !ok {
+			return fmt.Errorf("weather/postgres: malformed current cache key %q", key)
+		}
+		return upsert(ctx, s.db, &WeatherSnapshot{
+			ID: uuid.New(), CacheKey: key, Latitude: lat, Longitude: lon,
+			Payload: string(payload), ExpiresAt: expiresAt,
+		})
+	case strings.HasPrefix(key, "forecast:"):
+		lat, lon, days, ok := parseForecastKey(key)
+		if !ok {
+			return fmt.Errorf("weather/postgres: malformed forecast cache key %q", key)
+		}
+		return upsert(ctx, s.db, &ForecastSnapshot{
+			ID: uuid.New(), CacheKey: key, Latitude: lat, Longitude: lon, Days: days,
+			Payload: string(payload), ExpiresAt: expiresAt,
+		})
+	case strings.HasPrefix(key, "geocode:"):
+		var geocode weather.GeocodingResult
+		if err := json.Unmarshal(payload, &geocode); err != nil {
+			return fmt.Errorf("weather/postgres: decoding geocode payload for %q: %w", key, err)
+		}
+		return upsert(ctx, s.db, &Location{
+			ID: uuid.New(), CacheKey: key, City: strings.TrimPrefix(key, "geocode:"),
+			Latitude: geocode.Latitude, Longitude: geocode.Longitude, Timezone: geocode.Timezone,
+			Payload: string(payload), ExpiresAt: expiresAt,
+		})
+	default:
+		return fmt.Errorf("weather/postgres: unrecognized cache key %q", key)
+	}
+}
+
+// parseCoordKey parses a "<prefix><lat>,<lon>" cache key (e.g.
+// "current:52.520,13.410").
+func parseCoordKey(key, prefix string) (lat, lon float64, ok bool) {
+	latStr, lonStr, found := strings.Cut(strings.TrimPrefix(key, prefix), ",")
+	if !found {
+		return 0, 0, false
+	}
+	lat, err := strconv.ParseFloat(latStr, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	lon, err = strconv.ParseFloat(lonStr, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return lat, lon, true
+}
+
+// parseForecastKey parses a "forecast:<lat>,<lon>:<days>" cache key.
+func parseForecastKey(key string) (lat, lon float64, days int, ok bool) {
+	rest := strings.TrimPrefix(key, "forecast:")
+	coords, daysStr, found := strings.Cut(rest, ":")
+	if !found {
+		return 0, 0, 0, false
+	}
+	lat, lon, ok = parseCoordKey(coords, "")
+	if !ok {
+		return 0, 0, 0, false
+	}
+	days, err := strconv.Atoi(daysStr)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return lat, lon, days, true
+}
+
+// upsert writes row, overwriting any existing row sharing its unique
+// cache_key (a Put for a key that's already cached refreshes it in place
+// rather than erroring on the uniqueIndex).
+func upsert(ctx context.Context, db *gorm.DB, row interface{}) error {
+	return db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "cache_key"}},
+		UpdateAll: true,
+	}).Create(row).Error
+}
@@ -0,0 +1,211 @@
+package weather
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/robfig/cron/v3"
+)
+
+// PeakRequest captures a single handled weather query, replayed by
+// Prefetcher once it's identified as part of the hot set. City is set for
+// a by-city-name query; Lat/Lon are set (and City left empty) for a
+// by-coordinates query.
+type PeakRequest struct {
+	City  string
+	Lat   float64
+	Lon   float64
+	Units string
+}
+
+// HotQuery summarizes one entry of Prefetcher's current hot set, as
+// reported by the /_admin/hot debug endpoint.
+type HotQuery struct {
+	Query string `json:"query"`
+	Count int64  `json:"count"`
+}
+
+// peakEntry is what a PeakTracker bucket stores per digest.
+type peakEntry struct {
+	request PeakRequest
+	count   int64
+}
+
+// PeakTracker records how often each distinct weather query is requested,
+// using two buckets swapped on every Prefetcher tick - the same pattern
+// wttr.in uses for its own peak-request cache (one bucket for each half of
+// the hour). Swapping rather than clearing keeps a full tick's worth of
+// history available immediately after a rotation, instead of the hot set
+// momentarily dropping to whatever's been seen since the swap.
+type PeakTracker struct {
+	buckets [2]*sync.Map
+	active  int32
+}
+
+// NewPeakTracker creates an empty PeakTracker.
+func NewPeakTracker() *PeakTracker {
+	return &PeakTracker{buckets: [2]*sync.Map{{}, {}}}
+}
+
+// savePeakRequest records one occurrence of req under digest, a caller-
+// supplied key that identifies the same query across requests (e.g. city
+// name + units, or "lat,lon"+units).
+func (t *PeakTracker) savePeakRequest(digest string, req PeakRequest) {
+	bucket := t.buckets[atomic.LoadInt32(&t.active)]
+	if v, ok := bucket.Load(digest); ok {
+		atomic.AddInt64(&v.(*peakEntry).count, 1)
+		return
+	}
+	bucket.Store(digest, &peakEntry{request: req, count: 1})
+}
+
+// rotate returns every entry accumulated across both buckets, then starts
+// writing new requests into a fresh bucket in place of the one that was
+// already active longest - so the next rotate() still has one full tick's
+// worth of the other bucket's history to report.
+func (t *PeakTracker) rotate() []peakEntry {
+	var all []peakEntry
+	for _, bucket := range t.buckets {
+		bucket.Range(func(_, v interface{}) bool {
+			all = append(all, *v.(*peakEntry))
+			return true
+		})
+	}
+
+	oldest := 1 - atomic.LoadInt32(&t.active)
+	t.buckets[oldest] = &sync.Map{}
+	atomic.StoreInt32(&t.active, oldest)
+
+	return all
+}
+
+// peakDigestForCity builds savePeakRequest's digest for a by-city-name
+// query.
+func peakDigestForCity(city, units string) string {
+	return fmt.Sprintf("city:%s:%s", city, units)
+}
+
+// peakDigestForCoords builds savePeakRequest's digest for a
+// by-coordinates query.
+func peakDigestForCoords(lat, lon float64, units string) string {
+	return fmt.Sprintf("coords:%s,%s:%s", formatFloat(lat), formatFloat(lon), units)
+}
+
+// peakQueryLabel renders req for HotQuery.Query.
+func peakQueryLabel(req PeakRequest) string {
+	if req.City != "" {
+		return req.City
+	}
+	return fmt.Sprintf("%s,%s", formatFloat(req.Lat), formatFloat(req.Lon))
+}
+
+// Prefetcher periodically replays the topN most frequently requested
+// GetCurrentWeatherByCoords/GetWeatherByCity queries against a Service, so
+// their cache entries stay warm - a request for a popular city or
+// coordinate pair keeps being served from Service's cache at sub-ms
+// latency instead of occasionally falling through to a slow upstream
+// fetch right after its TTL expires. The schedule (a robfig/cron spec,
+// e.g. "*/30 * * * *" for wttr.in's :00/:30 cadence) should run no more
+// often than the shortest TTL Prefetcher's queries are cached under, or
+// every tick will just re-read an already-fresh cache entry instead of
+// triggering a real upstream refresh.
+type Prefetcher struct {
+	service *Service
+	tracker *PeakTracker
+	topN    int
+	cron    *cron.Cron
+
+	mu  sync.Mutex
+	hot []HotQuery
+}
+
+// NewPrefetcher creates a Prefetcher that replays the topN hottest queries
+// recorded via RecordQuery against service, on the schedule described by
+// spec.
+func NewPrefetcher(service *Service, topN int, spec string) (*Prefetcher, error) {
+	p := &Prefetcher{
+		service: service,
+		tracker: NewPeakTracker(),
+		topN:    topN,
+		cron:    cron.New(),
+	}
+
+	if _, err := p.cron.AddFunc(spec, p.tick); err != nil {
+		return nil, fmt.Errorf("invalid prefetch schedule %q: %w", spec, err)
+	}
+
+	return p, nil
+}
+
+// RecordQuery notes that cityName (or lat/lon, when cityName is empty) was
+// just served, so Prefetcher can consider it for the next hot set. Callers
+// (typically WeatherHandler) should invoke this once per handled request.
+func (p *Prefetcher) RecordQuery(cityName string, lat, lon float64, units string) {
+	if cityName != "" {
+		p.tracker.savePeakRequest(peakDigestForCity(cityName, units), PeakRequest{City: cityName, Units: units})
+		return
+	}
+	p.tracker.savePeakRequest(peakDigestForCoords(lat, lon, units), PeakRequest{Lat: lat, Lon: lon, Units: units})
+}
+
+// Start begins running ticks on p's schedule in the background.
+func (p *Prefetcher) Start() {
+	p.cron.Start()
+}
+
+// Stop cancels the schedule, waiting for any in-flight tick to finish.
+func (p *Prefetcher) Stop() {
+	<-p.cron.Stop().Done()
+}
+
+// Hot returns the hot set reported by the most recent tick, most-requested
+// first.
+func (p *Prefetcher) Hot() []HotQuery {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	hot := make([]HotQuery, len(p.hot))
+	copy(hot, p.hot)
+	return hot
+}
+
+// tick rotates the peak tracker's buckets, picks the topN hottest queries,
+// and replays each through service so its cache entry is refreshed. A
+// query whose replay fails is counted in prefetchMissesTotal and left for
+// the next request's natural cache miss to retry, rather than aborting the
+// rest of the hot set.
+func (p *Prefetcher) tick() {
+	entries := p.tracker.rotate()
+	sort.Slice(entries, func(i, j int) bool { return entries[i].count > entries[j].count })
+	if len(entries) > p.topN {
+		entries = entries[:p.topN]
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+	defer cancel()
+
+	hot := make([]HotQuery, 0, len(entries))
+	for _, e := range entries {
+		hot = append(hot, HotQuery{Query: peakQueryLabel(e.request), Count: e.count})
+
+		var err error
+		if e.request.City != "" {
+			_, err = p.service.GetWeatherByCity(ctx, e.request.City, e.request.Units, "")
+		} else {
+			_, err = p.service.GetCurrentWeatherByCoords(ctx, formatFloat(e.request.Lat), formatFloat(e.request.Lon), e.request.Units, "")
+		}
+
+		if err != nil {
+			prefetchMissesTotal.Inc()
+			continue
+		}
+		prefetchHitsTotal.Inc()
+	}
+
+	p.mu.Lock()
+	p.hot = hot
+	p.mu.Unlock()
+}
@@ -0,0 +1,75 @@
+package weather
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeakTracker_SavePeakRequestCountsOccurrences(t *testing.T) {
+	tracker := NewPeakTracker()
+
+	tracker.savePeakRequest("city:Berlin:", PeakRequest{City: "Berlin"})
+	tracker.savePeakRequest("city:Berlin:", PeakRequest{City: "Berlin"})
+	tracker.savePeakRequest("city:Paris:", PeakRequest{City: "Paris"})
+
+	entries := tracker.rotate()
+	counts := map[string]int64{}
+	for _, e := range entries {
+		counts[e.request.City] = e.count
+	}
+
+	assert.Equal(t, int64(2), counts["Berlin"])
+	assert.Equal(t, int64(1), counts["Paris"])
+}
+
+func TestPeakTracker_RotateKeepsPriorBucketForOneMoreTick(t *testing.T) {
+	tracker := NewPeakTracker()
+
+	tracker.savePeakRequest("city:Berlin:", PeakRequest{City: "Berlin"})
+	first := tracker.rotate()
+	assert.Len(t, first, 1)
+
+	// Nothing recorded since the rotate; the now-active bucket is the one
+	// that was empty before, but rotate() still reports both buckets, so
+	// Berlin is seen once more before it ages out.
+	second := tracker.rotate()
+	assert.Len(t, second, 1)
+	assert.Equal(t, "Berlin", second[0].request.City)
+
+	third := tracker.rotate()
+	assert.Len(t, third, 0)
+}
+
+func TestPrefetcher_TickReplaysHottestQueries(t *testing.T) {
+	mockClient := new(MockWeatherClient)
+	service := NewServiceWithProvider(mockClient)
+
+	mockClient.On("GeocodeCity", "Berlin").Return(&GeocodingResult{Name: "Berlin", Latitude: 52.52, Longitude: 13.41}, nil)
+	mockClient.On("GetCurrentWeather", 52.52, 13.41).Return(&WeatherResponse{Location: Location{Latitude: 52.52, Longitude: 13.41}}, nil)
+	mockClient.On("GetCurrentWeather", 48.85, 2.35).Return(nil, errors.New("upstream unavailable")).Maybe()
+
+	prefetcher, err := NewPrefetcher(service, 1, "@every 1h")
+	assert.NoError(t, err)
+
+	prefetcher.RecordQuery("Berlin", 0, 0, "")
+	prefetcher.RecordQuery("Berlin", 0, 0, "")
+	prefetcher.RecordQuery("", 48.85, 2.35, "")
+
+	prefetcher.tick()
+
+	hot := prefetcher.Hot()
+	assert.Len(t, hot, 1, "topN=1 should keep only the hottest query")
+	assert.Equal(t, "Berlin", hot[0].Query)
+	assert.Equal(t, int64(2), hot[0].Count)
+	mockClient.AssertExpectations(t)
+}
+
+func TestPrefetcher_InvalidSchedule(t *testing.T) {
+	service := NewServiceWithProvider(new(MockWeatherClient))
+
+	_, err := NewPrefetcher(service, 1, "not a cron spec")
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,27 @@
+package weather
+
+import "context"
+
+// Provider is the contract every weather backend (Open-Meteo, NWS,
+// OpenWeatherMap, ...) implements. Service depends only on this interface,
+// so swapping backends - or wrapping several of them in a FallbackProvider
+// - never touches business logic in service.go. Every method takes a
+// context.Context so a caller's deadline or cancellation bounds the
+// upstream request, the same way pkg/stocks.StocksClient threads ctx
+// through its fetches.
+type Provider interface {
+	// GetCurrentWeather fetches current conditions at lat/lon.
+	GetCurrentWeather(ctx context.Context, lat, lon float64) (*WeatherResponse, error)
+	// GetForecast fetches a daily forecast at lat/lon for the given number
+	// of days.
+	GetForecast(ctx context.Context, lat, lon float64, days int) (*ForecastResponse, error)
+	// GeocodeCity resolves a city name to coordinates.
+	GeocodeCity(ctx context.Context, cityName string) (*GeocodingResult, error)
+	// GetHourlyForecast fetches an hour-by-hour forecast at lat/lon for the
+	// given number of hours.
+	GetHourlyForecast(ctx context.Context, lat, lon float64, hours int) (*HourlyForecastResponse, error)
+	// GetAlerts fetches active weather alerts for lat/lon. A provider with
+	// no alerts feed of its own (e.g. Open-Meteo) returns an empty slice
+	// rather than an error.
+	GetAlerts(ctx context.Context, lat, lon float64) ([]Alert, error)
+}
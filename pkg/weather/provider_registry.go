@@ -0,0 +1,41 @@
+package weather
+
+import "fmt"
+
+// ProviderName identifies a configured weather backend, so callers can
+// select one by name (e.g. from config) without importing every concrete
+// provider type.
+type ProviderName string
+
+const (
+	ProviderOpenMeteo          ProviderName = "open-meteo"
+	ProviderNWS                ProviderName = "nws"
+	ProviderOpenWeatherMap     ProviderName = "openweathermap"
+	ProviderWorldWeatherOnline ProviderName = "worldweatheronline"
+	ProviderMeteologix         ProviderName = "meteologix"
+)
+
+// ProviderRegistry resolves a ProviderName to a concrete Provider.
+type ProviderRegistry struct {
+	providers map[ProviderName]Provider
+}
+
+// NewProviderRegistry creates an empty ProviderRegistry.
+func NewProviderRegistry() *ProviderRegistry {
+	return &ProviderRegistry{providers: make(map[ProviderName]Provider)}
+}
+
+// Register adds or replaces the Provider backing name.
+func (r *ProviderRegistry) Register(name ProviderName, provider Provider) {
+	r.providers[name] = provider
+}
+
+// Get returns the Provider registered for name, or an error if none was
+// registered under that name.
+func (r *ProviderRegistry) Get(name ProviderName) (Provider, error) {
+	provider, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("no weather provider registered for %q", name)
+	}
+	return provider, nil
+}
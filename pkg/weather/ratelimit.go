@@ -0,0 +1,89 @@
+package weather
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// tokenBucket is a simple token-bucket rate limiter: tokens refill
+// continuously at rate per second, up to burst, and each request consumes
+// one token. Mirrors pkg/stocks' resilience.go tokenBucket.
+type tokenBucket struct {
+	mu         sync.Mutex
+	rate       float64
+	burst      float64
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newTokenBucket(ratePerSecond float64, burst int) *tokenBucket {
+	if ratePerSecond <= 0 {
+		ratePerSecond = 1
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:       ratePerSecond,
+		burst:      float64(burst),
+		tokens:     float64(burst),
+		lastRefill: time.Now(),
+	}
+}
+
+// allow reports whether a token is available and, if so, consumes it.
+func (b *tokenBucket) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	b.lastRefill = now
+	b.tokens = math.Min(b.burst, b.tokens+elapsed*b.rate)
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// refund returns one token to the bucket, capped at burst. Used when a
+// request consumed this bucket's token but was then rejected by another
+// bucket it shares a rate-limit decision with.
+func (b *tokenBucket) refund() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+// clientRateLimiter enforces two independent token-bucket ceilings at
+// once, matching the shape of Open-Meteo's free-tier quota: a short-term
+// per-minute burst limit and a longer-term per-day total.
+type clientRateLimiter struct {
+	perMinute *tokenBucket
+	perDay    *tokenBucket
+}
+
+func newClientRateLimiter(limit ClientRateLimit) *clientRateLimiter {
+	return &clientRateLimiter{
+		perMinute: newTokenBucket(float64(limit.RequestsPerMinute)/60, limit.RequestsPerMinute),
+		perDay:    newTokenBucket(float64(limit.RequestsPerDay)/86400, limit.RequestsPerDay),
+	}
+}
+
+// allow reports whether a request fits under both the per-minute and
+// per-day ceilings, consuming a token from each if so. perDay is checked
+// first and refunded if perMinute then rejects, so a throttled request
+// never permanently costs the daily quota.
+func (l *clientRateLimiter) allow() bool {
+	if !l.perDay.allow() {
+		return false
+	}
+	if !l.perMinute.allow() {
+		l.perDay.refund()
+		return false
+	}
+	return true
+}
@@ -0,0 +1,66 @@
+package weather
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ansi color codes used by RenderANSI, matching the palette wttr.in uses
+// for its own terminal output: cyan for location/structure, yellow for
+// temperature, blue for wind and precipitation.
+const (
+	ansiReset  = "\x1b[0m"
+	ansiCyan   = "\x1b[36m"
+	ansiYellow = "\x1b[33m"
+	ansiBlue   = "\x1b[34m"
+)
+
+// RenderPlain renders resp as the multi-line, terminal-friendly plain-text
+// summary returned for an `Accept: text/plain` weather request - no ANSI
+// escape codes, so it's as readable piped to a file or a non-color
+// terminal as it is printed directly.
+func RenderPlain(resp *WeatherResponse) string {
+	return renderLines(resp, func(s string) string { return s })
+}
+
+// RenderANSI renders resp the same as RenderPlain, but with ANSI color
+// escape codes around each line's label, for an `Accept: text/x-ansi`
+// weather request viewed in a color-capable terminal (the same use case
+// curl against wttr.in serves).
+func RenderANSI(resp *WeatherResponse) string {
+	return renderLines(resp, func(s string) string { return ansiCyan + s + ansiReset })
+}
+
+// renderLines builds the shared line layout for RenderPlain and
+// RenderANSI, applying colorLabel to each line's leading label.
+func renderLines(resp *WeatherResponse, colorLabel func(string) string) string {
+	loc := resp.Location.City
+	if loc == "" {
+		loc = fmt.Sprintf("%s,%s", formatFloat(resp.Location.Latitude), formatFloat(resp.Location.Longitude))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", colorLabel("Weather for"), loc)
+	fmt.Fprintf(&b, "%s %s\n", colorLabel("Condition:"), resp.Current.WeatherDescription)
+	fmt.Fprintf(&b, "%s %.1f%s\n", colorLabel("Temperature:"), resp.Current.Temperature, resp.Units.Temperature)
+	fmt.Fprintf(&b, "%s %d%%\n", colorLabel("Humidity:"), resp.Current.Humidity)
+	fmt.Fprintf(&b, "%s %.1f%s\n", colorLabel("Wind:"), resp.Current.WindSpeed, resp.Units.WindSpeed)
+
+	return b.String()
+}
+
+// RendererForAccept resolves an HTTP Accept header to the plain-text or
+// ANSI renderer and response Content-Type a weather handler should use
+// instead of its default JSON body, mirroring
+// dax.ExportSerializerForAccept's substring matching. A nil render func
+// means the caller should fall back to its normal JSON response.
+func RendererForAccept(accept string) (render func(*WeatherResponse) string, contentType string) {
+	switch {
+	case strings.Contains(accept, "text/x-ansi"):
+		return RenderANSI, "text/x-ansi; charset=utf-8"
+	case strings.Contains(accept, "text/plain"):
+		return RenderPlain, "text/plain; charset=utf-8"
+	default:
+		return nil, ""
+	}
+}
@@ -0,0 +1,59 @@
+package weather
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sampleRenderResponse() *WeatherResponse {
+	return &WeatherResponse{
+		Location: Location{City: "Berlin", Latitude: 52.52, Longitude: 13.41},
+		Current:  CurrentWeather{Temperature: 18.5, Humidity: 60, WindSpeed: 3.2, WeatherDescription: "Clear sky"},
+		Units:    Units{Temperature: "°C", WindSpeed: "m/s"},
+	}
+}
+
+func TestRenderPlain(t *testing.T) {
+	out := RenderPlain(sampleRenderResponse())
+
+	assert.Contains(t, out, "Berlin")
+	assert.Contains(t, out, "Clear sky")
+	assert.Contains(t, out, "18.5°C")
+	assert.NotContains(t, out, "\x1b[")
+}
+
+func TestRenderPlain_FallsBackToCoords(t *testing.T) {
+	resp := sampleRenderResponse()
+	resp.Location.City = ""
+
+	out := RenderPlain(resp)
+
+	assert.Contains(t, out, "52.52,13.41")
+}
+
+func TestRenderANSI(t *testing.T) {
+	out := RenderANSI(sampleRenderResponse())
+
+	assert.Contains(t, out, "\x1b[36m")
+	assert.Contains(t, out, "\x1b[0m")
+	assert.Contains(t, out, "Berlin")
+}
+
+func TestRendererForAccept(t *testing.T) {
+	render, contentType := RendererForAccept("text/plain")
+	assert.NotNil(t, render)
+	assert.Equal(t, "text/plain; charset=utf-8", contentType)
+
+	render, contentType = RendererForAccept("text/x-ansi")
+	assert.NotNil(t, render)
+	assert.Equal(t, "text/x-ansi; charset=utf-8", contentType)
+
+	render, contentType = RendererForAccept("application/json")
+	assert.Nil(t, render)
+	assert.Equal(t, "", contentType)
+
+	render, contentType = RendererForAccept("")
+	assert.Nil(t, render)
+	assert.Equal(t, "", contentType)
+}
@@ -0,0 +1,143 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// defaultMaxRetryAttempts is how many times retryableGet tries an
+	// idempotent GET (the initial attempt plus retries) before giving up,
+	// for Clients that don't configure ClientOptions.MaxRetryAttempts.
+	defaultMaxRetryAttempts = 3
+
+	retryBaseDelay = 100 * time.Millisecond
+	retryMaxDelay  = 5 * time.Second
+)
+
+// retryableGet issues an HTTP GET via httpClient, retrying on 429 and 5xx
+// responses (and on network-level timeouts) with exponential backoff plus
+// full jitter, honoring the upstream's Retry-After header when present, up
+// to maxAttempts total tries. errPrefix labels the returned error the same
+// way the caller's own non-retried error paths already do (e.g. "failed to
+// fetch weather data"), so callers can keep matching on that prefix while
+// also using errors.Is against ErrRateLimited, ErrUpstreamUnavailable, or
+// ErrTimeout.
+func retryableGet(ctx context.Context, httpClient HTTPClient, url, errPrefix string, maxAttempts int) ([]byte, error) {
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxRetryAttempts
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		body, retryAfter, err := doGet(httpClient, url)
+		if err == nil {
+			return body, nil
+		}
+		lastErr = fmt.Errorf("%s: %w", errPrefix, err)
+
+		if !isRetryable(err) || attempt == maxAttempts {
+			return nil, lastErr
+		}
+
+		delay := retryAfter
+		if delay <= 0 {
+			delay = backoffDelay(attempt)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("%s: %w", errPrefix, ErrTimeout)
+		case <-timer.C:
+		}
+	}
+	return nil, lastErr
+}
+
+// isRetryable reports whether err is one retryableGet should retry on.
+func isRetryable(err error) bool {
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrUpstreamUnavailable) || errors.Is(err, ErrTimeout)
+}
+
+// doGet issues a single GET, classifying the outcome into a typed
+// sentinel error the caller can retry on (ErrRateLimited for 429,
+// ErrUpstreamUnavailable for 5xx, ErrTimeout for a network-level timeout)
+// or a non-retryable error otherwise. retryAfter reports the upstream's
+// requested backoff for a 429 response, if any.
+func doGet(httpClient HTTPClient, url string) (body []byte, retryAfter time.Duration, err error) {
+	resp, getErr := httpClient.Get(url)
+	if getErr != nil {
+		if isTimeoutErr(getErr) {
+			return nil, 0, fmt.Errorf("%w: %v", ErrTimeout, getErr)
+		}
+		return nil, 0, getErr
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, readErr := io.ReadAll(resp.Body)
+	if readErr != nil {
+		return nil, 0, readErr
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return respBody, 0, nil
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return nil, parseRetryAfter(resp.Header.Get("Retry-After")), fmt.Errorf("API returned status %d: %s: %w", resp.StatusCode, string(respBody), ErrRateLimited)
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return nil, 0, fmt.Errorf("API returned status %d: %s: %w", resp.StatusCode, string(respBody), ErrUpstreamUnavailable)
+	default:
+		return nil, 0, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+}
+
+// isTimeoutErr reports whether err represents a network-level timeout
+// rather than some other transport failure.
+func isTimeoutErr(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return netErr.Timeout()
+	}
+	return false
+}
+
+// backoffDelay returns a randomized (full-jitter) delay for the given
+// retry attempt, doubling the base delay each attempt up to
+// retryMaxDelay.
+func backoffDelay(attempt int) time.Duration {
+	backoff := retryBaseDelay * time.Duration(uint(1)<<uint(attempt-1))
+	if backoff > retryMaxDelay {
+		backoff = retryMaxDelay
+	}
+	return time.Duration(rand.Int63n(int64(backoff)))
+}
+
+// parseRetryAfter parses an HTTP Retry-After header, which is either a
+// number of seconds or an HTTP-date, returning 0 if header is empty or
+// unparseable.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		return time.Until(when)
+	}
+	return 0
+}
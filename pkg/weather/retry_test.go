@@ -0,0 +1,148 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sequencedHTTPClient returns the next response in responses on each call,
+// repeating the last one once exhausted.
+func sequencedHTTPClient(responses ...func() (*http.Response, error)) (*MockHTTPClient, *int) {
+	calls := 0
+	mock := &MockHTTPClient{
+		GetFunc: func(url string) (*http.Response, error) {
+			i := calls
+			if i >= len(responses) {
+				i = len(responses) - 1
+			}
+			calls++
+			return responses[i]()
+		},
+	}
+	return mock, &calls
+}
+
+func statusResponse(status int, body string) func() (*http.Response, error) {
+	return func() (*http.Response, error) {
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}, nil
+	}
+}
+
+func TestClient_GetCurrentWeather_RetriesOn503ThenSucceeds(t *testing.T) {
+	successBody := `{
+		"latitude": 52.52,
+		"longitude": 13.405,
+		"timezone": "Europe/Berlin",
+		"current": {"temperature_2m": 15.5, "relative_humidity_2m": 65, "wind_speed_10m": 12.3, "weather_code": 0}
+	}`
+	mock, calls := sequencedHTTPClient(
+		statusResponse(http.StatusServiceUnavailable, "upstream hiccup"),
+		statusResponse(http.StatusOK, successBody),
+	)
+
+	client := NewClientWithHTTP(mock)
+	result, err := client.GetCurrentWeather(context.Background(), 52.52, 13.405)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, *calls)
+	assert.Equal(t, 15.5, result.Current.Temperature)
+}
+
+func TestClient_GetCurrentWeather_ExhaustsRetriesOn5xx(t *testing.T) {
+	mock, calls := sequencedHTTPClient(statusResponse(http.StatusInternalServerError, "down"))
+
+	client := NewClientWithHTTP(mock)
+	_, err := client.GetCurrentWeather(context.Background(), 52.52, 13.405)
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrUpstreamUnavailable)
+	assert.Equal(t, defaultMaxRetryAttempts, *calls)
+}
+
+func TestClient_GetCurrentWeather_429RespectsRetryAfter(t *testing.T) {
+	successBody := `{
+		"latitude": 52.52,
+		"longitude": 13.405,
+		"timezone": "Europe/Berlin",
+		"current": {"temperature_2m": 15.5, "relative_humidity_2m": 65, "wind_speed_10m": 12.3, "weather_code": 0}
+	}`
+	throttled := func() (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     http.Header{"Retry-After": []string{"0"}},
+			Body:       io.NopCloser(strings.NewReader("slow down")),
+		}, nil
+	}
+	mock, calls := sequencedHTTPClient(throttled, statusResponse(http.StatusOK, successBody))
+
+	client := NewClientWithHTTP(mock)
+	start := time.Now()
+	result, err := client.GetCurrentWeather(context.Background(), 52.52, 13.405)
+	elapsed := time.Since(start)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, *calls)
+	assert.Equal(t, 15.5, result.Current.Temperature)
+	assert.Less(t, elapsed, time.Second, "Retry-After: 0 should not wait a full backoff")
+}
+
+func TestClient_GetCurrentWeather_429ExhaustsRetries(t *testing.T) {
+	mock, calls := sequencedHTTPClient(statusResponse(http.StatusTooManyRequests, "slow down"))
+
+	client := NewClientWithHTTP(mock)
+	_, err := client.GetCurrentWeather(context.Background(), 52.52, 13.405)
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Equal(t, defaultMaxRetryAttempts, *calls)
+}
+
+func TestClient_GeocodeCity_ReturnsErrCityNotFound(t *testing.T) {
+	mock, _ := sequencedHTTPClient(statusResponse(http.StatusOK, `{"results": []}`))
+
+	client := NewClientWithHTTP(mock)
+	_, err := client.GeocodeCity(context.Background(), "NonExistentCity")
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrCityNotFound)
+}
+
+func TestRetryableGet_NetworkErrorIsNotRetried(t *testing.T) {
+	calls := 0
+	mock := &MockHTTPClient{
+		GetFunc: func(url string) (*http.Response, error) {
+			calls++
+			return nil, errors.New("network error")
+		},
+	}
+
+	client := NewClientWithHTTP(mock)
+	_, err := client.GetCurrentWeather(context.Background(), 52.52, 13.405)
+
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrTimeout))
+	assert.Equal(t, 1, calls, "a non-timeout transport error should not be retried")
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), parseRetryAfter(""))
+	assert.Equal(t, 5*time.Second, parseRetryAfter("5"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("not-a-value"))
+}
+
+func TestBackoffDelay_CapsAtMaxDelay(t *testing.T) {
+	delay := backoffDelay(10)
+	assert.LessOrEqual(t, delay, retryMaxDelay)
+}
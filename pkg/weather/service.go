@@ -1,50 +1,194 @@
 package weather
 
 import (
+	"context"
 	"fmt"
 	"strconv"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
 )
 
 const (
 	minDays = 1
-	maxDays = 7
+	maxDays = 14
 	minLat  = -90.0
 	maxLat  = 90.0
 	minLon  = -180.0
 	maxLon  = 180.0
-)
 
-// WeatherClient defines the interface for weather data retrieval
-type WeatherClient interface {
-	GetCurrentWeather(lat, lon float64) (*WeatherResponse, error)
-	GetForecast(lat, lon float64, days int) (*ForecastResponse, error)
-	GeocodeCity(cityName string) (*GeocodingResult, error)
-}
+	// maxForecastHours bounds GetHourlyForecastByCoords, mirroring maxDays'
+	// 14-day horizon (14 * 24h) for callers that want hour-by-hour rather
+	// than daily granularity.
+	maxForecastHours = maxDays * 24
+
+	// batchWorkerLimit bounds how many batch queries GetBatchWeatherByCities
+	// and GetBatchWeatherByCoords fan out to the upstream provider at once,
+	// the same way stocks.maxConcurrentBatches bounds its batch fetch.
+	batchWorkerLimit = 8
+)
 
 // Service provides weather business logic
 type Service struct {
-	client WeatherClient
+	client    Provider
+	units     UnitSystem
+	converter UnitConverter
+
+	// cache and cacheTTLs are set via SetCache. cache is nil by default,
+	// disabling the read-through caching it otherwise adds to
+	// GetCurrentWeatherByCoords, GetForecastByCoords and GetWeatherByCity's
+	// geocoding step.
+	cache     Cache
+	cacheTTLs CacheTTLs
 }
 
-// NewService creates a new weather service
-func NewService(client WeatherClient) *Service {
+// NewService creates a Service backed by providers, tried in priority
+// order per policy (see FailoverPolicy and MultiProvider), converting
+// responses to defaultUnits unless a request's own units string overrides
+// it. defaultUnits defaults to UnitsMetric for an empty string.
+func NewService(providers []Provider, policy FailoverPolicy, defaultUnits UnitSystem) *Service {
+	if defaultUnits == "" {
+		defaultUnits = UnitsMetric
+	}
 	return &Service{
-		client: client,
+		client: NewMultiProvider(policy, providers...),
+		units:  defaultUnits,
+	}
+}
+
+// NewServiceWithProvider creates a Service backed directly by a single
+// Provider (e.g. a MultiProvider/FallbackProvider the caller already built,
+// or a mock in tests), defaulting to UnitsMetric.
+func NewServiceWithProvider(client Provider) *Service {
+	return &Service{client: client, units: UnitsMetric}
+}
+
+// resolveUnits parses unitsStr (a request's `units` query parameter),
+// falling back to s.units for an empty string.
+func (s *Service) resolveUnits(unitsStr string) (UnitSystem, error) {
+	if unitsStr == "" {
+		return s.units, nil
+	}
+	return ParseUnitSystem(unitsStr)
+}
+
+// resolveProvider returns the Provider a request should use: s.client
+// itself for an empty providerStr, or the single wrapped Provider matching
+// providerStr when s.client implements ProviderSelector (as MultiProvider
+// does). Returns ErrProviderNotFound for an unmatched name, or an error if
+// s.client doesn't support per-request provider selection at all.
+func (s *Service) resolveProvider(providerStr string) (Provider, error) {
+	if providerStr == "" {
+		return s.client, nil
+	}
+
+	selector, ok := s.client.(ProviderSelector)
+	if !ok {
+		return nil, fmt.Errorf("weather: provider selection is not supported by this service")
+	}
+
+	provider, ok := selector.SelectProvider(providerStr)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrProviderNotFound, providerStr)
+	}
+	return provider, nil
+}
+
+// geocodeCached resolves cityName via provider.GeocodeCity, consulting and
+// populating s.cache (under its own TTL, much longer-lived than the
+// current/forecast caches since a city's coordinates essentially never
+// change) when cacheable is set. Unlike GetCurrentWeatherByCoords/
+// GetForecastByCoords, an expired entry isn't served as a stale fallback on
+// an upstream error - geocoding failures are rare and a caller almost
+// certainly wants that error surfaced rather than a silently re-served old
+// result.
+func (s *Service) geocodeCached(ctx context.Context, provider Provider, cityName string, cacheable bool) (*GeocodingResult, error) {
+	cacheable = cacheable && s.cache != nil
+	key := cacheKeyGeocode(cityName)
+	if cacheable {
+		if cached, age, ok := lookupCache[GeocodingResult](ctx, s.cache, key); ok && age <= s.cacheTTLs.Geocode {
+			return cached, nil
+		}
+	}
+
+	geocode, err := provider.GeocodeCity(ctx, cityName)
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheable {
+		storeCache(ctx, s.cache, key, geocode, s.cacheTTLs.Geocode)
 	}
+	return geocode, nil
 }
 
-// GetCurrentWeatherByCoords retrieves current weather by coordinates
-func (s *Service) GetCurrentWeatherByCoords(latStr, lonStr string) (*WeatherResponse, error) {
+// GetCurrentWeatherByCoords retrieves current weather by coordinates,
+// converted to unitsStr (or the Service's default if empty). providerStr
+// restricts the lookup to a single named provider (see
+// MultiProvider.SelectProvider) instead of s.client's usual failover
+// behavior; empty uses s.client as configured.
+func (s *Service) GetCurrentWeatherByCoords(ctx context.Context, latStr, lonStr, unitsStr, providerStr string) (*WeatherResponse, error) {
 	lat, lon, err := parseAndValidateCoords(latStr, lonStr)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.client.GetCurrentWeather(lat, lon)
+	units, err := s.resolveUnits(unitsStr)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := s.resolveProvider(providerStr)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.currentWeatherCached(ctx, provider, lat, lon, providerStr == "")
+	if err != nil {
+		return nil, err
+	}
+
+	s.converter.ConvertCurrent(resp, units)
+	return resp, nil
 }
 
-// GetForecastByCoords retrieves weather forecast by coordinates
-func (s *Service) GetForecastByCoords(latStr, lonStr string, days int) (*ForecastResponse, error) {
+// currentWeatherCached fetches current weather for (lat, lon) via provider,
+// consulting and populating s.cache (under s.cacheTTLs.Current) when
+// cacheable is set. A fresh cache entry short-circuits the provider call
+// entirely (CacheHit); an upstream error falls back to serving an expired
+// entry if one exists (CacheStale) rather than failing the request.
+func (s *Service) currentWeatherCached(ctx context.Context, provider Provider, lat, lon float64, cacheable bool) (*WeatherResponse, error) {
+	cacheable = cacheable && s.cache != nil
+	key := cacheKeyCurrent(lat, lon)
+	if cacheable {
+		if cached, age, ok := lookupCache[WeatherResponse](ctx, s.cache, key); ok && age <= s.cacheTTLs.Current {
+			cached.CacheStatus, cached.CacheAge = CacheHit, age
+			return cached, nil
+		}
+	}
+
+	resp, err := provider.GetCurrentWeather(ctx, lat, lon)
+	if err != nil {
+		if cacheable {
+			if cached, age, ok := lookupCache[WeatherResponse](ctx, s.cache, key); ok {
+				cached.CacheStatus, cached.CacheAge = CacheStale, age
+				return cached, nil
+			}
+		}
+		return nil, err
+	}
+
+	if cacheable {
+		storeCache(ctx, s.cache, key, resp, s.cacheTTLs.Current)
+	}
+	resp.CacheStatus = CacheMiss
+	return resp, nil
+}
+
+// GetForecastByCoords retrieves weather forecast by coordinates, converted
+// to unitsStr (or the Service's default if empty). providerStr is as
+// described on GetCurrentWeatherByCoords.
+func (s *Service) GetForecastByCoords(ctx context.Context, latStr, lonStr string, days int, unitsStr, providerStr string) (*ForecastResponse, error) {
 	lat, lon, err := parseAndValidateCoords(latStr, lonStr)
 	if err != nil {
 		return nil, err
@@ -54,21 +198,124 @@ func (s *Service) GetForecastByCoords(latStr, lonStr string, days int) (*Forecas
 		return nil, err
 	}
 
-	return s.client.GetForecast(lat, lon, days)
+	units, err := s.resolveUnits(unitsStr)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := s.resolveProvider(providerStr)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheable := s.cache != nil && providerStr == ""
+	key := cacheKeyForecast(lat, lon, days)
+	if cacheable {
+		if cached, age, ok := lookupCache[ForecastResponse](ctx, s.cache, key); ok && age <= s.cacheTTLs.Forecast {
+			cached.CacheStatus, cached.CacheAge = CacheHit, age
+			s.converter.ConvertForecast(cached, units)
+			return cached, nil
+		}
+	}
+
+	resp, err := provider.GetForecast(ctx, lat, lon, days)
+	if err != nil {
+		if cacheable {
+			if cached, age, ok := lookupCache[ForecastResponse](ctx, s.cache, key); ok {
+				cached.CacheStatus, cached.CacheAge = CacheStale, age
+				s.converter.ConvertForecast(cached, units)
+				return cached, nil
+			}
+		}
+		return nil, err
+	}
+
+	if cacheable {
+		storeCache(ctx, s.cache, key, resp, s.cacheTTLs.Forecast)
+	}
+	resp.CacheStatus = CacheMiss
+
+	s.converter.ConvertForecast(resp, units)
+	return resp, nil
+}
+
+// GetHourlyForecastByCoords retrieves an hour-by-hour forecast by
+// coordinates, converted to unitsStr (or the Service's default if empty).
+// providerStr is as described on GetCurrentWeatherByCoords. hours is capped
+// at maxForecastHours (14 days' worth), the same horizon GetForecastByCoords
+// enforces for daily granularity.
+func (s *Service) GetHourlyForecastByCoords(ctx context.Context, latStr, lonStr string, hours int, unitsStr, providerStr string) (*HourlyForecastResponse, error) {
+	lat, lon, err := parseAndValidateCoords(latStr, lonStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateHours(hours); err != nil {
+		return nil, err
+	}
+
+	units, err := s.resolveUnits(unitsStr)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := s.resolveProvider(providerStr)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheable := s.cache != nil && providerStr == ""
+	key := cacheKeyHourly(lat, lon, hours)
+	if cacheable {
+		if cached, age, ok := lookupCache[HourlyForecastResponse](ctx, s.cache, key); ok && age <= s.cacheTTLs.Forecast {
+			s.converter.ConvertHourly(cached, units)
+			return cached, nil
+		}
+	}
+
+	resp, err := provider.GetHourlyForecast(ctx, lat, lon, hours)
+	if err != nil {
+		if cacheable {
+			if cached, _, ok := lookupCache[HourlyForecastResponse](ctx, s.cache, key); ok {
+				s.converter.ConvertHourly(cached, units)
+				return cached, nil
+			}
+		}
+		return nil, err
+	}
+
+	if cacheable {
+		storeCache(ctx, s.cache, key, resp, s.cacheTTLs.Forecast)
+	}
+
+	s.converter.ConvertHourly(resp, units)
+	return resp, nil
 }
 
-// GetWeatherByCity retrieves current weather by city name
-func (s *Service) GetWeatherByCity(cityName string) (*WeatherResponse, error) {
+// GetWeatherByCity retrieves current weather by city name, converted to
+// unitsStr (or the Service's default if empty). providerStr is as
+// described on GetCurrentWeatherByCoords.
+func (s *Service) GetWeatherByCity(ctx context.Context, cityName, unitsStr, providerStr string) (*WeatherResponse, error) {
 	if cityName == "" {
 		return nil, fmt.Errorf("city name is required")
 	}
 
-	geocode, err := s.client.GeocodeCity(cityName)
+	units, err := s.resolveUnits(unitsStr)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := s.resolveProvider(providerStr)
 	if err != nil {
 		return nil, err
 	}
 
-	weather, err := s.client.GetCurrentWeather(geocode.Latitude, geocode.Longitude)
+	geocode, err := s.geocodeCached(ctx, provider, cityName, providerStr == "")
+	if err != nil {
+		return nil, err
+	}
+
+	weather, err := s.currentWeatherCached(ctx, provider, geocode.Latitude, geocode.Longitude, providerStr == "")
 	if err != nil {
 		return nil, err
 	}
@@ -76,9 +323,116 @@ func (s *Service) GetWeatherByCity(cityName string) (*WeatherResponse, error) {
 	weather.Location.City = geocode.Name
 	weather.Location.Timezone = geocode.Timezone
 
+	s.converter.ConvertCurrent(weather, units)
 	return weather, nil
 }
 
+// GetBatchWeatherByCities retrieves current weather for each
+// comma-separated city name in citiesStr, fanning out up to
+// batchWorkerLimit lookups at a time and bounding the whole batch by
+// defaultRequestTimeout, so one slow city cannot stall the others past
+// that point. providerStr is as described on GetCurrentWeatherByCoords.
+func (s *Service) GetBatchWeatherByCities(ctx context.Context, citiesStr, unitsStr, providerStr string) (*BatchWeatherResponse, error) {
+	queries := parseBatchQueries(citiesStr)
+	if len(queries) == 0 {
+		return nil, fmt.Errorf("cities parameter is required")
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
+	defer cancel()
+
+	return s.fetchBatch(fetchCtx, queries, func(ctx context.Context, query string) (*WeatherResponse, error) {
+		return s.GetWeatherByCity(ctx, query, unitsStr, providerStr)
+	}), nil
+}
+
+// GetBatchWeatherByCoords retrieves current weather for each
+// semicolon-separated "lat,lon" pair in coordsStr, with the same
+// bounded-concurrency and timeout behavior as GetBatchWeatherByCities.
+// providerStr is as described on GetCurrentWeatherByCoords.
+func (s *Service) GetBatchWeatherByCoords(ctx context.Context, coordsStr, unitsStr, providerStr string) (*BatchWeatherResponse, error) {
+	queries := parseBatchCoordQueries(coordsStr)
+	if len(queries) == 0 {
+		return nil, fmt.Errorf("coords parameter is required")
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
+	defer cancel()
+
+	return s.fetchBatch(fetchCtx, queries, func(ctx context.Context, query string) (*WeatherResponse, error) {
+		lat, lon, found := strings.Cut(query, ",")
+		if !found {
+			return nil, fmt.Errorf("invalid coordinates %q: expected \"lat,lon\"", query)
+		}
+		return s.GetCurrentWeatherByCoords(ctx, lat, lon, unitsStr, providerStr)
+	}), nil
+}
+
+// fetchBatch runs fetch for each query concurrently (bounded by
+// batchWorkerLimit) and collects the results into a BatchWeatherResponse in
+// the same order as queries: a query whose fetch errors is reported in
+// Errors instead of aborting the others.
+func (s *Service) fetchBatch(ctx context.Context, queries []string, fetch func(ctx context.Context, query string) (*WeatherResponse, error)) *BatchWeatherResponse {
+	results := make([]*WeatherResponse, len(queries))
+	errs := make([]error, len(queries))
+
+	g := new(errgroup.Group)
+	g.SetLimit(batchWorkerLimit)
+	for i, query := range queries {
+		i, query := i, query
+		g.Go(func() error {
+			resp, err := fetch(ctx, query)
+			results[i] = resp
+			errs[i] = err
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	response := &BatchWeatherResponse{
+		Summaries: make([]WeatherResponse, 0, len(queries)),
+		Errors:    make([]BatchWeatherError, 0),
+	}
+	for i, query := range queries {
+		if errs[i] != nil {
+			response.Errors = append(response.Errors, BatchWeatherError{Query: query, Error: errs[i].Error()})
+			continue
+		}
+		response.Summaries = append(response.Summaries, *results[i])
+	}
+	return response
+}
+
+// parseBatchQueries splits a comma-separated list of free-text queries
+// (city names), trimming whitespace and dropping empty entries.
+func parseBatchQueries(queriesStr string) []string {
+	parts := strings.Split(queriesStr, ",")
+	queries := make([]string, 0, len(parts))
+	for _, part := range parts {
+		query := strings.TrimSpace(part)
+		if query != "" {
+			queries = append(queries, query)
+		}
+	}
+	return queries
+}
+
+// parseBatchCoordQueries splits a semicolon-separated list of "lat,lon"
+// pairs, trimming whitespace and dropping empty entries. Unlike
+// parseBatchQueries, entries aren't split on comma since each one is itself
+// a "lat,lon" pair.
+func parseBatchCoordQueries(coordsStr string) []string {
+	parts := strings.Split(coordsStr, ";")
+	queries := make([]string, 0, len(parts))
+	for _, part := range parts {
+		query := strings.TrimSpace(part)
+		if query != "" {
+			queries = append(queries, query)
+		}
+	}
+	return queries
+}
+
 // parseAndValidateCoords parses and validates latitude and longitude
 func parseAndValidateCoords(latStr, lonStr string) (float64, float64, error) {
 	lat, err := strconv.ParseFloat(latStr, 64)
@@ -121,7 +475,15 @@ func validateLongitude(lon float64) error {
 // validateDays checks if days parameter is within valid range
 func validateDays(days int) error {
 	if days < minDays || days > maxDays {
-		return fmt.Errorf("days must be between 1 and 7")
+		return fmt.Errorf("days must be between 1 and 14")
+	}
+	return nil
+}
+
+// validateHours checks if hours parameter is within valid range
+func validateHours(hours int) error {
+	if hours < 1 || hours > maxForecastHours {
+		return fmt.Errorf("hours must be between 1 and %d", maxForecastHours)
 	}
 	return nil
 }
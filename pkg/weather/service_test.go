@@ -1,6 +1,7 @@
 package weather
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -13,7 +14,7 @@ type MockWeatherClient struct {
 	mock.Mock
 }
 
-func (m *MockWeatherClient) GetCurrentWeather(lat, lon float64) (*WeatherResponse, error) {
+func (m *MockWeatherClient) GetCurrentWeather(ctx context.Context, lat, lon float64) (*WeatherResponse, error) {
 	args := m.Called(lat, lon)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -21,7 +22,7 @@ func (m *MockWeatherClient) GetCurrentWeather(lat, lon float64) (*WeatherRespons
 	return args.Get(0).(*WeatherResponse), args.Error(1)
 }
 
-func (m *MockWeatherClient) GetForecast(lat, lon float64, days int) (*ForecastResponse, error) {
+func (m *MockWeatherClient) GetForecast(ctx context.Context, lat, lon float64, days int) (*ForecastResponse, error) {
 	args := m.Called(lat, lon, days)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -29,7 +30,7 @@ func (m *MockWeatherClient) GetForecast(lat, lon float64, days int) (*ForecastRe
 	return args.Get(0).(*ForecastResponse), args.Error(1)
 }
 
-func (m *MockWeatherClient) GeocodeCity(cityName string) (*GeocodingResult, error) {
+func (m *MockWeatherClient) GeocodeCity(ctx context.Context, cityName string) (*GeocodingResult, error) {
 	args := m.Called(cityName)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -37,9 +38,25 @@ func (m *MockWeatherClient) GeocodeCity(cityName string) (*GeocodingResult, erro
 	return args.Get(0).(*GeocodingResult), args.Error(1)
 }
 
+func (m *MockWeatherClient) GetHourlyForecast(ctx context.Context, lat, lon float64, hours int) (*HourlyForecastResponse, error) {
+	args := m.Called(lat, lon, hours)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*HourlyForecastResponse), args.Error(1)
+}
+
+func (m *MockWeatherClient) GetAlerts(ctx context.Context, lat, lon float64) ([]Alert, error) {
+	args := m.Called(lat, lon)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Alert), args.Error(1)
+}
+
 func TestGetCurrentWeatherByCoords_Success(t *testing.T) {
 	mockClient := new(MockWeatherClient)
-	service := NewService(mockClient)
+	service := NewServiceWithProvider(mockClient)
 
 	expectedResp := &WeatherResponse{
 		Location: Location{Latitude: 52.52, Longitude: 13.41},
@@ -48,7 +65,7 @@ func TestGetCurrentWeatherByCoords_Success(t *testing.T) {
 
 	mockClient.On("GetCurrentWeather", 52.52, 13.41).Return(expectedResp, nil)
 
-	result, err := service.GetCurrentWeatherByCoords("52.52", "13.41")
+	result, err := service.GetCurrentWeatherByCoords(context.Background(), "52.52", "13.41", "", "")
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResp, result)
@@ -57,9 +74,9 @@ func TestGetCurrentWeatherByCoords_Success(t *testing.T) {
 
 func TestGetCurrentWeatherByCoords_InvalidLatitude(t *testing.T) {
 	mockClient := new(MockWeatherClient)
-	service := NewService(mockClient)
+	service := NewServiceWithProvider(mockClient)
 
-	_, err := service.GetCurrentWeatherByCoords("invalid", "13.41")
+	_, err := service.GetCurrentWeatherByCoords(context.Background(), "invalid", "13.41", "", "")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid latitude")
@@ -67,9 +84,9 @@ func TestGetCurrentWeatherByCoords_InvalidLatitude(t *testing.T) {
 
 func TestGetCurrentWeatherByCoords_InvalidLongitude(t *testing.T) {
 	mockClient := new(MockWeatherClient)
-	service := NewService(mockClient)
+	service := NewServiceWithProvider(mockClient)
 
-	_, err := service.GetCurrentWeatherByCoords("52.52", "invalid")
+	_, err := service.GetCurrentWeatherByCoords(context.Background(), "52.52", "invalid", "", "")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid longitude")
@@ -77,9 +94,9 @@ func TestGetCurrentWeatherByCoords_InvalidLongitude(t *testing.T) {
 
 func TestGetCurrentWeatherByCoords_LatitudeOutOfRange(t *testing.T) {
 	mockClient := new(MockWeatherClient)
-	service := NewService(mockClient)
+	service := NewServiceWithProvider(mockClient)
 
-	_, err := service.GetCurrentWeatherByCoords("91.0", "13.41")
+	_, err := service.GetCurrentWeatherByCoords(context.Background(), "91.0", "13.41", "", "")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "latitude out of range")
@@ -87,9 +104,9 @@ func TestGetCurrentWeatherByCoords_LatitudeOutOfRange(t *testing.T) {
 
 func TestGetCurrentWeatherByCoords_LongitudeOutOfRange(t *testing.T) {
 	mockClient := new(MockWeatherClient)
-	service := NewService(mockClient)
+	service := NewServiceWithProvider(mockClient)
 
-	_, err := service.GetCurrentWeatherByCoords("52.52", "181.0")
+	_, err := service.GetCurrentWeatherByCoords(context.Background(), "52.52", "181.0", "", "")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "longitude out of range")
@@ -97,7 +114,7 @@ func TestGetCurrentWeatherByCoords_LongitudeOutOfRange(t *testing.T) {
 
 func TestGetForecastByCoords_Success(t *testing.T) {
 	mockClient := new(MockWeatherClient)
-	service := NewService(mockClient)
+	service := NewServiceWithProvider(mockClient)
 
 	expectedResp := &ForecastResponse{
 		Location: Location{Latitude: 52.52, Longitude: 13.41},
@@ -106,7 +123,7 @@ func TestGetForecastByCoords_Success(t *testing.T) {
 
 	mockClient.On("GetForecast", 52.52, 13.41, 7).Return(expectedResp, nil)
 
-	result, err := service.GetForecastByCoords("52.52", "13.41", 7)
+	result, err := service.GetForecastByCoords(context.Background(), "52.52", "13.41", 7, "", "")
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResp, result)
@@ -120,25 +137,66 @@ func TestGetForecastByCoords_InvalidDays(t *testing.T) {
 	}{
 		{"Zero days", 0},
 		{"Negative days", -1},
-		{"Too many days", 8},
+		{"Too many days", 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockWeatherClient)
+			service := NewServiceWithProvider(mockClient)
+
+			_, err := service.GetForecastByCoords(context.Background(), "52.52", "13.41", tt.days, "", "")
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "days must be between 1 and 14")
+		})
+	}
+}
+
+func TestGetHourlyForecastByCoords_Success(t *testing.T) {
+	mockClient := new(MockWeatherClient)
+	service := NewServiceWithProvider(mockClient)
+
+	expectedResp := &HourlyForecastResponse{
+		Location: Location{Latitude: 52.52, Longitude: 13.41},
+		Hourly:   []HourlyForecastEntry{{WeatherDescription: "Clear"}},
+	}
+
+	mockClient.On("GetHourlyForecast", 52.52, 13.41, 336).Return(expectedResp, nil)
+
+	result, err := service.GetHourlyForecastByCoords(context.Background(), "52.52", "13.41", 336, "", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedResp, result)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetHourlyForecastByCoords_InvalidHours(t *testing.T) {
+	tests := []struct {
+		name  string
+		hours int
+	}{
+		{"Zero hours", 0},
+		{"Negative hours", -1},
+		{"Too many hours", 337},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockClient := new(MockWeatherClient)
-			service := NewService(mockClient)
+			service := NewServiceWithProvider(mockClient)
 
-			_, err := service.GetForecastByCoords("52.52", "13.41", tt.days)
+			_, err := service.GetHourlyForecastByCoords(context.Background(), "52.52", "13.41", tt.hours, "", "")
 
 			assert.Error(t, err)
-			assert.Contains(t, err.Error(), "days must be between 1 and 7")
+			assert.Contains(t, err.Error(), "hours must be between 1 and 336")
 		})
 	}
 }
 
 func TestGetWeatherByCity_Success(t *testing.T) {
 	mockClient := new(MockWeatherClient)
-	service := NewService(mockClient)
+	service := NewServiceWithProvider(mockClient)
 
 	geocodeResp := &GeocodingResult{
 		Name:      "Berlin",
@@ -155,7 +213,7 @@ func TestGetWeatherByCity_Success(t *testing.T) {
 	mockClient.On("GeocodeCity", "Berlin").Return(geocodeResp, nil)
 	mockClient.On("GetCurrentWeather", 52.52, 13.41).Return(weatherResp, nil)
 
-	result, err := service.GetWeatherByCity("Berlin")
+	result, err := service.GetWeatherByCity(context.Background(), "Berlin", "", "")
 
 	assert.NoError(t, err)
 	assert.Equal(t, "Berlin", result.Location.City)
@@ -165,9 +223,9 @@ func TestGetWeatherByCity_Success(t *testing.T) {
 
 func TestGetWeatherByCity_EmptyName(t *testing.T) {
 	mockClient := new(MockWeatherClient)
-	service := NewService(mockClient)
+	service := NewServiceWithProvider(mockClient)
 
-	_, err := service.GetWeatherByCity("")
+	_, err := service.GetWeatherByCity(context.Background(), "", "", "")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "city name is required")
@@ -175,17 +233,69 @@ func TestGetWeatherByCity_EmptyName(t *testing.T) {
 
 func TestGetWeatherByCity_CityNotFound(t *testing.T) {
 	mockClient := new(MockWeatherClient)
-	service := NewService(mockClient)
+	service := NewServiceWithProvider(mockClient)
 
 	mockClient.On("GeocodeCity", "NonExistent").Return(nil, fmt.Errorf("city not found"))
 
-	_, err := service.GetWeatherByCity("NonExistent")
+	_, err := service.GetWeatherByCity(context.Background(), "NonExistent", "", "")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "city not found")
 	mockClient.AssertExpectations(t)
 }
 
+func TestGetBatchWeatherByCities(t *testing.T) {
+	mockClient := new(MockWeatherClient)
+	service := NewServiceWithProvider(mockClient)
+
+	mockClient.On("GeocodeCity", "Berlin").Return(&GeocodingResult{Name: "Berlin", Latitude: 52.52, Longitude: 13.41}, nil)
+	mockClient.On("GetCurrentWeather", 52.52, 13.41).Return(&WeatherResponse{Location: Location{Latitude: 52.52, Longitude: 13.41}}, nil)
+	mockClient.On("GeocodeCity", "NonExistent").Return(nil, fmt.Errorf("city not found"))
+
+	response, err := service.GetBatchWeatherByCities(context.Background(), "Berlin,NonExistent", "", "")
+
+	assert.NoError(t, err)
+	assert.Len(t, response.Summaries, 1)
+	assert.Len(t, response.Errors, 1)
+	assert.Equal(t, "NonExistent", response.Errors[0].Query)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetBatchWeatherByCities_EmptyCities(t *testing.T) {
+	mockClient := new(MockWeatherClient)
+	service := NewServiceWithProvider(mockClient)
+
+	_, err := service.GetBatchWeatherByCities(context.Background(), "", "", "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cities parameter is required")
+}
+
+func TestGetBatchWeatherByCoords(t *testing.T) {
+	mockClient := new(MockWeatherClient)
+	service := NewServiceWithProvider(mockClient)
+
+	mockClient.On("GetCurrentWeather", 52.52, 13.41).Return(&WeatherResponse{Location: Location{Latitude: 52.52, Longitude: 13.41}}, nil)
+
+	response, err := service.GetBatchWeatherByCoords(context.Background(), "52.52,13.41;not,coords", "", "")
+
+	assert.NoError(t, err)
+	assert.Len(t, response.Summaries, 1)
+	assert.Len(t, response.Errors, 1)
+	assert.Equal(t, "not,coords", response.Errors[0].Query)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetBatchWeatherByCoords_EmptyCoords(t *testing.T) {
+	mockClient := new(MockWeatherClient)
+	service := NewServiceWithProvider(mockClient)
+
+	_, err := service.GetBatchWeatherByCoords(context.Background(), "", "", "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "coords parameter is required")
+}
+
 func TestValidateLatitude(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,187 @@
+package weather
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidUnitSystem is returned by ParseUnitSystem for a units string
+// that isn't "metric", "imperial", or "standard".
+var ErrInvalidUnitSystem = errors.New("invalid units")
+
+// UnitSystem selects the measurement units Service converts a response into
+// before returning it, mirroring the metric/imperial/standard vocabulary
+// OpenWeatherMap's own `units` query parameter uses.
+type UnitSystem string
+
+const (
+	// UnitsMetric reports °C and m/s. Every Provider in this package
+	// already returns readings in these units, so UnitConverter treats
+	// UnitsMetric as the wire format it converts from.
+	UnitsMetric UnitSystem = "metric"
+	// UnitsImperial reports °F and mph.
+	UnitsImperial UnitSystem = "imperial"
+	// UnitsStandard reports Kelvin, keeping wind speed in m/s, matching
+	// OpenWeatherMap's "standard" system.
+	UnitsStandard UnitSystem = "standard"
+)
+
+// ParseUnitSystem parses the `units` query parameter, defaulting to
+// UnitsMetric for an empty string.
+func ParseUnitSystem(s string) (UnitSystem, error) {
+	switch UnitSystem(s) {
+	case "":
+		return UnitsMetric, nil
+	case UnitsMetric, UnitsImperial, UnitsStandard:
+		return UnitSystem(s), nil
+	default:
+		return "", fmt.Errorf("%w %q: must be metric, imperial, or standard", ErrInvalidUnitSystem, s)
+	}
+}
+
+// UnitConverter converts the metric-system responses every Provider
+// returns into the UnitSystem a caller asked for.
+type UnitConverter struct{}
+
+// ConvertCurrent rewrites resp.Current's temperature-like fields and
+// resp.Units in place for units. UnitsMetric is a no-op: providers already
+// report metric readings (if not always in the same metric units - see
+// toMetersPerSecond), and this package's existing callers expect a
+// provider's native Units labels ("km/h" for Open-Meteo, "mph" for NWS,
+// ...) to pass through unconverted by default. The source reading for a
+// non-metric target is recovered from resp.Units rather than assumed,
+// since NWS (unlike Open-Meteo and OpenWeatherMap) already reports in
+// °F/mph. A nil resp is a no-op.
+func (UnitConverter) ConvertCurrent(resp *WeatherResponse, units UnitSystem) {
+	if resp == nil || units == UnitsMetric {
+		return
+	}
+	celsius := toCelsius(resp.Current.Temperature, resp.Units.Temperature)
+	metersPerSecond := toMetersPerSecond(resp.Current.WindSpeed, resp.Units.WindSpeed)
+
+	resp.Current.Temperature = convertTemperature(celsius, units)
+	resp.Current.WindSpeed = convertWindSpeed(metersPerSecond, units)
+	if resp.Current.Dewpoint != nil {
+		dewpointCelsius := toCelsius(*resp.Current.Dewpoint, resp.Units.Temperature)
+		resp.Current.Dewpoint = convertTemperaturePtr(&dewpointCelsius, units)
+	}
+	resp.Current.PressureMsl = convertPressurePtr(resp.Current.PressureMsl, units)
+	resp.Current.Precipitation10m = convertPrecipitationPtr(resp.Current.Precipitation10m, units)
+	resp.Current.Precipitation1h = convertPrecipitationPtr(resp.Current.Precipitation1h, units)
+	resp.Current.Precipitation24h = convertPrecipitationPtr(resp.Current.Precipitation24h, units)
+	resp.Units = unitsFor(units)
+}
+
+// ConvertForecast rewrites every ForecastDay's temperature fields in
+// place for units, assuming the °C readings every Provider's GetForecast
+// already returns (ForecastResponse carries no per-response Units to
+// recover an NWS-style source unit from). UnitsMetric and a nil resp are
+// both no-ops.
+func (UnitConverter) ConvertForecast(resp *ForecastResponse, units UnitSystem) {
+	if resp == nil || units == UnitsMetric {
+		return
+	}
+	for i := range resp.Forecast {
+		resp.Forecast[i].TemperatureMax = convertTemperature(resp.Forecast[i].TemperatureMax, units)
+		resp.Forecast[i].TemperatureMin = convertTemperature(resp.Forecast[i].TemperatureMin, units)
+	}
+}
+
+// ConvertHourly rewrites every HourlyForecastEntry's temperature and wind
+// speed fields in place for units, with the same °C/m/s assumption as
+// ConvertForecast. UnitsMetric and a nil resp are both no-ops.
+func (UnitConverter) ConvertHourly(resp *HourlyForecastResponse, units UnitSystem) {
+	if resp == nil || units == UnitsMetric {
+		return
+	}
+	for i := range resp.Hourly {
+		resp.Hourly[i].Temperature = convertTemperature(resp.Hourly[i].Temperature, units)
+		resp.Hourly[i].WindSpeed = convertWindSpeed(resp.Hourly[i].WindSpeed, units)
+	}
+}
+
+// toCelsius converts value to °C given the unit label a response reported
+// it in (as CurrentWeather.Units.Temperature would), defaulting to
+// treating it as already °C for an empty or unrecognized label.
+func toCelsius(value float64, label string) float64 {
+	if label == "°F" {
+		return (value - 32) * 5 / 9
+	}
+	return value
+}
+
+// toMetersPerSecond converts value to m/s given the unit label a response
+// reported it in (Open-Meteo's "km/h" or NWS's "mph"), defaulting to
+// treating it as already m/s (OpenWeatherMap's native unit).
+func toMetersPerSecond(value float64, label string) float64 {
+	switch label {
+	case "mph":
+		return value / 2.23694
+	case "km/h":
+		return value / 3.6
+	default:
+		return value
+	}
+}
+
+func unitsFor(units UnitSystem) Units {
+	switch units {
+	case UnitsImperial:
+		return Units{Temperature: "°F", WindSpeed: "mph", Humidity: "%", Pressure: "inHg", Precipitation: "in"}
+	case UnitsStandard:
+		return Units{Temperature: "K", WindSpeed: "m/s", Humidity: "%", Pressure: "hPa", Precipitation: "mm"}
+	default:
+		return Units{Temperature: "°C", WindSpeed: "m/s", Humidity: "%", Pressure: "hPa", Precipitation: "mm"}
+	}
+}
+
+func convertTemperature(celsius float64, units UnitSystem) float64 {
+	switch units {
+	case UnitsImperial:
+		return celsius*9/5 + 32
+	case UnitsStandard:
+		return celsius + 273.15
+	default:
+		return celsius
+	}
+}
+
+func convertTemperaturePtr(celsius *float64, units UnitSystem) *float64 {
+	if celsius == nil {
+		return nil
+	}
+	converted := convertTemperature(*celsius, units)
+	return &converted
+}
+
+func convertWindSpeed(metersPerSecond float64, units UnitSystem) float64 {
+	if units == UnitsImperial {
+		return metersPerSecond * 2.23694
+	}
+	return metersPerSecond
+}
+
+// hPaPerInHg is the number of hectopascals in one inch of mercury, used to
+// convert PressureMsl (which every Provider reports in hPa) for
+// UnitsImperial.
+const hPaPerInHg = 33.8639
+
+// mmPerInch converts the Precipitation* fields (every Provider reports
+// them in mm) for UnitsImperial.
+const mmPerInch = 25.4
+
+func convertPressurePtr(hPa *float64, units UnitSystem) *float64 {
+	if hPa == nil || units != UnitsImperial {
+		return hPa
+	}
+	converted := *hPa / hPaPerInHg
+	return &converted
+}
+
+func convertPrecipitationPtr(mm *float64, units UnitSystem) *float64 {
+	if mm == nil || units != UnitsImperial {
+		return mm
+	}
+	converted := *mm / mmPerInch
+	return &converted
+}
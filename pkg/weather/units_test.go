@@ -0,0 +1,83 @@
+package weather
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUnitSystem(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    UnitSystem
+		wantErr bool
+	}{
+		{name: "empty defaults to metric", input: "", want: UnitsMetric},
+		{name: "metric", input: "metric", want: UnitsMetric},
+		{name: "imperial", input: "imperial", want: UnitsImperial},
+		{name: "standard", input: "standard", want: UnitsStandard},
+		{name: "invalid", input: "bogus", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseUnitSystem(tt.input)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidUnitSystem)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func floatPtr(f float64) *float64 { return &f }
+
+func TestUnitConverter_ConvertCurrent(t *testing.T) {
+	resp := &WeatherResponse{
+		Current: CurrentWeather{
+			Temperature:      20,
+			WindSpeed:        36, // km/h
+			PressureMsl:      floatPtr(1013.25),
+			Precipitation1h:  floatPtr(25.4),
+			Precipitation24h: floatPtr(50.8),
+		},
+		Units: Units{Temperature: "°C", WindSpeed: "km/h", Pressure: "hPa", Precipitation: "mm"},
+	}
+
+	UnitConverter{}.ConvertCurrent(resp, UnitsImperial)
+
+	assert.InDelta(t, 68, resp.Current.Temperature, 0.01)
+	assert.InDelta(t, 22.37, resp.Current.WindSpeed, 0.01)
+	assert.InDelta(t, 29.92, *resp.Current.PressureMsl, 0.01)
+	assert.InDelta(t, 1, *resp.Current.Precipitation1h, 0.01)
+	assert.InDelta(t, 2, *resp.Current.Precipitation24h, 0.01)
+	assert.Equal(t, "inHg", resp.Units.Pressure)
+	assert.Equal(t, "in", resp.Units.Precipitation)
+}
+
+func TestUnitConverter_ConvertCurrent_StandardLeavesPressureAndPrecipitationAlone(t *testing.T) {
+	resp := &WeatherResponse{
+		Current: CurrentWeather{
+			Temperature: 20,
+			PressureMsl: floatPtr(1013.25),
+		},
+		Units: Units{Temperature: "°C", Pressure: "hPa"},
+	}
+
+	UnitConverter{}.ConvertCurrent(resp, UnitsStandard)
+
+	assert.InDelta(t, 1013.25, *resp.Current.PressureMsl, 0.01)
+	assert.Equal(t, "hPa", resp.Units.Pressure)
+}
+
+func TestUnitConverter_ConvertCurrent_NilPressureStaysNil(t *testing.T) {
+	resp := &WeatherResponse{Current: CurrentWeather{Temperature: 20}}
+
+	UnitConverter{}.ConvertCurrent(resp, UnitsImperial)
+
+	assert.Nil(t, resp.Current.PressureMsl)
+}
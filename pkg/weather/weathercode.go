@@ -0,0 +1,135 @@
+package weather
+
+import "strings"
+
+// Source identifies which Provider produced a reading, so a raw
+// provider-native code or text description can be translated back into
+// the canonical WMO weather code space WeatherCodeDescriptions already
+// keys off.
+type Source string
+
+const (
+	SourceOpenMeteo          Source = "open-meteo"
+	SourceNWS                Source = "nws"
+	SourceOWM                Source = "openweathermap"
+	SourceWorldWeatherOnline Source = "worldweatheronline"
+	SourceMeteologix         Source = "meteologix"
+)
+
+// NormalizeWeatherCode translates a provider-native numeric weather code
+// into the canonical WMO code space, so GetWeatherDescription produces a
+// consistent description regardless of which Provider answered the
+// request. Open-Meteo already speaks WMO codes natively, so it passes
+// through unchanged.
+func NormalizeWeatherCode(source Source, code int) int {
+	switch source {
+	case SourceOWM:
+		return owmConditionToWMO(code)
+	case SourceWorldWeatherOnline:
+		return wwoConditionToWMO(code)
+	default:
+		// Open-Meteo and Meteologix both speak WMO codes natively.
+		return code
+	}
+}
+
+// owmConditionToWMO maps an OpenWeatherMap condition ID (weather[0].id) to
+// the closest WMO code, using OWM's documented ID group ranges
+// (https://openweathermap.org/weather-conditions): 2xx thunderstorm, 3xx
+// drizzle, 5xx rain, 6xx snow, 7xx atmosphere (fog/haze/etc.), 800 clear,
+// 80x increasing cloud cover.
+func owmConditionToWMO(id int) int {
+	switch {
+	case id >= 200 && id < 300:
+		return 95 // thunderstorm
+	case id >= 300 && id < 400:
+		return 51 // drizzle
+	case id >= 500 && id < 600:
+		if id >= 502 {
+			return 65 // heavy rain
+		}
+		return 61 // slight/moderate rain
+	case id >= 600 && id < 700:
+		if id >= 602 {
+			return 75 // heavy snow
+		}
+		return 71 // slight/moderate snow
+	case id >= 700 && id < 800:
+		return 45 // fog/mist/haze/etc.
+	case id == 800:
+		return 0 // clear sky
+	case id == 801:
+		return 1 // mainly clear, few clouds
+	case id == 802:
+		return 2 // partly cloudy
+	case id >= 803:
+		return 3 // overcast
+	default:
+		return -1
+	}
+}
+
+// wwoConditionToWMO maps a WorldWeatherOnline-style numeric weatherCode
+// (https://www.worldweatheronline.com/weather-api/api/docs/weather-icons.aspx)
+// to the closest WMO code. WWO's codes don't line up with WMO's at all, so
+// this is a coarse best-effort mapping rather than a lossless one.
+func wwoConditionToWMO(code int) int {
+	switch code {
+	case 113:
+		return 0 // Sunny/Clear
+	case 116:
+		return 2 // Partly cloudy
+	case 119, 122:
+		return 3 // Cloudy/Overcast
+	case 143, 248, 260:
+		return 45 // Mist/Fog
+	case 176, 263, 266, 293, 296, 353:
+		return 51 // Patchy/light rain or drizzle
+	case 302, 305, 308, 356, 359:
+		return 65 // Moderate/heavy rain
+	case 179, 182, 185, 281, 284, 311, 314, 317, 320, 362, 365:
+		return 66 // Freezing rain/sleet
+	case 323, 326, 329, 332, 368, 371:
+		return 71 // Snow
+	case 335, 338:
+		return 75 // Heavy snow
+	case 200:
+		return 95 // Thundery outbreaks
+	default:
+		return -1
+	}
+}
+
+// NormalizeWeatherText translates a provider's free-text condition summary
+// into the closest WMO code, for providers like NWS that report conditions
+// as prose ("shortForecast") instead of a numeric code. Matching is
+// keyword-based and intentionally coarse: NWS text is meant for human
+// reading, not exact classification.
+func NormalizeWeatherText(text string) int {
+	lower := strings.ToLower(text)
+
+	switch {
+	case strings.Contains(lower, "thunderstorm"):
+		return 95
+	case strings.Contains(lower, "snow"):
+		return 71
+	case strings.Contains(lower, "sleet"), strings.Contains(lower, "freezing"):
+		return 66
+	case strings.Contains(lower, "rain"), strings.Contains(lower, "showers"):
+		return 61
+	case strings.Contains(lower, "drizzle"):
+		return 51
+	case strings.Contains(lower, "fog"), strings.Contains(lower, "haze"), strings.Contains(lower, "mist"):
+		return 45
+	case strings.Contains(lower, "overcast"):
+		return 3
+	case strings.Contains(lower, "mostly cloudy"), strings.Contains(lower, "partly cloudy"):
+		return 2
+	case strings.Contains(lower, "mostly sunny"), strings.Contains(lower, "mostly clear"):
+		return 1
+	case strings.Contains(lower, "sunny"), strings.Contains(lower, "clear"):
+		return 0
+	default:
+		return -1
+	}
+}
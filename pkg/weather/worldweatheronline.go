@@ -0,0 +1,287 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const wwoBaseURL = "https://api.worldweatheronline.com/premium/v1/weather.ashx"
+
+// WorldWeatherOnlineProvider implements Provider against the
+// WorldWeatherOnline Local Weather API. Every call hits the same
+// weather.ashx endpoint - current conditions, forecast, and geocoding (via
+// its `q`-as-city-name support and `nearest_area` response field) all come
+// back in one payload, so GetCurrentWeather, GetForecast, and GeocodeCity
+// each fetch independently rather than sharing a cached call the way
+// OpenWeatherMapProvider's oneCall does.
+type WorldWeatherOnlineProvider struct {
+	httpClient Requester
+	apiKey     string
+}
+
+// NewWorldWeatherOnlineProvider creates a WorldWeatherOnlineProvider
+// authenticated with apiKey.
+func NewWorldWeatherOnlineProvider(apiKey string) *WorldWeatherOnlineProvider {
+	return NewWorldWeatherOnlineProviderWithHTTP(&http.Client{Timeout: defaultRequestTimeout}, apiKey)
+}
+
+// NewWorldWeatherOnlineProviderWithHTTP creates a WorldWeatherOnlineProvider
+// with a custom Requester, for tests.
+func NewWorldWeatherOnlineProviderWithHTTP(httpClient Requester, apiKey string) *WorldWeatherOnlineProvider {
+	return &WorldWeatherOnlineProvider{httpClient: httpClient, apiKey: apiKey}
+}
+
+type wwoAreaName struct {
+	Value string `json:"value"`
+}
+
+type wwoNearestArea struct {
+	AreaName  []wwoAreaName `json:"areaName"`
+	Latitude  string        `json:"latitude"`
+	Longitude string        `json:"longitude"`
+}
+
+type wwoCurrentCondition struct {
+	TempC         string `json:"temp_C"`
+	WindspeedKmph string `json:"windspeedKmph"`
+	Humidity      string `json:"humidity"`
+	WeatherCode   string `json:"weatherCode"`
+}
+
+type wwoHourly struct {
+	TempC         string `json:"tempC"`
+	ChanceOfRain  string `json:"chanceofrain"`
+	WeatherCode   string `json:"weatherCode"`
+	WindspeedKmph string `json:"windspeedKmph"`
+}
+
+type wwoWeatherDay struct {
+	Date     string      `json:"date"`
+	MaxTempC string      `json:"maxtempC"`
+	MinTempC string      `json:"mintempC"`
+	Hourly   []wwoHourly `json:"hourly"`
+}
+
+type wwoResponse struct {
+	Data struct {
+		NearestArea      []wwoNearestArea      `json:"nearest_area"`
+		CurrentCondition []wwoCurrentCondition `json:"current_condition"`
+		Weather          []wwoWeatherDay       `json:"weather"`
+	} `json:"data"`
+}
+
+// get fetches weather.ashx for query, which may be either "lat,lon" or a
+// free-text city name - WWO's `q` parameter accepts both.
+func (p *WorldWeatherOnlineProvider) get(ctx context.Context, query string, days int) (*wwoResponse, error) {
+	params := url.Values{}
+	params.Set("key", p.apiKey)
+	params.Set("q", query)
+	params.Set("format", "json")
+	params.Set("num_of_days", strconv.Itoa(days))
+
+	apiURL := fmt.Sprintf("%s?%s", wwoBaseURL, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch weather data: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch weather data: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var apiResp wwoResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &apiResp, nil
+}
+
+// GetCurrentWeather fetches current conditions for lat/lon.
+func (p *WorldWeatherOnlineProvider) GetCurrentWeather(ctx context.Context, lat, lon float64) (*WeatherResponse, error) {
+	apiResp, err := p.get(ctx, fmt.Sprintf("%s,%s", formatFloat(lat), formatFloat(lon)), 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(apiResp.Data.CurrentCondition) == 0 {
+		return nil, fmt.Errorf("WorldWeatherOnline returned no current_condition for %f,%f", lat, lon)
+	}
+
+	current := apiResp.Data.CurrentCondition[0]
+	code := NormalizeWeatherCode(SourceWorldWeatherOnline, atoiOrDefault(current.WeatherCode, -1))
+
+	return &WeatherResponse{
+		Location: Location{Latitude: lat, Longitude: lon},
+		Current: CurrentWeather{
+			Temperature:        atofOrZero(current.TempC),
+			Humidity:           int(atofOrZero(current.Humidity)),
+			WindSpeed:          atofOrZero(current.WindspeedKmph),
+			WeatherCode:        code,
+			WeatherDescription: GetWeatherDescription(code),
+		},
+		Units: Units{
+			Temperature:   "°C",
+			WindSpeed:     "km/h",
+			Humidity:      "%",
+			Pressure:      "hPa",
+			Precipitation: "mm",
+		},
+	}, nil
+}
+
+// GetForecast fetches a daily forecast for lat/lon, capped at days.
+func (p *WorldWeatherOnlineProvider) GetForecast(ctx context.Context, lat, lon float64, days int) (*ForecastResponse, error) {
+	apiResp, err := p.get(ctx, fmt.Sprintf("%s,%s", formatFloat(lat), formatFloat(lon)), days)
+	if err != nil {
+		return nil, err
+	}
+
+	weatherDays := apiResp.Data.Weather
+	if len(weatherDays) > days {
+		weatherDays = weatherDays[:days]
+	}
+
+	forecast := make([]ForecastDay, len(weatherDays))
+	for i, day := range weatherDays {
+		code := -1
+		precipProb := 0
+		if len(day.Hourly) > 0 {
+			code = NormalizeWeatherCode(SourceWorldWeatherOnline, atoiOrDefault(day.Hourly[0].WeatherCode, -1))
+			precipProb = atoiOrDefault(day.Hourly[0].ChanceOfRain, 0)
+		}
+
+		forecast[i] = ForecastDay{
+			Date:                     day.Date,
+			TemperatureMax:           atofOrZero(day.MaxTempC),
+			TemperatureMin:           atofOrZero(day.MinTempC),
+			PrecipitationProbability: precipProb,
+			WeatherCode:              code,
+			WeatherDescription:       GetWeatherDescription(code),
+		}
+	}
+
+	return &ForecastResponse{
+		Location: Location{Latitude: lat, Longitude: lon},
+		Forecast: forecast,
+	}, nil
+}
+
+// GetHourlyForecast fetches an hourly forecast for lat/lon, capped at
+// hours (and at maxHourlyForecastHours).
+func (p *WorldWeatherOnlineProvider) GetHourlyForecast(ctx context.Context, lat, lon float64, hours int) (*HourlyForecastResponse, error) {
+	if hours > maxHourlyForecastHours {
+		hours = maxHourlyForecastHours
+	}
+	days := hours/24 + 1
+
+	apiResp, err := p.get(ctx, fmt.Sprintf("%s,%s", formatFloat(lat), formatFloat(lon)), days)
+	if err != nil {
+		return nil, err
+	}
+
+	// WWO reports each hour's "time" field as minutes-since-midnight rather
+	// than a timestamp, so HourlyForecastEntry.Time is left at its zero
+	// value here rather than reconstructed from date + time.
+	var entries []HourlyForecastEntry
+	for _, day := range apiResp.Data.Weather {
+		for _, hour := range day.Hourly {
+			if len(entries) >= hours {
+				break
+			}
+			code := NormalizeWeatherCode(SourceWorldWeatherOnline, atoiOrDefault(hour.WeatherCode, -1))
+			entries = append(entries, HourlyForecastEntry{
+				Temperature:              atofOrZero(hour.TempC),
+				PrecipitationProbability: atoiOrDefault(hour.ChanceOfRain, 0),
+				WindSpeed:                atofOrZero(hour.WindspeedKmph),
+				WeatherCode:              code,
+				WeatherDescription:       GetWeatherDescription(code),
+			})
+		}
+	}
+
+	return &HourlyForecastResponse{
+		Location: Location{Latitude: lat, Longitude: lon},
+		Hourly:   entries,
+	}, nil
+}
+
+// GetAlerts always returns an empty slice: WorldWeatherOnline's Local
+// Weather API has no severe-weather alerts feed.
+func (p *WorldWeatherOnlineProvider) GetAlerts(ctx context.Context, lat, lon float64) ([]Alert, error) {
+	return nil, nil
+}
+
+// GeocodeCity resolves cityName using weather.ashx's own `q`-as-city-name
+// support, reading the resolved location back from its nearest_area
+// field. WWO's response carries no IANA timezone, so GeocodingResult.
+// Timezone is left empty.
+func (p *WorldWeatherOnlineProvider) GeocodeCity(ctx context.Context, cityName string) (*GeocodingResult, error) {
+	apiResp, err := p.get(ctx, cityName, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(apiResp.Data.NearestArea) == 0 {
+		return nil, fmt.Errorf("city not found: %w", ErrCityNotFound)
+	}
+
+	area := apiResp.Data.NearestArea[0]
+	name := cityName
+	if len(area.AreaName) > 0 {
+		name = area.AreaName[0].Value
+	}
+
+	return &GeocodingResult{
+		Name:      name,
+		Latitude:  atofOrZero(area.Latitude),
+		Longitude: atofOrZero(area.Longitude),
+	}, nil
+}
+
+// Capabilities reports that WorldWeatherOnlineProvider doesn't populate
+// any of the optional data points beyond the baseline WeatherResponse
+// fields.
+func (p *WorldWeatherOnlineProvider) Capabilities() ProviderCapabilities {
+	return ProviderCapabilities{}
+}
+
+// Name identifies this provider as "worldweatheronline" for provider
+// selection (see MultiProvider.SelectProvider).
+func (p *WorldWeatherOnlineProvider) Name() string {
+	return "worldweatheronline"
+}
+
+// atofOrZero parses s as a float64, returning 0 if it doesn't parse - WWO
+// reports every numeric field as a JSON string.
+func atofOrZero(s string) float64 {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// atoiOrDefault parses s as an int, returning def if it doesn't parse.
+func atoiOrDefault(s string, def int) int {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return def
+	}
+	return v
+}